@@ -13,6 +13,7 @@ const (
 	ProtocolRedfish      = "Redfish"
 	ProtocolRedfishLocal = "RedfishLocal"
 	ProtocolRedfishKube  = "RedfishKube"
+	ProtocolRedfishFake  = "RedfishFake"
 )
 
 // BMCSpec defines the desired state of BMC
@@ -44,6 +45,120 @@ type BMCSpec struct {
 	// This field is optional and can be omitted if console access is not required.
 	// +optional
 	ConsoleProtocol *ConsoleProtocol `json:"consoleProtocol,omitempty"`
+
+	// Timeouts overrides the manager wide timeouts of the operations performed against the BMC.
+	// +optional
+	Timeouts *BMCTimeouts `json:"timeouts,omitempty"`
+
+	// CertificateFingerprint pins the TLS certificate of the BMC by the SHA-256 fingerprint of the certificate or of
+	// its public key, e.g. sha256:<hex>. Connections to a BMC presenting another certificate are rejected, even if
+	// the manager does not verify BMC certificates otherwise.
+	// +kubebuilder:validation:Pattern=`^sha256:[0-9a-fA-F]{64}$`
+	// +optional
+	CertificateFingerprint string `json:"certificateFingerprint,omitempty"`
+
+	// Forwarding configures where the BMC forwards hardware alerts to, e.g. the SNMP trap receivers and syslog
+	// servers of the NOC tooling. BMCs without forwarding keep the destinations configured on them.
+	// +optional
+	Forwarding *BMCForwarding `json:"forwarding,omitempty"`
+}
+
+// BMCForwarding defines the SNMP trap destinations and remote syslog targets of a BMC. They replace the
+// destinations and targets configured on the BMC before.
+type BMCForwarding struct {
+	// SNMPTrapDestinations are the destinations of the SNMP traps sent by the BMC.
+	// +kubebuilder:validation:MaxItems=8
+	// +optional
+	SNMPTrapDestinations []SNMPTrapDestination `json:"snmpTrapDestinations,omitempty"`
+
+	// SyslogTargets are the remote syslog servers the BMC forwards its log to.
+	// +kubebuilder:validation:MaxItems=3
+	// +optional
+	SyslogTargets []SyslogTarget `json:"syslogTargets,omitempty"`
+}
+
+// SNMPTrapDestination defines a receiver of SNMP traps.
+type SNMPTrapDestination struct {
+	// Address is the IP address or host name of the trap receiver.
+	// +kubebuilder:validation:MinLength=1
+	Address string `json:"address"`
+
+	// Port is the UDP port of the trap receiver.
+	// +kubebuilder:default=162
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// Version is the SNMP version of the traps.
+	// +kubebuilder:validation:Enum=SNMPv1;SNMPv2c
+	// +kubebuilder:default=SNMPv2c
+	// +optional
+	Version SNMPVersion `json:"version,omitempty"`
+
+	// Community is the community string sent with the traps.
+	// +optional
+	Community string `json:"community,omitempty"`
+}
+
+// SNMPVersion is the SNMP version of traps.
+type SNMPVersion string
+
+const (
+	// SNMPVersionV1 sends SNMPv1 traps.
+	SNMPVersionV1 SNMPVersion = "SNMPv1"
+	// SNMPVersionV2c sends SNMPv2c traps.
+	SNMPVersionV2c SNMPVersion = "SNMPv2c"
+)
+
+// SyslogTarget defines a remote syslog server.
+type SyslogTarget struct {
+	// Address is the IP address or host name of the syslog server.
+	// +kubebuilder:validation:MinLength=1
+	Address string `json:"address"`
+
+	// Port is the port of the syslog server.
+	// +kubebuilder:default=514
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// Protocol is the transport protocol to the syslog server.
+	// +kubebuilder:validation:Enum=UDP;TCP
+	// +kubebuilder:default=UDP
+	// +optional
+	Protocol SyslogProtocol `json:"protocol,omitempty"`
+}
+
+// SyslogProtocol is the transport protocol of a remote syslog server.
+type SyslogProtocol string
+
+const (
+	// SyslogProtocolUDP forwards the log over UDP.
+	SyslogProtocolUDP SyslogProtocol = "UDP"
+	// SyslogProtocolTCP forwards the log over TCP.
+	SyslogProtocolTCP SyslogProtocol = "TCP"
+)
+
+// BMCTimeouts defines the request timeouts of the different classes of operations performed against a BMC.
+// Unset timeouts default to the values configured in the manager.
+type BMCTimeouts struct {
+	// Login is the timeout for connecting and logging in to the BMC.
+	// +optional
+	Login *metav1.Duration `json:"login,omitempty"`
+
+	// FirmwareUpload is the timeout for triggering a firmware update, which may include the upload of the image.
+	// +optional
+	FirmwareUpload *metav1.Duration `json:"firmwareUpload,omitempty"`
+
+	// SettingsApply is the timeout for applying BIOS settings and the boot order.
+	// +optional
+	SettingsApply *metav1.Duration `json:"settingsApply,omitempty"`
+
+	// TaskPolling is the timeout for polling the state of a task.
+	// +optional
+	TaskPolling *metav1.Duration `json:"taskPolling,omitempty"`
 }
 
 // InlineEndpoint defines inline network access configuration for the BMC.
@@ -90,8 +205,48 @@ type Protocol struct {
 	// Port specifies the port number used for communication.
 	// This port is used by the specified protocol to establish connections.
 	Port int32 `json:"port"`
+
+	// Scheme overrides the URL scheme used to connect to the BMC. If empty, https is used, or http if the
+	// manager runs in insecure mode.
+	// +kubebuilder:validation:Enum=http;https
+	// +optional
+	Scheme ProtocolScheme `json:"scheme,omitempty"`
+
+	// BasePath is the path prefix the BMC is served under, e.g. /bmc1 for a BMC behind a reverse proxy. The Redfish
+	// service is expected at <basePath>/redfish/v1.
+	// +kubebuilder:validation:Pattern=`^(/[^/?#]+)*$`
+	// +optional
+	BasePath string `json:"basePath,omitempty"`
+
+	// AuthMode specifies how the operator authenticates at the BMC. If empty, the default of the manager is used.
+	// +kubebuilder:validation:Enum=Session;Basic
+	// +optional
+	AuthMode BMCAuthMode `json:"authMode,omitempty"`
 }
 
+// BMCAuthMode defines how the operator authenticates at a BMC.
+type BMCAuthMode string
+
+const (
+	// BMCAuthModeSession logs in via the Redfish SessionService, falling back to basic authentication if the BMC
+	// fails to create a session.
+	BMCAuthModeSession BMCAuthMode = "Session"
+
+	// BMCAuthModeBasic sends the credentials with every request.
+	BMCAuthModeBasic BMCAuthMode = "Basic"
+)
+
+// ProtocolScheme defines the URL scheme used for communicating with the BMC.
+type ProtocolScheme string
+
+const (
+	// ProtocolSchemeHTTP represents plain HTTP.
+	ProtocolSchemeHTTP ProtocolScheme = "http"
+
+	// ProtocolSchemeHTTPS represents HTTP over TLS.
+	ProtocolSchemeHTTPS ProtocolScheme = "https"
+)
+
 // ProtocolName defines the possible names for protocols used for communicating with the BMC.
 type ProtocolName string
 
@@ -144,6 +299,12 @@ type BMCStatus struct {
 	// Model is the model number or name of the BMC.
 	Model string `json:"model,omitempty"`
 
+	// CanonicalManufacturer is the canonical name of the BMC manufacturer, e.g. Dell for both "Dell Inc." and "DELL".
+	CanonicalManufacturer string `json:"canonicalManufacturer,omitempty"`
+
+	// CanonicalModel is the model of the BMC without the name of the manufacturer and vendor specific suffixes.
+	CanonicalModel string `json:"canonicalModel,omitempty"`
+
 	// SKU is the stock keeping unit identifier for the BMC.
 	SKU string `json:"sku,omitempty"`
 
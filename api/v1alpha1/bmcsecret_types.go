@@ -15,6 +15,13 @@ const (
 	BMCSecretPasswordKeyName = "password"
 )
 
+const (
+	// BMCCredentialProfileFirmware is the credential profile used for firmware updates.
+	BMCCredentialProfileFirmware = "firmware"
+	// BMCCredentialProfileMonitoring is the credential profile used for read-only monitoring of the BMC.
+	BMCCredentialProfileMonitoring = "monitoring"
+)
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:scope=Cluster
@@ -54,6 +61,12 @@ type BMCSecret struct {
 	// More info: https://kubernetes.io/docs/concepts/configuration/secret/#secret-types
 	// +optional
 	Type corev1.SecretType `json:"type,omitempty" protobuf:"bytes,3,opt,name=type,casttype=SecretType"`
+
+	// SecretRef references a Secret holding the credentials, e.g. a Secret managed by sealed-secrets or the
+	// External Secrets Operator, so that the credentials are not stored in the BMCSecret itself. Keys of the Secret
+	// take precedence over the keys of Data. Both the name and the namespace of the Secret have to be set.
+	// +optional
+	SecretRef *corev1.SecretReference `json:"secretRef,omitempty"`
 }
 
 //+kubebuilder:object:root=true
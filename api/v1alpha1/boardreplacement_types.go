@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BoardReplacementSpec defines the desired state of BoardReplacement.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+type BoardReplacementSpec struct {
+	// ServerRef is a reference to the server whose motherboard or BMC is replaced.
+	// +required
+	ServerRef v1.LocalObjectReference `json:"serverRef"`
+
+	// SystemUUID is the system UUID of the new board as reported by its BMC. If omitted, the BMC of the new board
+	// has to report a single system, whose UUID is taken over.
+	// +optional
+	SystemUUID string `json:"systemUUID,omitempty"`
+
+	// BMCMACAddress is the MAC address of the BMC of the new board. If omitted, the MAC address of the BMC is kept.
+	// +kubebuilder:validation:Pattern=`^([0-9A-Fa-f]{2}[:-]){5}[0-9A-Fa-f]{2}$`
+	// +optional
+	BMCMACAddress string `json:"bmcMACAddress,omitempty"`
+
+	// BMCAddress is the IP address of the BMC of the new board. If omitted, the address of the BMC is kept.
+	// +optional
+	BMCAddress string `json:"bmcAddress,omitempty"`
+}
+
+// BoardReplacementState defines the possible states of a BoardReplacement.
+type BoardReplacementState string
+
+const (
+	// BoardReplacementStatePending indicates that the replacement has not been started yet.
+	BoardReplacementStatePending BoardReplacementState = "Pending"
+	// BoardReplacementStateVerifying indicates that the replacement waits for the BMC of the new board to report
+	// the expected system.
+	BoardReplacementStateVerifying BoardReplacementState = "Verifying"
+	// BoardReplacementStateCompleted indicates that the server has been updated to the new board.
+	BoardReplacementStateCompleted BoardReplacementState = "Completed"
+	// BoardReplacementStateFailed indicates that the replacement has failed.
+	BoardReplacementStateFailed BoardReplacementState = "Failed"
+)
+
+// BoardReplacementStatus defines the observed state of BoardReplacement.
+type BoardReplacementStatus struct {
+	// State represents the current state of the board replacement.
+	State BoardReplacementState `json:"state,omitempty"`
+
+	// OldSystemUUID is the system UUID of the server when the replacement has been started.
+	// +optional
+	OldSystemUUID string `json:"oldSystemUUID,omitempty"`
+
+	// OldBMCMACAddress is the MAC address of the BMC when the replacement has been started.
+	// +optional
+	OldBMCMACAddress string `json:"oldBMCMACAddress,omitempty"`
+
+	// OldBMCAddress is the address of the BMC when the replacement has been started.
+	// +optional
+	OldBMCAddress string `json:"oldBMCAddress,omitempty"`
+
+	// NewSystemUUID is the system UUID of the new board the server has been updated to.
+	// +optional
+	NewSystemUUID string `json:"newSystemUUID,omitempty"`
+
+	// Message is a human-readable description of the current state of the replacement.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// CompletionTime is the time the replacement has completed or failed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Conditions represents the latest available observations of the replacement's current state.
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="ServerRef",type=string,JSONPath=`.spec.serverRef.name`
+//+kubebuilder:printcolumn:name="SystemUUID",type=string,JSONPath=`.status.newSystemUUID`
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// BoardReplacement is the Schema for the boardreplacements API
+type BoardReplacement struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BoardReplacementSpec   `json:"spec,omitempty"`
+	Status BoardReplacementStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// BoardReplacementList contains a list of BoardReplacement
+type BoardReplacementList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BoardReplacement `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BoardReplacement{}, &BoardReplacementList{})
+}
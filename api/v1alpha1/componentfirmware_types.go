@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ComponentType defines the type of a firmware component.
+type ComponentType string
+
+const (
+	// ComponentTypeBIOS is the BIOS of the server.
+	ComponentTypeBIOS ComponentType = "BIOS"
+	// ComponentTypeBMC is the BMC of the server.
+	ComponentTypeBMC ComponentType = "BMC"
+	// ComponentTypeNIC is a network adapter of the server.
+	ComponentTypeNIC ComponentType = "NIC"
+	// ComponentTypeDrive is a drive of the server.
+	ComponentTypeDrive ComponentType = "Drive"
+	// ComponentTypeDPU is a DPU of the server.
+	ComponentTypeDPU ComponentType = "DPU"
+)
+
+// ComponentSelector selects the firmware components of a server.
+type ComponentSelector struct {
+	// Type is the type of the component.
+	// +kubebuilder:validation:Enum=BIOS;BMC;NIC;Drive;DPU
+	// +required
+	Type ComponentType `json:"type"`
+
+	// Model selects the components whose firmware inventory name or software ID contains the given value.
+	// +optional
+	Model string `json:"model,omitempty"`
+
+	// VersionConstraint restricts the selection to components whose current firmware version satisfies
+	// the constraint, e.g. ">= 1.2.0, < 2.0.0".
+	// +optional
+	VersionConstraint string `json:"versionConstraint,omitempty"`
+}
+
+// ComponentFirmwareSpec defines the desired state of ComponentFirmware.
+type ComponentFirmwareSpec struct {
+	// ServerRef is a reference to the server whose components should be updated.
+	// +required
+	ServerRef v1.LocalObjectReference `json:"serverRef"`
+
+	// Component selects the components of the server which should be updated.
+	// +required
+	Component ComponentSelector `json:"component"`
+
+	// Version is the firmware version the selected components should be running.
+	// +required
+	Version string `json:"version"`
+
+	// Image specifies the firmware image which is applied to the selected components.
+	// +required
+	Image FirmwareImage `json:"image"`
+
+	// TTLSecondsAfterFinished limits the lifetime of the ComponentFirmware after the update has completed or
+	// failed. Once the TTL has expired, the ComponentFirmware is deleted. If unset, it is kept.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// ComponentFirmwareState defines the possible states of a ComponentFirmware update.
+type ComponentFirmwareState string
+
+const (
+	// ComponentFirmwareStatePending indicates that the update has not been started yet.
+	ComponentFirmwareStatePending ComponentFirmwareState = "Pending"
+	// ComponentFirmwareStateInProgress indicates that the update is in progress.
+	ComponentFirmwareStateInProgress ComponentFirmwareState = "InProgress"
+	// ComponentFirmwareStateCompleted indicates that the update has been completed.
+	ComponentFirmwareStateCompleted ComponentFirmwareState = "Completed"
+	// ComponentFirmwareStateFailed indicates that the update has failed.
+	ComponentFirmwareStateFailed ComponentFirmwareState = "Failed"
+)
+
+// ComponentFirmwareProgress describes the update progress of a single component.
+type ComponentFirmwareProgress struct {
+	// Name is the name of the firmware inventory entry of the component.
+	Name string `json:"name"`
+	// URI is the resource URI of the firmware inventory entry of the component.
+	URI string `json:"uri,omitempty"`
+	// Version is the firmware version last observed on the component.
+	Version string `json:"version,omitempty"`
+	// State is the update state of the component.
+	State ComponentFirmwareState `json:"state,omitempty"`
+	// TaskURI is the URI of the BMC task tracking the update of the component.
+	TaskURI string `json:"taskURI,omitempty"`
+	// PercentComplete is the progress of the update as reported by the BMC.
+	PercentComplete int32 `json:"percentComplete,omitempty"`
+	// Message is the last message reported by the BMC for the update of the component.
+	Message string `json:"message,omitempty"`
+}
+
+// ComponentFirmwareStatus defines the observed state of ComponentFirmware.
+type ComponentFirmwareStatus struct {
+	// State represents the current state of the firmware update.
+	State ComponentFirmwareState `json:"state,omitempty"`
+
+	// Components contains the update progress of each selected component.
+	Components []ComponentFirmwareProgress `json:"components,omitempty"`
+
+	// ActivationTime is the time the BMC or the server was restarted to activate the updated firmware. The
+	// components have to run the desired version within the activation timeout after it.
+	// +optional
+	ActivationTime *metav1.Time `json:"activationTime,omitempty"`
+
+	// CompletionTime is the time the update has completed or failed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Conditions represents the latest available observations of the update's current state.
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="ServerRef",type=string,JSONPath=`.spec.serverRef.name`
+//+kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.component.type`
+//+kubebuilder:printcolumn:name="Model",type=string,JSONPath=`.spec.component.model`,priority=100
+//+kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.spec.version`
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ComponentFirmware is the Schema for the componentfirmwares API
+type ComponentFirmware struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ComponentFirmwareSpec   `json:"spec,omitempty"`
+	Status ComponentFirmwareStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ComponentFirmwareList contains a list of ComponentFirmware
+type ComponentFirmwareList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ComponentFirmware `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ComponentFirmware{}, &ComponentFirmwareList{})
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ComposedServerSpec defines the desired state of ComposedServer.
+type ComposedServerSpec struct {
+	// BMCRef is a reference to the BMC providing the Redfish CompositionService.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="bmcRef is immutable"
+	// +required
+	BMCRef v1.LocalObjectReference `json:"bmcRef"`
+
+	// ResourceBlocks are the IDs or names of the resource blocks the server is composed of.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="resourceBlocks is immutable"
+	// +required
+	ResourceBlocks []string `json:"resourceBlocks"`
+}
+
+// ComposedServerState defines the possible states of a ComposedServer.
+type ComposedServerState string
+
+const (
+	// ComposedServerStatePending indicates that the server has not been composed yet.
+	ComposedServerStatePending ComposedServerState = "Pending"
+	// ComposedServerStateComposed indicates that the server has been composed and is represented by a Server.
+	ComposedServerStateComposed ComposedServerState = "Composed"
+	// ComposedServerStateFailed indicates that the server could not be composed.
+	ComposedServerStateFailed ComposedServerState = "Failed"
+	// ComposedServerStateDecomposing indicates that the server is being decomposed.
+	ComposedServerStateDecomposing ComposedServerState = "Decomposing"
+)
+
+// ComposedServerStatus defines the observed state of ComposedServer.
+type ComposedServerStatus struct {
+	// State represents the current state of the composed server.
+	State ComposedServerState `json:"state,omitempty"`
+
+	// SystemURI is the Redfish URI of the composed system.
+	SystemURI string `json:"systemURI,omitempty"`
+
+	// TaskURI is the Redfish URI of the task composing the system, if the BMC composes it asynchronously.
+	TaskURI string `json:"taskURI,omitempty"`
+
+	// SystemUUID is the UUID of the composed system.
+	SystemUUID string `json:"systemUUID,omitempty"`
+
+	// ServerRef is a reference to the Server representing the composed system.
+	ServerRef *v1.LocalObjectReference `json:"serverRef,omitempty"`
+
+	// Conditions represents the latest available observations of the composed server's current state.
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="BMCRef",type=string,JSONPath=`.spec.bmcRef.name`
+//+kubebuilder:printcolumn:name="ServerRef",type=string,JSONPath=`.status.serverRef.name`
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ComposedServer is the Schema for the composedservers API
+type ComposedServer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ComposedServerSpec   `json:"spec,omitempty"`
+	Status ComposedServerStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ComposedServerList contains a list of ComposedServer
+type ComposedServerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ComposedServer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ComposedServer{}, &ComposedServerList{})
+}
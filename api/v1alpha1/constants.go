@@ -3,9 +3,92 @@
 
 package v1alpha1
 
+import "strings"
+
 const (
 	// OperationAnnotation indicates which operation should be performed outside the current spec definition flow.
 	OperationAnnotation = "metal.ironcore.dev/operation"
 	// OperationAnnotationIgnore skips the reconciliation of a resource if set to true.
 	OperationAnnotationIgnore = "ignore"
+	// OperationAnnotationReplayDiscovery re-applies the last successful discovery of a Server from the registry
+	// instead of performing a new discovery boot.
+	OperationAnnotationReplayDiscovery = "replay-discovery"
+	// OperationAnnotationGracefulRestartBMC requests a graceful restart of a BMC. Concurrent requests for the
+	// same BMC are coalesced into a single reset.
+	OperationAnnotationGracefulRestartBMC = "GracefulRestartBMC"
+	// OperationAnnotationPXERestart restarts a Server into a PXE boot, unless it boots from a SAN.
+	OperationAnnotationPXERestart = "PXERestart"
+	// OperationAnnotationRediscover moves a Server out of the Error state into a new discovery.
+	OperationAnnotationRediscover = "Rediscover"
+	// OperationAnnotationCrashDump sends an NMI to a Server and collects its crash dump into the object storage,
+	// either from the BMC or from a capture environment the Server is booted into.
+	OperationAnnotationCrashDump = "CrashDump"
+	// OperationAnnotationClearError moves a Server out of the Error state into the state it would be in without
+	// the error. Servers which have not been discovered yet are discovered again.
+	OperationAnnotationClearError = "ClearError"
+	// OperationAnnotationRekeySystemUUID sets the systemUUID of a Server to the system UUID read in-band by its
+	// discovery, once the BMC reports a system with that UUID as well.
+	OperationAnnotationRekeySystemUUID = "RekeySystemUUID"
+	// OperationNotBeforeAnnotation defers the operation until the given RFC 3339 timestamp.
+	OperationNotBeforeAnnotation = "metal.ironcore.dev/operation-not-before"
+	// OperationNotAfterAnnotation discards the operation if it could not be performed before the given
+	// RFC 3339 timestamp.
+	OperationNotAfterAnnotation = "metal.ironcore.dev/operation-not-after"
+	// OperationNameAnnotation holds the name of the Operation which tracks the operation annotation. Operations
+	// requested through the annotation directly are recorded in an Operation created by the manager.
+	OperationNameAnnotation = "metal.ironcore.dev/operation-name"
+
+	// PausedUntilAnnotation pauses the reconciliation of a resource until the given RFC 3339 timestamp.
+	PausedUntilAnnotation = "metal.ironcore.dev/paused-until"
+
+	// DryRunAnnotation runs the reconciliation of a Server, BMC, ComponentFirmware or DriveFirmware without
+	// changing the state of its BMC if set to true. The planned actions are reported in the DryRun condition.
+	DryRunAnnotation = "metal.ironcore.dev/dry-run"
+
+	// DebugRedfishAnnotation enables the recording of the Redfish requests and responses of a BMC or of a Server
+	// with an inline BMC if set to true and the recorder is enabled in the manager.
+	DebugRedfishAnnotation = "metal.ironcore.dev/debug-redfish"
+
+	// IgnitionHashAnnotation holds the hash of the ignition a ServerBootConfiguration has been rendered with. It
+	// changes whenever the data of the ignition secret changes, so that boot operators re-render the configuration.
+	IgnitionHashAnnotation = "metal.ironcore.dev/ignition-hash"
+
+	// MaintenanceWebhookAnnotation holds the URL of a webhook of a ServerClaim, which is notified of maintenance
+	// requested for the claimed Server if the manager allows maintenance webhooks.
+	MaintenanceWebhookAnnotation = "metal.ironcore.dev/maintenance-webhook"
+
+	// CredentialsHashAnnotation holds the hash of the credentials of a BMCSecret, which changes whenever its
+	// credentials are rotated.
+	CredentialsHashAnnotation = "metal.ironcore.dev/credentials-hash"
+
+	// FirmwareCampaignLabel assigns a DriveFirmware or ComponentFirmware to the FirmwareCampaign with the given name,
+	// which records the outcome of the update. The FirmwareCampaign is created if it does not exist yet.
+	FirmwareCampaignLabel = "metal.ironcore.dev/firmware-campaign"
+
+	// ForceDeleteAnnotation allows the deletion of a Server which is claimed or under maintenance if set to true.
+	ForceDeleteAnnotation = "metal.ironcore.dev/force-delete"
+
+	// LocateDriveAnnotation turns on the location indicator of the drive of a Server with the given name, so that
+	// its bay blinks. The indicator is turned off once the annotation is removed, or once the locate timed out,
+	// which also removes the annotation.
+	LocateDriveAnnotation = "metal.ironcore.dev/locate-drive"
+
+	// OwnerNamespaceLabel marks a Server as part of the pool of the given namespace. The Server is only claimed by
+	// ServerClaims of the namespace and of the namespaces a ServerGrant of the namespace delegates it to.
+	OwnerNamespaceLabel = "metal.ironcore.dev/owner-namespace"
+
+	// ProbeExtensionAnnotationPrefix is the prefix of the Server annotations holding the JSON output of the
+	// collectors of the probe agent. The name of the collector is appended to the prefix.
+	ProbeExtensionAnnotationPrefix = "probe.metal.ironcore.dev/"
+
+	// PreTransitionHookAnnotationSuffix is the suffix of the annotation prefixes which block the transition of a
+	// Server into a state. An annotation "pre-<state>.hook.metal.ironcore.dev/<owner>" on a Server blocks its
+	// transition into <state>, given in lower case, until the owner removes the annotation.
+	PreTransitionHookAnnotationSuffix = ".hook.metal.ironcore.dev/"
 )
+
+// PreTransitionHookAnnotationPrefix returns the annotation prefix of the hooks blocking the transition of a Server
+// into the given state, e.g. "pre-reserved.hook.metal.ironcore.dev/".
+func PreTransitionHookAnnotationPrefix(state ServerState) string {
+	return "pre-" + strings.ToLower(string(state)) + PreTransitionHookAnnotationSuffix
+}
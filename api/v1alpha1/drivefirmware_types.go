@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DriveFirmwareSpec defines the desired state of DriveFirmware.
+type DriveFirmwareSpec struct {
+	// ServerRef is a reference to the server whose drives should be updated.
+	// +required
+	ServerRef v1.LocalObjectReference `json:"serverRef"`
+
+	// Model selects the drives of the server which should be updated by their model name.
+	// +required
+	Model string `json:"model"`
+
+	// Version is the firmware version the selected drives should be running.
+	// +required
+	Version string `json:"version"`
+
+	// Image specifies the firmware image which is applied to the selected drives.
+	// +required
+	Image FirmwareImage `json:"image"`
+
+	// TTLSecondsAfterFinished limits the lifetime of the DriveFirmware after the update has completed or
+	// failed. Once the TTL has expired, the DriveFirmware is deleted. If unset, it is kept.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// FirmwareImage defines the location of a firmware image.
+type FirmwareImage struct {
+	// URI is the location of the firmware image the BMC fetches the image from.
+	// +required
+	URI string `json:"uri"`
+
+	// TransferProtocol is the network protocol the BMC uses to fetch the image.
+	// If omitted, the protocol is derived from the URI scheme.
+	// +kubebuilder:validation:Enum=CIFS;FTP;SFTP;HTTP;HTTPS;NFS;SCP;TFTP
+	// +optional
+	TransferProtocol string `json:"transferProtocol,omitempty"`
+}
+
+// DriveFirmwareState defines the possible states of a DriveFirmware update.
+type DriveFirmwareState string
+
+const (
+	// DriveFirmwareStatePending indicates that the update has not been started yet.
+	DriveFirmwareStatePending DriveFirmwareState = "Pending"
+	// DriveFirmwareStateInProgress indicates that the update is in progress.
+	DriveFirmwareStateInProgress DriveFirmwareState = "InProgress"
+	// DriveFirmwareStateCompleted indicates that the update has been completed.
+	DriveFirmwareStateCompleted DriveFirmwareState = "Completed"
+	// DriveFirmwareStateFailed indicates that the update has failed.
+	DriveFirmwareStateFailed DriveFirmwareState = "Failed"
+)
+
+// DriveFirmwareProgress describes the update progress of a single drive.
+type DriveFirmwareProgress struct {
+	// Name is the name of the drive.
+	Name string `json:"name"`
+	// Storage is the name of the storage the drive is attached to.
+	Storage string `json:"storage,omitempty"`
+	// Version is the firmware version last observed on the drive.
+	Version string `json:"version,omitempty"`
+	// State is the update state of the drive.
+	State DriveFirmwareState `json:"state,omitempty"`
+	// TaskURI is the URI of the BMC task tracking the update of the drive.
+	TaskURI string `json:"taskURI,omitempty"`
+	// PercentComplete is the progress of the update as reported by the BMC.
+	PercentComplete int32 `json:"percentComplete,omitempty"`
+	// Message is the last message reported by the BMC for the update of the drive.
+	Message string `json:"message,omitempty"`
+}
+
+// DriveFirmwareStatus defines the observed state of DriveFirmware.
+type DriveFirmwareStatus struct {
+	// State represents the current state of the drive firmware update.
+	State DriveFirmwareState `json:"state,omitempty"`
+
+	// Drives contains the update progress of each selected drive.
+	Drives []DriveFirmwareProgress `json:"drives,omitempty"`
+
+	// CompletionTime is the time the update has completed or failed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Conditions represents the latest available observations of the update's current state.
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="ServerRef",type=string,JSONPath=`.spec.serverRef.name`
+//+kubebuilder:printcolumn:name="Model",type=string,JSONPath=`.spec.model`
+//+kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.spec.version`
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DriveFirmware is the Schema for the drivefirmwares API
+type DriveFirmware struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DriveFirmwareSpec   `json:"spec,omitempty"`
+	Status DriveFirmwareStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DriveFirmwareList contains a list of DriveFirmware
+type DriveFirmwareList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DriveFirmware `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DriveFirmware{}, &DriveFirmwareList{})
+}
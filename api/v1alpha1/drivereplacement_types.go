@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DriveReplacementSpec defines the desired state of DriveReplacement.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+type DriveReplacementSpec struct {
+	// ServerRef is a reference to the server whose drive is replaced.
+	// +required
+	ServerRef v1.LocalObjectReference `json:"serverRef"`
+
+	// DriveName is the name of the drive which is replaced as reported in the storages of the server.
+	// +required
+	DriveName string `json:"driveName"`
+
+	// FirmwareVersion is the firmware version the new drive is expected to run. If omitted, the firmware
+	// version of the new drive is not verified.
+	// +optional
+	FirmwareVersion string `json:"firmwareVersion,omitempty"`
+
+	// WaitForRebuild specifies whether the replacement waits until the volumes of the storage containing the
+	// drive have been rebuilt and are healthy again.
+	// +optional
+	WaitForRebuild bool `json:"waitForRebuild,omitempty"`
+}
+
+// DriveReplacementState defines the possible states of a DriveReplacement.
+type DriveReplacementState string
+
+const (
+	// DriveReplacementStatePending indicates that the replacement has not been started yet.
+	DriveReplacementStatePending DriveReplacementState = "Pending"
+	// DriveReplacementStateAwaitingSwap indicates that the location indicator of the drive is turned on and the
+	// replacement waits for the drive to be swapped.
+	DriveReplacementStateAwaitingSwap DriveReplacementState = "AwaitingSwap"
+	// DriveReplacementStateVerifying indicates that the drive has been swapped and the new drive is verified.
+	DriveReplacementStateVerifying DriveReplacementState = "Verifying"
+	// DriveReplacementStateRebuilding indicates that the replacement waits for the volumes of the storage to be
+	// rebuilt.
+	DriveReplacementStateRebuilding DriveReplacementState = "Rebuilding"
+	// DriveReplacementStateCompleted indicates that the drive has been replaced.
+	DriveReplacementStateCompleted DriveReplacementState = "Completed"
+	// DriveReplacementStateFailed indicates that the replacement has failed.
+	DriveReplacementStateFailed DriveReplacementState = "Failed"
+)
+
+// DriveReplacementStatus defines the observed state of DriveReplacement.
+type DriveReplacementStatus struct {
+	// State represents the current state of the drive replacement.
+	State DriveReplacementState `json:"state,omitempty"`
+
+	// OldSerialNumber is the serial number of the drive when the replacement has been started.
+	// +optional
+	OldSerialNumber string `json:"oldSerialNumber,omitempty"`
+
+	// NewSerialNumber is the serial number of the drive which has been inserted.
+	// +optional
+	NewSerialNumber string `json:"newSerialNumber,omitempty"`
+
+	// DriveRemoved reports whether the drive has been observed absent since the replacement has been started.
+	// +optional
+	DriveRemoved bool `json:"driveRemoved,omitempty"`
+
+	// Message is a human-readable description of the current state of the replacement.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// CompletionTime is the time the replacement has completed or failed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Conditions represents the latest available observations of the replacement's current state.
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="ServerRef",type=string,JSONPath=`.spec.serverRef.name`
+//+kubebuilder:printcolumn:name="Drive",type=string,JSONPath=`.spec.driveName`
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DriveReplacement is the Schema for the drivereplacements API
+type DriveReplacement struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DriveReplacementSpec   `json:"spec,omitempty"`
+	Status DriveReplacementStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DriveReplacementList contains a list of DriveReplacement
+type DriveReplacementList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DriveReplacement `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DriveReplacement{}, &DriveReplacementList{})
+}
@@ -19,6 +19,44 @@ type EndpointSpec struct {
 
 // EndpointStatus defines the observed state of Endpoint
 type EndpointStatus struct {
+	// CredentialAttempts records the attempts of the credential onboarding of the BMC behind the endpoint,
+	// oldest first.
+	// +optional
+	CredentialAttempts []CredentialAttempt `json:"credentialAttempts,omitempty"`
+
+	// Conditions represents the latest available observations of the endpoint's current state.
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+// CredentialAttemptResult is the result of a credential onboarding attempt.
+type CredentialAttemptResult string
+
+const (
+	// CredentialAttemptResultRejected indicates that the BMC rejected the factory-default credentials.
+	CredentialAttemptResultRejected CredentialAttemptResult = "Rejected"
+	// CredentialAttemptResultRotated indicates that the BMC accepted the factory-default credentials and the
+	// password has been rotated to a generated one.
+	CredentialAttemptResultRotated CredentialAttemptResult = "Rotated"
+	// CredentialAttemptResultRotationFailed indicates that the BMC accepted the factory-default credentials, but
+	// the password could not be rotated.
+	CredentialAttemptResultRotationFailed CredentialAttemptResult = "RotationFailed"
+)
+
+// CredentialAttempt records the attempt to log in to a BMC with factory-default credentials. Passwords are never
+// recorded.
+type CredentialAttempt struct {
+	// Time is the time of the attempt.
+	Time metav1.Time `json:"time"`
+	// Username is the user name of the tried credentials.
+	Username string `json:"username"`
+	// Result is the result of the attempt.
+	Result CredentialAttemptResult `json:"result"`
+	// Message describes the result of the attempt.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // +kubebuilder:object:root=true
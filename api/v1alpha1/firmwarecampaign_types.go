@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FirmwareCampaignSpec defines the desired state of FirmwareCampaign.
+type FirmwareCampaignSpec struct {
+	// Description describes the purpose of the rollout, e.g. the reference of the change request.
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// FirmwareCampaignState defines the possible states of a FirmwareCampaign.
+type FirmwareCampaignState string
+
+const (
+	// FirmwareCampaignStateInProgress indicates that updates of the campaign have not finished yet.
+	FirmwareCampaignStateInProgress FirmwareCampaignState = "InProgress"
+	// FirmwareCampaignStateCompleted indicates that all updates of the campaign have finished.
+	FirmwareCampaignStateCompleted FirmwareCampaignState = "Completed"
+)
+
+// FirmwareCampaignOutcome defines the outcome of a firmware update of a FirmwareCampaign.
+type FirmwareCampaignOutcome string
+
+const (
+	// FirmwareCampaignOutcomePending indicates that the update has not finished yet.
+	FirmwareCampaignOutcomePending FirmwareCampaignOutcome = "Pending"
+	// FirmwareCampaignOutcomeSucceeded indicates that the update has flashed the firmware successfully.
+	FirmwareCampaignOutcomeSucceeded FirmwareCampaignOutcome = "Succeeded"
+	// FirmwareCampaignOutcomeFailed indicates that the update has failed.
+	FirmwareCampaignOutcomeFailed FirmwareCampaignOutcome = "Failed"
+	// FirmwareCampaignOutcomeSkipped indicates that the update did not flash any firmware, e.g. because the server
+	// already ran the version.
+	FirmwareCampaignOutcomeSkipped FirmwareCampaignOutcome = "Skipped"
+)
+
+// FirmwareCampaignUpdate records the outcome of a firmware update of a FirmwareCampaign.
+type FirmwareCampaignUpdate struct {
+	// Kind is the kind of the update, DriveFirmware or ComponentFirmware.
+	Kind string `json:"kind"`
+	// Name is the name of the update.
+	Name string `json:"name"`
+	// Server is the name of the server which has been updated.
+	Server string `json:"server"`
+	// Version is the firmware version the update rolled out.
+	Version string `json:"version,omitempty"`
+	// Outcome is the outcome of the update.
+	Outcome FirmwareCampaignOutcome `json:"outcome"`
+	// Reason describes why the update has failed or has been skipped.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// CompletionTime is the time the update has finished.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// FirmwareCampaignStatus defines the observed state of FirmwareCampaign.
+type FirmwareCampaignStatus struct {
+	// State represents the current state of the campaign.
+	State FirmwareCampaignState `json:"state,omitempty"`
+
+	// StartTime is the time the first update of the campaign has been created.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the last update of the campaign has finished.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Succeeded is the number of updates which have succeeded.
+	Succeeded int32 `json:"succeeded,omitempty"`
+
+	// Failed is the number of updates which have failed.
+	Failed int32 `json:"failed,omitempty"`
+
+	// Skipped is the number of updates which have been skipped.
+	Skipped int32 `json:"skipped,omitempty"`
+
+	// Pending is the number of updates which have not finished yet.
+	Pending int32 `json:"pending,omitempty"`
+
+	// Updates records the outcome of each update of the campaign. Finished updates are kept after the update
+	// objects have been deleted.
+	// +optional
+	Updates []FirmwareCampaignUpdate `json:"updates,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+//+kubebuilder:printcolumn:name="Succeeded",type=integer,JSONPath=`.status.succeeded`
+//+kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failed`
+//+kubebuilder:printcolumn:name="Skipped",type=integer,JSONPath=`.status.skipped`
+//+kubebuilder:printcolumn:name="Pending",type=integer,JSONPath=`.status.pending`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// FirmwareCampaign is the Schema for the firmwarecampaigns API
+type FirmwareCampaign struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FirmwareCampaignSpec   `json:"spec,omitempty"`
+	Status FirmwareCampaignStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FirmwareCampaignList contains a list of FirmwareCampaign
+type FirmwareCampaignList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FirmwareCampaign `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FirmwareCampaign{}, &FirmwareCampaignList{})
+}
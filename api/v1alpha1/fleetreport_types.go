@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FleetReportSpec defines the desired state of FleetReport.
+type FleetReportSpec struct {
+	// ServerSelector restricts the report to the servers matching the selector. If empty, all servers are reported.
+	// +optional
+	ServerSelector *metav1.LabelSelector `json:"serverSelector,omitempty"`
+}
+
+// FailingBMC describes a BMC which is not in the enabled state.
+type FailingBMC struct {
+	// Name is the name of the BMC.
+	Name string `json:"name"`
+	// State is the state of the BMC.
+	State BMCState `json:"state,omitempty"`
+}
+
+// FleetReportStatus defines the observed state of FleetReport.
+type FleetReportStatus struct {
+	// TotalServers is the number of reported servers.
+	TotalServers int32 `json:"totalServers,omitempty"`
+
+	// ServersByState is the number of servers per server state.
+	// +optional
+	ServersByState map[string]int32 `json:"serversByState,omitempty"`
+
+	// BIOSVersions is the number of servers per BIOS version.
+	// +optional
+	BIOSVersions map[string]int32 `json:"biosVersions,omitempty"`
+
+	// BMCFirmwareVersions is the number of BMCs of the reported servers per firmware version.
+	// +optional
+	BMCFirmwareVersions map[string]int32 `json:"bmcFirmwareVersions,omitempty"`
+
+	// PendingFirmwareUpdates is the number of drive and component firmware updates of the reported servers
+	// which have not finished yet.
+	PendingFirmwareUpdates int32 `json:"pendingFirmwareUpdates,omitempty"`
+
+	// FailingBMCs lists the BMCs of the reported servers which are not enabled.
+	// +optional
+	FailingBMCs []FailingBMC `json:"failingBMCs,omitempty"`
+
+	// LastUpdateTime is the time the report has been updated last.
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Servers",type=integer,JSONPath=`.status.totalServers`
+//+kubebuilder:printcolumn:name="PendingFirmwareUpdates",type=integer,JSONPath=`.status.pendingFirmwareUpdates`
+//+kubebuilder:printcolumn:name="LastUpdate",type=date,JSONPath=`.status.lastUpdateTime`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// FleetReport is the Schema for the fleetreports API
+type FleetReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FleetReportSpec   `json:"spec,omitempty"`
+	Status FleetReportStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FleetReportList contains a list of FleetReport
+type FleetReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FleetReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FleetReport{}, &FleetReportList{})
+}
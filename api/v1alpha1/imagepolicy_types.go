@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ImagePolicySpec defines the desired state of ImagePolicy.
+type ImagePolicySpec struct {
+	// AllowedImages are the registries and repositories ServerClaims may boot images from, e.g.
+	// "registry.example.com" or "registry.example.com/os/gardenlinux". An image is allowed if it is part of one of
+	// the registries or repositories.
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	AllowedImages []string `json:"allowedImages"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="AllowedImages",type="string",JSONPath=".spec.allowedImages"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ImagePolicy is the Schema for the imagepolicies API. It restricts the images ServerClaims may boot. If no
+// ImagePolicy exists, any image is allowed. Otherwise, an image has to be allowed by any of the policies.
+type ImagePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ImagePolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ImagePolicyList contains a list of ImagePolicy
+type ImagePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ImagePolicy `json:"items"`
+}
+
+// ImageAllowed reports whether the image may be booted according to the policies. Any image is allowed if there
+// is no policy.
+func ImageAllowed(policies []ImagePolicy, image string) bool {
+	if len(policies) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(policies, func(policy ImagePolicy) bool {
+		return slices.ContainsFunc(policy.Spec.AllowedImages, func(allowed string) bool {
+			return imageInRepository(image, allowed)
+		})
+	})
+}
+
+// imageInRepository reports whether the image is part of the registry or repository. The image has to equal the
+// repository, or continue it with a path, a tag or a digest, so that "registry.example.com/os" does not allow
+// "registry.example.com/osx". A registry, which has no path, is only continued with a path, so that
+// "registry.example.com" does not allow the images of "registry.example.com:5000".
+func imageInRepository(image, repository string) bool {
+	repository = strings.TrimSuffix(repository, "/")
+	rest, ok := strings.CutPrefix(image, repository)
+	if !ok || rest == "" {
+		return ok
+	}
+	if !strings.Contains(repository, "/") {
+		return rest[0] == '/'
+	}
+	return strings.ContainsRune("/:@", rune(rest[0]))
+}
+
+func init() {
+	SchemeBuilder.Register(&ImagePolicy{}, &ImagePolicyList{})
+}
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OperationTargetKind is the kind of the resource an operation is performed on.
+// +kubebuilder:validation:Enum=Server;BMC
+type OperationTargetKind string
+
+const (
+	// OperationTargetKindServer performs the operation on a Server.
+	OperationTargetKindServer OperationTargetKind = "Server"
+	// OperationTargetKindBMC performs the operation on a BMC.
+	OperationTargetKindBMC OperationTargetKind = "BMC"
+)
+
+// OperationTargetRef references the resource an operation is performed on.
+type OperationTargetRef struct {
+	// Kind is the kind of the resource.
+	Kind OperationTargetKind `json:"kind"`
+	// Name is the name of the resource.
+	Name string `json:"name"`
+}
+
+// OperationSpec defines the desired state of Operation.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+type OperationSpec struct {
+	// TargetRef references the Server or BMC the operation is performed on.
+	TargetRef OperationTargetRef `json:"targetRef"`
+
+	// Type is the operation which is performed, with the values of the metal.ironcore.dev/operation annotation:
+	// a Redfish reset type of a Server, e.g. ForceRestart, PXERestart, Rediscover, ClearError or replay-discovery
+	// for Servers, and GracefulRestartBMC for BMCs.
+	Type string `json:"type"`
+
+	// NotBefore defers the operation until the given time.
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// NotAfter discards the operation if it could not be performed before the given time.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// RequestedBy is the user who created the operation. It is set by the webhook on creation.
+	// +optional
+	RequestedBy string `json:"requestedBy,omitempty"`
+}
+
+// OperationState defines the possible states of an Operation.
+type OperationState string
+
+const (
+	// OperationStatePending specifies that the operation waits to be performed.
+	OperationStatePending OperationState = "Pending"
+	// OperationStateInProgress specifies that the operation has been handed to the controller of its target.
+	OperationStateInProgress OperationState = "InProgress"
+	// OperationStateSucceeded specifies that the operation has been performed.
+	OperationStateSucceeded OperationState = "Succeeded"
+	// OperationStateFailed specifies that the operation cannot be performed.
+	OperationStateFailed OperationState = "Failed"
+	// OperationStateExpired specifies that the operation could not be performed before its NotAfter time.
+	OperationStateExpired OperationState = "Expired"
+)
+
+// OperationStatus defines the observed state of Operation.
+type OperationStatus struct {
+	// State represents the current state of the operation.
+	// +optional
+	State OperationState `json:"state,omitempty"`
+
+	// StartTime is the time the operation has been handed to the controller of its target.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the operation has reached a final state.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Message describes the result of the operation, or the error of its last attempt while it is in progress.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="TargetKind",type=string,JSONPath=`.spec.targetRef.kind`
+//+kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.targetRef.name`
+//+kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+//+kubebuilder:printcolumn:name="RequestedBy",type=string,JSONPath=`.spec.requestedBy`,priority=1
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Operation is the Schema for the operations API. It performs a one-off action on a Server or BMC, which is
+// otherwise requested with the metal.ironcore.dev/operation annotation, and keeps a record of it.
+type Operation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Spec   OperationSpec   `json:"spec,omitempty"`
+	Status OperationStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OperationList contains a list of Operation
+type OperationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Operation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Operation{}, &OperationList{})
+}
@@ -4,6 +4,8 @@
 package v1alpha1
 
 import (
+	"time"
+
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -54,6 +56,12 @@ type BMCAccess struct {
 	// BMCSecretRef is a reference to the Kubernetes Secret object that contains the credentials
 	// required to access the BMC. This secret includes sensitive information such as usernames and passwords.
 	BMCSecretRef v1.LocalObjectReference `json:"bmcSecretRef"`
+
+	// CertificateFingerprint pins the TLS certificate of the BMC by the SHA-256 fingerprint of the certificate or of
+	// its public key, e.g. sha256:<hex>.
+	// +kubebuilder:validation:Pattern=`^sha256:[0-9a-fA-F]{64}$`
+	// +optional
+	CertificateFingerprint string `json:"certificateFingerprint,omitempty"`
 }
 
 // BootOrder represents the boot order of the server.
@@ -66,15 +74,153 @@ type BootOrder struct {
 	Device string `json:"device"`
 }
 
+// NetworkBootInterface selects a network interface of a server by its MAC address or name.
+// +kubebuilder:validation:XValidation:rule="has(self.macAddress) != has(self.name)",message="exactly one of macAddress and name must be set"
+type NetworkBootInterface struct {
+	// MACAddress is the MAC address of the network interface.
+	// +optional
+	MACAddress string `json:"macAddress,omitempty"`
+	// Name is the name of the network interface, either as reported in the network interfaces of the server status
+	// or as the ID or part of the display name of the UEFI boot option of the BMC, e.g. NIC.Slot.3-1.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// BootMode is the firmware boot mode of a server.
+// +kubebuilder:validation:Enum=UEFI;Legacy
+type BootMode string
+
+const (
+	// BootModeUEFI boots the server via UEFI.
+	BootModeUEFI BootMode = "UEFI"
+	// BootModeLegacy boots the server via the legacy BIOS.
+	BootModeLegacy BootMode = "Legacy"
+)
+
 // BIOSSettings represents the BIOS settings for a server.
 type BIOSSettings struct {
 	// Version specifies the version of the server BIOS for which the settings are defined.
 	Version string `json:"version"`
 	// Settings is a map of key-value pairs representing the BIOS settings.
 	Settings map[string]string `json:"settings,omitempty"`
+	// SettingsFrom is a list of BIOS settings whose values are read from Secrets or ConfigMaps. Values read from
+	// Secrets are masked in the status of the server.
+	// +optional
+	SettingsFrom []BIOSSettingSource `json:"settingsFrom,omitempty"`
+	// ApplyTime defines when the BMC applies the settings. The maintenance window apply times require the
+	// maintenanceWindow of the server. If empty, the BMC applies the settings at its default time, usually on the
+	// next reset.
+	// +optional
+	ApplyTime SettingsApplyTime `json:"applyTime,omitempty"`
+}
+
+// SettingsApplyTime defines when a BMC applies settings, see the Redfish @Redfish.SettingsApplyTime annotation.
+// +kubebuilder:validation:Enum=Immediate;OnReset;AtMaintenanceWindowStart;InMaintenanceWindowOnReset
+type SettingsApplyTime string
+
+const (
+	// SettingsApplyTimeImmediate applies the settings immediately, which may reset the system.
+	SettingsApplyTimeImmediate SettingsApplyTime = "Immediate"
+	// SettingsApplyTimeOnReset applies the settings on the next reset of the system.
+	SettingsApplyTimeOnReset SettingsApplyTime = "OnReset"
+	// SettingsApplyTimeAtMaintenanceWindowStart applies the settings at the start of the maintenance window. The
+	// BMC may reset the system during the window.
+	SettingsApplyTimeAtMaintenanceWindowStart SettingsApplyTime = "AtMaintenanceWindowStart"
+	// SettingsApplyTimeInMaintenanceWindowOnReset applies the settings on a reset of the system within the
+	// maintenance window.
+	SettingsApplyTimeInMaintenanceWindowOnReset SettingsApplyTime = "InMaintenanceWindowOnReset"
+)
+
+// IsMaintenanceWindow reports whether the apply time is bound to the maintenance window of the server.
+func (t SettingsApplyTime) IsMaintenanceWindow() bool {
+	return t == SettingsApplyTimeAtMaintenanceWindowStart || t == SettingsApplyTimeInMaintenanceWindowOnReset
+}
+
+// MaintenanceWindow defines a time window in which the BMC may apply settings and reset the server.
+type MaintenanceWindow struct {
+	// Start is the time the window starts at.
+	Start metav1.Time `json:"start"`
+	// Duration is the length of the window.
+	Duration metav1.Duration `json:"duration"`
+}
+
+// Contains reports whether the given time lies within the window.
+func (w *MaintenanceWindow) Contains(t time.Time) bool {
+	return !t.Before(w.Start.Time) && t.Before(w.Start.Add(w.Duration.Duration))
+}
+
+// BIOSSettingSource defines a BIOS setting whose value is read from a Secret or a ConfigMap.
+// +kubebuilder:validation:XValidation:rule="has(self.secretKeyRef) != has(self.configMapKeyRef)",message="exactly one of secretKeyRef or configMapKeyRef must be set"
+type BIOSSettingSource struct {
+	// Name is the name of the BIOS setting.
+	Name string `json:"name"`
+	// SecretKeyRef selects the key of a Secret holding the value of the setting.
+	// +optional
+	SecretKeyRef *ObjectKeySelector `json:"secretKeyRef,omitempty"`
+	// ConfigMapKeyRef selects the key of a ConfigMap holding the value of the setting.
+	// +optional
+	ConfigMapKeyRef *ObjectKeySelector `json:"configMapKeyRef,omitempty"`
+}
+
+// BIOSPassword defines a BIOS password of a server, which is set whenever the value in the Secret changes.
+type BIOSPassword struct {
+	// Name is the name of the BIOS password, e.g. AdminPassword or UserPassword.
+	// +kubebuilder:default=AdminPassword
+	// +optional
+	Name string `json:"name,omitempty"`
+	// SecretKeyRef selects the key of a Secret holding the password.
+	SecretKeyRef ObjectKeySelector `json:"secretKeyRef"`
+	// SettingsAttribute is the BIOS attribute the current password is supplied in with changes of the BIOS
+	// settings, for systems which only accept settings changes together with the password, e.g. OldSetupPassword.
+	// If empty, the password is not supplied with the settings.
+	// +optional
+	SettingsAttribute string `json:"settingsAttribute,omitempty"`
+}
+
+// ObjectKeySelector selects a key of a namespaced Secret or ConfigMap.
+type ObjectKeySelector struct {
+	// Namespace is the namespace of the object.
+	Namespace string `json:"namespace"`
+	// Name is the name of the object.
+	Name string `json:"name"`
+	// Key is the key of the value in the object.
+	Key string `json:"key"`
+}
+
+// BIOSSettingsChange records BIOS settings applied to a server.
+type BIOSSettingsChange struct {
+	// Time is the time at which the settings were applied.
+	Time metav1.Time `json:"time"`
+	// Version is the version of the server BIOS to which the settings were applied.
+	Version string `json:"version"`
+	// Settings are the applied settings.
+	Settings []BIOSSettingChange `json:"settings"`
+}
+
+// PendingChange describes a setting which takes effect on the next reboot of the server.
+type PendingChange struct {
+	// Name is the name of the setting.
+	Name string `json:"name"`
+	// CurrentValue is the value of the setting until the reboot. It is empty if the value is unknown.
+	// +optional
+	CurrentValue string `json:"currentValue,omitempty"`
+	// PendingValue is the value of the setting after the reboot.
+	PendingValue string `json:"pendingValue"`
+}
+
+// BIOSSettingChange describes the change of a single BIOS setting.
+type BIOSSettingChange struct {
+	// Name is the name of the BIOS setting.
+	Name string `json:"name"`
+	// OldValue is the value of the setting before the change. It is empty if the value was unknown.
+	OldValue string `json:"oldValue,omitempty"`
+	// NewValue is the applied value of the setting.
+	NewValue string `json:"newValue"`
 }
 
 // ServerSpec defines the desired state of a Server.
+// +kubebuilder:validation:XValidation:rule="has(self.maintenanceWindow) || !has(self.BIOS) || self.BIOS.all(b, !has(b.applyTime) || !(b.applyTime in ['AtMaintenanceWindowStart', 'InMaintenanceWindowOnReset']))",message="maintenanceWindow is required for the maintenance window apply times of BIOS settings"
+// +kubebuilder:validation:XValidation:rule="!has(self.bootMode) || self.bootMode != 'Legacy' || !has(self.networkBootInterfaces) || size(self.networkBootInterfaces) == 0",message="networkBootInterfaces select UEFI boot options and require the UEFI boot mode"
 type ServerSpec struct {
 	// UUID is the unique identifier for the server.
 	// Deprecated in favor of systemUUID.
@@ -83,6 +229,11 @@ type ServerSpec struct {
 	// SystemUUID is the unique identifier for the server.
 	SystemUUID string `json:"systemUUID,omitempty"`
 
+	// SystemURI is the Redfish URI of the system of the server on its BMC. It addresses the system on BMCs which
+	// front many systems, e.g. rack managers aggregating the BMCs of their nodes.
+	// +optional
+	SystemURI string `json:"systemURI,omitempty"`
+
 	// Power specifies the desired power state of the server.
 	Power Power `json:"power,omitempty"`
 
@@ -108,8 +259,183 @@ type ServerSpec struct {
 
 	// BootOrder specifies the boot order of the server.
 	BootOrder []BootOrder `json:"bootOrder,omitempty"`
+
+	// BootMode is the firmware boot mode of the server. It is set through the BIOS attributes of the vendor while
+	// the server is not claimed and takes effect on its next boot. PXE boots use the boot mode. If empty, the boot
+	// mode of the server is kept.
+	// +optional
+	BootMode BootMode `json:"bootMode,omitempty"`
+
+	// NetworkBootInterfaces selects the network interfaces the server boots from via PXE, in order of preference.
+	// If empty, the server boots from the default network boot option of its BMC.
+	// +optional
+	NetworkBootInterfaces []NetworkBootInterface `json:"networkBootInterfaces,omitempty"`
+
 	// BIOS specifies the BIOS settings for the server.
 	BIOS []BIOSSettings `json:"BIOS,omitempty"`
+
+	// BIOSPassword is the BIOS password of the server, which is set and rotated from a Secret.
+	// +optional
+	BIOSPassword *BIOSPassword `json:"biosPassword,omitempty"`
+
+	// MaintenanceWindow is the window in which the BMC applies settings with a maintenance window apply time. The
+	// window is passed to the BMC, so that the hardware itself enforces it.
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// DiscoveryPolicy overrides the discovery and boot timings of the manager for this server, e.g. for slow
+	// legacy hardware.
+	// +optional
+	DiscoveryPolicy *ServerDiscoveryPolicy `json:"discoveryPolicy,omitempty"`
+
+	// DPUs configures the DPUs of the server.
+	// +optional
+	DPUs []DPUSpec `json:"dpus,omitempty"`
+}
+
+// DPUMode defines the mode a DPU runs in.
+// +kubebuilder:validation:Enum=DPU;NIC
+type DPUMode string
+
+const (
+	// DPUModeDPU runs the DPU with its own operating system in front of the host.
+	DPUModeDPU DPUMode = "DPU"
+	// DPUModeNIC runs the DPU as a plain network adapter of the host.
+	DPUModeNIC DPUMode = "NIC"
+)
+
+// DPUSpec defines the desired state of a DPU of a server.
+type DPUSpec struct {
+	// Name is the name of the DPU as reported in the status of the server.
+	// +required
+	Name string `json:"name"`
+
+	// Mode is the mode the DPU should run in. A mode switch is only applied while the server is not claimed and
+	// takes effect on the next power cycle of the server.
+	// +required
+	Mode DPUMode `json:"mode"`
+}
+
+// PowerSupply defines the details of a power supply of a server.
+type PowerSupply struct {
+	// Name is the name of the power supply.
+	Name string `json:"name"`
+	// Manufacturer is the manufacturer of the power supply.
+	Manufacturer string `json:"manufacturer,omitempty"`
+	// Model is the model of the power supply.
+	Model string `json:"model,omitempty"`
+	// SerialNumber is the serial number of the power supply.
+	SerialNumber string `json:"serialNumber,omitempty"`
+	// PartNumber is the part number of the power supply.
+	PartNumber string `json:"partNumber,omitempty"`
+	// FirmwareVersion is the firmware version of the power supply.
+	FirmwareVersion string `json:"firmwareVersion,omitempty"`
+	// CapacityWatts is the maximum power the power supply can deliver in watts.
+	CapacityWatts int32 `json:"capacityWatts,omitempty"`
+	// State is the state of the power supply, e.g. Enabled or Absent.
+	State string `json:"state,omitempty"`
+	// Health is the health of the power supply, e.g. OK or Critical.
+	Health string `json:"health,omitempty"`
+}
+
+// Fan defines the details of a fan of a server.
+type Fan struct {
+	// Name is the name of the fan.
+	Name string `json:"name"`
+	// Manufacturer is the manufacturer of the fan.
+	Manufacturer string `json:"manufacturer,omitempty"`
+	// Model is the model of the fan.
+	Model string `json:"model,omitempty"`
+	// SerialNumber is the serial number of the fan.
+	SerialNumber string `json:"serialNumber,omitempty"`
+	// PartNumber is the part number of the fan.
+	PartNumber string `json:"partNumber,omitempty"`
+	// State is the state of the fan, e.g. Enabled or Absent.
+	State string `json:"state,omitempty"`
+	// Health is the health of the fan, e.g. OK or Critical.
+	Health string `json:"health,omitempty"`
+}
+
+// RedundancyGroupType is the type of the members of a redundancy group.
+type RedundancyGroupType string
+
+const (
+	// RedundancyGroupTypePowerSupply is a redundancy group of power supplies.
+	RedundancyGroupTypePowerSupply RedundancyGroupType = "PowerSupply"
+	// RedundancyGroupTypeFan is a redundancy group of fans.
+	RedundancyGroupTypeFan RedundancyGroupType = "Fan"
+)
+
+// RedundancyGroup defines the redundancy of a group of power supplies or fans of a server.
+type RedundancyGroup struct {
+	// Name is the name of the redundancy group.
+	Name string `json:"name"`
+	// Type is the type of the members of the redundancy group.
+	Type RedundancyGroupType `json:"type"`
+	// Mode is the redundancy mode of the group, e.g. N+m.
+	Mode string `json:"mode,omitempty"`
+	// MinNumNeeded is the minimum number of members needed for the group to be redundant.
+	MinNumNeeded int32 `json:"minNumNeeded,omitempty"`
+	// MaxNumSupported is the maximum number of members of the group.
+	MaxNumSupported int32 `json:"maxNumSupported,omitempty"`
+	// State is the state of the redundancy group, e.g. Enabled.
+	State string `json:"state,omitempty"`
+	// Health is the health of the redundancy group. It is not OK once the group has lost its redundancy.
+	Health string `json:"health,omitempty"`
+}
+
+// DPUStatus defines the observed state of a DPU of a server.
+type DPUStatus struct {
+	// Name is the name of the system of the DPU on the BMC.
+	Name string `json:"name"`
+	// SystemURI is the URI of the system of the DPU on the BMC.
+	SystemURI string `json:"systemURI,omitempty"`
+	// Manufacturer is the manufacturer of the DPU.
+	Manufacturer string `json:"manufacturer,omitempty"`
+	// Model is the model of the DPU.
+	Model string `json:"model,omitempty"`
+	// SerialNumber is the serial number of the DPU.
+	SerialNumber string `json:"serialNumber,omitempty"`
+	// FirmwareVersion is the version of the firmware of the DPU.
+	FirmwareVersion string `json:"firmwareVersion,omitempty"`
+	// ManagementIP is the address of the out-of-band management interface of the DPU.
+	ManagementIP string `json:"managementIP,omitempty"`
+	// Mode is the mode the DPU currently runs in.
+	Mode DPUMode `json:"mode,omitempty"`
+	// PendingMode is the mode the DPU switches to on the next power cycle of the server.
+	PendingMode DPUMode `json:"pendingMode,omitempty"`
+}
+
+// ServerDiscoveryPolicy defines the discovery and boot timings of a server. Unset fields fall back to the flags of
+// the manager.
+type ServerDiscoveryPolicy struct {
+	// Timeout is the time the server has to report to the registry after its discovery boot. It overrides the
+	// --discovery-timeout flag.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// MaxAttempts is the number of timed out discovery boots after which the discovery escalation starts. Zero
+	// retries forever. It overrides the --max-discovery-attempts flag.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxAttempts *int32 `json:"maxAttempts,omitempty"`
+
+	// MaxBootRetries is the number of PXE boot retries before a boot of the reserved server is considered failed.
+	// It overrides the --max-boot-retries flag.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxBootRetries *int32 `json:"maxBootRetries,omitempty"`
+
+	// PowerOnTimeout is the time to wait for the server to reach the requested power state. It overrides the
+	// --power-polling-timeout flag.
+	// +optional
+	PowerOnTimeout *metav1.Duration `json:"powerOnTimeout,omitempty"`
+
+	// RediscoveryInterval is the time after its last discovery an Available server is discovered again, e.g. to
+	// refresh its inventory and to rerun the health checks of the discovery image. Zero disables the rediscovery.
+	// It overrides the --rediscovery-interval flag.
+	// +optional
+	RediscoveryInterval *metav1.Duration `json:"rediscoveryInterval,omitempty"`
 }
 
 // ServerState defines the possible states of a server.
@@ -161,6 +487,32 @@ const (
 	StorageStateAbsent StorageState = "Absent"
 )
 
+// StorageHealth represents the health of a storage device
+type StorageHealth string
+
+const (
+	// StorageHealthOK indicates that the storage device is healthy.
+	StorageHealthOK StorageHealth = "OK"
+
+	// StorageHealthWarning indicates that the storage device requires attention, e.g. a degraded volume.
+	StorageHealthWarning StorageHealth = "Warning"
+
+	// StorageHealthCritical indicates that the storage device has failed.
+	StorageHealthCritical StorageHealth = "Critical"
+)
+
+// Architecture is the CPU architecture of a server.
+// +kubebuilder:validation:Enum=x86_64;aarch64
+type Architecture string
+
+const (
+	// ArchitectureX8664 is the 64-bit x86 architecture.
+	ArchitectureX8664 Architecture = "x86_64"
+
+	// ArchitectureAArch64 is the 64-bit ARM architecture.
+	ArchitectureAArch64 Architecture = "aarch64"
+)
+
 // ServerStatus defines the observed state of Server.
 type ServerStatus struct {
 	// Manufacturer is the name of the server manufacturer.
@@ -169,18 +521,47 @@ type ServerStatus struct {
 	// Model is the model of the server.
 	Model string `json:"model,omitempty"`
 
+	// CanonicalManufacturer is the canonical name of the server manufacturer, e.g. Dell for both "Dell Inc." and
+	// "DELL".
+	CanonicalManufacturer string `json:"canonicalManufacturer,omitempty"`
+
+	// CanonicalModel is the model of the server without the name of the manufacturer and vendor specific suffixes.
+	CanonicalModel string `json:"canonicalModel,omitempty"`
+
 	// SKU is the stock keeping unit identifier for the server.
 	SKU string `json:"sku,omitempty"`
 
 	// SerialNumber is the serial number of the server.
 	SerialNumber string `json:"serialNumber,omitempty"`
 
+	// Architecture is the CPU architecture of the server as reported by its discovery.
+	// +optional
+	Architecture Architecture `json:"architecture,omitempty"`
+
+	// BootMode is the boot mode of the server as selected by the BIOS attributes of its vendor.
+	// +optional
+	BootMode BootMode `json:"bootMode,omitempty"`
+
+	// PendingBootMode is the boot mode the server switches to on its next boot.
+	// +optional
+	PendingBootMode BootMode `json:"pendingBootMode,omitempty"`
+
+	// InBandSystemUUID is the system UUID read from the SMBIOS tables by the discovery. It differs from the
+	// systemUUID reported by the BMC e.g. after a replacement of the board.
+	// +optional
+	InBandSystemUUID string `json:"inBandSystemUUID,omitempty"`
+
 	// PowerState represents the current power state of the server.
 	PowerState ServerPowerState `json:"powerState,omitempty"`
 
 	// IndicatorLED specifies the current state of the server's indicator LED.
 	IndicatorLED IndicatorLED `json:"indicatorLED,omitempty"`
 
+	// SupportedResetTypes are the Redfish reset types the system of the server supports, e.g. ForceRestart or Nmi.
+	// It is empty if the BMC does not report them.
+	// +optional
+	SupportedResetTypes []string `json:"supportedResetTypes,omitempty"`
+
 	// State represents the current state of the server.
 	State ServerState `json:"state,omitempty"`
 
@@ -193,8 +574,95 @@ type ServerStatus struct {
 	// Storages is a list of storages associated with the server.
 	Storages []Storage `json:"storages,omitempty"`
 
+	// CPUCores is the total number of cores of the CPUs of the server.
+	CPUCores int32 `json:"cpuCores,omitempty"`
+
+	// MemoryGiB is the total system memory of the server in GiB, rounded down.
+	MemoryGiB int32 `json:"memoryGiB,omitempty"`
+
+	// DiskCount is the number of drives of the storages of the server.
+	DiskCount int32 `json:"diskCount,omitempty"`
+
+	// GPUCount is the number of GPUs of the server.
+	GPUCount int32 `json:"gpuCount,omitempty"`
+
+	// Rack is the rack the chassis of the server is placed in, as reported by its BMC.
+	Rack string `json:"rack,omitempty"`
+
+	// DPUs is a list of the DPUs of the server, which its BMC reports as systems of their own.
+	// +optional
+	DPUs []DPUStatus `json:"dpus,omitempty"`
+
+	// PowerSupplies is a list of the power supplies of the chassis of the server.
+	// +optional
+	PowerSupplies []PowerSupply `json:"powerSupplies,omitempty"`
+
+	// Fans is a list of the fans of the chassis of the server.
+	// +optional
+	Fans []Fan `json:"fans,omitempty"`
+
+	// Redundancy is a list of the redundancy groups formed by the power supplies and fans of the server.
+	// +optional
+	Redundancy []RedundancyGroup `json:"redundancy,omitempty"`
+
 	BIOS BIOSSettings `json:"BIOS,omitempty"`
 
+	// BIOSSecretVersions contains the resource versions of the Secrets whose values were last applied as
+	// BIOS settings, keyed by BIOS version and setting name.
+	// +optional
+	BIOSSecretVersions map[string]string `json:"biosSecretVersions,omitempty"`
+
+	// PendingChanges are the BIOS settings which have been set on the BMC, but take effect on the next reboot of
+	// the server, as read from the Redfish settings object of the BIOS. Settings whose pending value equals their
+	// current value are omitted, so an empty list means that a reboot does not change the BIOS settings.
+	// +optional
+	PendingChanges []PendingChange `json:"pendingChanges,omitempty"`
+
+	// BIOSPasswordVersion is the resource version of the Secret whose password was last set as BIOS password.
+	// +optional
+	BIOSPasswordVersion string `json:"biosPasswordVersion,omitempty"`
+
+	// BIOSSettingsHistory contains the latest BIOS settings changes applied to the server, oldest first.
+	// +optional
+	BIOSSettingsHistory []BIOSSettingsChange `json:"biosSettingsHistory,omitempty"`
+
+	// DiscoveryAttempts is the number of discovery boots of the server which timed out since its last
+	// successful discovery.
+	// +optional
+	DiscoveryAttempts int32 `json:"discoveryAttempts,omitempty"`
+
+	// LastDiscoveryTime is the time the last discovery of the server completed.
+	// +optional
+	LastDiscoveryTime *metav1.Time `json:"lastDiscoveryTime,omitempty"`
+
+	// BootAttempts is the number of PXE boots performed for the current reservation of the server
+	// while waiting for the operating system to come up.
+	// +optional
+	BootAttempts int32 `json:"bootAttempts,omitempty"`
+
+	// BootOverrideRetries is the number of PXE boots which were retried since the BMC of the server did not honor
+	// the PXE boot override of the previous boot.
+	// +optional
+	BootOverrideRetries int32 `json:"bootOverrideRetries,omitempty"`
+
+	// PXEBootOverrideTime is the time the operator last set a PXE boot override on the BMC of the server. Only the
+	// overrides set by the operator are verified after the boot. It is cleared once the override has been verified.
+	// +optional
+	PXEBootOverrideTime *metav1.Time `json:"pxeBootOverrideTime,omitempty"`
+
+	// ErrorDiagnostics summarizes why the server entered the Error state. It is cleared once the server leaves the
+	// Error state.
+	// +optional
+	ErrorDiagnostics *ServerErrorDiagnostics `json:"errorDiagnostics,omitempty"`
+
+	// CrashDump describes the latest crash dump requested with the CrashDump operation.
+	// +optional
+	CrashDump *ServerCrashDump `json:"crashDump,omitempty"`
+
+	// LocatedDrive describes the drive whose location indicator is turned on with the locate-drive annotation.
+	// +optional
+	LocatedDrive *LocatedDrive `json:"locatedDrive,omitempty"`
+
 	// Conditions represents the latest available observations of the server's current state.
 	// +patchStrategy=merge
 	// +patchMergeKey=type
@@ -202,6 +670,103 @@ type ServerStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
 }
 
+// CrashDumpState is the state of the collection of a crash dump.
+type CrashDumpState string
+
+const (
+	// CrashDumpStateCapturing specifies that the NMI has been sent and the dump is being captured and uploaded.
+	CrashDumpStateCapturing CrashDumpState = "Capturing"
+	// CrashDumpStateCompleted specifies that the dump has been uploaded.
+	CrashDumpStateCompleted CrashDumpState = "Completed"
+	// CrashDumpStateFailed specifies that no dump could be collected.
+	CrashDumpStateFailed CrashDumpState = "Failed"
+)
+
+// CrashDumpSource is where a crash dump is captured.
+type CrashDumpSource string
+
+const (
+	// CrashDumpSourceBMC specifies that the BMC captures the dump, which the manager uploads.
+	CrashDumpSourceBMC CrashDumpSource = "BMC"
+	// CrashDumpSourceCaptureEnvironment specifies that the server is booted into a capture environment, which
+	// uploads the dump written by the crashed operating system.
+	CrashDumpSourceCaptureEnvironment CrashDumpSource = "CaptureEnvironment"
+)
+
+// ServerCrashDump describes the collection of a crash dump of a server.
+type ServerCrashDump struct {
+	// State is the state of the collection.
+	State CrashDumpState `json:"state"`
+
+	// Source is where the dump is captured.
+	// +optional
+	Source CrashDumpSource `json:"source,omitempty"`
+
+	// StartTime is the time the NMI has been sent to the server.
+	StartTime metav1.Time `json:"startTime"`
+
+	// CompletionTime is the time the collection completed or failed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// URL is the location of the dump in the object storage.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// Message describes the result of the collection.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// PreviousBootConfigurationRef is the boot configuration of the server which is restored once the capture
+	// environment is no longer needed.
+	// +optional
+	PreviousBootConfigurationRef *v1.ObjectReference `json:"previousBootConfigurationRef,omitempty"`
+}
+
+// ServerErrorDiagnostics summarizes why a server entered the Error state.
+type ServerErrorDiagnostics struct {
+	// Reason is a CamelCase reason for the error, e.g. DiscoveryAttemptsExhausted.
+	Reason string `json:"reason"`
+
+	// Operation is the operation which failed, e.g. Discovery or FirmwareUpdate.
+	Operation string `json:"operation"`
+
+	// Message describes the error.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// PreviousState is the state the server was in before it entered the Error state.
+	// +optional
+	PreviousState ServerState `json:"previousState,omitempty"`
+
+	// Time is the time the server entered the Error state.
+	Time metav1.Time `json:"time"`
+
+	// EventLog holds the latest entries of the system event log, oldest first, at the time the diagnostics have
+	// been collected.
+	// +optional
+	EventLog []ServerEventLogEntry `json:"eventLog,omitempty"`
+
+	// BundleRef references the ConfigMap holding the diagnostic bundle of the error, with the conditions and
+	// the event log of the server at the time the diagnostics have been collected.
+	// +optional
+	BundleRef *v1.ObjectReference `json:"bundleRef,omitempty"`
+}
+
+// ServerEventLogEntry is an entry of the system event log of a server.
+type ServerEventLogEntry struct {
+	// Created is the time the entry has been created, as reported by the BMC.
+	// +optional
+	Created string `json:"created,omitempty"`
+
+	// Severity is the severity of the entry, e.g. Critical.
+	// +optional
+	Severity string `json:"severity,omitempty"`
+
+	// Message is the message of the entry.
+	Message string `json:"message"`
+}
+
 // NetworkInterface defines the details of a network interface.
 type NetworkInterface struct {
 	// Name is the name of the network interface.
@@ -231,8 +796,51 @@ type StorageDrive struct {
 	Vendor string `json:"vendor,omitempty"`
 	// Model specifies the model of the storage device.
 	Model string `json:"model,omitempty"`
+	// SerialNumber specifies the serial number of the storage device.
+	SerialNumber string `json:"serialNumber,omitempty"`
+	// FirmwareVersion specifies the firmware revision of the storage device.
+	FirmwareVersion string `json:"firmwareVersion,omitempty"`
 	// State specifies the state of the storage device.
 	State StorageState `json:"state,omitempty"`
+	// Health specifies the health of the storage device.
+	Health StorageHealth `json:"health,omitempty"`
+	// Bay specifies the physical location of the storage device in its enclosure, e.g. the service label of the
+	// drive bay.
+	Bay string `json:"bay,omitempty"`
+	// Enclosure specifies the chassis or backplane containing the storage device.
+	Enclosure *StorageEnclosure `json:"enclosure,omitempty"`
+}
+
+// LocatedDrive defines the drive whose location indicator is turned on
+type LocatedDrive struct {
+	// Name is the name of the drive.
+	Name string `json:"name"`
+	// ExpirationTime is the time the location indicator is turned off at.
+	ExpirationTime metav1.Time `json:"expirationTime"`
+}
+
+// StorageEnclosure defines the details of the chassis or backplane containing a storage drive
+type StorageEnclosure struct {
+	// Name is the name of the enclosure.
+	Name string `json:"name,omitempty"`
+	// Model specifies the model of the enclosure.
+	Model string `json:"model,omitempty"`
+	// SerialNumber specifies the serial number of the enclosure.
+	SerialNumber string `json:"serialNumber,omitempty"`
+}
+
+// StorageController defines the details of one storage controller
+type StorageController struct {
+	// Name is the name of the storage controller.
+	Name string `json:"name,omitempty"`
+	// Manufacturer specifies the manufacturer of the storage controller.
+	Manufacturer string `json:"manufacturer,omitempty"`
+	// Model specifies the model of the storage controller.
+	Model string `json:"model,omitempty"`
+	// FirmwareVersion specifies the firmware version of the storage controller.
+	FirmwareVersion string `json:"firmwareVersion,omitempty"`
+	// SerialNumber specifies the serial number of the storage controller.
+	SerialNumber string `json:"serialNumber,omitempty"`
 }
 
 // StorageVolume defines the details of one storage volume
@@ -243,6 +851,8 @@ type StorageVolume struct {
 	Capacity *resource.Quantity `json:"capacity,omitempty"`
 	// Status specifies the status of the volume.
 	State StorageState `json:"state,omitempty"`
+	// Health specifies the health of the volume, e.g. Warning while it is degraded or rebuilt.
+	Health StorageHealth `json:"health,omitempty"`
 	// RAIDType specifies the RAID type of the associated Volume.
 	RAIDType string `json:"raidType,omitempty"`
 	// VolumeUsage specifies the volume usage type for the Volume.
@@ -255,6 +865,8 @@ type Storage struct {
 	Name string `json:"name,omitempty"`
 	// State specifies the state of the storage device.
 	State StorageState `json:"state,omitempty"`
+	// Controllers is a collection of controllers of this storage.
+	Controllers []StorageController `json:"controllers,omitempty"`
 	// Volumes is a collection of volumes associated with this storage.
 	Volumes []StorageVolume `json:"volumes,omitempty"`
 	// Drives is a collection of drives associated with this storage.
@@ -272,7 +884,18 @@ type Storage struct {
 //+kubebuilder:printcolumn:name="PowerState",type=string,JSONPath=`.status.powerState`
 //+kubebuilder:printcolumn:name="IndicatorLED",type=string,JSONPath=`.status.indicatorLED`,priority=100
 //+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+//+kubebuilder:printcolumn:name="CPUCores",type=integer,JSONPath=`.status.cpuCores`,priority=100
+//+kubebuilder:printcolumn:name="MemoryGiB",type=integer,JSONPath=`.status.memoryGiB`,priority=100
+//+kubebuilder:printcolumn:name="Disks",type=integer,JSONPath=`.status.diskCount`,priority=100
+//+kubebuilder:printcolumn:name="GPUs",type=integer,JSONPath=`.status.gpuCount`,priority=100
+//+kubebuilder:printcolumn:name="Rack",type=string,JSONPath=`.status.rack`,priority=100
 //+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+//+kubebuilder:selectablefield:JSONPath=`.status.state`
+//+kubebuilder:selectablefield:JSONPath=`.status.cpuCores`
+//+kubebuilder:selectablefield:JSONPath=`.status.memoryGiB`
+//+kubebuilder:selectablefield:JSONPath=`.status.diskCount`
+//+kubebuilder:selectablefield:JSONPath=`.status.gpuCount`
+//+kubebuilder:selectablefield:JSONPath=`.status.rack`
 
 // Server is the Schema for the servers API
 type Server struct {
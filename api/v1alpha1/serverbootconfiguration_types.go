@@ -20,6 +20,51 @@ type ServerBootConfigurationSpec struct {
 	// IgnitionSecretRef is a reference to the Kubernetes Secret object that contains
 	// the ignition configuration for the server. This field is optional and can be omitted if not specified.
 	IgnitionSecretRef *v1.LocalObjectReference `json:"ignitionSecretRef,omitempty"`
+
+	// SANBoot configures the server to boot from a storage area network instead of PXE.
+	// +optional
+	SANBoot *SANBootConfiguration `json:"sanBoot,omitempty"`
+}
+
+// SANBootConfiguration defines how a server boots from a storage area network.
+type SANBootConfiguration struct {
+	// ISCSI configures the server to boot from an iSCSI target.
+	// +optional
+	ISCSI *ISCSIBootConfiguration `json:"iscsi,omitempty"`
+}
+
+// ISCSIBootConfiguration defines the iSCSI initiator and target used to boot a server.
+type ISCSIBootConfiguration struct {
+	// NetworkDeviceFunction is the ID of the Redfish network device function used as initiator.
+	// If omitted, the first network device function supporting iSCSI is used.
+	// +optional
+	NetworkDeviceFunction string `json:"networkDeviceFunction,omitempty"`
+
+	// InitiatorName is the iSCSI qualified name of the initiator.
+	// +required
+	InitiatorName string `json:"initiatorName"`
+
+	// TargetName is the iSCSI qualified name of the target.
+	// +required
+	TargetName string `json:"targetName"`
+
+	// TargetAddress is the IP address of the target.
+	// +required
+	TargetAddress string `json:"targetAddress"`
+
+	// TargetPort is the TCP port of the target.
+	// +kubebuilder:default=3260
+	// +optional
+	TargetPort int32 `json:"targetPort,omitempty"`
+
+	// LUN is the logical unit number to boot from.
+	// +optional
+	LUN int32 `json:"lun,omitempty"`
+
+	// CHAPSecretRef is a reference to a Secret in the namespace of the boot configuration containing the
+	// keys username and password used for CHAP authentication. If omitted, no authentication is used.
+	// +optional
+	CHAPSecretRef *v1.LocalObjectReference `json:"chapSecretRef,omitempty"`
 }
 
 // ServerBootConfigurationState defines the possible states of a ServerBootConfiguration.
@@ -40,6 +85,17 @@ const (
 type ServerBootConfigurationStatus struct {
 	// State represents the current state of the boot configuration.
 	State ServerBootConfigurationState `json:"state,omitempty"`
+
+	// Architecture is the CPU architecture of the server. Boot operators select the kernel, initrd and image
+	// matching the architecture from multi-architecture images.
+	// +optional
+	Architecture Architecture `json:"architecture,omitempty"`
+
+	// ObservedIgnitionHash is the value of the metal.ironcore.dev/ignition-hash annotation the boot operator has
+	// rendered the configuration with. Servers are only restarted for a changed ignition once it matches the
+	// annotation, so that they do not boot the previous rendering.
+	// +optional
+	ObservedIgnitionHash string `json:"observedIgnitionHash,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -47,6 +103,7 @@ type ServerBootConfigurationStatus struct {
 //+kubebuilder:printcolumn:name="ServerRef",type=string,JSONPath=`.spec.serverRef.name`
 //+kubebuilder:printcolumn:name="Image",type=string,JSONPath=`.spec.image`
 //+kubebuilder:printcolumn:name="IgnitionRef",type=string,JSONPath=`.spec.ignitionSecretRef.name`
+//+kubebuilder:printcolumn:name="Architecture",type=string,JSONPath=`.status.architecture`,priority=1
 //+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
 //+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
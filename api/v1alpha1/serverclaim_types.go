@@ -31,10 +31,34 @@ type ServerClaimSpec struct {
 	// the ignition configuration for the server. This field is optional and can be omitted if not specified.
 	IgnitionSecretRef *v1.LocalObjectReference `json:"ignitionSecretRef,omitempty"`
 
+	// IgnitionUpdatePolicy defines how a bound server picks up changes of the ignition. The boot configuration is
+	// re-rendered on every change, the policy decides whether the server is restarted to boot it.
+	// +kubebuilder:default=None
+	// +optional
+	IgnitionUpdatePolicy IgnitionUpdatePolicy `json:"ignitionUpdatePolicy,omitempty"`
+
 	// Image specifies the boot image to be used for the server.
 	Image string `json:"image"`
+
+	// SANBoot configures the server to boot from a storage area network instead of PXE.
+	// +optional
+	SANBoot *SANBootConfiguration `json:"sanBoot,omitempty"`
 }
 
+// IgnitionUpdatePolicy defines how a bound server picks up changes of the ignition of its claim.
+// +kubebuilder:validation:Enum=None;Reboot;Reprovision
+type IgnitionUpdatePolicy string
+
+const (
+	// IgnitionUpdatePolicyNone leaves the server running. The changed ignition is booted on the next restart.
+	IgnitionUpdatePolicyNone IgnitionUpdatePolicy = "None"
+	// IgnitionUpdatePolicyReboot gracefully restarts the server once the boot configuration has been re-rendered.
+	IgnitionUpdatePolicyReboot IgnitionUpdatePolicy = "Reboot"
+	// IgnitionUpdatePolicyReprovision restarts the server into a PXE boot once the boot configuration has been
+	// re-rendered.
+	IgnitionUpdatePolicyReprovision IgnitionUpdatePolicy = "Reprovision"
+)
+
 // Phase defines the possible phases of a ServerClaim.
 type Phase string
 
@@ -50,6 +74,18 @@ const (
 type ServerClaimStatus struct {
 	// Phase represents the current phase of the server claim.
 	Phase Phase `json:"phase,omitempty"`
+
+	// ImageDigest is the digest the image of the claim has been resolved to before binding.
+	ImageDigest string `json:"imageDigest,omitempty"`
+
+	// IgnitionHash is the hash of the ignition the boot configuration of the claim has last been rendered with.
+	IgnitionHash string `json:"ignitionHash,omitempty"`
+
+	// Conditions represents the latest available observations of the server claim's current state.
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServerGrantSpec defines the desired state of ServerGrant.
+type ServerGrantSpec struct {
+	// GranteeNamespace is the namespace whose ServerClaims may claim the granted servers.
+	// +required
+	GranteeNamespace string `json:"granteeNamespace"`
+
+	// Servers are the servers of the pool which are granted. If empty, all servers of the pool matching the
+	// serverSelector are granted.
+	// +optional
+	Servers []v1.LocalObjectReference `json:"servers,omitempty"`
+
+	// ServerSelector specifies a label selector to identify the servers of the pool which are granted. If empty,
+	// all servers of the pool listed in servers are granted.
+	// +optional
+	ServerSelector *metav1.LabelSelector `json:"serverSelector,omitempty"`
+
+	// MaxServers is the maximum number of granted servers claimed by the grantee namespace at the same time. If
+	// unset, the number is not limited.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxServers *int32 `json:"maxServers,omitempty"`
+
+	// Duration is the time the grant is valid after its creation. If unset, the grant does not expire.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// AllowedImages are the registries and repositories the ServerClaims of the grantee namespace may boot images
+	// from on the granted servers, e.g. "registry.example.com/os". They are matched like the allowed images of an
+	// ImagePolicy. If empty, any image may be booted.
+	// +optional
+	AllowedImages []string `json:"allowedImages,omitempty"`
+}
+
+// ServerGrantState defines the possible states of a ServerGrant.
+type ServerGrantState string
+
+const (
+	// ServerGrantStateActive indicates that the granted servers may be claimed by the grantee namespace.
+	ServerGrantStateActive ServerGrantState = "Active"
+	// ServerGrantStateExpired indicates that the duration of the grant has passed.
+	ServerGrantStateExpired ServerGrantState = "Expired"
+)
+
+// ServerGrantStatus defines the observed state of ServerGrant.
+type ServerGrantStatus struct {
+	// State represents the current state of the grant.
+	State ServerGrantState `json:"state,omitempty"`
+
+	// ExpirationTime is the time the grant expires at.
+	// +optional
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+
+	// ClaimedServers are the granted servers claimed by the grantee namespace.
+	// +optional
+	ClaimedServers []v1.LocalObjectReference `json:"claimedServers,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Grantee",type="string",JSONPath=".spec.granteeNamespace"
+// +kubebuilder:printcolumn:name="MaxServers",type="integer",JSONPath=".spec.maxServers"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Expiration",type="date",JSONPath=".status.expirationTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ServerGrant is the Schema for the servergrants API. It is created in the namespace owning a pool of servers and
+// delegates the right to claim servers of the pool to another namespace.
+type ServerGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ServerGrantSpec   `json:"spec,omitempty"`
+	Status ServerGrantStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ServerGrantList contains a list of ServerGrant
+type ServerGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServerGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ServerGrant{}, &ServerGrantList{})
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServerReservationSpec defines the desired state of ServerReservation.
+type ServerReservationSpec struct {
+	// Replicas is the number of servers held by the reservation.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="replicas is immutable"
+	// +required
+	Replicas int32 `json:"replicas"`
+
+	// ServerSelector specifies a label selector to identify the servers to be held. If empty, any available server
+	// may be held.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="serverSelector is immutable"
+	ServerSelector *metav1.LabelSelector `json:"serverSelector,omitempty"`
+
+	// StartTime is the start of the window the servers are reserved for. The held servers are claimed with
+	// ServerClaims created from the template at this time.
+	// +required
+	StartTime metav1.Time `json:"startTime"`
+
+	// Template is the spec of the ServerClaims created for the held servers. Its serverRef is set for each server.
+	// +required
+	Template ServerClaimSpec `json:"template"`
+}
+
+// ServerReservationState defines the possible states of a ServerReservation.
+type ServerReservationState string
+
+const (
+	// ServerReservationStatePending indicates that the servers have not been held yet, as too few are available.
+	ServerReservationStatePending ServerReservationState = "Pending"
+	// ServerReservationStateHeld indicates that the servers are held until the start of the window.
+	ServerReservationStateHeld ServerReservationState = "Held"
+	// ServerReservationStateClaimed indicates that the held servers have been claimed at the start of the window.
+	ServerReservationStateClaimed ServerReservationState = "Claimed"
+)
+
+// ServerReservationStatus defines the observed state of ServerReservation.
+type ServerReservationStatus struct {
+	// State represents the current state of the reservation.
+	State ServerReservationState `json:"state,omitempty"`
+
+	// Servers are the servers held by the reservation.
+	// +optional
+	Servers []v1.LocalObjectReference `json:"servers,omitempty"`
+
+	// ClaimRefs are the ServerClaims created for the held servers at the start of the window.
+	// +optional
+	ClaimRefs []v1.LocalObjectReference `json:"claimRefs,omitempty"`
+
+	// Conditions represents the latest available observations of the reservation's current state.
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".spec.replicas"
+// +kubebuilder:printcolumn:name="Start",type="date",JSONPath=".spec.startTime"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ServerReservation is the Schema for the serverreservations API. It holds servers for a future window, so that
+// no other claim takes them, and claims them at the start of the window.
+type ServerReservation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ServerReservationSpec   `json:"spec,omitempty"`
+	Status ServerReservationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ServerReservationList contains a list of ServerReservation
+type ServerReservationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServerReservation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ServerReservation{}, &ServerReservationList{})
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServerSetClaimSpec defines the desired state of ServerSetClaim.
+type ServerSetClaimSpec struct {
+	// Replicas is the number of servers claimed together.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="replicas is immutable"
+	// +required
+	Replicas int32 `json:"replicas"`
+
+	// ServerSelector specifies a label selector to identify the servers to be claimed. If empty, any available
+	// server may be claimed.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="serverSelector is immutable"
+	ServerSelector *metav1.LabelSelector `json:"serverSelector,omitempty"`
+
+	// Template is the spec of the ServerClaims of the members. Its serverRef and serverSelector are set for each
+	// member.
+	// +required
+	Template ServerClaimSpec `json:"template"`
+}
+
+// ServerSetClaimMember is a ServerClaim of a ServerSetClaim.
+type ServerSetClaimMember struct {
+	// ClaimName is the name of the ServerClaim of the member.
+	ClaimName string `json:"claimName"`
+
+	// ServerRef is a reference to the server claimed by the member.
+	// +optional
+	ServerRef *v1.LocalObjectReference `json:"serverRef,omitempty"`
+
+	// Phase is the phase of the ServerClaim of the member.
+	// +optional
+	Phase Phase `json:"phase,omitempty"`
+}
+
+// ServerSetClaimStatus defines the observed state of ServerSetClaim.
+type ServerSetClaimStatus struct {
+	// Phase is Bound once the ServerClaims of all members are bound.
+	Phase Phase `json:"phase,omitempty"`
+
+	// BoundReplicas is the number of members whose ServerClaim is bound.
+	BoundReplicas int32 `json:"boundReplicas,omitempty"`
+
+	// Members are the ServerClaims of the claimed servers.
+	// +optional
+	Members []ServerSetClaimMember `json:"members,omitempty"`
+
+	// Conditions represents the latest available observations of the server set claim's current state.
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".spec.replicas"
+// +kubebuilder:printcolumn:name="Bound",type="integer",JSONPath=".status.boundReplicas"
+// +kubebuilder:printcolumn:name="Image",type="string",JSONPath=".spec.template.image"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ServerSetClaim is the Schema for the serversetclaims API. It claims a number of servers at once, all or none.
+type ServerSetClaim struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ServerSetClaimSpec   `json:"spec,omitempty"`
+	Status ServerSetClaimStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ServerSetClaimList contains a list of ServerSetClaim
+type ServerSetClaimList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServerSetClaim `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ServerSetClaim{}, &ServerSetClaimList{})
+}
@@ -8,11 +8,67 @@
 package v1alpha1
 
 import (
-	v1 "k8s.io/api/core/v1"
+	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BIOSPassword) DeepCopyInto(out *BIOSPassword) {
+	*out = *in
+	out.SecretKeyRef = in.SecretKeyRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BIOSPassword.
+func (in *BIOSPassword) DeepCopy() *BIOSPassword {
+	if in == nil {
+		return nil
+	}
+	out := new(BIOSPassword)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BIOSSettingChange) DeepCopyInto(out *BIOSSettingChange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BIOSSettingChange.
+func (in *BIOSSettingChange) DeepCopy() *BIOSSettingChange {
+	if in == nil {
+		return nil
+	}
+	out := new(BIOSSettingChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BIOSSettingSource) DeepCopyInto(out *BIOSSettingSource) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(ObjectKeySelector)
+		**out = **in
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(ObjectKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BIOSSettingSource.
+func (in *BIOSSettingSource) DeepCopy() *BIOSSettingSource {
+	if in == nil {
+		return nil
+	}
+	out := new(BIOSSettingSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BIOSSettings) DeepCopyInto(out *BIOSSettings) {
 	*out = *in
@@ -23,6 +79,13 @@ func (in *BIOSSettings) DeepCopyInto(out *BIOSSettings) {
 			(*out)[key] = val
 		}
 	}
+	if in.SettingsFrom != nil {
+		in, out := &in.SettingsFrom, &out.SettingsFrom
+		*out = make([]BIOSSettingSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BIOSSettings.
@@ -35,6 +98,27 @@ func (in *BIOSSettings) DeepCopy() *BIOSSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BIOSSettingsChange) DeepCopyInto(out *BIOSSettingsChange) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = make([]BIOSSettingChange, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BIOSSettingsChange.
+func (in *BIOSSettingsChange) DeepCopy() *BIOSSettingsChange {
+	if in == nil {
+		return nil
+	}
+	out := new(BIOSSettingsChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BMC) DeepCopyInto(out *BMC) {
 	*out = *in
@@ -79,6 +163,31 @@ func (in *BMCAccess) DeepCopy() *BMCAccess {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BMCForwarding) DeepCopyInto(out *BMCForwarding) {
+	*out = *in
+	if in.SNMPTrapDestinations != nil {
+		in, out := &in.SNMPTrapDestinations, &out.SNMPTrapDestinations
+		*out = make([]SNMPTrapDestination, len(*in))
+		copy(*out, *in)
+	}
+	if in.SyslogTargets != nil {
+		in, out := &in.SyslogTargets, &out.SyslogTargets
+		*out = make([]SyslogTarget, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BMCForwarding.
+func (in *BMCForwarding) DeepCopy() *BMCForwarding {
+	if in == nil {
+		return nil
+	}
+	out := new(BMCForwarding)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BMCList) DeepCopyInto(out *BMCList) {
 	*out = *in
@@ -144,6 +253,11 @@ func (in *BMCSecret) DeepCopyInto(out *BMCSecret) {
 			(*out)[key] = val
 		}
 	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BMCSecret.
@@ -216,6 +330,16 @@ func (in *BMCSpec) DeepCopyInto(out *BMCSpec) {
 		*out = new(ConsoleProtocol)
 		**out = **in
 	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(BMCTimeouts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Forwarding != nil {
+		in, out := &in.Forwarding, &out.Forwarding
+		*out = new(BMCForwarding)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BMCSpec.
@@ -252,56 +376,61 @@ func (in *BMCStatus) DeepCopy() *BMCStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BootOrder) DeepCopyInto(out *BootOrder) {
+func (in *BMCTimeouts) DeepCopyInto(out *BMCTimeouts) {
 	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootOrder.
-func (in *BootOrder) DeepCopy() *BootOrder {
-	if in == nil {
-		return nil
+	if in.Login != nil {
+		in, out := &in.Login, &out.Login
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.FirmwareUpload != nil {
+		in, out := &in.FirmwareUpload, &out.FirmwareUpload
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.SettingsApply != nil {
+		in, out := &in.SettingsApply, &out.SettingsApply
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TaskPolling != nil {
+		in, out := &in.TaskPolling, &out.TaskPolling
+		*out = new(metav1.Duration)
+		**out = **in
 	}
-	out := new(BootOrder)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ConsoleProtocol) DeepCopyInto(out *ConsoleProtocol) {
-	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsoleProtocol.
-func (in *ConsoleProtocol) DeepCopy() *ConsoleProtocol {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BMCTimeouts.
+func (in *BMCTimeouts) DeepCopy() *BMCTimeouts {
 	if in == nil {
 		return nil
 	}
-	out := new(ConsoleProtocol)
+	out := new(BMCTimeouts)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Endpoint) DeepCopyInto(out *Endpoint) {
+func (in *BoardReplacement) DeepCopyInto(out *BoardReplacement) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Endpoint.
-func (in *Endpoint) DeepCopy() *Endpoint {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BoardReplacement.
+func (in *BoardReplacement) DeepCopy() *BoardReplacement {
 	if in == nil {
 		return nil
 	}
-	out := new(Endpoint)
+	out := new(BoardReplacement)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Endpoint) DeepCopyObject() runtime.Object {
+func (in *BoardReplacement) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -309,31 +438,31 @@ func (in *Endpoint) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EndpointList) DeepCopyInto(out *EndpointList) {
+func (in *BoardReplacementList) DeepCopyInto(out *BoardReplacementList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Endpoint, len(*in))
+		*out = make([]BoardReplacement, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointList.
-func (in *EndpointList) DeepCopy() *EndpointList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BoardReplacementList.
+func (in *BoardReplacementList) DeepCopy() *BoardReplacementList {
 	if in == nil {
 		return nil
 	}
-	out := new(EndpointList)
+	out := new(BoardReplacementList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *EndpointList) DeepCopyObject() runtime.Object {
+func (in *BoardReplacementList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -341,85 +470,64 @@ func (in *EndpointList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EndpointSpec) DeepCopyInto(out *EndpointSpec) {
+func (in *BoardReplacementSpec) DeepCopyInto(out *BoardReplacementSpec) {
 	*out = *in
-	in.IP.DeepCopyInto(&out.IP)
+	out.ServerRef = in.ServerRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointSpec.
-func (in *EndpointSpec) DeepCopy() *EndpointSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BoardReplacementSpec.
+func (in *BoardReplacementSpec) DeepCopy() *BoardReplacementSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(EndpointSpec)
+	out := new(BoardReplacementSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EndpointStatus) DeepCopyInto(out *EndpointStatus) {
+func (in *BoardReplacementStatus) DeepCopyInto(out *BoardReplacementStatus) {
 	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointStatus.
-func (in *EndpointStatus) DeepCopy() *EndpointStatus {
-	if in == nil {
-		return nil
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
 	}
-	out := new(EndpointStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InlineEndpoint) DeepCopyInto(out *InlineEndpoint) {
-	*out = *in
-	in.IP.DeepCopyInto(&out.IP)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InlineEndpoint.
-func (in *InlineEndpoint) DeepCopy() *InlineEndpoint {
-	if in == nil {
-		return nil
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	out := new(InlineEndpoint)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkInterface) DeepCopyInto(out *NetworkInterface) {
-	*out = *in
-	in.IP.DeepCopyInto(&out.IP)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInterface.
-func (in *NetworkInterface) DeepCopy() *NetworkInterface {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BoardReplacementStatus.
+func (in *BoardReplacementStatus) DeepCopy() *BoardReplacementStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkInterface)
+	out := new(BoardReplacementStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Protocol) DeepCopyInto(out *Protocol) {
+func (in *BootOrder) DeepCopyInto(out *BootOrder) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Protocol.
-func (in *Protocol) DeepCopy() *Protocol {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootOrder.
+func (in *BootOrder) DeepCopy() *BootOrder {
 	if in == nil {
 		return nil
 	}
-	out := new(Protocol)
+	out := new(BootOrder)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Server) DeepCopyInto(out *Server) {
+func (in *ComponentFirmware) DeepCopyInto(out *ComponentFirmware) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -427,18 +535,18 @@ func (in *Server) DeepCopyInto(out *Server) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Server.
-func (in *Server) DeepCopy() *Server {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentFirmware.
+func (in *ComponentFirmware) DeepCopy() *ComponentFirmware {
 	if in == nil {
 		return nil
 	}
-	out := new(Server)
+	out := new(ComponentFirmware)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Server) DeepCopyObject() runtime.Object {
+func (in *ComponentFirmware) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -446,26 +554,31 @@ func (in *Server) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServerBootConfiguration) DeepCopyInto(out *ServerBootConfiguration) {
+func (in *ComponentFirmwareList) DeepCopyInto(out *ComponentFirmwareList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ComponentFirmware, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerBootConfiguration.
-func (in *ServerBootConfiguration) DeepCopy() *ServerBootConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentFirmwareList.
+func (in *ComponentFirmwareList) DeepCopy() *ComponentFirmwareList {
 	if in == nil {
 		return nil
 	}
-	out := new(ServerBootConfiguration)
+	out := new(ComponentFirmwareList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ServerBootConfiguration) DeepCopyObject() runtime.Object {
+func (in *ComponentFirmwareList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -473,94 +586,114 @@ func (in *ServerBootConfiguration) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServerBootConfigurationList) DeepCopyInto(out *ServerBootConfigurationList) {
+func (in *ComponentFirmwareProgress) DeepCopyInto(out *ComponentFirmwareProgress) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ServerBootConfiguration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerBootConfigurationList.
-func (in *ServerBootConfigurationList) DeepCopy() *ServerBootConfigurationList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentFirmwareProgress.
+func (in *ComponentFirmwareProgress) DeepCopy() *ComponentFirmwareProgress {
 	if in == nil {
 		return nil
 	}
-	out := new(ServerBootConfigurationList)
+	out := new(ComponentFirmwareProgress)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ServerBootConfigurationList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentFirmwareSpec) DeepCopyInto(out *ComponentFirmwareSpec) {
+	*out = *in
+	out.ServerRef = in.ServerRef
+	out.Component = in.Component
+	out.Image = in.Image
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
 	}
-	return nil
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentFirmwareSpec.
+func (in *ComponentFirmwareSpec) DeepCopy() *ComponentFirmwareSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentFirmwareSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServerBootConfigurationSpec) DeepCopyInto(out *ServerBootConfigurationSpec) {
+func (in *ComponentFirmwareStatus) DeepCopyInto(out *ComponentFirmwareStatus) {
 	*out = *in
-	out.ServerRef = in.ServerRef
-	if in.IgnitionSecretRef != nil {
-		in, out := &in.IgnitionSecretRef, &out.IgnitionSecretRef
-		*out = new(v1.LocalObjectReference)
-		**out = **in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]ComponentFirmwareProgress, len(*in))
+		copy(*out, *in)
+	}
+	if in.ActivationTime != nil {
+		in, out := &in.ActivationTime, &out.ActivationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerBootConfigurationSpec.
-func (in *ServerBootConfigurationSpec) DeepCopy() *ServerBootConfigurationSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentFirmwareStatus.
+func (in *ComponentFirmwareStatus) DeepCopy() *ComponentFirmwareStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ServerBootConfigurationSpec)
+	out := new(ComponentFirmwareStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServerBootConfigurationStatus) DeepCopyInto(out *ServerBootConfigurationStatus) {
+func (in *ComponentSelector) DeepCopyInto(out *ComponentSelector) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerBootConfigurationStatus.
-func (in *ServerBootConfigurationStatus) DeepCopy() *ServerBootConfigurationStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentSelector.
+func (in *ComponentSelector) DeepCopy() *ComponentSelector {
 	if in == nil {
 		return nil
 	}
-	out := new(ServerBootConfigurationStatus)
+	out := new(ComponentSelector)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServerClaim) DeepCopyInto(out *ServerClaim) {
+func (in *ComposedServer) DeepCopyInto(out *ComposedServer) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerClaim.
-func (in *ServerClaim) DeepCopy() *ServerClaim {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposedServer.
+func (in *ComposedServer) DeepCopy() *ComposedServer {
 	if in == nil {
 		return nil
 	}
-	out := new(ServerClaim)
+	out := new(ComposedServer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ServerClaim) DeepCopyObject() runtime.Object {
+func (in *ComposedServer) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -568,31 +701,31 @@ func (in *ServerClaim) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServerClaimList) DeepCopyInto(out *ServerClaimList) {
+func (in *ComposedServerList) DeepCopyInto(out *ComposedServerList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ServerClaim, len(*in))
+		*out = make([]ComposedServer, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerClaimList.
-func (in *ServerClaimList) DeepCopy() *ServerClaimList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposedServerList.
+func (in *ComposedServerList) DeepCopy() *ComposedServerList {
 	if in == nil {
 		return nil
 	}
-	out := new(ServerClaimList)
+	out := new(ComposedServerList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ServerClaimList) DeepCopyObject() runtime.Object {
+func (in *ComposedServerList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -600,7 +733,1335 @@ func (in *ServerClaimList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServerClaimSpec) DeepCopyInto(out *ServerClaimSpec) {
+func (in *ComposedServerSpec) DeepCopyInto(out *ComposedServerSpec) {
+	*out = *in
+	out.BMCRef = in.BMCRef
+	if in.ResourceBlocks != nil {
+		in, out := &in.ResourceBlocks, &out.ResourceBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposedServerSpec.
+func (in *ComposedServerSpec) DeepCopy() *ComposedServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComposedServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComposedServerStatus) DeepCopyInto(out *ComposedServerStatus) {
+	*out = *in
+	if in.ServerRef != nil {
+		in, out := &in.ServerRef, &out.ServerRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposedServerStatus.
+func (in *ComposedServerStatus) DeepCopy() *ComposedServerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComposedServerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsoleProtocol) DeepCopyInto(out *ConsoleProtocol) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsoleProtocol.
+func (in *ConsoleProtocol) DeepCopy() *ConsoleProtocol {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsoleProtocol)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialAttempt) DeepCopyInto(out *CredentialAttempt) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialAttempt.
+func (in *CredentialAttempt) DeepCopy() *CredentialAttempt {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialAttempt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DPUSpec) DeepCopyInto(out *DPUSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DPUSpec.
+func (in *DPUSpec) DeepCopy() *DPUSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DPUSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DPUStatus) DeepCopyInto(out *DPUStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DPUStatus.
+func (in *DPUStatus) DeepCopy() *DPUStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DPUStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriveFirmware) DeepCopyInto(out *DriveFirmware) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriveFirmware.
+func (in *DriveFirmware) DeepCopy() *DriveFirmware {
+	if in == nil {
+		return nil
+	}
+	out := new(DriveFirmware)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DriveFirmware) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriveFirmwareList) DeepCopyInto(out *DriveFirmwareList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DriveFirmware, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriveFirmwareList.
+func (in *DriveFirmwareList) DeepCopy() *DriveFirmwareList {
+	if in == nil {
+		return nil
+	}
+	out := new(DriveFirmwareList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DriveFirmwareList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriveFirmwareProgress) DeepCopyInto(out *DriveFirmwareProgress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriveFirmwareProgress.
+func (in *DriveFirmwareProgress) DeepCopy() *DriveFirmwareProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(DriveFirmwareProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriveFirmwareSpec) DeepCopyInto(out *DriveFirmwareSpec) {
+	*out = *in
+	out.ServerRef = in.ServerRef
+	out.Image = in.Image
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriveFirmwareSpec.
+func (in *DriveFirmwareSpec) DeepCopy() *DriveFirmwareSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DriveFirmwareSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriveFirmwareStatus) DeepCopyInto(out *DriveFirmwareStatus) {
+	*out = *in
+	if in.Drives != nil {
+		in, out := &in.Drives, &out.Drives
+		*out = make([]DriveFirmwareProgress, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriveFirmwareStatus.
+func (in *DriveFirmwareStatus) DeepCopy() *DriveFirmwareStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DriveFirmwareStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriveReplacement) DeepCopyInto(out *DriveReplacement) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriveReplacement.
+func (in *DriveReplacement) DeepCopy() *DriveReplacement {
+	if in == nil {
+		return nil
+	}
+	out := new(DriveReplacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DriveReplacement) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriveReplacementList) DeepCopyInto(out *DriveReplacementList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DriveReplacement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriveReplacementList.
+func (in *DriveReplacementList) DeepCopy() *DriveReplacementList {
+	if in == nil {
+		return nil
+	}
+	out := new(DriveReplacementList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DriveReplacementList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriveReplacementSpec) DeepCopyInto(out *DriveReplacementSpec) {
+	*out = *in
+	out.ServerRef = in.ServerRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriveReplacementSpec.
+func (in *DriveReplacementSpec) DeepCopy() *DriveReplacementSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DriveReplacementSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriveReplacementStatus) DeepCopyInto(out *DriveReplacementStatus) {
+	*out = *in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriveReplacementStatus.
+func (in *DriveReplacementStatus) DeepCopy() *DriveReplacementStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DriveReplacementStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Endpoint) DeepCopyInto(out *Endpoint) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Endpoint.
+func (in *Endpoint) DeepCopy() *Endpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(Endpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Endpoint) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointList) DeepCopyInto(out *EndpointList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Endpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointList.
+func (in *EndpointList) DeepCopy() *EndpointList {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EndpointList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointSpec) DeepCopyInto(out *EndpointSpec) {
+	*out = *in
+	in.IP.DeepCopyInto(&out.IP)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointSpec.
+func (in *EndpointSpec) DeepCopy() *EndpointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointStatus) DeepCopyInto(out *EndpointStatus) {
+	*out = *in
+	if in.CredentialAttempts != nil {
+		in, out := &in.CredentialAttempts, &out.CredentialAttempts
+		*out = make([]CredentialAttempt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointStatus.
+func (in *EndpointStatus) DeepCopy() *EndpointStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailingBMC) DeepCopyInto(out *FailingBMC) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailingBMC.
+func (in *FailingBMC) DeepCopy() *FailingBMC {
+	if in == nil {
+		return nil
+	}
+	out := new(FailingBMC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Fan) DeepCopyInto(out *Fan) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Fan.
+func (in *Fan) DeepCopy() *Fan {
+	if in == nil {
+		return nil
+	}
+	out := new(Fan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirmwareCampaign) DeepCopyInto(out *FirmwareCampaign) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirmwareCampaign.
+func (in *FirmwareCampaign) DeepCopy() *FirmwareCampaign {
+	if in == nil {
+		return nil
+	}
+	out := new(FirmwareCampaign)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FirmwareCampaign) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirmwareCampaignList) DeepCopyInto(out *FirmwareCampaignList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FirmwareCampaign, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirmwareCampaignList.
+func (in *FirmwareCampaignList) DeepCopy() *FirmwareCampaignList {
+	if in == nil {
+		return nil
+	}
+	out := new(FirmwareCampaignList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FirmwareCampaignList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirmwareCampaignSpec) DeepCopyInto(out *FirmwareCampaignSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirmwareCampaignSpec.
+func (in *FirmwareCampaignSpec) DeepCopy() *FirmwareCampaignSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FirmwareCampaignSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirmwareCampaignStatus) DeepCopyInto(out *FirmwareCampaignStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Updates != nil {
+		in, out := &in.Updates, &out.Updates
+		*out = make([]FirmwareCampaignUpdate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirmwareCampaignStatus.
+func (in *FirmwareCampaignStatus) DeepCopy() *FirmwareCampaignStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FirmwareCampaignStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirmwareCampaignUpdate) DeepCopyInto(out *FirmwareCampaignUpdate) {
+	*out = *in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirmwareCampaignUpdate.
+func (in *FirmwareCampaignUpdate) DeepCopy() *FirmwareCampaignUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(FirmwareCampaignUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirmwareImage) DeepCopyInto(out *FirmwareImage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirmwareImage.
+func (in *FirmwareImage) DeepCopy() *FirmwareImage {
+	if in == nil {
+		return nil
+	}
+	out := new(FirmwareImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetReport) DeepCopyInto(out *FleetReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetReport.
+func (in *FleetReport) DeepCopy() *FleetReport {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FleetReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetReportList) DeepCopyInto(out *FleetReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FleetReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetReportList.
+func (in *FleetReportList) DeepCopy() *FleetReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FleetReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetReportSpec) DeepCopyInto(out *FleetReportSpec) {
+	*out = *in
+	if in.ServerSelector != nil {
+		in, out := &in.ServerSelector, &out.ServerSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetReportSpec.
+func (in *FleetReportSpec) DeepCopy() *FleetReportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetReportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetReportStatus) DeepCopyInto(out *FleetReportStatus) {
+	*out = *in
+	if in.ServersByState != nil {
+		in, out := &in.ServersByState, &out.ServersByState
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.BIOSVersions != nil {
+		in, out := &in.BIOSVersions, &out.BIOSVersions
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.BMCFirmwareVersions != nil {
+		in, out := &in.BMCFirmwareVersions, &out.BMCFirmwareVersions
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.FailingBMCs != nil {
+		in, out := &in.FailingBMCs, &out.FailingBMCs
+		*out = make([]FailingBMC, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetReportStatus.
+func (in *FleetReportStatus) DeepCopy() *FleetReportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetReportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ISCSIBootConfiguration) DeepCopyInto(out *ISCSIBootConfiguration) {
+	*out = *in
+	if in.CHAPSecretRef != nil {
+		in, out := &in.CHAPSecretRef, &out.CHAPSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ISCSIBootConfiguration.
+func (in *ISCSIBootConfiguration) DeepCopy() *ISCSIBootConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ISCSIBootConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePolicy) DeepCopyInto(out *ImagePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePolicy.
+func (in *ImagePolicy) DeepCopy() *ImagePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImagePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePolicyList) DeepCopyInto(out *ImagePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ImagePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePolicyList.
+func (in *ImagePolicyList) DeepCopy() *ImagePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImagePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePolicySpec) DeepCopyInto(out *ImagePolicySpec) {
+	*out = *in
+	if in.AllowedImages != nil {
+		in, out := &in.AllowedImages, &out.AllowedImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePolicySpec.
+func (in *ImagePolicySpec) DeepCopy() *ImagePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InlineEndpoint) DeepCopyInto(out *InlineEndpoint) {
+	*out = *in
+	in.IP.DeepCopyInto(&out.IP)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InlineEndpoint.
+func (in *InlineEndpoint) DeepCopy() *InlineEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(InlineEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocatedDrive) DeepCopyInto(out *LocatedDrive) {
+	*out = *in
+	in.ExpirationTime.DeepCopyInto(&out.ExpirationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocatedDrive.
+func (in *LocatedDrive) DeepCopy() *LocatedDrive {
+	if in == nil {
+		return nil
+	}
+	out := new(LocatedDrive)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkBootInterface) DeepCopyInto(out *NetworkBootInterface) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkBootInterface.
+func (in *NetworkBootInterface) DeepCopy() *NetworkBootInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkBootInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkInterface) DeepCopyInto(out *NetworkInterface) {
+	*out = *in
+	in.IP.DeepCopyInto(&out.IP)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInterface.
+func (in *NetworkInterface) DeepCopy() *NetworkInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectKeySelector) DeepCopyInto(out *ObjectKeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectKeySelector.
+func (in *ObjectKeySelector) DeepCopy() *ObjectKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Operation) DeepCopyInto(out *Operation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Operation.
+func (in *Operation) DeepCopy() *Operation {
+	if in == nil {
+		return nil
+	}
+	out := new(Operation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Operation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperationList) DeepCopyInto(out *OperationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Operation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperationList.
+func (in *OperationList) DeepCopy() *OperationList {
+	if in == nil {
+		return nil
+	}
+	out := new(OperationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OperationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperationSpec) DeepCopyInto(out *OperationSpec) {
+	*out = *in
+	out.TargetRef = in.TargetRef
+	if in.NotBefore != nil {
+		in, out := &in.NotBefore, &out.NotBefore
+		*out = (*in).DeepCopy()
+	}
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperationSpec.
+func (in *OperationSpec) DeepCopy() *OperationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OperationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperationStatus) DeepCopyInto(out *OperationStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperationStatus.
+func (in *OperationStatus) DeepCopy() *OperationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OperationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperationTargetRef) DeepCopyInto(out *OperationTargetRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperationTargetRef.
+func (in *OperationTargetRef) DeepCopy() *OperationTargetRef {
+	if in == nil {
+		return nil
+	}
+	out := new(OperationTargetRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingChange) DeepCopyInto(out *PendingChange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingChange.
+func (in *PendingChange) DeepCopy() *PendingChange {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerSupply) DeepCopyInto(out *PowerSupply) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerSupply.
+func (in *PowerSupply) DeepCopy() *PowerSupply {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerSupply)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Protocol) DeepCopyInto(out *Protocol) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Protocol.
+func (in *Protocol) DeepCopy() *Protocol {
+	if in == nil {
+		return nil
+	}
+	out := new(Protocol)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedundancyGroup) DeepCopyInto(out *RedundancyGroup) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedundancyGroup.
+func (in *RedundancyGroup) DeepCopy() *RedundancyGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(RedundancyGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SANBootConfiguration) DeepCopyInto(out *SANBootConfiguration) {
+	*out = *in
+	if in.ISCSI != nil {
+		in, out := &in.ISCSI, &out.ISCSI
+		*out = new(ISCSIBootConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SANBootConfiguration.
+func (in *SANBootConfiguration) DeepCopy() *SANBootConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(SANBootConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SNMPTrapDestination) DeepCopyInto(out *SNMPTrapDestination) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNMPTrapDestination.
+func (in *SNMPTrapDestination) DeepCopy() *SNMPTrapDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(SNMPTrapDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Server) DeepCopyInto(out *Server) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Server.
+func (in *Server) DeepCopy() *Server {
+	if in == nil {
+		return nil
+	}
+	out := new(Server)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Server) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerBootConfiguration) DeepCopyInto(out *ServerBootConfiguration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerBootConfiguration.
+func (in *ServerBootConfiguration) DeepCopy() *ServerBootConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerBootConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServerBootConfiguration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerBootConfigurationList) DeepCopyInto(out *ServerBootConfigurationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ServerBootConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerBootConfigurationList.
+func (in *ServerBootConfigurationList) DeepCopy() *ServerBootConfigurationList {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerBootConfigurationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServerBootConfigurationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerBootConfigurationSpec) DeepCopyInto(out *ServerBootConfigurationSpec) {
+	*out = *in
+	out.ServerRef = in.ServerRef
+	if in.IgnitionSecretRef != nil {
+		in, out := &in.IgnitionSecretRef, &out.IgnitionSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.SANBoot != nil {
+		in, out := &in.SANBoot, &out.SANBoot
+		*out = new(SANBootConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerBootConfigurationSpec.
+func (in *ServerBootConfigurationSpec) DeepCopy() *ServerBootConfigurationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerBootConfigurationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerBootConfigurationStatus) DeepCopyInto(out *ServerBootConfigurationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerBootConfigurationStatus.
+func (in *ServerBootConfigurationStatus) DeepCopy() *ServerBootConfigurationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerBootConfigurationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerClaim) DeepCopyInto(out *ServerClaim) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerClaim.
+func (in *ServerClaim) DeepCopy() *ServerClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServerClaim) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerClaimList) DeepCopyInto(out *ServerClaimList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ServerClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerClaimList.
+func (in *ServerClaimList) DeepCopy() *ServerClaimList {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerClaimList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServerClaimList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerClaimSpec) DeepCopyInto(out *ServerClaimSpec) {
 	*out = *in
 	if in.ServerRef != nil {
 		in, out := &in.ServerRef, &out.ServerRef
@@ -612,70 +2073,553 @@ func (in *ServerClaimSpec) DeepCopyInto(out *ServerClaimSpec) {
 		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.IgnitionSecretRef != nil {
-		in, out := &in.IgnitionSecretRef, &out.IgnitionSecretRef
-		*out = new(v1.LocalObjectReference)
-		**out = **in
+	if in.IgnitionSecretRef != nil {
+		in, out := &in.IgnitionSecretRef, &out.IgnitionSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.SANBoot != nil {
+		in, out := &in.SANBoot, &out.SANBoot
+		*out = new(SANBootConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerClaimSpec.
+func (in *ServerClaimSpec) DeepCopy() *ServerClaimSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerClaimSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerClaimStatus) DeepCopyInto(out *ServerClaimStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerClaimStatus.
+func (in *ServerClaimStatus) DeepCopy() *ServerClaimStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerClaimStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerCrashDump) DeepCopyInto(out *ServerCrashDump) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PreviousBootConfigurationRef != nil {
+		in, out := &in.PreviousBootConfigurationRef, &out.PreviousBootConfigurationRef
+		*out = new(v1.ObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerCrashDump.
+func (in *ServerCrashDump) DeepCopy() *ServerCrashDump {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerCrashDump)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerDiscoveryPolicy) DeepCopyInto(out *ServerDiscoveryPolicy) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxBootRetries != nil {
+		in, out := &in.MaxBootRetries, &out.MaxBootRetries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PowerOnTimeout != nil {
+		in, out := &in.PowerOnTimeout, &out.PowerOnTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RediscoveryInterval != nil {
+		in, out := &in.RediscoveryInterval, &out.RediscoveryInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerDiscoveryPolicy.
+func (in *ServerDiscoveryPolicy) DeepCopy() *ServerDiscoveryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerDiscoveryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerErrorDiagnostics) DeepCopyInto(out *ServerErrorDiagnostics) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.EventLog != nil {
+		in, out := &in.EventLog, &out.EventLog
+		*out = make([]ServerEventLogEntry, len(*in))
+		copy(*out, *in)
+	}
+	if in.BundleRef != nil {
+		in, out := &in.BundleRef, &out.BundleRef
+		*out = new(v1.ObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerErrorDiagnostics.
+func (in *ServerErrorDiagnostics) DeepCopy() *ServerErrorDiagnostics {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerErrorDiagnostics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerEventLogEntry) DeepCopyInto(out *ServerEventLogEntry) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerEventLogEntry.
+func (in *ServerEventLogEntry) DeepCopy() *ServerEventLogEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerEventLogEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerGrant) DeepCopyInto(out *ServerGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerGrant.
+func (in *ServerGrant) DeepCopy() *ServerGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServerGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerGrantList) DeepCopyInto(out *ServerGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ServerGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerGrantList.
+func (in *ServerGrantList) DeepCopy() *ServerGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServerGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerGrantSpec) DeepCopyInto(out *ServerGrantSpec) {
+	*out = *in
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServerSelector != nil {
+		in, out := &in.ServerSelector, &out.ServerSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxServers != nil {
+		in, out := &in.MaxServers, &out.MaxServers
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.AllowedImages != nil {
+		in, out := &in.AllowedImages, &out.AllowedImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerGrantSpec.
+func (in *ServerGrantSpec) DeepCopy() *ServerGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerGrantStatus) DeepCopyInto(out *ServerGrantStatus) {
+	*out = *in
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ClaimedServers != nil {
+		in, out := &in.ClaimedServers, &out.ClaimedServers
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerGrantStatus.
+func (in *ServerGrantStatus) DeepCopy() *ServerGrantStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerGrantStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerList) DeepCopyInto(out *ServerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Server, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerList.
+func (in *ServerList) DeepCopy() *ServerList {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerReservation) DeepCopyInto(out *ServerReservation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerReservation.
+func (in *ServerReservation) DeepCopy() *ServerReservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerReservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServerReservation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerReservationList) DeepCopyInto(out *ServerReservationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ServerReservation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerReservationList.
+func (in *ServerReservationList) DeepCopy() *ServerReservationList {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerReservationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServerReservationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerReservationSpec) DeepCopyInto(out *ServerReservationSpec) {
+	*out = *in
+	if in.ServerSelector != nil {
+		in, out := &in.ServerSelector, &out.ServerSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerReservationSpec.
+func (in *ServerReservationSpec) DeepCopy() *ServerReservationSpec {
+	if in == nil {
+		return nil
 	}
+	out := new(ServerReservationSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerClaimSpec.
-func (in *ServerClaimSpec) DeepCopy() *ServerClaimSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerReservationStatus) DeepCopyInto(out *ServerReservationStatus) {
+	*out = *in
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClaimRefs != nil {
+		in, out := &in.ClaimRefs, &out.ClaimRefs
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerReservationStatus.
+func (in *ServerReservationStatus) DeepCopy() *ServerReservationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ServerClaimSpec)
+	out := new(ServerReservationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServerClaimStatus) DeepCopyInto(out *ServerClaimStatus) {
+func (in *ServerSetClaim) DeepCopyInto(out *ServerSetClaim) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerClaimStatus.
-func (in *ServerClaimStatus) DeepCopy() *ServerClaimStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerSetClaim.
+func (in *ServerSetClaim) DeepCopy() *ServerSetClaim {
 	if in == nil {
 		return nil
 	}
-	out := new(ServerClaimStatus)
+	out := new(ServerSetClaim)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServerSetClaim) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServerList) DeepCopyInto(out *ServerList) {
+func (in *ServerSetClaimList) DeepCopyInto(out *ServerSetClaimList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Server, len(*in))
+		*out = make([]ServerSetClaim, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerList.
-func (in *ServerList) DeepCopy() *ServerList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerSetClaimList.
+func (in *ServerSetClaimList) DeepCopy() *ServerSetClaimList {
 	if in == nil {
 		return nil
 	}
-	out := new(ServerList)
+	out := new(ServerSetClaimList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ServerList) DeepCopyObject() runtime.Object {
+func (in *ServerSetClaimList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerSetClaimMember) DeepCopyInto(out *ServerSetClaimMember) {
+	*out = *in
+	if in.ServerRef != nil {
+		in, out := &in.ServerRef, &out.ServerRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerSetClaimMember.
+func (in *ServerSetClaimMember) DeepCopy() *ServerSetClaimMember {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerSetClaimMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerSetClaimSpec) DeepCopyInto(out *ServerSetClaimSpec) {
+	*out = *in
+	if in.ServerSelector != nil {
+		in, out := &in.ServerSelector, &out.ServerSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerSetClaimSpec.
+func (in *ServerSetClaimSpec) DeepCopy() *ServerSetClaimSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerSetClaimSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerSetClaimStatus) DeepCopyInto(out *ServerSetClaimStatus) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]ServerSetClaimMember, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerSetClaimStatus.
+func (in *ServerSetClaimStatus) DeepCopy() *ServerSetClaimStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerSetClaimStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerSpec) DeepCopyInto(out *ServerSpec) {
 	*out = *in
@@ -704,6 +2648,11 @@ func (in *ServerSpec) DeepCopyInto(out *ServerSpec) {
 		*out = make([]BootOrder, len(*in))
 		copy(*out, *in)
 	}
+	if in.NetworkBootInterfaces != nil {
+		in, out := &in.NetworkBootInterfaces, &out.NetworkBootInterfaces
+		*out = make([]NetworkBootInterface, len(*in))
+		copy(*out, *in)
+	}
 	if in.BIOS != nil {
 		in, out := &in.BIOS, &out.BIOS
 		*out = make([]BIOSSettings, len(*in))
@@ -711,6 +2660,26 @@ func (in *ServerSpec) DeepCopyInto(out *ServerSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.BIOSPassword != nil {
+		in, out := &in.BIOSPassword, &out.BIOSPassword
+		*out = new(BIOSPassword)
+		**out = **in
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DiscoveryPolicy != nil {
+		in, out := &in.DiscoveryPolicy, &out.DiscoveryPolicy
+		*out = new(ServerDiscoveryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DPUs != nil {
+		in, out := &in.DPUs, &out.DPUs
+		*out = make([]DPUSpec, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerSpec.
@@ -726,6 +2695,11 @@ func (in *ServerSpec) DeepCopy() *ServerSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerStatus) DeepCopyInto(out *ServerStatus) {
 	*out = *in
+	if in.SupportedResetTypes != nil {
+		in, out := &in.SupportedResetTypes, &out.SupportedResetTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.NetworkInterfaces != nil {
 		in, out := &in.NetworkInterfaces, &out.NetworkInterfaces
 		*out = make([]NetworkInterface, len(*in))
@@ -745,7 +2719,69 @@ func (in *ServerStatus) DeepCopyInto(out *ServerStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DPUs != nil {
+		in, out := &in.DPUs, &out.DPUs
+		*out = make([]DPUStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.PowerSupplies != nil {
+		in, out := &in.PowerSupplies, &out.PowerSupplies
+		*out = make([]PowerSupply, len(*in))
+		copy(*out, *in)
+	}
+	if in.Fans != nil {
+		in, out := &in.Fans, &out.Fans
+		*out = make([]Fan, len(*in))
+		copy(*out, *in)
+	}
+	if in.Redundancy != nil {
+		in, out := &in.Redundancy, &out.Redundancy
+		*out = make([]RedundancyGroup, len(*in))
+		copy(*out, *in)
+	}
 	in.BIOS.DeepCopyInto(&out.BIOS)
+	if in.BIOSSecretVersions != nil {
+		in, out := &in.BIOSSecretVersions, &out.BIOSSecretVersions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PendingChanges != nil {
+		in, out := &in.PendingChanges, &out.PendingChanges
+		*out = make([]PendingChange, len(*in))
+		copy(*out, *in)
+	}
+	if in.BIOSSettingsHistory != nil {
+		in, out := &in.BIOSSettingsHistory, &out.BIOSSettingsHistory
+		*out = make([]BIOSSettingsChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastDiscoveryTime != nil {
+		in, out := &in.LastDiscoveryTime, &out.LastDiscoveryTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PXEBootOverrideTime != nil {
+		in, out := &in.PXEBootOverrideTime, &out.PXEBootOverrideTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ErrorDiagnostics != nil {
+		in, out := &in.ErrorDiagnostics, &out.ErrorDiagnostics
+		*out = new(ServerErrorDiagnostics)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CrashDump != nil {
+		in, out := &in.CrashDump, &out.CrashDump
+		*out = new(ServerCrashDump)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LocatedDrive != nil {
+		in, out := &in.LocatedDrive, &out.LocatedDrive
+		*out = new(LocatedDrive)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -768,6 +2804,11 @@ func (in *ServerStatus) DeepCopy() *ServerStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Storage) DeepCopyInto(out *Storage) {
 	*out = *in
+	if in.Controllers != nil {
+		in, out := &in.Controllers, &out.Controllers
+		*out = make([]StorageController, len(*in))
+		copy(*out, *in)
+	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = make([]StorageVolume, len(*in))
@@ -794,6 +2835,21 @@ func (in *Storage) DeepCopy() *Storage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageController) DeepCopyInto(out *StorageController) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageController.
+func (in *StorageController) DeepCopy() *StorageController {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageController)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageDrive) DeepCopyInto(out *StorageDrive) {
 	*out = *in
@@ -802,6 +2858,11 @@ func (in *StorageDrive) DeepCopyInto(out *StorageDrive) {
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.Enclosure != nil {
+		in, out := &in.Enclosure, &out.Enclosure
+		*out = new(StorageEnclosure)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageDrive.
@@ -814,6 +2875,21 @@ func (in *StorageDrive) DeepCopy() *StorageDrive {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageEnclosure) DeepCopyInto(out *StorageEnclosure) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageEnclosure.
+func (in *StorageEnclosure) DeepCopy() *StorageEnclosure {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageEnclosure)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageVolume) DeepCopyInto(out *StorageVolume) {
 	*out = *in
@@ -833,3 +2909,18 @@ func (in *StorageVolume) DeepCopy() *StorageVolume {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyslogTarget) DeepCopyInto(out *SyslogTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogTarget.
+func (in *SyslogTarget) DeepCopy() *SyslogTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(SyslogTarget)
+	in.DeepCopyInto(out)
+	return out
+}
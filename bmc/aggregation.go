@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+const systemsCollectionURI = "/redfish/v1/Systems/"
+
+// listSystems returns the systems of the BMC. For aggregators fronting further BMCs, e.g. rack managers, the
+// systems accessed through the aggregation sources of their AggregationService are included. Aggregated members
+// which cannot be read are skipped, so that a single failing node does not fail the operations on all others;
+// their errors are returned as skipped.
+func (r *RedfishBMC) listSystems() (systems []*redfish.ComputerSystem, skipped []error, err error) {
+	systems, err = r.client.GetService().Systems()
+	if err != nil {
+		return nil, nil, err
+	}
+	uris, skipped := r.getAggregatedSystemURIs()
+	known := make(map[string]struct{}, len(systems))
+	for _, system := range systems {
+		known[strings.TrimSuffix(system.ODataID, "/")] = struct{}{}
+	}
+	for _, uri := range uris {
+		if _, ok := known[strings.TrimSuffix(uri, "/")]; ok {
+			continue
+		}
+		system, err := redfish.GetComputerSystem(r.client, uri)
+		if err != nil {
+			skipped = append(skipped, fmt.Errorf("failed to get aggregated system %s: %w", uri, err))
+			continue
+		}
+		known[strings.TrimSuffix(uri, "/")] = struct{}{}
+		systems = append(systems, system)
+	}
+	return systems, skipped, nil
+}
+
+// getAggregatedSystemURIs traverses the connection methods of the AggregationService and returns the URIs of the
+// systems accessed through their aggregation sources, along with the errors of the members which could not be read.
+func (r *RedfishBMC) getAggregatedSystemURIs() ([]string, []error) {
+	aggregation, err := r.client.GetService().AggregationService()
+	if err != nil {
+		return nil, []error{fmt.Errorf("failed to get aggregation service: %w", err)}
+	}
+	if aggregation == nil {
+		return nil, nil
+	}
+	methods, err := aggregation.ConnectionMethods()
+	if err != nil {
+		return nil, []error{fmt.Errorf("failed to get connection methods: %w", err)}
+	}
+	var (
+		uris []string
+		errs []error
+	)
+	for _, method := range methods {
+		sources, err := method.AggregationSources()
+		if err != nil {
+			errs = append(errs, fmt.Errorf(
+				"failed to get aggregation sources of connection method %s: %w", method.ODataID, err))
+			continue
+		}
+		for _, source := range sources {
+			resources, err := r.getAggregationSourceResources(source.ODataID)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			for _, uri := range resources {
+				if strings.HasPrefix(uri, systemsCollectionURI) {
+					uris = append(uris, uri)
+				}
+			}
+		}
+	}
+	return uris, errs
+}
+
+// getAggregationSourceResources returns the URIs of the resources accessed through an aggregation source, which
+// gofish does not expose.
+func (r *RedfishBMC) getAggregationSourceResources(uri string) ([]string, error) {
+	resp, err := r.client.Get(uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get aggregation source %s: %w", uri, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get aggregation source %s: %s", uri, resp.Status)
+	}
+	var source struct {
+		Links struct {
+			ResourcesAccessed common.Links
+		}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&source); err != nil {
+		return nil, fmt.Errorf("failed to decode aggregation source %s: %w", uri, err)
+	}
+	return source.Links.ResourcesAccessed.ToStrings(), nil
+}
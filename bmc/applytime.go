@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/stmcginnis/gofish/common"
+)
+
+// ErrApplyTimeNotSupported is returned if the BMC does not offer the requested apply time for settings.
+var ErrApplyTimeNotSupported = errors.New("apply time not supported by the BMC")
+
+// SetBiosAttributesApplyAt sets the BIOS attributes with the @Redfish.SettingsApplyTime annotation, including the
+// maintenance window, so that the BMC applies them at the requested time. Apply times bound to a maintenance window
+// are only requested from BMCs advertising them, as other BMCs would apply the settings outside the window.
+func (r *RedfishBMC) SetBiosAttributesApplyAt(ctx context.Context, systemUUID string, attributes map[string]string, applyTime SettingsApplyTime) (bool, error) {
+	if applyTime.ApplyTime == "" {
+		return r.SetBiosAttributes(ctx, systemUUID, attributes)
+	}
+	r, cancel := r.withTimeout(ctx, r.options.Timeouts.SettingsApply)
+	defer cancel()
+	system, err := r.getSystemByUUID(ctx, systemUUID)
+	if err != nil {
+		return false, err
+	}
+	bios, err := system.Bios()
+	if err != nil {
+		return false, fmt.Errorf("failed to get BIOS: %w", err)
+	}
+	// the settings annotation of the BIOS is not exposed by gofish
+	resp, err := r.client.Get(bios.ODataID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get BIOS: %w", err)
+	}
+	defer resp.Body.Close() // nolint: errcheck
+	var settings struct {
+		Settings common.Settings `json:"@Redfish.Settings"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&settings); err != nil {
+		return false, fmt.Errorf("failed to parse BIOS settings: %w", err)
+	}
+	supported := settings.Settings.SupportedApplyTimes
+	maintenanceWindow := applyTime.ApplyTime == string(common.AtMaintenanceWindowStartApplyTime) ||
+		applyTime.ApplyTime == string(common.InMaintenanceWindowOnResetApplyTime)
+	if (len(supported) > 0 || maintenanceWindow) && !slices.Contains(supported, common.ApplyTime(applyTime.ApplyTime)) {
+		return false, fmt.Errorf("%w: %s", ErrApplyTimeNotSupported, applyTime.ApplyTime)
+	}
+	reset, err := r.checkBiosAttributes(attributes)
+	if err != nil {
+		return false, err
+	}
+
+	preferredApplyTime := map[string]any{"ApplyTime": applyTime.ApplyTime}
+	if maintenanceWindow {
+		preferredApplyTime["MaintenanceWindowStartTime"] = applyTime.MaintenanceWindowStart.UTC().Format(time.RFC3339)
+		preferredApplyTime["MaintenanceWindowDurationInSeconds"] = int(applyTime.MaintenanceWindowDuration.Seconds())
+	}
+	target := settings.Settings.SettingsObject.String()
+	if target == "" {
+		target = bios.ODataID
+	}
+	patchResp, err := r.client.Patch(target, map[string]any{
+		"Attributes":                 attributes,
+		"@Redfish.SettingsApplyTime": preferredApplyTime,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to set BIOS attributes: %w", r.translateError(OperationSetBiosAttributes, err))
+	}
+	return reset, patchResp.Body.Close()
+}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// SetBiosPassword changes the BIOS password of the given name, e.g. AdminPassword, from oldPassword to newPassword.
+// The Bios.ChangePassword action is used if the BMC offers it. Otherwise, the password is set through the BIOS
+// attribute of the same name, together with the attribute Old<name> holding the old password if the BIOS has one.
+// Passwords set through attributes take effect on the next boot of the system.
+func (r *RedfishBMC) SetBiosPassword(ctx context.Context, systemUUID, passwordName, oldPassword, newPassword string) error {
+	r, cancel := r.withTimeout(ctx, r.options.Timeouts.SettingsApply)
+	defer cancel()
+	system, err := r.getSystemByUUID(ctx, systemUUID)
+	if err != nil {
+		return err
+	}
+	bios, err := system.Bios()
+	if err != nil {
+		return fmt.Errorf("failed to get BIOS: %w", err)
+	}
+	// the ChangePassword action target is not exposed by gofish
+	resp, err := r.client.Get(bios.ODataID)
+	if err != nil {
+		return fmt.Errorf("failed to get BIOS: %w", err)
+	}
+	var raw struct {
+		Actions struct {
+			ChangePassword struct {
+				Target string `json:"target"`
+			} `json:"#Bios.ChangePassword"`
+		} `json:"Actions"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&raw)
+	_ = resp.Body.Close()
+	if err != nil {
+		return fmt.Errorf("failed to parse BIOS: %w", err)
+	}
+	if raw.Actions.ChangePassword.Target != "" {
+		if err := bios.ChangePassword(passwordName, oldPassword, newPassword); err != nil {
+			return fmt.Errorf("failed to change BIOS password %s: %w", passwordName, err)
+		}
+		return nil
+	}
+
+	if _, ok := bios.Attributes[passwordName]; !ok {
+		return fmt.Errorf("BIOS offers neither the ChangePassword action nor the attribute %s", passwordName)
+	}
+	attrs := map[string]any{passwordName: newPassword}
+	if _, ok := bios.Attributes["Old"+passwordName]; ok {
+		attrs["Old"+passwordName] = oldPassword
+	}
+	if err := bios.UpdateBiosAttributes(attrs); err != nil {
+		return fmt.Errorf("failed to set BIOS password %s: %w", passwordName, err)
+	}
+	return nil
+}
@@ -5,6 +5,8 @@ package bmc
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/stmcginnis/gofish/common"
 	"github.com/stmcginnis/gofish/redfish"
@@ -31,6 +33,26 @@ type BMC interface {
 	// GetSystemInfo retrieves information about the system.
 	GetSystemInfo(ctx context.Context, systemUUID string) (SystemInfo, error)
 
+	// GetProcessors returns the processors of the system.
+	GetProcessors(ctx context.Context, systemUUID string) ([]Processor, error)
+
+	// GetSystemRack returns the rack the chassis of the system is placed in. It is empty if the BMC does not report
+	// it.
+	GetSystemRack(ctx context.Context, systemUUID string) (string, error)
+
+	// GetDPUs returns the DPUs of the system. DPUs are attributed to a system if it is the only physical system of
+	// the BMC.
+	GetDPUs(ctx context.Context, systemUUID string) ([]DPU, error)
+
+	// SetDPUMode sets the mode of the DPU with the given system URI, which takes effect on the next power cycle.
+	SetDPUMode(ctx context.Context, dpuURI string, mode string) error
+
+	// GetBootMode returns the boot mode of the system and the boot mode it switches to on its next boot.
+	GetBootMode(ctx context.Context, systemUUID string) (mode BootMode, pendingMode BootMode, err error)
+
+	// SetBootMode sets the boot mode of the system through its BIOS attributes, which takes effect on its next boot.
+	SetBootMode(ctx context.Context, systemUUID string, mode BootMode) error
+
 	// Logout closes the BMC client connection by logging out
 	Logout()
 
@@ -42,17 +64,99 @@ type BMC interface {
 
 	GetBootOrder(ctx context.Context, systemUUID string) ([]string, error)
 
+	// GetBootSourceOverride returns the boot source override of the system.
+	GetBootSourceOverride(ctx context.Context, systemUUID string) (BootSourceOverride, error)
+
 	GetBiosAttributeValues(ctx context.Context, systemUUID string, attributes []string) (map[string]string, error)
 
 	SetBiosAttributes(ctx context.Context, systemUUID string, attributes map[string]string) (reset bool, err error)
 
+	// SetBiosAttributesApplyAt sets the BIOS attributes to be applied at the given apply time. It returns
+	// ErrApplyTimeNotSupported if the BMC does not offer the apply time.
+	SetBiosAttributesApplyAt(ctx context.Context, systemUUID string, attributes map[string]string, applyTime SettingsApplyTime) (reset bool, err error)
+
+	// GetBiosPendingAttributeValues returns the BIOS attributes which have been set, but take effect on the next
+	// boot of the system.
+	GetBiosPendingAttributeValues(ctx context.Context, systemUUID string) (map[string]string, error)
+
 	GetBiosVersion(ctx context.Context, systemUUID string) (string, error)
 
+	// SetBiosPassword changes the BIOS password of the given name, e.g. AdminPassword, from oldPassword to
+	// newPassword. An empty oldPassword changes a password which has not been set.
+	SetBiosPassword(ctx context.Context, systemUUID, passwordName, oldPassword, newPassword string) error
+
+	// SetDriveLocationIndicator turns the location indicator of the drive with the given name or ID on or off.
+	SetDriveLocationIndicator(ctx context.Context, systemUUID, driveName string, active bool) error
+
 	SetBootOrder(ctx context.Context, systemUUID string, order []string) error
 
 	GetStorages(ctx context.Context, systemUUID string) ([]Storage, error)
 
+	// GetChassisFRUs returns the power supplies and fans of the chassis of the system and their redundancy.
+	GetChassisFRUs(ctx context.Context, systemUUID string) (ChassisFRUs, error)
+
 	WaitForServerPowerState(ctx context.Context, systemUUID string, powerState redfish.PowerState) error
+
+	// UpdateFirmware triggers a firmware update through the UpdateService and returns the URI of the
+	// task tracking the update.
+	UpdateFirmware(ctx context.Context, params FirmwareUpdateParameters) (string, error)
+
+	// GetTask returns the current state of the task with the given URI.
+	GetTask(ctx context.Context, taskURI string) (*Task, error)
+
+	// GetFirmwareInventory returns the firmware inventory of the UpdateService.
+	GetFirmwareInventory(ctx context.Context) ([]FirmwareInventory, error)
+
+	// SetPXEBootOnceWithMode sets the boot device for the next system boot using the given boot mode.
+	SetPXEBootOnceWithMode(ctx context.Context, systemUUID string, mode redfish.BootSourceOverrideMode) error
+
+	// SetPXEBootOnceFromInterfaces sets the UEFI boot option of the first of the given network interfaces which
+	// has one as boot device for the next system boot, using the given boot mode if set.
+	SetPXEBootOnceFromInterfaces(ctx context.Context, systemUUID string, mode redfish.BootSourceOverrideMode, interfaces []NetworkBootInterface) error
+
+	// ResetManager performs a reset on the BMC itself.
+	ResetManager(ctx context.Context, resetType redfish.ResetType) error
+
+	// GetEventLogEntries returns the latest entries of the system event log, oldest first.
+	GetEventLogEntries(ctx context.Context, systemUUID string, limit int) ([]LogEntry, error)
+
+	// CollectCrashDump requests the BMC to capture a crash dump of the operating system of the system. It returns
+	// ErrCrashDumpNotSupported if the BMC does not capture crash dumps.
+	CollectCrashDump(ctx context.Context, systemUUID string) error
+
+	// GetCrashDump returns the latest crash dump of the system captured by the BMC since the given time, or nil if
+	// there is none yet.
+	GetCrashDump(ctx context.Context, systemUUID string, since time.Time) (*CrashDump, error)
+
+	// DownloadCrashDump returns the content of a crash dump captured by the BMC. The caller has to close it.
+	DownloadCrashDump(ctx context.Context, dump CrashDump) (io.ReadCloser, error)
+
+	// SetISCSIBoot configures a network device function of the system to boot from an iSCSI target, unless it is
+	// configured already, and boots the system once from the target.
+	SetISCSIBoot(ctx context.Context, systemUUID string, params ISCSIBootParameters) error
+
+	// GetResourceBlocks returns the resource blocks of the CompositionService.
+	GetResourceBlocks(ctx context.Context) ([]ResourceBlock, error)
+
+	// ComposeSystem composes a new system with the given name from the given resource blocks and returns its URI.
+	// If the BMC composes the system asynchronously, the URI of the composition task is returned instead.
+	ComposeSystem(ctx context.Context, name string, resourceBlockURIs []string) (systemURI string, taskURI string, err error)
+
+	// GetSystem returns the system with the given URI.
+	GetSystem(ctx context.Context, systemURI string) (Server, error)
+
+	// DecomposeSystem decomposes the composed system with the given URI, freeing its resource blocks.
+	DecomposeSystem(ctx context.Context, systemURI string) error
+
+	// SetAccountPassword sets the password of the BMC account with the given user name.
+	SetAccountPassword(ctx context.Context, username, password string) error
+
+	// GetMetricReports returns the metric reports of the TelemetryService.
+	GetMetricReports(ctx context.Context) ([]MetricReport, error)
+
+	// SetForwarding configures the SNMP trap destinations and remote syslog targets of the BMC, replacing the
+	// destinations and targets configured before.
+	SetForwarding(ctx context.Context, config ForwardingConfig) error
 }
 
 type Entity struct {
@@ -81,7 +185,15 @@ type RegistryEntryAttributes struct {
 	ReadOnly      bool
 	ResetRequired bool
 	Type          string
-	WriteOnly     bool
+	// Value lists the allowed values of enumeration attributes.
+	Value     []RegistryEntryValue
+	WriteOnly bool
+}
+
+// RegistryEntryValue is an allowed value of an enumeration attribute.
+type RegistryEntryValue struct {
+	ValueName        string
+	ValueDisplayName string
 }
 
 type RegistryEntry struct {
@@ -105,6 +217,25 @@ type BiosRegistry struct {
 	RegistryEntries RegistryEntry
 }
 
+// BootSourceOverride is the boot source override of a system.
+type BootSourceOverride struct {
+	Enabled redfish.BootSourceOverrideEnabled
+	Target  redfish.BootSourceOverrideTarget
+}
+
+// Pending reports whether the override has not been consumed by a boot of the system yet.
+func (o BootSourceOverride) Pending() bool {
+	return o.Enabled == redfish.OnceBootSourceOverrideEnabled
+}
+
+// NetworkBootInterface selects a network interface of a system to boot from by its MAC address or name.
+type NetworkBootInterface struct {
+	// MACAddress is the MAC address of the network interface.
+	MACAddress string
+	// Name is matched against the ID, the display name and the UEFI device path of the boot options.
+	Name string
+}
+
 type NetworkInterface struct {
 	ID                  string
 	MACAddress          string
@@ -112,11 +243,54 @@ type NetworkInterface struct {
 }
 
 type Server struct {
+	// URI is the Redfish URI of the system.
+	URI string
+	// Name is the name of the system. Composed systems are named after the ComposedServer they were composed for.
+	Name         string
 	UUID         string
 	Model        string
 	Manufacturer string
 	PowerState   PowerState
 	SerialNumber string
+	// SystemType is the Redfish type of the system, e.g. Physical or DPU.
+	SystemType string
+}
+
+// Redfish types of computer systems. BMCs of servers with DPUs or SmartNICs report the systems of these devices
+// with other types than SystemTypePhysical, e.g. SystemTypeDPU.
+const (
+	SystemTypePhysical = "Physical"
+	SystemTypeDPU      = "DPU"
+	SystemTypeComposed = "Composed"
+)
+
+// Modes of a DPU as named by the NicMode BIOS attribute of NVIDIA BlueField DPUs.
+const (
+	dpuModeAttribute = "NicMode"
+
+	// DPUModeDPU runs the DPU with its own operating system in front of the host.
+	DPUModeDPU = "DpuMode"
+	// DPUModeNIC runs the DPU as a plain network adapter of the host.
+	DPUModeNIC = "NicMode"
+)
+
+// DPU represents a DPU or SmartNIC which its BMC reports as a system of its own.
+type DPU struct {
+	Server
+	// FirmwareVersion is the version of the firmware of the DPU.
+	FirmwareVersion string
+	// ManagementIP is the address of the out-of-band management interface of the DPU.
+	ManagementIP string
+	// Mode is the current mode of the DPU, e.g. DPUModeDPU.
+	Mode string
+	// PendingMode is the mode the DPU switches to on the next power cycle of the server.
+	PendingMode string
+}
+
+// IsPhysical reports whether the system is a physical computer system. Systems without a type are considered
+// physical, as older BMCs do not report it.
+func (s Server) IsPhysical() bool {
+	return s.SystemType == "" || s.SystemType == SystemTypePhysical
 }
 
 // Volume represents a storage volume.
@@ -126,6 +300,8 @@ type Volume struct {
 	SizeBytes int64 `json:"sizeBytes,omitempty"`
 	// Status specifies the status of the volume.
 	State common.State `json:"state,omitempty"`
+	// Health specifies the health of the volume, e.g. degraded while it is rebuilt.
+	Health common.Health `json:"health,omitempty"`
 	// RAIDType specifies the RAID type of the associated Volume.
 	RAIDType redfish.RAIDType `json:"raidType,omitempty"`
 	// VolumeUsage specifies the volume usage type for the Volume.
@@ -145,8 +321,44 @@ type Drive struct {
 	Vendor string `json:"vendor,omitempty"`
 	// Model specifies the model of the storage device.
 	Model string `json:"model,omitempty"`
+	// FirmwareVersion specifies the firmware revision of the storage device.
+	FirmwareVersion string `json:"firmwareVersion,omitempty"`
+	// URI specifies the resource URI of the storage device.
+	URI string `json:"uri,omitempty"`
 	// State specifies the state of the storage device.
 	State common.State `json:"state,omitempty"`
+	// Health specifies the health of the storage device.
+	Health common.Health `json:"health,omitempty"`
+	// SerialNumber specifies the serial number of the storage device.
+	SerialNumber string `json:"serialNumber,omitempty"`
+	// Bay specifies the physical location of the storage device in its enclosure, preferably the service label.
+	Bay string `json:"bay,omitempty"`
+	// Enclosure specifies the chassis or backplane containing the storage device.
+	Enclosure *Enclosure `json:"enclosure,omitempty"`
+	// LocationIndicatorActive specifies whether the location indicator of the storage device is on.
+	LocationIndicatorActive bool `json:"locationIndicatorActive,omitempty"`
+}
+
+// Enclosure represents the chassis or backplane containing a drive.
+type Enclosure struct {
+	Entity
+	// Model specifies the model of the enclosure.
+	Model string `json:"model,omitempty"`
+	// SerialNumber specifies the serial number of the enclosure.
+	SerialNumber string `json:"serialNumber,omitempty"`
+}
+
+// StorageController represents a controller of a storage resource.
+type StorageController struct {
+	Entity
+	// Manufacturer specifies the manufacturer of the controller.
+	Manufacturer string `json:"manufacturer,omitempty"`
+	// Model specifies the model of the controller.
+	Model string `json:"model,omitempty"`
+	// FirmwareVersion specifies the firmware version of the controller.
+	FirmwareVersion string `json:"firmwareVersion,omitempty"`
+	// SerialNumber specifies the serial number of the controller.
+	SerialNumber string `json:"serialNumber,omitempty"`
 }
 
 // Storage represents a storage resource.
@@ -154,12 +366,106 @@ type Storage struct {
 	Entity
 	// State specifies the state of the storage.
 	State common.State `json:"state,omitempty"`
+	// Controllers is a collection of controllers of this storage.
+	Controllers []StorageController `json:"controllers,omitempty"`
 	// Drives is a collection of drives associated with this storage.
 	Drives []Drive `json:"drives,omitempty"`
 	// Volumes is a collection of volumes associated with this storage.
 	Volumes []Volume `json:"volumes,omitempty"`
 }
 
+// FirmwareUpdateParameters contains the parameters for a firmware update.
+type FirmwareUpdateParameters struct {
+	// ImageURI is the URI of the firmware image.
+	ImageURI string
+	// TransferProtocol is the protocol used by the BMC to fetch the image.
+	TransferProtocol string
+	// Targets are the resource URIs the image should be applied to.
+	Targets []string
+	// ForceUpdate bypasses the update policies of the BMC.
+	ForceUpdate bool
+	// ApplyTime is the Redfish OperationApplyTime of the update, e.g. Immediate or OnReset. If empty, the
+	// default of the BMC is used.
+	ApplyTime string
+}
+
+// SettingsApplyTime defines when the BMC applies settings, see the Redfish @Redfish.SettingsApplyTime annotation.
+type SettingsApplyTime struct {
+	// ApplyTime is the Redfish ApplyTime, e.g. Immediate or AtMaintenanceWindowStart.
+	ApplyTime string
+	// MaintenanceWindowStart and MaintenanceWindowDuration define the maintenance window of the apply times
+	// AtMaintenanceWindowStart and InMaintenanceWindowOnReset.
+	MaintenanceWindowStart    time.Time
+	MaintenanceWindowDuration time.Duration
+}
+
+// ISCSIBootParameters contains the parameters for booting a system from an iSCSI target.
+type ISCSIBootParameters struct {
+	// NetworkDeviceFunctionID is the ID of the network device function to configure. If empty, the first
+	// function supporting iSCSI is used.
+	NetworkDeviceFunctionID string
+	// InitiatorName is the iSCSI qualified name of the initiator.
+	InitiatorName string
+	// TargetName is the iSCSI qualified name of the target.
+	TargetName string
+	// TargetAddress is the IP address of the target.
+	TargetAddress string
+	// TargetPort is the TCP port of the target.
+	TargetPort int
+	// LUN is the logical unit number to boot from.
+	LUN int
+	// CHAPUsername and CHAPSecret enable CHAP authentication if set.
+	CHAPUsername string
+	CHAPSecret   string
+}
+
+// ResourceBlock represents a resource block of the CompositionService.
+type ResourceBlock struct {
+	Entity
+	// URI is the resource URI of the resource block.
+	URI string
+	// Types are the types of resources contained in the resource block, e.g. Compute or Storage.
+	Types []string
+	// CompositionState is the composition state of the resource block, e.g. Unused or Composed.
+	CompositionState string
+}
+
+// LogEntry represents an entry of an event log.
+type LogEntry struct {
+	Created  string
+	Severity string
+	Message  string
+}
+
+// FirmwareInventory represents an entry of the firmware inventory.
+type FirmwareInventory struct {
+	Entity
+	// URI is the resource URI of the inventory entry.
+	URI string
+	// SoftwareID is the implementation-specific identifier of the firmware.
+	SoftwareID string
+	// Manufacturer is the manufacturer of the firmware.
+	Manufacturer string
+	// Version is the version of the firmware.
+	Version string
+	// Updateable indicates whether the firmware can be updated through the UpdateService.
+	Updateable bool
+	// RelatedItems are the resource URIs of the components the firmware is associated with.
+	RelatedItems []string
+}
+
+// Task represents a long-running task on the BMC.
+type Task struct {
+	// URI is the URI of the task.
+	URI string
+	// State is the state of the task.
+	State redfish.TaskState
+	// PercentComplete is the progress of the task.
+	PercentComplete int
+	// Message is the last message reported by the task.
+	Message string
+}
+
 // PowerState is the power state of the system.
 type PowerState string
 
@@ -191,6 +497,36 @@ type Processor struct {
 	TotalThreads          int32
 }
 
+// Architectures of the CPUs of a system in the naming of the kernel.
+const (
+	ArchitectureX8664   = "x86_64"
+	ArchitectureAArch64 = "aarch64"
+)
+
+// ArchitectureFromProcessors returns the architecture of the CPUs among the processors, or an empty string if
+// it is unknown. The instruction set is preferred, as BMCs of ARM servers report ProcessorArchitecture ARM for
+// 32-bit and 64-bit cores alike.
+func ArchitectureFromProcessors(processors []Processor) string {
+	for _, processor := range processors {
+		if processor.ProcessorType != "" && processor.ProcessorType != "CPU" {
+			continue
+		}
+		switch processor.InstructionSet {
+		case "x86-64":
+			return ArchitectureX8664
+		case "ARM-A64":
+			return ArchitectureAArch64
+		}
+		switch processor.ProcessorArchitecture {
+		case "x86":
+			return ArchitectureX8664
+		case "ARM":
+			return ArchitectureAArch64
+		}
+	}
+	return ""
+}
+
 // SystemInfo represents basic information about the system.
 type SystemInfo struct {
 	Manufacturer      string
@@ -204,6 +540,8 @@ type SystemInfo struct {
 	SerialNumber      string
 	SKU               string
 	IndicatorLED      string
+	// SupportedResetTypes are the reset types the system allows, if the BMC reports them.
+	SupportedResetTypes []redfish.ResetType
 }
 
 // Manager represents the manager information.
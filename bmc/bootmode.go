@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+// BootMode is the firmware boot mode of a system.
+type BootMode string
+
+const (
+	BootModeUEFI   BootMode = "UEFI"
+	BootModeLegacy BootMode = "Legacy"
+)
+
+// bootModeAttribute is a BIOS attribute selecting the boot mode and its values for the boot modes.
+type bootModeAttribute struct {
+	flavor Flavor
+	name   string
+	values map[BootMode]string
+}
+
+// bootModeAttributes are the BIOS attributes vendors select the boot mode with. Attributes of FlavorGeneric are
+// tried for all BMCs, as vendors like Supermicro and Lenovo are not told apart by their service root.
+var bootModeAttributes = []bootModeAttribute{
+	{flavor: FlavorHPE, name: "BootMode", values: map[BootMode]string{BootModeUEFI: "Uefi", BootModeLegacy: "LegacyBios"}},
+	{flavor: FlavorDell, name: "BootMode", values: map[BootMode]string{BootModeUEFI: "Uefi", BootModeLegacy: "Bios"}},
+	// Supermicro
+	{flavor: FlavorGeneric, name: "BootModeSelect", values: map[BootMode]string{BootModeUEFI: "UEFI", BootModeLegacy: "LEGACY"}},
+	// Lenovo XClarity Controller
+	{flavor: FlavorGeneric, name: "BootModes_SystemBootMode", values: map[BootMode]string{BootModeUEFI: "UEFIMode", BootModeLegacy: "LegacyMode"}},
+}
+
+// bootModeAttributeFor returns the boot mode attribute of the BIOS of a BMC of the flavor.
+func bootModeAttributeFor(flavor Flavor, bios *redfish.Bios) (bootModeAttribute, bool) {
+	for _, attribute := range bootModeAttributes {
+		if attribute.flavor != flavor && attribute.flavor != FlavorGeneric {
+			continue
+		}
+		if _, ok := bios.Attributes[attribute.name]; ok {
+			return attribute, true
+		}
+	}
+	return bootModeAttribute{}, false
+}
+
+// bootMode returns the boot mode selected by the value of the attribute, or an empty boot mode for unknown values,
+// e.g. the DUAL mode of Supermicro.
+func (a bootModeAttribute) bootMode(value string) BootMode {
+	for mode, v := range a.values {
+		if v == value {
+			return mode
+		}
+	}
+	return ""
+}
+
+// GetBootMode returns the boot mode of the system and the boot mode it switches to on its next boot, which is empty
+// if no switch is pending. The boot mode is empty if the BIOS of the system has no known boot mode attribute.
+func (r *RedfishBMC) GetBootMode(ctx context.Context, systemUUID string) (BootMode, BootMode, error) {
+	system, err := r.getSystemByUUID(ctx, systemUUID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get systems: %w", err)
+	}
+	bios, err := system.Bios()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get bios: %w", err)
+	}
+	attribute, ok := bootModeAttributeFor(r.flavor, bios)
+	if !ok {
+		return "", "", nil
+	}
+	mode := attribute.bootMode(bios.Attributes.String(attribute.name))
+	pending, err := r.pendingBiosAttributes(bios)
+	if err != nil {
+		return "", "", err
+	}
+	var pendingMode BootMode
+	if value, ok := pending[attribute.name]; ok {
+		pendingMode = attribute.bootMode(value)
+	}
+	return mode, pendingMode, nil
+}
+
+// SetBootMode sets the boot mode attribute of the BIOS of the system, which takes effect on its next boot.
+func (r *RedfishBMC) SetBootMode(ctx context.Context, systemUUID string, mode BootMode) error {
+	r, cancel := r.withTimeout(ctx, r.options.Timeouts.SettingsApply)
+	defer cancel()
+	system, err := r.getSystemByUUID(ctx, systemUUID)
+	if err != nil {
+		return fmt.Errorf("failed to get systems: %w", err)
+	}
+	bios, err := system.Bios()
+	if err != nil {
+		return fmt.Errorf("failed to get bios: %w", err)
+	}
+	attribute, ok := bootModeAttributeFor(r.flavor, bios)
+	if !ok {
+		return errors.New("the BIOS of the system has no known boot mode attribute")
+	}
+	value, ok := attribute.values[mode]
+	if !ok {
+		return fmt.Errorf("unknown boot mode %q", mode)
+	}
+	if err := bios.UpdateBiosAttributes(redfish.SettingsAttributes{attribute.name: value}); err != nil {
+		return fmt.Errorf("failed to set boot mode: %w", r.translateError(OperationSetBootMode, err))
+	}
+	return nil
+}
+
+// BootSourceOverrideMode returns the boot source override mode booting in the boot mode.
+func BootSourceOverrideMode(mode BootMode) redfish.BootSourceOverrideMode {
+	switch mode {
+	case BootModeUEFI:
+		return redfish.UEFIBootSourceOverrideMode
+	case BootModeLegacy:
+		return redfish.LegacyBootSourceOverrideMode
+	default:
+		return ""
+	}
+}
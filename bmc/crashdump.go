@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+// ErrCrashDumpNotSupported is returned if the BMC does not capture crash dumps of the operating system.
+var ErrCrashDumpNotSupported = errors.New("crash dumps not supported by the BMC")
+
+// CrashDump is a crash dump of the operating system captured by the BMC.
+type CrashDump struct {
+	// URI is the URI the dump is downloaded from.
+	URI string
+	// Created is the time the BMC captured the dump.
+	Created time.Time
+}
+
+// crashDumpLogService is a log service collecting diagnostic data of the operating system.
+type crashDumpLogService struct {
+	*redfish.LogService
+	// collectTarget is the target of the CollectDiagnosticData action of the log service.
+	collectTarget string
+}
+
+// crashDumpLogServices returns the log services of the system which collect diagnostic data of the operating system.
+func (r *RedfishBMC) crashDumpLogServices(ctx context.Context, systemUUID string) ([]crashDumpLogService, error) {
+	system, err := r.getSystemByUUID(ctx, systemUUID)
+	if err != nil {
+		return nil, err
+	}
+	services, err := system.LogServices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system log services: %w", err)
+	}
+	var result []crashDumpLogService
+	for _, service := range services {
+		// the CollectDiagnosticData action is not exposed by gofish
+		resp, err := r.client.Get(service.ODataID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get log service %s: %w", service.ID, err)
+		}
+		var raw struct {
+			Actions struct {
+				CollectDiagnosticData struct {
+					Target             string                           `json:"target"`
+					DiagnosticDataType []redfish.LogDiagnosticDataTypes `json:"DiagnosticDataType@Redfish.AllowableValues"`
+				} `json:"#LogService.CollectDiagnosticData"`
+			} `json:"Actions"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&raw)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse log service %s: %w", service.ID, err)
+		}
+		action := raw.Actions.CollectDiagnosticData
+		if action.Target != "" && slices.Contains(action.DiagnosticDataType, redfish.OSLogDiagnosticDataTypes) {
+			result = append(result, crashDumpLogService{LogService: service, collectTarget: action.Target})
+		}
+	}
+	if len(result) == 0 {
+		return nil, ErrCrashDumpNotSupported
+	}
+	return result, nil
+}
+
+// CollectCrashDump requests the BMC to capture a crash dump of the operating system of the system. It returns
+// ErrCrashDumpNotSupported if the BMC does not offer the collection of operating system diagnostic data.
+func (r *RedfishBMC) CollectCrashDump(ctx context.Context, systemUUID string) error {
+	services, err := r.crashDumpLogServices(ctx, systemUUID)
+	if err != nil {
+		return err
+	}
+	resp, err := r.client.Post(services[0].collectTarget, map[string]any{"DiagnosticDataType": redfish.OSLogDiagnosticDataTypes})
+	if err != nil {
+		return fmt.Errorf("failed to collect crash dump: %w", err)
+	}
+	return resp.Body.Close()
+}
+
+// GetCrashDump returns the latest crash dump of the operating system of the system captured since the given time,
+// or nil if the BMC did not capture one yet.
+func (r *RedfishBMC) GetCrashDump(ctx context.Context, systemUUID string, since time.Time) (*CrashDump, error) {
+	services, err := r.crashDumpLogServices(ctx, systemUUID)
+	if err != nil {
+		return nil, err
+	}
+	var latest *CrashDump
+	for _, service := range services {
+		entries, err := service.Entries()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get log entries of %s: %w", service.ID, err)
+		}
+		for _, entry := range entries {
+			if entry.DiagnosticDataType != redfish.OSLogDiagnosticDataTypes || entry.AdditionalDataURI == "" {
+				continue
+			}
+			created, err := time.Parse(time.RFC3339, entry.Created)
+			if err != nil || created.Before(since) {
+				continue
+			}
+			if latest == nil || created.After(latest.Created) {
+				latest = &CrashDump{URI: entry.AdditionalDataURI, Created: created}
+			}
+		}
+	}
+	return latest, nil
+}
+
+// DownloadCrashDump returns the content of the crash dump. The caller has to close it.
+func (r *RedfishBMC) DownloadCrashDump(ctx context.Context, dump CrashDump) (io.ReadCloser, error) {
+	resp, err := r.client.Get(dump.URI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download crash dump: %w", err)
+	}
+	return resp.Body, nil
+}
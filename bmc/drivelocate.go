@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+// SetDriveLocationIndicator turns the location indicator of the drive with the given name or ID on or off, so that
+// the bay of the drive blinks. The LocationIndicatorActive property of the drive is used if the BMC offers it, the
+// deprecated IndicatorLED property otherwise.
+func (r *RedfishBMC) SetDriveLocationIndicator(ctx context.Context, systemUUID, driveName string, active bool) error {
+	system, err := r.getSystemByUUID(ctx, systemUUID)
+	if err != nil {
+		return err
+	}
+	drive, err := findDrive(system, driveName)
+	if err != nil {
+		return err
+	}
+
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(drive.RawData, &properties); err != nil {
+		return fmt.Errorf("failed to parse drive %s: %w", driveName, err)
+	}
+	var payload map[string]any
+	switch {
+	case properties["LocationIndicatorActive"] != nil:
+		payload = map[string]any{"LocationIndicatorActive": active}
+	case properties["IndicatorLED"] != nil:
+		led := common.OffIndicatorLED
+		if active {
+			led = common.BlinkingIndicatorLED
+		}
+		payload = map[string]any{"IndicatorLED": led}
+	default:
+		return fmt.Errorf("drive %s has no location indicator", driveName)
+	}
+	if err := drive.Patch(drive.ODataID, payload); err != nil {
+		return fmt.Errorf("failed to set location indicator of drive %s: %w", driveName, err)
+	}
+	return nil
+}
+
+// findDrive returns the drive of the system with the given name or ID.
+func findDrive(system *redfish.ComputerSystem, driveName string) (*redfish.Drive, error) {
+	storages, err := system.Storage()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storages: %w", err)
+	}
+	for _, storage := range storages {
+		drives, err := storage.Drives()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get drives of storage %s: %w", storage.ID, err)
+		}
+		for _, drive := range drives {
+			if drive.Name == driveName || drive.ID == driveName {
+				return drive, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("drive %s not found", driveName)
+}
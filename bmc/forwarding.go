@@ -0,0 +1,303 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/stmcginnis/gofish/common"
+)
+
+// SNMPVersion is the SNMP version of the traps sent to a destination.
+type SNMPVersion string
+
+const (
+	SNMPVersionV1  SNMPVersion = "SNMPv1"
+	SNMPVersionV2c SNMPVersion = "SNMPv2c"
+)
+
+// SyslogProtocol is the transport protocol of a remote syslog target.
+type SyslogProtocol string
+
+const (
+	SyslogProtocolUDP SyslogProtocol = "UDP"
+	SyslogProtocolTCP SyslogProtocol = "TCP"
+)
+
+// ForwardingConfig describes where the BMC forwards hardware alerts to.
+type ForwardingConfig struct {
+	// SNMPTrapDestinations are the destinations of SNMP traps.
+	SNMPTrapDestinations []SNMPTrapDestination
+	// SyslogTargets are the remote syslog targets.
+	SyslogTargets []SyslogTarget
+}
+
+// SNMPTrapDestination is a destination of SNMP traps.
+type SNMPTrapDestination struct {
+	Address   string
+	Port      int32
+	Version   SNMPVersion
+	Community string
+}
+
+// SyslogTarget is a remote syslog target.
+type SyslogTarget struct {
+	Address  string
+	Port     int32
+	Protocol SyslogProtocol
+}
+
+const (
+	// forwardingSubscriptionContext is the context of the event subscriptions created for the forwarding of
+	// alerts, which tells them apart from the subscriptions of other clients.
+	forwardingSubscriptionContext = "metal-operator-forwarding"
+
+	// dellSNMPAlertDestinations and dellSyslogServers are the number of SNMP alert destinations and remote syslog
+	// servers of iDRAC.
+	dellSNMPAlertDestinations = 8
+	dellSyslogServers         = 3
+)
+
+// SetForwarding configures the SNMP trap destinations and remote syslog targets of the BMC. The configuration
+// replaces the destinations and targets configured before. iLO and iDRAC are configured through their OEM
+// resources, other BMCs through SNMP and syslog subscriptions of the EventService.
+func (r *RedfishBMC) SetForwarding(ctx context.Context, config ForwardingConfig) error {
+	r, cancel := r.withTimeout(ctx, r.options.Timeouts.SettingsApply)
+	defer cancel()
+	switch r.flavor {
+	case FlavorHPE:
+		return r.setHPEForwarding(config)
+	case FlavorDell:
+		return r.setDellForwarding(config)
+	default:
+		return r.setSubscriptionForwarding(config)
+	}
+}
+
+// setSubscriptionForwarding replaces the SNMP and syslog subscriptions of the EventService created for the
+// forwarding before.
+func (r *RedfishBMC) setSubscriptionForwarding(config ForwardingConfig) error {
+	eventService, err := r.client.Service.EventService()
+	if err != nil {
+		return fmt.Errorf("failed to get event service: %w", err)
+	}
+	var service struct {
+		Subscriptions common.Link `json:"Subscriptions"`
+	}
+	if err := r.getJSON(eventService.ODataID, &service); err != nil {
+		return fmt.Errorf("failed to get event service: %w", err)
+	}
+	if service.Subscriptions == "" {
+		return errors.New("event service does not offer subscriptions")
+	}
+	subscriptionsURI := service.Subscriptions.String()
+
+	var subscriptions struct {
+		Members common.Links `json:"Members"`
+	}
+	if err := r.getJSON(subscriptionsURI, &subscriptions); err != nil {
+		return fmt.Errorf("failed to get event subscriptions: %w", err)
+	}
+	for _, member := range subscriptions.Members {
+		var subscription struct {
+			Context string `json:"Context"`
+		}
+		if err := r.getJSON(member.String(), &subscription); err != nil {
+			return fmt.Errorf("failed to get event subscription %s: %w", member, err)
+		}
+		if subscription.Context != forwardingSubscriptionContext {
+			continue
+		}
+		if err := r.deleteResource(member.String()); err != nil {
+			return fmt.Errorf("failed to delete event subscription %s: %w", member, err)
+		}
+	}
+
+	for _, destination := range config.SNMPTrapDestinations {
+		subscription := map[string]any{
+			"Context":          forwardingSubscriptionContext,
+			"Destination":      "snmp://" + hostPort(destination.Address, destination.Port),
+			"Protocol":         string(destination.Version),
+			"SubscriptionType": "SNMPTrap",
+		}
+		if destination.Community != "" {
+			subscription["SNMP"] = map[string]any{"TrapCommunity": destination.Community}
+		}
+		if err := r.postResource(subscriptionsURI, subscription); err != nil {
+			return fmt.Errorf("failed to subscribe SNMP trap destination %s: %w", destination.Address, err)
+		}
+	}
+	for _, target := range config.SyslogTargets {
+		if err := r.postResource(subscriptionsURI, map[string]any{
+			"Context":          forwardingSubscriptionContext,
+			"Destination":      "syslog://" + hostPort(target.Address, target.Port),
+			"Protocol":         "Syslog" + string(target.Protocol),
+			"SubscriptionType": "Syslog",
+		}); err != nil {
+			return fmt.Errorf("failed to subscribe syslog target %s: %w", target.Address, err)
+		}
+	}
+	return nil
+}
+
+// setHPEForwarding replaces the SNMP alert destinations of the SnmpService of iLO and configures its remote syslog
+// server in the HPE OEM extension of the NetworkProtocol of the manager. iLO sends community based traps as SNMPv1
+// traps and forwards to a single remote syslog server over UDP.
+func (r *RedfishBMC) setHPEForwarding(config ForwardingConfig) error {
+	if len(config.SyslogTargets) > 1 {
+		return errors.New("iLO supports a single remote syslog target")
+	}
+	for _, target := range config.SyslogTargets {
+		if target.Protocol == SyslogProtocolTCP {
+			return errors.New("iLO only forwards to remote syslog targets over UDP")
+		}
+	}
+	managerURI, err := r.managerURI()
+	if err != nil {
+		return err
+	}
+
+	destinationsURI := managerURI + "/SnmpService/SNMPAlertDestinations"
+	var destinations struct {
+		Members common.Links `json:"Members"`
+	}
+	if err := r.getJSON(destinationsURI, &destinations); err != nil {
+		return fmt.Errorf("failed to get SNMP alert destinations: %w", err)
+	}
+	for _, member := range destinations.Members {
+		if err := r.deleteResource(member.String()); err != nil {
+			return fmt.Errorf("failed to delete SNMP alert destination %s: %w", member, err)
+		}
+	}
+	for _, destination := range config.SNMPTrapDestinations {
+		if err := r.postResource(destinationsURI, map[string]any{
+			"AlertDestination":  destination.Address,
+			"SNMPAlertProtocol": "SNMPv1Trap",
+			"TrapCommunity":     destination.Community,
+		}); err != nil {
+			return fmt.Errorf("failed to create SNMP alert destination %s: %w", destination.Address, err)
+		}
+	}
+
+	syslog := map[string]any{"RemoteSyslogEnabled": len(config.SyslogTargets) > 0}
+	for _, target := range config.SyslogTargets {
+		syslog["RemoteSyslogServer"] = target.Address
+		syslog["RemoteSyslogPort"] = target.Port
+	}
+	if err := r.patchResource(managerURI+"/NetworkProtocol", map[string]any{
+		"Oem": map[string]any{"Hpe": syslog},
+	}); err != nil {
+		return fmt.Errorf("failed to configure remote syslog: %w", err)
+	}
+	return nil
+}
+
+// setDellForwarding configures the SNMPAlert, SNMP and SysLog attributes of iDRAC. iDRAC sends all traps with the
+// same format and community to the same port, and forwards to all remote syslog servers on the same port.
+func (r *RedfishBMC) setDellForwarding(config ForwardingConfig) error {
+	if len(config.SNMPTrapDestinations) > dellSNMPAlertDestinations {
+		return fmt.Errorf("iDRAC supports at most %d SNMP trap destinations", dellSNMPAlertDestinations)
+	}
+	if len(config.SyslogTargets) > dellSyslogServers {
+		return fmt.Errorf("iDRAC supports at most %d remote syslog targets", dellSyslogServers)
+	}
+	managerURI, err := r.managerURI()
+	if err != nil {
+		return err
+	}
+
+	attributes := map[string]any{}
+	for i := range dellSNMPAlertDestinations {
+		prefix := fmt.Sprintf("SNMPAlert.%d.", i+1)
+		if i >= len(config.SNMPTrapDestinations) {
+			attributes[prefix+"State"] = "Disabled"
+			continue
+		}
+		destination := config.SNMPTrapDestinations[i]
+		first := config.SNMPTrapDestinations[0]
+		if destination.Port != first.Port || destination.Version != first.Version || destination.Community != first.Community {
+			return errors.New("iDRAC requires the same port, version and community for all SNMP trap destinations")
+		}
+		attributes[prefix+"Destination"] = destination.Address
+		attributes[prefix+"State"] = "Enabled"
+	}
+	if len(config.SNMPTrapDestinations) > 0 {
+		first := config.SNMPTrapDestinations[0]
+		attributes["SNMP.1.AlertPort"] = first.Port
+		attributes["SNMP.1.TrapFormat"] = map[SNMPVersion]string{
+			SNMPVersionV1:  "SNMPv1",
+			SNMPVersionV2c: "SNMPv2",
+		}[first.Version]
+		if first.Community != "" {
+			attributes["SNMP.1.AgentCommunity"] = first.Community
+		}
+	}
+
+	attributes["SysLog.1.SysLogEnable"] = "Disabled"
+	for i := range dellSyslogServers {
+		server := ""
+		if i < len(config.SyslogTargets) {
+			target := config.SyslogTargets[i]
+			first := config.SyslogTargets[0]
+			if target.Port != first.Port || target.Protocol != first.Protocol {
+				return errors.New("iDRAC requires the same port and protocol for all remote syslog targets")
+			}
+			server = target.Address
+		}
+		attributes[fmt.Sprintf("SysLog.1.Server%d", i+1)] = server
+	}
+	if len(config.SyslogTargets) > 0 {
+		attributes["SysLog.1.SysLogEnable"] = "Enabled"
+		attributes["SysLog.1.Port"] = config.SyslogTargets[0].Port
+	}
+
+	if err := r.patchResource(managerURI+"/Attributes", map[string]any{"Attributes": attributes}); err != nil {
+		return fmt.Errorf("failed to set iDRAC forwarding attributes: %w", err)
+	}
+	return nil
+}
+
+// managerURI returns the URI of the first manager of the BMC.
+func (r *RedfishBMC) managerURI() (string, error) {
+	managers, err := r.client.Service.Managers()
+	if err != nil {
+		return "", fmt.Errorf("failed to get managers: %w", err)
+	}
+	if len(managers) == 0 {
+		return "", errors.New("no manager found")
+	}
+	return managers[0].ODataID, nil
+}
+
+func (r *RedfishBMC) postResource(uri string, payload any) error {
+	resp, err := r.client.Post(uri, payload)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (r *RedfishBMC) patchResource(uri string, payload any) error {
+	resp, err := r.client.Patch(uri, payload)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (r *RedfishBMC) deleteResource(uri string) error {
+	resp, err := r.client.Delete(uri)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func hostPort(address string, port int32) string {
+	return net.JoinHostPort(address, strconv.Itoa(int(port)))
+}
@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+// ChassisFRUs contains the field replaceable power supplies and fans of the chassis of a system together with the
+// redundancy groups they form.
+type ChassisFRUs struct {
+	// PowerSupplies are the power supplies of the chassis.
+	PowerSupplies []PowerSupply `json:"powerSupplies,omitempty"`
+	// PowerRedundancy are the redundancy groups of the power supplies.
+	PowerRedundancy []Redundancy `json:"powerRedundancy,omitempty"`
+	// Fans are the fans of the chassis.
+	Fans []Fan `json:"fans,omitempty"`
+	// FanRedundancy are the redundancy groups of the fans.
+	FanRedundancy []Redundancy `json:"fanRedundancy,omitempty"`
+}
+
+// PowerSupply represents a power supply of a chassis.
+type PowerSupply struct {
+	Entity
+	// Manufacturer specifies the manufacturer of the power supply.
+	Manufacturer string `json:"manufacturer,omitempty"`
+	// Model specifies the model of the power supply.
+	Model string `json:"model,omitempty"`
+	// SerialNumber specifies the serial number of the power supply.
+	SerialNumber string `json:"serialNumber,omitempty"`
+	// PartNumber specifies the part number of the power supply.
+	PartNumber string `json:"partNumber,omitempty"`
+	// FirmwareVersion specifies the firmware version of the power supply.
+	FirmwareVersion string `json:"firmwareVersion,omitempty"`
+	// PowerCapacityWatts specifies the maximum power the power supply can deliver.
+	PowerCapacityWatts float32 `json:"powerCapacityWatts,omitempty"`
+	// State specifies the state of the power supply.
+	State common.State `json:"state,omitempty"`
+	// Health specifies the health of the power supply.
+	Health common.Health `json:"health,omitempty"`
+}
+
+// Fan represents a fan of a chassis.
+type Fan struct {
+	Entity
+	// Manufacturer specifies the manufacturer of the fan.
+	Manufacturer string `json:"manufacturer,omitempty"`
+	// Model specifies the model of the fan.
+	Model string `json:"model,omitempty"`
+	// SerialNumber specifies the serial number of the fan.
+	SerialNumber string `json:"serialNumber,omitempty"`
+	// PartNumber specifies the part number of the fan.
+	PartNumber string `json:"partNumber,omitempty"`
+	// State specifies the state of the fan.
+	State common.State `json:"state,omitempty"`
+	// Health specifies the health of the fan.
+	Health common.Health `json:"health,omitempty"`
+}
+
+// Redundancy represents a redundancy group of power supplies or fans.
+type Redundancy struct {
+	// Name is the name of the redundancy group.
+	Name string `json:"name,omitempty"`
+	// Mode specifies the redundancy mode of the group, e.g. N+m.
+	Mode redfish.RedundancyMode `json:"mode,omitempty"`
+	// MinNumNeeded specifies the minimum number of members needed for the group to be redundant.
+	MinNumNeeded int `json:"minNumNeeded,omitempty"`
+	// MaxNumSupported specifies the maximum number of members of the group.
+	MaxNumSupported int `json:"maxNumSupported,omitempty"`
+	// State specifies the state of the group.
+	State common.State `json:"state,omitempty"`
+	// Health specifies the health of the group. It is degraded once the group has lost its redundancy.
+	Health common.Health `json:"health,omitempty"`
+}
+
+// GetChassisFRUs returns the power supplies and fans of the chassis of the system. They are read from the Power and
+// Thermal resources of the chassis. Chassis without these resources contribute no power supplies or fans.
+func (r *RedfishBMC) GetChassisFRUs(ctx context.Context, systemUUID string) (ChassisFRUs, error) {
+	system, err := r.getSystemByUUID(ctx, systemUUID)
+	if err != nil {
+		return ChassisFRUs{}, err
+	}
+	var frus ChassisFRUs
+	for _, uri := range systemChassisURIs(system) {
+		var chassis struct {
+			Power   common.Link `json:"Power"`
+			Thermal common.Link `json:"Thermal"`
+		}
+		if err := r.getJSON(uri, &chassis); err != nil {
+			return ChassisFRUs{}, fmt.Errorf("failed to get chassis %s: %w", uri, err)
+		}
+
+		if chassis.Power != "" {
+			var power struct {
+				PowerSupplies []redfish.PowerSupply `json:"PowerSupplies"`
+				Redundancy    []redfish.Redundancy  `json:"Redundancy"`
+			}
+			if err := r.getJSON(chassis.Power.String(), &power); err != nil {
+				return ChassisFRUs{}, fmt.Errorf("failed to get power of chassis %s: %w", uri, err)
+			}
+			for _, psu := range power.PowerSupplies {
+				frus.PowerSupplies = append(frus.PowerSupplies, PowerSupply{
+					Entity:             Entity{ID: psu.MemberID, Name: fruName(psu.Name, psu.MemberID)},
+					Manufacturer:       psu.Manufacturer,
+					Model:              psu.Model,
+					SerialNumber:       psu.SerialNumber,
+					PartNumber:         psu.PartNumber,
+					FirmwareVersion:    psu.FirmwareVersion,
+					PowerCapacityWatts: psu.PowerCapacityWatts,
+					State:              psu.Status.State,
+					Health:             psu.Status.Health,
+				})
+			}
+			frus.PowerRedundancy = append(frus.PowerRedundancy, redundancyGroups(power.Redundancy)...)
+		}
+
+		if chassis.Thermal != "" {
+			var thermal struct {
+				Fans       []redfish.ThermalFan `json:"Fans"`
+				Redundancy []redfish.Redundancy `json:"Redundancy"`
+			}
+			if err := r.getJSON(chassis.Thermal.String(), &thermal); err != nil {
+				return ChassisFRUs{}, fmt.Errorf("failed to get thermal of chassis %s: %w", uri, err)
+			}
+			for _, fan := range thermal.Fans {
+				frus.Fans = append(frus.Fans, Fan{
+					Entity:       Entity{ID: fan.MemberID, Name: fruName(fan.Name, fan.MemberID)},
+					Manufacturer: fan.Manufacturer,
+					Model:        fan.Model,
+					SerialNumber: fan.SerialNumber,
+					PartNumber:   fan.PartNumber,
+					State:        fan.Status.State,
+					Health:       fan.Status.Health,
+				})
+			}
+			frus.FanRedundancy = append(frus.FanRedundancy, redundancyGroups(thermal.Redundancy)...)
+		}
+	}
+	return frus, nil
+}
+
+// systemChassisURIs returns the URIs of the chassis the system is contained in.
+func systemChassisURIs(system *redfish.ComputerSystem) []string {
+	var raw struct {
+		Links struct {
+			Chassis []common.Link `json:"Chassis"`
+		} `json:"Links"`
+	}
+	if err := json.Unmarshal(system.RawData, &raw); err != nil {
+		return nil
+	}
+	uris := make([]string, 0, len(raw.Links.Chassis))
+	for _, link := range raw.Links.Chassis {
+		uris = append(uris, link.String())
+	}
+	return uris
+}
+
+func redundancyGroups(groups []redfish.Redundancy) []Redundancy {
+	result := make([]Redundancy, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, Redundancy{
+			Name:            fruName(group.Name, group.MemberID),
+			Mode:            group.Mode,
+			MinNumNeeded:    group.MinNumNeeded,
+			MaxNumSupported: group.MaxNumSupported,
+			State:           group.Status.State,
+			Health:          group.Status.Health,
+		})
+	}
+	return result
+}
+
+// fruName returns the name of a member of a Power or Thermal resource, falling back to its member ID for BMCs which
+// do not name their members.
+func fruName(name, memberID string) string {
+	if name != "" {
+		return name
+	}
+	return memberID
+}
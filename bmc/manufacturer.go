@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Canonical names of the manufacturers of servers and BMCs.
+const (
+	ManufacturerDell       = "Dell"
+	ManufacturerHPE        = "HPE"
+	ManufacturerLenovo     = "Lenovo"
+	ManufacturerSupermicro = "Supermicro"
+	ManufacturerCisco      = "Cisco"
+	ManufacturerFujitsu    = "Fujitsu"
+	ManufacturerGigabyte   = "Gigabyte"
+	ManufacturerInspur     = "Inspur"
+	ManufacturerQuanta     = "Quanta"
+	ManufacturerASRockRack = "ASRockRack"
+	ManufacturerNVIDIA     = "NVIDIA"
+)
+
+// manufacturerAliases maps the manufacturer strings reported by BMCs, in lower case and without legal suffixes, to
+// the canonical manufacturer names.
+var manufacturerAliases = map[string]string{
+	"dell":                         ManufacturerDell,
+	"dell emc":                     ManufacturerDell,
+	"dellemc":                      ManufacturerDell,
+	"hpe":                          ManufacturerHPE,
+	"hp":                           ManufacturerHPE,
+	"hewlett packard enterprise":   ManufacturerHPE,
+	"hewlett-packard enterprise":   ManufacturerHPE,
+	"hewlett packard":              ManufacturerHPE,
+	"hewlett-packard":              ManufacturerHPE,
+	"lenovo":                       ManufacturerLenovo,
+	"supermicro":                   ManufacturerSupermicro,
+	"super micro":                  ManufacturerSupermicro,
+	"super micro computer":         ManufacturerSupermicro,
+	"cisco":                        ManufacturerCisco,
+	"cisco systems":                ManufacturerCisco,
+	"fujitsu":                      ManufacturerFujitsu,
+	"fujitsu technology solutions": ManufacturerFujitsu,
+	"gigabyte":                     ManufacturerGigabyte,
+	"giga-byte technology":         ManufacturerGigabyte,
+	"gigabyte technology":          ManufacturerGigabyte,
+	"inspur":                       ManufacturerInspur,
+	"quanta":                       ManufacturerQuanta,
+	"quanta cloud technology":      ManufacturerQuanta,
+	"quanta computer":              ManufacturerQuanta,
+	"asrockrack":                   ManufacturerASRockRack,
+	"asrock rack":                  ManufacturerASRockRack,
+	"nvidia":                       ManufacturerNVIDIA,
+}
+
+// manufacturerLegalSuffixes are the legal forms vendors append to their names, e.g. "Dell Inc.".
+var manufacturerLegalSuffixes = []string{"inc", "incorporated", "corp", "corporation", "co", "company", "ltd", "llc", "gmbh", "limited"}
+
+// lenovoMachineTypeSuffix matches the machine type Lenovo appends to its models, e.g. "ThinkSystem SR650 -[7X06CTO1WW]-".
+var lenovoMachineTypeSuffix = regexp.MustCompile(`\s*-\[[^\]]*\]-\s*$`)
+
+// NormalizeManufacturer returns the canonical name of the manufacturer, e.g. "Dell" for "Dell Inc." or "DELL".
+// Manufacturers which are no alias themselves are looked up by their first word, e.g. "Lenovo Global Technology".
+// Unknown manufacturers are returned without legal suffixes and with collapsed whitespace.
+func NormalizeManufacturer(manufacturer string) string {
+	key := manufacturerKey(manufacturer)
+	if canonical, ok := manufacturerAliases[key]; ok {
+		return canonical
+	}
+	if first, _, ok := strings.Cut(key, " "); ok {
+		if canonical, ok := manufacturerAliases[first]; ok {
+			return canonical
+		}
+	}
+	fields := strings.Fields(manufacturer)
+	for len(fields) > 1 && slices.Contains(manufacturerLegalSuffixes, strings.ToLower(strings.Trim(fields[len(fields)-1], ",."))) {
+		fields = fields[:len(fields)-1]
+	}
+	return strings.TrimRight(strings.Join(fields, " "), ",")
+}
+
+// manufacturerKey returns the lower case manufacturer without punctuation and legal suffixes.
+func manufacturerKey(manufacturer string) string {
+	fields := strings.Fields(strings.NewReplacer(",", " ", ".", " ").Replace(strings.ToLower(manufacturer)))
+	for len(fields) > 1 && slices.Contains(manufacturerLegalSuffixes, fields[len(fields)-1]) {
+		fields = fields[:len(fields)-1]
+	}
+	return strings.Join(fields, " ")
+}
+
+// NormalizeModel returns the canonical name of a model of the manufacturer. The name of the manufacturer and the
+// machine type of Lenovo models are stripped, e.g. "PowerEdge R650" for "DELL PowerEdge R650".
+func NormalizeModel(manufacturer, model string) string {
+	model = strings.Join(strings.Fields(model), " ")
+	canonical := NormalizeManufacturer(manufacturer)
+	if canonical == ManufacturerLenovo {
+		model = lenovoMachineTypeSuffix.ReplaceAllString(model, "")
+	}
+	if prefix, rest, ok := strings.Cut(model, " "); ok && strings.EqualFold(NormalizeManufacturer(prefix), canonical) {
+		model = rest
+	}
+	return model
+}
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc_test
+
+import (
+	"github.com/ironcore-dev/metal-operator/bmc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manufacturer normalization", func() {
+	DescribeTable("should map manufacturers to their canonical names",
+		func(manufacturer, canonical string) {
+			Expect(bmc.NormalizeManufacturer(manufacturer)).To(Equal(canonical))
+		},
+		Entry("Dell Inc.", "Dell Inc.", bmc.ManufacturerDell),
+		Entry("DELL", "DELL", bmc.ManufacturerDell),
+		Entry("Hewlett Packard Enterprise", "Hewlett Packard Enterprise", bmc.ManufacturerHPE),
+		Entry("HPE", "HPE", bmc.ManufacturerHPE),
+		Entry("Super Micro Computer, Inc.", "Super Micro Computer, Inc.", bmc.ManufacturerSupermicro),
+		Entry("Lenovo Global Technology", "Lenovo Global Technology", bmc.ManufacturerLenovo),
+		Entry("GIGA-BYTE TECHNOLOGY CO., LTD.", "GIGA-BYTE TECHNOLOGY CO., LTD.", bmc.ManufacturerGigabyte),
+		Entry("unknown manufacturers", "  Acme   Servers ", "Acme Servers"),
+		Entry("unknown manufacturers with legal suffixes", "Acme Servers, Inc.", "Acme Servers"),
+	)
+
+	DescribeTable("should strip the manufacturer from models",
+		func(manufacturer, model, canonical string) {
+			Expect(bmc.NormalizeModel(manufacturer, model)).To(Equal(canonical))
+		},
+		Entry("Dell", "Dell Inc.", "PowerEdge R650", "PowerEdge R650"),
+		Entry("HPE prefix", "HPE", "HPE ProLiant DL380 Gen10", "ProLiant DL380 Gen10"),
+		Entry("HP prefix", "Hewlett Packard Enterprise", "HP ProLiant DL360 Gen9", "ProLiant DL360 Gen9"),
+		Entry("Lenovo machine type", "Lenovo", "ThinkSystem SR650 -[7X06CTO1WW]-", "ThinkSystem SR650"),
+		Entry("Supermicro", "Supermicro", "Super Server", "Super Server"),
+	)
+})
@@ -0,0 +1,215 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+// Flavor identifies a Redfish implementation whose quirks are handled by the RedfishBMC.
+type Flavor string
+
+const (
+	// FlavorGeneric is a Redfish implementation without known quirks.
+	FlavorGeneric Flavor = "Generic"
+	// FlavorOpenBMC is the bmcweb Redfish implementation of OpenBMC.
+	FlavorOpenBMC Flavor = "OpenBMC"
+	// FlavorHPE is the Redfish implementation of HPE iLO.
+	FlavorHPE Flavor = "HPE"
+	// FlavorDell is the Redfish implementation of Dell iDRAC.
+	FlavorDell Flavor = "Dell"
+)
+
+// flavorVendors maps the lowercase vendors and OEM extension keys of the service root to the flavors.
+var flavorVendors = map[string]Flavor{
+	"openbmc": FlavorOpenBMC,
+	"hpe":     FlavorHPE,
+	"hp":      FlavorHPE,
+	"dell":    FlavorDell,
+}
+
+// OpenBMCDefaultApplyTime is the apply time of firmware updates on OpenBMC if none is requested. Some OpenBMC
+// builds default to OnReset, which completes the update task without activating the image.
+const OpenBMCDefaultApplyTime = "Immediate"
+
+// detectFlavor determines the Redfish implementation from the service root.
+func detectFlavor(service *gofish.Service) Flavor {
+	if flavor, ok := flavorVendors[strings.ToLower(service.Vendor)]; ok {
+		return flavor
+	}
+	var oem map[string]json.RawMessage
+	if err := json.Unmarshal(service.Oem, &oem); err == nil {
+		for key := range oem {
+			if flavor, ok := flavorVendors[strings.ToLower(key)]; ok {
+				return flavor
+			}
+		}
+	}
+	return FlavorGeneric
+}
+
+// Flavor returns the detected Redfish implementation of the BMC.
+func (r *RedfishBMC) Flavor() Flavor {
+	return r.flavor
+}
+
+// pushFirmwareUpdate uploads the firmware image to the MultipartHttpPushUri of the UpdateService. OpenBMC does
+// not fetch images itself for most transfer protocols, so the image is downloaded and pushed to the BMC.
+func (r *RedfishBMC) pushFirmwareUpdate(ctx context.Context, updateService *redfish.UpdateService, params FirmwareUpdateParameters) (*http.Response, error) {
+	var service struct {
+		MultipartHTTPPushURI string `json:"MultipartHttpPushUri"`
+	}
+	if err := json.Unmarshal(updateService.RawData, &service); err != nil {
+		return nil, fmt.Errorf("failed to parse update service: %w", err)
+	}
+	if service.MultipartHTTPPushURI == "" {
+		return nil, errors.New("update service does not support multipart HTTP push updates")
+	}
+
+	imageURL, err := firmwareImageURL(params.ImageURI, params.TransferProtocol)
+	if err != nil {
+		return nil, err
+	}
+
+	applyTime := params.ApplyTime
+	if applyTime == "" {
+		applyTime = OpenBMCDefaultApplyTime
+	}
+	updateParameters, err := json.Marshal(struct {
+		Targets   []string `json:"Targets,omitempty"`
+		ApplyTime string   `json:"@Redfish.OperationApplyTime"`
+	}{Targets: params.Targets, ApplyTime: applyTime})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal update parameters: %w", err)
+	}
+
+	// the multipart payload is buffered in a temporary file, as the client requires a seekable payload and
+	// firmware images can be large
+	payload, err := os.CreateTemp("", "firmware-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create firmware payload file: %w", err)
+	}
+	defer func() {
+		_ = payload.Close()
+		_ = os.Remove(payload.Name())
+	}()
+	writer := multipart.NewWriter(payload)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="UpdateParameters"`)
+	header.Set("Content-Type", "application/json")
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create update parameters part: %w", err)
+	}
+	if _, err := part.Write(updateParameters); err != nil {
+		return nil, fmt.Errorf("failed to write update parameters: %w", err)
+	}
+	header = textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="UpdateFile"; filename=%q`, path.Base(imageURL.Path)))
+	header.Set("Content-Type", "application/octet-stream")
+	part, err = writer.CreatePart(header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create update file part: %w", err)
+	}
+	if err := downloadImage(ctx, imageURL, r.options.MaxFirmwareImageSize, part); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write firmware payload: %w", err)
+	}
+	if _, err := payload.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to rewind firmware payload: %w", err)
+	}
+	return r.client.RunRawRequestWithHeaders(http.MethodPost, service.MultipartHTTPPushURI, payload,
+		writer.FormDataContentType(), nil)
+}
+
+// firmwareImageResponseTimeout bounds the time until the server of a firmware image responds. The download itself
+// is bound by the deadline of the firmware update.
+const firmwareImageResponseTimeout = time.Minute
+
+var firmwareImageClient = &http.Client{Transport: func() http.RoundTripper {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = firmwareImageResponseTimeout
+	return transport
+}()}
+
+// firmwareImageURL returns the URL the firmware image is downloaded from. The transfer protocol completes image
+// URIs without a scheme and has to match the scheme otherwise. Only HTTP and HTTPS are supported.
+func firmwareImageURL(imageURI, transferProtocol string) (*url.URL, error) {
+	u, err := url.Parse(imageURI)
+	if err == nil && u.Scheme == "" && transferProtocol != "" {
+		u, err = url.Parse(strings.ToLower(transferProtocol) + "://" + imageURI)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse firmware image URI: %w", err)
+	}
+	if transferProtocol != "" && !strings.EqualFold(transferProtocol, u.Scheme) {
+		return nil, fmt.Errorf("transfer protocol %s does not match the firmware image URI", transferProtocol)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("transfer protocol %q is not supported for pushed firmware updates", u.Scheme)
+	}
+	return u, nil
+}
+
+func downloadImage(ctx context.Context, imageURL *url.URL, maxSize int64, w io.Writer) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create firmware image request: %w", err)
+	}
+	resp, err := firmwareImageClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download firmware image: %w", err)
+	}
+	defer resp.Body.Close() // nolint: errcheck
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download firmware image: %s", resp.Status)
+	}
+	if resp.ContentLength > maxSize {
+		return fmt.Errorf("firmware image of %d bytes exceeds the maximum size of %d bytes", resp.ContentLength, maxSize)
+	}
+	n, err := io.Copy(w, io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return fmt.Errorf("failed to download firmware image: %w", err)
+	}
+	if n > maxSize {
+		return fmt.Errorf("firmware image exceeds the maximum size of %d bytes", maxSize)
+	}
+	return nil
+}
+
+// normalizeTaskURI returns the URI of the task resource for the task monitor URI returned by OpenBMC, which
+// only reports the task while it is running.
+func (r *RedfishBMC) normalizeTaskURI(taskURI string) string {
+	if r.flavor == FlavorOpenBMC {
+		return strings.TrimSuffix(taskURI, "/Monitor")
+	}
+	return taskURI
+}
+
+// normalizeAttributeType returns the lower case Redfish attribute type of a BIOS attribute registry entry. Some
+// OpenBMC builds report the D-Bus types of the BIOS configuration manager, e.g.
+// "xyz.openbmc_project.BIOSConfig.Manager.AttributeType.Enumeration".
+func normalizeAttributeType(attributeType string) string {
+	if i := strings.LastIndex(attributeType, "."); i >= 0 {
+		attributeType = attributeType[i+1:]
+	}
+	return strings.ToLower(attributeType)
+}
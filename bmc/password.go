@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/stmcginnis/gofish/common"
+)
+
+const (
+	passwordLowerCharacters = "abcdefghijklmnopqrstuvwxyz"
+	passwordUpperCharacters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordDigits          = "0123456789"
+)
+
+// PasswordPolicy describes the passwords accepted by the BMCs of a vendor.
+type PasswordPolicy struct {
+	// Length is the length of generated passwords. It has to be within the limits of the vendor.
+	Length int
+	// SpecialCharacters are the special characters accepted by the vendor. At least one of them is used in
+	// every generated password.
+	SpecialCharacters string
+}
+
+// DefaultPasswordPolicy is used for BMCs of vendors without an entry in the password policy table.
+var DefaultPasswordPolicy = PasswordPolicy{Length: 16, SpecialCharacters: "-_"}
+
+// passwordPolicies maps the canonical manufacturer names to the password policies of the vendors. The policies stay
+// well within the documented limits of the vendors, e.g. iDRAC accepts at most 20 characters on older firmware
+// versions.
+var passwordPolicies = map[string]PasswordPolicy{
+	ManufacturerDell:       {Length: 20, SpecialCharacters: "-_.!#%+?@"},
+	ManufacturerHPE:        {Length: 24, SpecialCharacters: "-_.!#%+?@"},
+	ManufacturerLenovo:     {Length: 20, SpecialCharacters: "-_.!#%+?@"},
+	ManufacturerSupermicro: {Length: 16, SpecialCharacters: "-_.!#%+"},
+}
+
+// PasswordPolicyForManufacturer returns the password policy for the BMCs of the given manufacturer.
+func PasswordPolicyForManufacturer(manufacturer string) PasswordPolicy {
+	if policy, ok := passwordPolicies[NormalizeManufacturer(manufacturer)]; ok {
+		return policy
+	}
+	return DefaultPasswordPolicy
+}
+
+// GeneratePassword generates a random password satisfying the policy. Every password contains at least one
+// lowercase letter, one uppercase letter, one digit and, if the policy has any, one special character.
+func (p PasswordPolicy) GeneratePassword() (string, error) {
+	classes := []string{passwordLowerCharacters, passwordUpperCharacters, passwordDigits}
+	if p.SpecialCharacters != "" {
+		classes = append(classes, p.SpecialCharacters)
+	}
+	if p.Length < len(classes) {
+		return "", fmt.Errorf("password length %d is too short", p.Length)
+	}
+
+	all := strings.Join(classes, "")
+	password := make([]byte, p.Length)
+	for i := range password {
+		// the first characters are taken from each class, so that every class is used at least once
+		characters := all
+		if i < len(classes) {
+			characters = classes[i]
+		}
+		c, err := randomCharacter(characters)
+		if err != nil {
+			return "", err
+		}
+		password[i] = c
+	}
+
+	// shuffle the password, so that the character classes do not appear at fixed positions
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate password: %w", err)
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+	return string(password), nil
+}
+
+func randomCharacter(characters string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(characters))))
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate password: %w", err)
+	}
+	return characters[n.Int64()], nil
+}
+
+// passwordChangeRequiredMessage is the suffix of the message ID of the Base registry with which BMCs reject
+// requests until the password of the account has been changed.
+const passwordChangeRequiredMessage = ".PasswordChangeRequired"
+
+// PasswordChangeRequired reports whether the error is a rejection by a BMC which requires the password of the
+// account to be changed before granting access, e.g. on the first login with factory-default credentials. The
+// URI of the account whose password has to be changed is returned if the BMC reported it.
+func PasswordChangeRequired(err error) (accountURI string, required bool) {
+	// collections report the errors of their members wrapped into a single error
+	var collectionErr *common.CollectionError
+	if errors.As(err, &collectionErr) {
+		for _, failure := range collectionErr.Failures {
+			if accountURI, required = PasswordChangeRequired(failure); required {
+				return accountURI, true
+			}
+		}
+		return "", false
+	}
+	var redfishErr *common.Error
+	if !errors.As(err, &redfishErr) {
+		return "", false
+	}
+	required = strings.HasSuffix(redfishErr.Code, passwordChangeRequiredMessage)
+	for _, info := range redfishErr.ExtendedInfos {
+		if !strings.HasSuffix(info.MessageID, passwordChangeRequiredMessage) {
+			continue
+		}
+		required = true
+		if len(info.MessageArgs) > 0 {
+			accountURI = info.MessageArgs[0]
+		}
+	}
+	return accountURI, required
+}
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc_test
+
+import (
+	"errors"
+
+	"github.com/ironcore-dev/metal-operator/bmc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PasswordPolicy", func() {
+	It("should select the policy of the vendor", func() {
+		Expect(bmc.PasswordPolicyForManufacturer("Dell Inc.").Length).To(Equal(20))
+		Expect(bmc.PasswordPolicyForManufacturer("Unknown")).To(Equal(bmc.DefaultPasswordPolicy))
+	})
+
+	It("should generate passwords satisfying the policy", func() {
+		policy := bmc.PasswordPolicy{Length: 12, SpecialCharacters: "#"}
+		for range 20 {
+			password, err := policy.GeneratePassword()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(password).To(HaveLen(12))
+			Expect(password).To(SatisfyAll(
+				MatchRegexp(`[a-z]`),
+				MatchRegexp(`[A-Z]`),
+				MatchRegexp(`[0-9]`),
+				ContainSubstring("#"),
+				MatchRegexp(`^[a-zA-Z0-9#]+$`),
+			))
+		}
+	})
+
+	It("should reject lengths shorter than the number of character classes", func() {
+		_, err := bmc.PasswordPolicy{Length: 3, SpecialCharacters: "#"}.GeneratePassword()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should only report password changes required by the BMC", func() {
+		_, required := bmc.PasswordChangeRequired(errors.New("PasswordChangeRequired"))
+		Expect(required).To(BeFalse())
+		_, required = bmc.PasswordChangeRequired(nil)
+		Expect(required).To(BeFalse())
+	})
+})
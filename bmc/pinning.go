@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrCertificateMismatch is returned if the certificate of a BMC does not match its pinned fingerprint.
+var ErrCertificateMismatch = errors.New("certificate does not match the pinned fingerprint")
+
+// TLSConfig returns the TLS configuration for connections to a BMC. BMCs mostly serve self-signed certificates,
+// so the certificate chain is not verified. If a fingerprint is given, e.g. sha256:<hex>, the certificate of the
+// BMC is verified against it on every connection instead. The fingerprint is either the SHA-256 hash of the
+// certificate or of its public key, so that the pin survives a renewal of the certificate with the same key.
+func TLSConfig(fingerprint string) *tls.Config {
+	config := &tls.Config{InsecureSkipVerify: true} // #nosec G402
+	if fingerprint == "" {
+		return config
+	}
+	expected := strings.ToLower(strings.TrimPrefix(fingerprint, "sha256:"))
+	config.VerifyConnection = func(state tls.ConnectionState) error {
+		if len(state.PeerCertificates) == 0 {
+			return fmt.Errorf("%w: no certificate presented", ErrCertificateMismatch)
+		}
+		leaf := state.PeerCertificates[0]
+		certificateHash := sha256.Sum256(leaf.Raw)
+		publicKeyHash := sha256.Sum256(leaf.RawSubjectPublicKeyInfo)
+		if hex.EncodeToString(certificateHash[:]) != expected && hex.EncodeToString(publicKeyHash[:]) != expected {
+			return fmt.Errorf("%w: got sha256:%x", ErrCertificateMismatch, certificateHash)
+		}
+		return nil
+	}
+	return config
+}
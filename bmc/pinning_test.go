@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/ironcore-dev/metal-operator/bmc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TLSConfig", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		DeferCleanup(server.Close)
+	})
+
+	get := func(fingerprint string) error {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: bmc.TLSConfig(fingerprint)}}
+		resp, err := client.Get(server.URL)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		return err
+	}
+
+	It("should accept any certificate without a fingerprint", func() {
+		Expect(get("")).To(Succeed())
+	})
+
+	It("should accept the pinned certificate or public key", func() {
+		certificate := server.Certificate()
+		Expect(get(fmt.Sprintf("sha256:%x", sha256.Sum256(certificate.Raw)))).To(Succeed())
+		Expect(get(fmt.Sprintf("sha256:%X", sha256.Sum256(certificate.RawSubjectPublicKeyInfo)))).To(Succeed())
+	})
+
+	It("should reject a certificate which does not match the fingerprint", func() {
+		Expect(get(fmt.Sprintf("sha256:%x", sha256.Sum256([]byte("other"))))).To(MatchError(bmc.ErrCertificateMismatch))
+	})
+})
@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+// ErrReadOnly is returned by a read-only BMC client for operations changing the state of the BMC or its systems.
+var ErrReadOnly = errors.New("BMC client is read-only")
+
+// PlannedWrites collects the writes skipped by the clients of a dry-run, see WithPlannedWrites.
+type PlannedWrites struct {
+	mu      sync.Mutex
+	actions []string
+}
+
+// Record adds the description of a skipped write. Writes which have already been recorded are ignored.
+func (p *PlannedWrites) Record(action string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !slices.Contains(p.actions, action) {
+		p.actions = append(p.actions, action)
+	}
+}
+
+// Actions returns the descriptions of the recorded writes in the order they were planned.
+func (p *PlannedWrites) Actions() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.actions)
+}
+
+type plannedWritesKey struct{}
+
+// WithPlannedWrites returns a context for a dry-run. The BMC clients created with bmcutils.CreateBMCClient for the
+// context record their writes into planned instead of performing them, see NewDryRunBMC.
+func WithPlannedWrites(ctx context.Context, planned *PlannedWrites) context.Context {
+	return context.WithValue(ctx, plannedWritesKey{}, planned)
+}
+
+// PlannedWritesFrom returns the planned writes of the dry-run the context belongs to, or nil outside of dry-runs.
+func PlannedWritesFrom(ctx context.Context) *PlannedWrites {
+	planned, _ := ctx.Value(plannedWritesKey{}).(*PlannedWrites)
+	return planned
+}
+
+// readOnlyBMC rejects or, in a dry-run, records all operations of the wrapped BMC which change the state of the BMC
+// or its systems.
+type readOnlyBMC struct {
+	BMC
+	planned *PlannedWrites
+}
+
+// NewReadOnlyBMC returns a BMC client which only performs the reading operations of the given client and returns
+// ErrReadOnly for all others.
+func NewReadOnlyBMC(bmc BMC) BMC {
+	return &readOnlyBMC{BMC: bmc}
+}
+
+// NewDryRunBMC returns a BMC client which only performs the reading operations of the given client. All others are
+// recorded into planned and reported as successful, so that the caller carries on as if they had been performed.
+func NewDryRunBMC(bmc BMC, planned *PlannedWrites) BMC {
+	return &readOnlyBMC{BMC: bmc, planned: planned}
+}
+
+func (r *readOnlyBMC) skip(format string, args ...any) error {
+	if r.planned == nil {
+		return ErrReadOnly
+	}
+	r.planned.Record(fmt.Sprintf(format, args...))
+	return nil
+}
+
+func (r *readOnlyBMC) PowerOn(_ context.Context, systemUUID string) error {
+	return r.skip("power on system %s", systemUUID)
+}
+
+func (r *readOnlyBMC) PowerOff(_ context.Context, systemUUID string) error {
+	return r.skip("power off system %s", systemUUID)
+}
+
+func (r *readOnlyBMC) ForcePowerOff(_ context.Context, systemUUID string) error {
+	return r.skip("force power off system %s", systemUUID)
+}
+
+func (r *readOnlyBMC) Reset(_ context.Context, systemUUID string, resetType redfish.ResetType) error {
+	return r.skip("reset system %s (%s)", systemUUID, resetType)
+}
+
+// WaitForServerPowerState does not wait in a dry-run, in which the power state is not changed, but assumes that the
+// planned power change succeeded.
+func (r *readOnlyBMC) WaitForServerPowerState(ctx context.Context, systemUUID string, powerState redfish.PowerState) error {
+	if r.planned != nil {
+		return nil
+	}
+	return r.BMC.WaitForServerPowerState(ctx, systemUUID, powerState)
+}
+
+func (r *readOnlyBMC) SetPXEBootOnce(_ context.Context, systemUUID string) error {
+	return r.skip("set PXE boot once on system %s", systemUUID)
+}
+
+func (r *readOnlyBMC) SetDPUMode(_ context.Context, dpuURI string, mode string) error {
+	return r.skip("set mode of DPU %s to %s", dpuURI, mode)
+}
+
+func (r *readOnlyBMC) SetBootMode(_ context.Context, systemUUID string, mode BootMode) error {
+	return r.skip("set boot mode of system %s to %s", systemUUID, mode)
+}
+
+func (r *readOnlyBMC) SetBiosAttributes(_ context.Context, systemUUID string, attributes map[string]string) (bool, error) {
+	return false, r.skip("set BIOS attributes %s of system %s", attributeNames(attributes), systemUUID)
+}
+
+func (r *readOnlyBMC) SetBiosAttributesApplyAt(_ context.Context, systemUUID string, attributes map[string]string, applyTime SettingsApplyTime) (bool, error) {
+	return false, r.skip("set BIOS attributes %s of system %s at %s", attributeNames(attributes), systemUUID, applyTime)
+}
+
+func (r *readOnlyBMC) SetBootOrder(_ context.Context, systemUUID string, order []string) error {
+	return r.skip("set boot order of system %s to %s", systemUUID, strings.Join(order, ", "))
+}
+
+func (r *readOnlyBMC) UpdateFirmware(_ context.Context, params FirmwareUpdateParameters) (string, error) {
+	return "", r.skip("update firmware of %s from %s", strings.Join(params.Targets, ", "), params.ImageURI)
+}
+
+func (r *readOnlyBMC) SetPXEBootOnceWithMode(_ context.Context, systemUUID string, mode redfish.BootSourceOverrideMode) error {
+	return r.skip("set %s PXE boot once on system %s", mode, systemUUID)
+}
+
+func (r *readOnlyBMC) SetPXEBootOnceFromInterfaces(_ context.Context, systemUUID string, mode redfish.BootSourceOverrideMode, interfaces []NetworkBootInterface) error {
+	names := make([]string, 0, len(interfaces))
+	for _, iface := range interfaces {
+		names = append(names, iface.MACAddress)
+	}
+	return r.skip("set %s PXE boot once from %s on system %s", mode, strings.Join(names, ", "), systemUUID)
+}
+
+func (r *readOnlyBMC) ResetManager(_ context.Context, resetType redfish.ResetType) error {
+	return r.skip("reset manager (%s)", resetType)
+}
+
+func (r *readOnlyBMC) SetBiosPassword(_ context.Context, systemUUID, passwordName, _, _ string) error {
+	return r.skip("set BIOS password %s of system %s", passwordName, systemUUID)
+}
+
+func (r *readOnlyBMC) SetDriveLocationIndicator(_ context.Context, systemUUID, driveName string, active bool) error {
+	return r.skip("set location indicator of drive %s of system %s to %t", driveName, systemUUID, active)
+}
+
+func (r *readOnlyBMC) CollectCrashDump(_ context.Context, systemUUID string) error {
+	return r.skip("collect crash dump of system %s", systemUUID)
+}
+
+func (r *readOnlyBMC) SetISCSIBoot(_ context.Context, systemUUID string, params ISCSIBootParameters) error {
+	return r.skip("set iSCSI boot of system %s to target %s", systemUUID, params.TargetName)
+}
+
+func (r *readOnlyBMC) ComposeSystem(_ context.Context, name string, _ []string) (string, string, error) {
+	return "", "", r.skip("compose system %s", name)
+}
+
+func (r *readOnlyBMC) DecomposeSystem(_ context.Context, systemURI string) error {
+	return r.skip("decompose system %s", systemURI)
+}
+
+func (r *readOnlyBMC) SetAccountPassword(_ context.Context, username, _ string) error {
+	return r.skip("set password of account %s", username)
+}
+
+func (r *readOnlyBMC) SetForwarding(_ context.Context, config ForwardingConfig) error {
+	return r.skip("configure %d SNMP trap destinations and %d syslog targets",
+		len(config.SNMPTrapDestinations), len(config.SyslogTargets))
+}
+
+// attributeNames returns the sorted names of the attributes, leaving out their values which may be sensitive.
+func attributeNames(attributes map[string]string) string {
+	return strings.Join(slices.Sorted(maps.Keys(attributes)), ", ")
+}
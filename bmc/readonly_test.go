@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc_test
+
+import (
+	"github.com/ironcore-dev/metal-operator/bmc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+var _ = Describe("ReadOnlyBMC", func() {
+	const systemUUID = "38947555-7742-3448-3784-823347823834"
+
+	var simulator *bmc.Simulator
+
+	BeforeEach(func() {
+		simulator = bmc.NewSimulator()
+		bmc.Simulators.Register("10.0.0.1:8000", simulator)
+		DeferCleanup(bmc.Simulators.Reset)
+	})
+
+	It("should read the state of the BMC, but reject changes", func(ctx SpecContext) {
+		fakeClient, err := bmc.NewRedfishFakeBMCClient(ctx, bmc.BMCOptions{Endpoint: "http://10.0.0.1:8000"})
+		Expect(err).NotTo(HaveOccurred())
+		client := bmc.NewReadOnlyBMC(fakeClient)
+
+		info, err := client.GetSystemInfo(ctx, systemUUID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.PowerState).To(Equal(redfish.OffPowerState))
+
+		Expect(client.PowerOn(ctx, systemUUID)).To(MatchError(bmc.ErrReadOnly))
+		Expect(client.SetPXEBootOnce(ctx, systemUUID)).To(MatchError(bmc.ErrReadOnly))
+		_, err = client.SetBiosAttributes(ctx, systemUUID, map[string]string{"BootMode": "Uefi"})
+		Expect(err).To(MatchError(bmc.ErrReadOnly))
+		Expect(client.ResetManager(ctx, redfish.GracefulRestartResetType)).To(MatchError(bmc.ErrReadOnly))
+
+		Expect(simulator.State().Systems[0].Info.PowerState).To(Equal(redfish.OffPowerState))
+		Expect(client.GetBiosPendingAttributeValues(ctx, systemUUID)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("DryRunBMC", func() {
+	const systemUUID = "38947555-7742-3448-3784-823347823834"
+
+	var simulator *bmc.Simulator
+
+	BeforeEach(func() {
+		simulator = bmc.NewSimulator()
+		bmc.Simulators.Register("10.0.0.1:8000", simulator)
+		DeferCleanup(bmc.Simulators.Reset)
+	})
+
+	It("should record the changes instead of performing them", func(ctx SpecContext) {
+		fakeClient, err := bmc.NewRedfishFakeBMCClient(ctx, bmc.BMCOptions{Endpoint: "http://10.0.0.1:8000"})
+		Expect(err).NotTo(HaveOccurred())
+		planned := &bmc.PlannedWrites{}
+		client := bmc.NewDryRunBMC(fakeClient, planned)
+
+		Expect(client.PowerOn(ctx, systemUUID)).To(Succeed())
+		Expect(client.PowerOn(ctx, systemUUID)).To(Succeed())
+		Expect(client.SetBiosAttributes(ctx, systemUUID, map[string]string{"BootMode": "Uefi", "AdminPhone": "123"})).
+			To(BeFalse())
+		Expect(client.SetBiosPassword(ctx, systemUUID, "AdminPassword", "old", "new")).To(Succeed())
+
+		Expect(planned.Actions()).To(HaveExactElements(
+			"power on system "+systemUUID,
+			"set BIOS attributes AdminPhone, BootMode of system "+systemUUID,
+			"set BIOS password AdminPassword of system "+systemUUID,
+		))
+		Expect(simulator.State().Systems[0].Info.PowerState).To(Equal(redfish.OffPowerState))
+		Expect(client.GetBiosPendingAttributeValues(ctx, systemUUID)).To(BeEmpty())
+	})
+})
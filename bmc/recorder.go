@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRecorderSize is the default number of exchanges kept by a Recorder.
+	DefaultRecorderSize = 100
+
+	// maxRecordedBodySize is the maximum number of bytes recorded of a request or response body. It keeps the
+	// recordings within the size limit of a ConfigMap.
+	maxRecordedBodySize = 4 * 1024
+)
+
+// sensitiveFieldPattern matches JSON string fields which must not be recorded, e.g. the credentials sent when
+// creating a session or an account.
+var sensitiveFieldPattern = regexp.MustCompile(`(?i)("(?:Password|UserName|Token|Secret)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// Exchange is a recorded Redfish request together with its response.
+type Exchange struct {
+	Time         time.Time     `json:"time"`
+	Method       string        `json:"method"`
+	URL          string        `json:"url"`
+	RequestBody  string        `json:"requestBody,omitempty"`
+	StatusCode   int           `json:"statusCode,omitempty"`
+	ResponseBody string        `json:"responseBody,omitempty"`
+	Duration     time.Duration `json:"duration"`
+	Error        string        `json:"error,omitempty"`
+}
+
+// Recorder records sanitized Redfish exchanges into a ring buffer. Headers are not recorded and credentials
+// are redacted from the bodies.
+type Recorder struct {
+	mu        sync.Mutex
+	exchanges []Exchange
+	next      int
+	full      bool
+}
+
+// NewRecorder creates a Recorder keeping the last size exchanges.
+func NewRecorder(size int) *Recorder {
+	if size <= 0 {
+		size = DefaultRecorderSize
+	}
+	return &Recorder{exchanges: make([]Exchange, size)}
+}
+
+// Exchanges returns the recorded exchanges, oldest first.
+func (r *Recorder) Exchanges() []Exchange {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.full {
+		return append([]Exchange(nil), r.exchanges[:r.next]...)
+	}
+	return append(append([]Exchange(nil), r.exchanges[r.next:]...), r.exchanges[:r.next]...)
+}
+
+func (r *Recorder) record(exchange Exchange) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exchanges[r.next] = exchange
+	r.next = (r.next + 1) % len(r.exchanges)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// Transport wraps the given transport to record all exchanges passing through it.
+func (r *Recorder) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &recordingTransport{recorder: r, next: next}
+}
+
+type recordingTransport struct {
+	recorder *Recorder
+	next     http.RoundTripper
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	exchange := Exchange{
+		Time:   time.Now(),
+		Method: req.Method,
+		URL:    req.URL.Path,
+	}
+	if req.Body != nil && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			exchange.RequestBody = readSanitized(body)
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	exchange.Duration = time.Since(exchange.Time)
+	if err != nil {
+		exchange.Error = err.Error()
+		t.recorder.record(exchange)
+		return resp, err
+	}
+
+	exchange.StatusCode = resp.StatusCode
+	if resp.Body != nil {
+		data, readErr := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		if readErr != nil {
+			exchange.Error = readErr.Error()
+		}
+		exchange.ResponseBody = sanitize(data)
+	}
+	t.recorder.record(exchange)
+	return resp, nil
+}
+
+func readSanitized(body io.ReadCloser) string {
+	defer func() {
+		_ = body.Close()
+	}()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+	return sanitize(data)
+}
+
+// sanitize redacts the sensitive fields of the body before truncating it, so that no field cut at the size limit
+// escapes the redaction.
+func sanitize(data []byte) string {
+	sanitized := sensitiveFieldPattern.ReplaceAllString(string(data), `$1"REDACTED"`)
+	if len(sanitized) > maxRecordedBodySize {
+		sanitized = sanitized[:maxRecordedBodySize] + "...(truncated)"
+	}
+	return sanitized
+}
+
+// RecorderRegistry holds a Recorder per BMC.
+type RecorderRegistry struct {
+	mu        sync.Mutex
+	size      int
+	recorders map[string]*Recorder
+}
+
+// NewRecorderRegistry creates a RecorderRegistry whose recorders keep the last size exchanges.
+func NewRecorderRegistry(size int) *RecorderRegistry {
+	return &RecorderRegistry{size: size, recorders: map[string]*Recorder{}}
+}
+
+// Get returns the Recorder for the given name, creating it if necessary.
+func (r *RecorderRegistry) Get(name string) *Recorder {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	recorder, ok := r.recorders[name]
+	if !ok {
+		recorder = NewRecorder(r.size)
+		r.recorders[name] = recorder
+	}
+	return recorder
+}
+
+// Names returns the names of all recorders in the registry.
+func (r *RecorderRegistry) Names() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	names := make([]string, 0, len(r.recorders))
+	for name := range r.recorders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
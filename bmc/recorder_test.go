@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/ironcore-dev/metal-operator/bmc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Recorder", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Auth-Token", "secret-token")
+			_, _ = w.Write([]byte(`{"UserName":"admin","Name":"System"}`))
+		}))
+		DeferCleanup(server.Close)
+	})
+
+	It("should record sanitized exchanges", func() {
+		recorder := bmc.NewRecorder(10)
+		httpClient := &http.Client{Transport: recorder.Transport(nil)}
+
+		resp, err := httpClient.Post(server.URL+"/redfish/v1/SessionService/Sessions", "application/json",
+			strings.NewReader(`{"UserName":"admin","Password":"p\"ssword"}`))
+		Expect(err).NotTo(HaveOccurred())
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Body.Close()).To(Succeed())
+		Expect(string(body)).To(Equal(`{"UserName":"admin","Name":"System"}`))
+
+		Expect(recorder.Exchanges()).To(ConsistOf(SatisfyAll(
+			HaveField("Method", http.MethodPost),
+			HaveField("URL", "/redfish/v1/SessionService/Sessions"),
+			HaveField("StatusCode", http.StatusOK),
+			HaveField("RequestBody", `{"UserName":"REDACTED","Password":"REDACTED"}`),
+			HaveField("ResponseBody", `{"UserName":"REDACTED","Name":"System"}`),
+		)))
+	})
+
+	It("should redact sensitive fields cut at the size limit", func() {
+		recorder := bmc.NewRecorder(10)
+		httpClient := &http.Client{Transport: recorder.Transport(nil)}
+
+		prefix := `{"Name":"` + strings.Repeat("x", 4070) + `",`
+		resp, err := httpClient.Post(server.URL+"/redfish/v1/AccountService/Accounts", "application/json",
+			strings.NewReader(prefix+`"Password":"0123456789abcdefghijklmnopqrstuvwxyz"}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Body.Close()).To(Succeed())
+
+		Expect(recorder.Exchanges()).To(ConsistOf(HaveField("RequestBody", SatisfyAll(
+			HavePrefix(prefix+`"Password":"RED`),
+			HaveSuffix("...(truncated)"),
+			Not(ContainSubstring("0123")),
+		))))
+	})
+
+	It("should only keep the latest exchanges", func() {
+		recorder := bmc.NewRecorder(2)
+		httpClient := &http.Client{Transport: recorder.Transport(nil)}
+
+		for _, path := range []string{"/a", "/b", "/c"} {
+			resp, err := httpClient.Get(server.URL + path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Body.Close()).To(Succeed())
+		}
+
+		Expect(recorder.Exchanges()).To(HaveExactElements(
+			HaveField("URL", "/b"),
+			HaveField("URL", "/c"),
+		))
+	})
+})
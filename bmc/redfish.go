@@ -5,13 +5,19 @@ package bmc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
 	"github.com/stmcginnis/gofish/redfish"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -28,25 +34,96 @@ const (
 	DefaultPowerPollingInterval = 30 * time.Second
 	// DefaultPowerPollingTimeout is the default timeout for polling power state.
 	DefaultPowerPollingTimeout = 5 * time.Minute
+	// DefaultLoginTimeout is the default timeout for connecting and logging in to a BMC.
+	DefaultLoginTimeout = 30 * time.Second
+	// DefaultFirmwareUploadTimeout is the default timeout for triggering a firmware update.
+	DefaultFirmwareUploadTimeout = time.Hour
+	// DefaultSettingsApplyTimeout is the default timeout for applying BIOS settings and the boot order.
+	DefaultSettingsApplyTimeout = 5 * time.Minute
+	// DefaultTaskPollingTimeout is the default timeout for polling the state of a task.
+	DefaultTaskPollingTimeout = 30 * time.Second
+	// DefaultMaxFirmwareImageSize is the default maximum size of the firmware images downloaded to push them to
+	// the BMC.
+	DefaultMaxFirmwareImageSize int64 = 1 << 30
 )
 
 // BMCOptions contains the options for the BMC redfish client.
 type BMCOptions struct {
-	Endpoint  string
-	Username  string
-	Password  string
+	Endpoint string
+	Username string
+	Password string
+	// BasicAuth sends the credentials with every request instead of logging in via a Redfish session. A session
+	// login rejected by the BMC falls back to basic authentication.
 	BasicAuth bool
+	// CredentialProfile selects the credentials of the BMCSecret the client logs in with. If empty, or if the
+	// BMCSecret holds no credentials for the profile, the default credentials are used.
+	CredentialProfile string
+	// CertificateFingerprint pins the certificate of the BMC, see TLSConfig. If empty, any certificate is accepted.
+	CertificateFingerprint string
+	// ReadOnly rejects all operations changing the state of the BMC or its systems, see NewReadOnlyBMC. It is
+	// honored by the clients created with bmcutils.CreateBMCClient.
+	ReadOnly bool
+	// SystemURI is the Redfish URI of the system the client operates on. If set, the system is fetched directly
+	// instead of being looked up among all systems of the BMC, which is costly on aggregators.
+	SystemURI string
 
 	ResourcePollingInterval time.Duration
 	ResourcePollingTimeout  time.Duration
 	PowerPollingInterval    time.Duration
 	PowerPollingTimeout     time.Duration
+
+	// Timeouts are the request timeouts of the different classes of BMC operations.
+	Timeouts OperationTimeouts
+	// SessionKeepAliveInterval is the interval in which the session is refreshed during long-running operations,
+	// e.g. firmware uploads. A zero value selects DefaultSessionKeepAliveInterval, a negative one disables it.
+	SessionKeepAliveInterval time.Duration
+	// MaxFirmwareImageSize is the maximum size in bytes of the firmware images downloaded to push them to BMCs
+	// which do not fetch images themselves, e.g. OpenBMC. A zero value selects DefaultMaxFirmwareImageSize.
+	MaxFirmwareImageSize int64
+
+	// DebugRecorders holds the recorders of the BMCs for which Redfish debug recording is enabled.
+	// If nil, debug recording is disabled.
+	DebugRecorders *RecorderRegistry
+	// Recorder records the Redfish exchanges of the client. If nil, nothing is recorded.
+	Recorder *Recorder
 }
 
 // RedfishBMC is an implementation of the BMC interface for Redfish.
 type RedfishBMC struct {
 	client  *gofish.APIClient
 	options BMCOptions
+	flavor  Flavor
+	// session re-authenticates the client once its session expired.
+	session *sessionTransport
+}
+
+// withTimeout returns a copy of the client whose requests are bound to the context of the operation, cancelled
+// after the timeout. The copy shares the session of the client, so that concurrent and nested operations each
+// keep their own deadline.
+func (r *RedfishBMC) withTimeout(ctx context.Context, timeout time.Duration) (*RedfishBMC, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	client := *r.client
+	client.HTTPClient = &http.Client{Transport: &contextTransport{next: r.client.HTTPClient.Transport, ctx: ctx}}
+	if r.client.Service != nil {
+		// the service root issues the requests of the resources retrieved through it
+		service := *r.client.Service
+		service.SetClient(&client)
+		client.Service = &service
+	}
+	scoped := *r
+	scoped.client = &client
+	return &scoped, cancel
+}
+
+// contextTransport sends requests with the context of the transport instead of the one of the gofish client, which
+// is fixed when the client connects.
+type contextTransport struct {
+	next http.RoundTripper
+	ctx  context.Context
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.next.RoundTrip(req.WithContext(t.ctx))
 }
 
 var pxeBootWithSettingUEFIBootMode = redfish.Boot{
@@ -59,6 +136,34 @@ var pxeBootWithoutSettingUEFIBootMode = redfish.Boot{
 	BootSourceOverrideTarget:  redfish.PxeBootSourceOverrideTarget,
 }
 
+// OperationTimeouts defines the timeouts of the requests sent for the different classes of BMC operations.
+// A zero value selects the default timeout of the class.
+type OperationTimeouts struct {
+	// Login is the timeout for connecting and logging in to the BMC.
+	Login time.Duration
+	// FirmwareUpload is the timeout for triggering a firmware update, which may include the upload of the image.
+	FirmwareUpload time.Duration
+	// SettingsApply is the timeout for applying BIOS settings and the boot order.
+	SettingsApply time.Duration
+	// TaskPolling is the timeout for polling the state of a task.
+	TaskPolling time.Duration
+}
+
+func (t *OperationTimeouts) setDefaults() {
+	if t.Login == 0 {
+		t.Login = DefaultLoginTimeout
+	}
+	if t.FirmwareUpload == 0 {
+		t.FirmwareUpload = DefaultFirmwareUploadTimeout
+	}
+	if t.SettingsApply == 0 {
+		t.SettingsApply = DefaultSettingsApplyTimeout
+	}
+	if t.TaskPolling == 0 {
+		t.TaskPolling = DefaultTaskPollingTimeout
+	}
+}
+
 // NewRedfishBMCClient creates a new RedfishBMC with the given connection details.
 func NewRedfishBMCClient(
 	ctx context.Context,
@@ -71,11 +176,42 @@ func NewRedfishBMCClient(
 		Insecure:  true,
 		BasicAuth: options.BasicAuth,
 	}
+	options.Timeouts.setDefaults()
+	if options.SessionKeepAliveInterval == 0 {
+		options.SessionKeepAliveInterval = DefaultSessionKeepAliveInterval
+	}
+	bmc := &RedfishBMC{}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = TLSConfig(options.CertificateFingerprint)
+	var roundTripper http.RoundTripper = transport
+	if options.Recorder != nil {
+		roundTripper = options.Recorder.Transport(roundTripper)
+	}
+	bmc.session = &sessionTransport{next: roundTripper, username: options.Username, password: options.Password}
+	loginCtx, cancelLogin := context.WithTimeout(ctx, options.Timeouts.Login)
+	clientConfig.HTTPClient = &http.Client{Transport: &contextTransport{next: bmc.session, ctx: loginCtx}}
+
 	client, err := gofish.ConnectContext(ctx, clientConfig)
+	var redfishErr *common.Error
+	if err != nil && !clientConfig.BasicAuth && errors.As(err, &redfishErr) {
+		// BMCs which fail to create a session are accessed via basic authentication instead.
+		clientConfig.BasicAuth = true
+		var basicErr error
+		if client, basicErr = gofish.ConnectContext(ctx, clientConfig); basicErr == nil {
+			err = nil
+		}
+	}
+	cancelLogin()
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to redfish endpoint: %w", err)
 	}
-	bmc := &RedfishBMC{client: client}
+	client.HTTPClient = &http.Client{Transport: bmc.session}
+	bmc.client = client
+	bmc.flavor = detectFlavor(client.GetService())
+	if session, err := client.GetSession(); err == nil && !clientConfig.BasicAuth {
+		bmc.session.track(strings.TrimSuffix(options.Endpoint, "/"), session.ID)
+	}
 	if options.ResourcePollingInterval == 0 {
 		options.ResourcePollingInterval = DefaultResourcePollingInterval
 	}
@@ -88,6 +224,9 @@ func NewRedfishBMCClient(
 	if options.PowerPollingTimeout == 0 {
 		options.PowerPollingTimeout = DefaultPowerPollingTimeout
 	}
+	if options.MaxFirmwareImageSize == 0 {
+		options.MaxFirmwareImageSize = DefaultMaxFirmwareImageSize
+	}
 	bmc.options = options
 
 	return bmc, nil
@@ -154,24 +293,42 @@ func (r *RedfishBMC) Reset(ctx context.Context, systemUUID string, resetType red
 
 // GetSystems get managed systems
 func (r *RedfishBMC) GetSystems(ctx context.Context) ([]Server, error) {
-	service := r.client.GetService()
-	systems, err := service.Systems()
+	systems, _, err := r.listSystems()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get systems: %w", err)
 	}
 	servers := make([]Server, 0, len(systems))
 	for _, s := range systems {
 		servers = append(servers, Server{
+			URI:          s.ODataID,
+			Name:         s.Name,
 			UUID:         s.UUID,
 			Model:        s.Model,
 			Manufacturer: s.Manufacturer,
 			PowerState:   PowerState(s.PowerState),
 			SerialNumber: s.SerialNumber,
+			SystemType:   string(s.SystemType),
 		})
 	}
 	return servers, nil
 }
 
+// SetPXEBootOnceWithMode sets the boot device for the next system boot using the given boot mode.
+func (r *RedfishBMC) SetPXEBootOnceWithMode(ctx context.Context, systemUUID string, mode redfish.BootSourceOverrideMode) error {
+	system, err := r.getSystemByUUID(ctx, systemUUID)
+	if err != nil {
+		return fmt.Errorf("failed to get systems: %w", err)
+	}
+	if err := system.SetBoot(redfish.Boot{
+		BootSourceOverrideEnabled: redfish.OnceBootSourceOverrideEnabled,
+		BootSourceOverrideMode:    mode,
+		BootSourceOverrideTarget:  redfish.PxeBootSourceOverrideTarget,
+	}); err != nil {
+		return fmt.Errorf("failed to set the boot order: %w", r.translateError(OperationSetPXEBootOnce, err))
+	}
+	return nil
+}
+
 // SetPXEBootOnce sets the boot device for the next system boot using Redfish.
 func (r *RedfishBMC) SetPXEBootOnce(ctx context.Context, systemUUID string) error {
 	system, err := r.getSystemByUUID(ctx, systemUUID)
@@ -186,11 +343,196 @@ func (r *RedfishBMC) SetPXEBootOnce(ctx context.Context, systemUUID string) erro
 		setBoot = pxeBootWithoutSettingUEFIBootMode
 	}
 	if err := system.SetBoot(setBoot); err != nil {
+		return fmt.Errorf("failed to set the boot order: %w", r.translateError(OperationSetPXEBootOnce, err))
+	}
+	return nil
+}
+
+// SetPXEBootOnceFromInterfaces sets the UEFI boot option of the first of the given network interfaces which has one
+// as boot device for the next system boot. The option is selected through BootNext if it has a reference, otherwise
+// through its UEFI device path.
+func (r *RedfishBMC) SetPXEBootOnceFromInterfaces(ctx context.Context, systemUUID string, mode redfish.BootSourceOverrideMode, interfaces []NetworkBootInterface) error {
+	system, err := r.getSystemByUUID(ctx, systemUUID)
+	if err != nil {
+		return fmt.Errorf("failed to get systems: %w", err)
+	}
+	options, err := system.BootOptions()
+	if err != nil {
+		return fmt.Errorf("failed to get boot options: %w", err)
+	}
+	option := findNetworkBootOption(options, interfaces)
+	if option == nil {
+		return fmt.Errorf("no network boot option found for the interfaces %v", interfaces)
+	}
+	if mode == "" {
+		// boot options are UEFI boot options
+		mode = redfish.UEFIBootSourceOverrideMode
+	}
+	boot := redfish.Boot{
+		BootSourceOverrideEnabled: redfish.OnceBootSourceOverrideEnabled,
+		BootSourceOverrideMode:    mode,
+	}
+	if option.BootOptionReference != "" {
+		boot.BootSourceOverrideTarget = redfish.UefiBootNextBootSourceOverrideTarget
+		boot.BootNext = option.BootOptionReference
+	} else {
+		boot.BootSourceOverrideTarget = redfish.UefiTargetBootSourceOverrideTarget
+		boot.UefiTargetBootSourceOverride = option.UefiDevicePath
+	}
+	if err := system.SetBoot(boot); err != nil {
 		return fmt.Errorf("failed to set the boot order: %w", err)
 	}
 	return nil
 }
 
+// findNetworkBootOption returns the network boot option of the first of the interfaces which has one. Options
+// booting via IPv4 are preferred over other options of the same interface.
+func findNetworkBootOption(options []*redfish.BootOption, interfaces []NetworkBootInterface) *redfish.BootOption {
+	for _, nic := range interfaces {
+		var match *redfish.BootOption
+		for _, option := range options {
+			if !isNetworkBootOption(option) || !matchesNetworkBootInterface(option, nic) {
+				continue
+			}
+			if strings.Contains(option.UefiDevicePath, "IPv4(") {
+				return option
+			}
+			if match == nil {
+				match = option
+			}
+		}
+		if match != nil {
+			return match
+		}
+	}
+	return nil
+}
+
+func isNetworkBootOption(option *redfish.BootOption) bool {
+	return option.Alias == redfish.PxeBootSourceOverrideTarget ||
+		strings.Contains(option.UefiDevicePath, "MAC(") ||
+		strings.Contains(strings.ToUpper(option.DisplayName), "PXE")
+}
+
+func matchesNetworkBootInterface(option *redfish.BootOption, nic NetworkBootInterface) bool {
+	if nic.MACAddress != "" {
+		mac := normalizeMACAddress(nic.MACAddress)
+		if strings.Contains(strings.ToUpper(option.UefiDevicePath), "MAC("+mac) ||
+			strings.Contains(normalizeMACAddress(option.DisplayName), mac) {
+			return true
+		}
+	}
+	return nic.Name != "" && (strings.EqualFold(option.ID, nic.Name) ||
+		containsWord(option.DisplayName, nic.Name) ||
+		containsWord(option.UefiDevicePath, nic.Name))
+}
+
+// containsWord reports whether s contains the word case-insensitively, neither preceded nor followed by a letter or
+// digit, so that e.g. "eth1" does not match "eth10".
+func containsWord(s, word string) bool {
+	s, word = strings.ToLower(s), strings.ToLower(word)
+	for offset := 0; ; {
+		i := strings.Index(s[offset:], word)
+		if i < 0 {
+			return false
+		}
+		start := offset + i
+		end := start + len(word)
+		if (start == 0 || !isAlphanumeric(s[start-1])) && (end == len(s) || !isAlphanumeric(s[end])) {
+			return true
+		}
+		offset = start + 1
+	}
+}
+
+func isAlphanumeric(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+// normalizeMACAddress returns the MAC address in upper case without separators, as in UEFI device paths.
+func normalizeMACAddress(mac string) string {
+	return strings.ToUpper(strings.NewReplacer(":", "", "-", "", ".", "").Replace(mac))
+}
+
+// ResetManager resets the first manager of the BMC.
+func (r *RedfishBMC) ResetManager(ctx context.Context, resetType redfish.ResetType) error {
+	if r.client == nil {
+		return fmt.Errorf("no client found")
+	}
+	managers, err := r.client.Service.Managers()
+	if err != nil {
+		return fmt.Errorf("failed to get managers: %w", err)
+	}
+	if len(managers) == 0 {
+		return fmt.Errorf("no managers found")
+	}
+	// TODO: always take the first for now.
+	if err := managers[0].Reset(resetType); err != nil {
+		return fmt.Errorf("failed to reset manager: %w", err)
+	}
+	return nil
+}
+
+// GetEventLogEntries returns the latest entries of the SEL log services of the system and its managers.
+// If no SEL log service is present, the entries of all system log services are returned.
+func (r *RedfishBMC) GetEventLogEntries(ctx context.Context, systemUUID string, limit int) ([]LogEntry, error) {
+	system, err := r.getSystemByUUID(ctx, systemUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get systems: %w", err)
+	}
+	services, err := system.LogServices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system log services: %w", err)
+	}
+	managers, err := r.client.Service.Managers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get managers: %w", err)
+	}
+	for _, m := range managers {
+		managerServices, err := m.LogServices()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get manager log services: %w", err)
+		}
+		for _, service := range managerServices {
+			if service.LogEntryType == redfish.SELLogEntryTypes {
+				services = append(services, service)
+			}
+		}
+	}
+
+	selServices := make([]*redfish.LogService, 0, len(services))
+	for _, service := range services {
+		if service.LogEntryType == redfish.SELLogEntryTypes {
+			selServices = append(selServices, service)
+		}
+	}
+	if len(selServices) > 0 {
+		services = selServices
+	}
+
+	var entries []LogEntry
+	for _, service := range services {
+		serviceEntries, err := service.Entries()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get log entries of %s: %w", service.ID, err)
+		}
+		for _, e := range serviceEntries {
+			entries = append(entries, LogEntry{
+				Created:  e.Created,
+				Severity: string(e.Severity),
+				Message:  e.Message,
+			})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Created < entries[j].Created
+	})
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	return entries, nil
+}
+
 func (r *RedfishBMC) GetManager() (*Manager, error) {
 	if r.client == nil {
 		return nil, fmt.Errorf("no client found")
@@ -229,18 +571,149 @@ func (r *RedfishBMC) GetSystemInfo(ctx context.Context, systemUUID string) (Syst
 		return SystemInfo{}, fmt.Errorf("failed to parse memory quantity: %w", err)
 	}
 	return SystemInfo{
-		SystemUUID:        system.UUID,
-		Manufacturer:      system.Manufacturer,
-		Model:             system.Model,
-		Status:            system.Status,
-		PowerState:        system.PowerState,
-		SerialNumber:      system.SerialNumber,
-		SKU:               system.SKU,
-		IndicatorLED:      string(system.IndicatorLED),
-		TotalSystemMemory: quantity,
+		SystemUUID:          system.UUID,
+		Manufacturer:        system.Manufacturer,
+		Model:               system.Model,
+		Status:              system.Status,
+		PowerState:          system.PowerState,
+		SerialNumber:        system.SerialNumber,
+		SKU:                 system.SKU,
+		IndicatorLED:        string(system.IndicatorLED),
+		TotalSystemMemory:   quantity,
+		SupportedResetTypes: system.SupportedResetTypes,
 	}, nil
 }
 
+// GetSystemRack returns the rack of the first chassis of the system. It is empty if the BMC does not report it.
+func (r *RedfishBMC) GetSystemRack(ctx context.Context, systemUUID string) (string, error) {
+	system, err := r.getSystemByUUID(ctx, systemUUID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get systems: %w", err)
+	}
+	uris := systemChassisURIs(system)
+	if len(uris) == 0 {
+		return "", nil
+	}
+	chassis, err := redfish.GetChassis(r.client, uris[0])
+	if err != nil {
+		return "", fmt.Errorf("failed to get chassis of system: %w", err)
+	}
+	return chassis.Location.Placement.Rack, nil
+}
+
+// GetProcessors returns the processors of the system.
+func (r *RedfishBMC) GetProcessors(ctx context.Context, systemUUID string) ([]Processor, error) {
+	system, err := r.getSystemByUUID(ctx, systemUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get systems: %w", err)
+	}
+	processors, err := system.Processors()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get processors: %w", err)
+	}
+	result := make([]Processor, 0, len(processors))
+	for _, p := range processors {
+		result = append(result, Processor{
+			ID:                    p.ID,
+			ProcessorType:         string(p.ProcessorType),
+			ProcessorArchitecture: string(p.ProcessorArchitecture),
+			InstructionSet:        string(p.InstructionSet),
+			Manufacturer:          p.Manufacturer,
+			Model:                 p.Model,
+			MaxSpeedMHz:           int32(p.MaxSpeedMHz),
+			TotalCores:            int32(p.TotalCores),
+			TotalThreads:          int32(p.TotalThreads),
+		})
+	}
+	return result, nil
+}
+
+// GetDPUs returns the systems of type DPU of the BMC if the system is its only physical system.
+func (r *RedfishBMC) GetDPUs(ctx context.Context, systemUUID string) ([]DPU, error) {
+	systems, _, err := r.listSystems()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get systems: %w", err)
+	}
+	var physical []string
+	var dpuSystems []*redfish.ComputerSystem
+	for _, system := range systems {
+		switch string(system.SystemType) {
+		case "", SystemTypePhysical:
+			physical = append(physical, strings.ToLower(system.UUID))
+		case SystemTypeDPU:
+			dpuSystems = append(dpuSystems, system)
+		}
+	}
+	if len(physical) != 1 || physical[0] != systemUUID {
+		return nil, nil
+	}
+
+	dpus := make([]DPU, 0, len(dpuSystems))
+	for _, system := range dpuSystems {
+		dpu := DPU{
+			Server: Server{
+				URI:          system.ODataID,
+				UUID:         system.UUID,
+				Model:        system.Model,
+				Manufacturer: system.Manufacturer,
+				PowerState:   PowerState(system.PowerState),
+				SerialNumber: system.SerialNumber,
+				SystemType:   string(system.SystemType),
+			},
+			FirmwareVersion: system.BIOSVersion,
+		}
+		if bios, err := system.Bios(); err == nil {
+			dpu.Mode = bios.Attributes.String(dpuModeAttribute)
+			if pending, err := r.pendingBiosAttributes(bios); err == nil {
+				dpu.PendingMode = pending[dpuModeAttribute]
+			}
+		}
+		if interfaces, err := system.EthernetInterfaces(); err == nil {
+			dpu.ManagementIP = dpuManagementIP(interfaces)
+		}
+		dpus = append(dpus, dpu)
+	}
+	return dpus, nil
+}
+
+// dpuManagementIP returns the IPv4 address of the out-of-band interface of a DPU, e.g. oob_net0 of BlueField DPUs,
+// falling back to the first address of any interface.
+func dpuManagementIP(interfaces []*redfish.EthernetInterface) string {
+	var fallback string
+	for _, iface := range interfaces {
+		for _, address := range iface.IPv4Addresses {
+			if address.Address == "" {
+				continue
+			}
+			if strings.Contains(strings.ToLower(iface.ID), "oob") {
+				return address.Address
+			}
+			if fallback == "" {
+				fallback = address.Address
+			}
+		}
+	}
+	return fallback
+}
+
+// SetDPUMode sets the NicMode BIOS attribute of the system of the DPU.
+func (r *RedfishBMC) SetDPUMode(ctx context.Context, dpuURI string, mode string) error {
+	r, cancel := r.withTimeout(ctx, r.options.Timeouts.SettingsApply)
+	defer cancel()
+	system, err := redfish.GetComputerSystem(r.client, dpuURI)
+	if err != nil {
+		return fmt.Errorf("failed to get system %s: %w", dpuURI, err)
+	}
+	bios, err := system.Bios()
+	if err != nil {
+		return fmt.Errorf("failed to get bios of DPU: %w", err)
+	}
+	if err := bios.UpdateBiosAttributes(redfish.SettingsAttributes{dpuModeAttribute: mode}); err != nil {
+		return fmt.Errorf("failed to set DPU mode: %w", err)
+	}
+	return nil
+}
+
 func (r *RedfishBMC) GetBootOrder(ctx context.Context, systemUUID string) ([]string, error) {
 	system, err := r.getSystemByUUID(ctx, systemUUID)
 	if err != nil {
@@ -249,6 +722,17 @@ func (r *RedfishBMC) GetBootOrder(ctx context.Context, systemUUID string) ([]str
 	return system.Boot.BootOrder, nil
 }
 
+func (r *RedfishBMC) GetBootSourceOverride(ctx context.Context, systemUUID string) (BootSourceOverride, error) {
+	system, err := r.getSystemByUUID(ctx, systemUUID)
+	if err != nil {
+		return BootSourceOverride{}, err
+	}
+	return BootSourceOverride{
+		Enabled: system.Boot.BootSourceOverrideEnabled,
+		Target:  system.Boot.BootSourceOverrideTarget,
+	}, nil
+}
+
 func (r *RedfishBMC) GetBiosVersion(ctx context.Context, systemUUID string) (string, error) {
 	system, err := r.getSystemByUUID(ctx, systemUUID)
 	if err != nil {
@@ -289,6 +773,45 @@ func (r *RedfishBMC) GetBiosAttributeValues(
 	return
 }
 
+// GetBiosPendingAttributeValues returns the BIOS attributes which have been set, but take effect on the next
+// boot of the system. They are read from the settings object the Bios resource directs updates to.
+func (r *RedfishBMC) GetBiosPendingAttributeValues(ctx context.Context, systemUUID string) (map[string]string, error) {
+	system, err := r.getSystemByUUID(ctx, systemUUID)
+	if err != nil {
+		return nil, err
+	}
+	bios, err := system.Bios()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bios: %w", err)
+	}
+	return r.pendingBiosAttributes(bios)
+}
+
+func (r *RedfishBMC) pendingBiosAttributes(bios *redfish.Bios) (map[string]string, error) {
+	var resource struct {
+		Settings common.Settings `json:"@Redfish.Settings"`
+	}
+	if err := r.getJSON(bios.ODataID, &resource); err != nil {
+		return nil, fmt.Errorf("failed to get bios: %w", err)
+	}
+	target := resource.Settings.SettingsObject.String()
+	if target == "" || target == bios.ODataID {
+		// settings are applied to the Bios resource directly, so there are no pending settings
+		return map[string]string{}, nil
+	}
+	var settings struct {
+		Attributes redfish.SettingsAttributes
+	}
+	if err := r.getJSON(target, &settings); err != nil {
+		return nil, fmt.Errorf("failed to get pending bios settings: %w", err)
+	}
+	result := make(map[string]string, len(settings.Attributes))
+	for name := range settings.Attributes {
+		result[name] = settings.Attributes.String(name)
+	}
+	return result, nil
+}
+
 // SetBiosAttributes sets given bios attributes. Returns true if bios reset is required
 func (r *RedfishBMC) SetBiosAttributes(
 	ctx context.Context,
@@ -298,6 +821,8 @@ func (r *RedfishBMC) SetBiosAttributes(
 	reset bool,
 	err error,
 ) {
+	r, cancel := r.withTimeout(ctx, r.options.Timeouts.SettingsApply)
+	defer cancel()
 	reset = false
 	system, err := r.getSystemByUUID(ctx, systemUUID)
 	if err != nil {
@@ -315,22 +840,24 @@ func (r *RedfishBMC) SetBiosAttributes(
 	for name, value := range attributes {
 		attrs[name] = value
 	}
-	return reset, bios.UpdateBiosAttributes(attrs)
+	return reset, r.translateError(OperationSetBiosAttributes, bios.UpdateBiosAttributes(attrs))
 }
 
 // SetBootOrder sets bios boot order
 func (r *RedfishBMC) SetBootOrder(ctx context.Context, systemUUID string, bootOrder []string) error {
+	r, cancel := r.withTimeout(ctx, r.options.Timeouts.SettingsApply)
+	defer cancel()
 	system, err := r.getSystemByUUID(ctx, systemUUID)
 	if err != nil {
 		return err
 	}
-	return system.SetBoot(
+	return r.translateError(OperationSetBootOrder, system.SetBoot(
 		redfish.Boot{
 			BootSourceOverrideEnabled: redfish.ContinuousBootSourceOverrideEnabled,
 			BootSourceOverrideTarget:  redfish.NoneBootSourceOverrideTarget,
 			BootOrder:                 bootOrder,
 		},
-	)
+	))
 }
 
 func (r *RedfishBMC) getFilteredBiosRegistryAttributes(
@@ -367,7 +894,7 @@ func (r *RedfishBMC) checkBiosAttributes(attrs map[string]string) (reset bool, e
 	if err != nil {
 		return
 	}
-	//TODO: add more types like maps and Enumerations
+	//TODO: add more types like maps
 	for name, value := range attrs {
 		entryAttribute, ok := filtered[name]
 		if !ok {
@@ -377,7 +904,7 @@ func (r *RedfishBMC) checkBiosAttributes(attrs map[string]string) (reset bool, e
 		if entryAttribute.ResetRequired {
 			reset = true
 		}
-		switch strings.ToLower(entryAttribute.Type) {
+		switch normalizeAttributeType(entryAttribute.Type) {
 		case "integer":
 			_, Aerr := strconv.Atoi(value)
 			if Aerr != nil {
@@ -385,6 +912,10 @@ func (r *RedfishBMC) checkBiosAttributes(attrs map[string]string) (reset bool, e
 			}
 		case "string":
 			continue
+		case "enumeration":
+			if !slices.ContainsFunc(entryAttribute.Value, func(v RegistryEntryValue) bool { return v.ValueName == value }) {
+				err = errors.Join(err, fmt.Errorf("attribute %s value %s is not allowed", name, value))
+			}
 		default:
 			err = errors.Join(err, fmt.Errorf("attribute %s value has wrong type", name))
 		}
@@ -416,7 +947,8 @@ func (r *RedfishBMC) GetStorages(ctx context.Context, systemUUID string) ([]Stor
 	result := make([]Storage, 0, len(systemStorage))
 	for _, s := range systemStorage {
 		storage := Storage{
-			Entity: Entity{ID: s.ID, Name: s.Name},
+			Entity:      Entity{ID: s.ID, Name: s.Name},
+			Controllers: storageControllers(s),
 		}
 		volumes, err := s.Volumes()
 		if err != nil {
@@ -429,6 +961,7 @@ func (r *RedfishBMC) GetStorages(ctx context.Context, systemUUID string) ([]Stor
 				SizeBytes: int64(v.CapacityBytes),
 				RAIDType:  v.RAIDType,
 				State:     v.Status.State,
+				Health:    v.Status.Health,
 			})
 		}
 		drives, err := s.Drives()
@@ -438,13 +971,21 @@ func (r *RedfishBMC) GetStorages(ctx context.Context, systemUUID string) ([]Stor
 		storage.Drives = make([]Drive, 0, len(drives))
 		for _, d := range drives {
 			storage.Drives = append(storage.Drives, Drive{
-				Entity:    Entity{ID: d.ID, Name: d.Name},
-				MediaType: string(d.MediaType),
-				Type:      d.DriveFormFactor,
-				SizeBytes: d.CapacityBytes,
-				Vendor:    d.Manufacturer,
-				Model:     d.Model,
-				State:     d.Status.State,
+				Entity:          Entity{ID: d.ID, Name: d.Name},
+				MediaType:       string(d.MediaType),
+				Type:            d.DriveFormFactor,
+				SizeBytes:       d.CapacityBytes,
+				Vendor:          d.Manufacturer,
+				Model:           d.Model,
+				FirmwareVersion: d.Revision,
+				URI:             d.ODataID,
+				State:           d.Status.State,
+				Health:          d.Status.Health,
+				SerialNumber:    d.SerialNumber,
+				Bay:             driveBay(d),
+				Enclosure:       driveEnclosure(d),
+
+				LocationIndicatorActive: d.LocationIndicatorActive || d.IndicatorLED == common.BlinkingIndicatorLED,
 			})
 		}
 		result = append(result, storage)
@@ -469,6 +1010,7 @@ func (r *RedfishBMC) GetStorages(ctx context.Context, systemUUID string) ([]Stor
 					Vendor:    d.Manufacturer,
 					Model:     d.Model,
 					State:     d.Status.State,
+					Health:    d.Status.Health,
 				})
 			}
 			result = append(result, storage)
@@ -478,9 +1020,320 @@ func (r *RedfishBMC) GetStorages(ctx context.Context, systemUUID string) ([]Stor
 	return result, nil
 }
 
+// storageControllers returns the controllers of the storage. The controllers are taken from the Controllers
+// collection, falling back to the deprecated StorageControllers property for older BMCs. Controllers are only
+// informational, so a failure to read them leaves them empty.
+func storageControllers(s *redfish.Storage) []StorageController {
+	var controllers []StorageController
+	if members, err := s.Controllers(); err == nil {
+		for _, c := range members {
+			controllers = append(controllers, StorageController{
+				Entity:          Entity{ID: c.ID, Name: c.Name},
+				Manufacturer:    c.Manufacturer,
+				Model:           c.Model,
+				FirmwareVersion: c.FirmwareVersion,
+				SerialNumber:    c.SerialNumber,
+			})
+		}
+	}
+	if len(controllers) > 0 {
+		return controllers
+	}
+	for _, c := range s.StorageControllers {
+		controllers = append(controllers, StorageController{
+			Entity:          Entity{ID: c.ID, Name: c.Name},
+			Manufacturer:    c.Manufacturer,
+			Model:           c.Model,
+			FirmwareVersion: c.FirmwareVersion,
+			SerialNumber:    c.SerialNumber,
+		})
+	}
+	return controllers
+}
+
+// driveBay returns the physical location of the drive in its enclosure. The service label printed on the enclosure
+// is preferred over the ordinal of the bay or slot, and the PhysicalLocation over the deprecated Location.
+func driveBay(d *redfish.Drive) string {
+	locations := append([]common.Location{d.PhysicalLocation}, d.Location...)
+	for _, location := range locations {
+		if label := location.PartLocation.ServiceLabel; label != "" {
+			return label
+		}
+	}
+	for _, location := range locations {
+		if part := location.PartLocation; part.LocationType != "" {
+			return fmt.Sprintf("%s %d", part.LocationType, part.LocationOrdinalValue)
+		}
+	}
+	return ""
+}
+
+// driveEnclosure returns the chassis or backplane containing the drive. The enclosure is only informational, so a
+// failure to read it leaves it empty.
+func driveEnclosure(d *redfish.Drive) *Enclosure {
+	chassis, err := d.Chassis()
+	if err != nil || chassis == nil {
+		return nil
+	}
+	return &Enclosure{
+		Entity:       Entity{ID: chassis.ID, Name: chassis.Name},
+		Model:        chassis.Model,
+		SerialNumber: chassis.SerialNumber,
+	}
+}
+
+func (r *RedfishBMC) SetISCSIBoot(ctx context.Context, systemUUID string, params ISCSIBootParameters) error {
+	r, cancel := r.withTimeout(ctx, r.options.Timeouts.SettingsApply)
+	defer cancel()
+	system, err := r.getSystemByUUID(ctx, systemUUID)
+	if err != nil {
+		return err
+	}
+	function, err := getISCSINetworkDeviceFunction(system, params.NetworkDeviceFunctionID)
+	if err != nil {
+		return err
+	}
+
+	if !iscsiBootConfigured(function, params) {
+		iscsiBoot := map[string]any{
+			"InitiatorName":          params.InitiatorName,
+			"PrimaryTargetName":      params.TargetName,
+			"PrimaryTargetIPAddress": params.TargetAddress,
+			"PrimaryTargetTCPPort":   params.TargetPort,
+			"PrimaryLUN":             params.LUN,
+			"IPMaskDNSViaDHCP":       true,
+			"AuthenticationMethod":   redfish.NoneAuthenticationMethod,
+		}
+		if params.CHAPUsername != "" {
+			iscsiBoot["AuthenticationMethod"] = redfish.CHAPAuthenticationMethod
+			iscsiBoot["CHAPUsername"] = params.CHAPUsername
+			iscsiBoot["CHAPSecret"] = params.CHAPSecret
+		}
+		resp, err := r.client.Patch(function.ODataID, map[string]any{
+			"BootMode":  redfish.ISCSIBootMode,
+			"iSCSIBoot": iscsiBoot,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to configure iSCSI boot on network device function %s: %w", function.ID, err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	if err := system.SetBoot(redfish.Boot{
+		BootSourceOverrideEnabled: redfish.OnceBootSourceOverrideEnabled,
+		BootSourceOverrideTarget:  redfish.RemoteDriveBootSourceOverrideTarget,
+	}); err != nil {
+		return fmt.Errorf("failed to set the boot override to the iSCSI target: %w", err)
+	}
+	return nil
+}
+
+// iscsiBootConfigured reports whether the network device function already boots from the iSCSI target of the
+// parameters. The CHAP secret cannot be read back, so a change of the secret alone is not detected.
+func iscsiBootConfigured(function *redfish.NetworkDeviceFunction, params ISCSIBootParameters) bool {
+	current := function.ISCSIBoot
+	authenticationMethod := redfish.NoneAuthenticationMethod
+	if params.CHAPUsername != "" {
+		authenticationMethod = redfish.CHAPAuthenticationMethod
+	}
+	return function.BootMode == redfish.ISCSIBootMode &&
+		current.InitiatorName == params.InitiatorName &&
+		current.PrimaryTargetName == params.TargetName &&
+		current.PrimaryTargetIPAddress == params.TargetAddress &&
+		current.PrimaryTargetTCPPort == params.TargetPort &&
+		current.PrimaryLUN == params.LUN &&
+		current.AuthenticationMethod == authenticationMethod &&
+		current.CHAPUsername == params.CHAPUsername
+}
+
+func (r *RedfishBMC) GetResourceBlocks(ctx context.Context) ([]ResourceBlock, error) {
+	compositionService, err := r.client.GetService().CompositionService()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get composition service: %w", err)
+	}
+	var service struct {
+		ResourceBlocks common.Link
+	}
+	if err := r.getJSON(compositionService.ODataID, &service); err != nil {
+		return nil, fmt.Errorf("failed to get composition service: %w", err)
+	}
+	if service.ResourceBlocks == "" {
+		return nil, errors.New("composition service has no resource blocks")
+	}
+	blocks, err := redfish.ListReferencedResourceBlocks(r.client, service.ResourceBlocks.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource blocks: %w", err)
+	}
+	result := make([]ResourceBlock, 0, len(blocks))
+	for _, block := range blocks {
+		types := make([]string, 0, len(block.ResourceBlockType))
+		for _, t := range block.ResourceBlockType {
+			types = append(types, string(t))
+		}
+		result = append(result, ResourceBlock{
+			Entity:           Entity{ID: block.ID, Name: block.Name},
+			URI:              block.ODataID,
+			Types:            types,
+			CompositionState: string(block.CompositionStatus.CompositionState),
+		})
+	}
+	return result, nil
+}
+
+func (r *RedfishBMC) ComposeSystem(ctx context.Context, name string, resourceBlockURIs []string) (string, string, error) {
+	links := make([]common.Link, 0, len(resourceBlockURIs))
+	for _, uri := range resourceBlockURIs {
+		links = append(links, common.Link(uri))
+	}
+	var request struct {
+		Name  string
+		Links struct {
+			ResourceBlocks []common.Link
+		}
+	}
+	request.Name = name
+	request.Links.ResourceBlocks = links
+
+	// Systems are composed by posting them to the systems collection of the service.
+	var root struct {
+		Systems common.Link
+	}
+	if err := r.getJSON(r.client.GetService().ODataID, &root); err != nil {
+		return "", "", fmt.Errorf("failed to get service root: %w", err)
+	}
+	resp, err := r.client.Post(root.Systems.String(), request)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to compose system: %w", err)
+	}
+	defer resp.Body.Close() // nolint: errcheck
+
+	// The Location header refers to the composed system, or to the task monitor if the composition is still
+	// running.
+	location := resp.Header.Get("Location")
+	if resp.StatusCode == http.StatusAccepted && location == "" {
+		task := &redfish.Task{}
+		if err := json.NewDecoder(resp.Body).Decode(task); err == nil {
+			location = task.ODataID
+		}
+	}
+	if location == "" {
+		return "", "", errors.New("no system returned for composition")
+	}
+	if u, err := url.Parse(location); err == nil && u.IsAbs() {
+		location = u.Path
+	}
+	if resp.StatusCode == http.StatusAccepted {
+		return "", r.normalizeTaskURI(location), nil
+	}
+	return location, "", nil
+}
+
+func (r *RedfishBMC) GetSystem(ctx context.Context, systemURI string) (Server, error) {
+	system, err := redfish.GetComputerSystem(r.client, systemURI)
+	if err != nil {
+		return Server{}, fmt.Errorf("failed to get system %s: %w", systemURI, err)
+	}
+	return Server{
+		URI:          system.ODataID,
+		Name:         system.Name,
+		UUID:         system.UUID,
+		Model:        system.Model,
+		Manufacturer: system.Manufacturer,
+		PowerState:   PowerState(system.PowerState),
+		SerialNumber: system.SerialNumber,
+		SystemType:   string(system.SystemType),
+	}, nil
+}
+
+func (r *RedfishBMC) DecomposeSystem(ctx context.Context, systemURI string) error {
+	resp, err := r.client.Delete(systemURI)
+	if err != nil {
+		return fmt.Errorf("failed to decompose system %s: %w", systemURI, err)
+	}
+	return resp.Body.Close()
+}
+
+func (r *RedfishBMC) SetAccountPassword(ctx context.Context, username, password string) error {
+	r, cancel := r.withTimeout(ctx, r.options.Timeouts.SettingsApply)
+	defer cancel()
+	accountService, err := r.client.Service.AccountService()
+	if err != nil {
+		return r.setRequiredAccountPassword(err, username, password)
+	}
+	accounts, err := accountService.Accounts()
+	if err != nil {
+		return r.setRequiredAccountPassword(err, username, password)
+	}
+	for _, account := range accounts {
+		if account.UserName != username {
+			continue
+		}
+		return r.patchAccountPassword(account.ODataID, username, password)
+	}
+	return fmt.Errorf("account %s not found", username)
+}
+
+// setRequiredAccountPassword sets the password of the account if the BMC rejected reading the accounts because the
+// password has to be changed first. Until then, BMCs only allow to change the password of the account they report.
+func (r *RedfishBMC) setRequiredAccountPassword(err error, username, password string) error {
+	accountURI, required := PasswordChangeRequired(err)
+	if !required {
+		return fmt.Errorf("failed to get accounts: %w", err)
+	}
+	if accountURI == "" {
+		return fmt.Errorf("BMC requires a password change, but did not report the account: %w", err)
+	}
+	return r.patchAccountPassword(accountURI, username, password)
+}
+
+func (r *RedfishBMC) patchAccountPassword(accountURI, username, password string) error {
+	resp, err := r.client.Patch(accountURI, map[string]any{"Password": password})
+	if err != nil {
+		return fmt.Errorf("failed to set password of account %s: %w", username, err)
+	}
+	return resp.Body.Close()
+}
+
+func getISCSINetworkDeviceFunction(system *redfish.ComputerSystem, id string) (*redfish.NetworkDeviceFunction, error) {
+	interfaces, err := system.NetworkInterfaces()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network interfaces: %w", err)
+	}
+	for _, networkInterface := range interfaces {
+		functions, err := networkInterface.NetworkDeviceFunctions()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get network device functions: %w", err)
+		}
+		for _, function := range functions {
+			if id != "" {
+				if function.ID == id {
+					return function, nil
+				}
+				continue
+			}
+			if slices.Contains(function.NetDevFuncCapabilities, redfish.ISCSINetworkDeviceTechnology) {
+				return function, nil
+			}
+		}
+	}
+	if id != "" {
+		return nil, fmt.Errorf("network device function %s not found", id)
+	}
+	return nil, errors.New("no network device function supporting iSCSI found")
+}
+
 func (r *RedfishBMC) getSystemByUUID(ctx context.Context, systemUUID string) (*redfish.ComputerSystem, error) {
-	service := r.client.GetService()
-	var systems []*redfish.ComputerSystem
+	if r.options.SystemURI != "" {
+		system, err := redfish.GetComputerSystem(r.client, r.options.SystemURI)
+		if err == nil && strings.ToLower(system.UUID) == systemUUID {
+			return system, nil
+		}
+	}
+
+	var (
+		systems []*redfish.ComputerSystem
+		skipped []error
+	)
 	err := wait.PollUntilContextTimeout(
 		ctx,
 		r.options.ResourcePollingInterval,
@@ -488,7 +1341,7 @@ func (r *RedfishBMC) getSystemByUUID(ctx context.Context, systemUUID string) (*r
 		true,
 		func(ctx context.Context) (bool, error) {
 			var err error
-			systems, err = service.Systems()
+			systems, skipped, err = r.listSystems()
 			return err == nil, nil
 		})
 	if err != nil {
@@ -499,6 +1352,9 @@ func (r *RedfishBMC) getSystemByUUID(ctx context.Context, systemUUID string) (*r
 			return system, nil
 		}
 	}
+	if len(skipped) > 0 {
+		return nil, fmt.Errorf("no system found: %w", errors.Join(skipped...))
+	}
 	return nil, errors.New("no system found")
 }
 
@@ -523,3 +1379,154 @@ func (r *RedfishBMC) WaitForServerPowerState(
 	}
 	return nil
 }
+
+func (r *RedfishBMC) UpdateFirmware(ctx context.Context, params FirmwareUpdateParameters) (string, error) {
+	// the deadline also bounds the download of images pushed to the BMC
+	ctx, cancel := context.WithTimeout(ctx, r.options.Timeouts.FirmwareUpload)
+	defer cancel()
+	r, cancel = r.withTimeout(ctx, r.options.Timeouts.FirmwareUpload)
+	defer cancel()
+	// uploads of large images may outlast the session timeout of the BMC
+	defer r.keepSessionAlive(ctx)()
+	updateService, err := r.client.GetService().UpdateService()
+	if err != nil {
+		return "", fmt.Errorf("failed to get update service: %w", err)
+	}
+	var resp *http.Response
+	if r.flavor == FlavorOpenBMC {
+		resp, err = r.pushFirmwareUpdate(ctx, updateService, params)
+	} else {
+		resp, err = r.simpleUpdateFirmware(updateService, params)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to trigger firmware update: %w", err)
+	}
+	defer resp.Body.Close() // nolint: errcheck
+
+	// The task monitor is returned in the Location header. Some implementations
+	// only return the task resource in the response body instead.
+	taskURI := resp.Header.Get("Location")
+	if taskURI == "" {
+		task := &redfish.Task{}
+		if err := json.NewDecoder(resp.Body).Decode(task); err == nil {
+			taskURI = task.ODataID
+		}
+	}
+	if taskURI == "" {
+		return "", errors.New("no task returned for firmware update")
+	}
+	if u, err := url.Parse(taskURI); err == nil && u.IsAbs() {
+		taskURI = u.Path
+	}
+	return r.normalizeTaskURI(taskURI), nil
+}
+
+func (r *RedfishBMC) simpleUpdateFirmware(updateService *redfish.UpdateService, params FirmwareUpdateParameters) (*http.Response, error) {
+	target, err := getSimpleUpdateTarget(updateService)
+	if err != nil {
+		return nil, err
+	}
+	return r.client.Post(target, &struct {
+		redfish.SimpleUpdateParameters
+		ApplyTime string `json:"@Redfish.OperationApplyTime,omitempty"`
+	}{
+		SimpleUpdateParameters: redfish.SimpleUpdateParameters{
+			ForceUpdate:      params.ForceUpdate,
+			ImageURI:         params.ImageURI,
+			Targets:          params.Targets,
+			TransferProtocol: redfish.TransferProtocolType(params.TransferProtocol),
+		},
+		ApplyTime: params.ApplyTime,
+	})
+}
+
+func (r *RedfishBMC) GetTask(ctx context.Context, taskURI string) (*Task, error) {
+	r, cancel := r.withTimeout(ctx, r.options.Timeouts.TaskPolling)
+	defer cancel()
+	task, err := redfish.GetTask(r.client, taskURI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task %s: %w", taskURI, err)
+	}
+	result := &Task{
+		URI:             taskURI,
+		State:           task.TaskState,
+		PercentComplete: task.PercentComplete,
+	}
+	if len(task.Messages) > 0 {
+		result.Message = task.Messages[len(task.Messages)-1].Message
+	}
+	return result, nil
+}
+
+func (r *RedfishBMC) GetFirmwareInventory(ctx context.Context) ([]FirmwareInventory, error) {
+	updateService, err := r.client.GetService().UpdateService()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get update service: %w", err)
+	}
+	var service struct {
+		FirmwareInventory common.Link
+	}
+	if err := json.Unmarshal(updateService.RawData, &service); err != nil {
+		return nil, fmt.Errorf("failed to parse update service: %w", err)
+	}
+	if service.FirmwareInventory == "" {
+		return nil, errors.New("update service does not provide a firmware inventory")
+	}
+	var collection struct {
+		Members common.Links
+	}
+	if err := r.getJSON(string(service.FirmwareInventory), &collection); err != nil {
+		return nil, fmt.Errorf("failed to get firmware inventory: %w", err)
+	}
+	result := make([]FirmwareInventory, 0, len(collection.Members))
+	for _, member := range collection.Members.ToStrings() {
+		var item struct {
+			ID           string `json:"Id"`
+			Name         string
+			SoftwareID   string `json:"SoftwareId"`
+			Manufacturer string
+			Version      string
+			Updateable   bool
+			RelatedItem  common.Links
+		}
+		if err := r.getJSON(member, &item); err != nil {
+			return nil, fmt.Errorf("failed to get firmware inventory entry %s: %w", member, err)
+		}
+		result = append(result, FirmwareInventory{
+			Entity:       Entity{ID: item.ID, Name: item.Name},
+			URI:          member,
+			SoftwareID:   item.SoftwareID,
+			Manufacturer: item.Manufacturer,
+			Version:      item.Version,
+			Updateable:   item.Updateable,
+			RelatedItems: item.RelatedItem.ToStrings(),
+		})
+	}
+	return result, nil
+}
+
+func (r *RedfishBMC) getJSON(uri string, v any) error {
+	resp, err := r.client.Get(uri)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() // nolint: errcheck
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func getSimpleUpdateTarget(updateService *redfish.UpdateService) (string, error) {
+	var tmp struct {
+		Actions struct {
+			SimpleUpdate struct {
+				Target string
+			} `json:"#UpdateService.SimpleUpdate"`
+		}
+	}
+	if err := json.Unmarshal(updateService.RawData, &tmp); err != nil {
+		return "", fmt.Errorf("failed to parse update service: %w", err)
+	}
+	if tmp.Actions.SimpleUpdate.Target == "" {
+		return "", errors.New("update service does not support SimpleUpdate")
+	}
+	return tmp.Actions.SimpleUpdate.Target, nil
+}
@@ -0,0 +1,1102 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stmcginnis/gofish/redfish"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var _ BMC = (*RedfishFakeBMC)(nil)
+
+// Simulators holds the simulated BMCs used by the clients of the RedfishFake protocol, keyed by the address
+// ("host:port") of the BMC.
+var Simulators = &SimulatorRegistry{}
+
+// SimulatorRegistry maps BMC addresses to simulated BMCs.
+type SimulatorRegistry struct {
+	mu         sync.Mutex
+	simulators map[string]*Simulator
+}
+
+// Register replaces the simulated BMC of the address.
+func (r *SimulatorRegistry) Register(address string, simulator *Simulator) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.simulators == nil {
+		r.simulators = map[string]*Simulator{}
+	}
+	r.simulators[address] = simulator
+}
+
+// Get returns the simulated BMC of the address. Unknown addresses get a new simulated BMC with a single system,
+// whose UUID, serial number and MAC addresses are derived from the address, so that a fleet of simulated BMCs
+// manages distinct systems.
+func (r *SimulatorRegistry) Get(address string) *Simulator {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.simulators == nil {
+		r.simulators = map[string]*Simulator{}
+	}
+	simulator, ok := r.simulators[address]
+	if !ok {
+		simulator = newSimulatorForAddress(address)
+		r.simulators[address] = simulator
+	}
+	return simulator
+}
+
+// newSimulatorForAddress returns a simulated BMC whose identifiers are derived from the address.
+func newSimulatorForAddress(address string) *Simulator {
+	simulator := NewSimulator()
+	sum := sha256.Sum256([]byte(address))
+	system := &simulator.state.Systems[0]
+	system.Info.SystemUUID = fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+	system.Info.SerialNumber = fmt.Sprintf("%X", sum[16:21])
+	system.Info.NetworkInterfaces[0].MACAddress = fmt.Sprintf("02:%02X:%02X:%02X:%02X:%02X", sum[21], sum[22], sum[23], sum[24], sum[25])
+	simulator.state.Manager.UUID = fmt.Sprintf("%x-%x-%x-%x-%x", sum[16:20], sum[20:22], sum[22:24], sum[24:26], sum[26:32])
+	simulator.state.Manager.MACAddress = fmt.Sprintf("02:%02X:%02X:%02X:%02X:%02X", sum[26], sum[27], sum[28], sum[29], sum[30])
+	return simulator
+}
+
+// LoadOrStore returns the simulated BMC of the address if there is one. Otherwise it registers the given one and
+// returns it.
+func (r *SimulatorRegistry) LoadOrStore(address string, simulator *Simulator) *Simulator {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.simulators[address]; ok {
+		return existing
+	}
+	if r.simulators == nil {
+		r.simulators = map[string]*Simulator{}
+	}
+	r.simulators[address] = simulator
+	return simulator
+}
+
+// Has returns whether there is a simulated BMC for the address.
+func (r *SimulatorRegistry) Has(address string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.simulators[address]
+	return ok
+}
+
+// Reset removes all simulated BMCs.
+func (r *SimulatorRegistry) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.simulators = nil
+}
+
+// SimulatedSystem is the state of a system of a simulated BMC.
+type SimulatedSystem struct {
+	Info SystemInfo
+	URI  string
+	// Name is the name of the system. Composed systems are named after the ComposedServer they were composed for.
+	Name string
+	// SystemType is the Redfish type of the system. Systems without a type are physical systems.
+	SystemType string
+	BootOrder  []string
+	// BootOverride is the boot source of the next boot, e.g. Pxe. It is cleared by the next power on or reset.
+	BootOverride string
+	// IgnoresBootOverride simulates firmware which boots from disk regardless of the BootOverride, which stays
+	// pending.
+	IgnoresBootOverride bool
+	// BootInterface is the MAC address of the network interface of the next boot, if one has been selected.
+	BootInterface         string
+	BiosVersion           string
+	BiosAttributes        map[string]string
+	PendingBiosAttributes map[string]string
+	Storages              []Storage
+	EventLog              []LogEntry
+	ISCSIBoot             *ISCSIBootParameters
+	// ChassisFRUs are the power supplies and fans of the chassis of the system.
+	ChassisFRUs ChassisFRUs
+	// Rack is the rack the chassis of the system is placed in.
+	Rack string
+	// BiosPasswords maps the names of the BIOS passwords which have been set to their values.
+	BiosPasswords map[string]string
+	// CrashDump is the content of the crash dumps the BMC captures. Crash dumps are not supported if it is nil.
+	CrashDump []byte
+	// CrashDumpTime is the time the BMC captured the latest crash dump.
+	CrashDumpTime time.Time
+}
+
+// SimulatorState is the state of a simulated BMC.
+type SimulatorState struct {
+	Manager Manager
+	Systems []SimulatedSystem
+	// Accounts maps the user names of the BMC accounts to their passwords. If empty, all credentials are accepted.
+	Accounts map[string]string
+	// FirmwareInventory is the firmware inventory of the BMC. A firmware update sets the version of its targets to
+	// the "version" query parameter of the image URI, e.g. http://images.example.com/bios.bin?version=2.0.0.
+	FirmwareInventory []FirmwareInventory
+	// FirmwareUpdates are the firmware updates triggered on the BMC, oldest first.
+	FirmwareUpdates []FirmwareUpdateParameters
+	Tasks           map[string]Task
+	ResourceBlocks  []ResourceBlock
+	MetricReports   []MetricReport
+	// ManagerResets counts the resets of the BMC itself.
+	ManagerResets int
+	// DPUs are the DPUs of the first system. A mode switch becomes pending until the next power on of the system.
+	DPUs []DPU
+	// Forwarding is the forwarding of alerts configured on the BMC.
+	Forwarding ForwardingConfig
+}
+
+// Simulator is an in-process BMC keeping its state in memory. Its behavior can be scripted by injecting failures
+// for operations or by intercepting every operation.
+type Simulator struct {
+	mu        sync.Mutex
+	state     SimulatorState
+	failures  map[string]error
+	intercept func(ctx context.Context, operation string) error
+}
+
+// NewSimulator returns a simulated BMC with a single powered off system, resembling the Redfish mockup server used
+// for the RedfishLocal protocol.
+func NewSimulator() *Simulator {
+	const systemURI = "/redfish/v1/Systems/437XR1138R2"
+	return &Simulator{state: SimulatorState{
+		Manager: Manager{
+			UUID:            "3c7f6ac3-3d5d-4a49-a3b5-0a9a9d4a6b2e",
+			Manufacturer:    "Contoso",
+			FirmwareVersion: "1.45.455b66-rev4",
+			SerialNumber:    "2M220100SL",
+			Model:           "Joo Janta 200",
+			PowerState:      string(OnPowerState),
+			State:           "Enabled",
+			MACAddress:      "23:11:8A:33:CF:EA",
+		},
+		Systems: []SimulatedSystem{{
+			URI: systemURI,
+			Info: SystemInfo{
+				SystemUUID:   "38947555-7742-3448-3784-823347823834",
+				Manufacturer: "Contoso",
+				Model:        "3500",
+				SerialNumber: "437XR1138R2",
+				SKU:          "8675309",
+				PowerState:   redfish.OffPowerState,
+				IndicatorLED: "Unknown",
+				NetworkInterfaces: []NetworkInterface{{
+					ID:         "1",
+					MACAddress: "12:44:6A:3B:04:11",
+				}},
+			},
+			BootOrder:             []string{"Pxe", "Hdd"},
+			BiosVersion:           "P79 v1.45 (12/06/2017)",
+			BiosAttributes:        map[string]string{},
+			PendingBiosAttributes: map[string]string{},
+		}},
+		FirmwareInventory: []FirmwareInventory{
+			{
+				Entity:       Entity{ID: "BIOS", Name: "BIOS"},
+				URI:          "/redfish/v1/UpdateService/FirmwareInventory/BIOS",
+				SoftwareID:   "BIOS",
+				Manufacturer: "Contoso",
+				Version:      "1.45.0",
+				Updateable:   true,
+				RelatedItems: []string{systemURI + "/Bios"},
+			},
+			{
+				Entity:       Entity{ID: "BMC", Name: "BMC"},
+				URI:          "/redfish/v1/UpdateService/FirmwareInventory/BMC",
+				SoftwareID:   "BMC",
+				Manufacturer: "Contoso",
+				Version:      "1.45.455",
+				Updateable:   true,
+				RelatedItems: []string{"/redfish/v1/Managers/BMC"},
+			},
+		},
+		Tasks: map[string]Task{},
+	}}
+}
+
+// SimulatorFixture describes the hardware of a simulated BMC and its system.
+type SimulatorFixture struct {
+	Manufacturer      string `json:"manufacturer"`
+	Model             string `json:"model"`
+	SKU               string `json:"sku,omitempty"`
+	SystemUUID        string `json:"systemUUID"`
+	SerialNumber      string `json:"serialNumber"`
+	MACAddress        string `json:"macAddress"`
+	BIOSVersion       string `json:"biosVersion"`
+	ProcessorModel    string `json:"processorModel,omitempty"`
+	Processors        int    `json:"processors,omitempty"`
+	CoresPerProcessor int    `json:"coresPerProcessor,omitempty"`
+	MemoryGiB         int64  `json:"memoryGiB,omitempty"`
+	// Architecture is the CPU architecture of the processors, x86_64 or aarch64. Defaults to x86_64.
+	Architecture string `json:"architecture,omitempty"`
+
+	BMCManufacturer    string `json:"bmcManufacturer"`
+	BMCModel           string `json:"bmcModel"`
+	BMCFirmwareVersion string `json:"bmcFirmwareVersion"`
+	BMCMACAddress      string `json:"bmcMACAddress"`
+}
+
+// NewSimulatorFromFixture returns a simulated BMC with a single powered off system described by the fixture.
+func NewSimulatorFromFixture(fixture SimulatorFixture) *Simulator {
+	simulator := NewSimulator()
+	manager := &simulator.state.Manager
+	manager.Manufacturer = fixture.BMCManufacturer
+	manager.Model = fixture.BMCModel
+	manager.FirmwareVersion = fixture.BMCFirmwareVersion
+	manager.MACAddress = fixture.BMCMACAddress
+	manager.SerialNumber = fixture.SerialNumber
+	manager.UUID = fixture.SystemUUID
+
+	system := &simulator.state.Systems[0]
+	system.Info.Manufacturer = fixture.Manufacturer
+	system.Info.Model = fixture.Model
+	system.Info.SKU = fixture.SKU
+	system.Info.SystemUUID = fixture.SystemUUID
+	system.Info.SerialNumber = fixture.SerialNumber
+	system.Info.NetworkInterfaces[0].MACAddress = fixture.MACAddress
+	system.Info.TotalSystemMemory = *resource.NewQuantity(fixture.MemoryGiB<<30, resource.BinarySI)
+	processorArchitecture, instructionSet := "x86", "x86-64"
+	if fixture.Architecture == ArchitectureAArch64 {
+		processorArchitecture, instructionSet = "ARM", "ARM-A64"
+	}
+	for i := range fixture.Processors {
+		system.Info.Processors = append(system.Info.Processors, Processor{
+			ID:                    fmt.Sprintf("CPU%d", i+1),
+			ProcessorType:         "CPU",
+			ProcessorArchitecture: processorArchitecture,
+			InstructionSet:        instructionSet,
+			Manufacturer:          fixture.Manufacturer,
+			Model:                 fixture.ProcessorModel,
+			TotalCores:            int32(fixture.CoresPerProcessor),
+			TotalThreads:          int32(2 * fixture.CoresPerProcessor),
+		})
+	}
+	system.BiosVersion = fixture.BIOSVersion
+
+	for i := range simulator.state.FirmwareInventory {
+		item := &simulator.state.FirmwareInventory[i]
+		item.Manufacturer = fixture.Manufacturer
+		switch item.ID {
+		case "BIOS":
+			item.Version = fixture.BIOSVersion
+		case "BMC":
+			item.Manufacturer = fixture.BMCManufacturer
+			item.Version = fixture.BMCFirmwareVersion
+		}
+	}
+	return simulator
+}
+
+// SetFailure lets the operation, e.g. "PowerOn", fail with the error. A nil error removes the failure.
+func (s *Simulator) SetFailure(operation string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err == nil {
+		delete(s.failures, operation)
+		return
+	}
+	if s.failures == nil {
+		s.failures = map[string]error{}
+	}
+	s.failures[operation] = err
+}
+
+// SetIntercept sets a function called before every operation, e.g. to delay or count operations. If it returns an
+// error, the operation fails with it.
+func (s *Simulator) SetIntercept(intercept func(ctx context.Context, operation string) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.intercept = intercept
+}
+
+// Update modifies the state of the simulated BMC.
+func (s *Simulator) Update(update func(state *SimulatorState)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	update(&s.state)
+}
+
+// State returns a copy of the state of the simulated BMC.
+func (s *Simulator) State() SimulatorState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := s.state
+	state.Systems = slices.Clone(s.state.Systems)
+	for i := range state.Systems {
+		state.Systems[i].BiosAttributes = maps.Clone(state.Systems[i].BiosAttributes)
+		state.Systems[i].PendingBiosAttributes = maps.Clone(state.Systems[i].PendingBiosAttributes)
+		state.Systems[i].BiosPasswords = maps.Clone(state.Systems[i].BiosPasswords)
+	}
+	state.Accounts = maps.Clone(s.state.Accounts)
+	state.FirmwareInventory = slices.Clone(s.state.FirmwareInventory)
+	state.FirmwareUpdates = slices.Clone(s.state.FirmwareUpdates)
+	state.Tasks = maps.Clone(s.state.Tasks)
+	state.ResourceBlocks = slices.Clone(s.state.ResourceBlocks)
+	state.DPUs = slices.Clone(s.state.DPUs)
+	return state
+}
+
+// do runs an operation on the state of the simulated BMC after applying the scripted behavior.
+func (s *Simulator) do(ctx context.Context, operation string, fn func(state *SimulatorState) error) error {
+	s.mu.Lock()
+	intercept, failure := s.intercept, s.failures[operation]
+	s.mu.Unlock()
+	if intercept != nil {
+		if err := intercept(ctx, operation); err != nil {
+			return err
+		}
+	}
+	if failure != nil {
+		return failure
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fn(&s.state)
+}
+
+// system returns the system with the UUID.
+func (s *SimulatorState) system(systemUUID string) (*SimulatedSystem, error) {
+	for i := range s.Systems {
+		if s.Systems[i].Info.SystemUUID == systemUUID {
+			return &s.Systems[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no system found for %v", systemUUID)
+}
+
+// boot applies the pending BIOS attributes and the boot override of the system.
+func (s *SimulatedSystem) boot() {
+	maps.Copy(s.BiosAttributes, s.PendingBiosAttributes)
+	clear(s.PendingBiosAttributes)
+	if !s.IgnoresBootOverride {
+		s.BootOverride = ""
+		s.BootInterface = ""
+	}
+	s.Info.PowerState = redfish.OnPowerState
+}
+
+// switchDPUModes applies the pending modes of the DPUs, which happens on a power cycle of their server.
+func (s *SimulatorState) switchDPUModes() {
+	for i := range s.DPUs {
+		if s.DPUs[i].PendingMode != "" {
+			s.DPUs[i].Mode = s.DPUs[i].PendingMode
+			s.DPUs[i].PendingMode = ""
+		}
+	}
+}
+
+// RedfishFakeBMC is an implementation of the BMC interface backed by a Simulator, which allows running full
+// workflows without any BMC on the network.
+type RedfishFakeBMC struct {
+	simulator *Simulator
+}
+
+// NewRedfishFakeBMCClient creates a new RedfishFakeBMC for the simulated BMC registered for the address of the
+// endpoint.
+func NewRedfishFakeBMCClient(ctx context.Context, options BMCOptions) (BMC, error) {
+	endpoint, err := url.Parse(options.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse endpoint: %w", err)
+	}
+	simulator := Simulators.Get(endpoint.Host)
+	if err := simulator.do(ctx, "Login", func(state *SimulatorState) error {
+		if len(state.Accounts) > 0 && state.Accounts[options.Username] != options.Password {
+			return errors.New("invalid credentials")
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to connect to simulated BMC: %w", err)
+	}
+	return &RedfishFakeBMC{simulator: simulator}, nil
+}
+
+func (r *RedfishFakeBMC) Logout() {}
+
+func (r *RedfishFakeBMC) PowerOn(ctx context.Context, systemUUID string) error {
+	return r.simulator.do(ctx, "PowerOn", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		if system.Info.PowerState != redfish.OnPowerState {
+			system.boot()
+			if system == &state.Systems[0] {
+				state.switchDPUModes()
+			}
+		}
+		return nil
+	})
+}
+
+func (r *RedfishFakeBMC) PowerOff(ctx context.Context, systemUUID string) error {
+	return r.setPowerState(ctx, "PowerOff", systemUUID, redfish.OffPowerState)
+}
+
+func (r *RedfishFakeBMC) ForcePowerOff(ctx context.Context, systemUUID string) error {
+	return r.setPowerState(ctx, "ForcePowerOff", systemUUID, redfish.OffPowerState)
+}
+
+func (r *RedfishFakeBMC) setPowerState(ctx context.Context, operation, systemUUID string, powerState redfish.PowerState) error {
+	return r.simulator.do(ctx, operation, func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		system.Info.PowerState = powerState
+		return nil
+	})
+}
+
+func (r *RedfishFakeBMC) Reset(ctx context.Context, systemUUID string, resetType redfish.ResetType) error {
+	return r.simulator.do(ctx, "Reset", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		switch resetType {
+		case redfish.ForceOffResetType, redfish.GracefulShutdownResetType:
+			system.Info.PowerState = redfish.OffPowerState
+		default:
+			system.boot()
+		}
+		return nil
+	})
+}
+
+func (r *RedfishFakeBMC) SetPXEBootOnce(ctx context.Context, systemUUID string) error {
+	return r.SetPXEBootOnceWithMode(ctx, systemUUID, "")
+}
+
+func (r *RedfishFakeBMC) SetPXEBootOnceWithMode(ctx context.Context, systemUUID string, _ redfish.BootSourceOverrideMode) error {
+	return r.simulator.do(ctx, "SetPXEBootOnce", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		system.BootOverride = string(redfish.PxeBootSourceOverrideTarget)
+		return nil
+	})
+}
+
+func (r *RedfishFakeBMC) SetPXEBootOnceFromInterfaces(ctx context.Context, systemUUID string, _ redfish.BootSourceOverrideMode, interfaces []NetworkBootInterface) error {
+	return r.simulator.do(ctx, "SetPXEBootOnce", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		for _, nic := range interfaces {
+			for _, ni := range system.Info.NetworkInterfaces {
+				if (nic.MACAddress != "" && normalizeMACAddress(nic.MACAddress) == normalizeMACAddress(ni.MACAddress)) ||
+					(nic.Name != "" && strings.EqualFold(nic.Name, ni.ID)) {
+					system.BootOverride = string(redfish.UefiBootNextBootSourceOverrideTarget)
+					system.BootInterface = ni.MACAddress
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("no network boot option found for the interfaces %v", interfaces)
+	})
+}
+
+func (r *RedfishFakeBMC) GetSystemInfo(ctx context.Context, systemUUID string) (SystemInfo, error) {
+	var info SystemInfo
+	err := r.simulator.do(ctx, "GetSystemInfo", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		info = system.Info
+		return nil
+	})
+	return info, err
+}
+
+func (r *RedfishFakeBMC) GetProcessors(ctx context.Context, systemUUID string) ([]Processor, error) {
+	var processors []Processor
+	err := r.simulator.do(ctx, "GetProcessors", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		processors = append(processors, system.Info.Processors...)
+		return nil
+	})
+	return processors, err
+}
+
+// GetSystemRack returns the rack of the chassis of the system.
+func (r *RedfishFakeBMC) GetSystemRack(ctx context.Context, systemUUID string) (string, error) {
+	var rack string
+	err := r.simulator.do(ctx, "GetSystemRack", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		rack = system.Rack
+		return nil
+	})
+	return rack, err
+}
+
+func (r *RedfishFakeBMC) GetDPUs(ctx context.Context, systemUUID string) ([]DPU, error) {
+	var dpus []DPU
+	err := r.simulator.do(ctx, "GetDPUs", func(state *SimulatorState) error {
+		if len(state.Systems) == 0 || !strings.EqualFold(state.Systems[0].Info.SystemUUID, systemUUID) {
+			return nil
+		}
+		dpus = append(dpus, state.DPUs...)
+		return nil
+	})
+	return dpus, err
+}
+
+func (r *RedfishFakeBMC) SetDPUMode(ctx context.Context, dpuURI string, mode string) error {
+	return r.simulator.do(ctx, "SetDPUMode", func(state *SimulatorState) error {
+		for i := range state.DPUs {
+			if state.DPUs[i].URI == dpuURI {
+				state.DPUs[i].PendingMode = mode
+				return nil
+			}
+		}
+		return fmt.Errorf("no DPU found for URI %s", dpuURI)
+	})
+}
+
+// simulatedBootModeAttribute is the BIOS attribute the simulated systems select their boot mode with, which takes
+// the values of iDRAC.
+const simulatedBootModeAttribute = "BootMode"
+
+func (r *RedfishFakeBMC) GetBootMode(ctx context.Context, systemUUID string) (BootMode, BootMode, error) {
+	var mode, pendingMode BootMode
+	err := r.simulator.do(ctx, "GetBootMode", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		mode = BootModeUEFI
+		if system.BiosAttributes[simulatedBootModeAttribute] == "Bios" {
+			mode = BootModeLegacy
+		}
+		switch system.PendingBiosAttributes[simulatedBootModeAttribute] {
+		case "Uefi":
+			pendingMode = BootModeUEFI
+		case "Bios":
+			pendingMode = BootModeLegacy
+		}
+		return nil
+	})
+	return mode, pendingMode, err
+}
+
+func (r *RedfishFakeBMC) SetBootMode(ctx context.Context, systemUUID string, mode BootMode) error {
+	return r.simulator.do(ctx, "SetBootMode", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		value := "Uefi"
+		if mode == BootModeLegacy {
+			value = "Bios"
+		}
+		if system.PendingBiosAttributes == nil {
+			system.PendingBiosAttributes = map[string]string{}
+		}
+		system.PendingBiosAttributes[simulatedBootModeAttribute] = value
+		return nil
+	})
+}
+
+func (r *RedfishFakeBMC) GetSystems(ctx context.Context) ([]Server, error) {
+	var servers []Server
+	err := r.simulator.do(ctx, "GetSystems", func(state *SimulatorState) error {
+		for _, system := range state.Systems {
+			servers = append(servers, serverForSimulatedSystem(system))
+		}
+		return nil
+	})
+	return servers, err
+}
+
+func (r *RedfishFakeBMC) GetSystem(ctx context.Context, systemURI string) (Server, error) {
+	var server Server
+	err := r.simulator.do(ctx, "GetSystem", func(state *SimulatorState) error {
+		for _, system := range state.Systems {
+			if system.URI == systemURI {
+				server = serverForSimulatedSystem(system)
+				return nil
+			}
+		}
+		return fmt.Errorf("no system found for %v", systemURI)
+	})
+	return server, err
+}
+
+func serverForSimulatedSystem(system SimulatedSystem) Server {
+	systemType := system.SystemType
+	if systemType == "" {
+		systemType = SystemTypePhysical
+	}
+	return Server{
+		URI:          system.URI,
+		Name:         system.Name,
+		UUID:         system.Info.SystemUUID,
+		Model:        system.Info.Model,
+		Manufacturer: system.Info.Manufacturer,
+		PowerState:   PowerState(system.Info.PowerState),
+		SerialNumber: system.Info.SerialNumber,
+		SystemType:   systemType,
+	}
+}
+
+func (r *RedfishFakeBMC) GetManager() (*Manager, error) {
+	var manager Manager
+	err := r.simulator.do(context.Background(), "GetManager", func(state *SimulatorState) error {
+		manager = state.Manager
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &manager, nil
+}
+
+func (r *RedfishFakeBMC) ResetManager(ctx context.Context, _ redfish.ResetType) error {
+	return r.simulator.do(ctx, "ResetManager", func(state *SimulatorState) error {
+		state.ManagerResets++
+		return nil
+	})
+}
+
+func (r *RedfishFakeBMC) GetBootOrder(ctx context.Context, systemUUID string) ([]string, error) {
+	var order []string
+	err := r.simulator.do(ctx, "GetBootOrder", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		order = slices.Clone(system.BootOrder)
+		return nil
+	})
+	return order, err
+}
+
+func (r *RedfishFakeBMC) GetBootSourceOverride(ctx context.Context, systemUUID string) (BootSourceOverride, error) {
+	override := BootSourceOverride{Enabled: redfish.DisabledBootSourceOverrideEnabled}
+	err := r.simulator.do(ctx, "GetBootSourceOverride", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		if system.BootOverride != "" {
+			override.Enabled = redfish.OnceBootSourceOverrideEnabled
+			override.Target = redfish.BootSourceOverrideTarget(system.BootOverride)
+		}
+		return nil
+	})
+	return override, err
+}
+
+func (r *RedfishFakeBMC) SetBootOrder(ctx context.Context, systemUUID string, order []string) error {
+	return r.simulator.do(ctx, "SetBootOrder", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		system.BootOrder = slices.Clone(order)
+		return nil
+	})
+}
+
+func (r *RedfishFakeBMC) GetBiosVersion(ctx context.Context, systemUUID string) (string, error) {
+	var version string
+	err := r.simulator.do(ctx, "GetBiosVersion", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		version = system.BiosVersion
+		return nil
+	})
+	return version, err
+}
+
+func (r *RedfishFakeBMC) GetBiosAttributeValues(ctx context.Context, systemUUID string, attributes []string) (map[string]string, error) {
+	values := map[string]string{}
+	err := r.simulator.do(ctx, "GetBiosAttributeValues", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		for _, attribute := range attributes {
+			if value, ok := system.BiosAttributes[attribute]; ok {
+				values[attribute] = value
+			}
+		}
+		return nil
+	})
+	return values, err
+}
+
+func (r *RedfishFakeBMC) GetBiosPendingAttributeValues(ctx context.Context, systemUUID string) (map[string]string, error) {
+	var values map[string]string
+	err := r.simulator.do(ctx, "GetBiosPendingAttributeValues", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		values = maps.Clone(system.PendingBiosAttributes)
+		return nil
+	})
+	return values, err
+}
+
+// SetBiosAttributes stages the attributes, which take effect on the next boot of the system.
+func (r *RedfishFakeBMC) SetBiosAttributes(ctx context.Context, systemUUID string, attributes map[string]string) (bool, error) {
+	err := r.simulator.do(ctx, "SetBiosAttributes", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		if system.PendingBiosAttributes == nil {
+			system.PendingBiosAttributes = map[string]string{}
+		}
+		maps.Copy(system.PendingBiosAttributes, attributes)
+		return nil
+	})
+	return err == nil, err
+}
+
+// SetBiosAttributesApplyAt stages the attributes like SetBiosAttributes, as the simulator applies staged
+// attributes on the next boot regardless of the apply time.
+func (r *RedfishFakeBMC) SetBiosAttributesApplyAt(ctx context.Context, systemUUID string, attributes map[string]string, _ SettingsApplyTime) (bool, error) {
+	return r.SetBiosAttributes(ctx, systemUUID, attributes)
+}
+
+// SetBiosPassword changes the BIOS password if the old password matches the current one.
+func (r *RedfishFakeBMC) SetBiosPassword(ctx context.Context, systemUUID, passwordName, oldPassword, newPassword string) error {
+	return r.simulator.do(ctx, "SetBiosPassword", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		if system.BiosPasswords[passwordName] != oldPassword {
+			return fmt.Errorf("old BIOS password %s does not match", passwordName)
+		}
+		if system.BiosPasswords == nil {
+			system.BiosPasswords = map[string]string{}
+		}
+		system.BiosPasswords[passwordName] = newPassword
+		return nil
+	})
+}
+
+// SetDriveLocationIndicator turns the location indicator of the drive with the given name or ID on or off. The
+// storages are copied before the change, so that states returned earlier are not modified.
+func (r *RedfishFakeBMC) SetDriveLocationIndicator(ctx context.Context, systemUUID, driveName string, active bool) error {
+	return r.simulator.do(ctx, "SetDriveLocationIndicator", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		system.Storages = slices.Clone(system.Storages)
+		for i := range system.Storages {
+			for j, drive := range system.Storages[i].Drives {
+				if drive.Name == driveName || drive.ID == driveName {
+					system.Storages[i].Drives = slices.Clone(system.Storages[i].Drives)
+					system.Storages[i].Drives[j].LocationIndicatorActive = active
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("drive %s not found", driveName)
+	})
+}
+
+func (r *RedfishFakeBMC) GetStorages(ctx context.Context, systemUUID string) ([]Storage, error) {
+	var storages []Storage
+	err := r.simulator.do(ctx, "GetStorages", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		storages = slices.Clone(system.Storages)
+		return nil
+	})
+	return storages, err
+}
+
+func (r *RedfishFakeBMC) GetChassisFRUs(ctx context.Context, systemUUID string) (ChassisFRUs, error) {
+	var frus ChassisFRUs
+	err := r.simulator.do(ctx, "GetChassisFRUs", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		frus = ChassisFRUs{
+			PowerSupplies:   slices.Clone(system.ChassisFRUs.PowerSupplies),
+			PowerRedundancy: slices.Clone(system.ChassisFRUs.PowerRedundancy),
+			Fans:            slices.Clone(system.ChassisFRUs.Fans),
+			FanRedundancy:   slices.Clone(system.ChassisFRUs.FanRedundancy),
+		}
+		return nil
+	})
+	return frus, err
+}
+
+// WaitForServerPowerState returns immediately, as the power state of simulated systems changes instantly.
+func (r *RedfishFakeBMC) WaitForServerPowerState(ctx context.Context, systemUUID string, powerState redfish.PowerState) error {
+	return r.simulator.do(ctx, "WaitForServerPowerState", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		if system.Info.PowerState != powerState {
+			return fmt.Errorf("system %s is in power state %s instead of %s", systemUUID, system.Info.PowerState, powerState)
+		}
+		return nil
+	})
+}
+
+// UpdateFirmware records the update and returns a task which has already completed.
+func (r *RedfishFakeBMC) UpdateFirmware(ctx context.Context, params FirmwareUpdateParameters) (string, error) {
+	imageURI, err := url.Parse(params.ImageURI)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse image URI: %w", err)
+	}
+	var taskURI string
+	err = r.simulator.do(ctx, "UpdateFirmware", func(state *SimulatorState) error {
+		if version := imageURI.Query().Get("version"); version != "" {
+			for i := range state.FirmwareInventory {
+				if slices.Contains(params.Targets, state.FirmwareInventory[i].URI) {
+					state.FirmwareInventory[i].Version = version
+				}
+			}
+		}
+		state.FirmwareUpdates = append(state.FirmwareUpdates, params)
+		taskURI = fmt.Sprintf("/redfish/v1/TaskService/Tasks/%d", len(state.FirmwareUpdates))
+		if state.Tasks == nil {
+			state.Tasks = map[string]Task{}
+		}
+		state.Tasks[taskURI] = Task{
+			URI:             taskURI,
+			State:           redfish.CompletedTaskState,
+			PercentComplete: 100,
+			Message:         "The firmware update has completed.",
+		}
+		return nil
+	})
+	return taskURI, err
+}
+
+func (r *RedfishFakeBMC) GetTask(ctx context.Context, taskURI string) (*Task, error) {
+	var task Task
+	err := r.simulator.do(ctx, "GetTask", func(state *SimulatorState) error {
+		var ok bool
+		if task, ok = state.Tasks[taskURI]; !ok {
+			return fmt.Errorf("no task found for %v", taskURI)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (r *RedfishFakeBMC) GetFirmwareInventory(ctx context.Context) ([]FirmwareInventory, error) {
+	var inventory []FirmwareInventory
+	err := r.simulator.do(ctx, "GetFirmwareInventory", func(state *SimulatorState) error {
+		inventory = slices.Clone(state.FirmwareInventory)
+		return nil
+	})
+	return inventory, err
+}
+
+func (r *RedfishFakeBMC) GetEventLogEntries(ctx context.Context, systemUUID string, limit int) ([]LogEntry, error) {
+	var entries []LogEntry
+	err := r.simulator.do(ctx, "GetEventLogEntries", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		entries = system.EventLog
+		if len(entries) > limit {
+			entries = entries[len(entries)-limit:]
+		}
+		entries = slices.Clone(entries)
+		return nil
+	})
+	return entries, err
+}
+
+func (r *RedfishFakeBMC) SetISCSIBoot(ctx context.Context, systemUUID string, params ISCSIBootParameters) error {
+	return r.simulator.do(ctx, "SetISCSIBoot", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		system.ISCSIBoot = &params
+		system.BootOverride = string(redfish.RemoteDriveBootSourceOverrideTarget)
+		return nil
+	})
+}
+
+func (r *RedfishFakeBMC) CollectCrashDump(ctx context.Context, systemUUID string) error {
+	return r.simulator.do(ctx, "CollectCrashDump", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		if system.CrashDump == nil {
+			return ErrCrashDumpNotSupported
+		}
+		system.CrashDumpTime = time.Now()
+		return nil
+	})
+}
+
+func (r *RedfishFakeBMC) GetCrashDump(ctx context.Context, systemUUID string, since time.Time) (*CrashDump, error) {
+	var dump *CrashDump
+	err := r.simulator.do(ctx, "GetCrashDump", func(state *SimulatorState) error {
+		system, err := state.system(systemUUID)
+		if err != nil {
+			return err
+		}
+		if system.CrashDump == nil {
+			return ErrCrashDumpNotSupported
+		}
+		if !system.CrashDumpTime.IsZero() && !system.CrashDumpTime.Before(since) {
+			dump = &CrashDump{URI: simulatedCrashDumpURI(system), Created: system.CrashDumpTime}
+		}
+		return nil
+	})
+	return dump, err
+}
+
+func simulatedCrashDumpURI(system *SimulatedSystem) string {
+	return system.URI + "/LogServices/Dump/Entries/1/attachment"
+}
+
+func (r *RedfishFakeBMC) DownloadCrashDump(ctx context.Context, dump CrashDump) (io.ReadCloser, error) {
+	var content []byte
+	err := r.simulator.do(ctx, "DownloadCrashDump", func(state *SimulatorState) error {
+		for _, system := range state.Systems {
+			if system.CrashDump != nil && dump.URI == simulatedCrashDumpURI(&system) {
+				content = bytes.Clone(system.CrashDump)
+				return nil
+			}
+		}
+		return fmt.Errorf("crash dump %s not found", dump.URI)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+func (r *RedfishFakeBMC) GetResourceBlocks(ctx context.Context) ([]ResourceBlock, error) {
+	var blocks []ResourceBlock
+	err := r.simulator.do(ctx, "GetResourceBlocks", func(state *SimulatorState) error {
+		blocks = slices.Clone(state.ResourceBlocks)
+		return nil
+	})
+	return blocks, err
+}
+
+// ComposeSystem adds a powered off system and marks the resource blocks as composed. The simulator always composes
+// systems synchronously.
+func (r *RedfishFakeBMC) ComposeSystem(ctx context.Context, name string, resourceBlockURIs []string) (string, string, error) {
+	systemURI := "/redfish/v1/Systems/" + name
+	err := r.simulator.do(ctx, "ComposeSystem", func(state *SimulatorState) error {
+		for _, uri := range resourceBlockURIs {
+			i := slices.IndexFunc(state.ResourceBlocks, func(block ResourceBlock) bool { return block.URI == uri })
+			if i < 0 {
+				return fmt.Errorf("no resource block found for %v", uri)
+			}
+			if state.ResourceBlocks[i].CompositionState != "Unused" {
+				return fmt.Errorf("resource block %v is %s", uri, state.ResourceBlocks[i].CompositionState)
+			}
+		}
+		for i := range state.ResourceBlocks {
+			if slices.Contains(resourceBlockURIs, state.ResourceBlocks[i].URI) {
+				state.ResourceBlocks[i].CompositionState = "Composed"
+			}
+		}
+		state.Systems = append(state.Systems, SimulatedSystem{
+			URI:        systemURI,
+			Name:       name,
+			SystemType: SystemTypeComposed,
+			Info: SystemInfo{
+				SystemUUID: fmt.Sprintf("00000000-0000-0000-0000-%012d", len(state.Systems)),
+				PowerState: redfish.OffPowerState,
+			},
+			BiosAttributes:        map[string]string{},
+			PendingBiosAttributes: map[string]string{},
+		})
+		return nil
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return systemURI, "", nil
+}
+
+// DecomposeSystem removes the system. As the simulator does not track which resource blocks a system was composed
+// of, all composed resource blocks are freed.
+func (r *RedfishFakeBMC) DecomposeSystem(ctx context.Context, systemURI string) error {
+	return r.simulator.do(ctx, "DecomposeSystem", func(state *SimulatorState) error {
+		i := slices.IndexFunc(state.Systems, func(system SimulatedSystem) bool { return system.URI == systemURI })
+		if i < 0 {
+			return fmt.Errorf("no system found for %v", systemURI)
+		}
+		state.Systems = slices.Delete(state.Systems, i, i+1)
+		for i := range state.ResourceBlocks {
+			if state.ResourceBlocks[i].CompositionState == "Composed" {
+				state.ResourceBlocks[i].CompositionState = "Unused"
+			}
+		}
+		return nil
+	})
+}
+
+func (r *RedfishFakeBMC) SetAccountPassword(ctx context.Context, username, password string) error {
+	return r.simulator.do(ctx, "SetAccountPassword", func(state *SimulatorState) error {
+		if state.Accounts == nil {
+			state.Accounts = map[string]string{}
+		}
+		state.Accounts[username] = password
+		return nil
+	})
+}
+
+func (r *RedfishFakeBMC) SetForwarding(ctx context.Context, config ForwardingConfig) error {
+	return r.simulator.do(ctx, "SetForwarding", func(state *SimulatorState) error {
+		state.Forwarding = ForwardingConfig{
+			SNMPTrapDestinations: slices.Clone(config.SNMPTrapDestinations),
+			SyslogTargets:        slices.Clone(config.SyslogTargets),
+		}
+		return nil
+	})
+}
+
+func (r *RedfishFakeBMC) GetMetricReports(ctx context.Context) ([]MetricReport, error) {
+	var reports []MetricReport
+	err := r.simulator.do(ctx, "GetMetricReports", func(state *SimulatorState) error {
+		reports = slices.Clone(state.MetricReports)
+		return nil
+	})
+	return reports, err
+}
@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/ironcore-dev/metal-operator/bmc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+var _ = Describe("RedfishFakeBMC", func() {
+	const systemUUID = "38947555-7742-3448-3784-823347823834"
+
+	var simulator *bmc.Simulator
+
+	BeforeEach(func() {
+		simulator = bmc.NewSimulator()
+		bmc.Simulators.Register("10.0.0.1:8000", simulator)
+		DeferCleanup(bmc.Simulators.Reset)
+	})
+
+	It("should apply pending BIOS attributes and the boot override on the next boot", func(ctx SpecContext) {
+		client, err := bmc.NewRedfishFakeBMCClient(ctx, bmc.BMCOptions{Endpoint: "http://10.0.0.1:8000"})
+		Expect(err).NotTo(HaveOccurred())
+
+		reset, err := client.SetBiosAttributes(ctx, systemUUID, map[string]string{"BootMode": "Uefi"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reset).To(BeTrue())
+		Expect(client.SetPXEBootOnce(ctx, systemUUID)).To(Succeed())
+		Expect(client.GetBiosPendingAttributeValues(ctx, systemUUID)).To(HaveKeyWithValue("BootMode", "Uefi"))
+
+		Expect(client.PowerOn(ctx, systemUUID)).To(Succeed())
+		Expect(client.WaitForServerPowerState(ctx, systemUUID, redfish.OnPowerState)).To(Succeed())
+		Expect(client.GetBiosAttributeValues(ctx, systemUUID, []string{"BootMode"})).To(HaveKeyWithValue("BootMode", "Uefi"))
+		Expect(client.GetBiosPendingAttributeValues(ctx, systemUUID)).To(BeEmpty())
+		Expect(simulator.State().Systems[0].BootOverride).To(BeEmpty())
+	})
+
+	It("should boot once from the selected network interface", func(ctx SpecContext) {
+		client, err := bmc.NewRedfishFakeBMCClient(ctx, bmc.BMCOptions{Endpoint: "http://10.0.0.1:8000"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(client.SetPXEBootOnceFromInterfaces(ctx, systemUUID, "", []bmc.NetworkBootInterface{
+			{MACAddress: "00:00:00:00:00:01"},
+		})).To(MatchError(ContainSubstring("no network boot option found")))
+		Expect(client.SetPXEBootOnceFromInterfaces(ctx, systemUUID, "", []bmc.NetworkBootInterface{
+			{MACAddress: "00:00:00:00:00:01"},
+			{MACAddress: "12-44-6a-3b-04-11"},
+		})).To(Succeed())
+		Expect(simulator.State().Systems[0].BootInterface).To(Equal("12:44:6A:3B:04:11"))
+
+		Expect(client.PowerOn(ctx, systemUUID)).To(Succeed())
+		Expect(simulator.State().Systems[0].BootInterface).To(BeEmpty())
+	})
+
+	It("should keep the boot override pending on firmware ignoring it", func(ctx SpecContext) {
+		client, err := bmc.NewRedfishFakeBMCClient(ctx, bmc.BMCOptions{Endpoint: "http://10.0.0.1:8000"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(client.SetPXEBootOnce(ctx, systemUUID)).To(Succeed())
+		Expect(client.PowerOn(ctx, systemUUID)).To(Succeed())
+		Expect(client.GetBootSourceOverride(ctx, systemUUID)).To(HaveField("Pending()", BeFalse()))
+
+		simulator.Update(func(state *bmc.SimulatorState) {
+			state.Systems[0].IgnoresBootOverride = true
+		})
+		Expect(client.SetPXEBootOnce(ctx, systemUUID)).To(Succeed())
+		Expect(client.Reset(ctx, systemUUID, redfish.ForceRestartResetType)).To(Succeed())
+		Expect(client.GetBootSourceOverride(ctx, systemUUID)).To(Equal(bmc.BootSourceOverride{
+			Enabled: redfish.OnceBootSourceOverrideEnabled,
+			Target:  redfish.PxeBootSourceOverrideTarget,
+		}))
+	})
+
+	It("should only change the BIOS password given the old one", func(ctx SpecContext) {
+		client, err := bmc.NewRedfishFakeBMCClient(ctx, bmc.BMCOptions{Endpoint: "http://10.0.0.1:8000"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(client.SetBiosPassword(ctx, systemUUID, "AdminPassword", "", "first")).To(Succeed())
+		Expect(client.SetBiosPassword(ctx, systemUUID, "AdminPassword", "wrong", "second")).To(HaveOccurred())
+		Expect(client.SetBiosPassword(ctx, systemUUID, "AdminPassword", "first", "second")).To(Succeed())
+		Expect(simulator.State().Systems[0].BiosPasswords).To(HaveKeyWithValue("AdminPassword", "second"))
+	})
+
+	It("should turn the location indicator of drives on and off", func(ctx SpecContext) {
+		client, err := bmc.NewRedfishFakeBMCClient(ctx, bmc.BMCOptions{Endpoint: "http://10.0.0.1:8000"})
+		Expect(err).NotTo(HaveOccurred())
+
+		simulator.Update(func(state *bmc.SimulatorState) {
+			state.Systems[0].Storages = []bmc.Storage{{
+				Entity: bmc.Entity{ID: "1", Name: "Storage"},
+				Drives: []bmc.Drive{{Entity: bmc.Entity{ID: "0", Name: "Disk 0"}}},
+			}}
+		})
+		before := simulator.State()
+
+		Expect(client.SetDriveLocationIndicator(ctx, systemUUID, "Disk 1", true)).To(HaveOccurred())
+		Expect(client.SetDriveLocationIndicator(ctx, systemUUID, "Disk 0", true)).To(Succeed())
+		Expect(client.GetStorages(ctx, systemUUID)).To(ConsistOf(
+			HaveField("Drives", ConsistOf(HaveField("LocationIndicatorActive", BeTrue()))),
+		))
+		Expect(before.Systems[0].Storages[0].Drives[0].LocationIndicatorActive).To(BeFalse())
+
+		Expect(client.SetDriveLocationIndicator(ctx, systemUUID, "0", false)).To(Succeed())
+		Expect(client.GetStorages(ctx, systemUUID)).To(ConsistOf(
+			HaveField("Drives", ConsistOf(HaveField("LocationIndicatorActive", BeFalse()))),
+		))
+	})
+
+	It("should capture crash dumps of systems supporting them", func(ctx SpecContext) {
+		client, err := bmc.NewRedfishFakeBMCClient(ctx, bmc.BMCOptions{Endpoint: "http://10.0.0.1:8000"})
+		Expect(err).NotTo(HaveOccurred())
+
+		start := time.Now().Add(-time.Second)
+		Expect(client.CollectCrashDump(ctx, systemUUID)).To(MatchError(bmc.ErrCrashDumpNotSupported))
+
+		simulator.Update(func(state *bmc.SimulatorState) {
+			state.Systems[0].CrashDump = []byte("crash dump")
+		})
+		Expect(client.GetCrashDump(ctx, systemUUID, start)).To(BeNil())
+		Expect(client.CollectCrashDump(ctx, systemUUID)).To(Succeed())
+		dump, err := client.GetCrashDump(ctx, systemUUID, start)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dump).NotTo(BeNil())
+
+		content, err := client.DownloadCrashDump(ctx, *dump)
+		Expect(err).NotTo(HaveOccurred())
+		defer content.Close() // nolint: errcheck
+		Expect(io.ReadAll(content)).To(Equal([]byte("crash dump")))
+	})
+
+	It("should fail operations as scripted", func(ctx SpecContext) {
+		client, err := bmc.NewRedfishFakeBMCClient(ctx, bmc.BMCOptions{Endpoint: "http://10.0.0.1:8000"})
+		Expect(err).NotTo(HaveOccurred())
+
+		simulator.SetFailure("PowerOn", errors.New("power supply failure"))
+		Expect(client.PowerOn(ctx, systemUUID)).To(MatchError("power supply failure"))
+		simulator.SetFailure("PowerOn", nil)
+
+		var operations []string
+		simulator.SetIntercept(func(_ context.Context, operation string) error {
+			operations = append(operations, operation)
+			return nil
+		})
+		Expect(client.PowerOn(ctx, systemUUID)).To(Succeed())
+		Expect(operations).To(Equal([]string{"PowerOn"}))
+	})
+
+	It("should simulate distinct systems for unknown addresses", func(ctx SpecContext) {
+		first, err := bmc.NewRedfishFakeBMCClient(ctx, bmc.BMCOptions{Endpoint: "http://10.0.0.2:8000"})
+		Expect(err).NotTo(HaveOccurred())
+		second, err := bmc.NewRedfishFakeBMCClient(ctx, bmc.BMCOptions{Endpoint: "http://10.0.0.3:8000"})
+		Expect(err).NotTo(HaveOccurred())
+
+		firstSystems, err := first.GetSystems(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		secondSystems, err := second.GetSystems(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(firstSystems).To(HaveLen(1))
+		Expect(secondSystems).To(HaveLen(1))
+		Expect(firstSystems[0].UUID).NotTo(Equal(secondSystems[0].UUID))
+	})
+
+	It("should only accept the credentials of its accounts", func(ctx SpecContext) {
+		simulator.Update(func(state *bmc.SimulatorState) {
+			state.Accounts = map[string]string{"admin": "secret"}
+		})
+		_, err := bmc.NewRedfishFakeBMCClient(ctx, bmc.BMCOptions{Endpoint: "http://10.0.0.1:8000", Username: "admin", Password: "wrong"})
+		Expect(err).To(HaveOccurred())
+
+		client, err := bmc.NewRedfishFakeBMCClient(ctx, bmc.BMCOptions{Endpoint: "http://10.0.0.1:8000", Username: "admin", Password: "secret"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.SetAccountPassword(ctx, "admin", "rotated")).To(Succeed())
+		Expect(simulator.State().Accounts).To(HaveKeyWithValue("admin", "rotated"))
+	})
+
+	It("should complete firmware updates immediately", func(ctx SpecContext) {
+		client, err := bmc.NewRedfishFakeBMCClient(ctx, bmc.BMCOptions{Endpoint: "http://10.0.0.1:8000"})
+		Expect(err).NotTo(HaveOccurred())
+
+		taskURI, err := client.UpdateFirmware(ctx, bmc.FirmwareUpdateParameters{
+			ImageURI: "http://images/bios.bin?version=2.0.0",
+			Targets:  []string{"/redfish/v1/UpdateService/FirmwareInventory/BIOS"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		task, err := client.GetTask(ctx, taskURI)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(task.State).To(Equal(redfish.CompletedTaskState))
+		Expect(simulator.State().FirmwareUpdates).To(ConsistOf(HaveField("ImageURI", "http://images/bios.bin?version=2.0.0")))
+		Expect(client.GetFirmwareInventory(ctx)).To(ContainElements(
+			SatisfyAll(HaveField("Name", "BIOS"), HaveField("Version", "2.0.0")),
+			SatisfyAll(HaveField("Name", "BMC"), HaveField("Version", "1.45.455")),
+		))
+	})
+	It("should switch the mode of a DPU on the next power on of its server", func(ctx SpecContext) {
+		simulator.Update(func(state *bmc.SimulatorState) {
+			state.DPUs = []bmc.DPU{{
+				Server: bmc.Server{URI: "/redfish/v1/Systems/Bluefield", SystemType: bmc.SystemTypeDPU},
+				Mode:   bmc.DPUModeDPU,
+			}}
+		})
+		client, err := bmc.NewRedfishFakeBMCClient(ctx, bmc.BMCOptions{Endpoint: "http://10.0.0.1:8000"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(client.SetDPUMode(ctx, "/redfish/v1/Systems/Bluefield", bmc.DPUModeNIC)).To(Succeed())
+		Expect(client.GetDPUs(ctx, systemUUID)).To(ConsistOf(SatisfyAll(
+			HaveField("Mode", bmc.DPUModeDPU),
+			HaveField("PendingMode", bmc.DPUModeNIC),
+		)))
+
+		Expect(client.PowerOn(ctx, systemUUID)).To(Succeed())
+		Expect(client.GetDPUs(ctx, systemUUID)).To(ConsistOf(SatisfyAll(
+			HaveField("Mode", bmc.DPUModeNIC),
+			HaveField("PendingMode", BeEmpty()),
+		)))
+	})
+})
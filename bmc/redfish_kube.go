@@ -72,6 +72,19 @@ func (r *RedfishKubeBMC) SetPXEBootOnce(ctx context.Context, systemUUID string)
 	if err := system.SetBoot(setBoot); err != nil {
 		return fmt.Errorf("failed to set the boot order: %w", err)
 	}
+	return r.registerSystem(ctx, systemUUID)
+}
+
+// SetPXEBootOnceFromInterfaces sets the network boot option of the interfaces using Redfish.
+func (r *RedfishKubeBMC) SetPXEBootOnceFromInterfaces(ctx context.Context, systemUUID string, mode redfish.BootSourceOverrideMode, interfaces []NetworkBootInterface) error {
+	if err := r.RedfishBMC.SetPXEBootOnceFromInterfaces(ctx, systemUUID, mode, interfaces); err != nil {
+		return err
+	}
+	return r.registerSystem(ctx, systemUUID)
+}
+
+// registerSystem creates a job registering the system in the registry, as the probe agent of a booted system would.
+func (r *RedfishKubeBMC) registerSystem(_ context.Context, systemUUID string) error {
 	netData := `{"networkInterfaces":[{"name":"dummy0","ipAddress":"127.0.0.2","macAddress":"aa:bb:cc:dd:ee:ff"}]`
 	curlCmd := fmt.Sprintf(
 		`apk add curl && curl -H 'Content-Type: application/json' \
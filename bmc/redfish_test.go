@@ -0,0 +1,1598 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ironcore-dev/metal-operator/bmc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stmcginnis/gofish/common"
+)
+
+var _ = Describe("RedfishBMC", func() {
+	It("should fail to connect if the login exceeds the login timeout", func(ctx SpecContext) {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		DeferCleanup(server.Close)
+		DeferCleanup(func() {
+			close(release)
+		})
+
+		start := time.Now()
+		_, err := bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+			Endpoint:  server.URL,
+			BasicAuth: true,
+			Timeouts:  bmc.OperationTimeouts{Login: 100 * time.Millisecond},
+		})
+		Expect(err).To(MatchError(ContainSubstring("failed to connect to redfish endpoint")))
+		Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+	})
+
+	It("should only apply the timeout of an operation to its own requests", func(ctx SpecContext) {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/redfish/v1/":
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"@odata.id": "/redfish/v1/",
+					"Systems":   map[string]any{"@odata.id": "/redfish/v1/Systems"},
+				})
+			case "/redfish/v1/Systems":
+				_ = json.NewEncoder(w).Encode(map[string]any{"@odata.id": "/redfish/v1/Systems", "Members": []any{}})
+			case "/redfish/v1/TaskService/Tasks/1":
+				<-release
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		DeferCleanup(server.Close)
+		DeferCleanup(func() {
+			close(release)
+		})
+
+		client, err := bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+			Endpoint:  server.URL,
+			BasicAuth: true,
+			Timeouts:  bmc.OperationTimeouts{TaskPolling: 100 * time.Millisecond},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Logout)
+
+		start := time.Now()
+		_, err = client.GetTask(ctx, "/redfish/v1/TaskService/Tasks/1")
+		Expect(err).To(HaveOccurred())
+		Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+
+		By("Ensuring that the expired deadline does not affect later operations")
+		Expect(client.GetSystems(ctx)).To(BeEmpty())
+	})
+
+	It("should fall back to basic authentication if the BMC fails to create a session", func(ctx SpecContext) {
+		var sessionRequests int
+		var basicAuthRequests int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, _, ok := r.BasicAuth(); ok {
+				basicAuthRequests++
+			}
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/redfish/v1/":
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"@odata.id": "/redfish/v1/",
+					"Systems":   map[string]any{"@odata.id": "/redfish/v1/Systems"},
+					"Links": map[string]any{
+						"Sessions": map[string]any{"@odata.id": "/redfish/v1/SessionService/Sessions"},
+					},
+				})
+			case "/redfish/v1/Systems":
+				_ = json.NewEncoder(w).Encode(map[string]any{"@odata.id": "/redfish/v1/Systems", "Members": []any{}})
+			case "/redfish/v1/SessionService/Sessions":
+				sessionRequests++
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		DeferCleanup(server.Close)
+
+		client, err := bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+			Endpoint: server.URL,
+			Username: "admin",
+			Password: "password",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Logout)
+		Expect(sessionRequests).To(Equal(1))
+
+		Expect(client.GetSystems(ctx)).To(BeEmpty())
+		Expect(basicAuthRequests).To(BeNumerically(">", 0))
+	})
+
+	It("should include the systems of aggregation sources", func(ctx SpecContext) {
+		resources := map[string]any{
+			"/redfish/v1/": map[string]any{
+				"@odata.id":          "/redfish/v1/",
+				"Systems":            map[string]any{"@odata.id": "/redfish/v1/Systems"},
+				"AggregationService": map[string]any{"@odata.id": "/redfish/v1/AggregationService"},
+			},
+			"/redfish/v1/Systems": map[string]any{
+				"@odata.id": "/redfish/v1/Systems",
+				"Members":   []any{map[string]any{"@odata.id": "/redfish/v1/Systems/rack"}},
+			},
+			"/redfish/v1/Systems/rack": map[string]any{
+				"@odata.id": "/redfish/v1/Systems/rack",
+				"UUID":      "00000000-0000-0000-0000-000000000000",
+			},
+			"/redfish/v1/Systems/node1": map[string]any{
+				"@odata.id": "/redfish/v1/Systems/node1",
+				"UUID":      "11111111-1111-1111-1111-111111111111",
+			},
+			"/redfish/v1/AggregationService": map[string]any{
+				"@odata.id":         "/redfish/v1/AggregationService",
+				"ConnectionMethods": map[string]any{"@odata.id": "/redfish/v1/AggregationService/ConnectionMethods"},
+			},
+			"/redfish/v1/AggregationService/ConnectionMethods": map[string]any{
+				"@odata.id": "/redfish/v1/AggregationService/ConnectionMethods",
+				"Members":   []any{map[string]any{"@odata.id": "/redfish/v1/AggregationService/ConnectionMethods/redfish"}},
+			},
+			"/redfish/v1/AggregationService/ConnectionMethods/redfish": map[string]any{
+				"@odata.id": "/redfish/v1/AggregationService/ConnectionMethods/redfish",
+				"Links": map[string]any{
+					"AggregationSources": []any{map[string]any{"@odata.id": "/redfish/v1/AggregationService/AggregationSources/node1"}},
+				},
+			},
+			"/redfish/v1/AggregationService/AggregationSources/node1": map[string]any{
+				"@odata.id": "/redfish/v1/AggregationService/AggregationSources/node1",
+				"Links": map[string]any{
+					"ResourcesAccessed": []any{
+						map[string]any{"@odata.id": "/redfish/v1/Systems/node1"},
+						map[string]any{"@odata.id": "/redfish/v1/Managers/node1"},
+					},
+				},
+			},
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			resource, ok := resources[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(resource)
+		}))
+		DeferCleanup(server.Close)
+
+		client, err := bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+			Endpoint:  server.URL,
+			BasicAuth: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Logout)
+
+		Expect(client.GetSystems(ctx)).To(ConsistOf(
+			SatisfyAll(
+				HaveField("URI", "/redfish/v1/Systems/rack"),
+				HaveField("UUID", "00000000-0000-0000-0000-000000000000"),
+			),
+			SatisfyAll(
+				HaveField("URI", "/redfish/v1/Systems/node1"),
+				HaveField("UUID", "11111111-1111-1111-1111-111111111111"),
+			),
+		))
+	})
+
+	It("should tolerate failing aggregation members and address systems by their URI", func(ctx SpecContext) {
+		resources := map[string]any{
+			"/redfish/v1/": map[string]any{
+				"@odata.id":          "/redfish/v1/",
+				"Systems":            map[string]any{"@odata.id": "/redfish/v1/Systems"},
+				"AggregationService": map[string]any{"@odata.id": "/redfish/v1/AggregationService"},
+			},
+			"/redfish/v1/Systems": map[string]any{
+				"@odata.id": "/redfish/v1/Systems",
+				"Members":   []any{},
+			},
+			"/redfish/v1/Systems/node1": map[string]any{
+				"@odata.id": "/redfish/v1/Systems/node1",
+				"UUID":      "11111111-1111-1111-1111-111111111111",
+			},
+			"/redfish/v1/AggregationService": map[string]any{
+				"@odata.id":         "/redfish/v1/AggregationService",
+				"ConnectionMethods": map[string]any{"@odata.id": "/redfish/v1/AggregationService/ConnectionMethods"},
+			},
+			"/redfish/v1/AggregationService/ConnectionMethods": map[string]any{
+				"@odata.id": "/redfish/v1/AggregationService/ConnectionMethods",
+				"Members":   []any{map[string]any{"@odata.id": "/redfish/v1/AggregationService/ConnectionMethods/redfish"}},
+			},
+			"/redfish/v1/AggregationService/ConnectionMethods/redfish": map[string]any{
+				"@odata.id": "/redfish/v1/AggregationService/ConnectionMethods/redfish",
+				"Links": map[string]any{
+					"AggregationSources": []any{
+						map[string]any{"@odata.id": "/redfish/v1/AggregationService/AggregationSources/node1"},
+						map[string]any{"@odata.id": "/redfish/v1/AggregationService/AggregationSources/node2"},
+					},
+				},
+			},
+			"/redfish/v1/AggregationService/AggregationSources/node1": map[string]any{
+				"@odata.id": "/redfish/v1/AggregationService/AggregationSources/node1",
+				"Links": map[string]any{
+					"ResourcesAccessed": []any{map[string]any{"@odata.id": "/redfish/v1/Systems/node1"}},
+				},
+			},
+			"/redfish/v1/AggregationService/AggregationSources/node2": map[string]any{
+				"@odata.id": "/redfish/v1/AggregationService/AggregationSources/node2",
+				"Links": map[string]any{
+					"ResourcesAccessed": []any{map[string]any{"@odata.id": "/redfish/v1/Systems/node2"}},
+				},
+			},
+		}
+		var aggregationRequests int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/redfish/v1/AggregationService") {
+				aggregationRequests++
+			}
+			if r.URL.Path == "/redfish/v1/Systems/node2" {
+				http.Error(w, "node unreachable", http.StatusInternalServerError)
+				return
+			}
+			resource, ok := resources[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(resource)
+		}))
+		DeferCleanup(server.Close)
+
+		By("Listing the systems of the members which can be read")
+		client, err := bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+			Endpoint:  server.URL,
+			BasicAuth: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Logout)
+		Expect(client.GetSystems(ctx)).To(ConsistOf(HaveField("URI", "/redfish/v1/Systems/node1")))
+		Expect(client.GetSystemInfo(ctx, "11111111-1111-1111-1111-111111111111")).To(
+			HaveField("SystemUUID", "11111111-1111-1111-1111-111111111111"))
+
+		By("Fetching the system by its URI without traversing the aggregation service")
+		client, err = bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+			Endpoint:  server.URL,
+			BasicAuth: true,
+			SystemURI: "/redfish/v1/Systems/node1",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Logout)
+		aggregationRequests = 0
+		Expect(client.GetSystemInfo(ctx, "11111111-1111-1111-1111-111111111111")).To(
+			HaveField("SystemUUID", "11111111-1111-1111-1111-111111111111"))
+		Expect(aggregationRequests).To(BeZero())
+	})
+
+	It("should push firmware updates to OpenBMC", func(ctx SpecContext) {
+		var updateParameters map[string]any
+		var image []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/redfish/v1/":
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"@odata.id":     "/redfish/v1/",
+					"Vendor":        "OpenBMC",
+					"UpdateService": map[string]any{"@odata.id": "/redfish/v1/UpdateService"},
+				})
+			case "/redfish/v1/UpdateService":
+				if r.Method == http.MethodGet {
+					_ = json.NewEncoder(w).Encode(map[string]any{
+						"@odata.id":            "/redfish/v1/UpdateService",
+						"MultipartHttpPushUri": "/redfish/v1/UpdateService/update",
+					})
+				}
+			case "/redfish/v1/UpdateService/update":
+				defer GinkgoRecover()
+				Expect(r.ParseMultipartForm(1 << 20)).To(Succeed())
+				Expect(json.Unmarshal([]byte(r.MultipartForm.Value["UpdateParameters"][0]), &updateParameters)).To(Succeed())
+				file, _, err := r.FormFile("UpdateFile")
+				Expect(err).NotTo(HaveOccurred())
+				image, err = io.ReadAll(file)
+				Expect(err).NotTo(HaveOccurred())
+				w.Header().Set("Location", "/redfish/v1/TaskService/Tasks/0/Monitor")
+				w.WriteHeader(http.StatusAccepted)
+			case "/images/bmc.tar":
+				_, _ = w.Write([]byte("image"))
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		DeferCleanup(server.Close)
+
+		client, err := bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+			Endpoint:  server.URL,
+			BasicAuth: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Logout)
+		Expect(client.Flavor()).To(Equal(bmc.FlavorOpenBMC))
+
+		taskURI, err := client.UpdateFirmware(ctx, bmc.FirmwareUpdateParameters{
+			ImageURI: server.URL + "/images/bmc.tar",
+			Targets:  []string{"/redfish/v1/UpdateService/FirmwareInventory/bmc"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(taskURI).To(Equal("/redfish/v1/TaskService/Tasks/0"))
+		Expect(image).To(Equal([]byte("image")))
+		Expect(updateParameters).To(Equal(map[string]any{
+			"Targets":                     []any{"/redfish/v1/UpdateService/FirmwareInventory/bmc"},
+			"@Redfish.OperationApplyTime": bmc.OpenBMCDefaultApplyTime,
+		}))
+	})
+
+	It("should reject firmware images for OpenBMC exceeding the size or the transfer protocol", func(ctx SpecContext) {
+		var pushed bool
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/redfish/v1/":
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"@odata.id":     "/redfish/v1/",
+					"Vendor":        "OpenBMC",
+					"UpdateService": map[string]any{"@odata.id": "/redfish/v1/UpdateService"},
+				})
+			case "/redfish/v1/UpdateService":
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"@odata.id":            "/redfish/v1/UpdateService",
+					"MultipartHttpPushUri": "/redfish/v1/UpdateService/update",
+				})
+			case "/redfish/v1/UpdateService/update":
+				pushed = true
+				w.WriteHeader(http.StatusAccepted)
+			case "/images/bmc.tar":
+				_, _ = w.Write([]byte("oversized image"))
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		DeferCleanup(server.Close)
+
+		client, err := bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+			Endpoint:             server.URL,
+			BasicAuth:            true,
+			MaxFirmwareImageSize: int64(len("image")),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Logout)
+
+		_, err = client.UpdateFirmware(ctx, bmc.FirmwareUpdateParameters{ImageURI: server.URL + "/images/bmc.tar"})
+		Expect(err).To(MatchError(ContainSubstring("exceeds the maximum size")))
+
+		_, err = client.UpdateFirmware(ctx, bmc.FirmwareUpdateParameters{
+			ImageURI:         server.URL + "/images/bmc.tar",
+			TransferProtocol: "HTTPS",
+		})
+		Expect(err).To(MatchError(ContainSubstring("does not match the firmware image URI")))
+
+		_, err = client.UpdateFirmware(ctx, bmc.FirmwareUpdateParameters{
+			ImageURI:         "images.example.com/bmc.tar",
+			TransferProtocol: "TFTP",
+		})
+		Expect(err).To(MatchError(ContainSubstring("is not supported")))
+		Expect(pushed).To(BeFalse())
+	})
+	It("should return the numeric values of the metric reports", func(ctx SpecContext) {
+		resources := map[string]any{
+			"/redfish/v1/": map[string]any{
+				"@odata.id":        "/redfish/v1/",
+				"TelemetryService": map[string]any{"@odata.id": "/redfish/v1/TelemetryService"},
+			},
+			"/redfish/v1/TelemetryService": map[string]any{
+				"@odata.id":     "/redfish/v1/TelemetryService",
+				"MetricReports": map[string]any{"@odata.id": "/redfish/v1/TelemetryService/MetricReports"},
+			},
+			"/redfish/v1/TelemetryService/MetricReports": map[string]any{
+				"@odata.id": "/redfish/v1/TelemetryService/MetricReports",
+				"Members":   []any{map[string]any{"@odata.id": "/redfish/v1/TelemetryService/MetricReports/Power"}},
+			},
+			"/redfish/v1/TelemetryService/MetricReports/Power": map[string]any{
+				"@odata.id": "/redfish/v1/TelemetryService/MetricReports/Power",
+				"Id":        "Power",
+				"MetricValues": []any{
+					map[string]any{
+						"MetricId":       "PowerConsumedWatts",
+						"MetricProperty": "/redfish/v1/Chassis/1/Power#/PowerControl/0/PowerConsumedWatts",
+						"MetricValue":    "231.5",
+					},
+					map[string]any{
+						"MetricId":       "PowerState",
+						"MetricProperty": "/redfish/v1/Systems/1#/PowerState",
+						"MetricValue":    "On",
+					},
+				},
+			},
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			resource, ok := resources[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(resource)
+		}))
+		DeferCleanup(server.Close)
+
+		client, err := bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+			Endpoint:  server.URL,
+			BasicAuth: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Logout)
+
+		Expect(client.GetMetricReports(ctx)).To(Equal([]bmc.MetricReport{{
+			ID: "Power",
+			Values: []bmc.MetricValue{{
+				MetricID:       "PowerConsumedWatts",
+				MetricProperty: "/redfish/v1/Chassis/1/Power#/PowerControl/0/PowerConsumedWatts",
+				Value:          231.5,
+			}},
+		}}))
+	})
+
+	It("should boot once from the network boot option of the selected interface", func(ctx SpecContext) {
+		resources := map[string]any{
+			"/redfish/v1/": map[string]any{
+				"@odata.id": "/redfish/v1/",
+				"Systems":   map[string]any{"@odata.id": "/redfish/v1/Systems"},
+			},
+			"/redfish/v1/Systems": map[string]any{
+				"@odata.id": "/redfish/v1/Systems",
+				"Members":   []any{map[string]any{"@odata.id": "/redfish/v1/Systems/1"}},
+			},
+			"/redfish/v1/Systems/1": map[string]any{
+				"@odata.id": "/redfish/v1/Systems/1",
+				"UUID":      "00000000-0000-0000-0000-000000000000",
+				"Boot": map[string]any{
+					"BootOptions": map[string]any{"@odata.id": "/redfish/v1/Systems/1/BootOptions"},
+				},
+			},
+			"/redfish/v1/Systems/1/BootOptions": map[string]any{
+				"@odata.id": "/redfish/v1/Systems/1/BootOptions",
+				"Members": []any{
+					map[string]any{"@odata.id": "/redfish/v1/Systems/1/BootOptions/0001"},
+					map[string]any{"@odata.id": "/redfish/v1/Systems/1/BootOptions/0002"},
+					map[string]any{"@odata.id": "/redfish/v1/Systems/1/BootOptions/0003"},
+					map[string]any{"@odata.id": "/redfish/v1/Systems/1/BootOptions/0004"},
+					map[string]any{"@odata.id": "/redfish/v1/Systems/1/BootOptions/0005"},
+				},
+			},
+			"/redfish/v1/Systems/1/BootOptions/0001": map[string]any{
+				"@odata.id":           "/redfish/v1/Systems/1/BootOptions/0001",
+				"Id":                  "0001",
+				"BootOptionReference": "Boot0001",
+				"DisplayName":         "UEFI PXEv4 (MAC:AABBCCDDEE01)",
+				"UefiDevicePath":      "PciRoot(0x0)/Pci(0x1C,0x0)/MAC(AABBCCDDEE01,0x1)/IPv4(0.0.0.0)",
+			},
+			"/redfish/v1/Systems/1/BootOptions/0002": map[string]any{
+				"@odata.id":           "/redfish/v1/Systems/1/BootOptions/0002",
+				"Id":                  "0002",
+				"BootOptionReference": "Boot0002",
+				"DisplayName":         "UEFI PXEv6 (MAC:AABBCCDDEE02)",
+				"UefiDevicePath":      "PciRoot(0x0)/Pci(0x1D,0x0)/MAC(AABBCCDDEE02,0x1)/IPv6(0000:0000:0000:0000:0000:0000:0000:0000)",
+			},
+			"/redfish/v1/Systems/1/BootOptions/0003": map[string]any{
+				"@odata.id":           "/redfish/v1/Systems/1/BootOptions/0003",
+				"Id":                  "0003",
+				"BootOptionReference": "Boot0003",
+				"DisplayName":         "UEFI PXEv4 (MAC:AABBCCDDEE02)",
+				"UefiDevicePath":      "PciRoot(0x0)/Pci(0x1D,0x0)/MAC(AABBCCDDEE02,0x1)/IPv4(0.0.0.0)",
+			},
+			"/redfish/v1/Systems/1/BootOptions/0004": map[string]any{
+				"@odata.id":           "/redfish/v1/Systems/1/BootOptions/0004",
+				"Id":                  "0004",
+				"BootOptionReference": "Boot0004",
+				"DisplayName":         "PXE Device: Integrated NIC.Slot.1-10 IPv4",
+				"UefiDevicePath":      "PciRoot(0x0)/Pci(0x1E,0x0)/IPv4(0.0.0.0)",
+			},
+			"/redfish/v1/Systems/1/BootOptions/0005": map[string]any{
+				"@odata.id":           "/redfish/v1/Systems/1/BootOptions/0005",
+				"Id":                  "0005",
+				"BootOptionReference": "Boot0005",
+				"DisplayName":         "PXE Device: Integrated NIC.Slot.1-1 IPv4",
+				"UefiDevicePath":      "PciRoot(0x0)/Pci(0x1F,0x0)/IPv4(0.0.0.0)",
+			},
+		}
+		var boot map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPatch && r.URL.Path == "/redfish/v1/Systems/1" {
+				defer GinkgoRecover()
+				var body map[string]map[string]any
+				Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+				boot = body["Boot"]
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			resource, ok := resources[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(resource)
+		}))
+		DeferCleanup(server.Close)
+
+		client, err := bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+			Endpoint:  server.URL,
+			BasicAuth: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Logout)
+
+		Expect(client.SetPXEBootOnceFromInterfaces(ctx, "00000000-0000-0000-0000-000000000000", "", []bmc.NetworkBootInterface{
+			{MACAddress: "aa:bb:cc:dd:ee:03"},
+			{MACAddress: "aa:bb:cc:dd:ee:02"},
+		})).To(Succeed())
+		Expect(boot).To(SatisfyAll(
+			HaveKeyWithValue("BootSourceOverrideEnabled", "Once"),
+			HaveKeyWithValue("BootSourceOverrideMode", "UEFI"),
+			HaveKeyWithValue("BootSourceOverrideTarget", "UefiBootNext"),
+			HaveKeyWithValue("BootNext", "Boot0003"),
+		))
+
+		By("Matching the names of the interfaces on word boundaries")
+		Expect(client.SetPXEBootOnceFromInterfaces(ctx, "00000000-0000-0000-0000-000000000000", "", []bmc.NetworkBootInterface{
+			{Name: "NIC.Slot.1-1"},
+		})).To(Succeed())
+		Expect(boot).To(HaveKeyWithValue("BootNext", "Boot0005"))
+
+		Expect(client.SetPXEBootOnceFromInterfaces(ctx, "00000000-0000-0000-0000-000000000000", "", []bmc.NetworkBootInterface{
+			{Name: "NIC.Slot.3-1"},
+		})).To(MatchError(ContainSubstring("no network boot option found")))
+	})
+
+	It("should only reconfigure iSCSI boot if the target changed and boot once from it", func(ctx SpecContext) {
+		resources := map[string]any{
+			"/redfish/v1/": map[string]any{
+				"@odata.id": "/redfish/v1/",
+				"Systems":   map[string]any{"@odata.id": "/redfish/v1/Systems"},
+			},
+			"/redfish/v1/Systems": map[string]any{
+				"@odata.id": "/redfish/v1/Systems",
+				"Members":   []any{map[string]any{"@odata.id": "/redfish/v1/Systems/1"}},
+			},
+			"/redfish/v1/Systems/1": map[string]any{
+				"@odata.id":         "/redfish/v1/Systems/1",
+				"UUID":              "00000000-0000-0000-0000-000000000000",
+				"NetworkInterfaces": map[string]any{"@odata.id": "/redfish/v1/Systems/1/NetworkInterfaces"},
+			},
+			"/redfish/v1/Systems/1/NetworkInterfaces": map[string]any{
+				"@odata.id": "/redfish/v1/Systems/1/NetworkInterfaces",
+				"Members":   []any{map[string]any{"@odata.id": "/redfish/v1/Systems/1/NetworkInterfaces/NIC1"}},
+			},
+			"/redfish/v1/Systems/1/NetworkInterfaces/NIC1": map[string]any{
+				"@odata.id": "/redfish/v1/Systems/1/NetworkInterfaces/NIC1",
+				"NetworkDeviceFunctions": map[string]any{
+					"Members": []any{map[string]any{"@odata.id": "/redfish/v1/Chassis/1/NetworkAdapters/NIC1/NetworkDeviceFunctions/1"}},
+				},
+			},
+			"/redfish/v1/Chassis/1/NetworkAdapters/NIC1/NetworkDeviceFunctions/1": map[string]any{
+				"@odata.id":              "/redfish/v1/Chassis/1/NetworkAdapters/NIC1/NetworkDeviceFunctions/1",
+				"Id":                     "1",
+				"NetDevFuncCapabilities": []any{"Ethernet", "iSCSI"},
+				"BootMode":               "iSCSI",
+				"iSCSIBoot": map[string]any{
+					"InitiatorName":          "iqn.2024-01.dev.ironcore:initiator",
+					"PrimaryTargetName":      "iqn.2024-01.dev.ironcore:target",
+					"PrimaryTargetIPAddress": "10.0.0.10",
+					"PrimaryTargetTCPPort":   3260,
+					"PrimaryLUN":             0,
+					"AuthenticationMethod":   "None",
+				},
+			},
+		}
+		var patched []string
+		var boot map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPatch {
+				defer GinkgoRecover()
+				patched = append(patched, r.URL.Path)
+				if r.URL.Path == "/redfish/v1/Systems/1" {
+					var body map[string]map[string]any
+					Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+					boot = body["Boot"]
+				}
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			resource, ok := resources[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(resource)
+		}))
+		DeferCleanup(server.Close)
+
+		client, err := bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+			Endpoint:  server.URL,
+			BasicAuth: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Logout)
+
+		params := bmc.ISCSIBootParameters{
+			InitiatorName: "iqn.2024-01.dev.ironcore:initiator",
+			TargetName:    "iqn.2024-01.dev.ironcore:target",
+			TargetAddress: "10.0.0.10",
+			TargetPort:    3260,
+		}
+		Expect(client.SetISCSIBoot(ctx, "00000000-0000-0000-0000-000000000000", params)).To(Succeed())
+		Expect(patched).To(Equal([]string{"/redfish/v1/Systems/1"}))
+		Expect(boot).To(SatisfyAll(
+			HaveKeyWithValue("BootSourceOverrideEnabled", "Once"),
+			HaveKeyWithValue("BootSourceOverrideTarget", "RemoteDrive"),
+		))
+
+		By("Changing the LUN of the target")
+		patched = nil
+		params.LUN = 1
+		Expect(client.SetISCSIBoot(ctx, "00000000-0000-0000-0000-000000000000", params)).To(Succeed())
+		Expect(patched).To(Equal([]string{
+			"/redfish/v1/Chassis/1/NetworkAdapters/NIC1/NetworkDeviceFunctions/1",
+			"/redfish/v1/Systems/1",
+		}))
+	})
+
+	It("should translate an unsupported boot order change of iLO into an error with a hint", func(ctx SpecContext) {
+		resources := map[string]any{
+			"/redfish/v1/": map[string]any{
+				"@odata.id": "/redfish/v1/",
+				"Vendor":    "HPE",
+				"Systems":   map[string]any{"@odata.id": "/redfish/v1/Systems"},
+			},
+			"/redfish/v1/Systems": map[string]any{
+				"@odata.id": "/redfish/v1/Systems",
+				"Members":   []any{map[string]any{"@odata.id": "/redfish/v1/Systems/1"}},
+			},
+			"/redfish/v1/Systems/1": map[string]any{
+				"@odata.id": "/redfish/v1/Systems/1",
+				"UUID":      "00000000-0000-0000-0000-000000000000",
+			},
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.Method == http.MethodPatch && r.URL.Path == "/redfish/v1/Systems/1" {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"error": map[string]any{
+						"code":    "iLO.0.10.ExtendedInfo",
+						"message": "See @Message.ExtendedInfo for more information.",
+						"@Message.ExtendedInfo": []any{map[string]any{
+							"MessageId": "iLO.2.14.UnsupportedOperation",
+						}},
+					},
+				})
+				return
+			}
+			resource, ok := resources[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(resource)
+		}))
+		DeferCleanup(server.Close)
+
+		client, err := bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+			Endpoint:  server.URL,
+			BasicAuth: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Logout)
+		Expect(client.Flavor()).To(Equal(bmc.FlavorHPE))
+
+		err = client.SetBootOrder(ctx, "00000000-0000-0000-0000-000000000000", []string{"Boot0001"})
+		var unsupportedErr *bmc.UnsupportedOperationError
+		Expect(errors.As(err, &unsupportedErr)).To(BeTrue())
+		Expect(unsupportedErr.Operation).To(Equal(bmc.OperationSetBootOrder))
+		Expect(unsupportedErr.MessageID).To(Equal("iLO.2.14.UnsupportedOperation"))
+		Expect(unsupportedErr.Hint).To(ContainSubstring("HPE BIOS attribute Boot Order"))
+		Expect(err.Error()).To(ContainSubstring("not ComputerSystem Boot"))
+	})
+
+	It("should replace the forwarding subscriptions of the event service", func(ctx SpecContext) {
+		resources := map[string]any{
+			"/redfish/v1/": map[string]any{
+				"@odata.id":    "/redfish/v1/",
+				"EventService": map[string]any{"@odata.id": "/redfish/v1/EventService"},
+			},
+			"/redfish/v1/EventService": map[string]any{
+				"@odata.id":     "/redfish/v1/EventService",
+				"Subscriptions": map[string]any{"@odata.id": "/redfish/v1/EventService/Subscriptions"},
+			},
+			"/redfish/v1/EventService/Subscriptions": map[string]any{
+				"@odata.id": "/redfish/v1/EventService/Subscriptions",
+				"Members": []any{
+					map[string]any{"@odata.id": "/redfish/v1/EventService/Subscriptions/1"},
+					map[string]any{"@odata.id": "/redfish/v1/EventService/Subscriptions/2"},
+				},
+			},
+			"/redfish/v1/EventService/Subscriptions/1": map[string]any{
+				"@odata.id": "/redfish/v1/EventService/Subscriptions/1",
+				"Context":   "metal-operator-forwarding",
+			},
+			"/redfish/v1/EventService/Subscriptions/2": map[string]any{
+				"@odata.id": "/redfish/v1/EventService/Subscriptions/2",
+				"Context":   "other-listener",
+			},
+		}
+		var deleted []string
+		var created []map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodDelete:
+				deleted = append(deleted, r.URL.Path)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			case http.MethodPost:
+				defer GinkgoRecover()
+				var body map[string]any
+				Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+				created = append(created, body)
+				w.WriteHeader(http.StatusCreated)
+				return
+			}
+			resource, ok := resources[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(resource)
+		}))
+		DeferCleanup(server.Close)
+
+		client, err := bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+			Endpoint:  server.URL,
+			BasicAuth: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Logout)
+
+		Expect(client.SetForwarding(ctx, bmc.ForwardingConfig{
+			SNMPTrapDestinations: []bmc.SNMPTrapDestination{
+				{Address: "10.0.0.1", Port: 162, Version: bmc.SNMPVersionV2c, Community: "alerts"},
+			},
+			SyslogTargets: []bmc.SyslogTarget{
+				{Address: "10.0.0.2", Port: 514, Protocol: bmc.SyslogProtocolUDP},
+			},
+		})).To(Succeed())
+		Expect(deleted).To(ConsistOf("/redfish/v1/EventService/Subscriptions/1"))
+		Expect(created).To(ConsistOf(
+			SatisfyAll(
+				HaveKeyWithValue("Destination", "snmp://10.0.0.1:162"),
+				HaveKeyWithValue("Protocol", "SNMPv2c"),
+				HaveKeyWithValue("SubscriptionType", "SNMPTrap"),
+				HaveKeyWithValue("SNMP", HaveKeyWithValue("TrapCommunity", "alerts")),
+				HaveKeyWithValue("Context", "metal-operator-forwarding"),
+			),
+			SatisfyAll(
+				HaveKeyWithValue("Destination", "syslog://10.0.0.2:514"),
+				HaveKeyWithValue("Protocol", "SyslogUDP"),
+				HaveKeyWithValue("SubscriptionType", "Syslog"),
+				HaveKeyWithValue("Context", "metal-operator-forwarding"),
+			),
+		))
+	})
+
+	It("should configure the forwarding of iDRAC through its attributes", func(ctx SpecContext) {
+		resources := map[string]any{
+			"/redfish/v1/": map[string]any{
+				"@odata.id": "/redfish/v1/",
+				"Vendor":    "Dell",
+				"Managers":  map[string]any{"@odata.id": "/redfish/v1/Managers"},
+			},
+			"/redfish/v1/Managers": map[string]any{
+				"@odata.id": "/redfish/v1/Managers",
+				"Members":   []any{map[string]any{"@odata.id": "/redfish/v1/Managers/iDRAC.Embedded.1"}},
+			},
+			"/redfish/v1/Managers/iDRAC.Embedded.1": map[string]any{
+				"@odata.id": "/redfish/v1/Managers/iDRAC.Embedded.1",
+			},
+		}
+		var attributes map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPatch && r.URL.Path == "/redfish/v1/Managers/iDRAC.Embedded.1/Attributes" {
+				defer GinkgoRecover()
+				var body map[string]map[string]any
+				Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+				attributes = body["Attributes"]
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			resource, ok := resources[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(resource)
+		}))
+		DeferCleanup(server.Close)
+
+		client, err := bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+			Endpoint:  server.URL,
+			BasicAuth: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Logout)
+		Expect(client.Flavor()).To(Equal(bmc.FlavorDell))
+
+		Expect(client.SetForwarding(ctx, bmc.ForwardingConfig{
+			SNMPTrapDestinations: []bmc.SNMPTrapDestination{
+				{Address: "10.0.0.1", Port: 162, Version: bmc.SNMPVersionV2c, Community: "alerts"},
+			},
+			SyslogTargets: []bmc.SyslogTarget{
+				{Address: "10.0.0.2", Port: 514, Protocol: bmc.SyslogProtocolUDP},
+			},
+		})).To(Succeed())
+		Expect(attributes).To(SatisfyAll(
+			HaveKeyWithValue("SNMPAlert.1.Destination", "10.0.0.1"),
+			HaveKeyWithValue("SNMPAlert.1.State", "Enabled"),
+			HaveKeyWithValue("SNMPAlert.2.State", "Disabled"),
+			HaveKeyWithValue("SNMP.1.TrapFormat", "SNMPv2"),
+			HaveKeyWithValue("SNMP.1.AlertPort", BeNumerically("==", 162)),
+			HaveKeyWithValue("SysLog.1.SysLogEnable", "Enabled"),
+			HaveKeyWithValue("SysLog.1.Server1", "10.0.0.2"),
+			HaveKeyWithValue("SysLog.1.Server2", ""),
+			HaveKeyWithValue("SysLog.1.Port", BeNumerically("==", 514)),
+		))
+	})
+
+	It("should switch the boot mode through the BIOS attribute of iDRAC", func(ctx SpecContext) {
+		resources := map[string]any{
+			"/redfish/v1/": map[string]any{
+				"@odata.id": "/redfish/v1/",
+				"Vendor":    "Dell",
+				"Systems":   map[string]any{"@odata.id": "/redfish/v1/Systems"},
+			},
+			"/redfish/v1/Systems": map[string]any{
+				"@odata.id": "/redfish/v1/Systems",
+				"Members":   []any{map[string]any{"@odata.id": "/redfish/v1/Systems/System.Embedded.1"}},
+			},
+			"/redfish/v1/Systems/System.Embedded.1": map[string]any{
+				"@odata.id": "/redfish/v1/Systems/System.Embedded.1",
+				"UUID":      "4c4c4544-0051-3810-8057-b5c04f4e4e32",
+				"Bios":      map[string]any{"@odata.id": "/redfish/v1/Systems/System.Embedded.1/Bios"},
+			},
+			"/redfish/v1/Systems/System.Embedded.1/Bios": map[string]any{
+				"@odata.id": "/redfish/v1/Systems/System.Embedded.1/Bios",
+				"@Redfish.Settings": map[string]any{
+					"SettingsObject": map[string]any{"@odata.id": "/redfish/v1/Systems/System.Embedded.1/Bios/Settings"},
+				},
+				"Attributes": map[string]any{"BootMode": "Bios", "BootModeSelect": "UEFI"},
+			},
+			"/redfish/v1/Systems/System.Embedded.1/Bios/Settings": map[string]any{
+				"@odata.id":  "/redfish/v1/Systems/System.Embedded.1/Bios/Settings",
+				"Attributes": map[string]any{},
+			},
+		}
+		var settings map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPatch && r.URL.Path == "/redfish/v1/Systems/System.Embedded.1/Bios/Settings" {
+				defer GinkgoRecover()
+				Expect(json.NewDecoder(r.Body).Decode(&settings)).To(Succeed())
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			resource, ok := resources[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(resource)
+		}))
+		DeferCleanup(server.Close)
+
+		client, err := bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+			Endpoint:  server.URL,
+			BasicAuth: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Logout)
+
+		mode, pendingMode, err := client.GetBootMode(ctx, "4c4c4544-0051-3810-8057-b5c04f4e4e32")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mode).To(Equal(bmc.BootModeLegacy))
+		Expect(pendingMode).To(BeEmpty())
+
+		Expect(client.SetBootMode(ctx, "4c4c4544-0051-3810-8057-b5c04f4e4e32", bmc.BootModeUEFI)).To(Succeed())
+		Expect(settings).To(HaveKeyWithValue("Attributes", map[string]any{"BootMode": "Uefi"}))
+	})
+
+	It("should return the BIOS attributes pending in the settings object", func(ctx SpecContext) {
+		resources := map[string]any{
+			"/redfish/v1/": map[string]any{
+				"@odata.id": "/redfish/v1/",
+				"Systems":   map[string]any{"@odata.id": "/redfish/v1/Systems"},
+			},
+			"/redfish/v1/Systems": map[string]any{
+				"@odata.id": "/redfish/v1/Systems",
+				"Members": []any{
+					map[string]any{"@odata.id": "/redfish/v1/Systems/1"},
+					map[string]any{"@odata.id": "/redfish/v1/Systems/2"},
+				},
+			},
+			"/redfish/v1/Systems/1": map[string]any{
+				"@odata.id": "/redfish/v1/Systems/1",
+				"UUID":      "11111111-1111-1111-1111-111111111111",
+				"Bios":      map[string]any{"@odata.id": "/redfish/v1/Systems/1/Bios"},
+			},
+			"/redfish/v1/Systems/1/Bios": map[string]any{
+				"@odata.id": "/redfish/v1/Systems/1/Bios",
+				"@Redfish.Settings": map[string]any{
+					"SettingsObject": map[string]any{"@odata.id": "/redfish/v1/Systems/1/Bios/Settings"},
+				},
+				"Attributes": map[string]any{"SriovGlobalEnable": "Disabled", "ProcCores": 8},
+			},
+			"/redfish/v1/Systems/1/Bios/Settings": map[string]any{
+				"@odata.id":  "/redfish/v1/Systems/1/Bios/Settings",
+				"Attributes": map[string]any{"SriovGlobalEnable": "Enabled", "ProcCores": 4},
+			},
+			"/redfish/v1/Systems/2": map[string]any{
+				"@odata.id": "/redfish/v1/Systems/2",
+				"UUID":      "22222222-2222-2222-2222-222222222222",
+				"Bios":      map[string]any{"@odata.id": "/redfish/v1/Systems/2/Bios"},
+			},
+			"/redfish/v1/Systems/2/Bios": map[string]any{
+				"@odata.id":  "/redfish/v1/Systems/2/Bios",
+				"Attributes": map[string]any{"SriovGlobalEnable": "Disabled"},
+			},
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			resource, ok := resources[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(resource)
+		}))
+		DeferCleanup(server.Close)
+
+		client, err := bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+			Endpoint:  server.URL,
+			BasicAuth: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Logout)
+
+		Expect(client.GetBiosPendingAttributeValues(ctx, "11111111-1111-1111-1111-111111111111")).To(Equal(map[string]string{
+			"SriovGlobalEnable": "Enabled",
+			"ProcCores":         "4",
+		}))
+		By("Reporting no pending attributes for a BIOS without a settings object")
+		Expect(client.GetBiosPendingAttributeValues(ctx, "22222222-2222-2222-2222-222222222222")).To(BeEmpty())
+	})
+
+	It("should change the password of the account reported by a BMC requiring a password change", func(ctx SpecContext) {
+		var patchedPassword string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case r.URL.Path == "/redfish/v1/":
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"@odata.id":      "/redfish/v1/",
+					"Systems":        map[string]any{"@odata.id": "/redfish/v1/Systems"},
+					"AccountService": map[string]any{"@odata.id": "/redfish/v1/AccountService"},
+				})
+			case r.Method == http.MethodPatch && r.URL.Path == "/redfish/v1/AccountService/Accounts/2":
+				defer GinkgoRecover()
+				var body map[string]string
+				Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+				patchedPassword = body["Password"]
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				w.WriteHeader(http.StatusForbidden)
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"error": map[string]any{
+						"code":    "Base.1.12.GeneralError",
+						"message": "A general error has occurred.",
+						"@Message.ExtendedInfo": []any{map[string]any{
+							"MessageId":   "Base.1.12.PasswordChangeRequired",
+							"MessageArgs": []string{"/redfish/v1/AccountService/Accounts/2"},
+						}},
+					},
+				})
+			}
+		}))
+		DeferCleanup(server.Close)
+
+		client, err := bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+			Endpoint:  server.URL,
+			Username:  "root",
+			Password:  "calvin",
+			BasicAuth: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Logout)
+
+		_, err = client.GetSystems(ctx)
+		accountURI, required := bmc.PasswordChangeRequired(err)
+		Expect(required).To(BeTrue())
+		Expect(accountURI).To(Equal("/redfish/v1/AccountService/Accounts/2"))
+
+		Expect(client.SetAccountPassword(ctx, "root", "Rotated-Password1")).To(Succeed())
+		Expect(patchedPassword).To(Equal("Rotated-Password1"))
+	})
+
+	It("should re-authenticate once the session expired", func(ctx SpecContext) {
+		var sessions []string
+		var deletedSessions []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case r.URL.Path == "/redfish/v1/":
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"@odata.id": "/redfish/v1/",
+					"Systems":   map[string]any{"@odata.id": "/redfish/v1/Systems"},
+					"Links": map[string]any{
+						"Sessions": map[string]any{"@odata.id": "/redfish/v1/SessionService/Sessions"},
+					},
+				})
+			case r.Method == http.MethodPost && r.URL.Path == "/redfish/v1/SessionService/Sessions":
+				sessions = append(sessions, fmt.Sprintf("token-%d", len(sessions)))
+				w.Header().Set("X-Auth-Token", sessions[len(sessions)-1])
+				w.Header().Set("Location", fmt.Sprintf("/redfish/v1/SessionService/Sessions/%d", len(sessions)-1))
+				w.WriteHeader(http.StatusCreated)
+			case r.Method == http.MethodDelete:
+				deletedSessions = append(deletedSessions, r.URL.Path)
+				w.WriteHeader(http.StatusNoContent)
+			case r.Header.Get("X-Auth-Token") != sessions[len(sessions)-1]:
+				w.WriteHeader(http.StatusUnauthorized)
+			case r.URL.Path == "/redfish/v1/Systems":
+				_ = json.NewEncoder(w).Encode(map[string]any{"@odata.id": "/redfish/v1/Systems", "Members": []any{}})
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		DeferCleanup(server.Close)
+
+		client, err := bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+			Endpoint: server.URL,
+			Username: "admin",
+			Password: "password",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sessions).To(HaveLen(1))
+
+		By("expiring the session")
+		sessions = append(sessions, "expired")
+		Expect(client.GetSystems(ctx)).To(BeEmpty())
+		Expect(sessions).To(HaveLen(3))
+		Expect(client.GetSystems(ctx)).To(BeEmpty())
+		Expect(sessions).To(HaveLen(3))
+
+		client.Logout()
+		Expect(deletedSessions).To(ConsistOf("/redfish/v1/SessionService/Sessions/2"))
+	})
+
+	It("should set BIOS attributes at the maintenance window start if the BMC offers it", func(ctx SpecContext) {
+		resources := map[string]any{
+			"/redfish/v1/": map[string]any{
+				"@odata.id":  "/redfish/v1/",
+				"Systems":    map[string]any{"@odata.id": "/redfish/v1/Systems"},
+				"Registries": map[string]any{"@odata.id": "/redfish/v1/Registries"},
+			},
+			"/redfish/v1/Systems": map[string]any{
+				"@odata.id": "/redfish/v1/Systems",
+				"Members":   []any{map[string]any{"@odata.id": "/redfish/v1/Systems/1"}},
+			},
+			"/redfish/v1/Systems/1": map[string]any{
+				"@odata.id": "/redfish/v1/Systems/1",
+				"UUID":      "00000000-0000-0000-0000-000000000000",
+				"Bios":      map[string]any{"@odata.id": "/redfish/v1/Systems/1/Bios"},
+			},
+			"/redfish/v1/Systems/1/Bios": map[string]any{
+				"@odata.id": "/redfish/v1/Systems/1/Bios",
+				"@Redfish.Settings": map[string]any{
+					"SettingsObject":      map[string]any{"@odata.id": "/redfish/v1/Systems/1/Bios/Settings"},
+					"SupportedApplyTimes": []string{"OnReset", "AtMaintenanceWindowStart"},
+				},
+			},
+			"/redfish/v1/Registries": map[string]any{
+				"@odata.id": "/redfish/v1/Registries",
+				"Members":   []any{map[string]any{"@odata.id": "/redfish/v1/Registries/BiosAttributeRegistry"}},
+			},
+			"/redfish/v1/Registries/BiosAttributeRegistry": map[string]any{
+				"@odata.id": "/redfish/v1/Registries/BiosAttributeRegistry",
+				"Id":        "BiosAttributeRegistry",
+				"Location":  []any{map[string]any{"Uri": "/redfish/v1/Registries/BiosAttributeRegistry/Registry"}},
+			},
+			"/redfish/v1/Registries/BiosAttributeRegistry/Registry": map[string]any{
+				"@odata.id": "/redfish/v1/Registries/BiosAttributeRegistry/Registry",
+				"RegistryEntries": map[string]any{
+					"Attributes": []any{map[string]any{
+						"AttributeName": "BootMode",
+						"Type":          "String",
+						"ResetRequired": true,
+					}},
+				},
+			},
+		}
+		var settings map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPatch && r.URL.Path == "/redfish/v1/Systems/1/Bios/Settings" {
+				defer GinkgoRecover()
+				Expect(json.NewDecoder(r.Body).Decode(&settings)).To(Succeed())
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			resource, ok := resources[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(resource)
+		}))
+		DeferCleanup(server.Close)
+
+		client, err := bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+			Endpoint:  server.URL,
+			BasicAuth: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Logout)
+
+		start := time.Date(2024, 11, 4, 22, 0, 0, 0, time.UTC)
+		reset, err := client.SetBiosAttributesApplyAt(ctx, "00000000-0000-0000-0000-000000000000",
+			map[string]string{"BootMode": "Uefi"}, bmc.SettingsApplyTime{
+				ApplyTime:                 "AtMaintenanceWindowStart",
+				MaintenanceWindowStart:    start,
+				MaintenanceWindowDuration: 2 * time.Hour,
+			})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reset).To(BeTrue())
+		Expect(settings).To(HaveKeyWithValue("Attributes", HaveKeyWithValue("BootMode", "Uefi")))
+		Expect(settings).To(HaveKeyWithValue("@Redfish.SettingsApplyTime", SatisfyAll(
+			HaveKeyWithValue("ApplyTime", "AtMaintenanceWindowStart"),
+			HaveKeyWithValue("MaintenanceWindowStartTime", "2024-11-04T22:00:00Z"),
+			HaveKeyWithValue("MaintenanceWindowDurationInSeconds", BeNumerically("==", 7200)),
+		)))
+
+		_, err = client.SetBiosAttributesApplyAt(ctx, "00000000-0000-0000-0000-000000000000",
+			map[string]string{"BootMode": "Uefi"}, bmc.SettingsApplyTime{ApplyTime: "InMaintenanceWindowOnReset"})
+		Expect(err).To(MatchError(bmc.ErrApplyTimeNotSupported))
+	})
+
+	It("should change the BIOS password with the ChangePassword action", func(ctx SpecContext) {
+		resources := map[string]any{
+			"/redfish/v1/": map[string]any{
+				"@odata.id": "/redfish/v1/",
+				"Systems":   map[string]any{"@odata.id": "/redfish/v1/Systems"},
+			},
+			"/redfish/v1/Systems": map[string]any{
+				"@odata.id": "/redfish/v1/Systems",
+				"Members":   []any{map[string]any{"@odata.id": "/redfish/v1/Systems/1"}},
+			},
+			"/redfish/v1/Systems/1": map[string]any{
+				"@odata.id": "/redfish/v1/Systems/1",
+				"UUID":      "00000000-0000-0000-0000-000000000000",
+				"Bios":      map[string]any{"@odata.id": "/redfish/v1/Systems/1/Bios"},
+			},
+			"/redfish/v1/Systems/1/Bios": map[string]any{
+				"@odata.id": "/redfish/v1/Systems/1/Bios",
+				"Actions": map[string]any{
+					"#Bios.ChangePassword": map[string]any{"target": "/redfish/v1/Systems/1/Bios/Actions/Bios.ChangePassword"},
+				},
+			},
+		}
+		var change map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost && r.URL.Path == "/redfish/v1/Systems/1/Bios/Actions/Bios.ChangePassword" {
+				defer GinkgoRecover()
+				Expect(json.NewDecoder(r.Body).Decode(&change)).To(Succeed())
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			resource, ok := resources[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(resource)
+		}))
+		DeferCleanup(server.Close)
+
+		client, err := bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+			Endpoint:  server.URL,
+			BasicAuth: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Logout)
+
+		Expect(client.SetBiosPassword(ctx, "00000000-0000-0000-0000-000000000000", "AdminPassword", "old", "new")).To(Succeed())
+		Expect(change).To(Equal(map[string]any{
+			"PasswordName": "AdminPassword",
+			"OldPassword":  "old",
+			"NewPassword":  "new",
+		}))
+	})
+
+	It("should return the task of an asynchronous composition and report the composed system by name", func(ctx SpecContext) {
+		resources := map[string]any{
+			"/redfish/v1/": map[string]any{
+				"@odata.id": "/redfish/v1/",
+				"Systems":   map[string]any{"@odata.id": "/redfish/v1/Systems"},
+			},
+			"/redfish/v1/Systems": map[string]any{
+				"@odata.id": "/redfish/v1/Systems",
+				"Members":   []any{map[string]any{"@odata.id": "/redfish/v1/Systems/1"}},
+			},
+			"/redfish/v1/Systems/1": map[string]any{
+				"@odata.id":  "/redfish/v1/Systems/1",
+				"Name":       "my-composed-server",
+				"SystemType": "Composed",
+			},
+		}
+		var composition map[string]any
+		var server *httptest.Server
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost && r.URL.Path == "/redfish/v1/Systems" {
+				defer GinkgoRecover()
+				Expect(json.NewDecoder(r.Body).Decode(&composition)).To(Succeed())
+				w.Header().Set("Location", server.URL+"/redfish/v1/TaskService/Tasks/1")
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			resource, ok := resources[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(resource)
+		}))
+		DeferCleanup(server.Close)
+
+		client, err := bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+			Endpoint:  server.URL,
+			BasicAuth: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Logout)
+
+		systemURI, taskURI, err := client.ComposeSystem(ctx, "my-composed-server", []string{"/redfish/v1/CompositionService/ResourceBlocks/1"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(systemURI).To(BeEmpty())
+		Expect(taskURI).To(Equal("/redfish/v1/TaskService/Tasks/1"))
+		Expect(composition).To(HaveKeyWithValue("Name", "my-composed-server"))
+
+		systems, err := client.GetSystems(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(systems).To(ConsistOf(SatisfyAll(
+			HaveField("URI", "/redfish/v1/Systems/1"),
+			HaveField("Name", "my-composed-server"),
+			HaveField("SystemType", bmc.SystemTypeComposed),
+		)))
+	})
+
+	It("should report the controllers, enclosures and bays of the storages", func(ctx SpecContext) {
+		resources := map[string]any{
+			"/redfish/v1/": map[string]any{
+				"@odata.id": "/redfish/v1/",
+				"Systems":   map[string]any{"@odata.id": "/redfish/v1/Systems"},
+			},
+			"/redfish/v1/Systems": map[string]any{
+				"@odata.id": "/redfish/v1/Systems",
+				"Members":   []any{map[string]any{"@odata.id": "/redfish/v1/Systems/1"}},
+			},
+			"/redfish/v1/Systems/1": map[string]any{
+				"@odata.id": "/redfish/v1/Systems/1",
+				"UUID":      "00000000-0000-0000-0000-000000000000",
+				"Storage":   map[string]any{"@odata.id": "/redfish/v1/Systems/1/Storage"},
+			},
+			"/redfish/v1/Systems/1/Storage": map[string]any{
+				"@odata.id": "/redfish/v1/Systems/1/Storage",
+				"Members":   []any{map[string]any{"@odata.id": "/redfish/v1/Systems/1/Storage/RAID"}},
+			},
+			"/redfish/v1/Systems/1/Storage/RAID": map[string]any{
+				"@odata.id":   "/redfish/v1/Systems/1/Storage/RAID",
+				"Id":          "RAID",
+				"Name":        "RAID Storage",
+				"Controllers": map[string]any{"@odata.id": "/redfish/v1/Systems/1/Storage/RAID/Controllers"},
+				"Volumes":     map[string]any{"@odata.id": "/redfish/v1/Systems/1/Storage/RAID/Volumes"},
+				"Drives":      []any{map[string]any{"@odata.id": "/redfish/v1/Systems/1/Storage/RAID/Drives/0"}},
+			},
+			"/redfish/v1/Systems/1/Storage/RAID/Controllers": map[string]any{
+				"@odata.id": "/redfish/v1/Systems/1/Storage/RAID/Controllers",
+				"Members":   []any{map[string]any{"@odata.id": "/redfish/v1/Systems/1/Storage/RAID/Controllers/0"}},
+			},
+			"/redfish/v1/Systems/1/Storage/RAID/Controllers/0": map[string]any{
+				"@odata.id":       "/redfish/v1/Systems/1/Storage/RAID/Controllers/0",
+				"Id":              "0",
+				"Name":            "RAID Controller",
+				"Manufacturer":    "Contoso",
+				"Model":           "RAID 9000",
+				"FirmwareVersion": "52.1.0",
+				"SerialNumber":    "C123",
+			},
+			"/redfish/v1/Systems/1/Storage/RAID/Volumes": map[string]any{
+				"@odata.id": "/redfish/v1/Systems/1/Storage/RAID/Volumes",
+				"Members":   []any{map[string]any{"@odata.id": "/redfish/v1/Systems/1/Storage/RAID/Volumes/0"}},
+			},
+			"/redfish/v1/Systems/1/Storage/RAID/Volumes/0": map[string]any{
+				"@odata.id": "/redfish/v1/Systems/1/Storage/RAID/Volumes/0",
+				"Id":        "0",
+				"Name":      "Volume 0",
+				"Status":    map[string]any{"State": "Enabled", "Health": "Warning"},
+			},
+			"/redfish/v1/Systems/1/Storage/RAID/Drives/0": map[string]any{
+				"@odata.id":    "/redfish/v1/Systems/1/Storage/RAID/Drives/0",
+				"Id":           "0",
+				"Name":         "Disk 0",
+				"SerialNumber": "D789",
+				"Status":       map[string]any{"State": "Enabled", "Health": "Critical"},
+				"PhysicalLocation": map[string]any{
+					"PartLocation": map[string]any{"ServiceLabel": "Front Bay 3", "LocationType": "Bay", "LocationOrdinalValue": 3},
+				},
+				"Links": map[string]any{
+					"Chassis": map[string]any{"@odata.id": "/redfish/v1/Chassis/Backplane"},
+				},
+			},
+			"/redfish/v1/Chassis/Backplane": map[string]any{
+				"@odata.id":    "/redfish/v1/Chassis/Backplane",
+				"Id":           "Backplane",
+				"Name":         "Front Backplane",
+				"Model":        "BP-12",
+				"SerialNumber": "B456",
+			},
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			resource, ok := resources[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(resource)
+		}))
+		DeferCleanup(server.Close)
+
+		client, err := bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+			Endpoint:  server.URL,
+			BasicAuth: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Logout)
+
+		storages, err := client.GetStorages(ctx, "00000000-0000-0000-0000-000000000000")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(storages).To(ConsistOf(SatisfyAll(
+			HaveField("Controllers", ConsistOf(bmc.StorageController{
+				Entity:          bmc.Entity{ID: "0", Name: "RAID Controller"},
+				Manufacturer:    "Contoso",
+				Model:           "RAID 9000",
+				FirmwareVersion: "52.1.0",
+				SerialNumber:    "C123",
+			})),
+			HaveField("Volumes", ConsistOf(SatisfyAll(
+				HaveField("Name", "Volume 0"),
+				HaveField("Health", common.WarningHealth),
+			))),
+			HaveField("Drives", ConsistOf(SatisfyAll(
+				HaveField("Name", "Disk 0"),
+				HaveField("SerialNumber", "D789"),
+				HaveField("Health", common.CriticalHealth),
+				HaveField("Bay", "Front Bay 3"),
+				HaveField("Enclosure", &bmc.Enclosure{
+					Entity:       bmc.Entity{ID: "Backplane", Name: "Front Backplane"},
+					Model:        "BP-12",
+					SerialNumber: "B456",
+				}),
+			))),
+		)))
+	})
+
+	It("should report the power supplies and fans of the chassis with their redundancy", func(ctx SpecContext) {
+		resources := map[string]any{
+			"/redfish/v1/": map[string]any{
+				"@odata.id": "/redfish/v1/",
+				"Systems":   map[string]any{"@odata.id": "/redfish/v1/Systems"},
+			},
+			"/redfish/v1/Systems": map[string]any{
+				"@odata.id": "/redfish/v1/Systems",
+				"Members":   []any{map[string]any{"@odata.id": "/redfish/v1/Systems/1"}},
+			},
+			"/redfish/v1/Systems/1": map[string]any{
+				"@odata.id": "/redfish/v1/Systems/1",
+				"UUID":      "00000000-0000-0000-0000-000000000000",
+				"Links": map[string]any{
+					"Chassis": []any{map[string]any{"@odata.id": "/redfish/v1/Chassis/1"}},
+				},
+			},
+			"/redfish/v1/Chassis/1": map[string]any{
+				"@odata.id": "/redfish/v1/Chassis/1",
+				"Id":        "1",
+				"Power":     map[string]any{"@odata.id": "/redfish/v1/Chassis/1/Power"},
+				"Thermal":   map[string]any{"@odata.id": "/redfish/v1/Chassis/1/Thermal"},
+			},
+			"/redfish/v1/Chassis/1/Power": map[string]any{
+				"@odata.id": "/redfish/v1/Chassis/1/Power",
+				"PowerSupplies": []any{
+					map[string]any{
+						"MemberId":           "0",
+						"Name":               "PSU 1",
+						"Manufacturer":       "Contoso",
+						"Model":              "PS-800",
+						"SerialNumber":       "P001",
+						"PartNumber":         "800-1",
+						"FirmwareVersion":    "1.2.0",
+						"PowerCapacityWatts": 800,
+						"Status":             map[string]any{"State": "Enabled", "Health": "OK"},
+					},
+					map[string]any{
+						"MemberId": "1",
+						"Status":   map[string]any{"State": "Absent"},
+					},
+				},
+				"Redundancy": []any{
+					map[string]any{
+						"MemberId":        "0",
+						"Name":            "PSU Redundancy",
+						"Mode":            "N+m",
+						"MinNumNeeded":    1,
+						"MaxNumSupported": 2,
+						"Status":          map[string]any{"State": "Enabled", "Health": "Critical"},
+					},
+				},
+			},
+			"/redfish/v1/Chassis/1/Thermal": map[string]any{
+				"@odata.id": "/redfish/v1/Chassis/1/Thermal",
+				"Fans": []any{
+					map[string]any{
+						"MemberId":     "0",
+						"Name":         "Fan 1",
+						"Model":        "F-40",
+						"SerialNumber": "F001",
+						"Status":       map[string]any{"State": "Enabled", "Health": "OK"},
+					},
+				},
+			},
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			resource, ok := resources[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(resource)
+		}))
+		DeferCleanup(server.Close)
+
+		client, err := bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+			Endpoint:  server.URL,
+			BasicAuth: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Logout)
+
+		frus, err := client.GetChassisFRUs(ctx, "00000000-0000-0000-0000-000000000000")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(frus.PowerSupplies).To(Equal([]bmc.PowerSupply{
+			{
+				Entity:             bmc.Entity{ID: "0", Name: "PSU 1"},
+				Manufacturer:       "Contoso",
+				Model:              "PS-800",
+				SerialNumber:       "P001",
+				PartNumber:         "800-1",
+				FirmwareVersion:    "1.2.0",
+				PowerCapacityWatts: 800,
+				State:              common.EnabledState,
+				Health:             common.OKHealth,
+			},
+			{
+				Entity: bmc.Entity{ID: "1", Name: "1"},
+				State:  common.AbsentState,
+			},
+		}))
+		Expect(frus.PowerRedundancy).To(Equal([]bmc.Redundancy{{
+			Name:            "PSU Redundancy",
+			Mode:            "N+m",
+			MinNumNeeded:    1,
+			MaxNumSupported: 2,
+			State:           common.EnabledState,
+			Health:          common.CriticalHealth,
+		}}))
+		Expect(frus.Fans).To(Equal([]bmc.Fan{{
+			Entity:       bmc.Entity{ID: "0", Name: "Fan 1"},
+			Model:        "F-40",
+			SerialNumber: "F001",
+			State:        common.EnabledState,
+			Health:       common.OKHealth,
+		}}))
+		Expect(frus.FanRedundancy).To(BeEmpty())
+	})
+
+	It("should only read the chassis of the system for its rack", func(ctx SpecContext) {
+		resources := map[string]any{
+			"/redfish/v1/": map[string]any{
+				"@odata.id": "/redfish/v1/",
+				"Systems":   map[string]any{"@odata.id": "/redfish/v1/Systems"},
+			},
+			"/redfish/v1/Systems": map[string]any{
+				"@odata.id": "/redfish/v1/Systems",
+				"Members":   []any{map[string]any{"@odata.id": "/redfish/v1/Systems/1"}},
+			},
+			"/redfish/v1/Systems/1": map[string]any{
+				"@odata.id":     "/redfish/v1/Systems/1",
+				"UUID":          "00000000-0000-0000-0000-000000000000",
+				"MemorySummary": map[string]any{"TotalSystemMemoryGiB": 64},
+				"Links": map[string]any{
+					"Chassis": []any{map[string]any{"@odata.id": "/redfish/v1/Chassis/1"}},
+				},
+			},
+			"/redfish/v1/Chassis/1": map[string]any{
+				"@odata.id": "/redfish/v1/Chassis/1",
+				"Id":        "1",
+				"Location":  map[string]any{"Placement": map[string]any{"Rack": "R12"}},
+			},
+		}
+		var chassisRequests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/redfish/v1/Chassis") {
+				chassisRequests.Add(1)
+			}
+			resource, ok := resources[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(resource)
+		}))
+		DeferCleanup(server.Close)
+
+		client, err := bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+			Endpoint:  server.URL,
+			BasicAuth: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Logout)
+
+		_, err = client.GetSystemInfo(ctx, "00000000-0000-0000-0000-000000000000")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(chassisRequests.Load()).To(BeZero())
+
+		Expect(client.GetSystemRack(ctx, "00000000-0000-0000-0000-000000000000")).To(Equal("R12"))
+		Expect(chassisRequests.Load()).To(BeEquivalentTo(1))
+	})
+
+	It("should derive the architecture from the processors", func() {
+		Expect(bmc.ArchitectureFromProcessors([]bmc.Processor{
+			{ProcessorType: "GPU", InstructionSet: "x86-64"},
+			{ProcessorType: "CPU", ProcessorArchitecture: "ARM", InstructionSet: "ARM-A64"},
+		})).To(Equal(bmc.ArchitectureAArch64))
+		Expect(bmc.ArchitectureFromProcessors([]bmc.Processor{{ProcessorArchitecture: "x86"}})).To(Equal(bmc.ArchitectureX8664))
+		Expect(bmc.ArchitectureFromProcessors([]bmc.Processor{{ProcessorType: "CPU"}})).To(BeEmpty())
+	})
+})
@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sync"
+	"time"
+)
+
+// DefaultSessionKeepAliveInterval is the interval in which the session of a client is refreshed during long-running
+// operations. It is well below the default session timeout of 30 minutes of most BMCs.
+const DefaultSessionKeepAliveInterval = 5 * time.Minute
+
+// sessionTransport re-authenticates clients whose Redfish session expired. A request rejected as unauthorized is
+// retried once with the token of a new session, which replaces the expired one for all further requests, so that
+// the expiry is transparent to the gofish client and to the resources it returned before.
+type sessionTransport struct {
+	next     http.RoundTripper
+	username string
+	password string
+
+	mu sync.Mutex
+	// endpoint and originalSession are the base URL and the URI of the session created by the gofish client. They
+	// are empty if the client does not use a session.
+	endpoint        string
+	originalSession string
+	// token and session are the token and the URI of the current session. They are empty until the original
+	// session expired.
+	token   string
+	session string
+}
+
+// track starts tracking the session of the client. It is called once the client logged in.
+func (t *sessionTransport) track(endpoint, session string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.endpoint = endpoint
+	if u, err := url.Parse(session); err == nil {
+		session = u.Path
+	}
+	t.originalSession = session
+}
+
+// currentSession returns the URI of the session the requests are authenticated with, if any.
+func (t *sessionTransport) currentSession() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.session != "" {
+		return t.session
+	}
+	return t.originalSession
+}
+
+func (t *sessionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("X-Auth-Token") == "" {
+		return t.next.RoundTrip(req)
+	}
+	req, token := t.authenticate(req)
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || req.GetBody == nil && req.Body != nil {
+		return resp, err
+	}
+
+	newToken, err := t.reauthenticate(req.Context(), token)
+	if err != nil {
+		// the unauthorized response is returned, as it explains the failure better than the failed login
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	retry.Header.Set("X-Auth-Token", newToken)
+	return t.next.RoundTrip(retry)
+}
+
+// authenticate replaces the token of the expired original session and redirects requests to the original session
+// to the current one, so that the gofish client keeps alive and logs out of the current session. It returns the
+// token the request is sent with.
+func (t *sessionTransport) authenticate(req *http.Request) (*http.Request, string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.token == "" {
+		return req, req.Header.Get("X-Auth-Token")
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("X-Auth-Token", t.token)
+	if t.originalSession != "" && req.URL.Path == t.originalSession {
+		req.URL.Path = t.session
+	}
+	return req, t.token
+}
+
+// reauthenticate creates a new session unless another request already replaced the session of the expired token.
+func (t *sessionTransport) reauthenticate(ctx context.Context, expiredToken string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.originalSession == "" {
+		return "", fmt.Errorf("client does not use a session")
+	}
+	if t.token != "" && t.token != expiredToken {
+		return t.token, nil
+	}
+
+	body, err := json.Marshal(map[string]string{"UserName": t.username, "Password": t.password})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint+path.Dir(t.originalSession), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to create session: %w", err)
+	}
+	defer resp.Body.Close() // nolint: errcheck
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to create session: %s", resp.Status)
+	}
+	token := resp.Header.Get("X-Auth-Token")
+	if token == "" {
+		return "", fmt.Errorf("failed to create session: no token returned")
+	}
+	session := resp.Header.Get("Location")
+	if u, err := url.Parse(session); err == nil {
+		session = u.Path
+	}
+	t.token = token
+	t.session = session
+	return token, nil
+}
+
+// keepSessionAlive refreshes the session of the client in the keep-alive interval until the returned function is
+// called, so that the session does not expire during long-running operations. Clients using basic authentication
+// are left untouched.
+func (r *RedfishBMC) keepSessionAlive(ctx context.Context) func() {
+	interval := r.options.SessionKeepAliveInterval
+	if r.session == nil || interval <= 0 || r.session.currentSession() == "" {
+		return func() {}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// the original session is redirected to the current one once it has been replaced
+				resp, err := r.client.Get(r.session.originalSession)
+				if err == nil {
+					_ = resp.Body.Close()
+				}
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
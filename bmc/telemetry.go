@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stmcginnis/gofish/common"
+)
+
+// MetricReport represents a metric report of the TelemetryService, e.g. the power or thermal readings of the
+// chassis.
+type MetricReport struct {
+	// ID is the ID of the metric report.
+	ID string
+	// Values are the numeric values of the metric report.
+	Values []MetricValue
+}
+
+// MetricValue represents a single value of a metric report.
+type MetricValue struct {
+	// MetricID is the ID of the metric definition of the value.
+	MetricID string
+	// MetricProperty is the URI of the property the value is derived from, e.g.
+	// "/redfish/v1/Chassis/1/Power#/PowerControl/0/PowerConsumedWatts".
+	MetricProperty string
+	// Value is the value of the metric.
+	Value float64
+}
+
+// GetMetricReports returns the metric reports of the TelemetryService. BMCs without a TelemetryService return no
+// reports.
+func (r *RedfishBMC) GetMetricReports(ctx context.Context) ([]MetricReport, error) {
+	var root struct {
+		TelemetryService common.Link
+	}
+	if err := r.getJSON(r.client.GetService().ODataID, &root); err != nil {
+		return nil, fmt.Errorf("failed to get service root: %w", err)
+	}
+	if root.TelemetryService == "" {
+		return nil, nil
+	}
+	var service struct {
+		MetricReports common.Link
+	}
+	if err := r.getJSON(string(root.TelemetryService), &service); err != nil {
+		return nil, fmt.Errorf("failed to get telemetry service: %w", err)
+	}
+	if service.MetricReports == "" {
+		return nil, nil
+	}
+	var collection struct {
+		Members common.Links
+	}
+	if err := r.getJSON(string(service.MetricReports), &collection); err != nil {
+		return nil, fmt.Errorf("failed to get metric reports: %w", err)
+	}
+	result := make([]MetricReport, 0, len(collection.Members))
+	for _, member := range collection.Members.ToStrings() {
+		var report struct {
+			ID           string `json:"Id"`
+			MetricValues []struct {
+				MetricID       string `json:"MetricId"`
+				MetricProperty string
+				MetricValue    string
+			}
+		}
+		if err := r.getJSON(member, &report); err != nil {
+			return nil, fmt.Errorf("failed to get metric report %s: %w", member, err)
+		}
+		metricReport := MetricReport{ID: report.ID}
+		for _, value := range report.MetricValues {
+			// metric values are reported as strings, non-numeric values cannot be exported
+			parsed, err := strconv.ParseFloat(value.MetricValue, 64)
+			if err != nil {
+				continue
+			}
+			metricReport.Values = append(metricReport.Values, MetricValue{
+				MetricID:       value.MetricID,
+				MetricProperty: value.MetricProperty,
+				Value:          parsed,
+			})
+		}
+		result = append(result, metricReport)
+	}
+	return result, nil
+}
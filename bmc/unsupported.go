@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/stmcginnis/gofish/common"
+)
+
+const (
+	// OperationSetBootOrder is the operation setting the persistent boot order of a system.
+	OperationSetBootOrder = "SetBootOrder"
+	// OperationSetPXEBootOnce is the operation setting a one time PXE boot of a system.
+	OperationSetPXEBootOnce = "SetPXEBootOnce"
+	// OperationSetBiosAttributes is the operation setting BIOS attributes of a system.
+	OperationSetBiosAttributes = "SetBiosAttributes"
+	// OperationSetBootMode is the operation setting the boot mode of a system.
+	OperationSetBootMode = "SetBootMode"
+)
+
+// UnsupportedOperationError is returned if a BMC rejects an operation as not supported by its Redfish
+// implementation. It carries a hint on how the vendor supports the operation instead, if one is known.
+type UnsupportedOperationError struct {
+	// Operation is the rejected operation, e.g. OperationSetBootOrder.
+	Operation string
+	// Flavor is the Redfish implementation of the BMC.
+	Flavor Flavor
+	// MessageID is the message ID with which the BMC rejected the operation.
+	MessageID string
+	// Hint describes how the vendor supports the operation instead. It is empty if no hint is known.
+	Hint string
+	// Err is the error returned by the BMC.
+	Err error
+}
+
+func (e *UnsupportedOperationError) Error() string {
+	msg := fmt.Sprintf("%s is not supported by the BMC (%s)", e.Operation, e.MessageID)
+	if e.Hint != "" {
+		msg += ": " + e.Hint
+	}
+	return msg
+}
+
+func (e *UnsupportedOperationError) Unwrap() error {
+	return e.Err
+}
+
+// unsupportedMessages are the suffixes of the message IDs of the Base registry and of vendor registries with
+// which BMCs reject operations their Redfish implementation does not support.
+var unsupportedMessages = []string{
+	".UnsupportedOperation",
+	".ActionNotSupported",
+	".PropertyNotWritable",
+	".PropertyUnknown",
+	".OperationNotAllowed",
+}
+
+// unsupportedOperationHints maps the operations of the vendors to hints on how the vendor supports them instead.
+var unsupportedOperationHints = map[Flavor]map[string]string{
+	FlavorHPE: {
+		OperationSetBootOrder: "iLO requires boot order changes via the HPE BIOS attribute Boot Order, " +
+			"not ComputerSystem Boot",
+		OperationSetPXEBootOnce: "iLO only accepts a one time boot override for boot sources listed in " +
+			"BootSourceOverrideTarget@Redfish.AllowableValues, enable network boot on the NIC via the BIOS first",
+		OperationSetBiosAttributes: "iLO applies BIOS attributes via the pending settings resource " +
+			"Bios/Settings, which requires a reset of the server",
+	},
+	FlavorDell: {
+		OperationSetBootOrder: "iDRAC requires boot order changes via the Dell BIOS attributes SetBootOrderEn " +
+			"and UefiBootSeq, which are applied by a BIOS configuration job",
+		OperationSetPXEBootOnce: "iDRAC rejects boot overrides while a BIOS configuration job is pending, " +
+			"wait for the job queue of the iDRAC Lifecycle Controller to finish",
+		OperationSetBiosAttributes: "iDRAC applies BIOS attributes via a BIOS configuration job, " +
+			"delete pending jobs of the iDRAC Lifecycle Controller first",
+	},
+	FlavorOpenBMC: {
+		OperationSetBootOrder: "OpenBMC does not support a persistent boot order via ComputerSystem Boot, " +
+			"configure the boot order in the host firmware",
+	},
+}
+
+// translateError translates errors with which the BMC rejected the operation as unsupported into an
+// UnsupportedOperationError with a hint for the flavor of the BMC. Other errors are returned unchanged.
+func (r *RedfishBMC) translateError(operation string, err error) error {
+	if err == nil {
+		return nil
+	}
+	messageID, ok := unsupportedMessageID(err)
+	if !ok {
+		return err
+	}
+	return &UnsupportedOperationError{
+		Operation: operation,
+		Flavor:    r.flavor,
+		MessageID: messageID,
+		Hint:      unsupportedOperationHints[r.flavor][operation],
+		Err:       err,
+	}
+}
+
+// unsupportedMessageID returns the message ID with which the BMC rejected an operation as unsupported.
+func unsupportedMessageID(err error) (string, bool) {
+	// collections report the errors of their members wrapped into a single error
+	var collectionErr *common.CollectionError
+	if errors.As(err, &collectionErr) {
+		for _, failure := range collectionErr.Failures {
+			if messageID, ok := unsupportedMessageID(failure); ok {
+				return messageID, true
+			}
+		}
+		return "", false
+	}
+	var redfishErr *common.Error
+	if !errors.As(err, &redfishErr) {
+		return "", false
+	}
+	for _, info := range redfishErr.ExtendedInfos {
+		if isUnsupportedMessage(info.MessageID) {
+			return info.MessageID, true
+		}
+	}
+	if isUnsupportedMessage(redfishErr.Code) {
+		return redfishErr.Code, true
+	}
+	return "", false
+}
+
+func isUnsupportedMessage(messageID string) bool {
+	for _, suffix := range unsupportedMessages {
+		if strings.HasSuffix(messageID, suffix) {
+			return true
+		}
+	}
+	return false
+}
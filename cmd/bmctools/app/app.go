@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"os"
+
+	metalv1alphav1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+const Name string = "bmctools"
+
+var (
+	scheme     = runtime.NewScheme()
+	kubeconfig string
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(metalv1alphav1.AddToScheme(scheme))
+}
+
+func NewCommand() *cobra.Command {
+	root := &cobra.Command{
+		Use:   Name,
+		Short: "Tools for debugging the BMCs managed by metal-operator",
+		Args:  cobra.NoArgs,
+	}
+	root.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig.")
+	root.AddCommand(NewExploreCommand())
+	root.AddCommand(NewRotatePasswordsCommand())
+	return root
+}
+
+func createClient() (client.Client, error) {
+	if kubeconfig != "" {
+		if err := os.Setenv("KUBECONFIG", kubeconfig); err != nil {
+			return nil, fmt.Errorf("failed to set KUBECONFIG: %w", err)
+		}
+	}
+	if os.Getenv("KUBECONFIG") == "" {
+		return nil, fmt.Errorf("--kubeconfig flag or KUBECONFIG environment variable must be set")
+	}
+
+	clientConfig, err := config.GetConfigWithContext("")
+	if err != nil {
+		return nil, fmt.Errorf("failed getting client config: %w", err)
+	}
+	k8sClient, err := client.New(clientConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed creating controller-runtime client: %w", err)
+	}
+	return k8sClient, nil
+}
@@ -0,0 +1,278 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const redfishRoot = "/redfish/v1"
+
+var exploreInsecure bool
+
+func NewExploreCommand() *cobra.Command {
+	exploreCmd := &cobra.Command{
+		Use:   "explore <bmc-name>",
+		Short: "Interactively browse the Redfish API of a BMC using the address and credentials from the cluster",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runExplore,
+	}
+	exploreCmd.Flags().BoolVar(&exploreInsecure, "insecure", true,
+		"If true, use http instead of https for connecting to the BMC.")
+	return exploreCmd
+}
+
+func runExplore(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	k8sClient, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	e, err := newExplorer(ctx, k8sClient, args[0], exploreInsecure)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Connected to %s, type 'help' for a list of commands.\n", e.endpoint)
+	return e.run(ctx, cmd.InOrStdin(), cmd.OutOrStdout())
+}
+
+// explorer browses the Redfish API of a BMC.
+type explorer struct {
+	httpClient *http.Client
+	endpoint   string
+	username   string
+	password   string
+	cwd        string
+}
+
+// newExplorer resolves the address and credentials of the BMC from its BMC and BMCSecret objects.
+func newExplorer(ctx context.Context, c client.Client, bmcName string, insecure bool) (*explorer, error) {
+	bmcObj, err := bmcutils.GetBMCFromBMCName(ctx, c, bmcName)
+	if err != nil {
+		return nil, err
+	}
+	switch bmcObj.Spec.Protocol.Name {
+	case metalv1alpha1.ProtocolRedfish, metalv1alpha1.ProtocolRedfishLocal, metalv1alpha1.ProtocolRedfishKube:
+	default:
+		return nil, fmt.Errorf("unsupported BMC protocol %s", bmcObj.Spec.Protocol.Name)
+	}
+	address, err := bmcutils.GetBMCAddressForBMC(ctx, c, bmcObj)
+	if err != nil {
+		return nil, err
+	}
+	username, password, err := bmcutils.GetBMCCredentialsForBMCSecretName(ctx, c, bmcObj.Spec.BMCSecretRef.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// the credentials are only sent to the BMC whose certificate is pinned, if any
+	transport.TLSClientConfig = bmc.TLSConfig(bmcObj.Spec.CertificateFingerprint)
+	return &explorer{
+		httpClient: &http.Client{Transport: transport, Timeout: 30 * time.Second},
+		endpoint:   bmcutils.GetBMCURL(bmcObj.Spec.Protocol, address, insecure).String(),
+		username:   username,
+		password:   password,
+		cwd:        redfishRoot,
+	}, nil
+}
+
+func (e *explorer) run(ctx context.Context, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	for {
+		_, _ = fmt.Fprintf(out, "%s> ", e.cwd)
+		if !scanner.Scan() {
+			_, _ = fmt.Fprintln(out)
+			return scanner.Err()
+		}
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		var arg string
+		if len(fields) > 1 {
+			arg = fields[1]
+		}
+
+		var err error
+		switch fields[0] {
+		case "help":
+			_, _ = fmt.Fprintln(out, "Commands:")
+			_, _ = fmt.Fprintln(out, "  ls [path]    list the members of a collection or the links of a resource")
+			_, _ = fmt.Fprintln(out, "  get [path]   show a resource")
+			_, _ = fmt.Fprintln(out, "  cd <path>    change to a resource, a link name of the current resource or ..")
+			_, _ = fmt.Fprintln(out, "  pwd          show the current resource")
+			_, _ = fmt.Fprintln(out, "  exit         leave the explorer")
+		case "pwd":
+			_, _ = fmt.Fprintln(out, e.cwd)
+		case "ls":
+			err = e.list(ctx, out, arg)
+		case "get":
+			err = e.show(ctx, out, arg)
+		case "cd":
+			err = e.changeTo(ctx, arg)
+		case "exit", "quit":
+			return nil
+		default:
+			err = fmt.Errorf("unknown command %q", fields[0])
+		}
+		if err != nil {
+			_, _ = fmt.Fprintln(out, "error:", err)
+		}
+	}
+}
+
+// resolve returns the URI of the given path relative to the current resource. Besides paths, the names of the
+// links of the current resource are accepted.
+func (e *explorer) resolve(ctx context.Context, p string) (string, error) {
+	switch {
+	case p == "":
+		return e.cwd, nil
+	case strings.HasPrefix(p, "/"):
+		return path.Clean(p), nil
+	case p == "..":
+		return path.Dir(e.cwd), nil
+	}
+
+	resource, err := e.get(ctx, e.cwd)
+	if err != nil {
+		return "", err
+	}
+	if uri, ok := resourceLinks(resource)[p]; ok {
+		return uri, nil
+	}
+	return path.Join(e.cwd, p), nil
+}
+
+func (e *explorer) changeTo(ctx context.Context, p string) error {
+	uri, err := e.resolve(ctx, p)
+	if err != nil {
+		return err
+	}
+	if _, err := e.get(ctx, uri); err != nil {
+		return err
+	}
+	e.cwd = uri
+	return nil
+}
+
+func (e *explorer) show(ctx context.Context, out io.Writer, p string) error {
+	uri, err := e.resolve(ctx, p)
+	if err != nil {
+		return err
+	}
+	resource, err := e.get(ctx, uri)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(resource, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format resource: %w", err)
+	}
+	_, _ = fmt.Fprintln(out, string(data))
+	return nil
+}
+
+func (e *explorer) list(ctx context.Context, out io.Writer, p string) error {
+	uri, err := e.resolve(ctx, p)
+	if err != nil {
+		return err
+	}
+	resource, err := e.get(ctx, uri)
+	if err != nil {
+		return err
+	}
+	if members, ok := resource["Members"].([]any); ok {
+		for _, member := range members {
+			if uri := odataID(member); uri != "" {
+				_, _ = fmt.Fprintln(out, uri)
+			}
+		}
+		return nil
+	}
+	links := resourceLinks(resource)
+	for _, name := range slices.Sorted(maps.Keys(links)) {
+		_, _ = fmt.Fprintf(out, "%-30s %s\n", name, links[name])
+	}
+	return nil
+}
+
+func (e *explorer) get(ctx context.Context, uri string) (map[string]any, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.endpoint+uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(e.username, e.password)
+	req.Header.Set("Accept", "application/json")
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", uri, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", uri, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get %s: %s: %s", uri, resp.Status, bytes.TrimSpace(body))
+	}
+	resource := map[string]any{}
+	if err := json.Unmarshal(body, &resource); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", uri, err)
+	}
+	return resource, nil
+}
+
+// resourceLinks returns the URIs of the resources linked from the given resource by their property names,
+// including the links in its Links object.
+func resourceLinks(resource map[string]any) map[string]string {
+	links := map[string]string{}
+	collect := func(properties map[string]any) {
+		for name, value := range properties {
+			if uri := odataID(value); uri != "" {
+				links[name] = uri
+				continue
+			}
+			if items, ok := value.([]any); ok {
+				for i, item := range items {
+					if uri := odataID(item); uri != "" {
+						links[fmt.Sprintf("%s[%d]", name, i)] = uri
+					}
+				}
+			}
+		}
+	}
+	collect(resource)
+	if nested, ok := resource["Links"].(map[string]any); ok {
+		collect(nested)
+	}
+	return links
+}
+
+func odataID(value any) string {
+	if object, ok := value.(map[string]any); ok {
+		if uri, ok := object["@odata.id"].(string); ok {
+			return uri
+		}
+	}
+	return ""
+}
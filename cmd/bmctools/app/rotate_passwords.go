@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	rotateSelector         string
+	rotateInsecure         bool
+	rotateDryRun           bool
+	rotateManagerNamespace string
+)
+
+func NewRotatePasswordsCommand() *cobra.Command {
+	rotateCmd := &cobra.Command{
+		Use:   "rotate-passwords",
+		Short: "Rotate the passwords of the BMC accounts used by metal-operator",
+		Long: `Rotate the passwords of the BMC accounts used by metal-operator.
+
+For each selected BMC, a new password is generated according to the password policy of the BMC vendor and set
+on the BMC. The BMCSecret of the BMC is updated and the login with the new credentials is verified.`,
+		Args: cobra.NoArgs,
+		RunE: runRotatePasswords,
+	}
+	rotateCmd.Flags().StringVar(&rotateSelector, "selector", "", "Label selector of the BMCs to rotate the passwords of.")
+	rotateCmd.Flags().BoolVar(&rotateInsecure, "insecure", true,
+		"If true, use http instead of https for connecting to the BMCs.")
+	rotateCmd.Flags().BoolVar(&rotateDryRun, "dry-run", false, "Only show the BMCs whose passwords would be rotated.")
+	rotateCmd.Flags().StringVar(&rotateManagerNamespace, "manager-namespace", bmcutils.DefaultKubeNamespace,
+		"Namespace of the manager, which the Secrets referenced by BMCSecrets have to be in.")
+	return rotateCmd
+}
+
+// rotationResult is the outcome of the password rotation of a single BMC.
+type rotationResult struct {
+	bmcName string
+	err     error
+}
+
+func runRotatePasswords(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+	selector, err := labels.Parse(rotateSelector)
+	if err != nil {
+		return fmt.Errorf("failed to parse selector: %w", err)
+	}
+	k8sClient, err := createClient()
+	if err != nil {
+		return err
+	}
+	bmcutils.SecretRefNamespace = rotateManagerNamespace
+
+	bmcList := &metalv1alpha1.BMCList{}
+	if err := k8sClient.List(ctx, bmcList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return fmt.Errorf("failed to list BMCs: %w", err)
+	}
+
+	var results []rotationResult
+	for i := range bmcList.Items {
+		bmcObj := &bmcList.Items[i]
+		if rotateDryRun {
+			results = append(results, rotationResult{bmcName: bmcObj.Name})
+			continue
+		}
+		results = append(results, rotationResult{bmcName: bmcObj.Name, err: rotatePassword(ctx, k8sClient, bmcObj)})
+	}
+
+	failed, err := printRotationReport(cmd.OutOrStdout(), results, rotateDryRun)
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to rotate the passwords of %d of %d BMCs", failed, len(results))
+	}
+	return nil
+}
+
+// rotatePassword sets a new password on the BMC, stores it in the BMCSecret and verifies the login with it. The
+// BMCSecret is updated before the login is verified, so that the cluster never loses the current password. If the
+// BMCSecret cannot be updated, the previous password is restored on the BMC, as the new one is never printed.
+func rotatePassword(ctx context.Context, c client.Client, bmcObj *metalv1alpha1.BMC) error {
+	bmcSecret := &metalv1alpha1.BMCSecret{}
+	if err := c.Get(ctx, client.ObjectKey{Name: bmcObj.Spec.BMCSecretRef.Name}, bmcSecret); err != nil {
+		return fmt.Errorf("failed to get BMCSecret: %w", err)
+	}
+	resolvedSecret, err := bmcutils.ResolveBMCSecret(ctx, c, bmcSecret)
+	if err != nil {
+		return err
+	}
+	username, _, err := bmcutils.GetBMCCredentialsFromSecret(resolvedSecret)
+	if err != nil {
+		return err
+	}
+	address, err := bmcutils.GetBMCAddressForBMC(ctx, c, bmcObj)
+	if err != nil {
+		return err
+	}
+	options := bmc.BMCOptions{BasicAuth: true}
+	bmcutils.ApplyBMCTimeouts(&options.Timeouts, bmcObj.Spec.Timeouts)
+
+	bmcClient, err := bmcutils.CreateBMCClient(ctx, c, rotateInsecure, bmcObj.Spec.Protocol, address, bmcSecret,
+		options)
+	if err != nil {
+		return fmt.Errorf("failed to connect to BMC: %w", err)
+	}
+	password, err := bmc.PasswordPolicyForManufacturer(bmcObj.Status.Manufacturer).GeneratePassword()
+	if err != nil {
+		bmcClient.Logout()
+		return err
+	}
+	err = bmcClient.SetAccountPassword(ctx, username, password)
+	bmcClient.Logout()
+	if err != nil {
+		return err
+	}
+
+	if err := bmcutils.UpdateBMCSecretData(ctx, c, bmcSecret, map[string][]byte{
+		metalv1alpha1.BMCSecretPasswordKeyName: []byte(password),
+	}); err != nil {
+		if restoreErr := restorePassword(ctx, c, bmcObj, address, resolvedSecret, username, password, options); restoreErr != nil {
+			return fmt.Errorf("password has been changed on the BMC, but the BMCSecret could not be updated (%w) "+
+				"and the previous password could not be restored (%v), the BMC account has to be recovered manually",
+				err, restoreErr)
+		}
+		return fmt.Errorf("failed to update the BMCSecret, the previous password has been restored on the BMC: %w", err)
+	}
+
+	verifyClient, err := bmcutils.CreateBMCClient(ctx, c, rotateInsecure, bmcObj.Spec.Protocol, address, bmcSecret,
+		options)
+	if err != nil {
+		return fmt.Errorf("failed to verify the login with the new password: %w", err)
+	}
+	defer verifyClient.Logout()
+	if _, err := verifyClient.GetSystems(ctx); err != nil {
+		return fmt.Errorf("failed to verify the login with the new password: %w", err)
+	}
+	return nil
+}
+
+// restorePassword sets the previous password of the account from the resolved BMCSecret again, logging in with the
+// new password.
+func restorePassword(ctx context.Context, c client.Client, bmcObj *metalv1alpha1.BMC, address string,
+	resolvedSecret *metalv1alpha1.BMCSecret, username, newPassword string, options bmc.BMCOptions) error {
+	_, previousPassword, err := bmcutils.GetBMCCredentialsFromSecret(resolvedSecret)
+	if err != nil {
+		return err
+	}
+	// The referenced Secret still holds the previous password, so the new one is only set on an unreferenced copy.
+	secret := resolvedSecret.DeepCopy()
+	secret.SecretRef = nil
+	secret.Data[metalv1alpha1.BMCSecretPasswordKeyName] = []byte(newPassword)
+	bmcClient, err := bmcutils.CreateBMCClient(ctx, c, rotateInsecure, bmcObj.Spec.Protocol, address, secret, options)
+	if err != nil {
+		return fmt.Errorf("failed to connect to BMC with the new password: %w", err)
+	}
+	defer bmcClient.Logout()
+	return bmcClient.SetAccountPassword(ctx, username, previousPassword)
+}
+
+func printRotationReport(out io.Writer, results []rotationResult, dryRun bool) (int, error) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "BMC\tRESULT")
+	failed := 0
+	for _, result := range results {
+		status := "rotated"
+		switch {
+		case dryRun:
+			status = "would be rotated"
+		case result.err != nil:
+			failed++
+			status = "failed: " + result.err.Error()
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\n", result.bmcName, status)
+	}
+	if err := w.Flush(); err != nil {
+		return 0, fmt.Errorf("failed to print report: %w", err)
+	}
+	return failed, nil
+}
@@ -4,10 +4,14 @@
 package main
 
 import (
+	"bytes"
 	"crypto/tls"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	webhookmetalv1alpha1 "github.com/ironcore-dev/metal-operator/internal/webhook/v1alpha1"
@@ -17,19 +21,31 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/yaml"
 
 	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
 	"github.com/ironcore-dev/metal-operator/internal/api/macdb"
+	"github.com/ironcore-dev/metal-operator/internal/bmcproxy"
+	"github.com/ironcore-dev/metal-operator/internal/bootserver"
+	"github.com/ironcore-dev/metal-operator/internal/config"
 	"github.com/ironcore-dev/metal-operator/internal/controller"
+	"github.com/ironcore-dev/metal-operator/internal/dhcp"
+	"github.com/ironcore-dev/metal-operator/internal/diagnostics"
+	"github.com/ironcore-dev/metal-operator/internal/features"
+	"github.com/ironcore-dev/metal-operator/internal/notification"
+	"github.com/ironcore-dev/metal-operator/internal/oci"
+	"github.com/ironcore-dev/metal-operator/internal/placement"
+	"github.com/ironcore-dev/metal-operator/internal/redact"
 	"github.com/ironcore-dev/metal-operator/internal/registry"
 	//+kubebuilder:scaffold:imports
 )
@@ -47,32 +63,144 @@ func init() {
 
 func main() {
 	var (
-		metricsAddr             string
-		enableLeaderElection    bool
-		probeAddr               string
-		secureMetrics           bool
-		enableHTTP2             bool
-		macPrefixesFile         string
-		insecure                bool
-		managerNamespace        string
-		probeImage              string
-		probeOSImage            string
-		registryPort            int
-		registryProtocol        string
-		registryURL             string
-		registryResyncInterval  time.Duration
-		webhookPort             int
-		enforceFirstBoot        bool
-		enforcePowerOff         bool
-		serverResyncInterval    time.Duration
-		powerPollingInterval    time.Duration
-		powerPollingTimeout     time.Duration
-		resourcePollingInterval time.Duration
-		resourcePollingTimeout  time.Duration
-		discoveryTimeout        time.Duration
+		metricsAddr                 string
+		enableLeaderElection        bool
+		probeAddr                   string
+		secureMetrics               bool
+		enableHTTP2                 bool
+		macPrefixesFile             string
+		insecure                    bool
+		managerNamespace            string
+		managerUser                 string
+		probeImage                  string
+		probeOSImage                string
+		discoveryImageConfigMap     string
+		leaseBindAddress            string
+		leaseTokenFile              string
+		registryPort                int
+		registryProtocol            string
+		registryURL                 string
+		registryTokenKeyFile        string
+		registryResyncInterval      time.Duration
+		registryEntryTTL            time.Duration
+		webhookPort                 int
+		enforceFirstBoot            bool
+		enforcePowerOff             bool
+		serverResyncInterval        time.Duration
+		powerPollingInterval        time.Duration
+		powerPollingTimeout         time.Duration
+		resourcePollingInterval     time.Duration
+		resourcePollingTimeout      time.Duration
+		discoveryTimeout            time.Duration
+		bmcFailureTimeout           time.Duration
+		verifyClaimImages           bool
+		placementWebhookURL         string
+		placementWebhookTimeout     time.Duration
+		placementIgnoreFailures     bool
+		maintenanceWebhooks         bool
+		rotationWebhookURL          string
+		redactKeyPatterns           string
+		bootTimeout                 time.Duration
+		bootVerificationPort        int
+		maxBootRetries              int
+		bootOverrideCheckDelay      time.Duration
+		taintOnBootFailure          bool
+		maxDiscoveryAttempts        int
+		discoveryEscalation         string
+		rediscoveryInterval         time.Duration
+		maxRediscoveries            int
+		bmcResetWaitTime            time.Duration
+		bmcResetTimeout             time.Duration
+		redfishRecorderSize         int
+		bmcTimeouts                 bmc.OperationTimeouts
+		bmcSessionKeepAliveInterval time.Duration
+		bmcMaxFirmwareImageSize     int64
+		bmcAuthMode                 string
+		warmUpPeriod                time.Duration
+		telemetryInterval           time.Duration
+		telemetryConcurrency        int
+		telemetryMaxSeriesPerBMC    int
+		notificationConfigFile      string
+		probeBMCAccount             string
+		credentialOnboarding        bool
+		observerMode                bool
+		crashDumpUploadURL          string
+		crashDumpCaptureImage       string
+		crashDumpTimeout            time.Duration
+		driveLocateTimeout          time.Duration
+		firmwareActivationTimeout   time.Duration
+		bmcProxyBindAddress         string
+		bmcProxyDomain              string
+		bmcProxyCertFile            string
+		bmcProxyKeyFile             string
+		diagnosticsBindAddress      string
+		diagnosticsCertFile         string
+		diagnosticsKeyFile          string
+		bootServerBindAddress       string
+		bootServerTFTPAddress       string
+		bootServerRoot              string
+		bootServerCacheDir          string
+		bootServerAllowedImages     string
+		bootServerMaxCacheSize      int64
+		configFile                  string
 	)
+	featureGate := features.NewGate()
 
 	flag.DurationVar(&discoveryTimeout, "discovery-timeout", 30*time.Minute, "Timeout for discovery boot")
+	flag.DurationVar(&bmcFailureTimeout, "bmc-failure-timeout", 0,
+		"Time the BMC of a Server may fail its status updates before the Server is moved into the Error state. "+
+			"Zero disables the timeout.")
+	flag.DurationVar(&bmcResetWaitTime, "bmc-reset-wait-time", time.Minute,
+		"Time to wait after a BMC reset before polling the BMC for its completion.")
+	flag.DurationVar(&bmcResetTimeout, "bmc-reset-timeout", 15*time.Minute,
+		"Time after a BMC reset within which the BMC has to be reachable again before the reset is considered failed.")
+	flag.IntVar(&maxDiscoveryAttempts, "max-discovery-attempts", 0,
+		"Number of timed out discovery boots after which the discovery escalation starts. Zero retries forever.")
+	flag.StringVar(&discoveryEscalation, "discovery-escalation", "ResetBMC,SwitchBootMode",
+		"Comma separated list of actions performed for each further timed out discovery boot "+
+			"before a Server is marked as DiscoveryFailed. Supported actions are ResetBMC and SwitchBootMode.")
+	flag.DurationVar(&rediscoveryInterval, "rediscovery-interval", 0,
+		"Time after its last discovery an Available Server is discovered again. Zero disables the rediscovery.")
+	flag.IntVar(&maxRediscoveries, "max-concurrent-rediscoveries", 1,
+		"Number of Servers which may be rediscovered at the same time. Zero does not limit the rediscoveries.")
+	flag.DurationVar(&bootTimeout, "boot-timeout", 0,
+		"Time a reserved Server has to become reachable after a PXE boot. Zero disables the boot verification.")
+	flag.IntVar(&bootVerificationPort, "boot-verification-port", 22,
+		"TCP port probed on the network interfaces of a reserved Server to verify its boot.")
+	flag.IntVar(&maxBootRetries, "max-boot-retries", 3, "Number of PXE boot retries before a Server boot is considered failed.")
+	flag.DurationVar(&bootOverrideCheckDelay, "boot-override-check-delay", 5*time.Minute,
+		"Time after a PXE boot after which a boot override still pending on the BMC is considered ignored and the "+
+			"boot is retried. Zero disables the check.")
+	flag.BoolVar(&taintOnBootFailure, "taint-on-boot-failure", false,
+		"Label a Server as boot failed once all boot retries are exhausted, excluding it from new claims.")
+	flag.IntVar(&redfishRecorderSize, "redfish-recorder-size", 0,
+		"Number of Redfish exchanges recorded for each BMC annotated for debugging. Zero disables the recording.")
+	flag.DurationVar(&telemetryInterval, "telemetry-interval", 0,
+		"Interval in which the metric reports of the Redfish TelemetryService of the BMCs are collected and exported "+
+			"as metrics. A value of 0 disables the collection.")
+	flag.IntVar(&telemetryConcurrency, "telemetry-concurrency", controller.DefaultTelemetryConcurrency,
+		"Number of BMCs whose metric reports are read at the same time.")
+	flag.IntVar(&telemetryMaxSeriesPerBMC, "telemetry-max-series-per-bmc", controller.DefaultTelemetryMaxSeriesPerBMC,
+		"Maximum number of metric values exported per BMC. Further values are dropped.")
+	flag.DurationVar(&warmUpPeriod, "warm-up-period", 0,
+		"Period over which a newly elected leader spreads its first BMC connections. Zero disables the warm-up.")
+	flag.StringVar(&bmcAuthMode, "bmc-auth-mode", string(metalv1alpha1.BMCAuthModeSession),
+		"Default authentication mode of the BMC clients, either Session or Basic. Session logins rejected by a BMC "+
+			"fall back to basic authentication. BMCs can override it with the authMode of their protocol.")
+	flag.DurationVar(&bmcTimeouts.Login, "bmc-login-timeout", bmc.DefaultLoginTimeout,
+		"Timeout for connecting and logging in to a BMC.")
+	flag.DurationVar(&bmcTimeouts.FirmwareUpload, "bmc-firmware-upload-timeout", bmc.DefaultFirmwareUploadTimeout,
+		"Timeout for triggering a firmware update on a BMC, which may include the upload of the image.")
+	flag.DurationVar(&bmcTimeouts.SettingsApply, "bmc-settings-apply-timeout", bmc.DefaultSettingsApplyTimeout,
+		"Timeout for applying BIOS settings and the boot order through a BMC.")
+	flag.DurationVar(&bmcTimeouts.TaskPolling, "bmc-task-polling-timeout", bmc.DefaultTaskPollingTimeout,
+		"Timeout for polling the state of a BMC task.")
+	flag.DurationVar(&bmcSessionKeepAliveInterval, "bmc-session-keepalive-interval", bmc.DefaultSessionKeepAliveInterval,
+		"Interval in which BMC sessions are refreshed during long-running operations, e.g. firmware uploads. "+
+			"A negative value disables the keep-alive.")
+	flag.Int64Var(&bmcMaxFirmwareImageSize, "bmc-max-firmware-image-size", bmc.DefaultMaxFirmwareImageSize,
+		"Maximum size in bytes of the firmware images downloaded by the manager to push them to BMCs which do not "+
+			"fetch images themselves, e.g. OpenBMC.")
 	flag.DurationVar(&resourcePollingInterval, "resource-polling-interval", 5*time.Second,
 		"Interval between polling resources")
 	flag.DurationVar(&resourcePollingTimeout, "resource-polling-timeout", 2*time.Minute, "Timeout for polling resources")
@@ -82,21 +210,100 @@ func main() {
 	flag.DurationVar(&registryResyncInterval, "registry-resync-interval", 10*time.Second,
 		"Defines the interval at which the registry is polled for new server information.")
 	flag.DurationVar(&serverResyncInterval, "server-resync-interval", 2*time.Minute,
-		"Defines the interval at which the server is polled.")
+		"Defines the interval at which the server is polled. Resyncs are smeared across the interval per server.")
+	flag.DurationVar(&registryEntryTTL, "registry-entry-ttl", 24*time.Hour,
+		"Defines the time after which registry entries without a matching Server are purged. 0 disables the purging.")
 	flag.StringVar(&registryURL, "registry-url", "", "The URL of the registry.")
 	flag.StringVar(&registryProtocol, "registry-protocol", "http", "The protocol to use for the registry.")
 	flag.IntVar(&registryPort, "registry-port", 10000, "The port to use for the registry.")
+	flag.StringVar(&registryTokenKeyFile, "registry-token-key-file", "",
+		"Path to a file holding the key the tokens authenticating the probe agents and the manager at the registry "+
+			"are derived from. If empty, a random key is generated, which does not survive restarts of the manager.")
 	flag.StringVar(&probeImage, "probe-image", "", "Image for the first boot probing of a Server.")
 	flag.StringVar(&probeOSImage, "probe-os-image", "", "OS image for the first boot probing of a Server.")
+	flag.StringVar(&probeBMCAccount, "probe-bmc-account", "",
+		"BMC account whose password the probe agent sets through the Redfish host interface of BMCs supporting "+
+			"credential bootstrapping. An empty value disables the bootstrapping.")
+	flag.StringVar(&discoveryImageConfigMap, "discovery-image-configmap", "",
+		"Name of the ConfigMap in the manager namespace mapping server models to probe OS images.")
+	flag.StringVar(&leaseBindAddress, "dhcp-lease-bind-address", "",
+		"The address the DHCP lease ingestion endpoint binds to. An empty value disables the endpoint.")
+	flag.StringVar(&leaseTokenFile, "dhcp-lease-token-file", "",
+		"Path to a file holding the bearer token required for the DHCP lease ingestion endpoint. "+
+			"Required if the endpoint is enabled.")
+	flag.StringVar(&bmcProxyBindAddress, "bmc-proxy-bind-address", "",
+		"The address the proxy to the web interfaces of the BMCs binds to. An empty value disables the proxy.")
+	flag.StringVar(&bmcProxyDomain, "bmc-proxy-domain", "",
+		"The domain below which the BMC proxy serves the BMCs as <bmc>.<domain>.")
+	flag.StringVar(&bmcProxyCertFile, "bmc-proxy-cert-file", "", "The TLS certificate file of the BMC proxy.")
+	flag.StringVar(&bmcProxyKeyFile, "bmc-proxy-key-file", "", "The TLS key file of the BMC proxy.")
+	flag.StringVar(&diagnosticsBindAddress, "diagnostics-bind-address", "",
+		"The address the diagnostics endpoint (pprof, controller queues, BMC clients) binds to. "+
+			"An empty value disables the endpoint.")
+	flag.StringVar(&diagnosticsCertFile, "diagnostics-cert-file", "", "The TLS certificate file of the diagnostics endpoint.")
+	flag.StringVar(&diagnosticsKeyFile, "diagnostics-key-file", "", "The TLS key file of the diagnostics endpoint.")
+	flag.StringVar(&bootServerBindAddress, "boot-server-bind-address", "",
+		"The address the embedded HTTP boot artifact server binds to. An empty value disables the boot server.")
+	flag.StringVar(&bootServerTFTPAddress, "boot-server-tftp-bind-address", "",
+		"The address the TFTP server of the embedded boot server binds to. An empty value disables TFTP.")
+	flag.StringVar(&bootServerRoot, "boot-server-root", "",
+		"Directory of the static files, e.g. iPXE binaries, served by the embedded boot server.")
+	flag.StringVar(&bootServerCacheDir, "boot-server-cache-dir", os.TempDir(),
+		"Directory the embedded boot server caches the layers of OCI artifact images in.")
+	flag.StringVar(&bootServerAllowedImages, "boot-server-allowed-images", "",
+		"Comma-separated registries and repositories, e.g. ghcr.io/example, whose OCI artifact images the embedded "+
+			"boot server serves. No image is served if it is empty.")
+	flag.Int64Var(&bootServerMaxCacheSize, "boot-server-max-cache-size", 10<<30,
+		"Maximum size in bytes of the layers cached by the embedded boot server. The least recently served layers are "+
+			"evicted once it is exceeded.")
+	flag.StringVar(&notificationConfigFile, "notification-config", "",
+		"Path to the file configuring the notification sinks and triggers. An empty value disables the notifications.")
+	flag.StringVar(&configFile, "config", "",
+		"Path to an OperatorConfiguration file overriding the flags. Changes of the discovery and boot settings are applied without a restart.")
+	flag.Var(featureGate, "feature-gates", features.Usage())
 	flag.StringVar(&managerNamespace, "manager-namespace", "default", "Namespace the manager is running in.")
+	flag.StringVar(&managerUser, "manager-user",
+		"system:serviceaccount:metal-operator-system:metal-operator-controller-manager",
+		"User the manager authenticates as, which may delete claimed Servers without the force annotation.")
 	flag.BoolVar(&insecure, "insecure", true, "If true, use http instead of https for connecting to a BMC.")
 	flag.StringVar(&macPrefixesFile, "mac-prefixes-file", "", "Location of the MAC prefixes file.")
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.BoolVar(&credentialOnboarding, "credential-onboarding", false,
+		"If true, new BMCs are logged in to with the factory-default credentials of their vendor, whose password is "+
+			"rotated to a generated one.")
+	flag.BoolVar(&observerMode, "observer-mode", false,
+		"Only observe BMCs and Servers: their status is updated and drift from their spec is reported, but the "+
+			"manager never changes the state of a BMC or the power state of a Server.")
+	flag.StringVar(&crashDumpUploadURL, "crash-dump-upload-url", "",
+		"URL of the object storage crash dumps of Servers are uploaded to. Empty disables the CrashDump operation.")
+	flag.StringVar(&crashDumpCaptureImage, "crash-dump-capture-image", "",
+		"Image of the capture environment which uploads the crash dump of Servers whose BMC does not capture it.")
+	flag.DurationVar(&crashDumpTimeout, "crash-dump-timeout", time.Hour, "Time the collection of a crash dump may take.")
+	flag.DurationVar(&driveLocateTimeout, "drive-locate-timeout", 30*time.Minute,
+		"Time the location indicator of a drive located with the metal.ironcore.dev/locate-drive annotation is on.")
+	flag.DurationVar(&firmwareActivationTimeout, "firmware-activation-timeout", 30*time.Minute,
+		"Time the components of a ComponentFirmware have to run the desired version after the restart activating it.")
 	flag.BoolVar(&enforceFirstBoot, "enforce-first-boot", false,
 		"Enforce the first boot probing of a Server even if it is powered on in the Initial state.")
 	flag.BoolVar(&enforcePowerOff, "enforce-power-off", false,
 		"Enforce the power off of a Server when graceful shutdown fails.")
+	flag.BoolVar(&verifyClaimImages, "verify-claim-images", false,
+		"Verify that the image of a ServerClaim exists in its registry before binding the claim.")
+	flag.StringVar(&placementWebhookURL, "placement-webhook-url", "",
+		"URL of a webhook which may veto or re-rank the servers selected for a ServerClaim before it is bound.")
+	flag.DurationVar(&placementWebhookTimeout, "placement-webhook-timeout", 10*time.Second,
+		"Timeout of the calls to the placement webhook.")
+	flag.BoolVar(&placementIgnoreFailures, "placement-webhook-ignore-failures", false,
+		"Bind ServerClaims to the first selected server if the placement webhook fails, instead of retrying.")
+	flag.BoolVar(&maintenanceWebhooks, "claim-maintenance-webhooks", false,
+		"Notify the webhooks registered by ServerClaims with the metal.ironcore.dev/maintenance-webhook annotation "+
+			"of maintenance requested for their servers.")
+	flag.StringVar(&rotationWebhookURL, "bmc-secret-rotation-webhook-url", "",
+		"URL of a webhook which is notified whenever the credentials of a BMCSecret change.")
+	flag.StringVar(&redactKeyPatterns, "redact-key-patterns", strings.Join(redact.DefaultKeyPatterns, ","),
+		"Comma-separated regular expressions of the keys, e.g. BIOS settings, whose values are redacted in the "+
+			"status of resources, in conditions and in logs.")
 	flag.IntVar(&webhookPort, "webhook-port", 9445, "The port to use for webhook server.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
@@ -111,13 +318,71 @@ func main() {
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	logger := zap.New(zap.UseFlagOptions(&opts))
+	redactor, redactErr := redact.New(strings.Split(redactKeyPatterns, ","))
+	if redactErr == nil {
+		logger = redactor.Logger(logger)
+	}
+	ctrl.SetLogger(logger)
+	if redactErr != nil {
+		setupLog.Error(redactErr, "invalid redaction key patterns")
+		os.Exit(1)
+	}
+	bmcutils.SecretRefNamespace = managerNamespace
+
+	// The settings of the flags are the base the reloaded OperatorConfiguration is applied to.
+	serverSettings := controller.ServerSettings{
+		DiscoveryTimeout:           discoveryTimeout,
+		MaxDiscoveryAttempts:       maxDiscoveryAttempts,
+		RediscoveryInterval:        rediscoveryInterval,
+		MaxConcurrentRediscoveries: maxRediscoveries,
+		BootVerificationTimeout:    bootTimeout,
+		MaxBootRetries:             maxBootRetries,
+		BootOverrideCheckDelay:     bootOverrideCheckDelay,
+		BMCFailureTimeout:          bmcFailureTimeout,
+	}
+	if configFile != "" {
+		operatorConfig, err := config.Load(configFile)
+		if err != nil {
+			setupLog.Error(err, "unable to load operator configuration")
+			os.Exit(1)
+		}
+		if err := operatorConfig.Apply(flag.CommandLine); err != nil {
+			setupLog.Error(err, "unable to apply operator configuration")
+			os.Exit(1)
+		}
+	}
 
 	if probeOSImage == "" {
 		setupLog.Error(nil, "probe OS image must be set")
 		os.Exit(1)
 	}
 
+	switch metalv1alpha1.BMCAuthMode(bmcAuthMode) {
+	case metalv1alpha1.BMCAuthModeSession, metalv1alpha1.BMCAuthModeBasic:
+	default:
+		setupLog.Error(nil, "invalid BMC auth mode", "AuthMode", bmcAuthMode)
+		os.Exit(1)
+	}
+	bmcBasicAuth := metalv1alpha1.BMCAuthMode(bmcAuthMode) == metalv1alpha1.BMCAuthModeBasic
+
+	if observerMode && credentialOnboarding {
+		setupLog.Info("Disabling credential onboarding in observer mode")
+		credentialOnboarding = false
+	}
+
+	var discoveryEscalationActions []controller.DiscoveryEscalationAction
+	for _, action := range strings.Split(discoveryEscalation, ",") {
+		switch a := controller.DiscoveryEscalationAction(strings.TrimSpace(action)); a {
+		case "":
+		case controller.DiscoveryEscalationResetBMC, controller.DiscoveryEscalationSwitchBootMode:
+			discoveryEscalationActions = append(discoveryEscalationActions, a)
+		default:
+			setupLog.Error(nil, "unknown discovery escalation action", "Action", a)
+			os.Exit(1)
+		}
+	}
+
 	// Load MACAddress DB
 	macPRefixes := &macdb.MacPrefixes{}
 	if macPrefixesFile != "" {
@@ -142,6 +407,23 @@ func main() {
 		}
 		registryURL = fmt.Sprintf("%s://%s:%d", registryProtocol, registryAddr, registryPort)
 	}
+	var registryTokenKey []byte
+	if registryTokenKeyFile != "" {
+		data, err := os.ReadFile(registryTokenKeyFile)
+		if err != nil {
+			setupLog.Error(err, "unable to read registry token key file")
+			os.Exit(1)
+		}
+		registryTokenKey = bytes.TrimSpace(data)
+	}
+	if len(registryTokenKey) == 0 {
+		key, err := registry.GenerateTokenKey()
+		if err != nil {
+			setupLog.Error(err, "unable to generate registry token key")
+			os.Exit(1)
+		}
+		registryTokenKey = key
+	}
 
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
@@ -192,54 +474,275 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = metrics.Registry.Register(&controller.PausedObjectsCollector{Client: mgr.GetClient()}); err != nil {
+		setupLog.Error(err, "unable to register paused objects metric")
+		os.Exit(1)
+	}
+
+	var redfishRecorders *bmc.RecorderRegistry
+	if redfishRecorderSize > 0 {
+		redfishRecorders = bmc.NewRecorderRegistry(redfishRecorderSize)
+		if err = mgr.Add(&bmcutils.RecordingExporter{
+			Client:    mgr.GetClient(),
+			Recorders: redfishRecorders,
+			Namespace: managerNamespace,
+			Interval:  10 * time.Second,
+		}); err != nil {
+			setupLog.Error(err, "unable to add Redfish recording exporter")
+			os.Exit(1)
+		}
+	}
+
+	if telemetryInterval > 0 {
+		telemetryCollector := &controller.TelemetryCollector{
+			Client:   mgr.GetClient(),
+			Insecure: insecure,
+			BMCOptions: bmc.BMCOptions{
+				BasicAuth:                bmcBasicAuth,
+				ReadOnly:                 observerMode,
+				CredentialProfile:        metalv1alpha1.BMCCredentialProfileMonitoring,
+				Timeouts:                 bmcTimeouts,
+				SessionKeepAliveInterval: bmcSessionKeepAliveInterval,
+				MaxFirmwareImageSize:     bmcMaxFirmwareImageSize,
+			},
+			Interval:        telemetryInterval,
+			Concurrency:     telemetryConcurrency,
+			MaxSeriesPerBMC: telemetryMaxSeriesPerBMC,
+		}
+		if err = mgr.Add(telemetryCollector); err != nil {
+			setupLog.Error(err, "unable to add telemetry collector")
+			os.Exit(1)
+		}
+		if err = metrics.Registry.Register(telemetryCollector); err != nil {
+			setupLog.Error(err, "unable to register telemetry metrics")
+			os.Exit(1)
+		}
+	}
+
+	if notificationConfigFile != "" {
+		notificationConfig, err := notification.LoadConfig(notificationConfigFile)
+		if err != nil {
+			setupLog.Error(err, "unable to load notification config")
+			os.Exit(1)
+		}
+		if err = mgr.Add(&notification.Notifier{
+			Client:    mgr.GetClient(),
+			Config:    notificationConfig,
+			Interval:  30 * time.Second,
+			Namespace: managerNamespace,
+		}); err != nil {
+			setupLog.Error(err, "unable to add notifier")
+			os.Exit(1)
+		}
+	}
+
+	var warmUp *controller.WarmUp
+	if warmUpPeriod > 0 {
+		warmUp = &controller.WarmUp{
+			Client:    mgr.GetClient(),
+			APIReader: mgr.GetAPIReader(),
+			Namespace: managerNamespace,
+			Period:    warmUpPeriod,
+			Interval:  30 * time.Second,
+		}
+		if err = mgr.Add(warmUp); err != nil {
+			setupLog.Error(err, "unable to add warm-up")
+			os.Exit(1)
+		}
+	}
+
+	if leaseBindAddress != "" {
+		if leaseTokenFile == "" {
+			setupLog.Error(nil, "the DHCP lease ingestion endpoint requires a token file")
+			os.Exit(1)
+		}
+		data, err := os.ReadFile(leaseTokenFile)
+		if err != nil {
+			setupLog.Error(err, "unable to read DHCP lease token file")
+			os.Exit(1)
+		}
+		leaseToken := strings.TrimSpace(string(data))
+		if leaseToken == "" {
+			setupLog.Error(nil, "the DHCP lease token file is empty")
+			os.Exit(1)
+		}
+		if err = mgr.Add(&dhcp.Server{
+			Client: mgr.GetClient(),
+			Addr:   leaseBindAddress,
+			Token:  leaseToken,
+		}); err != nil {
+			setupLog.Error(err, "unable to add DHCP lease server")
+			os.Exit(1)
+		}
+	}
+
+	if bmcProxyBindAddress != "" {
+		if bmcProxyDomain == "" {
+			setupLog.Error(nil, "the BMC proxy requires a domain")
+			os.Exit(1)
+		}
+		if err = mgr.Add(&bmcproxy.Server{
+			Client:   mgr.GetClient(),
+			Addr:     bmcProxyBindAddress,
+			Domain:   bmcProxyDomain,
+			CertFile: bmcProxyCertFile,
+			KeyFile:  bmcProxyKeyFile,
+			Insecure: insecure,
+		}); err != nil {
+			setupLog.Error(err, "unable to add BMC proxy")
+			os.Exit(1)
+		}
+	}
+
+	if diagnosticsBindAddress != "" {
+		if err = mgr.Add(&diagnostics.Server{
+			Client:   mgr.GetClient(),
+			Addr:     diagnosticsBindAddress,
+			CertFile: diagnosticsCertFile,
+			KeyFile:  diagnosticsKeyFile,
+		}); err != nil {
+			setupLog.Error(err, "unable to add diagnostics server")
+			os.Exit(1)
+		}
+	}
+
+	if bootServerBindAddress != "" {
+		allowedImages := slices.DeleteFunc(strings.Split(bootServerAllowedImages, ","), func(image string) bool {
+			return image == ""
+		})
+		if err = mgr.Add(&bootserver.Server{
+			Fetcher:       oci.NewFetcher(&http.Client{Timeout: 10 * time.Minute}),
+			Addr:          bootServerBindAddress,
+			TFTPAddr:      bootServerTFTPAddress,
+			Root:          bootServerRoot,
+			CacheDir:      bootServerCacheDir,
+			AllowedImages: allowedImages,
+			MaxCacheSize:  bootServerMaxCacheSize,
+		}); err != nil {
+			setupLog.Error(err, "unable to add boot server")
+			os.Exit(1)
+		}
+	}
+
 	if err = (&controller.EndpointReconciler{
-		Client:      mgr.GetClient(),
-		Scheme:      mgr.GetScheme(),
-		MACPrefixes: macPRefixes,
-		Insecure:    insecure,
+		Client:               mgr.GetClient(),
+		Scheme:               mgr.GetScheme(),
+		MACPrefixes:          macPRefixes,
+		Insecure:             insecure,
+		CredentialOnboarding: credentialOnboarding,
+		BMCOptions: bmc.BMCOptions{
+			BasicAuth:                bmcBasicAuth,
+			ReadOnly:                 observerMode,
+			Timeouts:                 bmcTimeouts,
+			SessionKeepAliveInterval: bmcSessionKeepAliveInterval,
+			MaxFirmwareImageSize:     bmcMaxFirmwareImageSize,
+		},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Endpoints")
 		os.Exit(1)
 	}
+	var rotationHooks []controller.CredentialRotationHook
+	if rotationWebhookURL != "" {
+		rotationHooks = append(rotationHooks, &controller.CredentialRotationWebhook{
+			URL:    rotationWebhookURL,
+			Client: &http.Client{Timeout: 10 * time.Second},
+		})
+	}
 	if err = (&controller.BMCSecretReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:        mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		RotationHooks: rotationHooks,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "BMCSecret")
 		os.Exit(1)
 	}
 	if err = (&controller.BMCReconciler{
-		Client:   mgr.GetClient(),
-		Scheme:   mgr.GetScheme(),
-		Insecure: insecure,
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		Insecure:     insecure,
+		ObserverMode: observerMode,
+		BMCPollingOptions: bmc.BMCOptions{
+			BasicAuth:                bmcBasicAuth,
+			ReadOnly:                 observerMode,
+			ResourcePollingInterval:  resourcePollingInterval,
+			ResourcePollingTimeout:   resourcePollingTimeout,
+			Timeouts:                 bmcTimeouts,
+			SessionKeepAliveInterval: bmcSessionKeepAliveInterval,
+			MaxFirmwareImageSize:     bmcMaxFirmwareImageSize,
+			DebugRecorders:           redfishRecorders,
+		},
+		BMCResetWaitTime: bmcResetWaitTime,
+		BMCResetTimeout:  bmcResetTimeout,
+		WarmUp:           warmUp,
+		RegistryURL:      registryURL,
+		RegistryToken:    registry.ManagerToken(registryTokenKey),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "BMC")
 		os.Exit(1)
 	}
-	if err = (&controller.ServerReconciler{
-		Client:                 mgr.GetClient(),
-		Scheme:                 mgr.GetScheme(),
-		Insecure:               insecure,
-		ManagerNamespace:       managerNamespace,
-		ProbeImage:             probeImage,
-		ProbeOSImage:           probeOSImage,
-		RegistryURL:            registryURL,
-		RegistryResyncInterval: registryResyncInterval,
-		ResyncInterval:         serverResyncInterval,
-		EnforceFirstBoot:       enforceFirstBoot,
-		EnforcePowerOff:        enforcePowerOff,
+	serverReconciler := &controller.ServerReconciler{
+		Client:                  mgr.GetClient(),
+		Scheme:                  mgr.GetScheme(),
+		Redactor:                redactor,
+		Insecure:                insecure,
+		ManagerNamespace:        managerNamespace,
+		ProbeImage:              probeImage,
+		ProbeOSImage:            probeOSImage,
+		DiscoveryImageConfigMap: discoveryImageConfigMap,
+		ProbeBMCAccount:         probeBMCAccount,
+		RegistryURL:             registryURL,
+		RegistryTokenKey:        registryTokenKey,
+		RegistryResyncInterval:  registryResyncInterval,
+		ResyncInterval:          serverResyncInterval,
+		EnforceFirstBoot:        enforceFirstBoot,
+		EnforcePowerOff:         enforcePowerOff,
 		BMCOptions: bmc.BMCOptions{
-			BasicAuth:               true,
-			PowerPollingInterval:    powerPollingInterval,
-			PowerPollingTimeout:     powerPollingTimeout,
-			ResourcePollingInterval: resourcePollingInterval,
-			ResourcePollingTimeout:  resourcePollingTimeout,
+			BasicAuth:                bmcBasicAuth,
+			ReadOnly:                 observerMode,
+			PowerPollingInterval:     powerPollingInterval,
+			PowerPollingTimeout:      powerPollingTimeout,
+			ResourcePollingInterval:  resourcePollingInterval,
+			ResourcePollingTimeout:   resourcePollingTimeout,
+			Timeouts:                 bmcTimeouts,
+			SessionKeepAliveInterval: bmcSessionKeepAliveInterval,
+			MaxFirmwareImageSize:     bmcMaxFirmwareImageSize,
+			DebugRecorders:           redfishRecorders,
 		},
-		DiscoveryTimeout: discoveryTimeout,
-	}).SetupWithManager(mgr); err != nil {
+		DiscoveryTimeout:           discoveryTimeout,
+		BMCFailureTimeout:          bmcFailureTimeout,
+		BootVerificationTimeout:    bootTimeout,
+		BootVerificationPort:       bootVerificationPort,
+		MaxBootRetries:             maxBootRetries,
+		BootOverrideCheckDelay:     bootOverrideCheckDelay,
+		TaintOnBootFailure:         taintOnBootFailure,
+		MaxDiscoveryAttempts:       maxDiscoveryAttempts,
+		DiscoveryEscalation:        discoveryEscalationActions,
+		RediscoveryInterval:        rediscoveryInterval,
+		MaxConcurrentRediscoveries: maxRediscoveries,
+		WarmUp:                     warmUp,
+		FeatureGate:                featureGate,
+		ObserverMode:               observerMode,
+		CrashDumpUploadURL:         crashDumpUploadURL,
+		CrashDumpCaptureImage:      crashDumpCaptureImage,
+		CrashDumpTimeout:           crashDumpTimeout,
+		DriveLocateTimeout:         driveLocateTimeout,
+	}
+	if err = serverReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Server")
 		os.Exit(1)
 	}
+	if configFile != "" {
+		if err = mgr.Add(&config.Watcher{
+			Path:     configFile,
+			Interval: 10 * time.Second,
+			OnChange: func(operatorConfig *config.OperatorConfiguration) {
+				serverReconciler.UpdateSettings(operatorConfig.ServerSettings(serverSettings))
+			},
+		}); err != nil {
+			setupLog.Error(err, "unable to add operator configuration watcher")
+			os.Exit(1)
+		}
+	}
 	if err = (&controller.ServerBootConfigurationReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
@@ -247,11 +750,169 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "ServerBootConfiguration")
 		os.Exit(1)
 	}
+	var imageResolver oci.Resolver
+	if verifyClaimImages {
+		imageResolver = oci.NewResolver(&http.Client{Timeout: 30 * time.Second})
+	}
+	var placementAdmitter placement.Admitter
+	if placementWebhookURL != "" {
+		placementAdmitter = &placement.Webhook{
+			URL:            placementWebhookURL,
+			Client:         &http.Client{Timeout: placementWebhookTimeout},
+			IgnoreFailures: placementIgnoreFailures,
+		}
+	}
 	if err = (&controller.ServerClaimReconciler{
+		Client:              mgr.GetClient(),
+		Scheme:              mgr.GetScheme(),
+		ImageResolver:       imageResolver,
+		PlacementAdmitter:   placementAdmitter,
+		MaintenanceWebhooks: maintenanceWebhooks,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ServerClaim")
+		os.Exit(1)
+	}
+	if err = (&controller.ServerSetClaimReconciler{
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		PlacementAdmitter: placementAdmitter,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ServerSetClaim")
+		os.Exit(1)
+	}
+	if err = (&controller.ServerReservationReconciler{
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		PlacementAdmitter: placementAdmitter,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ServerReservation")
+		os.Exit(1)
+	}
+	if err = (&controller.ServerGrantReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "ServerClaim")
+		setupLog.Error(err, "unable to create controller", "controller", "ServerGrant")
+		os.Exit(1)
+	}
+	if err = (&controller.DriveFirmwareReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Insecure: insecure,
+		BMCOptions: bmc.BMCOptions{
+			BasicAuth:                bmcBasicAuth,
+			ReadOnly:                 observerMode,
+			CredentialProfile:        metalv1alpha1.BMCCredentialProfileFirmware,
+			ResourcePollingInterval:  resourcePollingInterval,
+			ResourcePollingTimeout:   resourcePollingTimeout,
+			Timeouts:                 bmcTimeouts,
+			SessionKeepAliveInterval: bmcSessionKeepAliveInterval,
+			MaxFirmwareImageSize:     bmcMaxFirmwareImageSize,
+			DebugRecorders:           redfishRecorders,
+		},
+		ResyncInterval: serverResyncInterval,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DriveFirmware")
+		os.Exit(1)
+	}
+	if err = (&controller.DriveReplacementReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Insecure: insecure,
+		BMCOptions: bmc.BMCOptions{
+			BasicAuth:                bmcBasicAuth,
+			ReadOnly:                 observerMode,
+			ResourcePollingInterval:  resourcePollingInterval,
+			ResourcePollingTimeout:   resourcePollingTimeout,
+			Timeouts:                 bmcTimeouts,
+			SessionKeepAliveInterval: bmcSessionKeepAliveInterval,
+			MaxFirmwareImageSize:     bmcMaxFirmwareImageSize,
+			DebugRecorders:           redfishRecorders,
+		},
+		ResyncInterval: serverResyncInterval,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DriveReplacement")
+		os.Exit(1)
+	}
+	if err = (&controller.BoardReplacementReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Insecure: insecure,
+		BMCOptions: bmc.BMCOptions{
+			BasicAuth:                bmcBasicAuth,
+			ReadOnly:                 observerMode,
+			ResourcePollingInterval:  resourcePollingInterval,
+			ResourcePollingTimeout:   resourcePollingTimeout,
+			Timeouts:                 bmcTimeouts,
+			SessionKeepAliveInterval: bmcSessionKeepAliveInterval,
+			MaxFirmwareImageSize:     bmcMaxFirmwareImageSize,
+			DebugRecorders:           redfishRecorders,
+		},
+		ResyncInterval: serverResyncInterval,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BoardReplacement")
+		os.Exit(1)
+	}
+	if err = (&controller.FirmwareCampaignReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "FirmwareCampaign")
+		os.Exit(1)
+	}
+	if err = (&controller.ComponentFirmwareReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Insecure: insecure,
+		BMCOptions: bmc.BMCOptions{
+			BasicAuth:                bmcBasicAuth,
+			ReadOnly:                 observerMode,
+			CredentialProfile:        metalv1alpha1.BMCCredentialProfileFirmware,
+			ResourcePollingInterval:  resourcePollingInterval,
+			ResourcePollingTimeout:   resourcePollingTimeout,
+			Timeouts:                 bmcTimeouts,
+			SessionKeepAliveInterval: bmcSessionKeepAliveInterval,
+			MaxFirmwareImageSize:     bmcMaxFirmwareImageSize,
+			DebugRecorders:           redfishRecorders,
+		},
+		ResyncInterval:    serverResyncInterval,
+		ActivationTimeout: firmwareActivationTimeout,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ComponentFirmware")
+		os.Exit(1)
+	}
+	if err = (&controller.ComposedServerReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Insecure: insecure,
+		BMCOptions: bmc.BMCOptions{
+			BasicAuth:                bmcBasicAuth,
+			ReadOnly:                 observerMode,
+			ResourcePollingInterval:  resourcePollingInterval,
+			ResourcePollingTimeout:   resourcePollingTimeout,
+			Timeouts:                 bmcTimeouts,
+			SessionKeepAliveInterval: bmcSessionKeepAliveInterval,
+			MaxFirmwareImageSize:     bmcMaxFirmwareImageSize,
+			DebugRecorders:           redfishRecorders,
+		},
+		ResyncInterval: serverResyncInterval,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ComposedServer")
+		os.Exit(1)
+	}
+	if err = (&controller.FleetReportReconciler{
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		ResyncInterval: serverResyncInterval,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "FleetReport")
+		os.Exit(1)
+	}
+	if err = (&controller.OperationReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Operation")
 		os.Exit(1)
 	}
 	// nolint:goconst
@@ -260,6 +921,18 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Endpoint")
 			os.Exit(1)
 		}
+		if err = webhookmetalv1alpha1.SetupServerWebhookWithManager(mgr, managerUser); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Server")
+			os.Exit(1)
+		}
+		if err = webhookmetalv1alpha1.SetupOperationWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Operation")
+			os.Exit(1)
+		}
+		if err = webhookmetalv1alpha1.SetupServerClaimWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ServerClaim")
+			os.Exit(1)
+		}
 	}
 	//+kubebuilder:scaffold:builder
 
@@ -275,7 +948,18 @@ func main() {
 	ctx := ctrl.SetupSignalHandler()
 
 	setupLog.Info("starting registry server", "RegistryURL", registryURL)
-	registryServer := registry.NewServer(fmt.Sprintf(":%d", registryPort))
+	registryServer := registry.NewServer(fmt.Sprintf(":%d", registryPort), registryTokenKey)
+	if registryEntryTTL > 0 {
+		if err = mgr.Add(&registry.Sweeper{
+			Registry: registryServer,
+			Client:   mgr.GetClient(),
+			TTL:      registryEntryTTL,
+			Interval: min(registryEntryTTL, 10*time.Minute),
+		}); err != nil {
+			setupLog.Error(err, "unable to add registry sweeper")
+			os.Exit(1)
+		}
+	}
 	go func() {
 		if err := registryServer.Start(ctx); err != nil {
 			setupLog.Error(err, "problem running registry server")
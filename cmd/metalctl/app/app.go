@@ -31,5 +31,10 @@ func NewCommand() *cobra.Command {
 	}
 	root.AddCommand(NewMoveCommand())
 	root.AddCommand(NewConsoleCommand())
+	root.AddCommand(NewRedfishRecordingCommand())
+	root.AddCommand(NewClaimCommand())
+	root.AddCommand(NewFirmwareCommand())
+	root.AddCommand(NewWatchCommand())
+	root.AddCommand(NewDevCommand())
 	return root
 }
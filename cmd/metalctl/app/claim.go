@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/internal/controller"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	claimNamespace      string
+	claimImage          string
+	claimSelector       string
+	claimIgnitionSecret string
+	claimPower          string
+	claimAutoSelect     bool
+	claimWait           bool
+	claimTimeout        time.Duration
+)
+
+func NewClaimCommand() *cobra.Command {
+	claimCmd := &cobra.Command{
+		Use:   "claim",
+		Short: "Manage ServerClaims",
+		Args:  cobra.NoArgs,
+	}
+	claimCmd.AddCommand(newClaimCreateCommand())
+	claimCmd.AddCommand(newClaimWhatIfDeleteCommand())
+	return claimCmd
+}
+
+func newClaimCreateCommand() *cobra.Command {
+	createCmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Claim an Available Server and wait until it is bound and booted",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runClaimCreate,
+	}
+
+	createCmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig.")
+	createCmd.Flags().StringVar(&claimNamespace, "namespace", "default", "Namespace of the ServerClaim.")
+	createCmd.Flags().StringVar(&claimImage, "image", "", "Boot image of the Server.")
+	createCmd.Flags().StringVar(&claimSelector, "selector", "", "Label selector the claimed Server has to match.")
+	createCmd.Flags().StringVar(&claimIgnitionSecret, "ignition-secret", "",
+		"Name of the Secret containing the ignition of the Server.")
+	createCmd.Flags().StringVar(&claimPower, "power", string(metalv1alpha1.PowerOn), "Power state of the Server.")
+	createCmd.Flags().BoolVar(&claimAutoSelect, "auto-select", false,
+		"Select the first matching Server instead of prompting for it.")
+	createCmd.Flags().BoolVar(&claimWait, "wait", true, "Wait until the Server is bound and booted.")
+	createCmd.Flags().DurationVar(&claimTimeout, "timeout", 30*time.Minute, "Timeout for waiting on the Server.")
+	_ = createCmd.MarkFlagRequired("image")
+
+	return createCmd
+}
+
+func runClaimCreate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	selector, err := labels.Parse(claimSelector)
+	if err != nil {
+		return fmt.Errorf("failed to parse selector: %w", err)
+	}
+
+	k8sClient, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	servers, err := listAvailableServers(ctx, k8sClient, selector)
+	if err != nil {
+		return err
+	}
+	if len(servers) == 0 {
+		return fmt.Errorf("no Available server matches the selector %q", claimSelector)
+	}
+
+	server := &servers[0]
+	if !claimAutoSelect && len(servers) > 1 {
+		if server, err = selectServer(cmd.InOrStdin(), cmd.OutOrStdout(), servers); err != nil {
+			return err
+		}
+	}
+
+	claim := &metalv1alpha1.ServerClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: claimNamespace,
+			Name:      args[0],
+		},
+		Spec: metalv1alpha1.ServerClaimSpec{
+			Power:     metalv1alpha1.Power(claimPower),
+			ServerRef: &v1.LocalObjectReference{Name: server.Name},
+			Image:     claimImage,
+		},
+	}
+	if claimIgnitionSecret != "" {
+		claim.Spec.IgnitionSecretRef = &v1.LocalObjectReference{Name: claimIgnitionSecret}
+	}
+	if err := k8sClient.Create(ctx, claim); err != nil {
+		return fmt.Errorf("failed to create ServerClaim: %w", err)
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Created ServerClaim %s/%s for Server %s\n", claim.Namespace, claim.Name, server.Name)
+
+	if !claimWait {
+		return nil
+	}
+	return waitForClaim(ctx, k8sClient, cmd.OutOrStdout(), client.ObjectKeyFromObject(claim))
+}
+
+// listAvailableServers returns the unclaimed Servers in the Available state matching the selector, sorted by name.
+func listAvailableServers(ctx context.Context, c client.Client, selector labels.Selector) ([]metalv1alpha1.Server, error) {
+	serverList := &metalv1alpha1.ServerList{}
+	if err := c.List(ctx, serverList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list servers: %w", err)
+	}
+	var servers []metalv1alpha1.Server
+	for _, server := range serverList.Items {
+		if server.Status.State == metalv1alpha1.ServerStateAvailable && server.Spec.ServerClaimRef == nil {
+			servers = append(servers, server)
+		}
+	}
+	// the API server returns the items sorted by name already
+	return servers, nil
+}
+
+func selectServer(in io.Reader, out io.Writer, servers []metalv1alpha1.Server) (*metalv1alpha1.Server, error) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "#\tNAME\tMANUFACTURER\tMODEL\tMEMORY")
+	for i, server := range servers {
+		memory := ""
+		if server.Status.TotalSystemMemory != nil {
+			memory = server.Status.TotalSystemMemory.String()
+		}
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", i+1, server.Name, server.Status.Manufacturer,
+			server.Status.Model, memory)
+	}
+	if err := w.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to print servers: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(out, "Select a server [1-%d]: ", len(servers))
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read selection: %w", err)
+	}
+	index, err := strconv.Atoi(strings.TrimSpace(line))
+	if err != nil || index < 1 || index > len(servers) {
+		return nil, fmt.Errorf("invalid selection %q", strings.TrimSpace(line))
+	}
+	return &servers[index-1], nil
+}
+
+// waitForClaim waits until the claim is bound and its Server is powered on and, if the boot verification is
+// enabled, booted. Condition changes are printed as they are observed.
+func waitForClaim(ctx context.Context, c client.Client, out io.Writer, key client.ObjectKey) error {
+	observed := map[string]string{}
+	err := wait.PollUntilContextTimeout(ctx, 2*time.Second, claimTimeout, true, func(ctx context.Context) (bool, error) {
+		claim := &metalv1alpha1.ServerClaim{}
+		if err := c.Get(ctx, key, claim); err != nil {
+			return false, fmt.Errorf("failed to get ServerClaim: %w", err)
+		}
+		for _, cond := range claim.Status.Conditions {
+			state := fmt.Sprintf("%s/%s/%s", cond.Status, cond.Reason, cond.Message)
+			if observed[cond.Type] == state {
+				continue
+			}
+			observed[cond.Type] = state
+			_, _ = fmt.Fprintf(out, "%s %s=%s %s: %s\n", time.Now().Format(time.RFC3339), cond.Type, cond.Status,
+				cond.Reason, cond.Message)
+		}
+
+		if claim.Status.Phase != metalv1alpha1.PhaseBound ||
+			!meta.IsStatusConditionTrue(claim.Status.Conditions, controller.ServerClaimConditionServerPoweredOn) {
+			return false, nil
+		}
+		bootVerified := meta.FindStatusCondition(claim.Status.Conditions, controller.ServerClaimConditionBootVerified)
+		return bootVerified == nil || bootVerified.Status == metav1.ConditionTrue, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to wait for ServerClaim %s to be bound and booted: %w", key, err)
+	}
+	_, _ = fmt.Fprintf(out, "ServerClaim %s is bound and booted\n", key)
+	return nil
+}
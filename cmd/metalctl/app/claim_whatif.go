@@ -0,0 +1,227 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/internal/controller"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	whatIfSelector            string
+	whatIfGroupBy             string
+	whatIfDiscoveryTimeout    time.Duration
+	whatIfRediscoveryInterval time.Duration
+)
+
+func newClaimWhatIfDeleteCommand() *cobra.Command {
+	whatIfCmd := &cobra.Command{
+		Use:   "what-if-delete [claim...]",
+		Short: "Report what would happen to the Servers and the pool of Available Servers if ServerClaims were deleted",
+		RunE:  runClaimWhatIfDelete,
+	}
+	whatIfCmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig.")
+	whatIfCmd.Flags().StringVar(&claimNamespace, "namespace", "default", "Namespace of the ServerClaims.")
+	whatIfCmd.Flags().StringVar(&whatIfSelector, "selector", "",
+		"Label selector of the ServerClaims, instead of naming them.")
+	whatIfCmd.Flags().StringVar(&whatIfGroupBy, "group-by", "",
+		"Server label to break down the change of the Available Servers by, e.g. a pool label.")
+	whatIfCmd.Flags().DurationVar(&whatIfDiscoveryTimeout, "discovery-timeout", 30*time.Minute,
+		"Discovery timeout of the manager, used to estimate the duration of rediscoveries.")
+	whatIfCmd.Flags().DurationVar(&whatIfRediscoveryInterval, "rediscovery-interval", 0,
+		"Rediscovery interval of the manager. Zero disables the rediscovery.")
+	return whatIfCmd
+}
+
+// claimDeletionImpact is the predicted effect of the deletion of a ServerClaim on its Server.
+type claimDeletionImpact struct {
+	claim  string
+	server string
+	// effects are the actions taken when the claim is released, in the order they happen.
+	effects []string
+	// state is the state the Server settles in.
+	state metalv1alpha1.ServerState
+	// available reports whether the Server can be claimed again after the release.
+	available bool
+	// duration is the estimated time until the Server is available again. It is an upper bound for rediscoveries.
+	duration time.Duration
+}
+
+func runClaimWhatIfDelete(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	if len(args) > 0 && whatIfSelector != "" {
+		return fmt.Errorf("claims must be named or selected with --selector, not both")
+	}
+	selector, err := labels.Parse(whatIfSelector)
+	if err != nil {
+		return fmt.Errorf("failed to parse selector: %w", err)
+	}
+	k8sClient, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	claimList := &metalv1alpha1.ServerClaimList{}
+	if err := k8sClient.List(ctx, claimList, client.InNamespace(claimNamespace),
+		client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return fmt.Errorf("failed to list ServerClaims: %w", err)
+	}
+	var claims []metalv1alpha1.ServerClaim
+	for _, claim := range claimList.Items {
+		if len(args) == 0 || slices.Contains(args, claim.Name) {
+			claims = append(claims, claim)
+		}
+	}
+	for _, name := range args {
+		if !slices.ContainsFunc(claims, func(claim metalv1alpha1.ServerClaim) bool { return claim.Name == name }) {
+			return fmt.Errorf("ServerClaim %s/%s not found", claimNamespace, name)
+		}
+	}
+
+	serverList := &metalv1alpha1.ServerList{}
+	if err := k8sClient.List(ctx, serverList); err != nil {
+		return fmt.Errorf("failed to list servers: %w", err)
+	}
+	servers := map[string]*metalv1alpha1.Server{}
+	for i := range serverList.Items {
+		servers[serverList.Items[i].Name] = &serverList.Items[i]
+	}
+
+	now := time.Now()
+	impacts := make([]claimDeletionImpact, 0, len(claims))
+	for i := range claims {
+		var server *metalv1alpha1.Server
+		if ref := claims[i].Spec.ServerRef; ref != nil {
+			server = servers[ref.Name]
+		}
+		impacts = append(impacts, predictClaimDeletion(&claims[i], server, now))
+	}
+	return printClaimDeletionImpacts(cmd.OutOrStdout(), impacts, serverList.Items)
+}
+
+// predictClaimDeletion predicts the effect of the deletion of the claim on its Server along the release flow of
+// the ServerClaimReconciler and the ServerReconciler.
+func predictClaimDeletion(claim *metalv1alpha1.ServerClaim, server *metalv1alpha1.Server, now time.Time) claimDeletionImpact {
+	impact := claimDeletionImpact{claim: claim.Namespace + "/" + claim.Name}
+	if claim.Spec.ServerRef == nil {
+		impact.effects = append(impact.effects, "claim is not bound to a server")
+		return impact
+	}
+	impact.server = claim.Spec.ServerRef.Name
+	if server == nil {
+		impact.effects = append(impact.effects, "server does not exist")
+		return impact
+	}
+
+	impact.effects = append(impact.effects, "delete ServerBootConfiguration "+impact.claim)
+	if server.Status.PowerState != metalv1alpha1.ServerOffPowerState {
+		impact.effects = append(impact.effects, "power off server")
+	}
+	maintenance := meta.FindStatusCondition(claim.Status.Conditions, controller.ServerClaimConditionMaintenancePending)
+	if maintenance != nil && maintenance.Status == metav1.ConditionTrue {
+		impact.effects = append(impact.effects, "start pending maintenance: "+maintenance.Message)
+	}
+
+	if server.Status.State == metalv1alpha1.ServerStateError {
+		impact.state = metalv1alpha1.ServerStateError
+		impact.effects = append(impact.effects, "server stays in the Error state")
+		return impact
+	}
+	impact.state = metalv1alpha1.ServerStateAvailable
+	impact.available = true
+
+	interval := whatIfRediscoveryInterval
+	timeout := whatIfDiscoveryTimeout
+	if policy := server.Spec.DiscoveryPolicy; policy != nil {
+		if policy.RediscoveryInterval != nil {
+			interval = policy.RediscoveryInterval.Duration
+		}
+		if policy.Timeout != nil {
+			timeout = policy.Timeout.Duration
+		}
+	}
+	if last := server.Status.LastDiscoveryTime; interval > 0 && last != nil && now.Sub(last.Time) >= interval {
+		impact.effects = append(impact.effects, "rediscover server")
+		impact.state = metalv1alpha1.ServerStateDiscovery
+		impact.duration = timeout
+	}
+	if _, ok := server.Labels[controller.ServerBootFailedLabel]; ok {
+		impact.effects = append(impact.effects, "server is excluded from claims by the boot-failed label")
+		impact.available = false
+	}
+	return impact
+}
+
+func printClaimDeletionImpacts(out io.Writer, impacts []claimDeletionImpact, servers []metalv1alpha1.Server) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "CLAIM\tSERVER\tSTATE\tAVAILABLE\tESTIMATE\tEFFECTS")
+	released := map[string]bool{}
+	for _, impact := range impacts {
+		estimate := "-"
+		if impact.available {
+			estimate = "immediately"
+			if impact.duration > 0 {
+				estimate = "<= " + impact.duration.String()
+			}
+			released[impact.server] = true
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\t%s\n", impact.claim, valueOrDash(impact.server),
+			valueOrDash(string(impact.state)), impact.available, estimate, strings.Join(impact.effects, "; "))
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to print impacts: %w", err)
+	}
+
+	before := map[string]int{}
+	after := map[string]int{}
+	for _, server := range servers {
+		group := server.Labels[whatIfGroupBy]
+		if server.Status.State == metalv1alpha1.ServerStateAvailable && server.Spec.ServerClaimRef == nil {
+			before[group]++
+			after[group]++
+		} else if released[server.Name] {
+			after[group]++
+		}
+	}
+	_, _ = fmt.Fprintln(out)
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if whatIfGroupBy != "" {
+		_, _ = fmt.Fprintf(w, "%s\t", strings.ToUpper(whatIfGroupBy))
+	}
+	_, _ = fmt.Fprintln(w, "AVAILABLE NOW\tAVAILABLE AFTER")
+	groups := make([]string, 0, len(after))
+	for group := range after {
+		groups = append(groups, group)
+	}
+	slices.Sort(groups)
+	for _, group := range groups {
+		if whatIfGroupBy != "" {
+			_, _ = fmt.Fprintf(w, "%s\t", valueOrDash(group))
+		}
+		_, _ = fmt.Fprintf(w, "%d\t%d\n", before[group], after[group])
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to print availability: %w", err)
+	}
+	return nil
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ironcore-dev/metal-operator/internal/devenv"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+var (
+	devClusterName       string
+	devImage             string
+	devKustomizeDir      string
+	devSystems           int
+	devFleetPrefix       string
+	devRegistryLocalPort int
+	devInterval          time.Duration
+	devSeedCount         int
+	devSeedVendor        string
+	devSeedPrefix        string
+	devSeedRandomSeed    uint64
+	devSeedDelete        bool
+)
+
+func NewDevCommand() *cobra.Command {
+	devCmd := &cobra.Command{
+		Use:   "dev",
+		Short: "Manage a development environment with a fleet of simulated BMCs",
+		Args:  cobra.NoArgs,
+	}
+	devCmd.PersistentFlags().StringVar(&devClusterName, "cluster-name", devenv.DefaultClusterName,
+		"Name of the kind cluster of the environment.")
+	devCmd.AddCommand(newDevUpCommand())
+	devCmd.AddCommand(newDevDiscoverCommand())
+	devCmd.AddCommand(newDevSeedCommand())
+	devCmd.AddCommand(newDevDownCommand())
+	return devCmd
+}
+
+func newDevUpCommand() *cobra.Command {
+	upCmd := &cobra.Command{
+		Use:   "up",
+		Short: "Create a kind cluster running the metal-operator and a fleet of simulated BMCs",
+		Args:  cobra.NoArgs,
+		RunE:  runDevUp,
+	}
+	upCmd.Flags().StringVar(&devImage, "image", "", "Locally available image of the metal-operator.")
+	upCmd.Flags().StringVar(&devKustomizeDir, "kustomize-dir", devenv.DefaultKustomizeDir,
+		"Kustomization the metal-operator is deployed with.")
+	upCmd.Flags().IntVar(&devSystems, "systems", 3, "Number of simulated BMCs, each managing a single system.")
+	upCmd.Flags().StringVar(&devFleetPrefix, "prefix", "mock-bmc", "Name prefix of the simulated BMCs.")
+	_ = upCmd.MarkFlagRequired("image")
+	return upCmd
+}
+
+func runDevUp(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+	env := &devenv.Environment{
+		ClusterName:  devClusterName,
+		Image:        devImage,
+		KustomizeDir: devKustomizeDir,
+		Out:          os.Stderr,
+	}
+	if err := env.Up(ctx); err != nil {
+		return err
+	}
+	k8sClient, err := createDevClient()
+	if err != nil {
+		return err
+	}
+	if err := (devenv.Fleet{Prefix: devFleetPrefix, Size: devSystems}).Apply(ctx, k8sClient); err != nil {
+		return err
+	}
+	fmt.Printf("Created %d simulated BMCs in cluster %s.\n", devSystems, devClusterName)
+	fmt.Println("Run 'metalctl dev discover' to complete the discovery of their servers.")
+	return nil
+}
+
+func newDevDiscoverCommand() *cobra.Command {
+	discoverCmd := &cobra.Command{
+		Use: "discover",
+		Short: "Act as boot operator and probe agent for the servers of the simulated BMCs until interrupted, " +
+			"so that they complete discovery and claims",
+		Args: cobra.NoArgs,
+		RunE: runDevDiscover,
+	}
+	discoverCmd.Flags().IntVar(&devRegistryLocalPort, "registry-local-port", devenv.RegistryPort,
+		"Local port the registry of the metal-operator is forwarded to.")
+	discoverCmd.Flags().DurationVar(&devInterval, "interval", 5*time.Second,
+		"Interval in which boot configurations and servers are handled.")
+	return discoverCmd
+}
+
+func runDevDiscover(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+	env := &devenv.Environment{ClusterName: devClusterName, Out: os.Stderr}
+	if err := env.PortForward(ctx, devRegistryLocalPort, devenv.RegistryPort); err != nil {
+		return err
+	}
+	k8sClient, err := createDevClient()
+	if err != nil {
+		return err
+	}
+	responder := &devenv.DiscoveryResponder{
+		Client:      k8sClient,
+		RegistryURL: fmt.Sprintf("http://127.0.0.1:%d", devRegistryLocalPort),
+		Interval:    devInterval,
+	}
+	return responder.Start(ctx)
+}
+
+func newDevSeedCommand() *cobra.Command {
+	seedCmd := &cobra.Command{
+		Use: "seed",
+		Short: "Create simulated BMCs of a vendor with realistic Endpoints, BMCSecrets and hardware, " +
+			"for load-testing controllers and dashboards",
+		Args: cobra.NoArgs,
+		RunE: runDevSeed,
+	}
+	seedCmd.Flags().IntVar(&devSeedCount, "count", 10, "Number of simulated BMCs.")
+	seedCmd.Flags().StringVar(&devSeedVendor, "vendor", "dell",
+		fmt.Sprintf("Vendor of the simulated BMCs, one of %s.", strings.Join(devenv.Vendors(), ", ")))
+	seedCmd.Flags().StringVar(&devSeedPrefix, "prefix", "", "Name prefix of the objects. Defaults to the vendor.")
+	seedCmd.Flags().Uint64Var(&devSeedRandomSeed, "seed", 0, "Seed of the generated hardware, for reproducible fixtures.")
+	seedCmd.Flags().BoolVar(&devSeedDelete, "delete", false, "Delete the objects of the simulated BMCs instead.")
+	return seedCmd
+}
+
+func runDevSeed(cmd *cobra.Command, _ []string) error {
+	prefix := devSeedPrefix
+	if prefix == "" {
+		prefix = devSeedVendor
+	}
+	seed := devenv.Seed{Prefix: prefix, Count: devSeedCount, Vendor: devSeedVendor, RandomSeed: devSeedRandomSeed}
+	k8sClient, err := createDevClient()
+	if err != nil {
+		return err
+	}
+	if devSeedDelete {
+		if err := seed.Delete(cmd.Context(), k8sClient); err != nil {
+			return err
+		}
+		fmt.Printf("Deleted %d simulated %s BMCs in cluster %s.\n", devSeedCount, devSeedVendor, devClusterName)
+		return nil
+	}
+	if err := seed.Apply(cmd.Context(), k8sClient); err != nil {
+		return err
+	}
+	fmt.Printf("Created %d simulated %s BMCs in cluster %s.\n", devSeedCount, devSeedVendor, devClusterName)
+	return nil
+}
+
+func newDevDownCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "down",
+		Short: "Delete the kind cluster of the development environment",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			env := &devenv.Environment{ClusterName: devClusterName, Out: os.Stderr}
+			return env.Down(cmd.Context())
+		},
+	}
+}
+
+// createDevClient creates a client for the kind cluster of the development environment.
+func createDevClient() (client.Client, error) {
+	clientConfig, err := config.GetConfigWithContext("kind-" + devClusterName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig of cluster %s: %w", devClusterName, err)
+	}
+	k8sClient, err := client.New(clientConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+	return k8sClient, nil
+}
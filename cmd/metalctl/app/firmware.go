@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/spf13/cobra"
+)
+
+func NewFirmwareCommand() *cobra.Command {
+	firmwareCmd := &cobra.Command{
+		Use:   "firmware",
+		Short: "Inspect the firmware of Servers",
+		Args:  cobra.NoArgs,
+	}
+	firmwareCmd.AddCommand(newFirmwareStatusCommand())
+	return firmwareCmd
+}
+
+func newFirmwareStatusCommand() *cobra.Command {
+	statusCmd := &cobra.Command{
+		Use:   "status [server...]",
+		Short: "Show the BIOS, BMC and NIC firmware versions of Servers and the versions they are updated to",
+		RunE:  runFirmwareStatus,
+	}
+	statusCmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig.")
+	return statusCmd
+}
+
+// firmwareTargetKey identifies the firmware of a component type of a Server.
+type firmwareTargetKey struct {
+	server        string
+	componentType metalv1alpha1.ComponentType
+}
+
+// firmwareTarget is the version a ComponentFirmware updates the components of a Server to.
+type firmwareTarget struct {
+	current string
+	version string
+	state   metalv1alpha1.ComponentFirmwareState
+}
+
+func runFirmwareStatus(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	k8sClient, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	serverList := &metalv1alpha1.ServerList{}
+	if err := k8sClient.List(ctx, serverList); err != nil {
+		return fmt.Errorf("failed to list servers: %w", err)
+	}
+	bmcList := &metalv1alpha1.BMCList{}
+	if err := k8sClient.List(ctx, bmcList); err != nil {
+		return fmt.Errorf("failed to list BMCs: %w", err)
+	}
+	componentFirmwareList := &metalv1alpha1.ComponentFirmwareList{}
+	if err := k8sClient.List(ctx, componentFirmwareList); err != nil {
+		return fmt.Errorf("failed to list ComponentFirmwares: %w", err)
+	}
+
+	bmcVersions := map[string]string{}
+	for _, bmcObj := range bmcList.Items {
+		bmcVersions[bmcObj.Name] = bmcObj.Status.FirmwareVersion
+	}
+	// the most recently created ComponentFirmware of a type determines the target version of a server
+	slices.SortFunc(componentFirmwareList.Items, func(a, b metalv1alpha1.ComponentFirmware) int {
+		return a.CreationTimestamp.Compare(b.CreationTimestamp.Time)
+	})
+	targets := map[firmwareTargetKey]firmwareTarget{}
+	for _, firmware := range componentFirmwareList.Items {
+		var current []string
+		for _, component := range firmware.Status.Components {
+			if component.Version != "" && !slices.Contains(current, component.Version) {
+				current = append(current, component.Version)
+			}
+		}
+		targets[firmwareTargetKey{server: firmware.Spec.ServerRef.Name, componentType: firmware.Spec.Component.Type}] = firmwareTarget{
+			current: strings.Join(current, ","),
+			version: firmware.Spec.Version,
+			state:   firmware.Status.State,
+		}
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "SERVER\tBIOS\tBMC\tNIC")
+	for _, server := range serverList.Items {
+		if len(args) > 0 && !slices.Contains(args, server.Name) {
+			continue
+		}
+		bmcVersion := ""
+		if server.Spec.BMCRef != nil {
+			bmcVersion = bmcVersions[server.Spec.BMCRef.Name]
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", server.Name,
+			formatFirmwareVersion(server.Status.BIOS.Version, targets, metalv1alpha1.ComponentTypeBIOS, server.Name),
+			formatFirmwareVersion(bmcVersion, targets, metalv1alpha1.ComponentTypeBMC, server.Name),
+			formatFirmwareVersion("", targets, metalv1alpha1.ComponentTypeNIC, server.Name))
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to print firmware status: %w", err)
+	}
+	return nil
+}
+
+// formatFirmwareVersion formats the current version of a component type and, unless it is already reached, the
+// version it is updated to.
+func formatFirmwareVersion(current string, targets map[firmwareTargetKey]firmwareTarget, componentType metalv1alpha1.ComponentType, serverName string) string {
+	target, ok := targets[firmwareTargetKey{server: serverName, componentType: componentType}]
+	if current == "" && ok {
+		current = target.current
+	}
+	if current == "" {
+		current = "-"
+	}
+	if !ok || target.version == current {
+		return current
+	}
+	state := target.state
+	if state == "" {
+		state = metalv1alpha1.ComponentFirmwareStatePending
+	}
+	return fmt.Sprintf("%s -> %s (%s)", current, target.version, state)
+}
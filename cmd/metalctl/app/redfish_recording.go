@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	recordingNamespace string
+	showBodies         bool
+)
+
+func NewRedfishRecordingCommand() *cobra.Command {
+	recordingCmd := &cobra.Command{
+		Use:   "redfish-recording <name>",
+		Short: "Show the recorded Redfish requests and responses of a BMC or a Server with an inline BMC",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runRedfishRecording,
+	}
+
+	recordingCmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig.")
+	recordingCmd.Flags().StringVar(&recordingNamespace, "namespace", "default",
+		"Namespace the metal-operator manager is running in.")
+	recordingCmd.Flags().BoolVar(&showBodies, "show-bodies", false, "Show the request and response bodies.")
+
+	return recordingCmd
+}
+
+func runRedfishRecording(cmd *cobra.Command, args []string) error {
+	k8sClient, err := createClient()
+	if err != nil {
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: recordingNamespace, Name: bmcutils.RedfishRecordingConfigMapName(args[0])}
+	if err := k8sClient.Get(cmd.Context(), key, configMap); err != nil {
+		return fmt.Errorf("failed to get Redfish recording: %w", err)
+	}
+
+	var exchanges []bmc.Exchange
+	if err := json.Unmarshal([]byte(configMap.Data[bmcutils.RedfishRecordingKey]), &exchanges); err != nil {
+		return fmt.Errorf("failed to unmarshal Redfish recording: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	for _, exchange := range exchanges {
+		status := fmt.Sprintf("%d", exchange.StatusCode)
+		if exchange.Error != "" {
+			status = "error: " + exchange.Error
+		}
+		_, _ = fmt.Fprintf(out, "%s %s %s %s (%s)\n", exchange.Time.Format(time.RFC3339), exchange.Method,
+			exchange.URL, status, exchange.Duration)
+		if showBodies {
+			if exchange.RequestBody != "" {
+				_, _ = fmt.Fprintf(out, "  request: %s\n", exchange.RequestBody)
+			}
+			if exchange.ResponseBody != "" {
+				_, _ = fmt.Fprintf(out, "  response: %s\n", exchange.ResponseBody)
+			}
+		}
+	}
+	return nil
+}
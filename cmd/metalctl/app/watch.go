@@ -0,0 +1,299 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+var watchAll bool
+
+func NewWatchCommand() *cobra.Command {
+	watchCmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Watch resources and their related resources in a single timeline",
+		Args:  cobra.NoArgs,
+	}
+	watchCmd.AddCommand(newWatchServerCommand())
+	return watchCmd
+}
+
+func newWatchServerCommand() *cobra.Command {
+	serverCmd := &cobra.Command{
+		Use:   "server [name]",
+		Short: "Stream the state, power and condition changes of a Server, its BMC, claim and firmware updates",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  runWatchServer,
+	}
+	serverCmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig.")
+	serverCmd.Flags().BoolVar(&watchAll, "all", false, "Watch all Servers.")
+	return serverCmd
+}
+
+// watchedKind describes a kind of resource shown in the timeline of a Server.
+type watchedKind struct {
+	name string
+	list client.ObjectList
+	// servers returns the names of the Servers the object relates to.
+	servers func(obj client.Object, bmcServers map[string][]string) []string
+	// fields returns the fields of the object shown in the timeline.
+	fields func(obj client.Object) map[string]string
+}
+
+// timelineEvent is a change of a watched object.
+type timelineEvent struct {
+	kind  *watchedKind
+	event watch.Event
+}
+
+func runWatchServer(cmd *cobra.Command, args []string) error {
+	if watchAll == (len(args) == 1) {
+		return fmt.Errorf("either a server name or --all is required")
+	}
+	var serverName string
+	if len(args) == 1 {
+		serverName = args[0]
+	}
+
+	watchClient, err := createWatchClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+	events := make(chan timelineEvent)
+	errs := make(chan error, len(watchedKinds))
+	for _, kind := range watchedKinds {
+		w, err := watchClient.Watch(ctx, kind.list)
+		if err != nil {
+			return fmt.Errorf("failed to watch %ss: %w", kind.name, err)
+		}
+		go func() {
+			defer w.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case event, ok := <-w.ResultChan():
+					if !ok {
+						errs <- fmt.Errorf("watch of %ss has been closed", kind.name)
+						return
+					}
+					select {
+					case events <- timelineEvent{kind: kind, event: event}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	return printTimeline(ctx, cmd.OutOrStdout(), serverName, events, errs)
+}
+
+// printTimeline prints the changes of the watched objects which relate to the given Server, or to any Server if
+// serverName is empty.
+func printTimeline(ctx context.Context, out io.Writer, serverName string, events <-chan timelineEvent, errs <-chan error) error {
+	observed := map[string]map[string]string{}
+	bmcServers := map[string][]string{}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errs:
+			return err
+		case e := <-events:
+			if e.event.Type == watch.Error || e.event.Type == watch.Bookmark {
+				continue
+			}
+			obj, ok := e.event.Object.(client.Object)
+			if !ok {
+				continue
+			}
+			if server, ok := obj.(*metalv1alpha1.Server); ok && server.Spec.BMCRef != nil {
+				names := bmcServers[server.Spec.BMCRef.Name]
+				if !slices.Contains(names, server.Name) {
+					bmcServers[server.Spec.BMCRef.Name] = append(names, server.Name)
+				}
+			}
+			servers := e.kind.servers(obj, bmcServers)
+			if serverName != "" && !slices.Contains(servers, serverName) {
+				continue
+			}
+
+			key := e.kind.name + "/" + obj.GetName()
+			if obj.GetNamespace() != "" {
+				key = e.kind.name + "/" + obj.GetNamespace() + "/" + obj.GetName()
+			}
+			var changes []string
+			if e.event.Type == watch.Deleted {
+				delete(observed, key)
+				changes = []string{"deleted"}
+			} else {
+				fields := e.kind.fields(obj)
+				changes = diffFields(observed[key], fields)
+				observed[key] = fields
+			}
+			if len(changes) == 0 {
+				continue
+			}
+			_, _ = fmt.Fprintf(out, "%s %s %s %s\n", time.Now().Format(time.TimeOnly), strings.Join(servers, ","), key,
+				strings.Join(changes, ", "))
+		}
+	}
+}
+
+// diffFields returns the fields which changed between old and current, sorted by name.
+func diffFields(old, current map[string]string) []string {
+	var changes []string
+	for _, name := range slices.Sorted(maps.Keys(current)) {
+		oldValue, ok := old[name]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("%s: %s", name, current[name]))
+		case oldValue != current[name]:
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", name, oldValue, current[name]))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(old)) {
+		if _, ok := current[name]; !ok {
+			changes = append(changes, fmt.Sprintf("%s: %s -> -", name, old[name]))
+		}
+	}
+	return changes
+}
+
+func conditionFields(fields map[string]string, conditions []metav1.Condition) map[string]string {
+	for _, cond := range conditions {
+		value := string(cond.Status)
+		if cond.Reason != "" {
+			value += " (" + cond.Reason + ")"
+		}
+		fields[cond.Type] = value
+	}
+	return fields
+}
+
+func setField(fields map[string]string, name, value string) {
+	if value != "" {
+		fields[name] = value
+	}
+}
+
+var watchedKinds = []*watchedKind{
+	{
+		name: "server",
+		list: &metalv1alpha1.ServerList{},
+		servers: func(obj client.Object, _ map[string][]string) []string {
+			return []string{obj.GetName()}
+		},
+		fields: func(obj client.Object) map[string]string {
+			server := obj.(*metalv1alpha1.Server)
+			fields := map[string]string{}
+			setField(fields, "State", string(server.Status.State))
+			setField(fields, "Power", string(server.Status.PowerState))
+			if server.Spec.ServerClaimRef != nil {
+				fields["Claim"] = server.Spec.ServerClaimRef.Namespace + "/" + server.Spec.ServerClaimRef.Name
+			}
+			if n := len(server.Status.BIOSSettingsHistory); n > 0 {
+				last := server.Status.BIOSSettingsHistory[n-1]
+				fields["BIOSSettings"] = fmt.Sprintf("%d applied at %s", len(last.Settings), last.Time.Format(time.RFC3339))
+			}
+			return conditionFields(fields, server.Status.Conditions)
+		},
+	},
+	{
+		name: "bmc",
+		list: &metalv1alpha1.BMCList{},
+		servers: func(obj client.Object, bmcServers map[string][]string) []string {
+			return bmcServers[obj.GetName()]
+		},
+		fields: func(obj client.Object) map[string]string {
+			bmcObj := obj.(*metalv1alpha1.BMC)
+			fields := map[string]string{}
+			setField(fields, "State", string(bmcObj.Status.State))
+			setField(fields, "Firmware", bmcObj.Status.FirmwareVersion)
+			return conditionFields(fields, bmcObj.Status.Conditions)
+		},
+	},
+	{
+		name: "serverclaim",
+		list: &metalv1alpha1.ServerClaimList{},
+		servers: func(obj client.Object, _ map[string][]string) []string {
+			claim := obj.(*metalv1alpha1.ServerClaim)
+			if claim.Spec.ServerRef == nil {
+				return nil
+			}
+			return []string{claim.Spec.ServerRef.Name}
+		},
+		fields: func(obj client.Object) map[string]string {
+			claim := obj.(*metalv1alpha1.ServerClaim)
+			fields := map[string]string{}
+			setField(fields, "Phase", string(claim.Status.Phase))
+			return conditionFields(fields, claim.Status.Conditions)
+		},
+	},
+	{
+		name: "componentfirmware",
+		list: &metalv1alpha1.ComponentFirmwareList{},
+		servers: func(obj client.Object, _ map[string][]string) []string {
+			return []string{obj.(*metalv1alpha1.ComponentFirmware).Spec.ServerRef.Name}
+		},
+		fields: func(obj client.Object) map[string]string {
+			firmware := obj.(*metalv1alpha1.ComponentFirmware)
+			fields := map[string]string{"Version": firmware.Spec.Version}
+			setField(fields, "State", string(firmware.Status.State))
+			return conditionFields(fields, firmware.Status.Conditions)
+		},
+	},
+	{
+		name: "drivefirmware",
+		list: &metalv1alpha1.DriveFirmwareList{},
+		servers: func(obj client.Object, _ map[string][]string) []string {
+			return []string{obj.(*metalv1alpha1.DriveFirmware).Spec.ServerRef.Name}
+		},
+		fields: func(obj client.Object) map[string]string {
+			firmware := obj.(*metalv1alpha1.DriveFirmware)
+			fields := map[string]string{"Version": firmware.Spec.Version}
+			setField(fields, "State", string(firmware.Status.State))
+			return conditionFields(fields, firmware.Status.Conditions)
+		},
+	},
+}
+
+func createWatchClient() (client.WithWatch, error) {
+	if kubeconfig == "" && os.Getenv("KUBECONFIG") == "" {
+		return nil, fmt.Errorf("--kubeconfig flag or KUBECONFIG environment variable must be set")
+	}
+	if kubeconfig != "" {
+		if err := os.Setenv("KUBECONFIG", kubeconfig); err != nil {
+			return nil, fmt.Errorf("failed to set KUBECONFIG: %w", err)
+		}
+	}
+	clientConfig, err := config.GetConfigWithContext("")
+	if err != nil {
+		return nil, fmt.Errorf("failed getting client config: %w", err)
+	}
+	watchClient, err := client.NewWithWatch(clientConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed creating controller-runtime watch client: %w", err)
+	}
+	return watchClient, nil
+}
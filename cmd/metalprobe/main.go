@@ -21,10 +21,23 @@ func main() {
 	var registryURL string
 	var serverUUID string
 	var duration time.Duration
+	var collectorDir string
+	var collectorTimeout time.Duration
+	var bmcAccount string
+	var registryToken string
 
 	flag.StringVar(&registryURL, "registry-url", "", "Registry URL where the probe will register itself.")
 	flag.StringVar(&serverUUID, "server-uuid", "", "Agent UUID to register with the registry.")
+	flag.StringVar(&registryToken, "registry-token", "",
+		"Token authenticating the agent at the registry. Required for the credential bootstrapping.")
 	flag.DurationVar(&duration, "duration", 5*time.Second, "Duration of time to wait between checks.")
+	flag.StringVar(&collectorDir, "collector-dir", "",
+		"Directory of executables whose JSON output is attached to the registration as vendor extensions.")
+	flag.DurationVar(&collectorTimeout, "collector-timeout", probe.DefaultCollectorTimeout,
+		"Time each collector may take.")
+	flag.StringVar(&bmcAccount, "bmc-account", "",
+		"BMC account whose password is set through the Redfish host interface. An empty value disables the "+
+			"credential bootstrapping.")
 
 	opts := zap.Options{
 		Development: true,
@@ -48,6 +61,18 @@ func main() {
 
 	setupLog.Info("starting registry agent")
 	agent := probe.NewAgent(serverUUID, registryURL, duration)
+	agent.Collectors = probe.RegisteredCollectors()
+	agent.CollectorTimeout = collectorTimeout
+	agent.BMCAccount = bmcAccount
+	agent.RegistryToken = registryToken
+	if collectorDir != "" {
+		execCollectors, err := probe.ExecCollectorsFromDir(collectorDir)
+		if err != nil {
+			setupLog.Error(err, "failed to load collectors")
+			os.Exit(1)
+		}
+		agent.Collectors = append(agent.Collectors, execCollectors...)
+	}
 	if err := agent.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running probe agent")
 		os.Exit(1)
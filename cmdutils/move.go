@@ -118,9 +118,24 @@ func crsOwnerReferenceTrees(crs []*unstructured.Unstructured) []*Node {
 	return roots
 }
 
+// cleanup deletes the CRs created in the target cluster. Servers are annotated to be deleted forcefully first, as
+// the Server webhook protects claimed Servers from deletion.
 func cleanup(ctx context.Context, cl client.Client, crs []*unstructured.Unstructured) error {
 	cleanupErrs := make([]error, 0)
 	for _, cr := range crs {
+		if cr.GroupVersionKind() == metalv1alphav1.GroupVersion.WithKind("Server") {
+			crBase := cr.DeepCopy()
+			annotations := cr.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[metalv1alphav1.ForceDeleteAnnotation] = "true"
+			cr.SetAnnotations(annotations)
+			if err := cl.Patch(ctx, cr, client.MergeFrom(crBase)); client.IgnoreNotFound(err) != nil {
+				cleanupErrs = append(cleanupErrs, err)
+				continue
+			}
+		}
 		if err := cl.Delete(ctx, cr); err != nil {
 			cleanupErrs = append(cleanupErrs, err)
 		}
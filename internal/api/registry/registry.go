@@ -3,9 +3,64 @@
 
 package registry
 
+import "time"
+
 // RegistrationPayload represents the payload to send to the `/register` endpoint,
 // including the systemUUID and the server details.
 type RegistrationPayload struct {
 	SystemUUID string `json:"systemUUID"`
 	Data       Server `json:"data"`
 }
+
+// BMCCredentialsPayload represents the payload to send to the `/bmc-credentials` endpoint, holding the
+// credentials the probe agent has set on the BMC through the Redfish host interface.
+type BMCCredentialsPayload struct {
+	// SystemUUID is the UUID of the system of the probe agent, whose token authenticates the payload.
+	SystemUUID string `json:"systemUUID"`
+	MACAddress string `json:"macAddress"`
+	Username   string `json:"username"`
+	Password   string `json:"password"`
+}
+
+// SystemState is the state of a system in the registry.
+type SystemState string
+
+const (
+	// SystemStateRegistered is the state of a system whose discovery has been registered but not yet consumed.
+	SystemStateRegistered SystemState = "Registered"
+	// SystemStateConsumed is the state of a system whose last discovery has been consumed and is kept as history.
+	SystemStateConsumed SystemState = "Consumed"
+)
+
+// SystemEntry is a system as listed by the `/systems` endpoint.
+type SystemEntry struct {
+	SystemUUID string      `json:"systemUUID"`
+	State      SystemState `json:"state"`
+	// Manufacturer is the canonical manufacturer of the system as read from its SMBIOS tables.
+	Manufacturer string `json:"manufacturer,omitempty"`
+	// Timestamp is the time of the last registration or consumption of the system.
+	Timestamp time.Time `json:"timestamp"`
+	Data      Server    `json:"data"`
+}
+
+// SystemList is a page of systems as returned by the `/systems` endpoint. Continue is set if more systems are
+// available and has to be passed as the `continue` query parameter to fetch the next page.
+type SystemList struct {
+	Items    []SystemEntry `json:"items"`
+	Continue string        `json:"continue,omitempty"`
+}
+
+// SystemEventType is the type of a change of a system in the registry.
+type SystemEventType string
+
+const (
+	SystemEventAdded    SystemEventType = "ADDED"
+	SystemEventModified SystemEventType = "MODIFIED"
+	SystemEventDeleted  SystemEventType = "DELETED"
+)
+
+// SystemEvent is a change of a system streamed by the `/systems?watch=true` endpoint.
+type SystemEvent struct {
+	Type   SystemEventType `json:"type"`
+	System SystemEntry     `json:"system"`
+}
@@ -3,6 +3,11 @@
 
 package registry
 
+import (
+	"encoding/json"
+	"time"
+)
+
 // NetworkInterface represents a network interface on a server,
 // including its IP and MAC addresses.
 type NetworkInterface struct {
@@ -14,4 +19,60 @@ type NetworkInterface struct {
 // Server represents a server with a list of network interfaces.
 type Server struct {
 	NetworkInterfaces []NetworkInterface `json:"networkInterfaces,omitempty"`
+	// Architecture is the CPU architecture of the server, e.g. x86_64 or aarch64.
+	Architecture string `json:"architecture,omitempty"`
+	// Extensions holds the JSON output of the collectors of the probe agent by collector name.
+	Extensions map[string]json.RawMessage `json:"extensions,omitempty"`
+	// SMBIOS is the parsed subset of the SMBIOS tables of the server. The raw tables are uploaded separately.
+	SMBIOS *SMBIOS `json:"smbios,omitempty"`
+}
+
+// SMBIOS is the subset of the SMBIOS tables of a server which identifies its system, baseboard and chassis.
+type SMBIOS struct {
+	// System is read from the System Information structure (type 1).
+	System SMBIOSSystem `json:"system"`
+	// Baseboard is read from the first Baseboard Information structure (type 2).
+	Baseboard SMBIOSBaseboard `json:"baseboard"`
+	// Chassis is read from the first System Enclosure structure (type 3).
+	Chassis SMBIOSChassis `json:"chassis"`
+	// Slots are read from the System Slots structures (type 9).
+	Slots []SMBIOSSlot `json:"slots,omitempty"`
+}
+
+// SMBIOSSystem identifies the system as seen from the host.
+type SMBIOSSystem struct {
+	Manufacturer string `json:"manufacturer,omitempty"`
+	ProductName  string `json:"productName,omitempty"`
+	SerialNumber string `json:"serialNumber,omitempty"`
+	// UUID is the system UUID as reported in-band, which has to match the UUID of the system reported by the BMC.
+	UUID string `json:"uuid,omitempty"`
+}
+
+// SMBIOSBaseboard identifies the baseboard of the system.
+type SMBIOSBaseboard struct {
+	Manufacturer string `json:"manufacturer,omitempty"`
+	Product      string `json:"product,omitempty"`
+	SerialNumber string `json:"serialNumber,omitempty"`
+}
+
+// SMBIOSChassis identifies the chassis of the system.
+type SMBIOSChassis struct {
+	Manufacturer string `json:"manufacturer,omitempty"`
+	SerialNumber string `json:"serialNumber,omitempty"`
+	AssetTag     string `json:"assetTag,omitempty"`
+}
+
+// SMBIOSSlot is an expansion slot of the system.
+type SMBIOSSlot struct {
+	Designation string `json:"designation"`
+	// InUse reports whether the slot is populated.
+	InUse bool `json:"inUse"`
+}
+
+// DiscoveryRecord represents the last successful discovery payload of a system, which is kept by the
+// registry after the system entry has been consumed.
+type DiscoveryRecord struct {
+	SystemUUID string    `json:"systemUUID"`
+	Timestamp  time.Time `json:"timestamp"`
+	Data       Server    `json:"data"`
 }
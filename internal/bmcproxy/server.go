@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmcproxy
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+
+	"github.com/go-logr/logr"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+	"github.com/ironcore-dev/metal-operator/internal/kubeauth"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// TokenCookieName is the name of the cookie holding the Kubernetes token of the user.
+	TokenCookieName = "bmc-proxy-token"
+	// LoginPath is the path of the login form of the proxy. It is served for every BMC host.
+	LoginPath = "/.bmc-proxy/login"
+)
+
+// Server is an authenticated reverse proxy to the web interfaces and virtual consoles of the BMCs. A BMC is
+// addressed by the host name "<bmc>.<Domain>", so that the absolute paths used by BMC web interfaces keep working.
+// Users authenticate with their Kubernetes token and need the permission to get the proxy subresource of the BMC.
+// The proxy logs in to the BMC with the credentials of its BMCSecret, so that users never see them.
+type Server struct {
+	Client client.Client
+	// Addr is the address the proxy listens on.
+	Addr string
+	// Domain is the domain below which the BMCs are served.
+	Domain string
+	// CertFile and KeyFile are the TLS certificate and key of the proxy. If unset, the proxy serves plain HTTP and
+	// has to be exposed through a TLS terminating ingress.
+	CertFile string
+	KeyFile  string
+	// Insecure connects to the BMCs via HTTP instead of HTTPS.
+	Insecure bool
+
+	authorizer kubeauth.Authorizer
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Every replica serves the proxy.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable.
+func (s *Server) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("bmc-proxy")
+	server := &http.Server{
+		Addr:        s.Addr,
+		Handler:     s,
+		BaseContext: func(net.Listener) context.Context { return logr.NewContext(ctx, log) },
+	}
+
+	log.Info("Starting BMC proxy", "Address", s.Addr, "Domain", s.Domain)
+	if err := kubeauth.ListenAndServe(ctx, server, s.CertFile, s.KeyFile); err != nil {
+		return fmt.Errorf("HTTP BMC proxy %w", err)
+	}
+	return nil
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bmcName, ok := s.bmcNameForHost(r.Host)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if r.URL.Path == LoginPath {
+		s.loginHandler(w, r)
+		return
+	}
+
+	token := tokenFromRequest(r)
+	if token == "" {
+		http.Redirect(w, r, LoginPath+"?"+url.Values{"redirect": {r.URL.RequestURI()}}.Encode(), http.StatusFound)
+		return
+	}
+	log := logr.FromContextOrDiscard(r.Context())
+	user, allowed, err := s.authorizer.Authorize(r.Context(), s.Client, token, &authorizationv1.ResourceAttributes{
+		Group:       metalv1alpha1.GroupVersion.Group,
+		Resource:    "bmcs",
+		Subresource: "proxy",
+		Verb:        "get",
+		Name:        bmcName,
+	}, nil)
+	if err != nil {
+		log.Error(err, "Failed to authorize BMC proxy request", "BMC", bmcName)
+		http.Error(w, "Failed to authorize request", http.StatusInternalServerError)
+		return
+	}
+	if user == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if !allowed {
+		log.Info("Denied BMC proxy request", "User", user, "BMC", bmcName, "Method", r.Method, "Path", r.URL.Path)
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	proxy, err := s.proxyForBMC(r.Context(), bmcName)
+	if err != nil {
+		log.Error(err, "Failed to create BMC proxy", "BMC", bmcName)
+		http.Error(w, "Failed to connect to BMC", http.StatusBadGateway)
+		return
+	}
+	log.Info("Proxied BMC request", "User", user, "BMC", bmcName, "Method", r.Method, "Path", r.URL.Path)
+	proxy.ServeHTTP(w, r)
+}
+
+// bmcNameForHost returns the name of the BMC addressed by the host name of a request.
+func (s *Server) bmcNameForHost(host string) (string, bool) {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	name, ok := strings.CutSuffix(strings.ToLower(host), "."+strings.ToLower(s.Domain))
+	if !ok || name == "" || strings.Contains(name, ".") {
+		return "", false
+	}
+	return name, true
+}
+
+func tokenFromRequest(r *http.Request) string {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return token
+	}
+	if cookie, err := r.Cookie(TokenCookieName); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+var loginTemplate = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html>
+<head><title>BMC Proxy</title></head>
+<body>
+<form method="post">
+<label for="token">Kubernetes token</label>
+<input type="password" id="token" name="token" autofocus>
+<input type="hidden" name="redirect" value="{{.}}">
+<input type="submit" value="Log in">
+</form>
+</body>
+</html>
+`))
+
+// loginHandler serves the login form and stores the submitted token in a cookie of the BMC host.
+func (s *Server) loginHandler(w http.ResponseWriter, r *http.Request) {
+	redirect := r.FormValue("redirect")
+	if !strings.HasPrefix(redirect, "/") || strings.HasPrefix(redirect, "//") {
+		redirect = "/"
+	}
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_ = loginTemplate.Execute(w, redirect)
+	case http.MethodPost:
+		http.SetCookie(w, &http.Cookie{
+			Name:     TokenCookieName,
+			Value:    r.PostFormValue("token"),
+			Path:     "/",
+			Secure:   r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https",
+			HttpOnly: true,
+			SameSite: http.SameSiteStrictMode,
+		})
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+	default:
+		http.Error(w, "Only GET and POST methods are allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// proxyForBMC returns a reverse proxy to the web interface of the BMC, which logs in with the credentials of the
+// BMCSecret of the BMC.
+func (s *Server) proxyForBMC(ctx context.Context, bmcName string) (*httputil.ReverseProxy, error) {
+	bmcObj := &metalv1alpha1.BMC{}
+	if err := s.Client.Get(ctx, client.ObjectKey{Name: bmcName}, bmcObj); err != nil {
+		return nil, fmt.Errorf("failed to get BMC: %w", err)
+	}
+	address, err := bmcutils.GetBMCAddressForBMC(ctx, s.Client, bmcObj)
+	if err != nil {
+		return nil, err
+	}
+	username, password, err := bmcutils.GetBMCCredentialsForBMCSecretName(ctx, s.Client, bmcObj.Spec.BMCSecretRef.Name)
+	if err != nil {
+		return nil, err
+	}
+	target := bmcutils.GetBMCURL(bmcObj.Spec.Protocol, address, s.Insecure)
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = bmc.TLSConfig(bmcObj.Spec.CertificateFingerprint)
+	return &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.SetURL(target)
+			r.Out.Host = target.Host
+			r.Out.SetBasicAuth(username, password)
+			removeTokenCookie(r.Out)
+		},
+		Transport: transport,
+	}, nil
+}
+
+// removeTokenCookie keeps the Kubernetes token of the user from being sent to the BMC.
+func removeTokenCookie(r *http.Request) {
+	cookies := r.Cookies()
+	r.Header.Del("Cookie")
+	for _, cookie := range cookies {
+		if cookie.Name != TokenCookieName {
+			r.AddCookie(cookie)
+		}
+	}
+}
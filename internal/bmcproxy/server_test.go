@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmcproxy
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("BMC Proxy", func() {
+	var (
+		server  *Server
+		bmcAuth string
+		cookies []*http.Cookie
+	)
+
+	BeforeEach(func() {
+		bmcAuth = ""
+		cookies = nil
+		backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			bmcAuth = r.Header.Get("Authorization")
+			cookies = r.Cookies()
+			_, _ = w.Write([]byte("BMC web interface"))
+		}))
+		DeferCleanup(backend.Close)
+		host, port, err := net.SplitHostPort(backend.Listener.Addr().String())
+		Expect(err).NotTo(HaveOccurred())
+		portNumber, err := strconv.Atoi(port)
+		Expect(err).NotTo(HaveOccurred())
+
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(metalv1alpha1.AddToScheme(scheme)).To(Succeed())
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&metalv1alpha1.BMC{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-bmc"},
+				Spec: metalv1alpha1.BMCSpec{
+					Endpoint: &metalv1alpha1.InlineEndpoint{
+						IP: metalv1alpha1.MustParseIP(host),
+					},
+					Protocol:     metalv1alpha1.Protocol{Name: metalv1alpha1.ProtocolRedfish, Port: int32(portNumber)},
+					BMCSecretRef: v1.LocalObjectReference{Name: "my-bmc-secret"},
+				},
+			},
+			&metalv1alpha1.BMCSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-bmc-secret"},
+				Data: map[string][]byte{
+					metalv1alpha1.BMCSecretUsernameKeyName: []byte("admin"),
+					metalv1alpha1.BMCSecretPasswordKeyName: []byte("secret"),
+				},
+			},
+		).WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				switch review := obj.(type) {
+				case *authenticationv1.TokenReview:
+					if review.Spec.Token == "operator-token" || review.Spec.Token == "viewer-token" {
+						review.Status.Authenticated = true
+						review.Status.User.Username = review.Spec.Token[:len(review.Spec.Token)-len("-token")]
+					}
+					return nil
+				case *authorizationv1.SubjectAccessReview:
+					review.Status.Allowed = review.Spec.User == "operator" &&
+						review.Spec.ResourceAttributes.Subresource == "proxy" &&
+						review.Spec.ResourceAttributes.Name == "my-bmc"
+					return nil
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).Build()
+		server = &Server{Client: k8sClient, Domain: "bmc.example.com", Insecure: true}
+	})
+
+	request := func(host, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "http://"+host+"/redfish/v1/", nil)
+		req.AddCookie(&http.Cookie{Name: "SESSION", Value: "bmc-session"})
+		if token != "" {
+			req.AddCookie(&http.Cookie{Name: TokenCookieName, Value: token})
+		}
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		return rec
+	}
+
+	It("Should proxy authorized requests with the credentials of the BMC", func() {
+		rec := request("my-bmc.bmc.example.com", "operator-token")
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Body.String()).To(Equal("BMC web interface"))
+
+		req := &http.Request{Header: http.Header{}}
+		req.SetBasicAuth("admin", "secret")
+		Expect(bmcAuth).To(Equal(req.Header.Get("Authorization")))
+		Expect(cookies).To(ConsistOf(HaveField("Name", "SESSION")))
+	})
+
+	It("Should redirect requests without token to the login form", func() {
+		rec := request("my-bmc.bmc.example.com", "")
+		Expect(rec.Code).To(Equal(http.StatusFound))
+		Expect(rec.Header().Get("Location")).To(HavePrefix(LoginPath))
+	})
+
+	It("Should reject invalid tokens", func() {
+		Expect(request("my-bmc.bmc.example.com", "invalid").Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("Should reject users without access to the BMC", func() {
+		Expect(request("my-bmc.bmc.example.com", "viewer-token").Code).To(Equal(http.StatusForbidden))
+		Expect(bmcAuth).To(BeEmpty())
+	})
+
+	It("Should not serve hosts outside of the domain", func() {
+		Expect(request("my-bmc.example.com", "operator-token").Code).To(Equal(http.StatusNotFound))
+	})
+})
@@ -7,10 +7,12 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/url"
 
 	"github.com/ironcore-dev/metal-operator/bmc"
 
 	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -27,6 +29,108 @@ func GetBMCCredentialsFromSecret(secret *metalv1alpha1.BMCSecret) (string, strin
 	return string(username), string(password), nil
 }
 
+// GetBMCCredentialsFromSecretForProfile returns the credentials of a credential profile, which are stored in the
+// BMC secret under the keys "<profile>.username" and "<profile>.password". This allows controllers to use BMC
+// accounts limited to their operations. If the secret holds no credentials for the profile, the default credentials
+// are returned.
+func GetBMCCredentialsFromSecretForProfile(secret *metalv1alpha1.BMCSecret, profile string) (string, string, error) {
+	if profile == "" {
+		return GetBMCCredentialsFromSecret(secret)
+	}
+	username, hasUsername := secret.Data[BMCSecretProfileKeyName(profile, metalv1alpha1.BMCSecretUsernameKeyName)]
+	password, hasPassword := secret.Data[BMCSecretProfileKeyName(profile, metalv1alpha1.BMCSecretPasswordKeyName)]
+	switch {
+	case !hasUsername && !hasPassword:
+		return GetBMCCredentialsFromSecret(secret)
+	case !hasUsername:
+		return "", "", fmt.Errorf("no username found in the BMC secret for credential profile %s", profile)
+	case !hasPassword:
+		return "", "", fmt.Errorf("no password found in the BMC secret for credential profile %s", profile)
+	}
+	return string(username), string(password), nil
+}
+
+// BMCSecretProfileKeyName returns the key of the BMC secret holding a credential of the credential profile.
+func BMCSecretProfileKeyName(profile, key string) string {
+	return profile + "." + key
+}
+
+// ResolveBMCSecret returns the BMCSecret with the data of the Secret referenced by its SecretRef merged into its
+// data. Keys of the Secret take precedence over the keys of the BMCSecret.
+func ResolveBMCSecret(ctx context.Context, c client.Client, bmcSecret *metalv1alpha1.BMCSecret) (*metalv1alpha1.BMCSecret, error) {
+	if bmcSecret.SecretRef == nil {
+		return bmcSecret, nil
+	}
+	secret, err := getReferencedSecret(ctx, c, bmcSecret)
+	if err != nil {
+		return nil, err
+	}
+	resolved := bmcSecret.DeepCopy()
+	if resolved.Data == nil {
+		resolved.Data = make(map[string][]byte, len(secret.Data))
+	}
+	for key, value := range secret.Data {
+		resolved.Data[key] = value
+	}
+	return resolved, nil
+}
+
+// UpdateBMCSecretData sets the given keys in the data of the BMCSecret, or of the Secret referenced by its
+// SecretRef, so that changed credentials are stored where they are read from.
+func UpdateBMCSecretData(ctx context.Context, c client.Client, bmcSecret *metalv1alpha1.BMCSecret, data map[string][]byte) error {
+	if bmcSecret.SecretRef == nil {
+		bmcSecretBase := bmcSecret.DeepCopy()
+		if bmcSecret.Data == nil {
+			bmcSecret.Data = make(map[string][]byte, len(data))
+		}
+		for key, value := range data {
+			bmcSecret.Data[key] = value
+		}
+		if err := c.Patch(ctx, bmcSecret, client.MergeFrom(bmcSecretBase)); err != nil {
+			return fmt.Errorf("failed to patch BMCSecret: %w", err)
+		}
+		return nil
+	}
+
+	secret, err := getReferencedSecret(ctx, c, bmcSecret)
+	if err != nil {
+		return err
+	}
+	secretBase := secret.DeepCopy()
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte, len(data))
+	}
+	for key, value := range data {
+		secret.Data[key] = value
+	}
+	if err := c.Patch(ctx, secret, client.MergeFrom(secretBase)); err != nil {
+		return fmt.Errorf("failed to patch Secret %s/%s of BMCSecret: %w", secret.Namespace, secret.Name, err)
+	}
+	return nil
+}
+
+// SecretRefNamespace is the namespace the Secrets referenced by BMCSecrets have to be in. BMCSecrets are
+// cluster-scoped, so references into other namespaces would let anyone creating a BMCSecret read and overwrite the
+// Secrets of any namespace with the permissions of the manager, and send them to a BMC address of their choice. It
+// is set to the namespace of the manager on startup.
+var SecretRefNamespace = DefaultKubeNamespace
+
+func getReferencedSecret(ctx context.Context, c client.Client, bmcSecret *metalv1alpha1.BMCSecret) (*corev1.Secret, error) {
+	ref := bmcSecret.SecretRef
+	if ref.Name == "" || ref.Namespace == "" {
+		return nil, fmt.Errorf("secretRef of BMCSecret %s requires a name and a namespace", bmcSecret.Name)
+	}
+	if ref.Namespace != SecretRefNamespace {
+		return nil, fmt.Errorf("secretRef of BMCSecret %s references Secret %s/%s outside of the manager namespace %s",
+			bmcSecret.Name, ref.Namespace, ref.Name, SecretRefNamespace)
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get Secret %s/%s of BMCSecret: %w", ref.Namespace, ref.Name, err)
+	}
+	return secret, nil
+}
+
 func GetBMCFromBMCName(ctx context.Context, c client.Client, bmcName string) (*metalv1alpha1.BMC, error) {
 	bmcObj := &metalv1alpha1.BMC{}
 	if err := c.Get(ctx, client.ObjectKey{Name: bmcName}, bmcObj); err != nil {
@@ -40,6 +144,10 @@ func GetBMCCredentialsForBMCSecretName(ctx context.Context, c client.Client, bmc
 	if err := c.Get(ctx, client.ObjectKey{Name: bmcSecretName}, bmcSecret); err != nil {
 		return "", "", fmt.Errorf("failed to get bmc secret: %w", err)
 	}
+	bmcSecret, err := ResolveBMCSecret(ctx, c, bmcSecret)
+	if err != nil {
+		return "", "", err
+	}
 	return GetBMCCredentialsFromSecret(bmcSecret)
 }
 
@@ -64,6 +172,7 @@ func GetBMCAddressForBMC(ctx context.Context, c client.Client, bmcObj *metalv1al
 const DefaultKubeNamespace = "default"
 
 func GetBMCClientForServer(ctx context.Context, c client.Client, server *metalv1alpha1.Server, insecure bool, options bmc.BMCOptions) (bmc.BMC, error) {
+	options.SystemURI = server.Spec.SystemURI
 	if server.Spec.BMCRef != nil {
 		b := &metalv1alpha1.BMC{}
 		bmcName := server.Spec.BMCRef.Name
@@ -75,6 +184,9 @@ func GetBMCClientForServer(ctx context.Context, c client.Client, server *metalv1
 	}
 
 	if server.Spec.BMC != nil {
+		options.Recorder = debugRecorderFor(server, options.DebugRecorders)
+		options.CertificateFingerprint = server.Spec.BMC.CertificateFingerprint
+
 		bmcSecret := &metalv1alpha1.BMCSecret{}
 		if err := c.Get(ctx, client.ObjectKey{Name: server.Spec.BMC.BMCSecretRef.Name}, bmcSecret); err != nil {
 			return nil, fmt.Errorf("failed to get BMC secret: %w", err)
@@ -84,9 +196,8 @@ func GetBMCClientForServer(ctx context.Context, c client.Client, server *metalv1
 			ctx,
 			c,
 			insecure,
-			server.Spec.BMC.Protocol.Name,
+			server.Spec.BMC.Protocol,
 			server.Spec.BMC.Address,
-			server.Spec.BMC.Protocol.Port,
 			bmcSecret,
 			options,
 		)
@@ -96,6 +207,10 @@ func GetBMCClientForServer(ctx context.Context, c client.Client, server *metalv1
 }
 
 func GetBMCClientFromBMC(ctx context.Context, c client.Client, bmcObj *metalv1alpha1.BMC, insecure bool, options bmc.BMCOptions) (bmc.BMC, error) {
+	options.Recorder = debugRecorderFor(bmcObj, options.DebugRecorders)
+	options.CertificateFingerprint = bmcObj.Spec.CertificateFingerprint
+	ApplyBMCTimeouts(&options.Timeouts, bmcObj.Spec.Timeouts)
+
 	var address string
 
 	if bmcObj.Spec.EndpointRef != nil {
@@ -115,30 +230,95 @@ func GetBMCClientFromBMC(ctx context.Context, c client.Client, bmcObj *metalv1al
 		return nil, fmt.Errorf("failed to get BMC secret: %w", err)
 	}
 
-	return CreateBMCClient(ctx, c, insecure, bmcObj.Spec.Protocol.Name, address, bmcObj.Spec.Protocol.Port, bmcSecret, options)
+	return CreateBMCClient(ctx, c, insecure, bmcObj.Spec.Protocol, address, bmcSecret, options)
+}
+
+// ApplyBMCTimeouts overrides the given operation timeouts with the ones configured on a BMC.
+func ApplyBMCTimeouts(timeouts *bmc.OperationTimeouts, overrides *metalv1alpha1.BMCTimeouts) {
+	if overrides == nil {
+		return
+	}
+	if overrides.Login != nil {
+		timeouts.Login = overrides.Login.Duration
+	}
+	if overrides.FirmwareUpload != nil {
+		timeouts.FirmwareUpload = overrides.FirmwareUpload.Duration
+	}
+	if overrides.SettingsApply != nil {
+		timeouts.SettingsApply = overrides.SettingsApply.Duration
+	}
+	if overrides.TaskPolling != nil {
+		timeouts.TaskPolling = overrides.TaskPolling.Duration
+	}
 }
 
 func CreateBMCClient(
 	ctx context.Context,
 	c client.Client,
 	insecure bool,
-	bmcProtocol metalv1alpha1.ProtocolName,
+	protocol metalv1alpha1.Protocol,
+	address string,
+	bmcSecret *metalv1alpha1.BMCSecret,
+	bmcOptions bmc.BMCOptions,
+) (bmc.BMC, error) {
+	bmcClient, err := createBMCClient(ctx, c, insecure, protocol, address, bmcSecret, bmcOptions)
+	if err != nil {
+		updateBMCClientStats(protocol.Name, func(stats *BMCClientStats) {
+			stats.Failed++
+		})
+		return nil, err
+	}
+	if planned := bmc.PlannedWritesFrom(ctx); planned != nil {
+		bmcClient = bmc.NewDryRunBMC(bmcClient, planned)
+	} else if bmcOptions.ReadOnly {
+		bmcClient = bmc.NewReadOnlyBMC(bmcClient)
+	}
+	return newTrackedBMC(bmcClient, protocol.Name), nil
+}
+
+// GetBMCURL returns the base URL of the BMC at the address. The scheme defaults to https, or http if insecure, and
+// is followed by the port and base path of the protocol.
+func GetBMCURL(protocol metalv1alpha1.Protocol, address string, insecure bool) *url.URL {
+	scheme := string(protocol.Scheme)
+	if scheme == "" {
+		scheme = "https"
+		if insecure {
+			scheme = "http"
+		}
+	}
+	return &url.URL{
+		Scheme: scheme,
+		Host:   net.JoinHostPort(address, fmt.Sprintf("%d", protocol.Port)),
+		Path:   protocol.BasePath,
+	}
+}
+
+func createBMCClient(
+	ctx context.Context,
+	c client.Client,
+	insecure bool,
+	protocol metalv1alpha1.Protocol,
 	address string,
-	port int32,
 	bmcSecret *metalv1alpha1.BMCSecret,
 	bmcOptions bmc.BMCOptions,
 ) (bmc.BMC, error) {
-	protocol := "https"
-	if insecure {
-		protocol = "http"
+	bmcSecret, err := ResolveBMCSecret(ctx, c, bmcSecret)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := GetBMCURL(protocol, address, insecure)
+	switch protocol.AuthMode {
+	case metalv1alpha1.BMCAuthModeSession:
+		bmcOptions.BasicAuth = false
+	case metalv1alpha1.BMCAuthModeBasic:
+		bmcOptions.BasicAuth = true
 	}
 
 	var bmcClient bmc.BMC
-	var err error
-	switch bmcProtocol {
+	switch protocol.Name {
 	case metalv1alpha1.ProtocolRedfish:
-		bmcOptions.Endpoint = fmt.Sprintf("%s://%s", protocol, net.JoinHostPort(address, fmt.Sprintf("%d", port)))
-		bmcOptions.Username, bmcOptions.Password, err = GetBMCCredentialsFromSecret(bmcSecret)
+		bmcOptions.Endpoint = endpoint.String()
+		bmcOptions.Username, bmcOptions.Password, err = GetBMCCredentialsFromSecretForProfile(bmcSecret, bmcOptions.CredentialProfile)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get credentials from BMC secret: %w", err)
 		}
@@ -147,8 +327,8 @@ func CreateBMCClient(
 			return nil, fmt.Errorf("failed to create Redfish client: %w", err)
 		}
 	case metalv1alpha1.ProtocolRedfishLocal:
-		bmcOptions.Endpoint = fmt.Sprintf("%s://%s", protocol, net.JoinHostPort(address, fmt.Sprintf("%d", port)))
-		bmcOptions.Username, bmcOptions.Password, err = GetBMCCredentialsFromSecret(bmcSecret)
+		bmcOptions.Endpoint = endpoint.String()
+		bmcOptions.Username, bmcOptions.Password, err = GetBMCCredentialsFromSecretForProfile(bmcSecret, bmcOptions.CredentialProfile)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get credentials from BMC secret: %w", err)
 		}
@@ -157,8 +337,8 @@ func CreateBMCClient(
 			return nil, fmt.Errorf("failed to create Redfish client: %w", err)
 		}
 	case metalv1alpha1.ProtocolRedfishKube:
-		bmcOptions.Endpoint = fmt.Sprintf("%s://%s", protocol, net.JoinHostPort(address, fmt.Sprintf("%d", port)))
-		bmcOptions.Username, bmcOptions.Password, err = GetBMCCredentialsFromSecret(bmcSecret)
+		bmcOptions.Endpoint = endpoint.String()
+		bmcOptions.Username, bmcOptions.Password, err = GetBMCCredentialsFromSecretForProfile(bmcSecret, bmcOptions.CredentialProfile)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get credentials from BMC secret: %w", err)
 		}
@@ -166,8 +346,21 @@ func CreateBMCClient(
 		if err != nil {
 			return nil, fmt.Errorf("failed to create Redfish client: %w", err)
 		}
+	case metalv1alpha1.ProtocolRedfishFake:
+		if err := LoadSimulatorFixture(ctx, c, endpoint.Host); err != nil {
+			return nil, err
+		}
+		bmcOptions.Endpoint = endpoint.String()
+		bmcOptions.Username, bmcOptions.Password, err = GetBMCCredentialsFromSecretForProfile(bmcSecret, bmcOptions.CredentialProfile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get credentials from BMC secret: %w", err)
+		}
+		bmcClient, err = bmc.NewRedfishFakeBMCClient(ctx, bmcOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Redfish client: %w", err)
+		}
 	default:
-		return nil, fmt.Errorf("unsupported BMC protocol %s", bmcProtocol)
+		return nil, fmt.Errorf("unsupported BMC protocol %s", protocol.Name)
 	}
 	return bmcClient, nil
 }
@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmcutils
+
+import (
+	"context"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("GetBMCCredentialsFromSecretForProfile", func() {
+	secret := &metalv1alpha1.BMCSecret{
+		Data: map[string][]byte{
+			metalv1alpha1.BMCSecretUsernameKeyName: []byte("admin"),
+			metalv1alpha1.BMCSecretPasswordKeyName: []byte("admin-password"),
+			"firmware.username":                    []byte("firmware"),
+			"firmware.password":                    []byte("firmware-password"),
+			"monitoring.username":                  []byte("monitoring"),
+		},
+	}
+
+	It("Should return the credentials of the profile", func() {
+		username, password, err := GetBMCCredentialsFromSecretForProfile(secret, metalv1alpha1.BMCCredentialProfileFirmware)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(username).To(Equal("firmware"))
+		Expect(password).To(Equal("firmware-password"))
+	})
+
+	It("Should fall back to the default credentials", func() {
+		for _, profile := range []string{"", "settings"} {
+			username, password, err := GetBMCCredentialsFromSecretForProfile(secret, profile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(username).To(Equal("admin"))
+			Expect(password).To(Equal("admin-password"))
+		}
+	})
+
+	It("Should fail for incomplete credentials of the profile", func() {
+		_, _, err := GetBMCCredentialsFromSecretForProfile(secret, metalv1alpha1.BMCCredentialProfileMonitoring)
+		Expect(err).To(MatchError(ContainSubstring("no password found")))
+	})
+})
+
+var _ = Describe("GetBMCURL", func() {
+	It("Should default the scheme to the insecure mode", func() {
+		protocol := metalv1alpha1.Protocol{Name: metalv1alpha1.ProtocolRedfish, Port: 443}
+		Expect(GetBMCURL(protocol, "10.0.0.1", false).String()).To(Equal("https://10.0.0.1:443"))
+		Expect(GetBMCURL(protocol, "10.0.0.1", true).String()).To(Equal("http://10.0.0.1:443"))
+	})
+
+	It("Should apply the scheme and base path of the protocol", func() {
+		protocol := metalv1alpha1.Protocol{
+			Name:     metalv1alpha1.ProtocolRedfish,
+			Port:     8443,
+			Scheme:   metalv1alpha1.ProtocolSchemeHTTPS,
+			BasePath: "/bmc/rack1",
+		}
+		Expect(GetBMCURL(protocol, "fd00::1", true).String()).To(Equal("https://[fd00::1]:8443/bmc/rack1"))
+	})
+})
+
+var _ = Describe("ResolveBMCSecret", func() {
+	var (
+		ctx       context.Context
+		k8sClient client.Client
+		bmcSecret *metalv1alpha1.BMCSecret
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(metalv1alpha1.AddToScheme(scheme)).To(Succeed())
+		bmcSecret = &metalv1alpha1.BMCSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: "bmc-secret"},
+			SecretRef:  &corev1.SecretReference{Namespace: "default", Name: "bmc-credentials"},
+			Data: map[string][]byte{
+				metalv1alpha1.BMCSecretUsernameKeyName: []byte("admin"),
+				metalv1alpha1.BMCSecretPasswordKeyName: []byte("stale-password"),
+			},
+		}
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			bmcSecret,
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "bmc-credentials"},
+				Data: map[string][]byte{
+					metalv1alpha1.BMCSecretPasswordKeyName: []byte("password"),
+				},
+			},
+		).Build()
+	})
+
+	It("Should prefer the keys of the referenced Secret", func() {
+		resolved, err := ResolveBMCSecret(ctx, k8sClient, bmcSecret)
+		Expect(err).NotTo(HaveOccurred())
+		username, password, err := GetBMCCredentialsFromSecret(resolved)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(username).To(Equal("admin"))
+		Expect(password).To(Equal("password"))
+		Expect(bmcSecret.Data[metalv1alpha1.BMCSecretPasswordKeyName]).To(Equal([]byte("stale-password")))
+	})
+
+	It("Should fail for a reference without a namespace", func() {
+		bmcSecret.SecretRef.Namespace = ""
+		_, err := ResolveBMCSecret(ctx, k8sClient, bmcSecret)
+		Expect(err).To(MatchError(ContainSubstring("requires a name and a namespace")))
+	})
+
+	It("Should reject references outside of the manager namespace", func() {
+		bmcSecret.SecretRef.Namespace = "kube-system"
+		_, err := ResolveBMCSecret(ctx, k8sClient, bmcSecret)
+		Expect(err).To(MatchError(ContainSubstring("outside of the manager namespace default")))
+		Expect(UpdateBMCSecretData(ctx, k8sClient, bmcSecret, map[string][]byte{
+			metalv1alpha1.BMCSecretPasswordKeyName: []byte("rotated-password"),
+		})).To(MatchError(ContainSubstring("outside of the manager namespace")))
+	})
+
+	It("Should store updated credentials in the referenced Secret", func() {
+		Expect(UpdateBMCSecretData(ctx, k8sClient, bmcSecret, map[string][]byte{
+			metalv1alpha1.BMCSecretPasswordKeyName: []byte("rotated-password"),
+		})).To(Succeed())
+
+		secret := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "bmc-credentials"}, secret)).To(Succeed())
+		Expect(secret.Data[metalv1alpha1.BMCSecretPasswordKeyName]).To(Equal([]byte("rotated-password")))
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(bmcSecret), bmcSecret)).To(Succeed())
+		Expect(bmcSecret.Data[metalv1alpha1.BMCSecretPasswordKeyName]).To(Equal([]byte("stale-password")))
+	})
+})
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmcutils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// RedfishRecordingConfigMapPrefix is the name prefix of the ConfigMaps holding the Redfish recordings.
+	RedfishRecordingConfigMapPrefix = "redfish-recording-"
+	// RedfishRecordingKey is the ConfigMap data key holding the recorded exchanges as JSON.
+	RedfishRecordingKey = "exchanges.json"
+)
+
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+
+// RedfishRecordingConfigMapName returns the name of the ConfigMap holding the Redfish recording of the given
+// BMC or Server.
+func RedfishRecordingConfigMapName(name string) string {
+	return RedfishRecordingConfigMapPrefix + name
+}
+
+func debugRecorderFor(obj client.Object, recorders *bmc.RecorderRegistry) *bmc.Recorder {
+	if recorders == nil || obj.GetAnnotations()[metalv1alpha1.DebugRedfishAnnotation] != "true" {
+		return nil
+	}
+	return recorders.Get(obj.GetName())
+}
+
+// RecordingExporter periodically writes the Redfish recordings of a RecorderRegistry into ConfigMaps, from
+// where they can be retrieved with metalctl.
+type RecordingExporter struct {
+	Client    client.Client
+	Recorders *bmc.RecorderRegistry
+	Namespace string
+	Interval  time.Duration
+}
+
+// Start implements manager.Runnable.
+func (e *RecordingExporter) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("redfish-recording-exporter")
+	ticker := time.NewTicker(e.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := e.export(ctx); err != nil {
+				log.Error(err, "Failed to export Redfish recordings")
+			}
+		}
+	}
+}
+
+func (e *RecordingExporter) export(ctx context.Context) error {
+	for _, name := range e.Recorders.Names() {
+		data, err := json.Marshal(e.Recorders.Get(name).Exchanges())
+		if err != nil {
+			return fmt.Errorf("failed to marshal Redfish recording of %s: %w", name, err)
+		}
+		if err := e.writeConfigMap(ctx, name, string(data)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *RecordingExporter) writeConfigMap(ctx context.Context, name, data string) error {
+	configMap := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: e.Namespace, Name: RedfishRecordingConfigMapName(name)}
+	if err := e.Client.Get(ctx, key, configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get Redfish recording ConfigMap: %w", err)
+		}
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Data:       map[string]string{RedfishRecordingKey: data},
+		}
+		if err := e.Client.Create(ctx, configMap); err != nil {
+			return fmt.Errorf("failed to create Redfish recording ConfigMap: %w", err)
+		}
+		return nil
+	}
+
+	configMapBase := configMap.DeepCopy()
+	configMap.Data = map[string]string{RedfishRecordingKey: data}
+	if err := e.Client.Patch(ctx, configMap, client.MergeFrom(configMapBase)); err != nil {
+		return fmt.Errorf("failed to patch Redfish recording ConfigMap: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmcutils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ironcore-dev/metal-operator/bmc"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SimulatorFixturesConfigMapName is the name of the ConfigMap in DefaultKubeNamespace holding the fixtures of
+// simulated BMCs, which describe the hardware the simulated BMC of an address presents.
+const SimulatorFixturesConfigMapName = "metal-simulator-fixtures"
+
+// SimulatorFixtureKey returns the key of the fixture of the simulated BMC with the address ("host:port") in the
+// fixtures ConfigMap.
+func SimulatorFixtureKey(address string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		}
+		return '_'
+	}, address)
+}
+
+// LoadSimulatorFixture registers the simulated BMC of the address from its fixture, unless the BMC has already been
+// simulated. Addresses without a fixture keep the default simulated BMC.
+func LoadSimulatorFixture(ctx context.Context, c client.Client, address string) error {
+	if bmc.Simulators.Has(address) {
+		return nil
+	}
+	configMap := &v1.ConfigMap{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: DefaultKubeNamespace, Name: SimulatorFixturesConfigMapName}, configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get simulator fixtures: %w", err)
+	}
+	data, ok := configMap.Data[SimulatorFixtureKey(address)]
+	if !ok {
+		return nil
+	}
+	var fixture bmc.SimulatorFixture
+	if err := json.Unmarshal([]byte(data), &fixture); err != nil {
+		return fmt.Errorf("failed to unmarshal simulator fixture of %s: %w", address, err)
+	}
+	bmc.Simulators.LoadOrStore(address, bmc.NewSimulatorFromFixture(fixture))
+	return nil
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bmcutils
+
+import (
+	"sync"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+)
+
+// BMCClientStats are the statistics of a protocol of the BMC clients created by CreateBMCClient.
+type BMCClientStats struct {
+	// Open is the number of clients which have not been logged out yet.
+	Open int64 `json:"open"`
+	// Created is the number of clients created since the start of the process.
+	Created int64 `json:"created"`
+	// Failed is the number of clients which failed to be created since the start of the process.
+	Failed int64 `json:"failed"`
+}
+
+var (
+	clientStatsMu sync.Mutex
+	clientStats   = map[metalv1alpha1.ProtocolName]*BMCClientStats{}
+)
+
+// GetBMCClientStats returns the statistics of the BMC clients by protocol.
+func GetBMCClientStats() map[metalv1alpha1.ProtocolName]BMCClientStats {
+	clientStatsMu.Lock()
+	defer clientStatsMu.Unlock()
+	stats := make(map[metalv1alpha1.ProtocolName]BMCClientStats, len(clientStats))
+	for protocol, s := range clientStats {
+		stats[protocol] = *s
+	}
+	return stats
+}
+
+func updateBMCClientStats(protocol metalv1alpha1.ProtocolName, update func(stats *BMCClientStats)) {
+	clientStatsMu.Lock()
+	defer clientStatsMu.Unlock()
+	stats, ok := clientStats[protocol]
+	if !ok {
+		stats = &BMCClientStats{}
+		clientStats[protocol] = stats
+	}
+	update(stats)
+}
+
+// trackedBMC counts the BMC client as open until it is logged out.
+type trackedBMC struct {
+	bmc.BMC
+	protocol metalv1alpha1.ProtocolName
+	once     sync.Once
+}
+
+func newTrackedBMC(bmcClient bmc.BMC, protocol metalv1alpha1.ProtocolName) *trackedBMC {
+	updateBMCClientStats(protocol, func(stats *BMCClientStats) {
+		stats.Created++
+		stats.Open++
+	})
+	return &trackedBMC{BMC: bmcClient, protocol: protocol}
+}
+
+// Logout implements bmc.BMC.
+func (t *trackedBMC) Logout() {
+	t.once.Do(func() {
+		updateBMCClientStats(t.protocol, func(stats *BMCClientStats) {
+			stats.Open--
+		})
+	})
+	t.BMC.Logout()
+}
@@ -0,0 +1,328 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bootserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/internal/oci"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// Server serves boot artifacts for small sites which do not run a separate PXE stack. Static files of the Root
+// directory, e.g. iPXE binaries, are served via HTTP below /files/ and via TFTP. Layers of OCI artifact images,
+// e.g. kernels and initrds pushed with ORAS, are served via HTTP below /oci/<image>/<title> and cached in the
+// CacheDir. The arch query parameter, e.g. aarch64, selects the manifest of multi-architecture images. Only images
+// of the AllowedImages registries and repositories are served, so that the server is no open pull-through proxy.
+type Server struct {
+	// Fetcher fetches the layers of OCI artifact images.
+	Fetcher oci.Fetcher
+	// Addr is the address the HTTP server listens on.
+	Addr string
+	// TFTPAddr is the address the TFTP server listens on. An empty value disables the TFTP server.
+	TFTPAddr string
+	// Root is the directory of the static files. An empty value disables the static files.
+	Root string
+	// CacheDir is the directory the layers of OCI artifact images are cached in.
+	CacheDir string
+	// AllowedImages are the registries and repositories, e.g. ghcr.io/example, whose images are served. No image
+	// is served if it is empty.
+	AllowedImages []string
+	// MaxCacheSize is the maximum size of the cached layers in bytes. The least recently served layers are evicted
+	// once it is exceeded. Zero disables the limit.
+	MaxCacheSize int64
+
+	mu sync.Mutex
+	// layers holds the last known layer of every requested image and title, so that cached layers are served
+	// while the registry is unreachable.
+	layers map[string]oci.Descriptor
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Every replica serves boot artifacts.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable.
+func (s *Server) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("boot-server")
+	server := &http.Server{
+		Addr:        s.Addr,
+		Handler:     s.Handler(),
+		BaseContext: func(net.Listener) context.Context { return logr.NewContext(ctx, log) },
+	}
+
+	errChan := make(chan error, 2)
+	if s.TFTPAddr != "" {
+		conn, err := net.ListenPacket("udp", s.TFTPAddr)
+		if err != nil {
+			return fmt.Errorf("TFTP boot server ListenPacket: %w", err)
+		}
+		log.Info("Starting TFTP boot server", "Address", s.TFTPAddr)
+		go func() {
+			if err := s.serveTFTP(logr.NewContext(ctx, log), conn); err != nil {
+				errChan <- fmt.Errorf("TFTP boot server: %w", err)
+			}
+		}()
+		defer func() {
+			_ = conn.Close()
+		}()
+	}
+
+	log.Info("Starting HTTP boot server", "Address", s.Addr)
+	go func() {
+		if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			errChan <- fmt.Errorf("HTTP boot server ListenAndServe: %w", err)
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		if err := server.Shutdown(context.Background()); err != nil {
+			return fmt.Errorf("HTTP boot server Shutdown: %w", err)
+		}
+		return nil
+	case err := <-errChan:
+		return err
+	}
+}
+
+// Handler returns the HTTP handler of the server.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	if s.Root != "" {
+		mux.Handle("/files/", http.StripPrefix("/files/", http.FileServer(http.Dir(s.Root))))
+	}
+	mux.HandleFunc("/oci/", s.ociHandler)
+	return mux
+}
+
+// ociHandler serves the layer of an OCI artifact image, addressed as /oci/<image>/<title>.
+func (s *Server) ociHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Only GET and HEAD methods are allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	image, name := path.Split(strings.TrimPrefix(r.URL.Path, "/oci/"))
+	image = strings.TrimSuffix(image, "/")
+	if image == "" || name == "" {
+		http.Error(w, "Expected /oci/<image>/<title>", http.StatusBadRequest)
+		return
+	}
+	if !metalv1alpha1.ImageAllowed([]metalv1alpha1.ImagePolicy{{
+		Spec: metalv1alpha1.ImagePolicySpec{AllowedImages: s.AllowedImages},
+	}}, image) {
+		http.Error(w, fmt.Sprintf("Image %s is not allowed", image), http.StatusForbidden)
+		return
+	}
+
+	file, err := s.openLayer(r.Context(), image, ociArchitecture(r.URL.Query().Get("arch")), name)
+	if err != nil {
+		if errors.Is(err, oci.ErrImageNotFound) || errors.Is(err, oci.ErrLayerNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		logr.FromContextOrDiscard(r.Context()).Error(err, "Failed to fetch layer", "Image", image, "Name", name)
+		http.Error(w, "Failed to fetch layer", http.StatusBadGateway)
+		return
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	stat, err := file.Stat()
+	if err != nil {
+		http.Error(w, "Failed to stat layer", http.StatusInternalServerError)
+		return
+	}
+	http.ServeContent(w, r, name, stat.ModTime(), file)
+}
+
+// openLayer opens the cached layer of the image with the given title, fetching it from the registry if it is
+// not cached yet.
+func (s *Server) openLayer(ctx context.Context, image, architecture, name string) (*os.File, error) {
+	key := image + "/" + architecture + "/" + name
+	layer, err := s.Fetcher.Layer(ctx, image, architecture, name)
+	if err != nil {
+		s.mu.Lock()
+		cached, ok := s.layers[key]
+		s.mu.Unlock()
+		if !ok {
+			return nil, err
+		}
+		logr.FromContextOrDiscard(ctx).V(1).Info("Serving cached layer", "Image", image, "Name", name, "Error", err.Error())
+		return s.openBlob(cached.Digest)
+	}
+	if s.MaxCacheSize > 0 && layer.Size > s.MaxCacheSize {
+		return nil, fmt.Errorf("layer of %d bytes exceeds the maximum cache size of %d bytes", layer.Size, s.MaxCacheSize)
+	}
+
+	file, err := s.openBlob(layer.Digest)
+	if errors.Is(err, os.ErrNotExist) {
+		if err := s.download(ctx, image, layer.Digest); err != nil {
+			return nil, err
+		}
+		file, err = s.openBlob(layer.Digest)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cached layer: %w", err)
+	}
+
+	s.mu.Lock()
+	if s.layers == nil {
+		s.layers = map[string]oci.Descriptor{}
+	}
+	s.layers[key] = layer
+	s.mu.Unlock()
+	if err := s.evict(layer.Digest); err != nil {
+		logr.FromContextOrDiscard(ctx).Error(err, "Failed to evict cached layers")
+	}
+	return file, nil
+}
+
+// openBlob opens the cached blob with the given digest and marks it as recently served.
+func (s *Server) openBlob(digest string) (*os.File, error) {
+	file, err := os.Open(s.blobPath(digest))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	_ = os.Chtimes(file.Name(), now, now)
+	return file, nil
+}
+
+// evict removes the least recently served blobs from the cache, except for the blob with the given digest, until
+// the cache does not exceed MaxCacheSize. The last known layers referring to removed blobs are forgotten.
+func (s *Server) evict(keep string) error {
+	if s.MaxCacheSize <= 0 {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	type blob struct {
+		digest  string
+		size    int64
+		modTime time.Time
+	}
+	var (
+		blobs []blob
+		total int64
+	)
+	algorithms, err := os.ReadDir(filepath.Join(s.CacheDir, "blobs"))
+	if err != nil {
+		return err
+	}
+	for _, algorithm := range algorithms {
+		entries, err := os.ReadDir(filepath.Join(s.CacheDir, "blobs", algorithm.Name()))
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			info, err := entry.Info()
+			if err != nil {
+				continue
+			}
+			blobs = append(blobs, blob{digest: algorithm.Name() + ":" + entry.Name(), size: info.Size(), modTime: info.ModTime()})
+			total += info.Size()
+		}
+	}
+
+	slices.SortFunc(blobs, func(a, b blob) int {
+		return a.modTime.Compare(b.modTime)
+	})
+	evicted := map[string]bool{}
+	for _, b := range blobs {
+		if total <= s.MaxCacheSize {
+			break
+		}
+		if b.digest == keep {
+			continue
+		}
+		if err := os.Remove(s.blobPath(b.digest)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove blob %s: %w", b.digest, err)
+		}
+		evicted[b.digest] = true
+		total -= b.size
+	}
+	for key, layer := range s.layers {
+		if evicted[layer.Digest] {
+			delete(s.layers, key)
+		}
+	}
+	return nil
+}
+
+// download stores the blob with the given digest in the cache after verifying its content.
+func (s *Server) download(ctx context.Context, image, digest string) error {
+	hexDigest, ok := strings.CutPrefix(digest, "sha256:")
+	if !ok {
+		return fmt.Errorf("unsupported digest %s", digest)
+	}
+	blob, err := s.Fetcher.Blob(ctx, image, digest)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = blob.Close()
+	}()
+
+	if err := os.MkdirAll(filepath.Dir(s.blobPath(digest)), 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(s.CacheDir, "download-")
+	if err != nil {
+		return fmt.Errorf("failed to create cache file: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmp, hash), blob)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to download blob %s: %w", digest, err)
+	}
+	if hex.EncodeToString(hash.Sum(nil)) != hexDigest {
+		return fmt.Errorf("content of blob %s does not match its digest", digest)
+	}
+	if err := os.Rename(tmp.Name(), s.blobPath(digest)); err != nil {
+		return fmt.Errorf("failed to store blob %s: %w", digest, err)
+	}
+	return nil
+}
+
+// ociArchitecture returns the OCI platform architecture of a CPU architecture in the naming of the kernel.
+func ociArchitecture(architecture string) string {
+	switch architecture {
+	case "x86_64":
+		return "amd64"
+	case "aarch64":
+		return "arm64"
+	default:
+		return architecture
+	}
+}
+
+func (s *Server) blobPath(digest string) string {
+	algorithm, hexDigest, _ := strings.Cut(digest, ":")
+	return filepath.Join(s.CacheDir, "blobs", algorithm, filepath.Base(hexDigest))
+}
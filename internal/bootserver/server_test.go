@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bootserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/ironcore-dev/metal-operator/internal/oci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakeFetcher struct {
+	layers map[string]oci.Descriptor
+	blobs  map[string][]byte
+	err    error
+	pulls  int
+}
+
+func (f *fakeFetcher) Layer(_ context.Context, image, architecture, name string) (oci.Descriptor, error) {
+	if f.err != nil {
+		return oci.Descriptor{}, f.err
+	}
+	layer, ok := f.layers[image+"/"+architecture+"/"+name]
+	if !ok {
+		return oci.Descriptor{}, oci.ErrLayerNotFound
+	}
+	return layer, nil
+}
+
+func (f *fakeFetcher) Blob(_ context.Context, _, digest string) (io.ReadCloser, error) {
+	f.pulls++
+	return io.NopCloser(bytes.NewReader(f.blobs[digest])), nil
+}
+
+var _ = Describe("Boot Server", func() {
+	var (
+		fetcher *fakeFetcher
+		server  *Server
+	)
+
+	BeforeEach(func() {
+		fetcher = &fakeFetcher{layers: map[string]oci.Descriptor{}, blobs: map[string][]byte{}}
+		for key, content := range map[string]string{
+			"ghcr.io/foo/os:1.0/arm64/vmlinuz":   "kernel",
+			"ghcr.io/foo/os:1.0/arm64/initrd":    "initrd",
+			"ghcr.io/foo/os:1.0/arm64/rootfs":    "rootfs-of-the-image",
+			"docker.io/bar/os:1.0/arm64/vmlinuz": "foreign",
+		} {
+			sum := sha256.Sum256([]byte(content))
+			digest := "sha256:" + hex.EncodeToString(sum[:])
+			fetcher.layers[key] = oci.Descriptor{Digest: digest, Size: int64(len(content))}
+			fetcher.blobs[digest] = []byte(content)
+		}
+
+		root := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(root, "ipxe.efi"), bytes.Repeat([]byte("x"), 1300), 0o644)).To(Succeed())
+		server = &Server{
+			Fetcher:       fetcher,
+			Root:          root,
+			CacheDir:      GinkgoT().TempDir(),
+			AllowedImages: []string{"ghcr.io/foo"},
+		}
+	})
+
+	get := func(path string) (int, string) {
+		rec := httptest.NewRecorder()
+		server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec.Code, rec.Body.String()
+	}
+
+	It("should serve static files", func() {
+		code, body := get("/files/ipxe.efi")
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(body).To(HaveLen(1300))
+	})
+
+	It("should serve and cache the layers of OCI artifact images", func() {
+		By("Fetching the layer from the registry")
+		code, body := get("/oci/ghcr.io/foo/os:1.0/vmlinuz?arch=aarch64")
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(body).To(Equal("kernel"))
+
+		By("Serving the layer from the cache")
+		code, body = get("/oci/ghcr.io/foo/os:1.0/vmlinuz?arch=aarch64")
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(body).To(Equal("kernel"))
+		Expect(fetcher.pulls).To(Equal(1))
+
+		By("Serving the cached layer while the registry is unreachable")
+		fetcher.err = errors.New("registry unreachable")
+		code, body = get("/oci/ghcr.io/foo/os:1.0/vmlinuz?arch=aarch64")
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(body).To(Equal("kernel"))
+
+		By("Ensuring that unknown layers are not found")
+		fetcher.err = nil
+		code, _ = get("/oci/ghcr.io/foo/os:1.0/firmware?arch=aarch64")
+		Expect(code).To(Equal(http.StatusNotFound))
+		code, _ = get("/oci/ghcr.io/foo/os:1.0/vmlinuz?arch=x86_64")
+		Expect(code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should only serve the images of the allowed registries and repositories", func() {
+		code, _ := get("/oci/docker.io/bar/os:1.0/vmlinuz?arch=aarch64")
+		Expect(code).To(Equal(http.StatusForbidden))
+		code, _ = get("/oci/ghcr.io/foobar/os:1.0/vmlinuz?arch=aarch64")
+		Expect(code).To(Equal(http.StatusForbidden))
+		Expect(fetcher.pulls).To(BeZero())
+
+		server.AllowedImages = []string{"ghcr.io"}
+		code, _ = get("/oci/ghcr.io:5000/foo/os:1.0/vmlinuz?arch=aarch64")
+		Expect(code).To(Equal(http.StatusForbidden))
+		code, _ = get("/oci/ghcr.io/foo/os:1.0/vmlinuz?arch=aarch64")
+		Expect(code).To(Equal(http.StatusOK))
+
+		server.AllowedImages = nil
+		code, _ = get("/oci/ghcr.io/foo/os:1.0/vmlinuz?arch=aarch64")
+		Expect(code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should evict the least recently served layers once the cache is full", func() {
+		server.MaxCacheSize = 12
+
+		code, _ := get("/oci/ghcr.io/foo/os:1.0/vmlinuz?arch=aarch64")
+		Expect(code).To(Equal(http.StatusOK))
+		code, _ = get("/oci/ghcr.io/foo/os:1.0/initrd?arch=aarch64")
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(fetcher.pulls).To(Equal(2))
+
+		By("Refusing layers exceeding the cache")
+		code, _ = get("/oci/ghcr.io/foo/os:1.0/rootfs?arch=aarch64")
+		Expect(code).To(Equal(http.StatusBadGateway))
+		Expect(fetcher.pulls).To(Equal(2))
+
+		By("Evicting the least recently served layer")
+		server.MaxCacheSize = 6
+		code, body := get("/oci/ghcr.io/foo/os:1.0/vmlinuz?arch=aarch64")
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(body).To(Equal("kernel"))
+		Expect(fetcher.pulls).To(Equal(2))
+		entries, err := os.ReadDir(filepath.Join(server.CacheDir, "blobs", "sha256"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+		Expect(server.layers).NotTo(HaveKey("ghcr.io/foo/os:1.0/arm64/initrd"))
+
+		code, body = get("/oci/ghcr.io/foo/os:1.0/initrd?arch=aarch64")
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(body).To(Equal("initrd"))
+		Expect(fetcher.pulls).To(Equal(3))
+	})
+
+	It("should serve static files via TFTP", func(ctx SpecContext) {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(conn.Close)
+		go func() {
+			defer GinkgoRecover()
+			Expect(server.serveTFTP(ctx, conn)).To(Succeed())
+		}()
+
+		client, err := net.ListenPacket("udp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Close)
+		request := binary.BigEndian.AppendUint16(nil, tftpOpRRQ)
+		request = append(request, "/ipxe.efi\x00octet\x00"...)
+		_, err = client.WriteTo(request, conn.LocalAddr())
+		Expect(err).NotTo(HaveOccurred())
+
+		var content []byte
+		buf := make([]byte, 1500)
+		for {
+			n, peer, err := client.ReadFrom(buf)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(binary.BigEndian.Uint16(buf)).To(Equal(uint16(tftpOpDATA)))
+			content = append(content, buf[4:n]...)
+			ack := binary.BigEndian.AppendUint16(nil, tftpOpACK)
+			_, err = client.WriteTo(append(ack, buf[2:4]...), peer)
+			Expect(err).NotTo(HaveOccurred())
+			if n-4 < tftpDefaultBlockSize {
+				break
+			}
+		}
+		Expect(content).To(HaveLen(1300))
+	})
+})
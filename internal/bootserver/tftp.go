@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bootserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// TFTP opcodes and error codes of RFC 1350 and the option extension of RFC 2347.
+const (
+	tftpOpRRQ   = 1
+	tftpOpDATA  = 3
+	tftpOpACK   = 4
+	tftpOpERROR = 5
+	tftpOpOACK  = 6
+
+	tftpErrNotDefined   = 0
+	tftpErrFileNotFound = 1
+	tftpErrIllegalOp    = 4
+
+	tftpDefaultBlockSize = 512
+	tftpMaxBlockSize     = 65464
+	tftpTimeout          = 3 * time.Second
+	tftpRetries          = 5
+)
+
+// serveTFTP answers read requests for the static files of the Root directory. Every transfer is served from its
+// own socket, as required by RFC 1350. Write requests are rejected.
+func (s *Server) serveTFTP(ctx context.Context, conn net.PacketConn) error {
+	buf := make([]byte, 1500)
+	for {
+		n, peer, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		filename, options, err := parseTFTPRequest(buf[:n])
+		if err != nil {
+			_, _ = conn.WriteTo(tftpError(tftpErrIllegalOp, err.Error()), peer)
+			continue
+		}
+		go s.sendTFTPFile(ctx, peer, filename, options)
+	}
+}
+
+// parseTFTPRequest parses a read request into the file name and the lower-cased options.
+func parseTFTPRequest(packet []byte) (string, map[string]string, error) {
+	if len(packet) < 2 || binary.BigEndian.Uint16(packet) != tftpOpRRQ {
+		return "", nil, errors.New("only read requests are supported")
+	}
+	fields := bytes.Split(packet[2:], []byte{0})
+	// A well-formed request ends with a zero byte, which leaves an empty last field.
+	if len(fields) < 3 || len(fields[len(fields)-1]) != 0 {
+		return "", nil, errors.New("malformed read request")
+	}
+	fields = fields[:len(fields)-1]
+	if mode := strings.ToLower(string(fields[1])); mode != "octet" {
+		return "", nil, fmt.Errorf("unsupported transfer mode %s", mode)
+	}
+	options := map[string]string{}
+	for i := 2; i+1 < len(fields); i += 2 {
+		options[strings.ToLower(string(fields[i]))] = string(fields[i+1])
+	}
+	return string(fields[0]), options, nil
+}
+
+func (s *Server) sendTFTPFile(ctx context.Context, peer net.Addr, filename string, options map[string]string) {
+	log := logr.FromContextOrDiscard(ctx).WithValues("Peer", peer.String(), "File", filename)
+	conn, err := net.ListenPacket("udp", ":0")
+	if err != nil {
+		log.Error(err, "Failed to open TFTP transfer socket")
+		return
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	file, size, err := s.openStaticFile(filename)
+	if err != nil {
+		log.V(1).Info("Rejected TFTP read request", "Error", err.Error())
+		_, _ = conn.WriteTo(tftpError(tftpErrFileNotFound, "file not found"), peer)
+		return
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	blockSize := tftpDefaultBlockSize
+	var accepted []string
+	if value, ok := options["blksize"]; ok {
+		if size, err := strconv.Atoi(value); err == nil && size >= 8 {
+			blockSize = min(size, tftpMaxBlockSize)
+			accepted = append(accepted, "blksize", strconv.Itoa(blockSize))
+		}
+	}
+	if _, ok := options["tsize"]; ok {
+		accepted = append(accepted, "tsize", strconv.FormatInt(size, 10))
+	}
+
+	t := &tftpTransfer{conn: conn, peer: peer}
+	if len(accepted) > 0 {
+		oack := binary.BigEndian.AppendUint16(nil, tftpOpOACK)
+		for _, field := range accepted {
+			oack = append(append(oack, field...), 0)
+		}
+		if err := t.send(oack, 0); err != nil {
+			log.V(1).Info("TFTP transfer failed", "Error", err.Error())
+			return
+		}
+	}
+
+	data := make([]byte, blockSize)
+	for block := uint16(1); ; block++ {
+		n, err := io.ReadFull(file, data)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			log.Error(err, "Failed to read file")
+			_, _ = conn.WriteTo(tftpError(tftpErrNotDefined, "read error"), peer)
+			return
+		}
+		packet := binary.BigEndian.AppendUint16(nil, tftpOpDATA)
+		packet = binary.BigEndian.AppendUint16(packet, block)
+		if err := t.send(append(packet, data[:n]...), block); err != nil {
+			log.V(1).Info("TFTP transfer failed", "Error", err.Error())
+			return
+		}
+		if n < blockSize {
+			log.V(1).Info("Completed TFTP transfer", "Size", size)
+			return
+		}
+	}
+}
+
+// openStaticFile opens a file of the Root directory. Paths escaping the Root directory are cleaned to paths below
+// it.
+func (s *Server) openStaticFile(name string) (*os.File, int64, error) {
+	if s.Root == "" {
+		return nil, 0, errors.New("no root directory configured")
+	}
+	file, err := os.Open(filepath.Join(s.Root, filepath.FromSlash(path.Clean("/"+name))))
+	if err != nil {
+		return nil, 0, err
+	}
+	stat, err := file.Stat()
+	if err != nil || !stat.Mode().IsRegular() {
+		_ = file.Close()
+		return nil, 0, fmt.Errorf("%s is no regular file", name)
+	}
+	return file, stat.Size(), nil
+}
+
+type tftpTransfer struct {
+	conn net.PacketConn
+	peer net.Addr
+}
+
+// send sends the packet until the peer acknowledges the given block.
+func (t *tftpTransfer) send(packet []byte, block uint16) error {
+	buf := make([]byte, 1500)
+	for range tftpRetries {
+		if _, err := t.conn.WriteTo(packet, t.peer); err != nil {
+			return err
+		}
+		deadline := time.Now().Add(tftpTimeout)
+		for {
+			if err := t.conn.SetReadDeadline(deadline); err != nil {
+				return err
+			}
+			n, addr, err := t.conn.ReadFrom(buf)
+			if err != nil {
+				var netErr net.Error
+				if errors.As(err, &netErr) && netErr.Timeout() {
+					break
+				}
+				return err
+			}
+			if addr.String() != t.peer.String() || n < 4 {
+				continue
+			}
+			switch binary.BigEndian.Uint16(buf) {
+			case tftpOpACK:
+				if binary.BigEndian.Uint16(buf[2:]) == block {
+					return nil
+				}
+			case tftpOpERROR:
+				return fmt.Errorf("peer aborted the transfer: %s", strings.TrimRight(string(buf[4:n]), "\x00"))
+			}
+		}
+	}
+	return fmt.Errorf("no acknowledgement for block %d", block)
+}
+
+func tftpError(code uint16, message string) []byte {
+	packet := binary.BigEndian.AppendUint16(nil, tftpOpERROR)
+	packet = binary.BigEndian.AppendUint16(packet, code)
+	return append(append(packet, message...), 0)
+}
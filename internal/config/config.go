@@ -0,0 +1,272 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ironcore-dev/metal-operator/internal/controller"
+	"github.com/ironcore-dev/metal-operator/internal/features"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// APIVersion is the API version of the OperatorConfiguration.
+	APIVersion = "config.metal.ironcore.dev/v1alpha1"
+	// Kind is the kind of the OperatorConfiguration.
+	Kind = "OperatorConfiguration"
+)
+
+// flagFeatureGates maps the feature gates of the OperatorConfiguration which predate the features package to the
+// boolean flags they set.
+var flagFeatureGates = map[string]string{
+	"EnforceFirstBoot":         "enforce-first-boot",
+	"EnforcePowerOff":          "enforce-power-off",
+	"VerifyClaimImages":        "verify-claim-images",
+	"CredentialOnboarding":     "credential-onboarding",
+	"TaintOnBootFailure":       "taint-on-boot-failure",
+	"ClaimMaintenanceWebhooks": "claim-maintenance-webhooks",
+}
+
+// OperatorConfiguration is the configuration of the manager. Every field which is set overrides the flag of the
+// same setting, so that the settings of the manager can be managed as a single file, e.g. a ConfigMap.
+type OperatorConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+	// ObserverMode only observes BMCs and Servers without changing their state, see the observer-mode flag.
+	ObserverMode *bool `json:"observerMode,omitempty"`
+	// Discovery configures the discovery of Servers.
+	Discovery DiscoveryConfiguration `json:"discovery,omitempty"`
+	// Boot configures the verification of PXE boots.
+	Boot BootConfiguration `json:"boot,omitempty"`
+	// BMC configures the connections to BMCs.
+	BMC BMCConfiguration `json:"bmc,omitempty"`
+	// Polling configures the polling and resync intervals.
+	Polling PollingConfiguration `json:"polling,omitempty"`
+	// Images configures the images of the discovery boot.
+	Images ImagesConfiguration `json:"images,omitempty"`
+	// CrashDump configures the collection of crash dumps of Servers.
+	CrashDump CrashDumpConfiguration `json:"crashDump,omitempty"`
+	// DriveLocate configures the location indicators of drives.
+	DriveLocate DriveLocateConfiguration `json:"driveLocate,omitempty"`
+	// FeatureGates enables or disables optional behavior of the manager, e.g. EnforceFirstBoot, and the
+	// experimental features of the features package.
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+}
+
+// DiscoveryConfiguration configures the discovery of Servers.
+type DiscoveryConfiguration struct {
+	Timeout                    *metav1.Duration `json:"timeout,omitempty"`
+	MaxAttempts                *int             `json:"maxAttempts,omitempty"`
+	Escalation                 []string         `json:"escalation,omitempty"`
+	RediscoveryInterval        *metav1.Duration `json:"rediscoveryInterval,omitempty"`
+	MaxConcurrentRediscoveries *int             `json:"maxConcurrentRediscoveries,omitempty"`
+}
+
+// BootConfiguration configures the verification of PXE boots.
+type BootConfiguration struct {
+	VerificationTimeout *metav1.Duration `json:"verificationTimeout,omitempty"`
+	VerificationPort    *int             `json:"verificationPort,omitempty"`
+	MaxRetries          *int             `json:"maxRetries,omitempty"`
+	OverrideCheckDelay  *metav1.Duration `json:"overrideCheckDelay,omitempty"`
+}
+
+// BMCConfiguration configures the connections to BMCs.
+type BMCConfiguration struct {
+	AuthMode                 string           `json:"authMode,omitempty"`
+	FailureTimeout           *metav1.Duration `json:"failureTimeout,omitempty"`
+	ResetWaitTime            *metav1.Duration `json:"resetWaitTime,omitempty"`
+	LoginTimeout             *metav1.Duration `json:"loginTimeout,omitempty"`
+	FirmwareUploadTimeout    *metav1.Duration `json:"firmwareUploadTimeout,omitempty"`
+	SettingsApplyTimeout     *metav1.Duration `json:"settingsApplyTimeout,omitempty"`
+	TaskPollingTimeout       *metav1.Duration `json:"taskPollingTimeout,omitempty"`
+	SessionKeepAliveInterval *metav1.Duration `json:"sessionKeepAliveInterval,omitempty"`
+}
+
+// PollingConfiguration configures the polling and resync intervals.
+type PollingConfiguration struct {
+	PowerInterval          *metav1.Duration `json:"powerInterval,omitempty"`
+	PowerTimeout           *metav1.Duration `json:"powerTimeout,omitempty"`
+	ResourceInterval       *metav1.Duration `json:"resourceInterval,omitempty"`
+	ResourceTimeout        *metav1.Duration `json:"resourceTimeout,omitempty"`
+	ServerResyncInterval   *metav1.Duration `json:"serverResyncInterval,omitempty"`
+	RegistryResyncInterval *metav1.Duration `json:"registryResyncInterval,omitempty"`
+}
+
+// ImagesConfiguration configures the images of the discovery boot.
+type ImagesConfiguration struct {
+	Probe              string `json:"probe,omitempty"`
+	ProbeOS            string `json:"probeOS,omitempty"`
+	DiscoveryConfigMap string `json:"discoveryConfigMap,omitempty"`
+}
+
+// CrashDumpConfiguration configures the collection of crash dumps of Servers.
+type CrashDumpConfiguration struct {
+	UploadURL    string           `json:"uploadURL,omitempty"`
+	CaptureImage string           `json:"captureImage,omitempty"`
+	Timeout      *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// DriveLocateConfiguration configures the location indicators of drives.
+type DriveLocateConfiguration struct {
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// Load reads the OperatorConfiguration from a YAML file.
+func Load(path string) (*OperatorConfiguration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read operator configuration: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes and validates an OperatorConfiguration.
+func Parse(data []byte) (*OperatorConfiguration, error) {
+	config := &OperatorConfiguration{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal operator configuration: %w", err)
+	}
+	if config.APIVersion != "" && config.APIVersion != APIVersion {
+		return nil, fmt.Errorf("unsupported apiVersion %q of operator configuration", config.APIVersion)
+	}
+	if config.Kind != "" && config.Kind != Kind {
+		return nil, fmt.Errorf("unsupported kind %q of operator configuration", config.Kind)
+	}
+	for gate := range config.FeatureGates {
+		if _, ok := flagFeatureGates[gate]; !ok && !features.IsKnown(features.Feature(gate)) {
+			return nil, fmt.Errorf("unknown feature gate %q", gate)
+		}
+	}
+	return config, nil
+}
+
+// Apply sets the flags of all fields which are set in the OperatorConfiguration. It has to be called after the
+// flags have been parsed.
+func (c *OperatorConfiguration) Apply(fs *flag.FlagSet) error {
+	values := c.flagValues()
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := fs.Set(name, values[name]); err != nil {
+			return fmt.Errorf("failed to set flag %s from operator configuration: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ServerSettings returns the settings of the ServerReconciler which may be changed while the manager is running,
+// overriding the given settings with the fields which are set in the OperatorConfiguration.
+func (c *OperatorConfiguration) ServerSettings(settings controller.ServerSettings) controller.ServerSettings {
+	if c.Discovery.Timeout != nil {
+		settings.DiscoveryTimeout = c.Discovery.Timeout.Duration
+	}
+	if c.Discovery.MaxAttempts != nil {
+		settings.MaxDiscoveryAttempts = *c.Discovery.MaxAttempts
+	}
+	if c.Discovery.RediscoveryInterval != nil {
+		settings.RediscoveryInterval = c.Discovery.RediscoveryInterval.Duration
+	}
+	if c.Discovery.MaxConcurrentRediscoveries != nil {
+		settings.MaxConcurrentRediscoveries = *c.Discovery.MaxConcurrentRediscoveries
+	}
+	if c.Boot.VerificationTimeout != nil {
+		settings.BootVerificationTimeout = c.Boot.VerificationTimeout.Duration
+	}
+	if c.Boot.MaxRetries != nil {
+		settings.MaxBootRetries = *c.Boot.MaxRetries
+	}
+	if c.Boot.OverrideCheckDelay != nil {
+		settings.BootOverrideCheckDelay = c.Boot.OverrideCheckDelay.Duration
+	}
+	if c.BMC.FailureTimeout != nil {
+		settings.BMCFailureTimeout = c.BMC.FailureTimeout.Duration
+	}
+	return settings
+}
+
+// flagValues returns the flag values of all fields which are set in the OperatorConfiguration by flag name.
+func (c *OperatorConfiguration) flagValues() map[string]string {
+	values := map[string]string{}
+	setDuration := func(name string, d *metav1.Duration) {
+		if d != nil {
+			values[name] = d.Duration.String()
+		}
+	}
+	setInt := func(name string, i *int) {
+		if i != nil {
+			values[name] = strconv.Itoa(*i)
+		}
+	}
+	setString := func(name, s string) {
+		if s != "" {
+			values[name] = s
+		}
+	}
+
+	if c.ObserverMode != nil {
+		values["observer-mode"] = strconv.FormatBool(*c.ObserverMode)
+	}
+
+	setDuration("discovery-timeout", c.Discovery.Timeout)
+	setInt("max-discovery-attempts", c.Discovery.MaxAttempts)
+	if c.Discovery.Escalation != nil {
+		values["discovery-escalation"] = strings.Join(c.Discovery.Escalation, ",")
+	}
+	setDuration("rediscovery-interval", c.Discovery.RediscoveryInterval)
+	setInt("max-concurrent-rediscoveries", c.Discovery.MaxConcurrentRediscoveries)
+
+	setDuration("boot-timeout", c.Boot.VerificationTimeout)
+	setInt("boot-verification-port", c.Boot.VerificationPort)
+	setInt("max-boot-retries", c.Boot.MaxRetries)
+	setDuration("boot-override-check-delay", c.Boot.OverrideCheckDelay)
+
+	setString("bmc-auth-mode", c.BMC.AuthMode)
+	setDuration("bmc-failure-timeout", c.BMC.FailureTimeout)
+	setDuration("bmc-reset-wait-time", c.BMC.ResetWaitTime)
+	setDuration("bmc-login-timeout", c.BMC.LoginTimeout)
+	setDuration("bmc-firmware-upload-timeout", c.BMC.FirmwareUploadTimeout)
+	setDuration("bmc-settings-apply-timeout", c.BMC.SettingsApplyTimeout)
+	setDuration("bmc-task-polling-timeout", c.BMC.TaskPollingTimeout)
+	setDuration("bmc-session-keepalive-interval", c.BMC.SessionKeepAliveInterval)
+
+	setDuration("power-polling-interval", c.Polling.PowerInterval)
+	setDuration("power-polling-timeout", c.Polling.PowerTimeout)
+	setDuration("resource-polling-interval", c.Polling.ResourceInterval)
+	setDuration("resource-polling-timeout", c.Polling.ResourceTimeout)
+	setDuration("server-resync-interval", c.Polling.ServerResyncInterval)
+	setDuration("registry-resync-interval", c.Polling.RegistryResyncInterval)
+
+	setString("probe-image", c.Images.Probe)
+	setString("probe-os-image", c.Images.ProbeOS)
+	setString("discovery-image-configmap", c.Images.DiscoveryConfigMap)
+
+	setString("crash-dump-upload-url", c.CrashDump.UploadURL)
+	setString("crash-dump-capture-image", c.CrashDump.CaptureImage)
+	setDuration("crash-dump-timeout", c.CrashDump.Timeout)
+
+	setDuration("drive-locate-timeout", c.DriveLocate.Timeout)
+
+	var gates []string
+	for gate, enabled := range c.FeatureGates {
+		if name, ok := flagFeatureGates[gate]; ok {
+			values[name] = strconv.FormatBool(enabled)
+			continue
+		}
+		gates = append(gates, fmt.Sprintf("%s=%t", gate, enabled))
+	}
+	if len(gates) > 0 {
+		sort.Strings(gates)
+		values["feature-gates"] = strings.Join(gates, ",")
+	}
+	return values
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ironcore-dev/metal-operator/internal/controller"
+	"github.com/ironcore-dev/metal-operator/internal/features"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OperatorConfiguration", func() {
+	It("should override the flags with the fields which are set", func() {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		discoveryTimeout := fs.Duration("discovery-timeout", 30*time.Minute, "")
+		maxBootRetries := fs.Int("max-boot-retries", 3, "")
+		escalation := fs.String("discovery-escalation", "ResetBMC,SwitchBootMode", "")
+		probeOSImage := fs.String("probe-os-image", "", "")
+		enforceFirstBoot := fs.Bool("enforce-first-boot", false, "")
+		observerMode := fs.Bool("observer-mode", false, "")
+		featureGate := features.NewGate()
+		fs.Var(featureGate, "feature-gates", "")
+		Expect(fs.Parse([]string{"--max-boot-retries=5", "--probe-os-image=flag"})).To(Succeed())
+
+		config, err := Parse([]byte(`
+apiVersion: config.metal.ironcore.dev/v1alpha1
+kind: OperatorConfiguration
+observerMode: true
+discovery:
+  timeout: 1h
+  escalation: []
+images:
+  probeOS: file
+featureGates:
+  EnforceFirstBoot: true
+  DPUModeSwitching: true
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config.Apply(fs)).To(Succeed())
+
+		Expect(*discoveryTimeout).To(Equal(time.Hour))
+		Expect(*maxBootRetries).To(Equal(5))
+		Expect(*escalation).To(BeEmpty())
+		Expect(*probeOSImage).To(Equal("file"))
+		Expect(*enforceFirstBoot).To(BeTrue())
+		Expect(*observerMode).To(BeTrue())
+		Expect(featureGate.Enabled(features.DPUModeSwitching)).To(BeTrue())
+	})
+
+	It("should reject invalid configurations", func() {
+		_, err := Parse([]byte("kind: BMC"))
+		Expect(err).To(MatchError(ContainSubstring("unsupported kind")))
+		_, err = Parse([]byte("featureGates: {Unknown: true}"))
+		Expect(err).To(MatchError(ContainSubstring("unknown feature gate")))
+		_, err = Parse([]byte("discovery: {unknown: 1}"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should override the server settings with the fields which are set", func() {
+		config, err := Parse([]byte(`
+discovery:
+  maxAttempts: 2
+boot:
+  verificationTimeout: 5m
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config.ServerSettings(controller.ServerSettings{
+			DiscoveryTimeout: time.Hour,
+			MaxBootRetries:   3,
+		})).To(Equal(controller.ServerSettings{
+			DiscoveryTimeout:        time.Hour,
+			MaxDiscoveryAttempts:    2,
+			BootVerificationTimeout: 5 * time.Minute,
+			MaxBootRetries:          3,
+		}))
+	})
+})
+
+var _ = Describe("Watcher", func() {
+	It("should report valid changes of the file", func(ctx SpecContext) {
+		path := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		Expect(os.WriteFile(path, []byte("discovery: {maxAttempts: 1}"), 0o644)).To(Succeed())
+
+		changes := make(chan *OperatorConfiguration, 10)
+		watcher := &Watcher{
+			Path:     path,
+			Interval: 10 * time.Millisecond,
+			OnChange: func(config *OperatorConfiguration) { changes <- config },
+		}
+		watchCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			defer GinkgoRecover()
+			Expect(watcher.Start(watchCtx)).To(Succeed())
+		}()
+
+		Consistently(changes, 50*time.Millisecond).ShouldNot(Receive())
+
+		Expect(os.WriteFile(path, []byte("discovery: {maxAttempts: oops}"), 0o644)).To(Succeed())
+		Consistently(changes, 50*time.Millisecond).ShouldNot(Receive())
+
+		Expect(os.WriteFile(path, []byte("discovery: {maxAttempts: 2}"), 0o644)).To(Succeed())
+		var config *OperatorConfiguration
+		Eventually(changes).Should(Receive(&config))
+		Expect(*config.Discovery.MaxAttempts).To(Equal(2))
+	})
+})
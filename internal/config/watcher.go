@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// Watcher reloads the OperatorConfiguration whenever the content of its file changes. The file is polled, as
+// ConfigMaps mounted into the manager are updated by swapping symlinks which file notifications do not report.
+type Watcher struct {
+	// Path is the path of the OperatorConfiguration file.
+	Path string
+	// Interval is the interval in which the file is polled.
+	Interval time.Duration
+	// OnChange is called with the changed OperatorConfiguration. Invalid configurations are logged and ignored.
+	OnChange func(config *OperatorConfiguration)
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Every replica keeps its configuration up to date.
+func (w *Watcher) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable.
+func (w *Watcher) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("config-watcher")
+	// The file has been loaded on startup, so only later changes are reported.
+	last, err := os.ReadFile(w.Path)
+	if err != nil {
+		log.Error(err, "Failed to read operator configuration", "Path", w.Path)
+	}
+
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			data, err := os.ReadFile(w.Path)
+			if err != nil {
+				log.Error(err, "Failed to read operator configuration", "Path", w.Path)
+				continue
+			}
+			if bytes.Equal(data, last) {
+				continue
+			}
+			last = data
+			config, err := Parse(data)
+			if err != nil {
+				log.Error(err, "Ignoring invalid operator configuration", "Path", w.Path)
+				continue
+			}
+			log.Info("Reloading operator configuration", "Path", w.Path)
+			w.OnChange(config)
+		}
+	}
+}
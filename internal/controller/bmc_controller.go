@@ -5,8 +5,12 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"slices"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 
@@ -15,22 +19,62 @@ import (
 	"github.com/ironcore-dev/controller-utils/metautils"
 	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
 	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/api/registry"
 	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+	"github.com/stmcginnis/gofish/redfish"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 const BMCFinalizer = "metal.ironcore.dev/bmc"
 
+const (
+	// BMCConditionReset is True while a reset of the BMC is in progress.
+	BMCConditionReset = "Reset"
+
+	// BMCConditionReachable reflects whether the manager could connect to the BMC on its last reconciliation.
+	BMCConditionReachable = "Reachable"
+
+	bmcResetReasonIssued    = "ResetIssued"
+	bmcResetReasonCompleted = "ResetCompleted"
+	bmcResetReasonFailed    = "ResetFailed"
+
+	bmcReachableReasonConnected        = "Connected"
+	bmcReachableReasonConnectionFailed = "ConnectionFailed"
+
+	defaultBMCResetWaitTime = time.Minute
+	defaultBMCResetTimeout  = 15 * time.Minute
+)
+
 // BMCReconciler reconciles a BMC object
 type BMCReconciler struct {
 	client.Client
 	Scheme            *runtime.Scheme
 	Insecure          bool
 	BMCPollingOptions bmc.BMCOptions
+	// BMCResetWaitTime is the minimum time a BMC is considered to be resetting before it is polled for completion.
+	BMCResetWaitTime time.Duration
+	// BMCResetTimeout is the time after issuing a reset within which the BMC has to be reachable again, after which
+	// the reset is considered failed.
+	BMCResetTimeout time.Duration
+	// WarmUp spreads the first BMC connections after a leader election. A nil value disables the warm-up.
+	WarmUp *WarmUp
+	// RegistryURL is the URL of the registry, from where credentials bootstrapped by the probe agent through the
+	// Redfish host interface are taken for BMCs which cannot be logged in to.
+	RegistryURL string
+	// RegistryToken authenticates the requests for bootstrapped credentials at the registry, see
+	// registry.ManagerToken.
+	RegistryToken string
+	// ObserverMode only updates the status of BMCs and discovers their servers, without performing resets.
+	ObserverMode bool
 }
 
 //+kubebuilder:rbac:groups=metal.ironcore.dev,resources=endpoints,verbs=get;list;watch
@@ -38,6 +82,7 @@ type BMCReconciler struct {
 //+kubebuilder:rbac:groups=metal.ironcore.dev,resources=bmcs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=metal.ironcore.dev,resources=bmcs/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=metal.ironcore.dev,resources=bmcs/finalizers,verbs=update
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=composedservers,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -70,9 +115,39 @@ func (r *BMCReconciler) delete(ctx context.Context, log logr.Logger, bmcObj *met
 
 func (r *BMCReconciler) reconcile(ctx context.Context, log logr.Logger, bmcObj *metalv1alpha1.BMC) (ctrl.Result, error) {
 	log.V(1).Info("Reconciling BMC")
-	if shouldIgnoreReconciliation(bmcObj) {
+	if paused, remaining := shouldIgnoreReconciliation(bmcObj); paused {
 		log.V(1).Info("Skipped BMC reconciliation")
-		return ctrl.Result{}, nil
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	if isDryRun(bmcObj) {
+		var actions []string
+		if bmcObj.GetAnnotations()[metalv1alpha1.OperationAnnotation] == metalv1alpha1.OperationAnnotationGracefulRestartBMC {
+			actions = append(actions, "reset BMC")
+		}
+		if forwardingPending(bmcObj) {
+			actions = append(actions, "configure forwarding")
+		}
+		bmcBase := bmcObj.DeepCopy()
+		if setDryRunCondition(&bmcObj.Status.Conditions, bmcObj.Generation, actions) {
+			if err := r.Status().Patch(ctx, bmcObj, client.MergeFrom(bmcBase)); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to patch DryRun condition: %w", err)
+			}
+		}
+	} else if err := clearDryRunCondition(ctx, r.Client, bmcObj, &bmcObj.Status.Conditions); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !r.ObserverMode && !isDryRun(bmcObj) {
+		if requeueAfter, err := r.handleReset(ctx, log, bmcObj); err != nil || requeueAfter > 0 {
+			return ctrl.Result{RequeueAfter: requeueAfter}, err
+		}
+	}
+
+	// Resets are issued right away, only the polling of the BMC is deferred during the warm-up.
+	if delay := r.WarmUp.Delay(bmcObj); delay > 0 {
+		log.V(1).Info("Deferred BMC polling during warm-up", "Delay", delay)
+		return ctrl.Result{RequeueAfter: delay}, nil
 	}
 
 	if err := r.updateBMCStatusDetails(ctx, log, bmcObj); err != nil {
@@ -80,6 +155,12 @@ func (r *BMCReconciler) reconcile(ctx context.Context, log logr.Logger, bmcObj *
 	}
 	log.V(1).Info("Updated BMC status")
 
+	if !r.ObserverMode && !isDryRun(bmcObj) {
+		if err := r.reconcileForwarding(ctx, log, bmcObj); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	if err := r.discoverServers(ctx, log, bmcObj); err != nil && !errors.IsNotFound(err) {
 		return ctrl.Result{}, fmt.Errorf("failed to discover servers: %w", err)
 	}
@@ -89,6 +170,132 @@ func (r *BMCReconciler) reconcile(ctx context.Context, log logr.Logger, bmcObj *
 	return ctrl.Result{}, nil
 }
 
+// handleReset performs the BMC resets requested through the OperationAnnotationGracefulRestartBMC annotation.
+// Only a single reset is in flight per BMC: requests made while a reset is in progress are coalesced into it.
+// The Reset condition is True until the BMC is reachable again or the reset timed out, which allows requesters to
+// wait for it.
+func (r *BMCReconciler) handleReset(ctx context.Context, log logr.Logger, bmcObj *metalv1alpha1.BMC) (time.Duration, error) {
+	waitTime := r.BMCResetWaitTime
+	if waitTime == 0 {
+		waitTime = defaultBMCResetWaitTime
+	}
+	timeout := r.BMCResetTimeout
+	if timeout == 0 {
+		timeout = defaultBMCResetTimeout
+	}
+	pollInterval := r.BMCPollingOptions.ResourcePollingInterval
+	if pollInterval == 0 {
+		pollInterval = bmc.DefaultResourcePollingInterval
+	}
+
+	requested := bmcObj.GetAnnotations()[metalv1alpha1.OperationAnnotation] == metalv1alpha1.OperationAnnotationGracefulRestartBMC
+	inProgress := meta.IsStatusConditionTrue(bmcObj.Status.Conditions, BMCConditionReset)
+
+	if requested {
+		if _, err := trackOperation(ctx, r.Client, bmcObj, metalv1alpha1.OperationTargetKindBMC); err != nil {
+			return 0, err
+		}
+	}
+
+	if requested && !inProgress {
+		bmcClient, err := bmcutils.GetBMCClientFromBMC(ctx, r.Client, bmcObj, r.Insecure, r.BMCPollingOptions)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create BMC client: %w", err)
+		}
+		defer bmcClient.Logout()
+		if err := bmcClient.ResetManager(ctx, redfish.GracefulRestartResetType); err != nil {
+			return 0, fmt.Errorf("failed to reset BMC: %w", err)
+		}
+		log.V(1).Info("Issued BMC reset")
+
+		bmcBase := bmcObj.DeepCopy()
+		meta.SetStatusCondition(&bmcObj.Status.Conditions, metav1.Condition{
+			Type:               BMCConditionReset,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: bmcObj.Generation,
+			Reason:             bmcResetReasonIssued,
+			Message:            "Graceful restart of the BMC has been issued",
+		})
+		if err := r.Status().Patch(ctx, bmcObj, client.MergeFrom(bmcBase)); err != nil {
+			return 0, fmt.Errorf("failed to patch BMC reset condition: %w", err)
+		}
+		inProgress = true
+	}
+
+	if requested {
+		// the request is either performed above or joins the reset in flight
+		if err := recordOperationResult(ctx, r.Client, bmcObj, metalv1alpha1.OperationStateSucceeded,
+			"Graceful restart of the BMC has been issued"); err != nil {
+			return 0, err
+		}
+		bmcBase := bmcObj.DeepCopy()
+		annotations := bmcObj.GetAnnotations()
+		delete(annotations, metalv1alpha1.OperationAnnotation)
+		delete(annotations, metalv1alpha1.OperationNameAnnotation)
+		bmcObj.SetAnnotations(annotations)
+		if err := r.Patch(ctx, bmcObj, client.MergeFrom(bmcBase)); err != nil {
+			return 0, fmt.Errorf("failed to remove BMC reset annotation: %w", err)
+		}
+	}
+
+	if !inProgress {
+		return 0, nil
+	}
+
+	condition := meta.FindStatusCondition(bmcObj.Status.Conditions, BMCConditionReset)
+	elapsed := time.Since(condition.LastTransitionTime.Time)
+	if remaining := waitTime - elapsed; remaining > 0 {
+		log.V(1).Info("Waiting for BMC reset", "Remaining", remaining)
+		return remaining, nil
+	}
+
+	if err := r.checkBMCReachable(ctx, bmcObj); err != nil {
+		if elapsed <= timeout {
+			log.V(1).Info("BMC is not reachable yet after reset", "Error", err.Error())
+			return pollInterval, nil
+		}
+		message := fmt.Sprintf("BMC is not reachable within %s after the reset: %v", timeout, err)
+		if err := r.finishReset(ctx, bmcObj, bmcResetReasonFailed, message); err != nil {
+			return 0, err
+		}
+		log.V(1).Info("BMC reset failed", "Error", err.Error())
+		return 0, nil
+	}
+
+	if err := r.finishReset(ctx, bmcObj, bmcResetReasonCompleted, "BMC is reachable again after the reset"); err != nil {
+		return 0, err
+	}
+	log.V(1).Info("Completed BMC reset")
+	return 0, nil
+}
+
+// checkBMCReachable returns an error if the BMC cannot be logged in to or does not serve its manager.
+func (r *BMCReconciler) checkBMCReachable(ctx context.Context, bmcObj *metalv1alpha1.BMC) error {
+	bmcClient, err := bmcutils.GetBMCClientFromBMC(ctx, r.Client, bmcObj, r.Insecure, r.BMCPollingOptions)
+	if err != nil {
+		return err
+	}
+	defer bmcClient.Logout()
+	_, err = bmcClient.GetManager()
+	return err
+}
+
+// finishReset sets the Reset condition to False with the reason, which ends the wait of the requesters.
+func (r *BMCReconciler) finishReset(ctx context.Context, bmcObj *metalv1alpha1.BMC, reason, message string) error {
+	bmcBase := bmcObj.DeepCopy()
+	meta.SetStatusCondition(&bmcObj.Status.Conditions, metav1.Condition{
+		Type:               BMCConditionReset,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: bmcObj.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+	if err := r.Status().Patch(ctx, bmcObj, client.MergeFrom(bmcBase)); err != nil {
+		return fmt.Errorf("failed to patch BMC reset condition: %w", err)
+	}
+	return nil
+}
+
 func (r *BMCReconciler) updateBMCStatusDetails(ctx context.Context, log logr.Logger, bmcObj *metalv1alpha1.BMC) error {
 	var (
 		ip         metalv1alpha1.IP
@@ -121,14 +328,35 @@ func (r *BMCReconciler) updateBMCStatusDetails(ctx context.Context, log logr.Log
 	}
 
 	bmcClient, err := bmcutils.GetBMCClientFromBMC(ctx, r.Client, bmcObj, r.Insecure, r.BMCPollingOptions)
+	if err != nil && r.RegistryURL != "" {
+		applied, credentialsErr := r.applyBootstrappedCredentials(ctx, log, bmcObj)
+		if credentialsErr != nil {
+			return credentialsErr
+		}
+		if applied {
+			bmcClient, err = bmcutils.GetBMCClientFromBMC(ctx, r.Client, bmcObj, r.Insecure, r.BMCPollingOptions)
+		}
+	}
+	if patchErr := r.patchReachableCondition(ctx, bmcObj, err); patchErr != nil {
+		return patchErr
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create BMC client: %w", err)
 	}
 	defer bmcClient.Logout()
 
-	// TODO: Secret rotation/User management
-
 	manager, err := bmcClient.GetManager()
+	if _, required := bmc.PasswordChangeRequired(err); required {
+		if err := r.changeRequiredPassword(ctx, log, bmcObj, bmcClient); err != nil {
+			return err
+		}
+		passwordClient, err := bmcutils.GetBMCClientFromBMC(ctx, r.Client, bmcObj, r.Insecure, r.BMCPollingOptions)
+		if err != nil {
+			return fmt.Errorf("failed to create BMC client after changing the password: %w", err)
+		}
+		defer passwordClient.Logout()
+		manager, err = passwordClient.GetManager()
+	}
 	if err != nil {
 		return fmt.Errorf("failed to get manager details: %w", err)
 	}
@@ -141,6 +369,8 @@ func (r *BMCReconciler) updateBMCStatusDetails(ctx context.Context, log logr.Log
 		bmcObj.Status.SerialNumber = manager.SerialNumber
 		bmcObj.Status.SKU = manager.SKU
 		bmcObj.Status.Model = manager.Model
+		bmcObj.Status.CanonicalManufacturer = bmc.NormalizeManufacturer(manager.Manufacturer)
+		bmcObj.Status.CanonicalModel = bmc.NormalizeModel(manager.Manufacturer, manager.Model)
 		if err := r.Status().Patch(ctx, bmcObj, client.MergeFrom(bmcBase)); err != nil {
 			return err
 		}
@@ -149,6 +379,146 @@ func (r *BMCReconciler) updateBMCStatusDetails(ctx context.Context, log logr.Log
 	return nil
 }
 
+// patchReachableCondition records whether the connection to the BMC succeeded. The transition time of the
+// condition tells for how long a BMC has been unreachable.
+func (r *BMCReconciler) patchReachableCondition(ctx context.Context, bmcObj *metalv1alpha1.BMC, connErr error) error {
+	condition := metav1.Condition{
+		Type:               BMCConditionReachable,
+		Status:             metav1.ConditionTrue,
+		Reason:             bmcReachableReasonConnected,
+		Message:            "Connected to the BMC",
+		ObservedGeneration: bmcObj.Generation,
+	}
+	if connErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = bmcReachableReasonConnectionFailed
+		condition.Message = connErr.Error()
+	}
+	bmcBase := bmcObj.DeepCopy()
+	if !meta.SetStatusCondition(&bmcObj.Status.Conditions, condition) {
+		return nil
+	}
+	if err := r.Status().Patch(ctx, bmcObj, client.MergeFrom(bmcBase)); err != nil {
+		return fmt.Errorf("failed to patch Reachable condition: %w", err)
+	}
+	return nil
+}
+
+// changeRequiredPassword changes the password of a BMC which requires a password change before granting access,
+// e.g. on the first login with factory-default credentials. The generated password is stored in the BMCSecret
+// before it is set on the BMC, and reverted if the BMC rejects it, so that the working password never gets lost.
+func (r *BMCReconciler) changeRequiredPassword(ctx context.Context, log logr.Logger, bmcObj *metalv1alpha1.BMC, bmcClient bmc.BMC) error {
+	if r.ObserverMode {
+		return fmt.Errorf("BMC requires a password change, which is not performed in observer mode")
+	}
+	if isDryRun(bmcObj) {
+		return fmt.Errorf("BMC requires a password change, which is not performed in a dry-run")
+	}
+	bmcSecret := &metalv1alpha1.BMCSecret{}
+	if err := r.Get(ctx, client.ObjectKey{Name: bmcObj.Spec.BMCSecretRef.Name}, bmcSecret); err != nil {
+		return fmt.Errorf("failed to get BMCSecret: %w", err)
+	}
+	resolved, err := bmcutils.ResolveBMCSecret(ctx, r.Client, bmcSecret)
+	if err != nil {
+		return err
+	}
+	username, oldPassword, err := bmcutils.GetBMCCredentialsFromSecret(resolved)
+	if err != nil {
+		return fmt.Errorf("failed to get credentials from BMCSecret: %w", err)
+	}
+	password, err := bmc.PasswordPolicyForManufacturer(bmcObj.Status.Manufacturer).GeneratePassword()
+	if err != nil {
+		return fmt.Errorf("failed to generate password: %w", err)
+	}
+
+	if err := bmcutils.UpdateBMCSecretData(ctx, r.Client, bmcSecret, map[string][]byte{
+		metalv1alpha1.BMCSecretPasswordKeyName: []byte(password),
+	}); err != nil {
+		return fmt.Errorf("failed to store changed password: %w", err)
+	}
+	if err := bmcClient.SetAccountPassword(ctx, username, password); err != nil {
+		if revertErr := bmcutils.UpdateBMCSecretData(ctx, r.Client, bmcSecret, map[string][]byte{
+			metalv1alpha1.BMCSecretPasswordKeyName: []byte(oldPassword),
+		}); revertErr != nil {
+			return fmt.Errorf("failed to change required password: %w, and failed to revert BMCSecret: %w", err, revertErr)
+		}
+		return fmt.Errorf("failed to change required password: %w", err)
+	}
+	log.V(1).Info("Changed password required by the BMC", "BMCSecret", bmcSecret.Name, "Username", username)
+	return nil
+}
+
+// applyBootstrappedCredentials updates the BMCSecret of the BMC with the credentials the probe agent has set
+// through the Redfish host interface, if the registry holds any for the MAC address of the BMC.
+func (r *BMCReconciler) applyBootstrappedCredentials(ctx context.Context, log logr.Logger, bmcObj *metalv1alpha1.BMC) (bool, error) {
+	if bmcObj.Status.MACAddress == "" || bmcObj.Spec.BMCSecretRef.Name == "" {
+		return false, nil
+	}
+	credentialsURL := fmt.Sprintf("%s/bmc-credentials/%s", r.RegistryURL, bmcObj.Status.MACAddress)
+	resp, err := r.registryRequest(ctx, http.MethodGet, credentialsURL)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch bootstrapped BMC credentials: %w", err)
+	}
+	defer resp.Body.Close() // nolint: errcheck
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("failed to fetch bootstrapped BMC credentials: %s", resp.Status)
+	}
+	credentials := registry.BMCCredentialsPayload{}
+	if err := json.NewDecoder(resp.Body).Decode(&credentials); err != nil {
+		return false, fmt.Errorf("failed to decode bootstrapped BMC credentials: %w", err)
+	}
+
+	// only a system behind the BMC may bootstrap its credentials
+	servers := &metalv1alpha1.ServerList{}
+	if err := r.List(ctx, servers); err != nil {
+		return false, fmt.Errorf("failed to list Servers: %w", err)
+	}
+	if !slices.ContainsFunc(servers.Items, func(server metalv1alpha1.Server) bool {
+		return server.Spec.BMCRef != nil && server.Spec.BMCRef.Name == bmcObj.Name &&
+			strings.EqualFold(server.Spec.SystemUUID, credentials.SystemUUID)
+	}) {
+		log.Info("Ignoring bootstrapped BMC credentials of a system not behind the BMC", "SystemUUID", credentials.SystemUUID)
+		return false, nil
+	}
+
+	bmcSecret := &metalv1alpha1.BMCSecret{}
+	if err := r.Get(ctx, client.ObjectKey{Name: bmcObj.Spec.BMCSecretRef.Name}, bmcSecret); err != nil {
+		return false, fmt.Errorf("failed to get BMCSecret: %w", err)
+	}
+	if err := bmcutils.UpdateBMCSecretData(ctx, r.Client, bmcSecret, map[string][]byte{
+		metalv1alpha1.BMCSecretUsernameKeyName: []byte(credentials.Username),
+		metalv1alpha1.BMCSecretPasswordKeyName: []byte(credentials.Password),
+	}); err != nil {
+		return false, fmt.Errorf("failed to store bootstrapped credentials: %w", err)
+	}
+	log.V(1).Info("Applied bootstrapped BMC credentials", "BMCSecret", bmcSecret.Name, "Username", credentials.Username)
+
+	// the credentials are only removed from the registry once they are stored in the BMCSecret
+	deleteResp, err := r.registryRequest(ctx, http.MethodDelete, credentialsURL)
+	if err != nil {
+		log.Error(err, "Failed to delete bootstrapped BMC credentials from the registry")
+		return true, nil
+	}
+	_ = deleteResp.Body.Close()
+	if deleteResp.StatusCode != http.StatusNoContent {
+		log.Info("Failed to delete bootstrapped BMC credentials from the registry", "Status", deleteResp.Status)
+	}
+	return true, nil
+}
+
+// registryRequest sends a request authenticated with the RegistryToken to the registry.
+func (r *BMCReconciler) registryRequest(ctx context.Context, method, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create registry request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+r.RegistryToken)
+	return http.DefaultClient.Do(req)
+}
+
 func (r *BMCReconciler) discoverServers(ctx context.Context, log logr.Logger, bmcObj *metalv1alpha1.BMC) error {
 	bmcClient, err := bmcutils.GetBMCClientFromBMC(ctx, r.Client, bmcObj, r.Insecure, r.BMCPollingOptions)
 	if err != nil {
@@ -160,7 +530,19 @@ func (r *BMCReconciler) discoverServers(ctx context.Context, log logr.Logger, bm
 	if err != nil {
 		return fmt.Errorf("failed to get Servers from BMC: %w", err)
 	}
+	composedUUIDs, err := r.getComposedSystemUUIDs(ctx, bmcObj)
+	if err != nil {
+		return err
+	}
 	for i, s := range servers {
+		if _, ok := composedUUIDs[strings.ToLower(s.UUID)]; ok {
+			continue
+		}
+		if !s.IsPhysical() {
+			// systems of DPUs and SmartNICs are no servers of their own
+			log.V(1).Info("Skipped non-physical system", "UUID", s.UUID, "SystemType", s.SystemType)
+			continue
+		}
 		server := &metalv1alpha1.Server{}
 		server.Name = bmcutils.GetServerNameFromBMCandIndex(i, bmcObj)
 
@@ -168,6 +550,7 @@ func (r *BMCReconciler) discoverServers(ctx context.Context, log logr.Logger, bm
 			metautils.SetLabels(server, bmcObj.Labels)
 			server.Spec.UUID = strings.ToLower(s.UUID)
 			server.Spec.SystemUUID = strings.ToLower(s.UUID)
+			server.Spec.SystemURI = s.URI
 			server.Spec.BMCRef = &v1.LocalObjectReference{Name: bmcObj.Name}
 			return controllerutil.SetControllerReference(bmcObj, server, r.Scheme)
 		})
@@ -180,11 +563,48 @@ func (r *BMCReconciler) discoverServers(ctx context.Context, log logr.Logger, bm
 	return nil
 }
 
+// getComposedSystemUUIDs returns the UUIDs of the systems of the BMC which are represented by the Server of a
+// ComposedServer.
+func (r *BMCReconciler) getComposedSystemUUIDs(ctx context.Context, bmcObj *metalv1alpha1.BMC) (map[string]struct{}, error) {
+	composedServers := &metalv1alpha1.ComposedServerList{}
+	if err := r.List(ctx, composedServers); err != nil {
+		return nil, fmt.Errorf("failed to list ComposedServers: %w", err)
+	}
+	uuids := map[string]struct{}{}
+	for _, composedServer := range composedServers.Items {
+		if composedServer.Spec.BMCRef.Name == bmcObj.Name && composedServer.Status.SystemUUID != "" {
+			uuids[composedServer.Status.SystemUUID] = struct{}{}
+		}
+	}
+	return uuids, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *BMCReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&metalv1alpha1.BMC{}).
 		Owns(&metalv1alpha1.Server{}).
-		// TODO: add watches for Endpoints and BMCSecrets
+		Watches(&metalv1alpha1.BMCSecret{}, r.enqueueBMCsByBMCSecret()).
+		// TODO: add watches for Endpoints
 		Complete(r)
 }
+
+// enqueueBMCsByBMCSecret enqueues the BMCs using a BMCSecret, so that rotated credentials are picked up.
+func (r *BMCReconciler) enqueueBMCsByBMCSecret() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
+		log := ctrl.LoggerFrom(ctx)
+
+		bmcList := &metalv1alpha1.BMCList{}
+		if err := r.List(ctx, bmcList); err != nil {
+			log.Error(err, "failed to list BMCs")
+			return nil
+		}
+		var req []reconcile.Request
+		for _, bmcObj := range bmcList.Items {
+			if bmcObj.Spec.BMCSecretRef.Name == object.GetName() {
+				req = append(req, reconcile.Request{NamespacedName: types.NamespacedName{Name: bmcObj.Name}})
+			}
+		}
+		return req
+	})
+}
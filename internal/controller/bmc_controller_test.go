@@ -4,12 +4,16 @@
 package controller
 
 import (
+	"errors"
+	"time"
+
 	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
 	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
@@ -182,7 +186,7 @@ var _ = Describe("BMC Validation", func() {
 			},
 		}
 		Expect(k8sClient.Create(ctx, bmc)).To(HaveOccurred())
-		Eventually(Get(bmc)).Should(Satisfy(errors.IsNotFound))
+		Eventually(Get(bmc)).Should(Satisfy(apierrors.IsNotFound))
 	})
 
 	It("Should deny if the BMC has no EndpointRef and InlineEndpoint spec fields", func(ctx SpecContext) {
@@ -193,7 +197,7 @@ var _ = Describe("BMC Validation", func() {
 			Spec: metalv1alpha1.BMCSpec{},
 		}
 		Expect(k8sClient.Create(ctx, bmc)).To(HaveOccurred())
-		Eventually(Get(bmc)).Should(Satisfy(errors.IsNotFound))
+		Eventually(Get(bmc)).Should(Satisfy(apierrors.IsNotFound))
 	})
 
 	It("Should admit if the BMC has an EndpointRef but no InlineEndpoint spec field", func(ctx SpecContext) {
@@ -313,3 +317,113 @@ var _ = Describe("BMC Validation", func() {
 	})
 
 })
+
+var _ = Describe("BMC Reset", func() {
+	_ = SetupTest()
+
+	var (
+		simulator  *bmc.Simulator
+		bmcObj     *metalv1alpha1.BMC
+		reconciler *BMCReconciler
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		simulator = bmc.NewSimulator()
+		bmc.Simulators.Register("10.30.0.6:8000", simulator)
+
+		bmcSecret := &metalv1alpha1.BMCSecret{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Data: map[string][]byte{
+				metalv1alpha1.BMCSecretUsernameKeyName: []byte("foo"),
+				metalv1alpha1.BMCSecretPasswordKeyName: []byte("bar"),
+			},
+		}
+		Expect(k8sClient.Create(ctx, bmcSecret)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, bmcSecret)
+
+		By("Creating a paused BMC, so that the reset is only handled by the test")
+		bmcObj = &metalv1alpha1.BMC{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Annotations: map[string]string{
+					metalv1alpha1.PausedUntilAnnotation: time.Now().Add(time.Hour).Format(time.RFC3339),
+					metalv1alpha1.OperationAnnotation:   metalv1alpha1.OperationAnnotationGracefulRestartBMC,
+				},
+			},
+			Spec: metalv1alpha1.BMCSpec{
+				Endpoint: &metalv1alpha1.InlineEndpoint{
+					IP:         metalv1alpha1.MustParseIP("10.30.0.6"),
+					MACAddress: "23:11:8A:33:CF:EB",
+				},
+				Protocol: metalv1alpha1.Protocol{
+					Name: metalv1alpha1.ProtocolRedfishFake,
+					Port: 8000,
+				},
+				BMCSecretRef: v1.LocalObjectReference{
+					Name: bmcSecret.Name,
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, bmcObj)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, bmcObj)
+
+		reconciler = &BMCReconciler{
+			Client:           k8sClient,
+			Scheme:           k8sClient.Scheme(),
+			Insecure:         true,
+			BMCResetWaitTime: time.Millisecond,
+		}
+	})
+
+	It("Should complete the reset once the BMC is reachable again", func(ctx SpecContext) {
+		requeueAfter, err := reconciler.handleReset(ctx, GinkgoLogr, bmcObj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requeueAfter).To(BeNumerically(">", 0))
+		Expect(simulator.State().ManagerResets).To(Equal(1))
+		Expect(Object(bmcObj)()).To(SatisfyAll(
+			HaveField("ObjectMeta.Annotations", Not(HaveKey(metalv1alpha1.OperationAnnotation))),
+			HaveField("Status.Conditions", ContainElement(SatisfyAll(
+				HaveField("Type", BMCConditionReset),
+				HaveField("Status", metav1.ConditionTrue),
+				HaveField("Reason", bmcResetReasonIssued),
+			))),
+		))
+
+		Eventually(func(g Gomega) {
+			requeueAfter, err := reconciler.handleReset(ctx, GinkgoLogr, bmcObj)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(requeueAfter).To(BeZero())
+		}).Should(Succeed())
+		Expect(Object(bmcObj)()).To(HaveField("Status.Conditions", ContainElement(SatisfyAll(
+			HaveField("Type", BMCConditionReset),
+			HaveField("Status", metav1.ConditionFalse),
+			HaveField("Reason", bmcResetReasonCompleted),
+		))))
+		Expect(simulator.State().ManagerResets).To(Equal(1))
+	})
+
+	It("Should fail the reset if the BMC is not reachable again in time", func(ctx SpecContext) {
+		reconciler.BMCResetTimeout = time.Millisecond
+		_, err := reconciler.handleReset(ctx, GinkgoLogr, bmcObj)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Simulating a BMC which does not come back after the reset")
+		simulator.SetFailure("GetManager", errors.New("connection refused"))
+
+		Eventually(func(g Gomega) {
+			requeueAfter, err := reconciler.handleReset(ctx, GinkgoLogr, bmcObj)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(requeueAfter).To(BeZero())
+		}).Should(Succeed())
+		Expect(Object(bmcObj)()).To(HaveField("Status.Conditions", ContainElement(SatisfyAll(
+			HaveField("Type", BMCConditionReset),
+			HaveField("Status", metav1.ConditionFalse),
+			HaveField("Reason", bmcResetReasonFailed),
+			HaveField("Message", ContainSubstring("connection refused")),
+		))))
+		Expect(isBMCResetInProgress(bmcObj)).To(BeFalse())
+		Expect(simulator.State().ManagerResets).To(Equal(1))
+	})
+})
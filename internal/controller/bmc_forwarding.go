@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+)
+
+const (
+	// BMCConditionForwardingConfigured reports whether the forwarding of hardware alerts in the spec of the BMC has
+	// been configured on the BMC. Its observed generation tells which spec has been configured.
+	BMCConditionForwardingConfigured = "ForwardingConfigured"
+
+	bmcForwardingReasonConfigured = "Configured"
+	bmcForwardingReasonFailed     = "ConfigurationFailed"
+)
+
+// forwardingPending reports whether the forwarding in the spec of the BMC has not been configured on the BMC yet.
+func forwardingPending(bmcObj *metalv1alpha1.BMC) bool {
+	if bmcObj.Spec.Forwarding == nil {
+		return false
+	}
+	cond := meta.FindStatusCondition(bmcObj.Status.Conditions, BMCConditionForwardingConfigured)
+	return cond == nil || cond.Status != metav1.ConditionTrue || cond.ObservedGeneration != bmcObj.Generation
+}
+
+// reconcileForwarding configures the SNMP trap destinations and remote syslog targets in the spec of the BMC on
+// the BMC. The configuration is applied once per generation of the BMC and retried until the BMC accepts it.
+func (r *BMCReconciler) reconcileForwarding(ctx context.Context, log logr.Logger, bmcObj *metalv1alpha1.BMC) error {
+	if !forwardingPending(bmcObj) {
+		return nil
+	}
+	bmcClient, err := bmcutils.GetBMCClientFromBMC(ctx, r.Client, bmcObj, r.Insecure, r.BMCPollingOptions)
+	if err != nil {
+		return fmt.Errorf("failed to create BMC client: %w", err)
+	}
+	defer bmcClient.Logout()
+
+	forwardingErr := bmcClient.SetForwarding(ctx, forwardingConfig(bmcObj.Spec.Forwarding))
+	condition := metav1.Condition{
+		Type:               BMCConditionForwardingConfigured,
+		Status:             metav1.ConditionTrue,
+		Reason:             bmcForwardingReasonConfigured,
+		Message:            "Forwarding of hardware alerts has been configured on the BMC",
+		ObservedGeneration: bmcObj.Generation,
+	}
+	if forwardingErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = bmcForwardingReasonFailed
+		condition.Message = forwardingErr.Error()
+	}
+	bmcBase := bmcObj.DeepCopy()
+	meta.SetStatusCondition(&bmcObj.Status.Conditions, condition)
+	if err := r.Status().Patch(ctx, bmcObj, client.MergeFrom(bmcBase)); err != nil {
+		return fmt.Errorf("failed to patch ForwardingConfigured condition: %w", err)
+	}
+	if forwardingErr != nil {
+		return fmt.Errorf("failed to configure forwarding: %w", forwardingErr)
+	}
+	log.V(1).Info("Configured forwarding of hardware alerts",
+		"SNMPTrapDestinations", len(bmcObj.Spec.Forwarding.SNMPTrapDestinations),
+		"SyslogTargets", len(bmcObj.Spec.Forwarding.SyslogTargets))
+	return nil
+}
+
+func forwardingConfig(forwarding *metalv1alpha1.BMCForwarding) bmc.ForwardingConfig {
+	config := bmc.ForwardingConfig{}
+	for _, destination := range forwarding.SNMPTrapDestinations {
+		config.SNMPTrapDestinations = append(config.SNMPTrapDestinations, bmc.SNMPTrapDestination{
+			Address:   destination.Address,
+			Port:      destination.Port,
+			Version:   bmc.SNMPVersion(destination.Version),
+			Community: destination.Community,
+		})
+	}
+	for _, target := range forwarding.SyslogTargets {
+		config.SyslogTargets = append(config.SyslogTargets, bmc.SyslogTarget{
+			Address:  target.Address,
+			Port:     target.Port,
+			Protocol: bmc.SyslogProtocol(target.Protocol),
+		})
+	}
+	return config
+}
@@ -4,46 +4,188 @@
 package controller
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
 
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
 )
 
+// CredentialRotationHook is notified whenever the credentials of a BMCSecret change.
+type CredentialRotationHook interface {
+	// CredentialsRotated is called with the rotated BMCSecret and the names of the BMCs using it. Returning an
+	// error retries the notification.
+	CredentialsRotated(ctx context.Context, bmcSecret *metalv1alpha1.BMCSecret, bmcNames []string) error
+}
+
+// CredentialRotation is the payload posted by a CredentialRotationWebhook. It never contains the credentials.
+type CredentialRotation struct {
+	// BMCSecret is the name of the rotated BMCSecret.
+	BMCSecret string `json:"bmcSecret"`
+	// BMCs are the names of the BMCs using the BMCSecret.
+	BMCs []string `json:"bmcs"`
+}
+
+// CredentialRotationWebhook is a CredentialRotationHook posting a CredentialRotation to a URL.
+type CredentialRotationWebhook struct {
+	URL    string
+	Client *http.Client
+}
+
+// CredentialsRotated implements CredentialRotationHook.
+func (w *CredentialRotationWebhook) CredentialsRotated(ctx context.Context, bmcSecret *metalv1alpha1.BMCSecret, bmcNames []string) error {
+	body, err := json.Marshal(CredentialRotation{BMCSecret: bmcSecret.Name, BMCs: bmcNames})
+	if err != nil {
+		return fmt.Errorf("failed to marshal credential rotation: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create credential rotation request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	c := w.Client
+	if c == nil {
+		c = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send credential rotation: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("credential rotation webhook responded with status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
 // BMCSecretReconciler reconciles a BMCSecret object
 type BMCSecretReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// RotationHooks are notified whenever the credentials of a BMCSecret change.
+	RotationHooks []CredentialRotationHook
 }
 
 //+kubebuilder:rbac:groups=metal.ironcore.dev,resources=bmcsecrets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=metal.ironcore.dev,resources=bmcsecrets/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=metal.ironcore.dev,resources=bmcsecrets/finalizers,verbs=update
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=bmcs,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;patch
 
-// Reconcile is part of the main kubernetes reconciliation loop which aims to
-// move the current state of the cluster closer to the desired state.
-// TODO(user): Modify the Reconcile function to compare the state specified by
-// the BMCSecret object against the actual cluster state, and then
-// perform operations to make the cluster state reflect the state specified by
-// the user.
-//
-// For more details, check Reconcile and its Result here:
-// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.17.0/pkg/reconcile
+// Reconcile tracks the credentials of a BMCSecret in the CredentialsHashAnnotation and notifies the rotation hooks
+// whenever they change. The change of the annotation re-reconciles the BMCs using the BMCSecret.
 func (r *BMCSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	_ = log.FromContext(ctx)
+	log := ctrl.LoggerFrom(ctx)
+	bmcSecret := &metalv1alpha1.BMCSecret{}
+	if err := r.Get(ctx, req.NamespacedName, bmcSecret); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !bmcSecret.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
 
-	// TODO(user): your logic here
+	resolved, err := bmcutils.ResolveBMCSecret(ctx, r.Client, bmcSecret)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	hash := credentialsHash(resolved.Data)
+	previous, tracked := bmcSecret.Annotations[metalv1alpha1.CredentialsHashAnnotation]
+	if previous == hash {
+		return ctrl.Result{}, nil
+	}
 
+	if tracked {
+		bmcNames, err := r.bmcNamesForBMCSecret(ctx, bmcSecret.Name)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		log.V(1).Info("Credentials of BMCSecret rotated", "BMCs", bmcNames)
+		for _, hook := range r.RotationHooks {
+			if err := hook.CredentialsRotated(ctx, bmcSecret, bmcNames); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to notify credential rotation: %w", err)
+			}
+		}
+	}
+
+	bmcSecretBase := bmcSecret.DeepCopy()
+	if bmcSecret.Annotations == nil {
+		bmcSecret.Annotations = map[string]string{}
+	}
+	bmcSecret.Annotations[metalv1alpha1.CredentialsHashAnnotation] = hash
+	if err := r.Patch(ctx, bmcSecret, client.MergeFrom(bmcSecretBase)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to patch credentials hash of BMCSecret: %w", err)
+	}
 	return ctrl.Result{}, nil
 }
 
+// credentialsHash returns the hash of the given credential data.
+func credentialsHash(data map[string][]byte) string {
+	hash := sha256.New()
+	for _, key := range slices.Sorted(maps.Keys(data)) {
+		hash.Write([]byte(key))
+		hash.Write([]byte{0})
+		hash.Write(data[key])
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (r *BMCSecretReconciler) bmcNamesForBMCSecret(ctx context.Context, name string) ([]string, error) {
+	bmcList := &metalv1alpha1.BMCList{}
+	if err := r.List(ctx, bmcList); err != nil {
+		return nil, fmt.Errorf("failed to list BMCs: %w", err)
+	}
+	var names []string
+	for _, bmcObj := range bmcList.Items {
+		if bmcObj.Spec.BMCSecretRef.Name == name {
+			names = append(names, bmcObj.Name)
+		}
+	}
+	return names, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *BMCSecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&metalv1alpha1.BMCSecret{}).
+		Watches(&v1.Secret{}, r.enqueueBMCSecretsBySecret()).
 		Complete(r)
 }
+
+func (r *BMCSecretReconciler) enqueueBMCSecretsBySecret() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
+		log := ctrl.LoggerFrom(ctx)
+
+		bmcSecretList := &metalv1alpha1.BMCSecretList{}
+		if err := r.List(ctx, bmcSecretList); err != nil {
+			log.Error(err, "failed to list BMCSecrets")
+			return nil
+		}
+		var req []reconcile.Request
+		for _, bmcSecret := range bmcSecretList.Items {
+			ref := bmcSecret.SecretRef
+			if ref != nil && ref.Namespace == object.GetNamespace() && ref.Name == object.GetName() {
+				req = append(req, reconcile.Request{NamespacedName: types.NamespacedName{Name: bmcSecret.Name}})
+			}
+		}
+		return req
+	})
+}
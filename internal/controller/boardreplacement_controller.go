@@ -0,0 +1,381 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// BoardReplacementConditionBoardVerified reports whether the BMC of the new board is reachable and reports the
+	// expected system.
+	BoardReplacementConditionBoardVerified = "BoardVerified"
+
+	boardReplacementReasonVerified    = "BoardVerified"
+	boardReplacementReasonUnreachable = "BMCUnreachable"
+	boardReplacementReasonMismatch    = "BoardMismatch"
+)
+
+// BoardReplacementReconciler reconciles a BoardReplacement object
+type BoardReplacementReconciler struct {
+	client.Client
+	Scheme         *runtime.Scheme
+	Insecure       bool
+	BMCOptions     bmc.BMCOptions
+	ResyncInterval time.Duration
+}
+
+// boardBMC describes how the BMC of a Server is accessed and which objects hold its address.
+type boardBMC struct {
+	protocol               metalv1alpha1.Protocol
+	secretName             string
+	certificateFingerprint string
+	address                string
+	macAddress             string
+	// endpoint is the Endpoint of the BMC, if the BMC references one.
+	endpoint *metalv1alpha1.Endpoint
+	// timeouts are the operation timeouts configured on the BMC, if any.
+	timeouts *metalv1alpha1.BMCTimeouts
+}
+
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=boardreplacements,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=boardreplacements/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=servers,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=bmcs,verbs=get;list;watch
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=bmcsecrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=endpoints,verbs=get;list;watch;update;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *BoardReplacementReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	replacement := &metalv1alpha1.BoardReplacement{}
+	if err := r.Get(ctx, req.NamespacedName, replacement); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	return r.reconcileExists(ctx, log, replacement)
+}
+
+func (r *BoardReplacementReconciler) reconcileExists(ctx context.Context, log logr.Logger, replacement *metalv1alpha1.BoardReplacement) (ctrl.Result, error) {
+	if !replacement.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	return r.reconcile(ctx, log, replacement)
+}
+
+func (r *BoardReplacementReconciler) reconcile(ctx context.Context, log logr.Logger, replacement *metalv1alpha1.BoardReplacement) (ctrl.Result, error) {
+	if paused, remaining := shouldIgnoreReconciliation(replacement); paused {
+		log.V(1).Info("Skipped BoardReplacement reconciliation")
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	switch replacement.Status.State {
+	case metalv1alpha1.BoardReplacementStateCompleted, metalv1alpha1.BoardReplacementStateFailed:
+		log.V(1).Info("BoardReplacement already finished", "State", replacement.Status.State)
+		return ctrl.Result{}, nil
+	}
+
+	replacementBase := replacement.DeepCopy()
+	server := &metalv1alpha1.Server{}
+	if err := r.Get(ctx, client.ObjectKey{Name: replacement.Spec.ServerRef.Name}, server); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("failed to get server %s: %w", replacement.Spec.ServerRef.Name, err)
+		}
+		return ctrl.Result{}, r.fail(ctx, replacement, replacementBase, fmt.Sprintf("Server %s not found", replacement.Spec.ServerRef.Name))
+	}
+	access, message, err := r.getBoardBMC(ctx, server, replacement)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if message != "" {
+		return ctrl.Result{}, r.fail(ctx, replacement, replacementBase, message)
+	}
+
+	switch replacement.Status.State {
+	case "", metalv1alpha1.BoardReplacementStatePending:
+		replacement.Status.State = metalv1alpha1.BoardReplacementStateVerifying
+		replacement.Status.OldSystemUUID = server.Spec.SystemUUID
+		replacement.Status.OldBMCMACAddress = access.macAddress
+		replacement.Status.OldBMCAddress = access.address
+		replacement.Status.Message = "Waiting for the BMC of the new board to report its system"
+		log.V(1).Info("Started board replacement", "SystemUUID", server.Spec.SystemUUID)
+		return ctrl.Result{Requeue: true}, r.patchStatus(ctx, replacement, replacementBase)
+
+	case metalv1alpha1.BoardReplacementStateVerifying:
+		system, condition, err := r.verifyBoard(ctx, replacement, server, access)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		meta.SetStatusCondition(&replacement.Status.Conditions, condition)
+		if condition.Status != metav1.ConditionTrue {
+			replacement.Status.Message = condition.Message
+			return ctrl.Result{RequeueAfter: r.ResyncInterval}, r.patchStatus(ctx, replacement, replacementBase)
+		}
+		if err := r.applyBoard(ctx, replacement, server, access, system); err != nil {
+			return ctrl.Result{}, err
+		}
+		replacement.Status.State = metalv1alpha1.BoardReplacementStateCompleted
+		replacement.Status.NewSystemUUID = system.UUID
+		replacement.Status.Message = fmt.Sprintf("Server %s has been moved to the new board", server.Name)
+		log.V(1).Info("Completed board replacement", "OldSystemUUID", replacement.Status.OldSystemUUID, "SystemUUID", system.UUID)
+		return ctrl.Result{}, r.patchStatus(ctx, replacement, replacementBase)
+	}
+	return ctrl.Result{}, nil
+}
+
+// getBoardBMC returns the access of the BMC of the Server. A message is returned instead if the BMC cannot be
+// updated as requested by the replacement.
+func (r *BoardReplacementReconciler) getBoardBMC(ctx context.Context, server *metalv1alpha1.Server, replacement *metalv1alpha1.BoardReplacement) (*boardBMC, string, error) {
+	if server.Spec.BMCRef != nil {
+		bmcObj := &metalv1alpha1.BMC{}
+		if err := r.Get(ctx, client.ObjectKey{Name: server.Spec.BMCRef.Name}, bmcObj); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, fmt.Sprintf("BMC %s not found", server.Spec.BMCRef.Name), nil
+			}
+			return nil, "", fmt.Errorf("failed to get BMC %s: %w", server.Spec.BMCRef.Name, err)
+		}
+		access := &boardBMC{
+			protocol:               bmcObj.Spec.Protocol,
+			secretName:             bmcObj.Spec.BMCSecretRef.Name,
+			certificateFingerprint: bmcObj.Spec.CertificateFingerprint,
+			timeouts:               bmcObj.Spec.Timeouts,
+		}
+		if bmcObj.Spec.EndpointRef == nil {
+			if replacement.Spec.BMCAddress != "" || replacement.Spec.BMCMACAddress != "" {
+				return nil, fmt.Sprintf("The access of BMC %s is immutable, it has to reference an Endpoint to change its address", bmcObj.Name), nil
+			}
+			access.address = bmcObj.Spec.Endpoint.IP.String()
+			access.macAddress = bmcObj.Spec.Endpoint.MACAddress
+			return access, "", nil
+		}
+		endpoint := &metalv1alpha1.Endpoint{}
+		if err := r.Get(ctx, client.ObjectKey{Name: bmcObj.Spec.EndpointRef.Name}, endpoint); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, fmt.Sprintf("Endpoint %s of BMC %s not found", bmcObj.Spec.EndpointRef.Name, bmcObj.Name), nil
+			}
+			return nil, "", fmt.Errorf("failed to get Endpoint %s: %w", bmcObj.Spec.EndpointRef.Name, err)
+		}
+		access.address = endpoint.Spec.IP.String()
+		access.macAddress = endpoint.Spec.MACAddress
+		access.endpoint = endpoint
+		return access, "", nil
+	}
+
+	if server.Spec.BMC != nil {
+		if replacement.Spec.BMCMACAddress != "" {
+			return nil, fmt.Sprintf("Server %s does not track the MAC address of its BMC", server.Name), nil
+		}
+		return &boardBMC{
+			protocol:               server.Spec.BMC.Protocol,
+			secretName:             server.Spec.BMC.BMCSecretRef.Name,
+			certificateFingerprint: server.Spec.BMC.CertificateFingerprint,
+			address:                server.Spec.BMC.Address,
+		}, "", nil
+	}
+
+	return nil, fmt.Sprintf("Server %s has neither a BMCRef nor a BMC configured", server.Name), nil
+}
+
+// verifyBoard connects to the BMC of the new board and returns the system the Server is moved to. The returned
+// condition is not true as long as the BMC is unreachable or does not report the expected system, so that the
+// replacement waits for the board to be installed.
+func (r *BoardReplacementReconciler) verifyBoard(ctx context.Context, replacement *metalv1alpha1.BoardReplacement, server *metalv1alpha1.Server, access *boardBMC) (bmc.Server, metav1.Condition, error) {
+	condition := metav1.Condition{
+		Type:   BoardReplacementConditionBoardVerified,
+		Status: metav1.ConditionFalse,
+	}
+	address := access.address
+	if replacement.Spec.BMCAddress != "" {
+		address = replacement.Spec.BMCAddress
+	}
+
+	bmcSecret := &metalv1alpha1.BMCSecret{}
+	if err := r.Get(ctx, client.ObjectKey{Name: access.secretName}, bmcSecret); err != nil {
+		return bmc.Server{}, condition, fmt.Errorf("failed to get BMC secret: %w", err)
+	}
+	options := r.BMCOptions
+	options.CertificateFingerprint = access.certificateFingerprint
+	bmcutils.ApplyBMCTimeouts(&options.Timeouts, access.timeouts)
+	bmcClient, err := bmcutils.CreateBMCClient(ctx, r.Client, r.Insecure, access.protocol, address, bmcSecret, options)
+	if err != nil {
+		condition.Reason = boardReplacementReasonUnreachable
+		condition.Message = fmt.Sprintf("Failed to connect to the BMC at %s: %v", address, err)
+		return bmc.Server{}, condition, nil
+	}
+	defer bmcClient.Logout()
+
+	systems, err := bmcClient.GetSystems(ctx)
+	if err != nil {
+		condition.Reason = boardReplacementReasonUnreachable
+		condition.Message = fmt.Sprintf("Failed to get the systems of the BMC at %s: %v", address, err)
+		return bmc.Server{}, condition, nil
+	}
+	system, found := findBoardSystem(systems, replacement.Spec.SystemUUID)
+	if !found {
+		condition.Reason = boardReplacementReasonMismatch
+		condition.Message = fmt.Sprintf("The BMC at %s does not report a single system", address)
+		if replacement.Spec.SystemUUID != "" {
+			condition.Message = fmt.Sprintf("The BMC at %s does not report a system with UUID %s", address, replacement.Spec.SystemUUID)
+		}
+		return bmc.Server{}, condition, nil
+	}
+
+	if replacement.Spec.BMCMACAddress != "" {
+		manager, err := bmcClient.GetManager()
+		if err != nil {
+			condition.Reason = boardReplacementReasonUnreachable
+			condition.Message = fmt.Sprintf("Failed to get the manager of the BMC at %s: %v", address, err)
+			return bmc.Server{}, condition, nil
+		}
+		if manager.MACAddress != "" && normalizeMACAddress(manager.MACAddress) != normalizeMACAddress(replacement.Spec.BMCMACAddress) {
+			condition.Reason = boardReplacementReasonMismatch
+			condition.Message = fmt.Sprintf("The BMC at %s reports the MAC address %s instead of %s", address, manager.MACAddress, replacement.Spec.BMCMACAddress)
+			return bmc.Server{}, condition, nil
+		}
+	}
+
+	servers := &metalv1alpha1.ServerList{}
+	if err := r.List(ctx, servers); err != nil {
+		return bmc.Server{}, condition, fmt.Errorf("failed to list servers: %w", err)
+	}
+	for _, other := range servers.Items {
+		if other.Name != server.Name && strings.EqualFold(other.Spec.SystemUUID, system.UUID) {
+			condition.Reason = boardReplacementReasonMismatch
+			condition.Message = fmt.Sprintf("The system UUID %s of the new board is used by server %s", system.UUID, other.Name)
+			return bmc.Server{}, condition, nil
+		}
+	}
+
+	condition.Status = metav1.ConditionTrue
+	condition.Reason = boardReplacementReasonVerified
+	condition.Message = fmt.Sprintf("The BMC at %s reports the system %s", address, system.UUID)
+	return system, condition, nil
+}
+
+// applyBoard moves the Server to the verified system of the new board. The Server is updated in a single patch,
+// including the address of an inline BMC, before the Endpoint of the BMC is updated. Both updates are idempotent,
+// so that a failed replacement is completed by the next reconciliation.
+func (r *BoardReplacementReconciler) applyBoard(ctx context.Context, replacement *metalv1alpha1.BoardReplacement, server *metalv1alpha1.Server, access *boardBMC, system bmc.Server) error {
+	serverBase := server.DeepCopy()
+	if strings.EqualFold(server.Spec.UUID, replacement.Status.OldSystemUUID) {
+		server.Spec.UUID = system.UUID
+	}
+	server.Spec.SystemUUID = system.UUID
+	if server.Spec.SystemURI != "" {
+		server.Spec.SystemURI = system.URI
+	}
+	if server.Spec.BMC != nil && replacement.Spec.BMCAddress != "" {
+		server.Spec.BMC.Address = replacement.Spec.BMCAddress
+	}
+	if err := r.Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return fmt.Errorf("failed to patch server: %w", err)
+	}
+
+	if access.endpoint == nil || (replacement.Spec.BMCAddress == "" && replacement.Spec.BMCMACAddress == "") {
+		return nil
+	}
+	endpointBase := access.endpoint.DeepCopy()
+	if replacement.Spec.BMCAddress != "" {
+		ip, err := metalv1alpha1.ParseIP(replacement.Spec.BMCAddress)
+		if err != nil {
+			return fmt.Errorf("failed to parse BMC address: %w", err)
+		}
+		access.endpoint.Spec.IP = ip
+	}
+	if replacement.Spec.BMCMACAddress != "" {
+		access.endpoint.Spec.MACAddress = replacement.Spec.BMCMACAddress
+	}
+	if err := r.Patch(ctx, access.endpoint, client.MergeFrom(endpointBase)); err != nil {
+		return fmt.Errorf("failed to patch endpoint: %w", err)
+	}
+	return nil
+}
+
+// findBoardSystem returns the system with the UUID, or the only system if no UUID is given.
+func findBoardSystem(systems []bmc.Server, systemUUID string) (bmc.Server, bool) {
+	if systemUUID == "" {
+		if len(systems) != 1 {
+			return bmc.Server{}, false
+		}
+		return systems[0], true
+	}
+	for _, system := range systems {
+		if strings.EqualFold(system.UUID, systemUUID) {
+			return system, true
+		}
+	}
+	return bmc.Server{}, false
+}
+
+func normalizeMACAddress(macAddress string) string {
+	return strings.ToLower(strings.ReplaceAll(macAddress, "-", ":"))
+}
+
+func (r *BoardReplacementReconciler) fail(ctx context.Context, replacement, replacementBase *metalv1alpha1.BoardReplacement, message string) error {
+	replacement.Status.State = metalv1alpha1.BoardReplacementStateFailed
+	replacement.Status.Message = message
+	return r.patchStatus(ctx, replacement, replacementBase)
+}
+
+func (r *BoardReplacementReconciler) patchStatus(ctx context.Context, replacement, replacementBase *metalv1alpha1.BoardReplacement) error {
+	switch replacement.Status.State {
+	case metalv1alpha1.BoardReplacementStateCompleted, metalv1alpha1.BoardReplacementStateFailed:
+		if replacement.Status.CompletionTime == nil {
+			now := metav1.Now()
+			replacement.Status.CompletionTime = &now
+		}
+	}
+	if err := r.Status().Patch(ctx, replacement, client.MergeFrom(replacementBase)); err != nil {
+		return fmt.Errorf("failed to patch BoardReplacement status: %w", err)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BoardReplacementReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&metalv1alpha1.BoardReplacement{}).
+		Watches(&metalv1alpha1.Server{}, r.enqueueBoardReplacementsByServerRefs()).
+		Complete(r)
+}
+
+func (r *BoardReplacementReconciler) enqueueBoardReplacementsByServerRefs() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
+		log := ctrl.LoggerFrom(ctx)
+
+		server := object.(*metalv1alpha1.Server)
+		replacementList := &metalv1alpha1.BoardReplacementList{}
+		if err := r.List(ctx, replacementList); err != nil {
+			log.Error(err, "failed to list BoardReplacements")
+			return nil
+		}
+		var req []reconcile.Request
+		for _, replacement := range replacementList.Items {
+			if replacement.Spec.ServerRef.Name == server.Name {
+				req = append(req, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: replacement.Name},
+				})
+			}
+		}
+		return req
+	})
+}
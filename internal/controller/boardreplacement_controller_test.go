@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+)
+
+var _ = Describe("BoardReplacement Controller", func() {
+	_ = SetupTest()
+
+	var bmcSecret *metalv1alpha1.BMCSecret
+
+	BeforeEach(func(ctx SpecContext) {
+		By("Creating a BMCSecret")
+		bmcSecret = &metalv1alpha1.BMCSecret{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Data: map[string][]byte{
+				metalv1alpha1.BMCSecretUsernameKeyName: []byte("foo"),
+				metalv1alpha1.BMCSecretPasswordKeyName: []byte("bar"),
+			},
+		}
+		Expect(k8sClient.Create(ctx, bmcSecret)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, bmcSecret)
+	})
+
+	createServer := func(ctx SpecContext, address, systemUUID string) *metalv1alpha1.Server {
+		server := &metalv1alpha1.Server{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Annotations: map[string]string{
+					metalv1alpha1.OperationAnnotation: metalv1alpha1.OperationAnnotationIgnore,
+				},
+			},
+			Spec: metalv1alpha1.ServerSpec{
+				UUID:       systemUUID,
+				SystemUUID: systemUUID,
+				BMC: &metalv1alpha1.BMCAccess{
+					Protocol: metalv1alpha1.Protocol{
+						Name: metalv1alpha1.ProtocolRedfishFake,
+						Port: 8000,
+					},
+					Address: address,
+					BMCSecretRef: v1.LocalObjectReference{
+						Name: bmcSecret.Name,
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, server)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, server)
+		return server
+	}
+
+	It("should fail the replacement of the board of an unknown server", func(ctx SpecContext) {
+		By("Creating a BoardReplacement object")
+		replacement := &metalv1alpha1.BoardReplacement{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.BoardReplacementSpec{
+				ServerRef: v1.LocalObjectReference{Name: "does-not-exist"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, replacement)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, replacement)
+
+		By("Ensuring that the replacement has failed")
+		Eventually(Object(replacement)).Should(SatisfyAll(
+			HaveField("Status.State", metalv1alpha1.BoardReplacementStateFailed),
+			HaveField("Status.Message", ContainSubstring("does-not-exist")),
+			HaveField("Status.CompletionTime", Not(BeNil())),
+		))
+	})
+
+	It("should move a server to the verified system of a new board", func(ctx SpecContext) {
+		By("Registering the simulated BMC of the new board")
+		bmc.Simulators.Register("10.20.0.2:8000", bmc.NewSimulatorFromFixture(bmc.SimulatorFixture{
+			SystemUUID:    "4c4c4544-0051-3810-8057-b5c04f4e4e32",
+			BMCMACAddress: "02:00:00:00:20:02",
+		}))
+
+		By("Creating a Server on the old board")
+		server := createServer(ctx, "10.20.0.1", "4c4c4544-0051-3810-8057-b5c04f4e4e31")
+
+		By("Creating a BoardReplacement object")
+		replacement := &metalv1alpha1.BoardReplacement{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.BoardReplacementSpec{
+				ServerRef:  v1.LocalObjectReference{Name: server.Name},
+				SystemUUID: "4c4c4544-0051-3810-8057-b5c04f4e4e32",
+				BMCAddress: "10.20.0.2",
+			},
+		}
+		Expect(k8sClient.Create(ctx, replacement)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, replacement)
+
+		By("Ensuring that the replacement has completed")
+		Eventually(Object(replacement)).Should(SatisfyAll(
+			HaveField("Status.State", metalv1alpha1.BoardReplacementStateCompleted),
+			HaveField("Status.OldSystemUUID", "4c4c4544-0051-3810-8057-b5c04f4e4e31"),
+			HaveField("Status.OldBMCAddress", "10.20.0.1"),
+			HaveField("Status.NewSystemUUID", "4c4c4544-0051-3810-8057-b5c04f4e4e32"),
+			HaveField("Status.Conditions", ContainElement(SatisfyAll(
+				HaveField("Type", BoardReplacementConditionBoardVerified),
+				HaveField("Status", metav1.ConditionTrue),
+			))),
+		))
+
+		By("Ensuring that the server has been moved to the new board")
+		Eventually(Object(server)).Should(SatisfyAll(
+			HaveField("Spec.SystemUUID", "4c4c4544-0051-3810-8057-b5c04f4e4e32"),
+			HaveField("Spec.UUID", "4c4c4544-0051-3810-8057-b5c04f4e4e32"),
+			HaveField("Spec.BMC.Address", "10.20.0.2"),
+		))
+	})
+
+	It("should wait for the BMC of the new board to report the expected system", func(ctx SpecContext) {
+		By("Creating a Server on the old board")
+		server := createServer(ctx, "10.20.1.1", "4c4c4544-0051-3810-8057-b5c04f4e4e41")
+
+		By("Creating a BoardReplacement object for a system the BMC does not report")
+		replacement := &metalv1alpha1.BoardReplacement{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.BoardReplacementSpec{
+				ServerRef:  v1.LocalObjectReference{Name: server.Name},
+				SystemUUID: "4c4c4544-0051-3810-8057-b5c04f4e4e42",
+			},
+		}
+		Expect(k8sClient.Create(ctx, replacement)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, replacement)
+
+		By("Ensuring that the replacement keeps verifying the board")
+		Eventually(Object(replacement)).Should(SatisfyAll(
+			HaveField("Status.State", metalv1alpha1.BoardReplacementStateVerifying),
+			HaveField("Status.Conditions", ContainElement(SatisfyAll(
+				HaveField("Type", BoardReplacementConditionBoardVerified),
+				HaveField("Status", metav1.ConditionFalse),
+				HaveField("Reason", "BoardMismatch"),
+			))),
+		))
+		Consistently(Object(server)).Should(HaveField("Spec.SystemUUID", "4c4c4544-0051-3810-8057-b5c04f4e4e41"))
+	})
+})
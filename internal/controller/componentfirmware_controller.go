@@ -0,0 +1,412 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+	"github.com/ironcore-dev/metal-operator/internal/firmware"
+	"github.com/stmcginnis/gofish/redfish"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// ComponentFirmwareConditionSkipped is set if components were not selected because their version cannot be
+	// compared with the version constraint of the selector.
+	ComponentFirmwareConditionSkipped = "ComponentsSkipped"
+	// ComponentFirmwareConditionActivated is set once the BMC or the server was restarted to activate the firmware.
+	ComponentFirmwareConditionActivated = "Activated"
+
+	// defaultFirmwareActivationTimeout is the time the components have to run the desired version after their
+	// activation if no ActivationTimeout is configured.
+	defaultFirmwareActivationTimeout = 30 * time.Minute
+)
+
+// ComponentFirmwareReconciler reconciles a ComponentFirmware object
+type ComponentFirmwareReconciler struct {
+	client.Client
+	Scheme         *runtime.Scheme
+	Insecure       bool
+	BMCOptions     bmc.BMCOptions
+	ResyncInterval time.Duration
+	// ActivationTimeout is the time the components have to run the desired version after the BMC or the server
+	// was restarted to activate the firmware.
+	ActivationTimeout time.Duration
+}
+
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=componentfirmwares,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=componentfirmwares/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=componentfirmwares/finalizers,verbs=update
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=drivefirmwares,verbs=get;list;watch
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=servers,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *ComponentFirmwareReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	componentFirmware := &metalv1alpha1.ComponentFirmware{}
+	if err := r.Get(ctx, req.NamespacedName, componentFirmware); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	return r.reconcileExists(ctx, log, componentFirmware)
+}
+
+func (r *ComponentFirmwareReconciler) reconcileExists(ctx context.Context, log logr.Logger, componentFirmware *metalv1alpha1.ComponentFirmware) (ctrl.Result, error) {
+	if !componentFirmware.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	return r.reconcile(ctx, log, componentFirmware)
+}
+
+func (r *ComponentFirmwareReconciler) reconcile(ctx context.Context, log logr.Logger, componentFirmware *metalv1alpha1.ComponentFirmware) (ctrl.Result, error) {
+	if paused, remaining := shouldIgnoreReconciliation(componentFirmware); paused {
+		log.V(1).Info("Skipped ComponentFirmware reconciliation")
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	switch componentFirmware.Status.State {
+	case metalv1alpha1.ComponentFirmwareStateCompleted, metalv1alpha1.ComponentFirmwareStateFailed:
+		log.V(1).Info("ComponentFirmware update already finished", "State", componentFirmware.Status.State)
+		return r.handleFinishedState(ctx, log, componentFirmware)
+	}
+
+	server := &metalv1alpha1.Server{}
+	if err := r.Get(ctx, client.ObjectKey{Name: componentFirmware.Spec.ServerRef.Name}, server); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get server %s: %w", componentFirmware.Spec.ServerRef.Name, err)
+	}
+
+	if componentFirmware.Status.State == "" || componentFirmware.Status.State == metalv1alpha1.ComponentFirmwareStatePending {
+		return r.handlePendingState(ctx, log, componentFirmware, server)
+	}
+	return r.handleInProgressState(ctx, log, componentFirmware, server)
+}
+
+func (r *ComponentFirmwareReconciler) handlePendingState(ctx context.Context, log logr.Logger, componentFirmware *metalv1alpha1.ComponentFirmware, server *metalv1alpha1.Server) (ctrl.Result, error) {
+	componentFirmwareBase := componentFirmware.DeepCopy()
+	componentFirmware.Status.State = metalv1alpha1.ComponentFirmwareStatePending
+	if !isDryRun(componentFirmware) {
+		meta.RemoveStatusCondition(&componentFirmware.Status.Conditions, ConditionDryRun)
+	}
+
+	inUse := firmwareServerInUseCondition(server)
+	meta.SetStatusCondition(&componentFirmware.Status.Conditions, inUse)
+	if inUse.Status == metav1.ConditionTrue {
+		log.V(1).Info("Server is in use, waiting for it to become available", "Reason", inUse.Reason)
+		return ctrl.Result{RequeueAfter: r.ResyncInterval}, r.patchStatus(ctx, componentFirmware, componentFirmwareBase)
+	}
+
+	busy, err := hasFirmwareUpdateInProgress(ctx, r.Client, server.Name, componentFirmware)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if busy {
+		log.V(1).Info("Another firmware update is in progress for server", "Server", server.Name)
+		return ctrl.Result{RequeueAfter: r.ResyncInterval}, r.patchStatus(ctx, componentFirmware, componentFirmwareBase)
+	}
+
+	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.BMCOptions)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to create BMC client: %w", err)
+	}
+	defer bmcClient.Logout()
+
+	inventory, err := bmcClient.GetFirmwareInventory(ctx)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get firmware inventory: %w", err)
+	}
+	components, skipped, err := firmware.SelectComponents(inventory, componentFirmware.Spec.Component)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to select components: %w", err)
+	}
+	if len(skipped) > 0 {
+		names := make([]string, 0, len(skipped))
+		for _, component := range skipped {
+			names = append(names, fmt.Sprintf("%s (%s)", component.Name, component.Version))
+		}
+		log.V(1).Info("Skipped components with unparsable versions", "Components", names)
+		meta.SetStatusCondition(&componentFirmware.Status.Conditions, metav1.Condition{
+			Type:    ComponentFirmwareConditionSkipped,
+			Status:  metav1.ConditionTrue,
+			Reason:  "UnparsableVersion",
+			Message: fmt.Sprintf("Versions of components %v cannot be compared with the constraint %q", names, componentFirmware.Spec.Component.VersionConstraint),
+		})
+	} else {
+		meta.RemoveStatusCondition(&componentFirmware.Status.Conditions, ComponentFirmwareConditionSkipped)
+	}
+	componentFirmware.Status.Components = make([]metalv1alpha1.ComponentFirmwareProgress, 0, len(components))
+	for _, component := range components {
+		state := metalv1alpha1.ComponentFirmwareStatePending
+		if component.Version == componentFirmware.Spec.Version {
+			state = metalv1alpha1.ComponentFirmwareStateCompleted
+		}
+		componentFirmware.Status.Components = append(componentFirmware.Status.Components, metalv1alpha1.ComponentFirmwareProgress{
+			Name:    component.Name,
+			URI:     component.URI,
+			Version: component.Version,
+			State:   state,
+		})
+	}
+	if isDryRun(componentFirmware) {
+		var actions []string
+		for _, component := range componentFirmware.Status.Components {
+			if component.State == metalv1alpha1.ComponentFirmwareStatePending {
+				actions = append(actions, fmt.Sprintf("update %s from %s to %s", component.Name, component.Version, componentFirmware.Spec.Version))
+			}
+		}
+		setDryRunCondition(&componentFirmware.Status.Conditions, componentFirmware.Generation, actions)
+		log.V(1).Info("Planned component firmware update", "Actions", len(actions))
+		return ctrl.Result{RequeueAfter: r.ResyncInterval}, r.patchStatus(ctx, componentFirmware, componentFirmwareBase)
+	}
+	componentFirmware.Status.State = metalv1alpha1.ComponentFirmwareStateInProgress
+	if err := r.patchStatus(ctx, componentFirmware, componentFirmwareBase); err != nil {
+		return ctrl.Result{}, err
+	}
+	log.V(1).Info("Started component firmware update", "Type", componentFirmware.Spec.Component.Type, "Components", len(components))
+	return ctrl.Result{Requeue: true}, nil
+}
+
+func (r *ComponentFirmwareReconciler) handleInProgressState(ctx context.Context, log logr.Logger, componentFirmware *metalv1alpha1.ComponentFirmware, server *metalv1alpha1.Server) (ctrl.Result, error) {
+	componentFirmwareBase := componentFirmware.DeepCopy()
+
+	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.BMCOptions)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to create BMC client: %w", err)
+	}
+	defer bmcClient.Logout()
+
+	inventory, err := bmcClient.GetFirmwareInventory(ctx)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get firmware inventory: %w", err)
+	}
+	inventoryByURI := make(map[string]bmc.FirmwareInventory, len(inventory))
+	for _, item := range inventory {
+		inventoryByURI[item.URI] = item
+	}
+
+	// Components are flashed one after another, so there is at most one component in progress.
+	for i := range componentFirmware.Status.Components {
+		progress := &componentFirmware.Status.Components[i]
+		if item, ok := inventoryByURI[progress.URI]; ok {
+			progress.Version = item.Version
+		}
+		switch progress.State {
+		case metalv1alpha1.ComponentFirmwareStateCompleted:
+			continue
+		case metalv1alpha1.ComponentFirmwareStateFailed:
+			componentFirmware.Status.State = metalv1alpha1.ComponentFirmwareStateFailed
+			return ctrl.Result{}, r.patchStatus(ctx, componentFirmware, componentFirmwareBase)
+		case metalv1alpha1.ComponentFirmwareStateInProgress:
+			task, done, failed, err := getFirmwareUpdateTask(ctx, bmcClient, progress.TaskURI)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to get firmware update progress of component %s: %w", progress.Name, err)
+			}
+			progress.PercentComplete = int32(task.PercentComplete)
+			progress.Message = task.Message
+			if !done {
+				return ctrl.Result{RequeueAfter: r.ResyncInterval}, r.patchStatus(ctx, componentFirmware, componentFirmwareBase)
+			}
+			if failed {
+				log.V(1).Info("Component firmware update failed", "Component", progress.Name, "Message", progress.Message)
+				progress.State = metalv1alpha1.ComponentFirmwareStateFailed
+				componentFirmware.Status.State = metalv1alpha1.ComponentFirmwareStateFailed
+				return ctrl.Result{}, r.patchStatus(ctx, componentFirmware, componentFirmwareBase)
+			}
+			progress.State = metalv1alpha1.ComponentFirmwareStateCompleted
+		default:
+			if _, ok := inventoryByURI[progress.URI]; !ok {
+				progress.State = metalv1alpha1.ComponentFirmwareStateFailed
+				progress.Message = "Component is no longer present in the firmware inventory"
+				componentFirmware.Status.State = metalv1alpha1.ComponentFirmwareStateFailed
+				return ctrl.Result{}, r.patchStatus(ctx, componentFirmware, componentFirmwareBase)
+			}
+			taskURI, err := bmcClient.UpdateFirmware(ctx, bmc.FirmwareUpdateParameters{
+				ImageURI:         componentFirmware.Spec.Image.URI,
+				TransferProtocol: componentFirmware.Spec.Image.TransferProtocol,
+				Targets:          []string{progress.URI},
+			})
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to update firmware of component %s: %w", progress.Name, err)
+			}
+			log.V(1).Info("Triggered component firmware update", "Component", progress.Name, "Task", taskURI)
+			progress.State = metalv1alpha1.ComponentFirmwareStateInProgress
+			progress.TaskURI = taskURI
+			return ctrl.Result{RequeueAfter: r.ResyncInterval}, r.patchStatus(ctx, componentFirmware, componentFirmwareBase)
+		}
+	}
+
+	// All components report a finished update, verify the running firmware version.
+	var mismatch []string
+	for _, progress := range componentFirmware.Status.Components {
+		if progress.Version != componentFirmware.Spec.Version {
+			mismatch = append(mismatch, progress.Name)
+		}
+	}
+	if len(mismatch) > 0 && componentFirmware.Status.ActivationTime == nil {
+		// Most BMCs only stage the image, which becomes active with the next restart of the BMC or the server.
+		return r.activateComponents(ctx, log, componentFirmware, componentFirmwareBase, server, bmcClient)
+	}
+	if len(mismatch) > 0 && time.Since(componentFirmware.Status.ActivationTime.Time) < r.activationTimeout() {
+		log.V(1).Info("Waiting for the activated firmware to be running", "Components", mismatch)
+		meta.SetStatusCondition(&componentFirmware.Status.Conditions, metav1.Condition{
+			Type:    FirmwareConditionVerified,
+			Status:  metav1.ConditionFalse,
+			Reason:  "AwaitingActivation",
+			Message: fmt.Sprintf("Waiting for components %v to run version %s", mismatch, componentFirmware.Spec.Version),
+		})
+		return ctrl.Result{RequeueAfter: r.ResyncInterval}, r.patchStatus(ctx, componentFirmware, componentFirmwareBase)
+	}
+	if len(mismatch) > 0 {
+		meta.SetStatusCondition(&componentFirmware.Status.Conditions, metav1.Condition{
+			Type:    FirmwareConditionVerified,
+			Status:  metav1.ConditionFalse,
+			Reason:  "VersionMismatch",
+			Message: fmt.Sprintf("Components %v are not running version %s", mismatch, componentFirmware.Spec.Version),
+		})
+		componentFirmware.Status.State = metalv1alpha1.ComponentFirmwareStateFailed
+		return ctrl.Result{}, r.patchStatus(ctx, componentFirmware, componentFirmwareBase)
+	}
+	meta.SetStatusCondition(&componentFirmware.Status.Conditions, metav1.Condition{
+		Type:   FirmwareConditionVerified,
+		Status: metav1.ConditionTrue,
+		Reason: "VersionMatch",
+	})
+	componentFirmware.Status.State = metalv1alpha1.ComponentFirmwareStateCompleted
+	log.V(1).Info("Completed component firmware update")
+	return ctrl.Result{}, r.patchStatus(ctx, componentFirmware, componentFirmwareBase)
+}
+
+// activateComponents restarts the BMC for BMC components, or the server for all other components, so that the
+// updated firmware becomes active. The server is only restarted if it is not in use.
+func (r *ComponentFirmwareReconciler) activateComponents(ctx context.Context, log logr.Logger, componentFirmware, componentFirmwareBase *metalv1alpha1.ComponentFirmware, server *metalv1alpha1.Server, bmcClient bmc.BMC) (ctrl.Result, error) {
+	condition := metav1.Condition{
+		Type:   ComponentFirmwareConditionActivated,
+		Status: metav1.ConditionTrue,
+	}
+	if componentFirmware.Spec.Component.Type == metalv1alpha1.ComponentTypeBMC {
+		var err error
+		if server.Spec.BMCRef != nil {
+			err = requestBMCReset(ctx, r.Client, server.Spec.BMCRef.Name)
+		} else {
+			err = bmcClient.ResetManager(ctx, redfish.GracefulRestartResetType)
+		}
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to reset BMC: %w", err)
+		}
+		condition.Reason = "BMCReset"
+		condition.Message = "Reset the BMC to activate the firmware"
+	} else {
+		inUse := firmwareServerInUseCondition(server)
+		meta.SetStatusCondition(&componentFirmware.Status.Conditions, inUse)
+		if inUse.Status == metav1.ConditionTrue {
+			log.V(1).Info("Server is in use, waiting for it to become available to activate the firmware", "Reason", inUse.Reason)
+			return ctrl.Result{RequeueAfter: r.ResyncInterval}, r.patchStatus(ctx, componentFirmware, componentFirmwareBase)
+		}
+		resetType, err := selectResetType(server, restartResetTypes...)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := bmcClient.Reset(ctx, server.Spec.SystemUUID, resetType); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to reset server: %w", err)
+		}
+		serverBase := server.DeepCopy()
+		setPowerCycleRequested(server, resetType, fmt.Sprintf("ComponentFirmware %s", componentFirmware.Name))
+		if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to patch server power conditions: %w", err)
+		}
+		condition.Reason = "ServerRestarted"
+		condition.Message = fmt.Sprintf("Reset the server with %s to activate the firmware", resetType)
+	}
+	log.V(1).Info("Activating component firmware", "Reason", condition.Reason)
+	now := metav1.Now()
+	componentFirmware.Status.ActivationTime = &now
+	meta.SetStatusCondition(&componentFirmware.Status.Conditions, condition)
+	return ctrl.Result{RequeueAfter: r.ResyncInterval}, r.patchStatus(ctx, componentFirmware, componentFirmwareBase)
+}
+
+func (r *ComponentFirmwareReconciler) activationTimeout() time.Duration {
+	if r.ActivationTimeout > 0 {
+		return r.ActivationTimeout
+	}
+	return defaultFirmwareActivationTimeout
+}
+
+// handleFinishedState deletes the ComponentFirmware once the TTL after its completion has expired. Updates which
+// finished before the completion time was recorded start their TTL now.
+func (r *ComponentFirmwareReconciler) handleFinishedState(ctx context.Context, log logr.Logger, componentFirmware *metalv1alpha1.ComponentFirmware) (ctrl.Result, error) {
+	if componentFirmware.Spec.TTLSecondsAfterFinished == nil {
+		return ctrl.Result{}, nil
+	}
+	if componentFirmware.Status.CompletionTime == nil {
+		return ctrl.Result{}, r.patchStatus(ctx, componentFirmware, componentFirmware.DeepCopy())
+	}
+	if remaining := ttlAfterFinished(*componentFirmware.Spec.TTLSecondsAfterFinished, *componentFirmware.Status.CompletionTime); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+	if err := r.Delete(ctx, componentFirmware); client.IgnoreNotFound(err) != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to delete finished ComponentFirmware: %w", err)
+	}
+	log.V(1).Info("Deleted finished ComponentFirmware after its TTL expired")
+	return ctrl.Result{}, nil
+}
+
+func (r *ComponentFirmwareReconciler) patchStatus(ctx context.Context, componentFirmware, componentFirmwareBase *metalv1alpha1.ComponentFirmware) error {
+	switch componentFirmware.Status.State {
+	case metalv1alpha1.ComponentFirmwareStateCompleted, metalv1alpha1.ComponentFirmwareStateFailed:
+		if componentFirmware.Status.CompletionTime == nil {
+			now := metav1.Now()
+			componentFirmware.Status.CompletionTime = &now
+		}
+	}
+	if err := r.Status().Patch(ctx, componentFirmware, client.MergeFrom(componentFirmwareBase)); err != nil {
+		return fmt.Errorf("failed to patch ComponentFirmware status: %w", err)
+	}
+	if componentFirmware.Status.State == metalv1alpha1.ComponentFirmwareStateFailed && componentFirmwareBase.Status.State != metalv1alpha1.ComponentFirmwareStateFailed {
+		return markServerFirmwareUpdateFailed(ctx, r.Client, componentFirmware.Spec.ServerRef.Name, "ComponentFirmware", componentFirmware.Name)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ComponentFirmwareReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&metalv1alpha1.ComponentFirmware{}).
+		Watches(&metalv1alpha1.Server{}, r.enqueueComponentFirmwareByServerRefs()).
+		Complete(r)
+}
+
+func (r *ComponentFirmwareReconciler) enqueueComponentFirmwareByServerRefs() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
+		log := ctrl.LoggerFrom(ctx)
+
+		server := object.(*metalv1alpha1.Server)
+		componentFirmwareList := &metalv1alpha1.ComponentFirmwareList{}
+		if err := r.List(ctx, componentFirmwareList); err != nil {
+			log.Error(err, "failed to list ComponentFirmwares")
+			return nil
+		}
+		var req []reconcile.Request
+		for _, componentFirmware := range componentFirmwareList.Items {
+			if componentFirmware.Spec.ServerRef.Name == server.Name {
+				req = append(req, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: componentFirmware.Name},
+				})
+			}
+		}
+		return req
+	})
+}
@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+)
+
+var _ = Describe("ComponentFirmware Controller", func() {
+	_ = SetupTest()
+
+	var server *metalv1alpha1.Server
+
+	BeforeEach(func(ctx SpecContext) {
+		By("Creating a claimed Server object")
+		server = &metalv1alpha1.Server{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Annotations: map[string]string{
+					metalv1alpha1.OperationAnnotation: metalv1alpha1.OperationAnnotationIgnore,
+				},
+			},
+			Spec: metalv1alpha1.ServerSpec{
+				SystemUUID: "38947555-7742-3448-3784-823347823834",
+				ServerClaimRef: &v1.ObjectReference{
+					Namespace: "foo",
+					Name:      "bar",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, server)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, server)
+	})
+
+	It("should not update the components of a claimed server", func(ctx SpecContext) {
+		By("Creating a ComponentFirmware object")
+		componentFirmware := &metalv1alpha1.ComponentFirmware{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.ComponentFirmwareSpec{
+				ServerRef: v1.LocalObjectReference{Name: server.Name},
+				Component: metalv1alpha1.ComponentSelector{
+					Type: metalv1alpha1.ComponentTypeBIOS,
+				},
+				Version: "2.0.0",
+				Image: metalv1alpha1.FirmwareImage{
+					URI: "http://example.com/bios.bin",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, componentFirmware)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, componentFirmware)
+
+		By("Ensuring that the update waits for the server to be released")
+		Eventually(Object(componentFirmware)).Should(SatisfyAll(
+			HaveField("Status.State", metalv1alpha1.ComponentFirmwareStatePending),
+			HaveField("Status.Conditions", ContainElement(SatisfyAll(
+				HaveField("Type", FirmwareConditionServerInUse),
+				HaveField("Status", metav1.ConditionTrue),
+			))),
+		))
+		Consistently(Object(componentFirmware)).Should(HaveField("Status.Components", BeEmpty()))
+	})
+
+	It("should delete a finished ComponentFirmware after its TTL", func(ctx SpecContext) {
+		By("Creating a ComponentFirmware object with a TTL")
+		componentFirmware := &metalv1alpha1.ComponentFirmware{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.ComponentFirmwareSpec{
+				ServerRef: v1.LocalObjectReference{Name: server.Name},
+				Component: metalv1alpha1.ComponentSelector{
+					Type: metalv1alpha1.ComponentTypeBIOS,
+				},
+				Version: "2.0.0",
+				Image: metalv1alpha1.FirmwareImage{
+					URI: "http://example.com/bios.bin",
+				},
+				TTLSecondsAfterFinished: ptr.To[int32](1),
+			},
+		}
+		Expect(k8sClient.Create(ctx, componentFirmware)).To(Succeed())
+
+		By("Marking the update as completed")
+		Eventually(UpdateStatus(componentFirmware, func() {
+			componentFirmware.Status.State = metalv1alpha1.ComponentFirmwareStateCompleted
+		})).Should(Succeed())
+
+		By("Ensuring that the ComponentFirmware is deleted")
+		Eventually(Get(componentFirmware)).Should(Satisfy(apierrors.IsNotFound))
+	})
+
+	It("should restart the server to activate the updated BIOS firmware", func(ctx SpecContext) {
+		By("Registering a simulated BMC which activates the BIOS firmware on a restart of the system")
+		simulator := bmc.NewSimulator()
+		simulator.Update(func(state *bmc.SimulatorState) {
+			state.Systems[0].Info.SystemUUID = "38947555-7742-3448-3784-823347823836"
+		})
+		simulator.SetIntercept(func(_ context.Context, operation string) error {
+			if operation == "Reset" {
+				simulator.Update(func(state *bmc.SimulatorState) {
+					state.FirmwareInventory[0].Version = "2.0.0"
+				})
+			}
+			return nil
+		})
+		bmc.Simulators.Register("10.30.0.2:8000", simulator)
+
+		By("Creating a BMCSecret")
+		bmcSecret := &metalv1alpha1.BMCSecret{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Data: map[string][]byte{
+				metalv1alpha1.BMCSecretUsernameKeyName: []byte("foo"),
+				metalv1alpha1.BMCSecretPasswordKeyName: []byte("bar"),
+			},
+		}
+		Expect(k8sClient.Create(ctx, bmcSecret)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, bmcSecret)
+
+		By("Creating a Server object behind the simulated BMC")
+		availableServer := &metalv1alpha1.Server{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Annotations: map[string]string{
+					metalv1alpha1.OperationAnnotation: metalv1alpha1.OperationAnnotationIgnore,
+				},
+			},
+			Spec: metalv1alpha1.ServerSpec{
+				SystemUUID: "38947555-7742-3448-3784-823347823836",
+				BMC: &metalv1alpha1.BMCAccess{
+					Protocol: metalv1alpha1.Protocol{
+						Name: metalv1alpha1.ProtocolRedfishFake,
+						Port: 8000,
+					},
+					Address:      "10.30.0.2",
+					BMCSecretRef: v1.LocalObjectReference{Name: bmcSecret.Name},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, availableServer)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, availableServer)
+
+		By("Creating a ComponentFirmware object whose image is only staged by the BMC")
+		componentFirmware := &metalv1alpha1.ComponentFirmware{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.ComponentFirmwareSpec{
+				ServerRef: v1.LocalObjectReference{Name: availableServer.Name},
+				Component: metalv1alpha1.ComponentSelector{
+					Type: metalv1alpha1.ComponentTypeBIOS,
+				},
+				Version: "2.0.0",
+				Image: metalv1alpha1.FirmwareImage{
+					URI: "http://example.com/bios.bin",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, componentFirmware)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, componentFirmware)
+
+		By("Ensuring that the update completes after the server has been restarted")
+		Eventually(Object(componentFirmware)).Should(SatisfyAll(
+			HaveField("Status.State", metalv1alpha1.ComponentFirmwareStateCompleted),
+			HaveField("Status.ActivationTime", Not(BeNil())),
+			HaveField("Status.Conditions", ContainElements(
+				SatisfyAll(
+					HaveField("Type", ComponentFirmwareConditionActivated),
+					HaveField("Reason", "ServerRestarted"),
+				),
+				SatisfyAll(
+					HaveField("Type", FirmwareConditionVerified),
+					HaveField("Status", metav1.ConditionTrue),
+				),
+			)),
+		))
+		Expect(simulator.State().FirmwareUpdates).To(HaveLen(1))
+		Eventually(Object(availableServer)).Should(HaveField("Status.Conditions", ContainElement(
+			HaveField("Type", ServerConditionPowerCycleRequested),
+		)))
+	})
+})
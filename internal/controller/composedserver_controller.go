@@ -0,0 +1,419 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/ironcore-dev/controller-utils/clientutils"
+	"github.com/ironcore-dev/controller-utils/metautils"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+	"github.com/stmcginnis/gofish/redfish"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	ComposedServerFinalizer = "metal.ironcore.dev/composedserver"
+
+	// ComposedServerConditionComposed reports whether the system of a ComposedServer has been composed.
+	ComposedServerConditionComposed = "Composed"
+
+	composedServerReasonComposing = "Composing"
+)
+
+// ComposedServerReconciler reconciles a ComposedServer object
+type ComposedServerReconciler struct {
+	client.Client
+	Scheme     *runtime.Scheme
+	Insecure   bool
+	BMCOptions bmc.BMCOptions
+	// ResyncInterval is the interval at which a ComposedServer is requeued while waiting for the BMC.
+	ResyncInterval time.Duration
+}
+
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=composedservers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=composedservers/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=composedservers/finalizers,verbs=update
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=servers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=bmcs,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *ComposedServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	composedServer := &metalv1alpha1.ComposedServer{}
+	if err := r.Get(ctx, req.NamespacedName, composedServer); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	return r.reconcileExists(ctx, log, composedServer)
+}
+
+func (r *ComposedServerReconciler) reconcileExists(ctx context.Context, log logr.Logger, composedServer *metalv1alpha1.ComposedServer) (ctrl.Result, error) {
+	if !composedServer.DeletionTimestamp.IsZero() {
+		return r.delete(ctx, log, composedServer)
+	}
+	return r.reconcile(ctx, log, composedServer)
+}
+
+func (r *ComposedServerReconciler) delete(ctx context.Context, log logr.Logger, composedServer *metalv1alpha1.ComposedServer) (ctrl.Result, error) {
+	log.V(1).Info("Deleting ComposedServer")
+	if !controllerutil.ContainsFinalizer(composedServer, ComposedServerFinalizer) {
+		log.V(1).Info("Deleted ComposedServer")
+		return ctrl.Result{}, nil
+	}
+
+	if composedServer.Status.ServerRef != nil {
+		server := &metalv1alpha1.Server{}
+		err := r.Get(ctx, client.ObjectKey{Name: composedServer.Status.ServerRef.Name}, server)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("failed to get server: %w", err)
+		}
+		if err == nil {
+			// The system is only decomposed once it has been released by its claim.
+			if server.Spec.ServerClaimRef != nil {
+				log.V(1).Info("Server is in use by a claim, waiting for it to be released", "Server", server.Name)
+				return ctrl.Result{RequeueAfter: r.ResyncInterval}, r.patchState(ctx, composedServer, metalv1alpha1.ComposedServerStateDecomposing)
+			}
+			if server.DeletionTimestamp.IsZero() {
+				if err := r.Delete(ctx, server); err != nil && !apierrors.IsNotFound(err) {
+					return ctrl.Result{}, fmt.Errorf("failed to delete server: %w", err)
+				}
+				log.V(1).Info("Deleted Server of composed system", "Server", server.Name)
+			}
+			return ctrl.Result{RequeueAfter: r.ResyncInterval}, r.patchState(ctx, composedServer, metalv1alpha1.ComposedServerStateDecomposing)
+		}
+	}
+
+	if composedServer.Status.SystemURI != "" || isCompositionRequested(composedServer) {
+		bmcClient, err := r.getBMCClient(ctx, composedServer)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		defer bmcClient.Logout()
+
+		systemURI := composedServer.Status.SystemURI
+		if systemURI == "" {
+			// The system may have been composed without its URI being recorded.
+			if composedServer.Status.TaskURI != "" {
+				task, err := bmcClient.GetTask(ctx, composedServer.Status.TaskURI)
+				if err != nil {
+					return ctrl.Result{}, fmt.Errorf("failed to get composition task: %w", err)
+				}
+				if !isTaskFinished(task) {
+					log.V(1).Info("Waiting for the composition task to finish", "Task", task.URI)
+					return ctrl.Result{RequeueAfter: r.ResyncInterval}, nil
+				}
+			}
+			if systemURI, err = findComposedSystem(ctx, bmcClient, composedServer.Name); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		if systemURI != "" {
+			if err := bmcClient.DecomposeSystem(ctx, systemURI); err != nil {
+				return ctrl.Result{}, err
+			}
+			log.V(1).Info("Decomposed system", "System", systemURI)
+		}
+	}
+
+	if modified, err := clientutils.PatchEnsureNoFinalizer(ctx, r.Client, composedServer, ComposedServerFinalizer); !apierrors.IsNotFound(err) || modified {
+		return ctrl.Result{}, err
+	}
+	log.V(1).Info("Ensured that the finalizer has been removed")
+
+	log.V(1).Info("Deleted ComposedServer")
+	return ctrl.Result{}, nil
+}
+
+func (r *ComposedServerReconciler) reconcile(ctx context.Context, log logr.Logger, composedServer *metalv1alpha1.ComposedServer) (ctrl.Result, error) {
+	log.V(1).Info("Reconciling ComposedServer")
+	if paused, remaining := shouldIgnoreReconciliation(composedServer); paused {
+		log.V(1).Info("Skipped ComposedServer reconciliation")
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	if modified, err := clientutils.PatchEnsureFinalizer(ctx, r.Client, composedServer, ComposedServerFinalizer); err != nil || modified {
+		return ctrl.Result{}, err
+	}
+	log.V(1).Info("Ensured finalizer has been added")
+
+	if composedServer.Status.State == metalv1alpha1.ComposedServerStateFailed {
+		log.V(1).Info("ComposedServer composition failed, recreate it to retry")
+		return ctrl.Result{}, nil
+	}
+
+	bmcClient, err := r.getBMCClient(ctx, composedServer)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	defer bmcClient.Logout()
+
+	if composedServer.Status.SystemURI == "" {
+		return r.composeSystem(ctx, log, bmcClient, composedServer)
+	}
+
+	composedServerBase := composedServer.DeepCopy()
+	if composedServer.Status.SystemUUID == "" {
+		system, err := bmcClient.GetSystem(ctx, composedServer.Status.SystemURI)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if system.UUID == "" {
+			log.V(1).Info("Composed system has no UUID yet", "System", composedServer.Status.SystemURI)
+			return ctrl.Result{RequeueAfter: r.ResyncInterval}, nil
+		}
+		composedServer.Status.SystemUUID = strings.ToLower(system.UUID)
+	}
+
+	server, err := r.ensureServer(ctx, composedServer)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	log.V(1).Info("Ensured Server of composed system", "Server", server.Name)
+
+	composedServer.Status.ServerRef = &v1.LocalObjectReference{Name: server.Name}
+	composedServer.Status.State = metalv1alpha1.ComposedServerStateComposed
+	if err := r.Status().Patch(ctx, composedServer, client.MergeFrom(composedServerBase)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to patch ComposedServer status: %w", err)
+	}
+
+	log.V(1).Info("Reconciled ComposedServer")
+	return ctrl.Result{}, nil
+}
+
+func (r *ComposedServerReconciler) composeSystem(ctx context.Context, log logr.Logger, bmcClient bmc.BMC, composedServer *metalv1alpha1.ComposedServer) (ctrl.Result, error) {
+	composedServerBase := composedServer.DeepCopy()
+	if composedServer.Status.TaskURI != "" {
+		task, err := bmcClient.GetTask(ctx, composedServer.Status.TaskURI)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to get composition task: %w", err)
+		}
+		if !isTaskFinished(task) {
+			log.V(1).Info("Waiting for the composition task to finish", "Task", task.URI)
+			return ctrl.Result{RequeueAfter: r.ResyncInterval}, nil
+		}
+		if task.State != redfish.CompletedTaskState {
+			composedServer.Status.TaskURI = ""
+			composedServer.Status.State = metalv1alpha1.ComposedServerStateFailed
+			meta.SetStatusCondition(&composedServer.Status.Conditions, metav1.Condition{
+				Type:    ComposedServerConditionComposed,
+				Status:  metav1.ConditionFalse,
+				Reason:  "CompositionFailed",
+				Message: fmt.Sprintf("Composition task %s is %s: %s", task.URI, task.State, task.Message),
+			})
+			if err := r.Status().Patch(ctx, composedServer, client.MergeFrom(composedServerBase)); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to patch ComposedServer status: %w", err)
+			}
+			return ctrl.Result{}, nil
+		}
+	}
+
+	// A previous composition may have succeeded without its system being recorded, e.g. if the status patch failed.
+	systemURI, err := findComposedSystem(ctx, bmcClient, composedServer.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if systemURI != "" {
+		log.V(1).Info("Found composed system", "System", systemURI)
+		return r.patchComposedSystem(ctx, composedServer, systemURI)
+	}
+	if composedServer.Status.TaskURI != "" {
+		return ctrl.Result{}, fmt.Errorf("composition task %s completed, but no system named %s exists", composedServer.Status.TaskURI, composedServer.Name)
+	}
+
+	blocks, err := bmcClient.GetResourceBlocks(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	uris := make([]string, 0, len(composedServer.Spec.ResourceBlocks))
+	var unavailable []string
+	for _, name := range composedServer.Spec.ResourceBlocks {
+		block, ok := findResourceBlock(blocks, name)
+		switch {
+		case !ok:
+			composedServer.Status.State = metalv1alpha1.ComposedServerStateFailed
+			meta.SetStatusCondition(&composedServer.Status.Conditions, metav1.Condition{
+				Type:    ComposedServerConditionComposed,
+				Status:  metav1.ConditionFalse,
+				Reason:  "ResourceBlockNotFound",
+				Message: fmt.Sprintf("Resource block %s does not exist", name),
+			})
+			if err := r.Status().Patch(ctx, composedServer, client.MergeFrom(composedServerBase)); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to patch ComposedServer status: %w", err)
+			}
+			return ctrl.Result{}, nil
+		case block.CompositionState != "" && block.CompositionState != "Unused":
+			unavailable = append(unavailable, name)
+		default:
+			uris = append(uris, block.URI)
+		}
+	}
+
+	// Resource blocks in use by other compositions may be freed later on. If the composition has been requested
+	// already, they may be in use by the system composed for this ComposedServer, which is found by name once the
+	// BMC reports it.
+	if len(unavailable) > 0 && isCompositionRequested(composedServer) {
+		return ctrl.Result{RequeueAfter: r.ResyncInterval}, nil
+	}
+	if len(unavailable) > 0 {
+		composedServer.Status.State = metalv1alpha1.ComposedServerStatePending
+		meta.SetStatusCondition(&composedServer.Status.Conditions, metav1.Condition{
+			Type:    ComposedServerConditionComposed,
+			Status:  metav1.ConditionFalse,
+			Reason:  "ResourceBlocksUnavailable",
+			Message: fmt.Sprintf("Resource blocks %v are in use", unavailable),
+		})
+		if err := r.Status().Patch(ctx, composedServer, client.MergeFrom(composedServerBase)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to patch ComposedServer status: %w", err)
+		}
+		return ctrl.Result{RequeueAfter: r.ResyncInterval}, nil
+	}
+
+	// The composition is recorded before it is requested, so that a system whose composition is not recorded
+	// afterwards is looked up by name and decomposed on deletion.
+	composedServer.Status.State = metalv1alpha1.ComposedServerStatePending
+	meta.SetStatusCondition(&composedServer.Status.Conditions, metav1.Condition{
+		Type:   ComposedServerConditionComposed,
+		Status: metav1.ConditionFalse,
+		Reason: composedServerReasonComposing,
+	})
+	if err := r.Status().Patch(ctx, composedServer, client.MergeFrom(composedServerBase)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to patch ComposedServer status: %w", err)
+	}
+
+	systemURI, taskURI, err := bmcClient.ComposeSystem(ctx, composedServer.Name, uris)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if taskURI != "" {
+		log.V(1).Info("Composing system", "Task", taskURI)
+		composedServerBase = composedServer.DeepCopy()
+		composedServer.Status.TaskURI = taskURI
+		if err := r.Status().Patch(ctx, composedServer, client.MergeFrom(composedServerBase)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to patch ComposedServer status: %w", err)
+		}
+		return ctrl.Result{RequeueAfter: r.ResyncInterval}, nil
+	}
+	log.V(1).Info("Composed system", "System", systemURI)
+	return r.patchComposedSystem(ctx, composedServer, systemURI)
+}
+
+func (r *ComposedServerReconciler) patchComposedSystem(ctx context.Context, composedServer *metalv1alpha1.ComposedServer, systemURI string) (ctrl.Result, error) {
+	composedServerBase := composedServer.DeepCopy()
+	composedServer.Status.SystemURI = systemURI
+	composedServer.Status.TaskURI = ""
+	composedServer.Status.State = metalv1alpha1.ComposedServerStatePending
+	meta.SetStatusCondition(&composedServer.Status.Conditions, metav1.Condition{
+		Type:   ComposedServerConditionComposed,
+		Status: metav1.ConditionTrue,
+		Reason: "SystemComposed",
+	})
+	if err := r.Status().Patch(ctx, composedServer, client.MergeFrom(composedServerBase)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to patch ComposedServer status: %w", err)
+	}
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// findComposedSystem returns the URI of the composed system with the given name, or an empty string if the BMC has
+// no such system.
+func findComposedSystem(ctx context.Context, bmcClient bmc.BMC, name string) (string, error) {
+	systems, err := bmcClient.GetSystems(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get systems: %w", err)
+	}
+	for _, system := range systems {
+		if system.SystemType == bmc.SystemTypeComposed && system.Name == name {
+			return system.URI, nil
+		}
+	}
+	return "", nil
+}
+
+// isCompositionRequested reports whether the composition of the system of the ComposedServer may have been requested
+// without its URI being recorded.
+func isCompositionRequested(composedServer *metalv1alpha1.ComposedServer) bool {
+	if composedServer.Status.TaskURI != "" {
+		return true
+	}
+	condition := meta.FindStatusCondition(composedServer.Status.Conditions, ComposedServerConditionComposed)
+	return condition != nil && condition.Reason == composedServerReasonComposing
+}
+
+func findResourceBlock(blocks []bmc.ResourceBlock, name string) (bmc.ResourceBlock, bool) {
+	for _, block := range blocks {
+		if block.ID == name || block.Name == name {
+			return block, true
+		}
+	}
+	return bmc.ResourceBlock{}, false
+}
+
+// ensureServer creates the Server representing the composed system. The BMCReconciler skips the systems of
+// ComposedServers, so the system is not discovered a second time.
+func (r *ComposedServerReconciler) ensureServer(ctx context.Context, composedServer *metalv1alpha1.ComposedServer) (*metalv1alpha1.Server, error) {
+	bmcObj := &metalv1alpha1.BMC{}
+	if err := r.Get(ctx, client.ObjectKey{Name: composedServer.Spec.BMCRef.Name}, bmcObj); err != nil {
+		return nil, fmt.Errorf("failed to get BMC: %w", err)
+	}
+
+	server := &metalv1alpha1.Server{}
+	server.Name = composedServer.Name
+	if _, err := controllerutil.CreateOrPatch(ctx, r.Client, server, func() error {
+		metautils.SetLabels(server, bmcObj.Labels)
+		server.Spec.UUID = composedServer.Status.SystemUUID
+		server.Spec.SystemUUID = composedServer.Status.SystemUUID
+		server.Spec.BMCRef = &v1.LocalObjectReference{Name: bmcObj.Name}
+		return controllerutil.SetControllerReference(composedServer, server, r.Scheme)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create or patch Server: %w", err)
+	}
+	return server, nil
+}
+
+func (r *ComposedServerReconciler) getBMCClient(ctx context.Context, composedServer *metalv1alpha1.ComposedServer) (bmc.BMC, error) {
+	bmcObj := &metalv1alpha1.BMC{}
+	if err := r.Get(ctx, client.ObjectKey{Name: composedServer.Spec.BMCRef.Name}, bmcObj); err != nil {
+		return nil, fmt.Errorf("failed to get BMC: %w", err)
+	}
+	bmcClient, err := bmcutils.GetBMCClientFromBMC(ctx, r.Client, bmcObj, r.Insecure, r.BMCOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create BMC client: %w", err)
+	}
+	return bmcClient, nil
+}
+
+func (r *ComposedServerReconciler) patchState(ctx context.Context, composedServer *metalv1alpha1.ComposedServer, state metalv1alpha1.ComposedServerState) error {
+	if composedServer.Status.State == state {
+		return nil
+	}
+	composedServerBase := composedServer.DeepCopy()
+	composedServer.Status.State = state
+	if err := r.Status().Patch(ctx, composedServer, client.MergeFrom(composedServerBase)); err != nil {
+		return fmt.Errorf("failed to patch ComposedServer state: %w", err)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ComposedServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&metalv1alpha1.ComposedServer{}).
+		Owns(&metalv1alpha1.Server{}).
+		Complete(r)
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"time"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+)
+
+var _ = Describe("ComposedServer Controller", func() {
+	_ = SetupTest()
+
+	It("should release a ComposedServer which has not been composed", func(ctx SpecContext) {
+		By("Creating a ComposedServer object")
+		composedServer := &metalv1alpha1.ComposedServer{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.ComposedServerSpec{
+				BMCRef:         v1.LocalObjectReference{Name: "does-not-exist"},
+				ResourceBlocks: []string{"ComputeBlock1"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, composedServer)).To(Succeed())
+
+		By("Ensuring that the finalizer has been added")
+		Eventually(Object(composedServer)).Should(
+			HaveField("Finalizers", ContainElement(ComposedServerFinalizer)),
+		)
+		Consistently(Object(composedServer)).Should(HaveField("Status.SystemURI", BeEmpty()))
+
+		By("Deleting the ComposedServer")
+		Expect(k8sClient.Delete(ctx, composedServer)).To(Succeed())
+		Eventually(Get(composedServer)).Should(Satisfy(apierrors.IsNotFound))
+	})
+
+	It("should adopt a composed system whose composition has not been recorded", func(ctx SpecContext) {
+		By("Simulating a BMC with a system composed for the ComposedServer")
+		simulator := bmc.NewSimulator()
+		simulator.Update(func(state *bmc.SimulatorState) {
+			state.ResourceBlocks = []bmc.ResourceBlock{{
+				Entity:           bmc.Entity{ID: "ComputeBlock1"},
+				URI:              "/redfish/v1/CompositionService/ResourceBlocks/ComputeBlock1",
+				CompositionState: "Composed",
+			}}
+			state.Systems = append(state.Systems, bmc.SimulatedSystem{
+				URI:        "/redfish/v1/Systems/adopted-composition",
+				Name:       "adopted-composition",
+				SystemType: bmc.SystemTypeComposed,
+				Info:       bmc.SystemInfo{SystemUUID: "38947555-7742-3448-3784-823347823841"},
+			})
+		})
+		bmc.Simulators.Register("10.30.0.8:8000", simulator)
+
+		bmcSecret := &metalv1alpha1.BMCSecret{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Data: map[string][]byte{
+				metalv1alpha1.BMCSecretUsernameKeyName: []byte("foo"),
+				metalv1alpha1.BMCSecretPasswordKeyName: []byte("bar"),
+			},
+		}
+		Expect(k8sClient.Create(ctx, bmcSecret)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, bmcSecret)
+
+		By("Creating a paused BMC, so that its systems are not discovered")
+		bmcObj := &metalv1alpha1.BMC{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Annotations: map[string]string{
+					metalv1alpha1.PausedUntilAnnotation: time.Now().Add(time.Hour).Format(time.RFC3339),
+				},
+			},
+			Spec: metalv1alpha1.BMCSpec{
+				Endpoint: &metalv1alpha1.InlineEndpoint{
+					IP:         metalv1alpha1.MustParseIP("10.30.0.8"),
+					MACAddress: "23:11:8A:33:CF:EC",
+				},
+				Protocol: metalv1alpha1.Protocol{
+					Name: metalv1alpha1.ProtocolRedfishFake,
+					Port: 8000,
+				},
+				BMCSecretRef: v1.LocalObjectReference{
+					Name: bmcSecret.Name,
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, bmcObj)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, bmcObj)
+
+		By("Creating a ComposedServer object")
+		composedServer := &metalv1alpha1.ComposedServer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "adopted-composition",
+			},
+			Spec: metalv1alpha1.ComposedServerSpec{
+				BMCRef:         v1.LocalObjectReference{Name: bmcObj.Name},
+				ResourceBlocks: []string{"ComputeBlock1"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, composedServer)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, composedServer)
+
+		By("Ensuring that the composed system has been adopted instead of composing another one")
+		Eventually(Object(composedServer)).Should(SatisfyAll(
+			HaveField("Status.SystemURI", "/redfish/v1/Systems/adopted-composition"),
+			HaveField("Status.SystemUUID", "38947555-7742-3448-3784-823347823841"),
+			HaveField("Status.State", metalv1alpha1.ComposedServerStateComposed),
+		))
+		Expect(simulator.State().Systems).To(HaveLen(2))
+	})
+})
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// DiscoveryImagesKey is the data key of the discovery image ConfigMap holding the rules.
+const DiscoveryImagesKey = "rules.yaml"
+
+// DiscoveryImageRule maps servers of a manufacturer and model to the probe OS image used for their discovery.
+type DiscoveryImageRule struct {
+	// Manufacturer is the manufacturer of the servers as reported by the BMC. An empty value matches all
+	// manufacturers.
+	Manufacturer string `json:"manufacturer,omitempty"`
+	// Model is the model of the servers as reported by the BMC. An empty value matches all models.
+	Model string `json:"model,omitempty"`
+	// Architecture is the CPU architecture of the servers. An empty value matches all architectures.
+	Architecture metalv1alpha1.Architecture `json:"architecture,omitempty"`
+	// Image is the probe OS image for the matching servers.
+	Image string `json:"image"`
+}
+
+// matches reports whether the rule matches the Server. Manufacturers and models are compared by their canonical
+// names and case insensitively, so that "Dell Inc." matches servers reporting "DELL".
+func (r DiscoveryImageRule) matches(server *metalv1alpha1.Server) bool {
+	manufacturer := bmc.NormalizeManufacturer(server.Status.Manufacturer)
+	model := bmc.NormalizeModel(server.Status.Manufacturer, server.Status.Model)
+	return (r.Manufacturer == "" || strings.EqualFold(bmc.NormalizeManufacturer(r.Manufacturer), manufacturer)) &&
+		(r.Model == "" || strings.EqualFold(bmc.NormalizeModel(r.Manufacturer, r.Model), model)) &&
+		(r.Architecture == "" || r.Architecture == server.Status.Architecture)
+}
+
+// discoveryImageForServer returns the probe OS image of the first rule of the discovery image ConfigMap
+// matching the Server, or the global ProbeOSImage if no rule matches.
+func (r *ServerReconciler) discoveryImageForServer(ctx context.Context, server *metalv1alpha1.Server) (string, error) {
+	if r.DiscoveryImageConfigMap == "" {
+		return r.ProbeOSImage, nil
+	}
+	configMap := &v1.ConfigMap{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: r.ManagerNamespace, Name: r.DiscoveryImageConfigMap}, configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return r.ProbeOSImage, nil
+		}
+		return "", fmt.Errorf("failed to get discovery image ConfigMap: %w", err)
+	}
+	var rules []DiscoveryImageRule
+	if err := yaml.Unmarshal([]byte(configMap.Data[DiscoveryImagesKey]), &rules); err != nil {
+		return "", fmt.Errorf("failed to unmarshal discovery image rules: %w", err)
+	}
+	for _, rule := range rules {
+		if rule.Image != "" && rule.matches(server) {
+			return rule.Image, nil
+		}
+	}
+	return r.ProbeOSImage, nil
+}
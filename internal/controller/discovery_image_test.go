@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+)
+
+var _ = Describe("Discovery Images", func() {
+	ns := SetupTest()
+
+	It("Should select the probe OS image of the first matching rule", func(ctx SpecContext) {
+		By("Creating the discovery image ConfigMap")
+		configMap := &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns.Name,
+				Name:      "discovery-images",
+			},
+			Data: map[string]string{
+				DiscoveryImagesKey: `
+- manufacturer: Contoso
+  model: "3500"
+  image: contosoOS:edge
+- manufacturer: Contoso
+  image: contosoOS:latest
+`,
+			},
+		}
+		Expect(k8sClient.Create(ctx, configMap)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, configMap)
+
+		reconciler := &ServerReconciler{
+			Client:                  k8sClient,
+			ManagerNamespace:        ns.Name,
+			ProbeOSImage:            "fooOS:latest",
+			DiscoveryImageConfigMap: configMap.Name,
+		}
+		server := &metalv1alpha1.Server{}
+
+		By("Ensuring that the model specific image is selected")
+		server.Status.Manufacturer = "Contoso"
+		server.Status.Model = "3500"
+		Eventually(func() (string, error) {
+			return reconciler.discoveryImageForServer(ctx, server)
+		}).Should(Equal("contosoOS:edge"))
+
+		By("Ensuring that the manufacturer specific image is selected")
+		server.Status.Model = "3000"
+		Expect(reconciler.discoveryImageForServer(ctx, server)).To(Equal("contosoOS:latest"))
+
+		By("Ensuring that manufacturers are matched by their canonical names")
+		server.Status.Manufacturer = "CONTOSO Inc."
+		server.Status.Model = "Contoso 3500"
+		Expect(reconciler.discoveryImageForServer(ctx, server)).To(Equal("contosoOS:edge"))
+
+		By("Ensuring that the global image is selected for other servers")
+		server.Status.Manufacturer = "Fabrikam"
+		Expect(reconciler.discoveryImageForServer(ctx, server)).To(Equal("fooOS:latest"))
+	})
+})
@@ -0,0 +1,361 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// DriveFirmwareReconciler reconciles a DriveFirmware object
+type DriveFirmwareReconciler struct {
+	client.Client
+	Scheme         *runtime.Scheme
+	Insecure       bool
+	BMCOptions     bmc.BMCOptions
+	ResyncInterval time.Duration
+}
+
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=drivefirmwares,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=drivefirmwares/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=drivefirmwares/finalizers,verbs=update
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=componentfirmwares,verbs=get;list;watch
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=servers,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *DriveFirmwareReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	firmware := &metalv1alpha1.DriveFirmware{}
+	if err := r.Get(ctx, req.NamespacedName, firmware); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	return r.reconcileExists(ctx, log, firmware)
+}
+
+func (r *DriveFirmwareReconciler) reconcileExists(ctx context.Context, log logr.Logger, firmware *metalv1alpha1.DriveFirmware) (ctrl.Result, error) {
+	if !firmware.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	return r.reconcile(ctx, log, firmware)
+}
+
+func (r *DriveFirmwareReconciler) reconcile(ctx context.Context, log logr.Logger, firmware *metalv1alpha1.DriveFirmware) (ctrl.Result, error) {
+	if paused, remaining := shouldIgnoreReconciliation(firmware); paused {
+		log.V(1).Info("Skipped DriveFirmware reconciliation")
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	switch firmware.Status.State {
+	case metalv1alpha1.DriveFirmwareStateCompleted, metalv1alpha1.DriveFirmwareStateFailed:
+		log.V(1).Info("DriveFirmware update already finished", "State", firmware.Status.State)
+		return r.handleFinishedState(ctx, log, firmware)
+	}
+
+	server := &metalv1alpha1.Server{}
+	if err := r.Get(ctx, client.ObjectKey{Name: firmware.Spec.ServerRef.Name}, server); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get server %s: %w", firmware.Spec.ServerRef.Name, err)
+	}
+
+	if firmware.Status.State == "" || firmware.Status.State == metalv1alpha1.DriveFirmwareStatePending {
+		return r.handlePendingState(ctx, log, firmware, server)
+	}
+	return r.handleInProgressState(ctx, log, firmware, server)
+}
+
+func (r *DriveFirmwareReconciler) handlePendingState(ctx context.Context, log logr.Logger, firmware *metalv1alpha1.DriveFirmware, server *metalv1alpha1.Server) (ctrl.Result, error) {
+	firmwareBase := firmware.DeepCopy()
+	firmware.Status.State = metalv1alpha1.DriveFirmwareStatePending
+	if !isDryRun(firmware) {
+		meta.RemoveStatusCondition(&firmware.Status.Conditions, ConditionDryRun)
+	}
+
+	// Drives of a server which is in use by a claim must not be touched.
+	inUse := firmwareServerInUseCondition(server)
+	meta.SetStatusCondition(&firmware.Status.Conditions, inUse)
+	if inUse.Status == metav1.ConditionTrue {
+		log.V(1).Info("Server is in use, waiting for it to be released", "Reason", inUse.Reason)
+		if err := r.Status().Patch(ctx, firmware, client.MergeFrom(firmwareBase)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to patch DriveFirmware status: %w", err)
+		}
+		return ctrl.Result{RequeueAfter: r.ResyncInterval}, nil
+	}
+
+	// Updates are serialized per server.
+	busy, err := hasFirmwareUpdateInProgress(ctx, r.Client, server.Name, firmware)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if busy {
+		log.V(1).Info("Another firmware update is in progress for server", "Server", server.Name)
+		if err := r.Status().Patch(ctx, firmware, client.MergeFrom(firmwareBase)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to patch DriveFirmware status: %w", err)
+		}
+		return ctrl.Result{RequeueAfter: r.ResyncInterval}, nil
+	}
+
+	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.BMCOptions)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to create BMC client: %w", err)
+	}
+	defer bmcClient.Logout()
+
+	drives, err := r.getSelectedDrives(ctx, bmcClient, firmware, server)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	firmware.Status.Drives = make([]metalv1alpha1.DriveFirmwareProgress, 0, len(drives))
+	for _, drive := range drives {
+		state := metalv1alpha1.DriveFirmwareStatePending
+		if drive.FirmwareVersion == firmware.Spec.Version {
+			state = metalv1alpha1.DriveFirmwareStateCompleted
+		}
+		firmware.Status.Drives = append(firmware.Status.Drives, metalv1alpha1.DriveFirmwareProgress{
+			Name:    drive.Name,
+			Storage: drive.storage,
+			Version: drive.FirmwareVersion,
+			State:   state,
+		})
+	}
+	if isDryRun(firmware) {
+		var actions []string
+		for _, drive := range firmware.Status.Drives {
+			if drive.State == metalv1alpha1.DriveFirmwareStatePending {
+				actions = append(actions, fmt.Sprintf("update drive %s from %s to %s", drive.Name, drive.Version, firmware.Spec.Version))
+			}
+		}
+		setDryRunCondition(&firmware.Status.Conditions, firmware.Generation, actions)
+		if err := r.Status().Patch(ctx, firmware, client.MergeFrom(firmwareBase)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to patch DriveFirmware status: %w", err)
+		}
+		log.V(1).Info("Planned drive firmware update", "Actions", len(actions))
+		return ctrl.Result{RequeueAfter: r.ResyncInterval}, nil
+	}
+	firmware.Status.State = metalv1alpha1.DriveFirmwareStateInProgress
+	if err := r.Status().Patch(ctx, firmware, client.MergeFrom(firmwareBase)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to patch DriveFirmware status: %w", err)
+	}
+	log.V(1).Info("Started drive firmware update", "Drives", len(drives))
+	return ctrl.Result{Requeue: true}, nil
+}
+
+func (r *DriveFirmwareReconciler) handleInProgressState(ctx context.Context, log logr.Logger, firmware *metalv1alpha1.DriveFirmware, server *metalv1alpha1.Server) (ctrl.Result, error) {
+	firmwareBase := firmware.DeepCopy()
+
+	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.BMCOptions)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to create BMC client: %w", err)
+	}
+	defer bmcClient.Logout()
+
+	drives, err := r.getSelectedDrives(ctx, bmcClient, firmware, server)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	drivesByName := make(map[string]selectedDrive, len(drives))
+	for _, drive := range drives {
+		drivesByName[drive.Name] = drive
+	}
+
+	// Drives are updated one after another, so there is at most one drive in progress.
+	for i := range firmware.Status.Drives {
+		progress := &firmware.Status.Drives[i]
+		if drive, ok := drivesByName[progress.Name]; ok {
+			progress.Version = drive.FirmwareVersion
+		}
+		switch progress.State {
+		case metalv1alpha1.DriveFirmwareStateCompleted:
+			continue
+		case metalv1alpha1.DriveFirmwareStateFailed:
+			firmware.Status.State = metalv1alpha1.DriveFirmwareStateFailed
+			return ctrl.Result{}, r.patchStatus(ctx, firmware, firmwareBase)
+		case metalv1alpha1.DriveFirmwareStateInProgress:
+			done, err := r.updateDriveProgress(ctx, bmcClient, progress)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if progress.State == metalv1alpha1.DriveFirmwareStateFailed {
+				log.V(1).Info("Drive firmware update failed", "Drive", progress.Name, "Message", progress.Message)
+				firmware.Status.State = metalv1alpha1.DriveFirmwareStateFailed
+				return ctrl.Result{}, r.patchStatus(ctx, firmware, firmwareBase)
+			}
+			if !done {
+				return ctrl.Result{RequeueAfter: r.ResyncInterval}, r.patchStatus(ctx, firmware, firmwareBase)
+			}
+			continue
+		default:
+			drive, ok := drivesByName[progress.Name]
+			if !ok {
+				progress.State = metalv1alpha1.DriveFirmwareStateFailed
+				progress.Message = "Drive is no longer present"
+				firmware.Status.State = metalv1alpha1.DriveFirmwareStateFailed
+				return ctrl.Result{}, r.patchStatus(ctx, firmware, firmwareBase)
+			}
+			// The server may have been claimed since the rollout started, so the gate is checked before each drive.
+			inUse := firmwareServerInUseCondition(server)
+			meta.SetStatusCondition(&firmware.Status.Conditions, inUse)
+			if inUse.Status == metav1.ConditionTrue {
+				log.V(1).Info("Server is in use, deferring the update of the next drive", "Drive", drive.Name, "Reason", inUse.Reason)
+				return ctrl.Result{RequeueAfter: r.ResyncInterval}, r.patchStatus(ctx, firmware, firmwareBase)
+			}
+			taskURI, err := bmcClient.UpdateFirmware(ctx, bmc.FirmwareUpdateParameters{
+				ImageURI:         firmware.Spec.Image.URI,
+				TransferProtocol: firmware.Spec.Image.TransferProtocol,
+				Targets:          []string{drive.URI},
+			})
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to update firmware of drive %s: %w", drive.Name, err)
+			}
+			log.V(1).Info("Triggered drive firmware update", "Drive", drive.Name, "Task", taskURI)
+			progress.State = metalv1alpha1.DriveFirmwareStateInProgress
+			progress.TaskURI = taskURI
+			return ctrl.Result{RequeueAfter: r.ResyncInterval}, r.patchStatus(ctx, firmware, firmwareBase)
+		}
+	}
+
+	// All drives report a finished update, verify the running firmware version.
+	var mismatch []string
+	for _, progress := range firmware.Status.Drives {
+		if progress.Version != firmware.Spec.Version {
+			mismatch = append(mismatch, progress.Name)
+		}
+	}
+	if len(mismatch) > 0 {
+		meta.SetStatusCondition(&firmware.Status.Conditions, metav1.Condition{
+			Type:    FirmwareConditionVerified,
+			Status:  metav1.ConditionFalse,
+			Reason:  "VersionMismatch",
+			Message: fmt.Sprintf("Drives %v are not running version %s", mismatch, firmware.Spec.Version),
+		})
+		firmware.Status.State = metalv1alpha1.DriveFirmwareStateFailed
+		return ctrl.Result{}, r.patchStatus(ctx, firmware, firmwareBase)
+	}
+	meta.SetStatusCondition(&firmware.Status.Conditions, metav1.Condition{
+		Type:   FirmwareConditionVerified,
+		Status: metav1.ConditionTrue,
+		Reason: "VersionMatch",
+	})
+	firmware.Status.State = metalv1alpha1.DriveFirmwareStateCompleted
+	log.V(1).Info("Completed drive firmware update")
+	return ctrl.Result{}, r.patchStatus(ctx, firmware, firmwareBase)
+}
+
+func (r *DriveFirmwareReconciler) updateDriveProgress(ctx context.Context, bmcClient bmc.BMC, progress *metalv1alpha1.DriveFirmwareProgress) (bool, error) {
+	task, done, failed, err := getFirmwareUpdateTask(ctx, bmcClient, progress.TaskURI)
+	if err != nil {
+		return false, fmt.Errorf("failed to get firmware update progress of drive %s: %w", progress.Name, err)
+	}
+	progress.PercentComplete = int32(task.PercentComplete)
+	progress.Message = task.Message
+	if done {
+		progress.State = metalv1alpha1.DriveFirmwareStateCompleted
+		if failed {
+			progress.State = metalv1alpha1.DriveFirmwareStateFailed
+		}
+	}
+	return done, nil
+}
+
+// handleFinishedState deletes the DriveFirmware once the TTL after its completion has expired. Updates which
+// finished before the completion time was recorded start their TTL now.
+func (r *DriveFirmwareReconciler) handleFinishedState(ctx context.Context, log logr.Logger, firmware *metalv1alpha1.DriveFirmware) (ctrl.Result, error) {
+	if firmware.Spec.TTLSecondsAfterFinished == nil {
+		return ctrl.Result{}, nil
+	}
+	if firmware.Status.CompletionTime == nil {
+		return ctrl.Result{}, r.patchStatus(ctx, firmware, firmware.DeepCopy())
+	}
+	if remaining := ttlAfterFinished(*firmware.Spec.TTLSecondsAfterFinished, *firmware.Status.CompletionTime); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+	if err := r.Delete(ctx, firmware); client.IgnoreNotFound(err) != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to delete finished DriveFirmware: %w", err)
+	}
+	log.V(1).Info("Deleted finished DriveFirmware after its TTL expired")
+	return ctrl.Result{}, nil
+}
+
+func (r *DriveFirmwareReconciler) patchStatus(ctx context.Context, firmware, firmwareBase *metalv1alpha1.DriveFirmware) error {
+	switch firmware.Status.State {
+	case metalv1alpha1.DriveFirmwareStateCompleted, metalv1alpha1.DriveFirmwareStateFailed:
+		if firmware.Status.CompletionTime == nil {
+			now := metav1.Now()
+			firmware.Status.CompletionTime = &now
+		}
+	}
+	if err := r.Status().Patch(ctx, firmware, client.MergeFrom(firmwareBase)); err != nil {
+		return fmt.Errorf("failed to patch DriveFirmware status: %w", err)
+	}
+	if firmware.Status.State == metalv1alpha1.DriveFirmwareStateFailed && firmwareBase.Status.State != metalv1alpha1.DriveFirmwareStateFailed {
+		return markServerFirmwareUpdateFailed(ctx, r.Client, firmware.Spec.ServerRef.Name, "DriveFirmware", firmware.Name)
+	}
+	return nil
+}
+
+type selectedDrive struct {
+	bmc.Drive
+	storage string
+}
+
+func (r *DriveFirmwareReconciler) getSelectedDrives(ctx context.Context, bmcClient bmc.BMC, firmware *metalv1alpha1.DriveFirmware, server *metalv1alpha1.Server) ([]selectedDrive, error) {
+	storages, err := bmcClient.GetStorages(ctx, server.Spec.SystemUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storages for Server: %w", err)
+	}
+	var drives []selectedDrive
+	for _, storage := range storages {
+		for _, drive := range storage.Drives {
+			if drive.Model != firmware.Spec.Model {
+				continue
+			}
+			drives = append(drives, selectedDrive{Drive: drive, storage: storage.Name})
+		}
+	}
+	return drives, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DriveFirmwareReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&metalv1alpha1.DriveFirmware{}).
+		Watches(&metalv1alpha1.Server{}, r.enqueueDriveFirmwareByServerRefs()).
+		Complete(r)
+}
+
+func (r *DriveFirmwareReconciler) enqueueDriveFirmwareByServerRefs() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
+		log := ctrl.LoggerFrom(ctx)
+
+		server := object.(*metalv1alpha1.Server)
+		firmwareList := &metalv1alpha1.DriveFirmwareList{}
+		if err := r.List(ctx, firmwareList); err != nil {
+			log.Error(err, "failed to list DriveFirmwares")
+			return nil
+		}
+		var req []reconcile.Request
+		for _, firmware := range firmwareList.Items {
+			if firmware.Spec.ServerRef.Name == server.Name {
+				req = append(req, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: firmware.Name},
+				})
+			}
+		}
+		return req
+	})
+}
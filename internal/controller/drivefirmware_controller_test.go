@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+)
+
+var _ = Describe("DriveFirmware Controller", func() {
+	_ = SetupTest()
+
+	var server *metalv1alpha1.Server
+
+	BeforeEach(func(ctx SpecContext) {
+		By("Creating a claimed Server object")
+		server = &metalv1alpha1.Server{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Annotations: map[string]string{
+					metalv1alpha1.OperationAnnotation: metalv1alpha1.OperationAnnotationIgnore,
+				},
+			},
+			Spec: metalv1alpha1.ServerSpec{
+				SystemUUID: "38947555-7742-3448-3784-823347823834",
+				ServerClaimRef: &v1.ObjectReference{
+					Namespace: "foo",
+					Name:      "bar",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, server)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, server)
+	})
+
+	It("should not update the drives of a claimed server", func(ctx SpecContext) {
+		By("Creating a DriveFirmware object")
+		firmware := &metalv1alpha1.DriveFirmware{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.DriveFirmwareSpec{
+				ServerRef: v1.LocalObjectReference{Name: server.Name},
+				Model:     "foo",
+				Version:   "1.0.0",
+				Image: metalv1alpha1.FirmwareImage{
+					URI: "http://example.com/drive-firmware.bin",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, firmware)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, firmware)
+
+		By("Ensuring that the update waits for the server to be released")
+		Eventually(Object(firmware)).Should(SatisfyAll(
+			HaveField("Status.State", metalv1alpha1.DriveFirmwareStatePending),
+			HaveField("Status.Conditions", ContainElement(SatisfyAll(
+				HaveField("Type", FirmwareConditionServerInUse),
+				HaveField("Status", metav1.ConditionTrue),
+			))),
+		))
+		Consistently(Object(firmware)).Should(HaveField("Status.Drives", BeEmpty()))
+	})
+
+	It("should not update the next drive of a server claimed during the rollout", func(ctx SpecContext) {
+		By("Registering a simulated BMC with a drive of the model")
+		simulator := bmc.NewSimulator()
+		simulator.Update(func(state *bmc.SimulatorState) {
+			state.Systems[0].Info.SystemUUID = "38947555-7742-3448-3784-823347823835"
+			state.Systems[0].Storages = []bmc.Storage{{
+				Entity: bmc.Entity{ID: "1", Name: "Storage"},
+				Drives: []bmc.Drive{{
+					Entity:          bmc.Entity{ID: "Disk.0", Name: "Disk 0"},
+					Model:           "foo",
+					FirmwareVersion: "0.9.0",
+					URI:             "/redfish/v1/Systems/437XR1138R2/Storage/1/Drives/Disk.0",
+				}},
+			}}
+		})
+		bmc.Simulators.Register("10.30.0.1:8000", simulator)
+
+		By("Creating a BMCSecret")
+		bmcSecret := &metalv1alpha1.BMCSecret{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Data: map[string][]byte{
+				metalv1alpha1.BMCSecretUsernameKeyName: []byte("foo"),
+				metalv1alpha1.BMCSecretPasswordKeyName: []byte("bar"),
+			},
+		}
+		Expect(k8sClient.Create(ctx, bmcSecret)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, bmcSecret)
+
+		By("Creating a claimed Server object behind the simulated BMC")
+		claimedServer := &metalv1alpha1.Server{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Annotations: map[string]string{
+					metalv1alpha1.OperationAnnotation: metalv1alpha1.OperationAnnotationIgnore,
+				},
+			},
+			Spec: metalv1alpha1.ServerSpec{
+				SystemUUID: "38947555-7742-3448-3784-823347823835",
+				BMC: &metalv1alpha1.BMCAccess{
+					Protocol: metalv1alpha1.Protocol{
+						Name: metalv1alpha1.ProtocolRedfishFake,
+						Port: 8000,
+					},
+					Address:      "10.30.0.1",
+					BMCSecretRef: v1.LocalObjectReference{Name: bmcSecret.Name},
+				},
+				ServerClaimRef: &v1.ObjectReference{
+					Namespace: "foo",
+					Name:      "bar",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, claimedServer)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, claimedServer)
+
+		By("Creating a DriveFirmware object")
+		firmware := &metalv1alpha1.DriveFirmware{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.DriveFirmwareSpec{
+				ServerRef: v1.LocalObjectReference{Name: claimedServer.Name},
+				Model:     "foo",
+				Version:   "1.0.0",
+				Image: metalv1alpha1.FirmwareImage{
+					URI: "http://example.com/drive-firmware.bin",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, firmware)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, firmware)
+		Eventually(Object(firmware)).Should(HaveField("Status.State", metalv1alpha1.DriveFirmwareStatePending))
+
+		By("Resuming the rollout as if the server had been claimed after it started")
+		Eventually(UpdateStatus(firmware, func() {
+			firmware.Status.State = metalv1alpha1.DriveFirmwareStateInProgress
+			firmware.Status.Drives = []metalv1alpha1.DriveFirmwareProgress{{
+				Name:    "Disk 0",
+				Storage: "Storage",
+				Version: "0.9.0",
+				State:   metalv1alpha1.DriveFirmwareStatePending,
+			}}
+		})).Should(Succeed())
+
+		By("Ensuring that the next drive is not updated")
+		Eventually(Object(firmware)).Should(SatisfyAll(
+			HaveField("Status.State", metalv1alpha1.DriveFirmwareStateInProgress),
+			HaveField("Status.Conditions", ContainElement(SatisfyAll(
+				HaveField("Type", FirmwareConditionServerInUse),
+				HaveField("Status", metav1.ConditionTrue),
+				HaveField("Reason", "ServerClaimed"),
+			))),
+		))
+		Consistently(func() []bmc.FirmwareUpdateParameters {
+			return simulator.State().FirmwareUpdates
+		}).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,302 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+	"github.com/stmcginnis/gofish/common"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// DriveReplacementConditionDriveVerified reports whether the drive inserted into the bay of the replaced drive
+	// is healthy and runs the expected firmware version.
+	DriveReplacementConditionDriveVerified = "DriveVerified"
+
+	driveReplacementReasonDriveHealthy   = "DriveHealthy"
+	driveReplacementReasonDriveUnhealthy = "DriveUnhealthy"
+	driveReplacementReasonVersionMatch   = "VersionMatch"
+	driveReplacementReasonVersionDiffers = "VersionMismatch"
+)
+
+// DriveReplacementReconciler reconciles a DriveReplacement object
+type DriveReplacementReconciler struct {
+	client.Client
+	Scheme         *runtime.Scheme
+	Insecure       bool
+	BMCOptions     bmc.BMCOptions
+	ResyncInterval time.Duration
+}
+
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=drivereplacements,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=drivereplacements/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=servers,verbs=get;list;watch
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=servers/status,verbs=get;update;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *DriveReplacementReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	replacement := &metalv1alpha1.DriveReplacement{}
+	if err := r.Get(ctx, req.NamespacedName, replacement); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	return r.reconcileExists(ctx, log, replacement)
+}
+
+func (r *DriveReplacementReconciler) reconcileExists(ctx context.Context, log logr.Logger, replacement *metalv1alpha1.DriveReplacement) (ctrl.Result, error) {
+	if !replacement.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	return r.reconcile(ctx, log, replacement)
+}
+
+func (r *DriveReplacementReconciler) reconcile(ctx context.Context, log logr.Logger, replacement *metalv1alpha1.DriveReplacement) (ctrl.Result, error) {
+	if paused, remaining := shouldIgnoreReconciliation(replacement); paused {
+		log.V(1).Info("Skipped DriveReplacement reconciliation")
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	switch replacement.Status.State {
+	case metalv1alpha1.DriveReplacementStateCompleted, metalv1alpha1.DriveReplacementStateFailed:
+		log.V(1).Info("DriveReplacement already finished", "State", replacement.Status.State)
+		return ctrl.Result{}, nil
+	}
+
+	replacementBase := replacement.DeepCopy()
+	server := &metalv1alpha1.Server{}
+	if err := r.Get(ctx, client.ObjectKey{Name: replacement.Spec.ServerRef.Name}, server); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("failed to get server %s: %w", replacement.Spec.ServerRef.Name, err)
+		}
+		return ctrl.Result{}, r.fail(ctx, replacement, replacementBase, fmt.Sprintf("Server %s not found", replacement.Spec.ServerRef.Name))
+	}
+
+	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.BMCOptions)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to create BMC client: %w", err)
+	}
+	defer bmcClient.Logout()
+
+	storages, err := bmcClient.GetStorages(ctx, server.Spec.SystemUUID)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get storages for Server: %w", err)
+	}
+	storage, drive, found := findReplacedDrive(storages, replacement.Spec.DriveName)
+
+	switch replacement.Status.State {
+	case "", metalv1alpha1.DriveReplacementStatePending:
+		if !found {
+			return ctrl.Result{}, r.fail(ctx, replacement, replacementBase, fmt.Sprintf("Drive %s not found", replacement.Spec.DriveName))
+		}
+		if err := bmcClient.SetDriveLocationIndicator(ctx, server.Spec.SystemUUID, replacement.Spec.DriveName, true); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to turn on location indicator of drive %s: %w", replacement.Spec.DriveName, err)
+		}
+		replacement.Status.State = metalv1alpha1.DriveReplacementStateAwaitingSwap
+		replacement.Status.OldSerialNumber = drive.SerialNumber
+		replacement.Status.DriveRemoved = drive.State == common.AbsentState
+		replacement.Status.Message = fmt.Sprintf("Waiting for drive %s to be swapped", replacement.Spec.DriveName)
+		if drive.Bay != "" {
+			replacement.Status.Message = fmt.Sprintf("Waiting for drive %s in %s to be swapped", replacement.Spec.DriveName, drive.Bay)
+		}
+		log.V(1).Info("Turned on location indicator of drive to be replaced", "Drive", replacement.Spec.DriveName)
+		return ctrl.Result{RequeueAfter: r.ResyncInterval}, r.patchStatus(ctx, replacement, replacementBase)
+
+	case metalv1alpha1.DriveReplacementStateAwaitingSwap:
+		if !found || drive.State == common.AbsentState {
+			if !replacement.Status.DriveRemoved {
+				log.V(1).Info("Drive has been removed", "Drive", replacement.Spec.DriveName)
+			}
+			replacement.Status.DriveRemoved = true
+			return ctrl.Result{RequeueAfter: r.ResyncInterval}, r.patchStatus(ctx, replacement, replacementBase)
+		}
+		if !driveSwapped(replacement, drive) {
+			if !drive.LocationIndicatorActive {
+				if err := bmcClient.SetDriveLocationIndicator(ctx, server.Spec.SystemUUID, replacement.Spec.DriveName, true); err != nil {
+					return ctrl.Result{}, fmt.Errorf("failed to turn on location indicator of drive %s: %w", replacement.Spec.DriveName, err)
+				}
+			}
+			return ctrl.Result{RequeueAfter: r.ResyncInterval}, nil
+		}
+		if err := bmcClient.SetDriveLocationIndicator(ctx, server.Spec.SystemUUID, replacement.Spec.DriveName, false); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to turn off location indicator of drive %s: %w", replacement.Spec.DriveName, err)
+		}
+		replacement.Status.State = metalv1alpha1.DriveReplacementStateVerifying
+		replacement.Status.NewSerialNumber = drive.SerialNumber
+		replacement.Status.Message = fmt.Sprintf("Drive %s has been swapped", replacement.Spec.DriveName)
+		log.V(1).Info("Detected swap of drive", "Drive", replacement.Spec.DriveName, "SerialNumber", drive.SerialNumber)
+		return ctrl.Result{Requeue: true}, r.patchStatus(ctx, replacement, replacementBase)
+
+	case metalv1alpha1.DriveReplacementStateVerifying:
+		if !found || drive.State == common.AbsentState {
+			// The new drive has been pulled again, start over waiting for a drive to be inserted.
+			replacement.Status.State = metalv1alpha1.DriveReplacementStateAwaitingSwap
+			replacement.Status.DriveRemoved = true
+			return ctrl.Result{Requeue: true}, r.patchStatus(ctx, replacement, replacementBase)
+		}
+		if drive.Health == common.CriticalHealth {
+			meta.SetStatusCondition(&replacement.Status.Conditions, metav1.Condition{
+				Type:    DriveReplacementConditionDriveVerified,
+				Status:  metav1.ConditionFalse,
+				Reason:  driveReplacementReasonDriveUnhealthy,
+				Message: fmt.Sprintf("Drive reports health %s", drive.Health),
+			})
+			return ctrl.Result{}, r.fail(ctx, replacement, replacementBase, fmt.Sprintf("New drive %s has failed", replacement.Spec.DriveName))
+		}
+		if !driveHealthy(drive) {
+			replacement.Status.Message = fmt.Sprintf("Waiting for drive %s to become healthy", replacement.Spec.DriveName)
+			return ctrl.Result{RequeueAfter: r.ResyncInterval}, r.patchStatus(ctx, replacement, replacementBase)
+		}
+		if replacement.Spec.FirmwareVersion != "" && drive.FirmwareVersion != replacement.Spec.FirmwareVersion {
+			meta.SetStatusCondition(&replacement.Status.Conditions, metav1.Condition{
+				Type:    DriveReplacementConditionDriveVerified,
+				Status:  metav1.ConditionFalse,
+				Reason:  driveReplacementReasonVersionDiffers,
+				Message: fmt.Sprintf("Drive runs firmware version %s instead of %s", drive.FirmwareVersion, replacement.Spec.FirmwareVersion),
+			})
+			return ctrl.Result{}, r.fail(ctx, replacement, replacementBase, fmt.Sprintf("New drive %s runs an unexpected firmware version", replacement.Spec.DriveName))
+		}
+		reason := driveReplacementReasonDriveHealthy
+		if replacement.Spec.FirmwareVersion != "" {
+			reason = driveReplacementReasonVersionMatch
+		}
+		meta.SetStatusCondition(&replacement.Status.Conditions, metav1.Condition{
+			Type:   DriveReplacementConditionDriveVerified,
+			Status: metav1.ConditionTrue,
+			Reason: reason,
+		})
+		if replacement.Spec.WaitForRebuild {
+			replacement.Status.State = metalv1alpha1.DriveReplacementStateRebuilding
+			replacement.Status.Message = fmt.Sprintf("Waiting for the volumes of storage %s to be rebuilt", storage.Name)
+			return ctrl.Result{Requeue: true}, r.patchStatus(ctx, replacement, replacementBase)
+		}
+		return ctrl.Result{}, r.complete(ctx, log, replacement, replacementBase, server, storages)
+
+	case metalv1alpha1.DriveReplacementStateRebuilding:
+		if !volumesHealthy(storage) {
+			return ctrl.Result{RequeueAfter: r.ResyncInterval}, nil
+		}
+		return ctrl.Result{}, r.complete(ctx, log, replacement, replacementBase, server, storages)
+	}
+	return ctrl.Result{}, nil
+}
+
+// complete refreshes the storages of the Server, which clears its Degraded condition once all drives are healthy,
+// and marks the replacement as completed.
+func (r *DriveReplacementReconciler) complete(ctx context.Context, log logr.Logger, replacement, replacementBase *metalv1alpha1.DriveReplacement, server *metalv1alpha1.Server, storages []bmc.Storage) error {
+	serverBase := server.DeepCopy()
+	server.Status.Storages = storageStatus(storages)
+	summarizeServerStatus(server)
+	setDegradedCondition(server)
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return fmt.Errorf("failed to patch Server status: %w", err)
+	}
+
+	replacement.Status.State = metalv1alpha1.DriveReplacementStateCompleted
+	replacement.Status.Message = fmt.Sprintf("Drive %s has been replaced", replacement.Spec.DriveName)
+	log.V(1).Info("Completed drive replacement", "Drive", replacement.Spec.DriveName)
+	return r.patchStatus(ctx, replacement, replacementBase)
+}
+
+func (r *DriveReplacementReconciler) fail(ctx context.Context, replacement, replacementBase *metalv1alpha1.DriveReplacement, message string) error {
+	replacement.Status.State = metalv1alpha1.DriveReplacementStateFailed
+	replacement.Status.Message = message
+	return r.patchStatus(ctx, replacement, replacementBase)
+}
+
+func (r *DriveReplacementReconciler) patchStatus(ctx context.Context, replacement, replacementBase *metalv1alpha1.DriveReplacement) error {
+	switch replacement.Status.State {
+	case metalv1alpha1.DriveReplacementStateCompleted, metalv1alpha1.DriveReplacementStateFailed:
+		if replacement.Status.CompletionTime == nil {
+			now := metav1.Now()
+			replacement.Status.CompletionTime = &now
+		}
+	}
+	if err := r.Status().Patch(ctx, replacement, client.MergeFrom(replacementBase)); err != nil {
+		return fmt.Errorf("failed to patch DriveReplacement status: %w", err)
+	}
+	return nil
+}
+
+// findReplacedDrive returns the drive with the given name and the storage it is attached to.
+func findReplacedDrive(storages []bmc.Storage, name string) (bmc.Storage, bmc.Drive, bool) {
+	for _, storage := range storages {
+		for _, drive := range storage.Drives {
+			if drive.Name == name {
+				return storage, drive, true
+			}
+		}
+	}
+	return bmc.Storage{}, bmc.Drive{}, false
+}
+
+// driveSwapped reports whether the present drive is a different one than the drive to be replaced, either because
+// the drive has been observed absent in between or because its serial number changed.
+func driveSwapped(replacement *metalv1alpha1.DriveReplacement, drive bmc.Drive) bool {
+	if replacement.Status.DriveRemoved {
+		return true
+	}
+	return drive.SerialNumber != "" && drive.SerialNumber != replacement.Status.OldSerialNumber
+}
+
+// driveHealthy reports whether the drive is enabled and reports no health issues.
+func driveHealthy(drive bmc.Drive) bool {
+	return drive.State == common.EnabledState && (drive.Health == "" || drive.Health == common.OKHealth)
+}
+
+// volumesHealthy reports whether all volumes of the storage report no health issues.
+func volumesHealthy(storage bmc.Storage) bool {
+	for _, volume := range storage.Volumes {
+		if volume.Health != "" && volume.Health != common.OKHealth {
+			return false
+		}
+	}
+	return true
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DriveReplacementReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&metalv1alpha1.DriveReplacement{}).
+		Watches(&metalv1alpha1.Server{}, r.enqueueDriveReplacementsByServerRefs()).
+		Complete(r)
+}
+
+func (r *DriveReplacementReconciler) enqueueDriveReplacementsByServerRefs() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
+		log := ctrl.LoggerFrom(ctx)
+
+		server := object.(*metalv1alpha1.Server)
+		replacementList := &metalv1alpha1.DriveReplacementList{}
+		if err := r.List(ctx, replacementList); err != nil {
+			log.Error(err, "failed to list DriveReplacements")
+			return nil
+		}
+		var req []reconcile.Request
+		for _, replacement := range replacementList.Items {
+			if replacement.Spec.ServerRef.Name == server.Name {
+				req = append(req, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: replacement.Name},
+				})
+			}
+		}
+		return req
+	})
+}
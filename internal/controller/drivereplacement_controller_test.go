@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+)
+
+var _ = Describe("DriveReplacement Controller", func() {
+	_ = SetupTest()
+
+	It("should fail the replacement of a drive of an unknown server", func(ctx SpecContext) {
+		By("Creating a DriveReplacement object")
+		replacement := &metalv1alpha1.DriveReplacement{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.DriveReplacementSpec{
+				ServerRef: v1.LocalObjectReference{Name: "does-not-exist"},
+				DriveName: "SATA Bay 1",
+			},
+		}
+		Expect(k8sClient.Create(ctx, replacement)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, replacement)
+
+		By("Ensuring that the replacement has failed")
+		Eventually(Object(replacement)).Should(SatisfyAll(
+			HaveField("Status.State", metalv1alpha1.DriveReplacementStateFailed),
+			HaveField("Status.Message", ContainSubstring("does-not-exist")),
+			HaveField("Status.CompletionTime", Not(BeNil())),
+		))
+	})
+
+	It("should fail the replacement of an unknown drive", func(ctx SpecContext) {
+		By("Creating a BMCSecret")
+		bmcSecret := &metalv1alpha1.BMCSecret{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Data: map[string][]byte{
+				metalv1alpha1.BMCSecretUsernameKeyName: []byte("foo"),
+				metalv1alpha1.BMCSecretPasswordKeyName: []byte("bar"),
+			},
+		}
+		Expect(k8sClient.Create(ctx, bmcSecret)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, bmcSecret)
+
+		By("Creating a Server")
+		server := &metalv1alpha1.Server{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Annotations: map[string]string{
+					metalv1alpha1.OperationAnnotation: metalv1alpha1.OperationAnnotationIgnore,
+				},
+			},
+			Spec: metalv1alpha1.ServerSpec{
+				UUID:       "38947555-7742-3448-3784-823347823834",
+				SystemUUID: "38947555-7742-3448-3784-823347823834",
+				BMC: &metalv1alpha1.BMCAccess{
+					Protocol: metalv1alpha1.Protocol{
+						Name: metalv1alpha1.ProtocolRedfishLocal,
+						Port: 8000,
+					},
+					Address: "127.0.0.1",
+					BMCSecretRef: v1.LocalObjectReference{
+						Name: bmcSecret.Name,
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, server)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, server)
+
+		By("Creating a DriveReplacement object")
+		replacement := &metalv1alpha1.DriveReplacement{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.DriveReplacementSpec{
+				ServerRef: v1.LocalObjectReference{Name: server.Name},
+				DriveName: "SATA Bay 9",
+			},
+		}
+		Expect(k8sClient.Create(ctx, replacement)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, replacement)
+
+		By("Ensuring that the replacement has failed")
+		Eventually(Object(replacement)).Should(SatisfyAll(
+			HaveField("Status.State", metalv1alpha1.DriveReplacementStateFailed),
+			HaveField("Status.Message", "Drive SATA Bay 9 not found"),
+			HaveField("Status.OldSerialNumber", BeEmpty()),
+		))
+	})
+})
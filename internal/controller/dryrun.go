@@ -0,0 +1,285 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+)
+
+const (
+	// ConditionDryRun reports the actions the reconciliation of a resource with the DryRunAnnotation would
+	// perform on its BMC.
+	ConditionDryRun = "DryRun"
+
+	dryRunReasonActionsPlanned = "ActionsPlanned"
+	dryRunReasonNoActions      = "NoActions"
+	dryRunReasonFailed         = "Failed"
+
+	// maxDryRunPasses bounds the reconciliation passes of a dry-run. As its writes are not persisted, every pass
+	// continues with the objects returned by the server-side dry-runs of the previous one.
+	maxDryRunPasses = 5
+)
+
+// isDryRun reports whether the object requests a dry-run of its reconciliation.
+func isDryRun(obj client.Object) bool {
+	return obj.GetAnnotations()[metalv1alpha1.DryRunAnnotation] == "true"
+}
+
+// setDryRunCondition reports the planned actions in the DryRun condition. It reports whether the condition changed.
+func setDryRunCondition(conditions *[]metav1.Condition, generation int64, actions []string) bool {
+	condition := metav1.Condition{
+		Type:               ConditionDryRun,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             dryRunReasonNoActions,
+		Message:            "No actions are planned",
+	}
+	if len(actions) > 0 {
+		condition.Reason = dryRunReasonActionsPlanned
+		condition.Message = "Planned actions: " + strings.Join(actions, "; ")
+	}
+	return meta.SetStatusCondition(conditions, condition)
+}
+
+// setDryRunFailedCondition reports the actions planned before the dry-run failed in the DryRun condition. It reports
+// whether the condition changed.
+func setDryRunFailedCondition(conditions *[]metav1.Condition, generation int64, actions []string, err error) bool {
+	message := fmt.Sprintf("Dry-run failed: %v", err)
+	if len(actions) > 0 {
+		message = fmt.Sprintf("Planned actions: %s; dry-run failed: %v", strings.Join(actions, "; "), err)
+	}
+	return meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               ConditionDryRun,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             dryRunReasonFailed,
+		Message:            message,
+	})
+}
+
+// clearDryRunCondition removes the DryRun condition of an object whose DryRunAnnotation has been removed. The
+// conditions have to belong to the object.
+func clearDryRunCondition(ctx context.Context, c client.Client, obj client.Object, conditions *[]metav1.Condition) error {
+	if meta.FindStatusCondition(*conditions, ConditionDryRun) == nil {
+		return nil
+	}
+	base := obj.DeepCopyObject().(client.Object)
+	meta.RemoveStatusCondition(conditions, ConditionDryRun)
+	if err := c.Status().Patch(ctx, obj, client.MergeFrom(base)); err != nil {
+		return fmt.Errorf("failed to remove DryRun condition: %w", err)
+	}
+	return nil
+}
+
+// dryRun runs the reconciliation of the Server with the context of a dry-run, in which the BMC clients and the
+// client of the reconciler record their writes instead of performing them, see dryRunClient. The recorded writes
+// are reported in the DryRun condition.
+func (r *ServerReconciler) dryRun(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) (ctrl.Result, error) {
+	if _, ok := r.Client.(*dryRunClient); !ok {
+		return ctrl.Result{}, errors.New("the client of the Server reconciler does not support dry-runs")
+	}
+	if warmUpDelay, err := r.pollServerStatus(ctx, log, server); err != nil || warmUpDelay > 0 {
+		return ctrl.Result{RequeueAfter: warmUpDelay}, err
+	}
+
+	planned := &bmc.PlannedWrites{}
+	plannedServer := server.DeepCopy()
+	var planErr error
+	for range maxDryRunPasses {
+		recorded := len(planned.Actions())
+		if _, planErr = r.reconcile(bmc.WithPlannedWrites(ctx, planned), log, plannedServer); planErr != nil ||
+			len(planned.Actions()) == recorded {
+			break
+		}
+	}
+
+	serverBase := server.DeepCopy()
+	var modified bool
+	if planErr != nil {
+		modified = setDryRunFailedCondition(&server.Status.Conditions, server.Generation, planned.Actions(), planErr)
+	} else {
+		modified = setDryRunCondition(&server.Status.Conditions, server.Generation, planned.Actions())
+	}
+	if modified {
+		if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to patch DryRun condition: %w", err)
+		}
+	}
+	if planErr != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to plan Server reconciliation: %w", planErr)
+	}
+	log.V(1).Info("Planned Server reconciliation", "Actions", planned.Actions())
+	return ctrl.Result{RequeueAfter: resyncAfter(server, r.ResyncInterval)}, nil
+}
+
+// dryRunClient performs the writes requested with the context of a dry-run, see bmc.WithPlannedWrites, as
+// server-side dry-runs and records them as planned writes. The server-side dry-runs still validate the writes and
+// return the objects as they would have been persisted. All other writes are performed as requested.
+type dryRunClient struct {
+	client.Client
+}
+
+// newDryRunClient wraps the client into a dryRunClient.
+func newDryRunClient(c client.Client) client.Client {
+	if _, ok := c.(*dryRunClient); ok {
+		return c
+	}
+	return &dryRunClient{Client: c}
+}
+
+func (c *dryRunClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if c.plan(ctx, "create", obj, "", nil) {
+		opts = append(opts, client.DryRunAll)
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *dryRunClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if c.plan(ctx, "update", obj, "", nil) {
+		opts = append(opts, client.DryRunAll)
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *dryRunClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if c.plan(ctx, "patch", obj, "", patch) {
+		opts = append(opts, client.DryRunAll)
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *dryRunClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if c.plan(ctx, "delete", obj, "", nil) {
+		opts = append(opts, client.DryRunAll)
+	}
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *dryRunClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	if c.plan(ctx, "delete all of", obj, "", nil) {
+		opts = append(opts, client.DryRunAll)
+	}
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c *dryRunClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+func (c *dryRunClient) SubResource(subResource string) client.SubResourceClient {
+	return &dryRunSubResourceClient{SubResourceClient: c.Client.SubResource(subResource), client: c, subResource: subResource}
+}
+
+// plan records the write of the object if the context belongs to a dry-run. It reports whether the write has to be
+// performed as server-side dry-run.
+func (c *dryRunClient) plan(ctx context.Context, verb string, obj client.Object, subResource string, patch client.Patch) bool {
+	planned := bmc.PlannedWritesFrom(ctx)
+	if planned == nil {
+		return false
+	}
+	kind := "object"
+	if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+		kind = gvk.Kind
+	}
+	name := obj.GetName()
+	if obj.GetNamespace() != "" {
+		name = obj.GetNamespace() + "/" + name
+	}
+	action := fmt.Sprintf("%s %s %s", verb, kind, name)
+	if subResource != "" {
+		action = fmt.Sprintf("%s %s of %s %s", verb, subResource, kind, name)
+	}
+	if patch != nil {
+		if data, err := patch.Data(obj); err == nil {
+			_, secret := obj.(*v1.Secret)
+			if fields := patchedFields(data, !secret); len(fields) > 0 {
+				action += ": " + strings.Join(fields, ", ")
+			}
+		}
+	}
+	planned.Record(action)
+	return true
+}
+
+// patchedFields returns the fields set by the JSON patch, up to the second level, e.g. status.state. Values are
+// only included if requested and scalar.
+func patchedFields(data []byte, values bool) []string {
+	var patch map[string]any
+	if err := json.Unmarshal(data, &patch); err != nil {
+		return nil
+	}
+	var fields []string
+	for _, key := range slices.Sorted(maps.Keys(patch)) {
+		nested, ok := patch[key].(map[string]any)
+		if !ok {
+			fields = append(fields, patchedField(key, patch[key], values))
+			continue
+		}
+		for _, nestedKey := range slices.Sorted(maps.Keys(nested)) {
+			if key == "metadata" && nestedKey == "resourceVersion" {
+				continue
+			}
+			fields = append(fields, patchedField(key+"."+nestedKey, nested[nestedKey], values))
+		}
+	}
+	return fields
+}
+
+func patchedField(field string, value any, values bool) string {
+	switch value.(type) {
+	case string, float64, bool:
+		if values {
+			return fmt.Sprintf("%s=%v", field, value)
+		}
+	case nil:
+		return field + " removed"
+	}
+	return field
+}
+
+// dryRunSubResourceClient performs the writes of subresources requested with the context of a dry-run as
+// server-side dry-runs, see dryRunClient.
+type dryRunSubResourceClient struct {
+	client.SubResourceClient
+	client      *dryRunClient
+	subResource string
+}
+
+func (c *dryRunSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	if c.client.plan(ctx, "create", obj, c.subResource, nil) {
+		opts = append(opts, client.DryRunAll)
+	}
+	return c.SubResourceClient.Create(ctx, obj, subResource, opts...)
+}
+
+func (c *dryRunSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	if c.client.plan(ctx, "update", obj, c.subResource, nil) {
+		opts = append(opts, client.DryRunAll)
+	}
+	return c.SubResourceClient.Update(ctx, obj, opts...)
+}
+
+func (c *dryRunSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	if c.client.plan(ctx, "patch", obj, c.subResource, patch) {
+		opts = append(opts, client.DryRunAll)
+	}
+	return c.SubResourceClient.Patch(ctx, obj, patch, opts...)
+}
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"time"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stmcginnis/gofish/redfish"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+)
+
+var _ = Describe("Server Dry-Run", func() {
+	_ = SetupTest()
+
+	const systemUUID = "38947555-7742-3448-3784-823347823851"
+
+	var (
+		simulator *bmc.Simulator
+		server    *metalv1alpha1.Server
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		simulator = registerSimulator("10.30.0.17:8000", systemUUID)
+		simulator.Update(func(state *bmc.SimulatorState) {
+			state.Systems[0].Info.PowerState = redfish.OffPowerState
+		})
+		server = createPausedServer(ctx, "10.30.0.17", systemUUID)
+	})
+
+	It("Should record the writes of the reconciliation without performing them", func(ctx SpecContext) {
+		Eventually(Update(server, func() {
+			delete(server.Annotations, metalv1alpha1.PausedUntilAnnotation)
+			server.Annotations[metalv1alpha1.DryRunAnnotation] = "true"
+		})).Should(Succeed())
+
+		Eventually(Object(server)).Should(HaveField("Status.Conditions", ContainElement(SatisfyAll(
+			HaveField("Type", ConditionDryRun),
+			HaveField("Reason", dryRunReasonActionsPlanned),
+			HaveField("Message", SatisfyAll(
+				ContainSubstring("patch status of Server "+server.Name+": status.state=Initial"),
+				ContainSubstring("patch Server "+server.Name+": metadata.finalizers"),
+			)),
+		))))
+		Consistently(Object(server), time.Second).Should(SatisfyAll(
+			HaveField("Status.State", BeEmpty()),
+			HaveField("Finalizers", BeEmpty()),
+		))
+	})
+
+	It("Should record the writes to the BMC without performing them", func(ctx SpecContext) {
+		reconciler := &ServerReconciler{
+			Client:     newDryRunClient(k8sClient),
+			Insecure:   true,
+			BMCOptions: bmc.BMCOptions{BasicAuth: true},
+		}
+		server.Spec.Power = metalv1alpha1.PowerOn
+		server.Status.PowerState = metalv1alpha1.ServerOffPowerState
+
+		planned := &bmc.PlannedWrites{}
+		Expect(reconciler.ensureServerPowerState(bmc.WithPlannedWrites(ctx, planned), GinkgoLogr, server)).To(Succeed())
+		Expect(planned.Actions()).To(HaveExactElements(
+			"power on system "+systemUUID,
+			HavePrefix("patch status of Server "+server.Name),
+		))
+		Expect(simulator.State().Systems[0].Info.PowerState).To(Equal(redfish.OffPowerState))
+	})
+
+	It("Should perform the writes to Kubernetes as server-side dry-runs", func(ctx SpecContext) {
+		reconciler := &ServerReconciler{Client: newDryRunClient(k8sClient)}
+
+		planned := &bmc.PlannedWrites{}
+		modified, err := reconciler.patchServerState(bmc.WithPlannedWrites(ctx, planned), server, metalv1alpha1.ServerStateDiscovery)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(modified).To(BeTrue())
+		Expect(server.Status.State).To(Equal(metalv1alpha1.ServerStateDiscovery))
+		Expect(planned.Actions()).To(HaveExactElements("patch status of Server " + server.Name + ": status.state=Discovery"))
+		Consistently(Object(server)).Should(HaveField("Status.State", BeEmpty()))
+	})
+})
@@ -18,8 +18,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
 const (
@@ -33,6 +35,9 @@ type EndpointReconciler struct {
 	MACPrefixes *macdb.MacPrefixes
 	Insecure    bool
 	BMCOptions  bmc.BMCOptions
+	// CredentialOnboarding enables the onboarding of Redfish BMCs with the factory-default credentials of their
+	// vendor, whose password is rotated on the first login.
+	CredentialOnboarding bool
 }
 
 //+kubebuilder:rbac:groups=metal.ironcore.dev,resources=bmcs,verbs=get;list;watch;create;update;patch;delete
@@ -70,11 +75,12 @@ func (r *EndpointReconciler) delete(ctx context.Context, log logr.Logger, endpoi
 
 func (r *EndpointReconciler) reconcile(ctx context.Context, log logr.Logger, endpoint *metalv1alpha1.Endpoint) (ctrl.Result, error) {
 	log.V(1).Info("Reconciling endpoint")
-	if shouldIgnoreReconciliation(endpoint) {
+	if paused, remaining := shouldIgnoreReconciliation(endpoint); paused {
 		log.V(1).Info("Skipped Endpoint reconciliation")
-		return ctrl.Result{}, nil
+		return ctrl.Result{RequeueAfter: remaining}, nil
 	}
 
+	var result ctrl.Result
 	sanitizedMACAddress := strings.Replace(endpoint.Spec.MACAddress, ":", "", -1)
 	for _, m := range r.MACPrefixes.MacPrefixes {
 		if strings.HasPrefix(sanitizedMACAddress, m.MacPrefix) && m.Type == metalv1alpha1.BMCType {
@@ -91,6 +97,14 @@ func (r *EndpointReconciler) reconcile(ctx context.Context, log logr.Logger, end
 			case metalv1alpha1.ProtocolRedfish:
 				log.V(1).Info("Creating client for BMC")
 				bmcOptions.Endpoint = fmt.Sprintf("%s://%s", r.getProtocol(), net.JoinHostPort(endpoint.Spec.IP.String(), fmt.Sprintf("%d", m.Port)))
+				if r.CredentialOnboarding {
+					requeueAfter, err := r.onboardBMC(ctx, log, endpoint, m, bmcOptions.Endpoint)
+					if err != nil {
+						return ctrl.Result{}, fmt.Errorf("failed to onboard BMC: %w", err)
+					}
+					result.RequeueAfter = requeueAfter
+					break
+				}
 				log.V(1).Info("Creating client for BMC", "Address", bmcOptions.Endpoint)
 				bmcClient, err := bmc.NewRedfishBMCClient(ctx, bmcOptions)
 				if err != nil {
@@ -151,14 +165,44 @@ func (r *EndpointReconciler) reconcile(ctx context.Context, log logr.Logger, end
 					return ctrl.Result{}, fmt.Errorf("failed to apply BMC object: %w", err)
 				}
 				log.V(1).Info("Applied BMC object for Endpoint")
+			case metalv1alpha1.ProtocolRedfishFake:
+				log.V(1).Info("Creating client for a simulated BMC")
+				hostPort := net.JoinHostPort(endpoint.Spec.IP.String(), fmt.Sprintf("%d", m.Port))
+				if err := bmcutils.LoadSimulatorFixture(ctx, r.Client, hostPort); err != nil {
+					return ctrl.Result{}, err
+				}
+				bmcOptions.Endpoint = fmt.Sprintf("%s://%s", r.getProtocol(), hostPort)
+				if r.CredentialOnboarding {
+					requeueAfter, err := r.onboardBMC(ctx, log, endpoint, m, bmcOptions.Endpoint)
+					if err != nil {
+						return ctrl.Result{}, fmt.Errorf("failed to onboard BMC: %w", err)
+					}
+					result.RequeueAfter = requeueAfter
+					break
+				}
+				bmcClient, err := bmc.NewRedfishFakeBMCClient(ctx, bmcOptions)
+				if err != nil {
+					return ctrl.Result{}, fmt.Errorf("failed to create BMC client: %w", err)
+				}
+				defer bmcClient.Logout()
 
+				var bmcSecret *metalv1alpha1.BMCSecret
+				if bmcSecret, err = r.applyBMCSecret(ctx, log, endpoint, m); err != nil {
+					return ctrl.Result{}, fmt.Errorf("failed to apply BMCSecret: %w", err)
+				}
+				log.V(1).Info("Applied simulated BMC secret for endpoint")
+
+				if err := r.applyBMC(ctx, log, endpoint, bmcSecret, m); err != nil {
+					return ctrl.Result{}, fmt.Errorf("failed to apply BMC object: %w", err)
+				}
+				log.V(1).Info("Applied BMC object for Endpoint")
 			}
 			// TODO: other types like Switches can be handled here later
 		}
 	}
 	log.V(1).Info("Reconciled endpoint")
 
-	return ctrl.Result{}, nil
+	return result, nil
 }
 
 func (r *EndpointReconciler) getProtocol() string {
@@ -215,7 +259,8 @@ func (r *EndpointReconciler) applyBMCSecret(ctx context.Context, log logr.Logger
 // SetupWithManager sets up the controller with the Manager.
 func (r *EndpointReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&metalv1alpha1.Endpoint{}).
+		// the status records the credential attempts, which must not trigger further attempts
+		For(&metalv1alpha1.Endpoint{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Owns(&metalv1alpha1.BMCSecret{}).
 		Owns(&metalv1alpha1.BMC{}).
 		Complete(r)
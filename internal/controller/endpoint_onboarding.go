@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/api/macdb"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// credentialAttemptsLimit is the number of credential onboarding attempts kept in the status of an Endpoint.
+	credentialAttemptsLimit = 10
+
+	// credentialRetryInterval is the time after which the factory-default credentials are tried again once the BMC
+	// rejected all of them. BMCs lock their accounts after a few failed logins, so they are not retried any sooner.
+	credentialRetryInterval = 6 * time.Hour
+
+	// credentialRotationPendingAnnotation marks a BMCSecret holding a generated password which has not been
+	// confirmed to be set on the BMC yet.
+	credentialRotationPendingAnnotation = "metal.ironcore.dev/credential-rotation-pending"
+)
+
+const (
+	// EndpointConditionCredentialsRejected is True once the BMC behind the Endpoint rejected all factory-default
+	// credentials. They are tried again after credentialRetryInterval.
+	EndpointConditionCredentialsRejected = "CredentialsRejected"
+
+	endpointCredentialsRejectedReason = "FactoryCredentialsRejected"
+)
+
+// onboardBMC tries the factory-default credentials of the vendor against a newly discovered BMC. The password of
+// the first accepted credentials is immediately rotated to a generated one, which is stored in the BMCSecret of the
+// BMC. Every attempt is recorded in the status of the Endpoint. Once the BMCSecret exists and the rotation has been
+// confirmed, the BMC is considered onboarded and its credentials are left untouched. If the BMC rejects all
+// credentials, they are not tried again before credentialRetryInterval, which is returned as requeue delay.
+func (r *EndpointReconciler) onboardBMC(ctx context.Context, log logr.Logger, endpoint *metalv1alpha1.Endpoint, m macdb.MacPrefix, address string) (time.Duration, error) {
+	var pending *metalv1alpha1.BMCSecret
+	bmcSecret := &metalv1alpha1.BMCSecret{}
+	if err := r.Get(ctx, client.ObjectKey{Name: endpoint.Name}, bmcSecret); err == nil {
+		if !metav1.HasAnnotation(bmcSecret.ObjectMeta, credentialRotationPendingAnnotation) {
+			log.V(1).Info("BMC has already been onboarded", "BMCSecret", bmcSecret.Name)
+			return 0, r.applyBMC(ctx, log, endpoint, bmcSecret, m)
+		}
+		pending = bmcSecret
+	} else if !apierrors.IsNotFound(err) {
+		return 0, fmt.Errorf("failed to get BMCSecret: %w", err)
+	}
+	if remaining := credentialRetryRemaining(endpoint); remaining > 0 {
+		log.V(1).Info("BMC rejected all factory-default credentials, postponing the next attempt", "Remaining", remaining)
+		return remaining, nil
+	}
+
+	endpointBase := endpoint.DeepCopy()
+	defer func() {
+		if patchErr := r.Status().Patch(ctx, endpoint, client.MergeFrom(endpointBase)); patchErr != nil {
+			log.Error(patchErr, "Failed to record credential attempts")
+		}
+	}()
+
+	credentials := m.DefaultCredentials
+	if pending != nil {
+		// setting the generated password might have failed after the BMC applied it, so it is tried before the
+		// factory-default credentials of the account
+		username := string(pending.Data[metalv1alpha1.BMCSecretUsernameKeyName])
+		credentials = []macdb.Credential{{
+			Username: username,
+			Password: string(pending.Data[metalv1alpha1.BMCSecretPasswordKeyName]),
+		}}
+		for _, credential := range m.DefaultCredentials {
+			if credential.Username == username {
+				credentials = append(credentials, credential)
+			}
+		}
+	}
+	for _, credential := range credentials {
+		bmcSecret, result, err := r.tryCredential(ctx, endpoint, m, address, credential, pending)
+		attempt := metalv1alpha1.CredentialAttempt{
+			Time:     metav1.Now(),
+			Username: credential.Username,
+			Result:   result,
+		}
+		if err != nil {
+			attempt.Message = err.Error()
+		}
+		recordCredentialAttempt(endpoint, attempt)
+		log.V(1).Info("Tried credentials", "Username", credential.Username, "Result", result)
+
+		switch result {
+		case metalv1alpha1.CredentialAttemptResultRotated:
+			meta.RemoveStatusCondition(&endpoint.Status.Conditions, EndpointConditionCredentialsRejected)
+			return 0, r.applyBMC(ctx, log, endpoint, bmcSecret, m)
+		case metalv1alpha1.CredentialAttemptResultRotationFailed:
+			// the credentials are valid, retry the rotation instead of trying further credentials
+			return 0, fmt.Errorf("failed to rotate the password of the BMC: %w", err)
+		}
+	}
+	log.V(1).Info("BMC rejected all credentials", "RetryAfter", credentialRetryInterval)
+	meta.SetStatusCondition(&endpoint.Status.Conditions, metav1.Condition{
+		Type:               EndpointConditionCredentialsRejected,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: endpoint.Generation,
+		Reason:             endpointCredentialsRejectedReason,
+		Message:            fmt.Sprintf("BMC rejected all credentials, retrying after %s", credentialRetryInterval),
+	})
+	return credentialRetryInterval, nil
+}
+
+// credentialRetryRemaining returns the time until the credentials are tried again against a BMC which rejected all
+// of them.
+func credentialRetryRemaining(endpoint *metalv1alpha1.Endpoint) time.Duration {
+	attempts := endpoint.Status.CredentialAttempts
+	if !meta.IsStatusConditionTrue(endpoint.Status.Conditions, EndpointConditionCredentialsRejected) || len(attempts) == 0 {
+		return 0
+	}
+	return time.Until(attempts[len(attempts)-1].Time.Add(credentialRetryInterval))
+}
+
+// tryCredential logs in to the BMC with the credentials and rotates their password. The BMCSecret holding the
+// generated password is created before the password is set on the BMC and marked as pending until the BMC
+// confirmed the password, so that it never gets lost. If there is a pending BMCSecret, its password is set
+// instead of generating a new one, and the credentials being the pending ones confirm the rotation.
+func (r *EndpointReconciler) tryCredential(ctx context.Context, endpoint *metalv1alpha1.Endpoint, m macdb.MacPrefix, address string, credential macdb.Credential, pending *metalv1alpha1.BMCSecret) (*metalv1alpha1.BMCSecret, metalv1alpha1.CredentialAttemptResult, error) {
+	bmcClient, err := newOnboardingBMCClient(ctx, m, bmc.BMCOptions{
+		Endpoint:  address,
+		Username:  credential.Username,
+		Password:  credential.Password,
+		BasicAuth: true,
+		Timeouts:  r.BMCOptions.Timeouts,
+	})
+	if err != nil {
+		return nil, metalv1alpha1.CredentialAttemptResultRejected, err
+	}
+	defer bmcClient.Logout()
+	// the service root is readable without authentication, so an authenticated resource is requested. BMCs
+	// requiring a password change on the first login accept the credentials, but reject everything else until the
+	// password has been rotated below.
+	if _, err := bmcClient.GetSystems(ctx); err != nil {
+		if _, required := bmc.PasswordChangeRequired(err); !required {
+			return nil, metalv1alpha1.CredentialAttemptResultRejected, err
+		}
+	}
+
+	bmcSecret := pending
+	if bmcSecret == nil {
+		password, err := bmc.PasswordPolicyForManufacturer(m.Manufacturer).GeneratePassword()
+		if err != nil {
+			return nil, metalv1alpha1.CredentialAttemptResultRotationFailed, err
+		}
+		bmcSecret = &metalv1alpha1.BMCSecret{}
+		bmcSecret.Name = endpoint.Name
+		bmcSecret.Annotations = map[string]string{credentialRotationPendingAnnotation: "true"}
+		bmcSecret.Data = map[string][]byte{
+			metalv1alpha1.BMCSecretUsernameKeyName: []byte(credential.Username),
+			metalv1alpha1.BMCSecretPasswordKeyName: []byte(password),
+		}
+		if err := controllerutil.SetControllerReference(endpoint, bmcSecret, r.Client.Scheme()); err != nil {
+			return nil, metalv1alpha1.CredentialAttemptResultRotationFailed, fmt.Errorf("failed to set controller reference: %w", err)
+		}
+		if err := r.Create(ctx, bmcSecret); err != nil {
+			return nil, metalv1alpha1.CredentialAttemptResultRotationFailed, fmt.Errorf("failed to create BMCSecret: %w", err)
+		}
+	}
+	password := string(bmcSecret.Data[metalv1alpha1.BMCSecretPasswordKeyName])
+	if credential.Password != password {
+		// the BMCSecret is kept on failures, as the BMC might have applied the password nonetheless
+		if err := bmcClient.SetAccountPassword(ctx, credential.Username, password); err != nil {
+			return nil, metalv1alpha1.CredentialAttemptResultRotationFailed, err
+		}
+	}
+
+	bmcSecretBase := bmcSecret.DeepCopy()
+	delete(bmcSecret.Annotations, credentialRotationPendingAnnotation)
+	if err := r.Patch(ctx, bmcSecret, client.MergeFrom(bmcSecretBase)); err != nil {
+		return nil, metalv1alpha1.CredentialAttemptResultRotationFailed, fmt.Errorf("failed to confirm BMCSecret: %w", err)
+	}
+	return bmcSecret, metalv1alpha1.CredentialAttemptResultRotated, nil
+}
+
+// newOnboardingBMCClient returns a client of the BMC logged in with the credentials of the options. Simulated BMCs
+// are onboarded like Redfish BMCs.
+func newOnboardingBMCClient(ctx context.Context, m macdb.MacPrefix, options bmc.BMCOptions) (bmc.BMC, error) {
+	if m.Protocol == metalv1alpha1.ProtocolRedfishFake {
+		return bmc.NewRedfishFakeBMCClient(ctx, options)
+	}
+	return bmc.NewRedfishBMCClient(ctx, options)
+}
+
+// recordCredentialAttempt appends the attempt to the status of the Endpoint, keeping at most
+// credentialAttemptsLimit entries.
+func recordCredentialAttempt(endpoint *metalv1alpha1.Endpoint, attempt metalv1alpha1.CredentialAttempt) {
+	attempts := append(endpoint.Status.CredentialAttempts, attempt)
+	if len(attempts) > credentialAttemptsLimit {
+		attempts = attempts[len(attempts)-credentialAttemptsLimit:]
+	}
+	endpoint.Status.CredentialAttempts = attempts
+}
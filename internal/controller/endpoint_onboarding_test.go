@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/api/macdb"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+)
+
+var _ = Describe("Endpoint Credential Onboarding", func() {
+	_ = SetupTest()
+
+	var (
+		simulator  *bmc.Simulator
+		endpoint   *metalv1alpha1.Endpoint
+		reconciler *EndpointReconciler
+		logins     atomic.Int32
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		simulator = registerSimulator("10.30.0.10:8000", "38947555-7742-3448-3784-823347823843")
+		simulator.Update(func(state *bmc.SimulatorState) {
+			state.Accounts = map[string]string{"root": "calvin"}
+		})
+		logins.Store(0)
+		simulator.SetIntercept(func(ctx context.Context, operation string) error {
+			if operation == "Login" {
+				logins.Add(1)
+			}
+			return nil
+		})
+
+		reconciler = &EndpointReconciler{
+			Client:   k8sClient,
+			Insecure: true,
+			MACPrefixes: &macdb.MacPrefixes{MacPrefixes: []macdb.MacPrefix{{
+				MacPrefix:    "4E",
+				Manufacturer: "Dell Inc.",
+				Protocol:     metalv1alpha1.ProtocolRedfishFake,
+				Port:         8000,
+				Type:         metalv1alpha1.BMCType,
+				DefaultCredentials: []macdb.Credential{
+					{Username: "ADMIN", Password: "ADMIN"},
+					{Username: "root", Password: "calvin"},
+				},
+			}}},
+			CredentialOnboarding: true,
+		}
+
+		// the Endpoint matches no prefix of the manager, so that only the reconciler of the test onboards it
+		endpoint = &metalv1alpha1.Endpoint{
+			ObjectMeta: metav1.ObjectMeta{GenerateName: "test-"},
+			Spec: metalv1alpha1.EndpointSpec{
+				MACAddress: "4E:11:8A:33:CF:EA",
+				IP:         metalv1alpha1.MustParseIP("10.30.0.10"),
+			},
+		}
+		Expect(k8sClient.Create(ctx, endpoint)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, endpoint)
+		DeferCleanup(func(ctx SpecContext) {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &metalv1alpha1.BMC{
+				ObjectMeta: metav1.ObjectMeta{Name: endpoint.Name},
+			}))).To(Succeed())
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &metalv1alpha1.BMCSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: endpoint.Name},
+			}))).To(Succeed())
+		})
+	})
+
+	It("Should rotate the password of the first accepted factory-default credentials", func(ctx SpecContext) {
+		_, err := reconciler.reconcile(ctx, GinkgoLogr, endpoint)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(endpoint.Status.CredentialAttempts).To(HaveExactElements(
+			SatisfyAll(HaveField("Username", "ADMIN"), HaveField("Result", metalv1alpha1.CredentialAttemptResultRejected)),
+			SatisfyAll(HaveField("Username", "root"), HaveField("Result", metalv1alpha1.CredentialAttemptResultRotated)),
+		))
+
+		bmcSecret := &metalv1alpha1.BMCSecret{ObjectMeta: metav1.ObjectMeta{Name: endpoint.Name}}
+		Eventually(Object(bmcSecret)).Should(SatisfyAll(
+			HaveField("Annotations", Not(HaveKey(credentialRotationPendingAnnotation))),
+			HaveField("Data", HaveKeyWithValue(metalv1alpha1.BMCSecretUsernameKeyName, []byte("root"))),
+		))
+		password := string(bmcSecret.Data[metalv1alpha1.BMCSecretPasswordKeyName])
+		Expect(password).NotTo(Equal("calvin"))
+		Expect(simulator.State().Accounts).To(HaveKeyWithValue("root", password))
+
+		Eventually(Get(&metalv1alpha1.BMC{ObjectMeta: metav1.ObjectMeta{Name: endpoint.Name}})).Should(Succeed())
+	})
+
+	It("Should not try the credentials again once the BMC rejected all of them", func(ctx SpecContext) {
+		simulator.Update(func(state *bmc.SimulatorState) {
+			state.Accounts = map[string]string{"root": "changed"}
+		})
+
+		result, err := reconciler.reconcile(ctx, GinkgoLogr, endpoint)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(credentialRetryInterval))
+		Expect(logins.Load()).To(BeEquivalentTo(2))
+		Eventually(Object(endpoint)).Should(SatisfyAll(
+			HaveField("Status.CredentialAttempts", HaveLen(2)),
+			HaveField("Status.Conditions", ContainElement(SatisfyAll(
+				HaveField("Type", EndpointConditionCredentialsRejected),
+				HaveField("Status", metav1.ConditionTrue),
+			))),
+		))
+
+		By("Postponing the next attempt")
+		result, err = reconciler.reconcile(ctx, GinkgoLogr, endpoint)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		Expect(logins.Load()).To(BeEquivalentTo(2))
+		Expect(Get(&metalv1alpha1.BMCSecret{ObjectMeta: metav1.ObjectMeta{Name: endpoint.Name}})()).
+			To(Satisfy(apierrors.IsNotFound))
+	})
+
+	It("Should keep the generated password if the BMC applied it despite failing to set it", func(ctx SpecContext) {
+		simulator.SetFailure("SetAccountPassword", errors.New("context deadline exceeded"))
+
+		_, err := reconciler.reconcile(ctx, GinkgoLogr, endpoint)
+		Expect(err).To(HaveOccurred())
+		bmcSecret := &metalv1alpha1.BMCSecret{ObjectMeta: metav1.ObjectMeta{Name: endpoint.Name}}
+		Eventually(Object(bmcSecret)).Should(HaveField("Annotations", HaveKey(credentialRotationPendingAnnotation)))
+		password := string(bmcSecret.Data[metalv1alpha1.BMCSecretPasswordKeyName])
+
+		By("Confirming the generated password the BMC applied")
+		simulator.SetFailure("SetAccountPassword", nil)
+		simulator.Update(func(state *bmc.SimulatorState) {
+			state.Accounts["root"] = password
+		})
+		_, err = reconciler.reconcile(ctx, GinkgoLogr, endpoint)
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(Object(bmcSecret)).Should(SatisfyAll(
+			HaveField("Annotations", Not(HaveKey(credentialRotationPendingAnnotation))),
+			HaveField("Data", HaveKeyWithValue(metalv1alpha1.BMCSecretPasswordKeyName, []byte(password))),
+		))
+		Expect(meta.IsStatusConditionTrue(endpoint.Status.Conditions, EndpointConditionCredentialsRejected)).To(BeFalse())
+	})
+
+	It("Should set the generated password again if the BMC did not apply it", func(ctx SpecContext) {
+		simulator.SetFailure("SetAccountPassword", errors.New("context deadline exceeded"))
+
+		_, err := reconciler.reconcile(ctx, GinkgoLogr, endpoint)
+		Expect(err).To(HaveOccurred())
+		bmcSecret := &metalv1alpha1.BMCSecret{ObjectMeta: metav1.ObjectMeta{Name: endpoint.Name}}
+		Eventually(Object(bmcSecret)).Should(HaveField("Annotations", HaveKey(credentialRotationPendingAnnotation)))
+		password := string(bmcSecret.Data[metalv1alpha1.BMCSecretPasswordKeyName])
+
+		simulator.SetFailure("SetAccountPassword", nil)
+		_, err = reconciler.reconcile(ctx, GinkgoLogr, endpoint)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(simulator.State().Accounts).To(HaveKeyWithValue("root", password))
+		Eventually(Object(bmcSecret)).Should(HaveField("Annotations", Not(HaveKey(credentialRotationPendingAnnotation))))
+	})
+})
@@ -0,0 +1,262 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const firmwareCampaignReasonDeleted = "Deleted before the update finished"
+
+// FirmwareCampaignReconciler reconciles a FirmwareCampaign object
+type FirmwareCampaignReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=firmwarecampaigns,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=firmwarecampaigns/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=drivefirmwares,verbs=get;list;watch
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=componentfirmwares,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *FirmwareCampaignReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	updates, startTime, err := r.listCampaignUpdates(ctx, req.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	campaign := &metalv1alpha1.FirmwareCampaign{}
+	if err := r.Get(ctx, req.NamespacedName, campaign); err != nil {
+		if !apierrors.IsNotFound(err) || len(updates) == 0 {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		campaign.Name = req.Name
+		if err := r.Create(ctx, campaign); client.IgnoreAlreadyExists(err) != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to create FirmwareCampaign: %w", err)
+		}
+		log.V(1).Info("Created FirmwareCampaign for labeled firmware updates")
+		return ctrl.Result{}, nil
+	}
+	if !campaign.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	if paused, remaining := shouldIgnoreReconciliation(campaign); paused {
+		log.V(1).Info("Skipped FirmwareCampaign reconciliation")
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	return ctrl.Result{}, r.reconcile(ctx, log, campaign, updates, startTime)
+}
+
+func (r *FirmwareCampaignReconciler) reconcile(ctx context.Context, log logr.Logger, campaign *metalv1alpha1.FirmwareCampaign, updates []metalv1alpha1.FirmwareCampaignUpdate, startTime *metav1.Time) error {
+	campaignBase := campaign.DeepCopy()
+
+	// Updates which have been deleted keep their last recorded outcome.
+	for _, recorded := range campaign.Status.Updates {
+		if slices.ContainsFunc(updates, func(update metalv1alpha1.FirmwareCampaignUpdate) bool {
+			return update.Kind == recorded.Kind && update.Name == recorded.Name
+		}) {
+			continue
+		}
+		if recorded.Outcome == metalv1alpha1.FirmwareCampaignOutcomePending {
+			now := metav1.Now()
+			recorded.Outcome = metalv1alpha1.FirmwareCampaignOutcomeFailed
+			recorded.Reason = firmwareCampaignReasonDeleted
+			recorded.CompletionTime = &now
+		}
+		updates = append(updates, recorded)
+	}
+	slices.SortFunc(updates, func(a, b metalv1alpha1.FirmwareCampaignUpdate) int {
+		return cmp.Or(cmp.Compare(a.Server, b.Server), cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Name, b.Name))
+	})
+
+	status := metalv1alpha1.FirmwareCampaignStatus{
+		State:     metalv1alpha1.FirmwareCampaignStateCompleted,
+		StartTime: campaign.Status.StartTime,
+		Updates:   updates,
+	}
+	if status.StartTime == nil || (startTime != nil && startTime.Before(status.StartTime)) {
+		status.StartTime = startTime
+	}
+	for _, update := range updates {
+		switch update.Outcome {
+		case metalv1alpha1.FirmwareCampaignOutcomeSucceeded:
+			status.Succeeded++
+		case metalv1alpha1.FirmwareCampaignOutcomeFailed:
+			status.Failed++
+		case metalv1alpha1.FirmwareCampaignOutcomeSkipped:
+			status.Skipped++
+		default:
+			status.Pending++
+		}
+		if update.CompletionTime != nil && (status.CompletionTime == nil || status.CompletionTime.Before(update.CompletionTime)) {
+			status.CompletionTime = update.CompletionTime
+		}
+	}
+	if status.Pending > 0 || len(updates) == 0 {
+		status.State = metalv1alpha1.FirmwareCampaignStateInProgress
+		status.CompletionTime = nil
+	}
+
+	campaign.Status = status
+	if err := r.Status().Patch(ctx, campaign, client.MergeFrom(campaignBase)); err != nil {
+		return fmt.Errorf("failed to patch FirmwareCampaign status: %w", err)
+	}
+	log.V(1).Info("Reconciled FirmwareCampaign", "State", status.State, "Succeeded", status.Succeeded,
+		"Failed", status.Failed, "Skipped", status.Skipped, "Pending", status.Pending)
+	return nil
+}
+
+// listCampaignUpdates returns the outcome of the DriveFirmwares and ComponentFirmwares labeled with the campaign and
+// the time the first of them has been created.
+func (r *FirmwareCampaignReconciler) listCampaignUpdates(ctx context.Context, name string) ([]metalv1alpha1.FirmwareCampaignUpdate, *metav1.Time, error) {
+	var updates []metalv1alpha1.FirmwareCampaignUpdate
+	var startTime *metav1.Time
+	observeCreation := func(obj client.Object) {
+		created := obj.GetCreationTimestamp()
+		if startTime == nil || created.Before(startTime) {
+			startTime = &created
+		}
+	}
+
+	driveFirmwares := &metalv1alpha1.DriveFirmwareList{}
+	if err := r.List(ctx, driveFirmwares, client.MatchingLabels{metalv1alpha1.FirmwareCampaignLabel: name}); err != nil {
+		return nil, nil, fmt.Errorf("failed to list DriveFirmwares: %w", err)
+	}
+	for _, firmware := range driveFirmwares.Items {
+		observeCreation(&firmware)
+		updates = append(updates, driveFirmwareCampaignUpdate(&firmware))
+	}
+
+	componentFirmwares := &metalv1alpha1.ComponentFirmwareList{}
+	if err := r.List(ctx, componentFirmwares, client.MatchingLabels{metalv1alpha1.FirmwareCampaignLabel: name}); err != nil {
+		return nil, nil, fmt.Errorf("failed to list ComponentFirmwares: %w", err)
+	}
+	for _, firmware := range componentFirmwares.Items {
+		observeCreation(&firmware)
+		updates = append(updates, componentFirmwareCampaignUpdate(&firmware))
+	}
+	return updates, startTime, nil
+}
+
+// driveFirmwareCampaignUpdate returns the outcome of the DriveFirmware. An update which completed without flashing
+// any drive is skipped.
+func driveFirmwareCampaignUpdate(firmware *metalv1alpha1.DriveFirmware) metalv1alpha1.FirmwareCampaignUpdate {
+	update := metalv1alpha1.FirmwareCampaignUpdate{
+		Kind:           "DriveFirmware",
+		Name:           firmware.Name,
+		Server:         firmware.Spec.ServerRef.Name,
+		Version:        firmware.Spec.Version,
+		Outcome:        metalv1alpha1.FirmwareCampaignOutcomePending,
+		CompletionTime: firmware.Status.CompletionTime,
+	}
+	switch firmware.Status.State {
+	case metalv1alpha1.DriveFirmwareStateCompleted:
+		update.Outcome = metalv1alpha1.FirmwareCampaignOutcomeSucceeded
+		if !slices.ContainsFunc(firmware.Status.Drives, func(drive metalv1alpha1.DriveFirmwareProgress) bool {
+			return drive.TaskURI != ""
+		}) {
+			update.Outcome = metalv1alpha1.FirmwareCampaignOutcomeSkipped
+			update.Reason = fmt.Sprintf("All drives of model %s already run version %s", firmware.Spec.Model, firmware.Spec.Version)
+			if len(firmware.Status.Drives) == 0 {
+				update.Reason = fmt.Sprintf("Server has no drives of model %s", firmware.Spec.Model)
+			}
+		}
+	case metalv1alpha1.DriveFirmwareStateFailed:
+		update.Outcome = metalv1alpha1.FirmwareCampaignOutcomeFailed
+		update.Reason = "Update failed"
+		if condition := meta.FindStatusCondition(firmware.Status.Conditions, FirmwareConditionVerified); condition != nil && condition.Status == metav1.ConditionFalse {
+			update.Reason = condition.Message
+		}
+		for _, drive := range firmware.Status.Drives {
+			if drive.State == metalv1alpha1.DriveFirmwareStateFailed {
+				update.Reason = fmt.Sprintf("Drive %s: %s", drive.Name, drive.Message)
+				break
+			}
+		}
+	default:
+		update.CompletionTime = nil
+	}
+	return update
+}
+
+// componentFirmwareCampaignUpdate returns the outcome of the ComponentFirmware. An update which completed without
+// flashing any component is skipped.
+func componentFirmwareCampaignUpdate(firmware *metalv1alpha1.ComponentFirmware) metalv1alpha1.FirmwareCampaignUpdate {
+	update := metalv1alpha1.FirmwareCampaignUpdate{
+		Kind:           "ComponentFirmware",
+		Name:           firmware.Name,
+		Server:         firmware.Spec.ServerRef.Name,
+		Version:        firmware.Spec.Version,
+		Outcome:        metalv1alpha1.FirmwareCampaignOutcomePending,
+		CompletionTime: firmware.Status.CompletionTime,
+	}
+	switch firmware.Status.State {
+	case metalv1alpha1.ComponentFirmwareStateCompleted:
+		update.Outcome = metalv1alpha1.FirmwareCampaignOutcomeSucceeded
+		if !slices.ContainsFunc(firmware.Status.Components, func(component metalv1alpha1.ComponentFirmwareProgress) bool {
+			return component.TaskURI != ""
+		}) {
+			update.Outcome = metalv1alpha1.FirmwareCampaignOutcomeSkipped
+			update.Reason = fmt.Sprintf("All %s components already run version %s", firmware.Spec.Component.Type, firmware.Spec.Version)
+			if len(firmware.Status.Components) == 0 {
+				update.Reason = fmt.Sprintf("Server has no matching %s components", firmware.Spec.Component.Type)
+			}
+		}
+	case metalv1alpha1.ComponentFirmwareStateFailed:
+		update.Outcome = metalv1alpha1.FirmwareCampaignOutcomeFailed
+		update.Reason = "Update failed"
+		if condition := meta.FindStatusCondition(firmware.Status.Conditions, FirmwareConditionVerified); condition != nil && condition.Status == metav1.ConditionFalse {
+			update.Reason = condition.Message
+		}
+		for _, component := range firmware.Status.Components {
+			if component.State == metalv1alpha1.ComponentFirmwareStateFailed {
+				update.Reason = fmt.Sprintf("Component %s: %s", component.Name, component.Message)
+				break
+			}
+		}
+	default:
+		update.CompletionTime = nil
+	}
+	return update
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FirmwareCampaignReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&metalv1alpha1.FirmwareCampaign{}).
+		Watches(&metalv1alpha1.DriveFirmware{}, enqueueFirmwareCampaignByLabel()).
+		Watches(&metalv1alpha1.ComponentFirmware{}, enqueueFirmwareCampaignByLabel()).
+		Complete(r)
+}
+
+// enqueueFirmwareCampaignByLabel enqueues the FirmwareCampaign a firmware update is labeled with, whether or not the
+// campaign exists yet.
+func enqueueFirmwareCampaignByLabel() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
+		name, ok := object.GetLabels()[metalv1alpha1.FirmwareCampaignLabel]
+		if !ok || name == "" {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name}}}
+	})
+}
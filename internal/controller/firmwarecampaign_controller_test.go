@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+)
+
+var _ = Describe("FirmwareCampaign Controller", func() {
+	_ = SetupTest()
+
+	It("should record the outcome of the updates of a campaign", func(ctx SpecContext) {
+		By("Creating a DriveFirmware labeled with a campaign")
+		firmware := &metalv1alpha1.DriveFirmware{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Labels:       map[string]string{metalv1alpha1.FirmwareCampaignLabel: "test-campaign"},
+				Annotations: map[string]string{
+					metalv1alpha1.OperationAnnotation: metalv1alpha1.OperationAnnotationIgnore,
+				},
+			},
+			Spec: metalv1alpha1.DriveFirmwareSpec{
+				ServerRef: v1.LocalObjectReference{Name: "foo"},
+				Model:     "foo",
+				Version:   "1.0.0",
+				Image: metalv1alpha1.FirmwareImage{
+					URI: "http://example.com/drive-firmware.bin",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, firmware)).To(Succeed())
+
+		By("Ensuring that the campaign has been generated")
+		campaign := &metalv1alpha1.FirmwareCampaign{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-campaign"},
+		}
+		DeferCleanup(k8sClient.Delete, campaign)
+		Eventually(Object(campaign)).Should(SatisfyAll(
+			HaveField("Status.State", metalv1alpha1.FirmwareCampaignStateInProgress),
+			HaveField("Status.StartTime", Not(BeNil())),
+			HaveField("Status.Pending", int32(1)),
+			HaveField("Status.Updates", ConsistOf(SatisfyAll(
+				HaveField("Kind", "DriveFirmware"),
+				HaveField("Name", firmware.Name),
+				HaveField("Server", "foo"),
+				HaveField("Outcome", metalv1alpha1.FirmwareCampaignOutcomePending),
+			))),
+		))
+
+		By("Completing the update without flashing any drive")
+		Eventually(UpdateStatus(firmware, func() {
+			firmware.Status.State = metalv1alpha1.DriveFirmwareStateCompleted
+			now := metav1.Now()
+			firmware.Status.CompletionTime = &now
+		})).Should(Succeed())
+
+		By("Ensuring that the update has been skipped")
+		Eventually(Object(campaign)).Should(SatisfyAll(
+			HaveField("Status.State", metalv1alpha1.FirmwareCampaignStateCompleted),
+			HaveField("Status.CompletionTime", Not(BeNil())),
+			HaveField("Status.Skipped", int32(1)),
+			HaveField("Status.Pending", int32(0)),
+			HaveField("Status.Updates", ConsistOf(SatisfyAll(
+				HaveField("Outcome", metalv1alpha1.FirmwareCampaignOutcomeSkipped),
+				HaveField("Reason", "Server has no drives of model foo"),
+			))),
+		))
+
+		By("Deleting the DriveFirmware")
+		Expect(k8sClient.Delete(ctx, firmware)).To(Succeed())
+
+		By("Ensuring that the campaign keeps the outcome of the update")
+		Consistently(Object(campaign)).Should(HaveField("Status.Updates", ConsistOf(
+			HaveField("Outcome", metalv1alpha1.FirmwareCampaignOutcomeSkipped),
+		)))
+	})
+})
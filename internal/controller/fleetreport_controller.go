@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// FleetReportReconciler reconciles a FleetReport object
+type FleetReportReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// ResyncInterval is the interval at which the reports are updated.
+	ResyncInterval time.Duration
+}
+
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=fleetreports,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=fleetreports/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=servers,verbs=get;list;watch
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=bmcs,verbs=get;list;watch
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=drivefirmwares,verbs=get;list;watch
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=componentfirmwares,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *FleetReportReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	report := &metalv1alpha1.FleetReport{}
+	if err := r.Get(ctx, req.NamespacedName, report); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	return r.reconcileExists(ctx, log, report)
+}
+
+func (r *FleetReportReconciler) reconcileExists(ctx context.Context, log logr.Logger, report *metalv1alpha1.FleetReport) (ctrl.Result, error) {
+	if !report.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	return r.reconcile(ctx, log, report)
+}
+
+func (r *FleetReportReconciler) reconcile(ctx context.Context, log logr.Logger, report *metalv1alpha1.FleetReport) (ctrl.Result, error) {
+	log.V(1).Info("Reconciling FleetReport")
+	if paused, remaining := shouldIgnoreReconciliation(report); paused {
+		log.V(1).Info("Skipped FleetReport reconciliation")
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	selector := labels.Everything()
+	if report.Spec.ServerSelector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(report.Spec.ServerSelector); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to parse server selector: %w", err)
+		}
+	}
+	servers := &metalv1alpha1.ServerList{}
+	if err := r.List(ctx, servers, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list servers: %w", err)
+	}
+
+	status := metalv1alpha1.FleetReportStatus{
+		TotalServers:        int32(len(servers.Items)),
+		ServersByState:      map[string]int32{},
+		BIOSVersions:        map[string]int32{},
+		BMCFirmwareVersions: map[string]int32{},
+	}
+	serverNames := make(map[string]struct{}, len(servers.Items))
+	bmcNames := map[string]struct{}{}
+	for _, server := range servers.Items {
+		serverNames[server.Name] = struct{}{}
+		status.ServersByState[string(server.Status.State)]++
+		if server.Status.BIOS.Version != "" {
+			status.BIOSVersions[server.Status.BIOS.Version]++
+		}
+		if server.Spec.BMCRef != nil {
+			bmcNames[server.Spec.BMCRef.Name] = struct{}{}
+		}
+	}
+
+	bmcs := &metalv1alpha1.BMCList{}
+	if err := r.List(ctx, bmcs); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list BMCs: %w", err)
+	}
+	for _, bmcObj := range bmcs.Items {
+		if _, ok := bmcNames[bmcObj.Name]; !ok {
+			continue
+		}
+		if bmcObj.Status.FirmwareVersion != "" {
+			status.BMCFirmwareVersions[bmcObj.Status.FirmwareVersion]++
+		}
+		if bmcObj.Status.State != metalv1alpha1.BMCStateEnabled {
+			status.FailingBMCs = append(status.FailingBMCs, metalv1alpha1.FailingBMC{
+				Name:  bmcObj.Name,
+				State: bmcObj.Status.State,
+			})
+		}
+	}
+	sort.Slice(status.FailingBMCs, func(i, j int) bool {
+		return status.FailingBMCs[i].Name < status.FailingBMCs[j].Name
+	})
+
+	pending, err := r.countPendingFirmwareUpdates(ctx, serverNames)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	status.PendingFirmwareUpdates = pending
+
+	reportBase := report.DeepCopy()
+	now := metav1.Now()
+	status.LastUpdateTime = &now
+	report.Status = status
+	if err := r.Status().Patch(ctx, report, client.MergeFrom(reportBase)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to patch FleetReport status: %w", err)
+	}
+
+	log.V(1).Info("Reconciled FleetReport")
+	return ctrl.Result{RequeueAfter: r.ResyncInterval}, nil
+}
+
+func (r *FleetReportReconciler) countPendingFirmwareUpdates(ctx context.Context, serverNames map[string]struct{}) (int32, error) {
+	var pending int32
+	driveFirmwares := &metalv1alpha1.DriveFirmwareList{}
+	if err := r.List(ctx, driveFirmwares); err != nil {
+		return 0, fmt.Errorf("failed to list DriveFirmwares: %w", err)
+	}
+	for _, firmware := range driveFirmwares.Items {
+		if _, ok := serverNames[firmware.Spec.ServerRef.Name]; !ok {
+			continue
+		}
+		if firmware.Status.State != metalv1alpha1.DriveFirmwareStateCompleted && firmware.Status.State != metalv1alpha1.DriveFirmwareStateFailed {
+			pending++
+		}
+	}
+
+	componentFirmwares := &metalv1alpha1.ComponentFirmwareList{}
+	if err := r.List(ctx, componentFirmwares); err != nil {
+		return 0, fmt.Errorf("failed to list ComponentFirmwares: %w", err)
+	}
+	for _, firmware := range componentFirmwares.Items {
+		if _, ok := serverNames[firmware.Spec.ServerRef.Name]; !ok {
+			continue
+		}
+		if firmware.Status.State != metalv1alpha1.ComponentFirmwareStateCompleted && firmware.Status.State != metalv1alpha1.ComponentFirmwareStateFailed {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FleetReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&metalv1alpha1.FleetReport{}).
+		Complete(r)
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+)
+
+var _ = Describe("FleetReport Controller", func() {
+	_ = SetupTest()
+
+	It("should report the servers matching the selector", func(ctx SpecContext) {
+		By("Creating a Server object")
+		server := &metalv1alpha1.Server{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Labels: map[string]string{
+					"fleet": "report",
+				},
+				Annotations: map[string]string{
+					metalv1alpha1.OperationAnnotation: metalv1alpha1.OperationAnnotationIgnore,
+				},
+			},
+			Spec: metalv1alpha1.ServerSpec{
+				SystemUUID: "38947555-7742-3448-3784-823347823834",
+			},
+		}
+		Expect(k8sClient.Create(ctx, server)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, server)
+
+		By("Creating a FleetReport object")
+		report := &metalv1alpha1.FleetReport{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.FleetReportSpec{
+				ServerSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"fleet": "report",
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, report)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, report)
+
+		By("Ensuring that the report contains the server")
+		Eventually(Object(report)).Should(SatisfyAll(
+			HaveField("Status.TotalServers", BeNumerically("==", 1)),
+			HaveField("Status.LastUpdateTime", Not(BeNil())),
+		))
+	})
+})
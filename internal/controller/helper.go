@@ -4,24 +4,120 @@
 package controller
 
 import (
+	"context"
 	"crypto/rand"
 	"fmt"
+	"hash/fnv"
 	"math/big"
+	mathrand "math/rand/v2"
+	"time"
 
 	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/stmcginnis/gofish/redfish"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
 	fieldOwner = client.FieldOwner("metal.ironcore.dev/controller-manager")
+
+	// resyncJitterFactor is the maximum random jitter added to a resync, as a fraction of the resync interval.
+	resyncJitterFactor = 0.1
+)
+
+const (
+	// FirmwareConditionServerInUse is set while a firmware update waits for the server to be released by its claim
+	// or for its maintenance window.
+	FirmwareConditionServerInUse = "ServerInUse"
+	// FirmwareConditionVerified is set once the firmware version of the updated components has been verified.
+	FirmwareConditionVerified = "Verified"
 )
 
-func shouldIgnoreReconciliation(obj client.Object) bool {
-	val, found := obj.GetAnnotations()[metalv1alpha1.OperationAnnotation]
+// firmwareServerInUseCondition returns the ServerInUse condition of a firmware update of the Server. Firmware of a
+// server which is in use by a claim, or outside of its maintenance window, must not be touched. The condition is
+// true while the update has to wait.
+func firmwareServerInUseCondition(server *metalv1alpha1.Server) metav1.Condition {
+	if claim := server.Spec.ServerClaimRef; claim != nil {
+		return metav1.Condition{
+			Type:    FirmwareConditionServerInUse,
+			Status:  metav1.ConditionTrue,
+			Reason:  "ServerClaimed",
+			Message: fmt.Sprintf("Server is claimed by %s/%s", claim.Namespace, claim.Name),
+		}
+	}
+	if window := server.Spec.MaintenanceWindow; window != nil && !window.Contains(time.Now()) {
+		return metav1.Condition{
+			Type:    FirmwareConditionServerInUse,
+			Status:  metav1.ConditionTrue,
+			Reason:  "OutsideMaintenanceWindow",
+			Message: fmt.Sprintf("Server is outside of its maintenance window starting at %s", window.Start.Format(time.RFC3339)),
+		}
+	}
+	return metav1.Condition{
+		Type:   FirmwareConditionServerInUse,
+		Status: metav1.ConditionFalse,
+		Reason: "ServerNotClaimed",
+	}
+}
+
+// isBMCResetInProgress returns true while a reset of the BMC is requested or has not completed yet.
+func isBMCResetInProgress(bmcObj *metalv1alpha1.BMC) bool {
+	if bmcObj.GetAnnotations()[metalv1alpha1.OperationAnnotation] == metalv1alpha1.OperationAnnotationGracefulRestartBMC {
+		return true
+	}
+	return meta.IsStatusConditionTrue(bmcObj.Status.Conditions, BMCConditionReset)
+}
+
+// requestBMCReset requests a graceful restart of the BMC from the BMCReconciler, which performs at most one reset
+// per BMC at a time. A request made while a reset is already in progress joins that reset. Callers should wait
+// until isBMCResetInProgress returns false.
+func requestBMCReset(ctx context.Context, c client.Client, bmcName string) error {
+	bmcObj := &metalv1alpha1.BMC{}
+	if err := c.Get(ctx, client.ObjectKey{Name: bmcName}, bmcObj); err != nil {
+		return fmt.Errorf("failed to get BMC: %w", err)
+	}
+	if isBMCResetInProgress(bmcObj) {
+		return nil
+	}
+	bmcBase := bmcObj.DeepCopy()
+	metav1.SetMetaDataAnnotation(&bmcObj.ObjectMeta, metalv1alpha1.OperationAnnotation, metalv1alpha1.OperationAnnotationGracefulRestartBMC)
+	if err := c.Patch(ctx, bmcObj, client.MergeFrom(bmcBase)); err != nil {
+		return fmt.Errorf("failed to request BMC reset: %w", err)
+	}
+	return nil
+}
+
+// shouldIgnoreReconciliation reports whether the reconciliation of the object is paused, either indefinitely by
+// the ignore operation or until the time of the PausedUntilAnnotation. For a pause with expiry, it returns the
+// remaining time after which the reconciliation has to be resumed.
+func shouldIgnoreReconciliation(obj client.Object) (bool, time.Duration) {
+	if obj.GetAnnotations()[metalv1alpha1.OperationAnnotation] == metalv1alpha1.OperationAnnotationIgnore {
+		return true, 0
+	}
+	until, ok := pausedUntil(obj)
+	if !ok {
+		return false, 0
+	}
+	if remaining := time.Until(until); remaining > 0 {
+		return true, remaining
+	}
+	return false, 0
+}
+
+// pausedUntil returns the expiry of the pause of the object. Invalid timestamps, which the webhook rejects for
+// Servers, do not pause the reconciliation.
+func pausedUntil(obj client.Object) (time.Time, bool) {
+	val, found := obj.GetAnnotations()[metalv1alpha1.PausedUntilAnnotation]
 	if !found {
-		return false
+		return time.Time{}, false
+	}
+	until, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return time.Time{}, false
 	}
-	return val == metalv1alpha1.OperationAnnotationIgnore
+	return until, true
 }
 
 func GenerateRandomPassword(length int) ([]byte, error) {
@@ -36,3 +132,88 @@ func GenerateRandomPassword(length int) ([]byte, error) {
 	}
 	return result, nil
 }
+
+// ttlAfterFinished returns the time until a finished object has to be deleted according to its TTL.
+func ttlAfterFinished(ttlSeconds int32, completionTime metav1.Time) time.Duration {
+	return time.Until(completionTime.Add(time.Duration(ttlSeconds) * time.Second))
+}
+
+// hasFirmwareUpdateInProgress reports whether a firmware update other than the given one is in progress
+// for the server. Firmware updates are serialized per server across all firmware kinds.
+func hasFirmwareUpdateInProgress(ctx context.Context, c client.Client, serverName string, self client.Object) (bool, error) {
+	driveFirmwareList := &metalv1alpha1.DriveFirmwareList{}
+	if err := c.List(ctx, driveFirmwareList); err != nil {
+		return false, fmt.Errorf("failed to list DriveFirmwares: %w", err)
+	}
+	for _, item := range driveFirmwareList.Items {
+		if item.Spec.ServerRef.Name != serverName || item.UID == self.GetUID() {
+			continue
+		}
+		if item.Status.State == metalv1alpha1.DriveFirmwareStateInProgress {
+			return true, nil
+		}
+	}
+
+	componentFirmwareList := &metalv1alpha1.ComponentFirmwareList{}
+	if err := c.List(ctx, componentFirmwareList); err != nil {
+		return false, fmt.Errorf("failed to list ComponentFirmwares: %w", err)
+	}
+	for _, item := range componentFirmwareList.Items {
+		if item.Spec.ServerRef.Name != serverName || item.UID == self.GetUID() {
+			continue
+		}
+		if item.Status.State == metalv1alpha1.ComponentFirmwareStateInProgress {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getFirmwareUpdateTask fetches the BMC task of a firmware update and reports whether the task
+// has finished and whether it has failed.
+func getFirmwareUpdateTask(ctx context.Context, bmcClient bmc.BMC, taskURI string) (task *bmc.Task, done bool, failed bool, err error) {
+	task, err = bmcClient.GetTask(ctx, taskURI)
+	if err != nil {
+		return nil, false, false, fmt.Errorf("failed to get firmware update task: %w", err)
+	}
+	if !isTaskFinished(task) {
+		return task, false, false, nil
+	}
+	return task, true, task.State != redfish.CompletedTaskState, nil
+}
+
+// isTaskFinished reports whether the BMC task has completed or has been stopped.
+func isTaskFinished(task *bmc.Task) bool {
+	switch task.State {
+	case redfish.CompletedTaskState, redfish.ExceptionTaskState, redfish.KilledTaskState, redfish.CancelledTaskState,
+		redfish.InterruptedTaskState:
+		return true
+	}
+	return false
+}
+
+// resyncAfter returns the time until the next periodic resync of the object. Instead of resyncing every object
+// exactly one interval after its last reconciliation, the resyncs are smeared across the interval by a phase
+// derived from the object's UID, and a random jitter is added so that objects sharing a phase are spread as well.
+// The returned duration is between half and one and a half intervals plus the jitter.
+func resyncAfter(obj client.Object, interval time.Duration) time.Duration {
+	if interval <= 0 {
+		return 0
+	}
+	key := string(obj.GetUID())
+	if key == "" {
+		key = obj.GetName()
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	phase := int64(h.Sum64() % uint64(interval))
+
+	next := interval - time.Duration((time.Now().UnixNano()-phase)%int64(interval))
+	if next < interval/2 {
+		next += interval
+	}
+	if maxJitter := int64(float64(interval) * resyncJitterFactor); maxJitter > 0 {
+		next += time.Duration(mathrand.Int64N(maxJitter))
+	}
+	return next
+}
@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var pausedObjectsDesc = prometheus.NewDesc(
+	"metal_operator_paused_objects",
+	"Number of objects whose reconciliation is paused by the ignore operation or the paused-until annotation.",
+	[]string{"kind"}, nil,
+)
+
+// pausableKinds are the kinds whose reconciliation can be paused.
+var pausableKinds = []struct {
+	kind string
+	list func() client.ObjectList
+}{
+	{kind: "BMC", list: func() client.ObjectList { return &metalv1alpha1.BMCList{} }},
+	{kind: "ComponentFirmware", list: func() client.ObjectList { return &metalv1alpha1.ComponentFirmwareList{} }},
+	{kind: "ComposedServer", list: func() client.ObjectList { return &metalv1alpha1.ComposedServerList{} }},
+	{kind: "DriveFirmware", list: func() client.ObjectList { return &metalv1alpha1.DriveFirmwareList{} }},
+	{kind: "Endpoint", list: func() client.ObjectList { return &metalv1alpha1.EndpointList{} }},
+	{kind: "FleetReport", list: func() client.ObjectList { return &metalv1alpha1.FleetReportList{} }},
+	{kind: "Server", list: func() client.ObjectList { return &metalv1alpha1.ServerList{} }},
+	{kind: "ServerClaim", list: func() client.ObjectList { return &metalv1alpha1.ServerClaimList{} }},
+}
+
+// PausedObjectsCollector is a prometheus.Collector exporting the number of paused objects by kind. The objects
+// are counted from the cache on every scrape, so that the metric never drifts from the annotations.
+type PausedObjectsCollector struct {
+	Client client.Reader
+}
+
+// Describe implements prometheus.Collector.
+func (c *PausedObjectsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pausedObjectsDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *PausedObjectsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for _, pausable := range pausableKinds {
+		list := pausable.list()
+		if err := c.Client.List(ctx, list); err != nil {
+			ctrl.Log.WithName("metrics").Error(err, "Failed to list objects for paused objects metric", "Kind", pausable.kind)
+			continue
+		}
+		objects, err := meta.ExtractList(list)
+		if err != nil {
+			continue
+		}
+		paused := 0
+		for _, object := range objects {
+			if obj, ok := object.(client.Object); ok {
+				if ignored, _ := shouldIgnoreReconciliation(obj); ignored {
+					paused++
+				}
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(pausedObjectsDesc, prometheus.GaugeValue, float64(paused), pausable.kind)
+	}
+}
+
+// queueMetricsProvider records the metrics of the priority queues in the workqueue metrics of controller-runtime,
+// which are only registered for the default work queues.
+var queueMetricsProvider workqueue.MetricsProvider = workqueueMetricsProvider{}
+
+var (
+	queueDepth = registerQueueMetric(prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metrics.WorkQueueSubsystem,
+		Name:      metrics.DepthKey,
+		Help:      "Current depth of workqueue",
+	}, []string{"name", "controller"}))
+	queueAdds = registerQueueMetric(prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metrics.WorkQueueSubsystem,
+		Name:      metrics.AddsKey,
+		Help:      "Total number of adds handled by workqueue",
+	}, []string{"name", "controller"}))
+	queueLatency = registerQueueMetric(prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: metrics.WorkQueueSubsystem,
+		Name:      metrics.QueueLatencyKey,
+		Help:      "How long in seconds an item stays in workqueue before being requested",
+		Buckets:   prometheus.ExponentialBuckets(10e-9, 10, 12),
+	}, []string{"name", "controller"}))
+	queueWorkDuration = registerQueueMetric(prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: metrics.WorkQueueSubsystem,
+		Name:      metrics.WorkDurationKey,
+		Help:      "How long in seconds processing an item from workqueue takes.",
+		Buckets:   prometheus.ExponentialBuckets(10e-9, 10, 12),
+	}, []string{"name", "controller"}))
+	queueUnfinishedWork = registerQueueMetric(prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metrics.WorkQueueSubsystem,
+		Name:      metrics.UnfinishedWorkKey,
+		Help: "How many seconds of work has been done that " +
+			"is in progress and hasn't been observed by work_duration. Large " +
+			"values indicate stuck threads. One can deduce the number of stuck " +
+			"threads by observing the rate at which this increases.",
+	}, []string{"name", "controller"}))
+	queueLongestRunningProcessor = registerQueueMetric(prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metrics.WorkQueueSubsystem,
+		Name:      metrics.LongestRunningProcessorKey,
+		Help: "How many seconds has the longest running " +
+			"processor for workqueue been running.",
+	}, []string{"name", "controller"}))
+	queueRetries = registerQueueMetric(prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metrics.WorkQueueSubsystem,
+		Name:      metrics.RetriesKey,
+		Help:      "Total number of retries handled by workqueue",
+	}, []string{"name", "controller"}))
+)
+
+// registerQueueMetric returns the workqueue metric registered by controller-runtime, or registers the metric if it
+// is not registered yet.
+func registerQueueMetric[C prometheus.Collector](collector C) C {
+	if err := metrics.Registry.Register(collector); err != nil {
+		if registered := (prometheus.AlreadyRegisteredError{}); errors.As(err, &registered) {
+			return registered.ExistingCollector.(C)
+		}
+		panic(err)
+	}
+	return collector
+}
+
+type workqueueMetricsProvider struct{}
+
+func (workqueueMetricsProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
+	return queueDepth.WithLabelValues(name, name)
+}
+
+func (workqueueMetricsProvider) NewAddsMetric(name string) workqueue.CounterMetric {
+	return queueAdds.WithLabelValues(name, name)
+}
+
+func (workqueueMetricsProvider) NewLatencyMetric(name string) workqueue.HistogramMetric {
+	return queueLatency.WithLabelValues(name, name)
+}
+
+func (workqueueMetricsProvider) NewWorkDurationMetric(name string) workqueue.HistogramMetric {
+	return queueWorkDuration.WithLabelValues(name, name)
+}
+
+func (workqueueMetricsProvider) NewUnfinishedWorkSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return queueUnfinishedWork.WithLabelValues(name, name)
+}
+
+func (workqueueMetricsProvider) NewLongestRunningProcessorSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return queueLongestRunningProcessor.WithLabelValues(name, name)
+}
+
+func (workqueueMetricsProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
+	return queueRetries.WithLabelValues(name, name)
+}
+
+// queueMetrics records the workqueue metrics of a priority queue like the default work queue does. It is guarded by
+// the lock of the queue.
+type queueMetrics[T comparable] struct {
+	depth          workqueue.GaugeMetric
+	adds           workqueue.CounterMetric
+	latency        workqueue.HistogramMetric
+	workDuration   workqueue.HistogramMetric
+	unfinishedWork workqueue.SettableGaugeMetric
+	longestRunning workqueue.SettableGaugeMetric
+	retries        workqueue.CounterMetric
+
+	addTimes        map[T]time.Time
+	processingTimes map[T]time.Time
+}
+
+func newQueueMetrics[T comparable](provider workqueue.MetricsProvider, name string) *queueMetrics[T] {
+	return &queueMetrics[T]{
+		depth:           provider.NewDepthMetric(name),
+		adds:            provider.NewAddsMetric(name),
+		latency:         provider.NewLatencyMetric(name),
+		workDuration:    provider.NewWorkDurationMetric(name),
+		unfinishedWork:  provider.NewUnfinishedWorkSecondsMetric(name),
+		longestRunning:  provider.NewLongestRunningProcessorSecondsMetric(name),
+		retries:         provider.NewRetriesMetric(name),
+		addTimes:        map[T]time.Time{},
+		processingTimes: map[T]time.Time{},
+	}
+}
+
+func (m *queueMetrics[T]) add(item T) {
+	m.adds.Inc()
+	if _, ok := m.addTimes[item]; !ok {
+		m.addTimes[item] = time.Now()
+	}
+}
+
+func (m *queueMetrics[T]) get(item T) {
+	m.depth.Dec()
+	m.processingTimes[item] = time.Now()
+	if added, ok := m.addTimes[item]; ok {
+		m.latency.Observe(time.Since(added).Seconds())
+		delete(m.addTimes, item)
+	}
+}
+
+func (m *queueMetrics[T]) done(item T) {
+	if started, ok := m.processingTimes[item]; ok {
+		m.workDuration.Observe(time.Since(started).Seconds())
+		delete(m.processingTimes, item)
+	}
+}
+
+func (m *queueMetrics[T]) updateUnfinishedWork() {
+	var total, longest float64
+	for _, started := range m.processingTimes {
+		elapsed := time.Since(started).Seconds()
+		total += elapsed
+		if elapsed > longest {
+			longest = elapsed
+		}
+	}
+	m.unfinishedWork.Set(total)
+	m.longestRunning.Set(longest)
+}
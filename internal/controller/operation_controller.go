@@ -0,0 +1,336 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/stmcginnis/gofish/redfish"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// operationHandoverGracePeriod is the time after the start of an Operation its target may lack the operation
+// annotations, as the cache of the target may not reflect the handover yet.
+const operationHandoverGracePeriod = 2 * time.Second
+
+// serverOperationTypes are the operations which may be performed on a Server besides its Redfish reset types.
+var serverOperationTypes = []string{
+	metalv1alpha1.OperationAnnotationPXERestart,
+	metalv1alpha1.OperationAnnotationRediscover,
+	metalv1alpha1.OperationAnnotationClearError,
+	metalv1alpha1.OperationAnnotationReplayDiscovery,
+	metalv1alpha1.OperationAnnotationCrashDump,
+	metalv1alpha1.OperationAnnotationRekeySystemUUID,
+}
+
+// serverResetTypes are the Redfish reset types which may be performed on a Server.
+var serverResetTypes = []redfish.ResetType{
+	redfish.OnResetType,
+	redfish.ForceOnResetType,
+	redfish.ForceOffResetType,
+	redfish.GracefulShutdownResetType,
+	redfish.GracefulRestartResetType,
+	redfish.ForceRestartResetType,
+	redfish.NmiResetType,
+	redfish.PushPowerButtonResetType,
+	redfish.PowerCycleResetType,
+}
+
+// OperationReconciler reconciles a Operation object. It hands pending Operations to the controller of their target
+// by setting the operation annotations on it, which the controller records the result of in the Operation.
+type OperationReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=operations,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=operations/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=servers,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=metal.ironcore.dev,resources=bmcs,verbs=get;list;watch;update;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *OperationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	operation := &metalv1alpha1.Operation{}
+	if err := r.Get(ctx, req.NamespacedName, operation); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	return r.reconcileExists(ctx, log, operation)
+}
+
+func (r *OperationReconciler) reconcileExists(ctx context.Context, log logr.Logger, operation *metalv1alpha1.Operation) (ctrl.Result, error) {
+	if !operation.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	return r.reconcile(ctx, log, operation)
+}
+
+func (r *OperationReconciler) reconcile(ctx context.Context, log logr.Logger, operation *metalv1alpha1.Operation) (ctrl.Result, error) {
+	log.V(1).Info("Reconciling Operation")
+	switch operation.Status.State {
+	case "", metalv1alpha1.OperationStatePending:
+		return r.handlePendingState(ctx, log, operation)
+	case metalv1alpha1.OperationStateInProgress:
+		return r.handleInProgressState(ctx, log, operation)
+	default:
+		log.V(1).Info("Operation is completed", "State", operation.Status.State)
+		return ctrl.Result{}, nil
+	}
+}
+
+func (r *OperationReconciler) handlePendingState(ctx context.Context, log logr.Logger, operation *metalv1alpha1.Operation) (ctrl.Result, error) {
+	if notAfter := operation.Spec.NotAfter; notAfter != nil && time.Now().After(notAfter.Time) {
+		return ctrl.Result{}, r.patchState(ctx, operation, metalv1alpha1.OperationStateExpired,
+			fmt.Sprintf("Operation could not be performed before %s", notAfter.Format(time.RFC3339)))
+	}
+	if err := validateOperationType(operation.Spec.TargetRef.Kind, operation.Spec.Type); err != nil {
+		return ctrl.Result{}, r.patchState(ctx, operation, metalv1alpha1.OperationStateFailed, err.Error())
+	}
+	target, err := r.getTarget(ctx, operation)
+	if apierrors.IsNotFound(err) {
+		return ctrl.Result{}, r.patchState(ctx, operation, metalv1alpha1.OperationStateFailed,
+			fmt.Sprintf("%s %s not found", operation.Spec.TargetRef.Kind, operation.Spec.TargetRef.Name))
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	annotations := target.GetAnnotations()
+	if _, ok := annotations[metalv1alpha1.OperationAnnotation]; ok && annotations[metalv1alpha1.OperationNameAnnotation] != operation.Name {
+		// the target is requeued once its current operation has been removed
+		log.V(1).Info("Target has another operation in progress", "Operation", annotations[metalv1alpha1.OperationAnnotation])
+		if operation.Status.State == "" {
+			return ctrl.Result{}, r.patchState(ctx, operation, metalv1alpha1.OperationStatePending, "")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// the Operation is recorded as InProgress before the target sees it, so that the result recorded by the
+	// controller of the target is not overwritten
+	operationBase := operation.DeepCopy()
+	now := metav1.Now()
+	operation.Status.State = metalv1alpha1.OperationStateInProgress
+	operation.Status.StartTime = &now
+	if err := r.Status().Patch(ctx, operation, client.MergeFromWithOptions(operationBase, client.MergeFromWithOptimisticLock{})); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to patch Operation status: %w", err)
+	}
+
+	targetBase := target.DeepCopyObject().(client.Object)
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[metalv1alpha1.OperationAnnotation] = operation.Spec.Type
+	annotations[metalv1alpha1.OperationNameAnnotation] = operation.Name
+	delete(annotations, metalv1alpha1.OperationNotBeforeAnnotation)
+	delete(annotations, metalv1alpha1.OperationNotAfterAnnotation)
+	if notBefore := operation.Spec.NotBefore; notBefore != nil {
+		annotations[metalv1alpha1.OperationNotBeforeAnnotation] = notBefore.UTC().Format(time.RFC3339)
+	}
+	if notAfter := operation.Spec.NotAfter; notAfter != nil {
+		annotations[metalv1alpha1.OperationNotAfterAnnotation] = notAfter.UTC().Format(time.RFC3339)
+	}
+	target.SetAnnotations(annotations)
+	if err := r.Patch(ctx, target, client.MergeFrom(targetBase)); err != nil {
+		err = fmt.Errorf("failed to patch operation annotations of target: %w", err)
+		operationBase := operation.DeepCopy()
+		operation.Status.State = metalv1alpha1.OperationStatePending
+		operation.Status.StartTime = nil
+		if resetErr := r.Status().Patch(ctx, operation, client.MergeFromWithOptions(operationBase, client.MergeFromWithOptimisticLock{})); resetErr != nil {
+			return ctrl.Result{}, errors.Join(err, fmt.Errorf("failed to reset Operation status: %w", resetErr))
+		}
+		return ctrl.Result{}, err
+	}
+	log.V(1).Info("Handed operation to the controller of the target")
+	return ctrl.Result{}, nil
+}
+
+// handleInProgressState fails the Operation if its target has been deleted or its annotations have been removed
+// without the result being recorded by the controller of the target. Missing annotations are tolerated for the
+// operationHandoverGracePeriod after the start of the Operation, until the cache reflects the handover.
+func (r *OperationReconciler) handleInProgressState(ctx context.Context, log logr.Logger, operation *metalv1alpha1.Operation) (ctrl.Result, error) {
+	target, err := r.getTarget(ctx, operation)
+	if apierrors.IsNotFound(err) {
+		return ctrl.Result{}, r.patchState(ctx, operation, metalv1alpha1.OperationStateFailed,
+			fmt.Sprintf("%s %s has been deleted", operation.Spec.TargetRef.Kind, operation.Spec.TargetRef.Name))
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if target.GetAnnotations()[metalv1alpha1.OperationNameAnnotation] == operation.Name {
+		log.V(1).Info("Operation is in progress")
+		return ctrl.Result{}, nil
+	}
+	if start := operation.Status.StartTime; start != nil {
+		if remaining := operationHandoverGracePeriod - time.Since(start.Time); remaining > 0 {
+			log.V(1).Info("Waiting for the handover of the operation to the target")
+			return ctrl.Result{RequeueAfter: remaining}, nil
+		}
+	}
+	return ctrl.Result{}, r.patchState(ctx, operation, metalv1alpha1.OperationStateFailed,
+		"Operation annotations have been removed from the target before the operation completed")
+}
+
+func (r *OperationReconciler) getTarget(ctx context.Context, operation *metalv1alpha1.Operation) (client.Object, error) {
+	var target client.Object
+	switch operation.Spec.TargetRef.Kind {
+	case metalv1alpha1.OperationTargetKindBMC:
+		target = &metalv1alpha1.BMC{}
+	default:
+		target = &metalv1alpha1.Server{}
+	}
+	if err := r.Get(ctx, client.ObjectKey{Name: operation.Spec.TargetRef.Name}, target); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// patchState moves the Operation into the given state. The patch fails if the Operation changed in the meantime,
+// so that results recorded by the controller of the target are not overwritten.
+func (r *OperationReconciler) patchState(ctx context.Context, operation *metalv1alpha1.Operation, state metalv1alpha1.OperationState, message string) error {
+	operationBase := operation.DeepCopy()
+	operation.Status.State = state
+	operation.Status.Message = message
+	if state != metalv1alpha1.OperationStatePending {
+		now := metav1.Now()
+		operation.Status.CompletionTime = &now
+	}
+	if err := r.Status().Patch(ctx, operation, client.MergeFromWithOptions(operationBase, client.MergeFromWithOptimisticLock{})); err != nil {
+		return fmt.Errorf("failed to patch Operation status: %w", err)
+	}
+	return nil
+}
+
+// validateOperationType ensures that the operation may be performed on the kind of its target.
+func validateOperationType(kind metalv1alpha1.OperationTargetKind, operationType string) error {
+	switch kind {
+	case metalv1alpha1.OperationTargetKindBMC:
+		if operationType == metalv1alpha1.OperationAnnotationGracefulRestartBMC {
+			return nil
+		}
+	case metalv1alpha1.OperationTargetKindServer:
+		if slices.Contains(serverOperationTypes, operationType) || slices.Contains(serverResetTypes, redfish.ResetType(operationType)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("operation %s cannot be performed on a %s", operationType, kind)
+}
+
+// trackOperation returns the Operation tracking the operation annotation of the object. Operations requested
+// through the annotation directly are recorded in a new Operation.
+func trackOperation(ctx context.Context, c client.Client, obj client.Object, kind metalv1alpha1.OperationTargetKind) (*metalv1alpha1.Operation, error) {
+	annotations := obj.GetAnnotations()
+	name := annotations[metalv1alpha1.OperationNameAnnotation]
+	if name == "" {
+		name = fmt.Sprintf("%s-%s", obj.GetName(), utilrand.String(5))
+		objBase := obj.DeepCopyObject().(client.Object)
+		annotations[metalv1alpha1.OperationNameAnnotation] = name
+		obj.SetAnnotations(annotations)
+		if err := c.Patch(ctx, obj, client.MergeFrom(objBase)); err != nil {
+			return nil, fmt.Errorf("failed to patch operation name annotation: %w", err)
+		}
+	}
+
+	operation := &metalv1alpha1.Operation{}
+	err := c.Get(ctx, client.ObjectKey{Name: name}, operation)
+	if err == nil || !apierrors.IsNotFound(err) {
+		return operation, err
+	}
+
+	operation = &metalv1alpha1.Operation{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: metalv1alpha1.OperationSpec{
+			TargetRef: metalv1alpha1.OperationTargetRef{Kind: kind, Name: obj.GetName()},
+			Type:      annotations[metalv1alpha1.OperationAnnotation],
+		},
+	}
+	if notBefore, err := time.Parse(time.RFC3339, annotations[metalv1alpha1.OperationNotBeforeAnnotation]); err == nil {
+		operation.Spec.NotBefore = &metav1.Time{Time: notBefore}
+	}
+	if notAfter, err := time.Parse(time.RFC3339, annotations[metalv1alpha1.OperationNotAfterAnnotation]); err == nil {
+		operation.Spec.NotAfter = &metav1.Time{Time: notAfter}
+	}
+	if err := c.Create(ctx, operation); err != nil {
+		return nil, fmt.Errorf("failed to create Operation: %w", err)
+	}
+	operationBase := operation.DeepCopy()
+	now := metav1.Now()
+	operation.Status.State = metalv1alpha1.OperationStateInProgress
+	operation.Status.StartTime = &now
+	operation.Status.Message = fmt.Sprintf("Requested through the %s annotation", metalv1alpha1.OperationAnnotation)
+	if err := c.Status().Patch(ctx, operation, client.MergeFrom(operationBase)); err != nil {
+		return nil, fmt.Errorf("failed to patch Operation status: %w", err)
+	}
+	return operation, nil
+}
+
+// recordOperationResult records the result of the operation annotation of the object in the Operation tracking it.
+// It has to be called before the operation annotations are removed. Results in the InProgress state record the
+// error of a failed attempt.
+func recordOperationResult(ctx context.Context, c client.Client, obj client.Object, state metalv1alpha1.OperationState, message string) error {
+	name := obj.GetAnnotations()[metalv1alpha1.OperationNameAnnotation]
+	if name == "" {
+		return nil
+	}
+	operation := &metalv1alpha1.Operation{}
+	if err := c.Get(ctx, client.ObjectKey{Name: name}, operation); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	operationBase := operation.DeepCopy()
+	operation.Status.State = state
+	operation.Status.Message = message
+	if state != metalv1alpha1.OperationStateInProgress {
+		now := metav1.Now()
+		operation.Status.CompletionTime = &now
+	}
+	if err := c.Status().Patch(ctx, operation, client.MergeFrom(operationBase)); err != nil {
+		return fmt.Errorf("failed to patch Operation status: %w", err)
+	}
+	return nil
+}
+
+// enqueueOperationsByTarget enqueues the uncompleted Operations of the Server or BMC.
+func (r *OperationReconciler) enqueueOperationsByTarget(kind metalv1alpha1.OperationTargetKind) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		operations := &metalv1alpha1.OperationList{}
+		if err := r.List(ctx, operations); err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "failed to list Operations")
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, operation := range operations.Items {
+			if operation.Spec.TargetRef.Kind != kind || operation.Spec.TargetRef.Name != obj.GetName() {
+				continue
+			}
+			switch operation.Status.State {
+			case "", metalv1alpha1.OperationStatePending, metalv1alpha1.OperationStateInProgress:
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKey{Name: operation.Name}})
+			}
+		}
+		return requests
+	})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OperationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&metalv1alpha1.Operation{}).
+		Watches(&metalv1alpha1.Server{}, r.enqueueOperationsByTarget(metalv1alpha1.OperationTargetKindServer)).
+		Watches(&metalv1alpha1.BMC{}, r.enqueueOperationsByTarget(metalv1alpha1.OperationTargetKindBMC)).
+		Complete(r)
+}
@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"time"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+)
+
+var _ = Describe("Operation Controller", func() {
+	_ = SetupTest()
+
+	It("should fail operations whose target does not exist", func(ctx SpecContext) {
+		By("Creating an Operation object")
+		operation := &metalv1alpha1.Operation{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.OperationSpec{
+				TargetRef: metalv1alpha1.OperationTargetRef{Kind: metalv1alpha1.OperationTargetKindServer, Name: "missing"},
+				Type:      metalv1alpha1.OperationAnnotationPXERestart,
+			},
+		}
+		Expect(k8sClient.Create(ctx, operation)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, operation)
+
+		By("Ensuring that the operation failed")
+		Eventually(Object(operation)).Should(SatisfyAll(
+			HaveField("Status.State", metalv1alpha1.OperationStateFailed),
+			HaveField("Status.Message", ContainSubstring("not found")),
+			HaveField("Status.CompletionTime", Not(BeNil())),
+		))
+	})
+
+	It("should fail operations which cannot be performed on the kind of the target", func(ctx SpecContext) {
+		By("Creating an Operation object")
+		operation := &metalv1alpha1.Operation{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.OperationSpec{
+				TargetRef: metalv1alpha1.OperationTargetRef{Kind: metalv1alpha1.OperationTargetKindServer, Name: "foo"},
+				Type:      metalv1alpha1.OperationAnnotationGracefulRestartBMC,
+			},
+		}
+		Expect(k8sClient.Create(ctx, operation)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, operation)
+
+		By("Ensuring that the operation failed")
+		Eventually(Object(operation)).Should(SatisfyAll(
+			HaveField("Status.State", metalv1alpha1.OperationStateFailed),
+			HaveField("Status.Message", ContainSubstring("cannot be performed on a Server")),
+		))
+	})
+
+	It("should hand the operation to the target and fail it if its annotations are removed", func(ctx SpecContext) {
+		By("Creating a paused Server object")
+		server := &metalv1alpha1.Server{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Annotations: map[string]string{
+					metalv1alpha1.PausedUntilAnnotation: time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+				},
+			},
+			Spec: metalv1alpha1.ServerSpec{
+				SystemUUID: "38947555-7742-3448-3784-823347823834",
+			},
+		}
+		Expect(k8sClient.Create(ctx, server)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, server)
+
+		By("Creating an Operation object")
+		notBefore := metav1.NewTime(time.Now().Add(time.Hour).Truncate(time.Second))
+		operation := &metalv1alpha1.Operation{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.OperationSpec{
+				TargetRef: metalv1alpha1.OperationTargetRef{Kind: metalv1alpha1.OperationTargetKindServer, Name: server.Name},
+				Type:      metalv1alpha1.OperationAnnotationPXERestart,
+				NotBefore: &notBefore,
+			},
+		}
+		Expect(k8sClient.Create(ctx, operation)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, operation)
+
+		By("Ensuring that the operation has been handed to the server")
+		Eventually(Object(operation)).Should(SatisfyAll(
+			HaveField("Status.State", metalv1alpha1.OperationStateInProgress),
+			HaveField("Status.StartTime", Not(BeNil())),
+		))
+		Eventually(Object(server)).Should(HaveField("ObjectMeta.Annotations", SatisfyAll(
+			HaveKeyWithValue(metalv1alpha1.OperationAnnotation, metalv1alpha1.OperationAnnotationPXERestart),
+			HaveKeyWithValue(metalv1alpha1.OperationNameAnnotation, operation.Name),
+			HaveKeyWithValue(metalv1alpha1.OperationNotBeforeAnnotation, notBefore.UTC().Format(time.RFC3339)),
+		)))
+
+		By("Removing the operation annotations from the server")
+		Eventually(Update(server, func() {
+			delete(server.Annotations, metalv1alpha1.OperationAnnotation)
+			delete(server.Annotations, metalv1alpha1.OperationNameAnnotation)
+			delete(server.Annotations, metalv1alpha1.OperationNotBeforeAnnotation)
+		})).Should(Succeed())
+
+		By("Ensuring that the operation failed")
+		Eventually(Object(operation)).Should(SatisfyAll(
+			HaveField("Status.State", metalv1alpha1.OperationStateFailed),
+			HaveField("Status.Message", ContainSubstring("removed")),
+		))
+	})
+
+	It("should keep the result the target recorded right after the handover", func(ctx SpecContext) {
+		By("Creating a paused Server object")
+		server := &metalv1alpha1.Server{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Annotations: map[string]string{
+					metalv1alpha1.PausedUntilAnnotation: time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+				},
+			},
+			Spec: metalv1alpha1.ServerSpec{
+				SystemUUID: "38947555-7742-3448-3784-823347823848",
+			},
+		}
+		Expect(k8sClient.Create(ctx, server)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, server)
+
+		By("Creating an Operation object")
+		operation := &metalv1alpha1.Operation{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.OperationSpec{
+				TargetRef: metalv1alpha1.OperationTargetRef{Kind: metalv1alpha1.OperationTargetKindServer, Name: server.Name},
+				Type:      metalv1alpha1.OperationAnnotationPXERestart,
+			},
+		}
+		Expect(k8sClient.Create(ctx, operation)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, operation)
+
+		By("Recording the result as soon as the server carries the operation")
+		Eventually(Object(server)).Should(HaveField("ObjectMeta.Annotations",
+			HaveKeyWithValue(metalv1alpha1.OperationNameAnnotation, operation.Name)))
+		Expect(recordOperationResult(ctx, k8sClient, server, metalv1alpha1.OperationStateSucceeded, "")).To(Succeed())
+
+		By("Ensuring that the result is not overwritten")
+		Eventually(Object(operation)).Should(HaveField("Status.State", metalv1alpha1.OperationStateSucceeded))
+		Consistently(Object(operation)).Should(SatisfyAll(
+			HaveField("Status.State", metalv1alpha1.OperationStateSucceeded),
+			HaveField("Status.StartTime", Not(BeNil())),
+		))
+	})
+})
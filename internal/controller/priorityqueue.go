@@ -0,0 +1,334 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"container/heap"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// expediter is implemented by work queues which can process items ahead of the others.
+type expediter[T comparable] interface {
+	AddExpedited(item T)
+}
+
+// priorityQueue is a rate limiting work queue with two priorities. Expedited items, e.g. those triggered by a
+// ServerClaim, are handed out before all other items, e.g. periodic resyncs. Like the default work queue, an
+// item is never processed concurrently and is only queued once, delayed items are kept in a heap served by a
+// single timer, and the workqueue metrics are recorded under the name of the controller.
+type priorityQueue[T comparable] struct {
+	rateLimiter workqueue.TypedRateLimiter[T]
+	metrics     *queueMetrics[T]
+
+	cond         *sync.Cond
+	expedited    []T
+	normal       []T
+	dirty        map[T]bool
+	processing   map[T]struct{}
+	shuttingDown bool
+
+	// waiting holds the items added with a delay. An item is only waiting once, with its earliest ready time.
+	waiting      waitingHeap[T]
+	waitingItems map[T]*waitingItem[T]
+	timer        *time.Timer
+	timerAt      time.Time
+	stopMetrics  chan struct{}
+}
+
+func newPriorityQueue[T comparable](name string, rateLimiter workqueue.TypedRateLimiter[T]) workqueue.TypedRateLimitingInterface[T] {
+	q := &priorityQueue[T]{
+		rateLimiter:  rateLimiter,
+		metrics:      newQueueMetrics[T](queueMetricsProvider, name),
+		cond:         sync.NewCond(&sync.Mutex{}),
+		dirty:        map[T]bool{},
+		processing:   map[T]struct{}{},
+		waitingItems: map[T]*waitingItem[T]{},
+		stopMetrics:  make(chan struct{}),
+	}
+	go q.updateUnfinishedWorkLoop()
+	return q
+}
+
+// Add queues the item with normal priority.
+func (q *priorityQueue[T]) Add(item T) {
+	q.add(item, false)
+}
+
+// AddExpedited queues the item ahead of all items with normal priority. An item which is already queued with
+// normal priority is moved ahead.
+func (q *priorityQueue[T]) AddExpedited(item T) {
+	q.add(item, true)
+}
+
+func (q *priorityQueue[T]) add(item T, expedited bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	q.addLocked(item, expedited)
+}
+
+func (q *priorityQueue[T]) addLocked(item T, expedited bool) {
+	if q.shuttingDown {
+		return
+	}
+
+	if wasExpedited, ok := q.dirty[item]; ok {
+		if !expedited || wasExpedited {
+			return
+		}
+		q.dirty[item] = true
+		if _, ok := q.processing[item]; !ok {
+			q.normal = slices.DeleteFunc(q.normal, func(i T) bool { return i == item })
+			q.expedited = append(q.expedited, item)
+			q.cond.Signal()
+		}
+		return
+	}
+
+	q.metrics.add(item)
+	q.dirty[item] = expedited
+	if _, ok := q.processing[item]; ok {
+		return
+	}
+	q.push(item, expedited)
+	q.cond.Signal()
+}
+
+func (q *priorityQueue[T]) push(item T, expedited bool) {
+	q.metrics.depth.Inc()
+	if expedited {
+		q.expedited = append(q.expedited, item)
+	} else {
+		q.normal = append(q.normal, item)
+	}
+}
+
+// Len returns the number of queued items.
+func (q *priorityQueue[T]) Len() int {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	return len(q.expedited) + len(q.normal)
+}
+
+// Get blocks until an item can be processed, preferring expedited items.
+func (q *priorityQueue[T]) Get() (item T, shutdown bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	for len(q.expedited) == 0 && len(q.normal) == 0 && !q.shuttingDown {
+		q.cond.Wait()
+	}
+	switch {
+	case len(q.expedited) > 0:
+		item, q.expedited = q.expedited[0], q.expedited[1:]
+	case len(q.normal) > 0:
+		item, q.normal = q.normal[0], q.normal[1:]
+	default:
+		return item, true
+	}
+	q.metrics.get(item)
+	delete(q.dirty, item)
+	q.processing[item] = struct{}{}
+	return item, false
+}
+
+// Done marks the item as processed. If it has been added again while it was processed, it is queued again.
+func (q *priorityQueue[T]) Done(item T) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	q.metrics.done(item)
+	delete(q.processing, item)
+	if expedited, ok := q.dirty[item]; ok {
+		q.push(item, expedited)
+	}
+	q.cond.Broadcast()
+}
+
+func (q *priorityQueue[T]) ShutDown() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	q.shutDownLocked()
+}
+
+// ShutDownWithDrain shuts the queue down and waits until all items in processing are done.
+func (q *priorityQueue[T]) ShutDownWithDrain() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	q.shutDownLocked()
+	for len(q.processing) > 0 {
+		q.cond.Wait()
+	}
+}
+
+func (q *priorityQueue[T]) shutDownLocked() {
+	if !q.shuttingDown {
+		q.shuttingDown = true
+		close(q.stopMetrics)
+		if q.timer != nil {
+			q.timer.Stop()
+		}
+	}
+	q.cond.Broadcast()
+}
+
+func (q *priorityQueue[T]) ShuttingDown() bool {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	return q.shuttingDown
+}
+
+// AddAfter queues the item with normal priority once the duration has passed. If the item is already waiting, it
+// is queued at the earlier of both times.
+func (q *priorityQueue[T]) AddAfter(item T, duration time.Duration) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	if q.shuttingDown {
+		return
+	}
+	q.metrics.retries.Inc()
+	if duration <= 0 {
+		q.addLocked(item, false)
+		return
+	}
+
+	readyAt := time.Now().Add(duration)
+	if entry, ok := q.waitingItems[item]; ok {
+		if readyAt.Before(entry.readyAt) {
+			entry.readyAt = readyAt
+			heap.Fix(&q.waiting, entry.index)
+		}
+	} else {
+		entry := &waitingItem[T]{item: item, readyAt: readyAt}
+		heap.Push(&q.waiting, entry)
+		q.waitingItems[item] = entry
+	}
+	q.scheduleLocked()
+}
+
+// scheduleLocked arms the timer for the waiting item which is ready first.
+func (q *priorityQueue[T]) scheduleLocked() {
+	if len(q.waiting) == 0 {
+		q.timerAt = time.Time{}
+		return
+	}
+	next := q.waiting[0].readyAt
+	if !q.timerAt.IsZero() && !next.Before(q.timerAt) {
+		return
+	}
+	q.timerAt = next
+	if q.timer == nil {
+		q.timer = time.AfterFunc(time.Until(next), q.addReady)
+		return
+	}
+	q.timer.Reset(time.Until(next))
+}
+
+// addReady queues the waiting items whose time has come.
+func (q *priorityQueue[T]) addReady() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	if q.shuttingDown {
+		return
+	}
+	now := time.Now()
+	for len(q.waiting) > 0 && !q.waiting[0].readyAt.After(now) {
+		entry := heap.Pop(&q.waiting).(*waitingItem[T])
+		delete(q.waitingItems, entry.item)
+		q.addLocked(entry.item, false)
+	}
+	q.timerAt = time.Time{}
+	q.scheduleLocked()
+}
+
+func (q *priorityQueue[T]) updateUnfinishedWorkLoop() {
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-q.stopMetrics:
+			return
+		case <-ticker.C:
+			q.cond.L.Lock()
+			q.metrics.updateUnfinishedWork()
+			q.cond.L.Unlock()
+		}
+	}
+}
+
+func (q *priorityQueue[T]) AddRateLimited(item T) {
+	q.AddAfter(item, q.rateLimiter.When(item))
+}
+
+func (q *priorityQueue[T]) Forget(item T) {
+	q.rateLimiter.Forget(item)
+}
+
+func (q *priorityQueue[T]) NumRequeues(item T) int {
+	return q.rateLimiter.NumRequeues(item)
+}
+
+type waitingItem[T comparable] struct {
+	item    T
+	readyAt time.Time
+	index   int
+}
+
+// waitingHeap implements heap.Interface, ordering the waiting items by their ready time.
+type waitingHeap[T comparable] []*waitingItem[T]
+
+func (h waitingHeap[T]) Len() int           { return len(h) }
+func (h waitingHeap[T]) Less(i, j int) bool { return h[i].readyAt.Before(h[j].readyAt) }
+func (h waitingHeap[T]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *waitingHeap[T]) Push(x any) {
+	entry := x.(*waitingItem[T])
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *waitingHeap[T]) Pop() any {
+	old := *h
+	entry := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return entry
+}
+
+// addExpedited queues the request ahead of the others if the queue supports it.
+func addExpedited(q workqueue.TypedRateLimitingInterface[reconcile.Request], req reconcile.Request) {
+	if e, ok := q.(expediter[reconcile.Request]); ok {
+		e.AddExpedited(req)
+		return
+	}
+	q.Add(req)
+}
+
+// enqueueExpedited expedites the requests of the objects for which expedite returns true. It is used next to
+// the regular For watch of a controller, which queues every event with normal priority: the priority queue
+// merges both into a single expedited request.
+func enqueueExpedited(expedite func(oldObj, newObj client.Object) bool) handler.EventHandler {
+	return handler.Funcs{
+		CreateFunc: func(_ context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			if expedite(nil, e.Object) {
+				addExpedited(q, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(e.Object)})
+			}
+		},
+		UpdateFunc: func(_ context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			if expedite(e.ObjectOld, e.ObjectNew) {
+				addExpedited(q, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(e.ObjectNew)})
+			}
+		},
+	}
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/client-go/util/workqueue"
+)
+
+var _ = Describe("Priority queue", func() {
+	It("should hand out expedited items first", func() {
+		q := newPriorityQueue("test", workqueue.DefaultTypedControllerRateLimiter[string]())
+		DeferCleanup(q.ShutDown)
+
+		q.Add("resync-1")
+		q.Add("resync-2")
+		q.(expediter[string]).AddExpedited("claim")
+		q.(expediter[string]).AddExpedited("resync-2")
+		Expect(q.Len()).To(Equal(3))
+
+		for _, expected := range []string{"claim", "resync-2", "resync-1"} {
+			item, shutdown := q.Get()
+			Expect(shutdown).To(BeFalse())
+			Expect(item).To(Equal(expected))
+			q.Done(item)
+		}
+	})
+
+	It("should requeue an item added while it is processed", func() {
+		q := newPriorityQueue("test", workqueue.DefaultTypedControllerRateLimiter[string]())
+		DeferCleanup(q.ShutDown)
+
+		q.Add("server")
+		item, _ := q.Get()
+		q.Add("server")
+		Expect(q.Len()).To(Equal(0))
+		q.Done(item)
+		Expect(q.Len()).To(Equal(1))
+	})
+
+	It("should keep a single delayed add per item at its earliest time", func() {
+		q := newPriorityQueue("test", workqueue.DefaultTypedControllerRateLimiter[string]())
+		DeferCleanup(q.ShutDown)
+		waiting := func() int {
+			pq := q.(*priorityQueue[string])
+			pq.cond.L.Lock()
+			defer pq.cond.L.Unlock()
+			return len(pq.waiting)
+		}
+
+		q.AddAfter("server", time.Hour)
+		q.AddAfter("server", 10*time.Millisecond)
+		q.AddAfter("server", time.Hour)
+		q.AddAfter("other", time.Hour)
+		Expect(waiting()).To(Equal(2))
+
+		Eventually(q.Len).Should(Equal(1))
+		Expect(waiting()).To(Equal(1))
+		item, _ := q.Get()
+		Expect(item).To(Equal("server"))
+		q.Done(item)
+		Consistently(q.Len, 50*time.Millisecond).Should(BeZero())
+	})
+
+	It("should record the workqueue metrics", func() {
+		q := newPriorityQueue("metrics-test", workqueue.DefaultTypedControllerRateLimiter[string]())
+		DeferCleanup(q.ShutDown)
+
+		q.Add("server")
+		q.(expediter[string]).AddExpedited("claim")
+		q.AddRateLimited("resync")
+		Eventually(q.Len).Should(Equal(3))
+		Expect(testutil.ToFloat64(queueDepth.WithLabelValues("metrics-test", "metrics-test"))).To(Equal(3.0))
+		Expect(testutil.ToFloat64(queueAdds.WithLabelValues("metrics-test", "metrics-test"))).To(Equal(3.0))
+		Expect(testutil.ToFloat64(queueRetries.WithLabelValues("metrics-test", "metrics-test"))).To(Equal(1.0))
+
+		item, _ := q.Get()
+		Expect(testutil.ToFloat64(queueDepth.WithLabelValues("metrics-test", "metrics-test"))).To(Equal(2.0))
+		q.Done(item)
+	})
+})
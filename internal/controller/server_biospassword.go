@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+)
+
+const (
+	// defaultBIOSPasswordName is the BIOS password which is set if the BIOSPassword of a Server names none.
+	defaultBIOSPasswordName = "AdminPassword"
+	// biosPasswordSecretKey is the key of the password in the Secret recording the BIOS password of a Server.
+	biosPasswordSecretKey = "password"
+	// biosPendingPasswordSecretKey is the key of the password which is being set in the Secret recording the BIOS
+	// password of a Server. It is recorded before the password is set, so that a password which the BMC applied
+	// despite a failure, or whose recording failed, is not lost.
+	biosPendingPasswordSecretKey = "pendingPassword"
+)
+
+// biosPasswordSecretName is the name of the Secret in the manager namespace recording the BIOS password which has
+// been set on the Server.
+func biosPasswordSecretName(server *metalv1alpha1.Server) string {
+	return server.Name + "-bios-password"
+}
+
+// reconcileBIOSPassword sets the BIOS password of the Server whenever the value in its Secret changed. The password
+// which has been set is recorded in a Secret of the manager, as it is the old password of the next rotation. It
+// returns the current BIOS password, or an empty string if the Server has none. Only Secrets in the manager
+// namespace are read.
+func (r *ServerReconciler) reconcileBIOSPassword(ctx context.Context, log logr.Logger, bmcClient bmc.BMC, server *metalv1alpha1.Server) (string, error) {
+	spec := server.Spec.BIOSPassword
+	if spec == nil {
+		return "", nil
+	}
+	name := spec.Name
+	if name == "" {
+		name = defaultBIOSPasswordName
+	}
+
+	ref := spec.SecretKeyRef
+	if err := r.checkManagerNamespace("Secret", &ref); err != nil {
+		return "", fmt.Errorf("invalid BIOS password: %w", err)
+	}
+	secret := &v1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+		return "", fmt.Errorf("failed to get Secret for BIOS password: %w", err)
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("secret %s/%s has no key %s for BIOS password", ref.Namespace, ref.Name, ref.Key)
+	}
+	password := string(value)
+
+	applied := &v1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: r.ManagerNamespace, Name: biosPasswordSecretName(server)}, applied); err != nil && !apierrors.IsNotFound(err) {
+		return "", fmt.Errorf("failed to get applied BIOS password: %w", err)
+	}
+	oldPassword := string(applied.Data[biosPasswordSecretKey])
+	pendingPassword, pending := applied.Data[biosPendingPasswordSecretKey]
+	if !pending && server.Status.BIOSPasswordVersion == secret.ResourceVersion && oldPassword == password {
+		return password, nil
+	}
+
+	if oldPassword != password || pending {
+		// the current password is the pending one if the BMC applied it before the last attempt failed
+		candidates := []string{oldPassword}
+		if pending {
+			candidates = []string{string(pendingPassword), oldPassword}
+		}
+		if err := r.recordBIOSPassword(ctx, server, applied, map[string][]byte{
+			biosPasswordSecretKey:        []byte(oldPassword),
+			biosPendingPasswordSecretKey: value,
+		}); err != nil {
+			return "", err
+		}
+		var err error
+		for _, candidate := range slices.Compact(candidates) {
+			if err = bmcClient.SetBiosPassword(ctx, server.Spec.SystemUUID, name, candidate, password); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to set BIOS password: %w", r.Redactor.Error(err))
+		}
+		log.V(1).Info("Set BIOS password", "Name", name)
+	}
+
+	if err := r.recordBIOSPassword(ctx, server, applied, map[string][]byte{biosPasswordSecretKey: value}); err != nil {
+		return "", err
+	}
+
+	serverBase := server.DeepCopy()
+	server.Status.BIOSPasswordVersion = secret.ResourceVersion
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return "", fmt.Errorf("failed to patch Server status: %w", err)
+	}
+	return password, nil
+}
+
+// recordBIOSPassword replaces the data of the Secret recording the BIOS password of the Server.
+func (r *ServerReconciler) recordBIOSPassword(ctx context.Context, server *metalv1alpha1.Server, applied *v1.Secret, data map[string][]byte) error {
+	applied.Name = biosPasswordSecretName(server)
+	applied.Namespace = r.ManagerNamespace
+	if _, err := controllerutil.CreateOrPatch(ctx, r.Client, applied, func() error {
+		applied.Data = data
+		return controllerutil.SetControllerReference(server, applied, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to record applied BIOS password: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+)
+
+var _ = Describe("Server BIOS Password", func() {
+	ns := SetupTest()
+
+	var (
+		simulator  *bmc.Simulator
+		server     *metalv1alpha1.Server
+		reconciler *ServerReconciler
+		bmcClient  bmc.BMC
+		secret     *v1.Secret
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		simulator = registerSimulator("10.30.0.15:8000", "38947555-7742-3448-3784-823347823849")
+		server = createPausedServer(ctx, "10.30.0.15", "38947555-7742-3448-3784-823347823849")
+		reconciler = &ServerReconciler{
+			Client:           k8sClient,
+			Scheme:           k8sClient.Scheme(),
+			Insecure:         true,
+			ManagerNamespace: ns.Name,
+			BMCOptions:       bmc.BMCOptions{BasicAuth: true},
+		}
+		var err error
+		bmcClient, err = bmcutils.GetBMCClientForServer(ctx, k8sClient, server, true, reconciler.BMCOptions)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(bmcClient.Logout)
+
+		secret = &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name, Name: "bios-passwords"},
+			Data:       map[string][]byte{"admin": []byte("first")},
+		}
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, secret)
+		Eventually(Update(server, func() {
+			server.Spec.BIOSPassword = &metalv1alpha1.BIOSPassword{
+				SecretKeyRef: metalv1alpha1.ObjectKeySelector{Namespace: ns.Name, Name: secret.Name, Key: "admin"},
+			}
+		})).Should(Succeed())
+	})
+
+	It("Should only read the BIOS password from Secrets in the manager namespace", func(ctx SpecContext) {
+		Eventually(Update(server, func() {
+			server.Spec.BIOSPassword.SecretKeyRef.Namespace = "kube-system"
+		})).Should(Succeed())
+		_, err := reconciler.reconcileBIOSPassword(ctx, GinkgoLogr, bmcClient, server)
+		Expect(err).To(MatchError(ContainSubstring("not in the manager namespace")))
+		Expect(simulator.State().Systems[0].BiosPasswords).To(BeEmpty())
+	})
+
+	It("Should keep the password which the BMC applied despite failing to set it", func(ctx SpecContext) {
+		applied := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name, Name: biosPasswordSecretName(server)}}
+
+		By("Setting the first password")
+		Expect(reconciler.reconcileBIOSPassword(ctx, GinkgoLogr, bmcClient, server)).To(Equal("first"))
+		Expect(simulator.State().Systems[0].BiosPasswords).To(HaveKeyWithValue(defaultBIOSPasswordName, "first"))
+		Eventually(Object(applied)).Should(HaveField("Data", Equal(map[string][]byte{biosPasswordSecretKey: []byte("first")})))
+
+		By("Failing to set the second password after the BMC applied it")
+		simulator.SetIntercept(func(ctx context.Context, operation string) error {
+			if operation != "SetBiosPassword" {
+				return nil
+			}
+			simulator.Update(func(state *bmc.SimulatorState) {
+				state.Systems[0].BiosPasswords[defaultBIOSPasswordName] = "second"
+			})
+			return errors.New("context deadline exceeded")
+		})
+		Eventually(Update(secret, func() {
+			secret.Data["admin"] = []byte("second")
+		})).Should(Succeed())
+		_, err := reconciler.reconcileBIOSPassword(ctx, GinkgoLogr, bmcClient, server)
+		Expect(err).To(HaveOccurred())
+		Eventually(Object(applied)).Should(HaveField("Data", SatisfyAll(
+			HaveKeyWithValue(biosPasswordSecretKey, []byte("first")),
+			HaveKeyWithValue(biosPendingPasswordSecretKey, []byte("second")),
+		)))
+
+		By("Confirming the pending password once the BMC accepts it")
+		simulator.SetIntercept(nil)
+		Expect(reconciler.reconcileBIOSPassword(ctx, GinkgoLogr, bmcClient, server)).To(Equal("second"))
+		Expect(simulator.State().Systems[0].BiosPasswords).To(HaveKeyWithValue(defaultBIOSPasswordName, "second"))
+		Eventually(Object(applied)).Should(HaveField("Data", Equal(map[string][]byte{biosPasswordSecretKey: []byte("second")})))
+	})
+})
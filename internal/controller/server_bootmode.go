@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+)
+
+const (
+	// ServerConditionBootModeConfigured reports whether the Server boots in the boot mode of its spec. A switch of
+	// the boot mode is pending until the next boot of the Server.
+	ServerConditionBootModeConfigured = "BootModeConfigured"
+
+	serverBootModeReasonConfigured  = "Configured"
+	serverBootModeReasonPending     = "PendingReboot"
+	serverBootModeReasonDeferred    = "Deferred"
+	serverBootModeReasonUnsupported = "Unsupported"
+)
+
+// serverBootMode returns the boot mode the Server boots in, which is the boot mode of its spec, or the boot mode
+// reported by its BMC if the spec has none.
+func serverBootMode(server *metalv1alpha1.Server) metalv1alpha1.BootMode {
+	if server.Spec.BootMode != "" {
+		return server.Spec.BootMode
+	}
+	return server.Status.BootMode
+}
+
+// applyBootMode switches the Server into the boot mode of its spec through the BIOS attributes of its vendor. As
+// the operating system of a claimed Server does not boot in another boot mode, the switch is only applied while the
+// Server is not claimed.
+func (r *ServerReconciler) applyBootMode(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) error {
+	mode := server.Spec.BootMode
+	if mode == "" {
+		return nil
+	}
+	serverBase := server.DeepCopy()
+	condition := metav1.Condition{
+		Type:               ServerConditionBootModeConfigured,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: server.Generation,
+	}
+	switch {
+	case server.Status.BootMode == mode:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = serverBootModeReasonConfigured
+		condition.Message = fmt.Sprintf("The server boots in the %s boot mode.", mode)
+	case mode == metalv1alpha1.BootModeLegacy && server.Status.Architecture == metalv1alpha1.ArchitectureAArch64:
+		condition.Reason = serverBootModeReasonUnsupported
+		condition.Message = "aarch64 servers boot via UEFI only."
+	case server.Status.BootMode == "":
+		condition.Reason = serverBootModeReasonUnsupported
+		condition.Message = "The BIOS of the server has no known boot mode attribute."
+	case server.Status.PendingBootMode == mode:
+		condition.Reason = serverBootModeReasonPending
+		condition.Message = fmt.Sprintf("The server switches to the %s boot mode on its next boot.", mode)
+	case server.Spec.ServerClaimRef != nil:
+		condition.Reason = serverBootModeReasonDeferred
+		condition.Message = fmt.Sprintf("The server is switched to the %s boot mode once it is not claimed.", mode)
+	default:
+		bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.bmcOptions(server))
+		if err != nil {
+			return fmt.Errorf("failed to create BMC client: %w", err)
+		}
+		defer bmcClient.Logout()
+		if err := bmcClient.SetBootMode(ctx, server.Spec.SystemUUID, bmc.BootMode(mode)); err != nil {
+			return fmt.Errorf("failed to set boot mode %s: %w", mode, err)
+		}
+		log.V(1).Info("Switched boot mode, which takes effect on the next boot", "BootMode", mode)
+		condition.Reason = serverBootModeReasonPending
+		condition.Message = fmt.Sprintf("The server switches to the %s boot mode on its next boot.", mode)
+		server.Status.PendingBootMode = mode
+	}
+
+	if !meta.SetStatusCondition(&server.Status.Conditions, condition) && server.Status.PendingBootMode == serverBase.Status.PendingBootMode {
+		return nil
+	}
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return fmt.Errorf("failed to patch BootModeConfigured condition: %w", err)
+	}
+	return nil
+}
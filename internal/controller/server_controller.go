@@ -9,10 +9,19 @@ import (
 	"crypto/rsa"
 	"encoding/json"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
+	mathrand "math/rand/v2"
+	"net"
 	"net/http"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
@@ -20,6 +29,8 @@ import (
 	"golang.org/x/crypto/ssh"
 
 	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+	"github.com/ironcore-dev/metal-operator/internal/features"
+	"github.com/ironcore-dev/metal-operator/internal/redact"
 
 	"github.com/go-logr/logr"
 	"github.com/ironcore-dev/controller-utils/clientutils"
@@ -27,19 +38,24 @@ import (
 	"github.com/ironcore-dev/metal-operator/bmc"
 	"github.com/ironcore-dev/metal-operator/internal/api/registry"
 	"github.com/ironcore-dev/metal-operator/internal/ignition"
+	registryserver "github.com/ironcore-dev/metal-operator/internal/registry"
 	"github.com/stmcginnis/gofish/redfish"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	meta "k8s.io/apimachinery/pkg/api/meta"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
@@ -54,6 +70,113 @@ const (
 	ServerFinalizer               = "metal.ironcore.dev/server"
 	InternalAnnotationTypeKeyName = "metal.ironcore.dev/type"
 	InternalAnnotationTypeValue   = "Internal"
+
+	// ServerBootFailedLabel taints a Server whose operating system did not come up after all boot retries.
+	// Servers carrying this label are not picked by ServerClaims until the label is removed.
+	ServerBootFailedLabel = "metal.ironcore.dev/boot-failed"
+	// ServerArchitectureLabel holds the CPU architecture of a Server, so that ServerClaims can select by it.
+	ServerArchitectureLabel = "metal.ironcore.dev/architecture"
+	// ServerManufacturerLabel holds the canonical manufacturer of a Server, so that ServerClaims can select by it.
+	ServerManufacturerLabel = "metal.ironcore.dev/manufacturer"
+	// ServerModelLabel holds the canonical model of a Server, so that ServerClaims can select by it.
+	ServerModelLabel = "metal.ironcore.dev/model"
+)
+
+const (
+	// ServerConditionBootFailed reports whether the operating system of a reserved Server came up after
+	// it has been PXE booted.
+	ServerConditionBootFailed = "BootFailed"
+
+	serverBootReasonInProgress = "BootInProgress"
+	serverBootReasonSucceeded  = "BootSucceeded"
+	serverBootReasonFailed     = "BootVerificationFailed"
+
+	serverBootDialTimeout = 2 * time.Second
+
+	// ServerConditionBootOverrideIgnored reports whether the BMC of a Server ignored the PXE boot override, so that
+	// the Server booted from disk instead of the network.
+	ServerConditionBootOverrideIgnored = "BootOverrideIgnored"
+
+	serverBootOverrideReasonHonored  = "BootOverrideHonored"
+	serverBootOverrideReasonRetrying = "BootOverrideRetrying"
+	serverBootOverrideReasonIgnored  = "BootOverrideIgnored"
+
+	// ServerConditionRebootNeeded reports whether applied BIOS settings require a reboot of the Server.
+	ServerConditionRebootNeeded = "RebootNeeded"
+	// legacyServerConditionRebootNeeded is the type the RebootNeeded condition was recorded with before.
+	legacyServerConditionRebootNeeded = "Reboot needed"
+
+	// biosSettingMaskedValue replaces the values of BIOS settings read from Secrets or with sensitive keys in the
+	// status of a Server.
+	biosSettingMaskedValue = redact.Mask
+
+	// biosSettingsHistoryLimit is the number of BIOS settings changes kept in the status of a Server.
+	biosSettingsHistoryLimit = 10
+
+	// ServerConditionTransitionBlocked reports whether a state transition of the Server is blocked by
+	// pre-transition hooks.
+	ServerConditionTransitionBlocked = "TransitionBlocked"
+
+	serverTransitionReasonHooksPending = "HooksPending"
+
+	// ServerConditionPaused reports whether the reconciliation of the Server is paused by the ignore operation
+	// or the PausedUntilAnnotation.
+	ServerConditionPaused = "Paused"
+
+	serverPausedReasonIgnoreOperation = "IgnoreOperation"
+	serverPausedReasonPausedUntil     = "PausedUntil"
+
+	// maxProbeExtensionSize is the maximum size of the output of a probe agent collector surfaced as annotation.
+	maxProbeExtensionSize = 32 * 1024
+	// maxProbeExtensionsSize is the maximum total size of the probe agent collector outputs surfaced as annotations,
+	// which keeps the annotations of the Server well below the limit of the API server.
+	maxProbeExtensionsSize = 128 * 1024
+)
+
+const (
+	// ServerConditionDiscoveryFailed reports whether a Server gave up on discovery after all escalation
+	// steps have been performed.
+	ServerConditionDiscoveryFailed = "DiscoveryFailed"
+
+	serverDiscoveryReasonEscalated = "Escalated"
+	serverDiscoveryReasonExhausted = "DiscoveryAttemptsExhausted"
+
+	// serverDiscoveryEventLogEntries is the number of event log entries reported on a failed discovery.
+	serverDiscoveryEventLogEntries = 5
+
+	// ServerConditionRediscovering marks a Server which has been moved back into discovery by its rediscovery
+	// interval. It counts the Server towards the MaxConcurrentRediscoveries until its discovery completes.
+	ServerConditionRediscovering = "Rediscovering"
+
+	serverRediscoveryReasonIntervalPassed = "RediscoveryIntervalPassed"
+
+	// rediscoveryPendingTimeout is the time a Server moved into rediscovery is counted towards the
+	// MaxConcurrentRediscoveries before the cache reflects its Rediscovering condition.
+	rediscoveryPendingTimeout = time.Minute
+)
+
+const (
+	// ServerConditionPoweredOn reports whether the Server is powered on.
+	ServerConditionPoweredOn = "PoweredOn"
+	// ServerConditionPoweredOff reports whether the Server is powered off.
+	ServerConditionPoweredOff = "PoweredOff"
+	// ServerConditionPowerCycleRequested records the latest reset requested for the Server.
+	ServerConditionPowerCycleRequested = "PowerCycleRequested"
+
+	serverPowerReasonOnCompleted  = "PowerOnCompleted"
+	serverPowerReasonOffCompleted = "PowerOffCompleted"
+	serverPowerReasonObserved     = "PowerStateObserved"
+	serverPowerReasonReset        = "ResetRequested"
+)
+
+// DiscoveryEscalationAction is a remediation step performed for a Server which repeatedly times out in discovery.
+type DiscoveryEscalationAction string
+
+const (
+	// DiscoveryEscalationResetBMC resets the BMC of the Server before the next discovery attempt.
+	DiscoveryEscalationResetBMC DiscoveryEscalationAction = "ResetBMC"
+	// DiscoveryEscalationSwitchBootMode PXE boots the Server in legacy boot mode for all following discovery attempts.
+	DiscoveryEscalationSwitchBootMode DiscoveryEscalationAction = "SwitchBootMode"
 )
 
 const (
@@ -65,18 +188,84 @@ const (
 // ServerReconciler reconciles a Server object
 type ServerReconciler struct {
 	client.Client
-	Scheme                 *runtime.Scheme
-	Insecure               bool
-	ManagerNamespace       string
-	ProbeImage             string
-	RegistryURL            string
-	ProbeOSImage           string
-	RegistryResyncInterval time.Duration
-	EnforceFirstBoot       bool
-	EnforcePowerOff        bool
-	ResyncInterval         time.Duration
-	BMCOptions             bmc.BMCOptions
-	DiscoveryTimeout       time.Duration
+	Scheme *runtime.Scheme
+	// Redactor masks the values of sensitive BIOS settings in the status, the settings history and errors. If nil,
+	// redact.Default is used.
+	Redactor         *redact.Redactor
+	Insecure         bool
+	ManagerNamespace string
+	ProbeImage       string
+	RegistryURL      string
+	// RegistryTokenKey is the key the tokens authenticating the probe agents at the registry are derived from, see
+	// registryserver.SystemToken. The token of a server is passed to its probe agent through the ignition.
+	RegistryTokenKey []byte
+	ProbeOSImage     string
+	// ProbeBMCAccount is the BMC account whose password the probe agent sets through the Redfish host interface.
+	// An empty value disables the credential bootstrapping.
+	ProbeBMCAccount string
+	// DiscoveryImageConfigMap is the name of the ConfigMap in the ManagerNamespace which maps server models to
+	// probe OS images overriding the ProbeOSImage. An empty value disables the mapping.
+	DiscoveryImageConfigMap string
+	RegistryResyncInterval  time.Duration
+	EnforceFirstBoot        bool
+	EnforcePowerOff         bool
+	ResyncInterval          time.Duration
+	BMCOptions              bmc.BMCOptions
+	DiscoveryTimeout        time.Duration
+	// BMCFailureTimeout is the time the BMC of a Server may fail its status updates before the Server is moved
+	// into the Error state. A zero value disables the timeout.
+	BMCFailureTimeout time.Duration
+	// BootVerificationTimeout is the time a reserved Server has to become reachable after a PXE boot.
+	// A zero value disables the boot verification.
+	BootVerificationTimeout time.Duration
+	// BootVerificationPort is the TCP port which is probed on the Server's network interfaces.
+	BootVerificationPort int
+	// MaxBootRetries is the number of additional PXE boots before the boot is considered failed.
+	MaxBootRetries int
+	// BootOverrideCheckDelay is the time after a PXE boot after which a PXE boot override which is still pending on
+	// the BMC is considered ignored. A zero value disables the check.
+	BootOverrideCheckDelay time.Duration
+	// TaintOnBootFailure labels a Server with ServerBootFailedLabel once its boot is considered failed.
+	TaintOnBootFailure bool
+	// MaxDiscoveryAttempts is the number of timed out discovery boots after which the DiscoveryEscalation
+	// steps are performed. A zero value retries the discovery forever.
+	MaxDiscoveryAttempts int
+	// DiscoveryEscalation is the ordered list of actions performed for each further timed out discovery
+	// boot. Once all actions have been performed, the Server is marked as DiscoveryFailed.
+	DiscoveryEscalation []DiscoveryEscalationAction
+	// RediscoveryInterval is the time after its last discovery an Available Server is discovered again. A zero
+	// value disables the rediscovery.
+	RediscoveryInterval time.Duration
+	// MaxConcurrentRediscoveries is the number of Servers which may be rediscovered at the same time. A zero value
+	// does not limit the rediscoveries.
+	MaxConcurrentRediscoveries int
+	// WarmUp spreads the first BMC connections after a leader election. A nil value disables the warm-up.
+	WarmUp *WarmUp
+	// FeatureGate enables experimental features. A nil value enables the features which are enabled by default.
+	FeatureGate *features.Gate
+	// ObserverMode only updates the status of Servers from their BMCs and reports their drift from the spec,
+	// without performing operations or state transitions.
+	ObserverMode bool
+	// CrashDumpUploadURL is the URL of the object storage crash dumps are uploaded to. An empty value disables the
+	// CrashDump operation.
+	CrashDumpUploadURL string
+	// CrashDumpCaptureImage is the image of the capture environment which uploads the crash dump of Servers whose
+	// BMC does not capture crash dumps. An empty value only collects crash dumps captured by the BMC.
+	CrashDumpCaptureImage string
+	// CrashDumpTimeout is the time the collection of a crash dump may take.
+	CrashDumpTimeout time.Duration
+	// DriveLocateTimeout is the time the location indicator of a drive located with the locate-drive annotation
+	// is turned on.
+	DriveLocateTimeout time.Duration
+
+	// updatedSettings overrides the settings above once they have been updated with UpdateSettings.
+	updatedSettings atomic.Pointer[ServerSettings]
+
+	// rediscoveryMu serializes the rediscoveries, so that concurrent reconciles do not exceed the
+	// MaxConcurrentRediscoveries.
+	rediscoveryMu sync.Mutex
+	// rediscoveryPending holds the time Servers were moved into rediscovery until the cache reflects it.
+	rediscoveryPending map[string]time.Time
 }
 
 //+kubebuilder:rbac:groups=metal.ironcore.dev,resources=bmcs,verbs=get;list;watch
@@ -108,6 +297,44 @@ func (r *ServerReconciler) reconcileExists(ctx context.Context, log logr.Logger,
 	return r.reconcile(ctx, log, server)
 }
 
+// warmUpDelay returns the time the polling of the BMC of the Server is deferred by while the leader warms up.
+func (r *ServerReconciler) warmUpDelay(ctx context.Context, server *metalv1alpha1.Server) (time.Duration, error) {
+	if server.Spec.BMCRef == nil {
+		return r.WarmUp.DelayInline(server), nil
+	}
+	bmcObj := &metalv1alpha1.BMC{}
+	if err := r.Get(ctx, client.ObjectKey{Name: server.Spec.BMCRef.Name}, bmcObj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get BMC: %w", err)
+	}
+	return r.WarmUp.Delay(bmcObj), nil
+}
+
+// pollServerStatus updates the status of the Server from its BMC. While the leader warms up, the polling is deferred
+// and the remaining warm-up delay is returned, so that claims and operations are still handled right away.
+func (r *ServerReconciler) pollServerStatus(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) (time.Duration, error) {
+	delay, err := r.warmUpDelay(ctx, server)
+	if err != nil {
+		return 0, err
+	}
+	if delay > 0 {
+		log.V(1).Info("Deferred Server status polling during warm-up", "Delay", delay)
+		return delay, nil
+	}
+
+	updateErr := r.updateServerStatus(ctx, log, server)
+	if err := r.recordBMCStatus(ctx, log, server, updateErr); err != nil {
+		return 0, err
+	}
+	if updateErr != nil {
+		return 0, fmt.Errorf("failed to update server status: %w", updateErr)
+	}
+	log.V(1).Info("Updated Server status", "Status", server.Status.State)
+	return 0, nil
+}
+
 func (r *ServerReconciler) delete(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) (ctrl.Result, error) {
 	log.V(1).Info("Deleting server")
 
@@ -122,6 +349,15 @@ func (r *ServerReconciler) delete(ctx context.Context, log logr.Logger, server *
 		log.V(1).Info("Deleted server boot configuration")
 	}
 
+	// Systems left behind are purged by the registry sweeper, so a failure does not block the deletion.
+	if server.Spec.SystemUUID != "" {
+		if err := r.discardRegistryEntryForServer(ctx, log, server, true); err != nil {
+			log.Error(err, "Failed to purge registry entry of server")
+		} else {
+			log.V(1).Info("Purged server from registry")
+		}
+	}
+
 	log.V(1).Info("Ensuring that the finalizer is removed")
 	if modified, err := clientutils.PatchEnsureNoFinalizer(ctx, r.Client, server, ServerFinalizer); err != nil || modified {
 		return ctrl.Result{}, err
@@ -134,9 +370,16 @@ func (r *ServerReconciler) delete(ctx context.Context, log logr.Logger, server *
 
 func (r *ServerReconciler) reconcile(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) (ctrl.Result, error) {
 	log.V(1).Info("Reconciling Server")
-	if shouldIgnoreReconciliation(server) {
+	paused, remaining := shouldIgnoreReconciliation(server)
+	if err := r.patchPausedCondition(ctx, server, paused); err != nil {
+		return ctrl.Result{}, err
+	}
+	if paused {
 		log.V(1).Info("Skipped Server reconciliation")
-		return ctrl.Result{}, nil
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+	if isDryRun(server) && bmc.PlannedWritesFrom(ctx) == nil {
+		return r.dryRun(ctx, log, server)
 	}
 
 	// do late state initialization
@@ -145,7 +388,16 @@ func (r *ServerReconciler) reconcile(ctx context.Context, log logr.Logger, serve
 			return ctrl.Result{}, err
 		}
 	}
-	if modified, err := r.handleAnnotionOperations(ctx, log, server); err != nil || modified {
+	if r.ObserverMode {
+		return r.observe(ctx, log, server)
+	}
+	if !isDryRun(server) {
+		if err := clearDryRunCondition(ctx, r.Client, server, &server.Status.Conditions); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	modified, operationDelay, err := r.handleAnnotionOperations(ctx, log, server)
+	if err != nil || modified {
 		return ctrl.Result{}, err
 	}
 	log.V(1).Info("Handled annotation operations")
@@ -155,7 +407,15 @@ func (r *ServerReconciler) reconcile(ctx context.Context, log logr.Logger, serve
 	}
 	log.V(1).Info("Ensured finalizer has been added")
 
-	if server.Spec.ServerClaimRef != nil {
+	if modified, err := r.ensureArchitectureLabel(ctx, server); err != nil || modified {
+		return ctrl.Result{}, err
+	}
+
+	if modified, err := r.ensureVendorLabels(ctx, server); err != nil || modified {
+		return ctrl.Result{}, err
+	}
+
+	if server.Spec.ServerClaimRef != nil && server.Status.State != metalv1alpha1.ServerStateError {
 		if modified, err := r.patchServerState(ctx, server, metalv1alpha1.ServerStateReserved); err != nil || modified {
 			return ctrl.Result{}, err
 		}
@@ -164,36 +424,74 @@ func (r *ServerReconciler) reconcile(ctx context.Context, log logr.Logger, serve
 	// TODO: This needs be reworked later as the Server cleanup has to happen here. For now we just transition the server
 	// 		 back to available state.
 	if server.Spec.ServerClaimRef == nil && server.Status.State == metalv1alpha1.ServerStateReserved {
+		if modified, err := r.handleReleasedServerError(ctx, log, server); err != nil || modified {
+			return ctrl.Result{}, err
+		}
 		if modified, err := r.patchServerState(ctx, server, metalv1alpha1.ServerStateAvailable); err != nil || modified {
 			return ctrl.Result{}, err
 		}
 	}
 
-	if err := r.updateServerStatus(ctx, log, server); err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to update server status: %w", err)
+	warmUpDelay, err := r.pollServerStatus(ctx, log, server)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if warmUpDelay > 0 && (operationDelay == 0 || warmUpDelay < operationDelay) {
+		operationDelay = warmUpDelay
 	}
-	log.V(1).Info("Updated Server status", "Status", server.Status.State)
 
-	if err := r.applyBiosSettings(ctx, log, server); err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to update server bios settings: %w", err)
+	biosErr := r.applyBiosSettings(ctx, log, server)
+	if err := r.recordOperationSupport(ctx, server, bmc.OperationSetBiosAttributes, biosErr); err != nil {
+		return ctrl.Result{}, err
+	}
+	if biosErr != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update server bios settings: %w", biosErr)
 	}
 	log.V(1).Info("Updated Server BIOS settings")
 
-	if err := r.applyBootOrder(ctx, log, server); err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to update server bios boot order: %w", err)
+	bootOrderErr := r.applyBootOrder(ctx, log, server)
+	if err := r.recordOperationSupport(ctx, server, bmc.OperationSetBootOrder, bootOrderErr); err != nil {
+		return ctrl.Result{}, err
+	}
+	if bootOrderErr != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update server bios boot order: %w", bootOrderErr)
 	}
 	log.V(1).Info("Updated Server BIOS boot order")
 
+	bootModeErr := r.applyBootMode(ctx, log, server)
+	if err := r.recordOperationSupport(ctx, server, bmc.OperationSetBootMode, bootModeErr); err != nil {
+		return ctrl.Result{}, err
+	}
+	if bootModeErr != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to apply boot mode: %w", bootModeErr)
+	}
+
+	if err := r.applyDPUModes(ctx, log, server); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to apply DPU modes: %w", err)
+	}
+
+	locateDelay, err := r.reconcileDriveLocate(ctx, log, server)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile drive locate: %w", err)
+	}
+	if locateDelay > 0 && (operationDelay == 0 || locateDelay < operationDelay) {
+		operationDelay = locateDelay
+	}
+
 	requeue, err := r.ensureServerStateTransition(ctx, log, server)
 	if requeue && err == nil {
-		return ctrl.Result{Requeue: requeue, RequeueAfter: r.ResyncInterval}, nil
+		requeueAfter := resyncAfter(server, r.ResyncInterval)
+		if operationDelay > 0 && operationDelay < requeueAfter {
+			requeueAfter = operationDelay
+		}
+		return ctrl.Result{Requeue: requeue, RequeueAfter: requeueAfter}, nil
 	}
 	if err != nil && !apierrors.IsNotFound(err) {
 		return ctrl.Result{}, fmt.Errorf("failed to ensure server state transition: %w", err)
 	}
 
 	log.V(1).Info("Reconciled Server")
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: operationDelay}, nil
 }
 
 // Server state-machine:
@@ -234,12 +532,19 @@ func (r *ServerReconciler) ensureServerStateTransition(ctx context.Context, log
 		return r.handleAvailableState(ctx, log, server)
 	case metalv1alpha1.ServerStateReserved:
 		return r.handleReservedState(ctx, log, server)
+	case metalv1alpha1.ServerStateError:
+		return r.handleErrorState(ctx, log, server)
 	default:
 		return false, nil
 	}
 }
 
 func (r *ServerReconciler) handleInitialState(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) (bool, error) {
+	if resetting, err := r.isServerBMCResetInProgress(ctx, server); err != nil || resetting {
+		log.V(1).Info("Waiting for the BMC reset to complete")
+		return resetting, err
+	}
+
 	if requeue, err := r.ensureInitialConditions(ctx, log, server); err != nil || requeue {
 		return requeue, err
 	}
@@ -250,15 +555,29 @@ func (r *ServerReconciler) handleInitialState(ctx context.Context, log logr.Logg
 	}
 	log.V(1).Info("Ensured power state for Server")
 
+	// A registration left over from an earlier discovery must not be taken for the result of the next one.
+	if err := r.discardRegistryEntryForServer(ctx, log, server, false); err != nil {
+		return false, fmt.Errorf("failed to discard registry entry for server: %w", err)
+	}
+	log.V(1).Info("Discarded stale registry entry of Server")
+
 	if err := r.applyBootConfigurationAndIgnitionForDiscovery(ctx, log, server); err != nil {
 		return false, fmt.Errorf("failed to apply server boot configuration: %w", err)
 	}
 	log.V(1).Info("Applied Server boot configuration")
 
-	if err := r.pxeBootServer(ctx, log, server); err != nil {
-		return false, fmt.Errorf("failed to set PXE boot for server: %w", err)
+	var bootMode redfish.BootSourceOverrideMode
+	if r.isDiscoveryBootModeSwitched(server) {
+		bootMode = redfish.LegacyBootSourceOverrideMode
+	}
+	pxeErr := r.pxeBootServerWithMode(ctx, log, server, bootMode)
+	if err := r.recordOperationSupport(ctx, server, bmc.OperationSetPXEBootOnce, pxeErr); err != nil {
+		return false, err
+	}
+	if pxeErr != nil {
+		return false, fmt.Errorf("failed to set PXE boot for server: %w", pxeErr)
 	}
-	log.V(1).Info("Set PXE Boot for Server")
+	log.V(1).Info("Set PXE Boot for Server", "BootMode", bootMode)
 
 	if modified, err := r.patchServerState(ctx, server, metalv1alpha1.ServerStateDiscovery); err != nil || modified {
 		return false, err
@@ -266,6 +585,17 @@ func (r *ServerReconciler) handleInitialState(ctx context.Context, log logr.Logg
 	return false, nil
 }
 
+func (r *ServerReconciler) isServerBMCResetInProgress(ctx context.Context, server *metalv1alpha1.Server) (bool, error) {
+	if server.Spec.BMCRef == nil {
+		return false, nil
+	}
+	bmcObj := &metalv1alpha1.BMC{}
+	if err := r.Get(ctx, client.ObjectKey{Name: server.Spec.BMCRef.Name}, bmcObj); err != nil {
+		return false, fmt.Errorf("failed to get BMC: %w", err)
+	}
+	return isBMCResetInProgress(bmcObj), nil
+}
+
 func (r *ServerReconciler) handleDiscoveryState(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) (bool, error) {
 	if ready, err := r.serverBootConfigurationIsReady(ctx, server); err != nil || !ready {
 		log.V(1).Info("Server boot configuration is not ready. Retrying ...")
@@ -285,7 +615,7 @@ func (r *ServerReconciler) handleDiscoveryState(ctx context.Context, log logr.Lo
 	}
 	log.V(1).Info("Server state set to power on")
 
-	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.BMCOptions)
+	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.bmcOptions(server))
 	if err != nil {
 		return false, fmt.Errorf("failed to create BMC client: %w", err)
 	}
@@ -293,42 +623,16 @@ func (r *ServerReconciler) handleDiscoveryState(ctx context.Context, log logr.Lo
 	if err != nil {
 		return false, fmt.Errorf("failed to get storages for Server: %w", err)
 	}
-	server.Status.Storages = nil
-	for _, storage := range storages {
-		metalStorage := metalv1alpha1.Storage{
-			Name:  storage.Name,
-			State: metalv1alpha1.StorageState(storage.State),
-		}
-		for _, drive := range storage.Drives {
-			metalStorage.Drives = append(metalStorage.Drives, metalv1alpha1.StorageDrive{
-				Name:      drive.Name,
-				Model:     drive.Model,
-				Vendor:    drive.Vendor,
-				Capacity:  resource.NewQuantity(drive.SizeBytes, resource.BinarySI),
-				Type:      string(drive.Type),
-				State:     metalv1alpha1.StorageState(drive.State),
-				MediaType: drive.MediaType,
-			})
-		}
-		metalStorage.Volumes = make([]metalv1alpha1.StorageVolume, 0, len(storage.Volumes))
-		for _, volume := range storage.Volumes {
-			metalStorage.Volumes = append(metalStorage.Volumes, metalv1alpha1.StorageVolume{
-				Name:        volume.Name,
-				Capacity:    resource.NewQuantity(volume.SizeBytes, resource.BinarySI),
-				State:       metalv1alpha1.StorageState(volume.State),
-				RAIDType:    string(volume.RAIDType),
-				VolumeUsage: volume.VolumeUsage,
-			})
-		}
-		server.Status.Storages = append(server.Status.Storages, metalStorage)
-	}
+	server.Status.Storages = storageStatus(storages)
+	setDegradedCondition(server)
+	summarizeServerStatus(server)
 	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
 		return false, fmt.Errorf("failed to patch Server status: %w", err)
 	}
 
-	if r.checkLastStatusUpdateAfter(r.DiscoveryTimeout, server) {
-		log.V(1).Info("Server did not post info to registry in time, back to initial state")
-		if modified, err := r.patchServerState(ctx, server, metalv1alpha1.ServerStateInitial); err != nil || modified {
+	if r.checkLastStatusUpdateAfter(r.discoveryTimeout(server), server) {
+		log.V(1).Info("Server did not post info to registry in time")
+		if modified, err := r.handleDiscoveryTimeout(ctx, log, server); err != nil || modified {
 			return false, err
 		}
 	}
@@ -336,6 +640,9 @@ func (r *ServerReconciler) handleDiscoveryState(ctx context.Context, log logr.Lo
 	ready, err := r.extractServerDetailsFromRegistry(ctx, log, server)
 	if !ready && err == nil {
 		log.V(1).Info("Server agent did not post info to registry")
+		if err := r.verifyBootOverride(ctx, log, server); err != nil {
+			return false, fmt.Errorf("failed to verify boot override: %w", err)
+		}
 		return true, nil
 	}
 	if err != nil {
@@ -344,11 +651,15 @@ func (r *ServerReconciler) handleDiscoveryState(ctx context.Context, log logr.Lo
 	}
 	log.V(1).Info("Extracted Server details")
 
-	if err := r.invalidateRegistryEntryForServer(log, server); err != nil {
+	if err := r.invalidateRegistryEntryForServer(ctx, log, server); err != nil {
 		return false, fmt.Errorf("failed to invalidate registry entry for server: %w", err)
 	}
 	log.V(1).Info("Removed Server from Registry")
 
+	if err := r.completeDiscovery(ctx, server); err != nil {
+		return false, fmt.Errorf("failed to complete discovery: %w", err)
+	}
+
 	log.V(1).Info("Setting Server state set to available")
 	if modified, err := r.patchServerState(ctx, server, metalv1alpha1.ServerStateAvailable); err != nil || modified {
 		return false, err
@@ -357,6 +668,10 @@ func (r *ServerReconciler) handleDiscoveryState(ctx context.Context, log logr.Lo
 }
 
 func (r *ServerReconciler) handleAvailableState(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) (bool, error) {
+	if modified, err := r.ensureRediscovery(ctx, log, server); err != nil || modified {
+		return false, err
+	}
+
 	serverBase := server.DeepCopy()
 	if server.Status.PowerState != metalv1alpha1.ServerOffPowerState {
 		server.Spec.Power = metalv1alpha1.PowerOff
@@ -370,6 +685,10 @@ func (r *ServerReconciler) handleAvailableState(ctx context.Context, log logr.Lo
 		}
 		log.V(1).Info("Server state set to power off")
 	}
+	if err := r.resetBootVerification(ctx, server); err != nil {
+		return false, fmt.Errorf("failed to reset boot verification: %w", err)
+	}
+
 	log.V(1).Info("ensureInitialBootConfigurationIsDeleted")
 	if err := r.ensureInitialBootConfigurationIsDeleted(ctx, server); err != nil {
 		return false, fmt.Errorf("failed to ensure server initial boot configuration is deleted: %w", err)
@@ -383,6 +702,95 @@ func (r *ServerReconciler) handleAvailableState(ctx context.Context, log logr.Lo
 	return true, nil
 }
 
+// ensureRediscovery moves an unclaimed Server back into the Initial state once its rediscovery interval has passed
+// since its last discovery. The rediscovery is deferred while MaxConcurrentRediscoveries other Servers are being
+// rediscovered. Servers without a last discovery time, i.e. discovered before it has been recorded, are given a
+// random one within the interval, so that their rediscoveries are spread instead of all starting at once.
+func (r *ServerReconciler) ensureRediscovery(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) (bool, error) {
+	interval := r.rediscoveryInterval(server)
+	if interval <= 0 || server.Spec.ServerClaimRef != nil {
+		return false, nil
+	}
+	if server.Status.LastDiscoveryTime == nil {
+		serverBase := server.DeepCopy()
+		server.Status.LastDiscoveryTime = &metav1.Time{Time: time.Now().Add(-mathrand.N(interval))}
+		if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+			return false, fmt.Errorf("failed to seed last discovery time: %w", err)
+		}
+		log.V(1).Info("Seeded last discovery time of Server", "LastDiscoveryTime", server.Status.LastDiscoveryTime)
+		return false, nil
+	}
+	if time.Since(server.Status.LastDiscoveryTime.Time) < interval {
+		return false, nil
+	}
+
+	r.rediscoveryMu.Lock()
+	defer r.rediscoveryMu.Unlock()
+	if maxRediscoveries := r.Settings().MaxConcurrentRediscoveries; maxRediscoveries > 0 {
+		rediscovering, err := r.countRediscoveries(ctx)
+		if err != nil {
+			return false, err
+		}
+		if rediscovering >= maxRediscoveries {
+			log.V(1).Info("Deferring rediscovery of Server", "Rediscovering", rediscovering)
+			return false, nil
+		}
+	}
+
+	log.V(1).Info("Rediscovering Server", "LastDiscoveryTime", server.Status.LastDiscoveryTime)
+	if modified, err := r.patchServerState(ctx, server, metalv1alpha1.ServerStateInitial); err != nil || !modified ||
+		server.Status.State != metalv1alpha1.ServerStateInitial {
+		return modified, err
+	}
+	if bmc.PlannedWritesFrom(ctx) == nil {
+		if r.rediscoveryPending == nil {
+			r.rediscoveryPending = map[string]time.Time{}
+		}
+		r.rediscoveryPending[server.Name] = time.Now()
+	}
+
+	serverBase := server.DeepCopy()
+	meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:    ServerConditionRediscovering,
+		Status:  metav1.ConditionTrue,
+		Reason:  serverRediscoveryReasonIntervalPassed,
+		Message: fmt.Sprintf("Rediscovering Server last discovered at %s", server.Status.LastDiscoveryTime.Format(time.RFC3339)),
+	})
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return true, fmt.Errorf("failed to patch rediscovering condition: %w", err)
+	}
+	return true, nil
+}
+
+// countRediscoveries returns the number of Servers which are being rediscovered. Besides the Servers marked with
+// the Rediscovering condition, it counts the Servers moved into rediscovery which the cache does not reflect yet.
+// The caller has to hold the rediscoveryMu.
+func (r *ServerReconciler) countRediscoveries(ctx context.Context) (int, error) {
+	servers := &metalv1alpha1.ServerList{}
+	if err := r.List(ctx, servers); err != nil {
+		return 0, fmt.Errorf("failed to list servers: %w", err)
+	}
+	rediscovering := 0
+	marked := map[string]bool{}
+	for _, item := range servers.Items {
+		switch item.Status.State {
+		case metalv1alpha1.ServerStateInitial, metalv1alpha1.ServerStateDiscovery:
+			if meta.IsStatusConditionTrue(item.Status.Conditions, ServerConditionRediscovering) {
+				marked[item.Name] = true
+				rediscovering++
+			}
+		}
+	}
+	for name, since := range r.rediscoveryPending {
+		if marked[name] || time.Since(since) > rediscoveryPendingTimeout {
+			delete(r.rediscoveryPending, name)
+			continue
+		}
+		rediscovering++
+	}
+	return rediscovering, nil
+}
+
 func (r *ServerReconciler) handleReservedState(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) (bool, error) {
 	if ready, err := r.serverBootConfigurationIsReady(ctx, server); err != nil || !ready {
 		log.V(1).Info("Server boot configuration is not ready. Retrying ...")
@@ -392,10 +800,26 @@ func (r *ServerReconciler) handleReservedState(ctx context.Context, log logr.Log
 
 	//TODO: handle working Reserved Server that was suddenly powered off but needs to boot from disk
 	if server.Status.PowerState == metalv1alpha1.ServerOffPowerState {
-		if err := r.pxeBootServer(ctx, log, server); err != nil {
-			return false, fmt.Errorf("failed to boot server: %w", err)
+		sanBoot, err := r.applySANBootConfiguration(ctx, log, server)
+		if err != nil {
+			return false, fmt.Errorf("failed to apply SAN boot configuration: %w", err)
+		}
+		if !sanBoot {
+			pxeErr := r.pxeBootServer(ctx, log, server)
+			if err := r.recordOperationSupport(ctx, server, bmc.OperationSetPXEBootOnce, pxeErr); err != nil {
+				return false, err
+			}
+			if pxeErr != nil {
+				return false, fmt.Errorf("failed to boot server: %w", pxeErr)
+			}
+			log.V(1).Info("Server is powered off, booting Server in PXE")
+		}
+
+		if server.Spec.Power == metalv1alpha1.PowerOn && !isServerBootInProgress(server) {
+			if err := r.startBootVerification(ctx, server, 1); err != nil {
+				return false, fmt.Errorf("failed to start boot verification: %w", err)
+			}
 		}
-		log.V(1).Info("Server is powered off, booting Server in PXE")
 	}
 	if err := r.ensureServerPowerState(ctx, log, server); err != nil {
 		return false, fmt.Errorf("failed to ensure server power state: %w", err)
@@ -404,111 +828,524 @@ func (r *ServerReconciler) handleReservedState(ctx context.Context, log logr.Log
 	if err := r.ensureIndicatorLED(ctx, log, server); err != nil {
 		return false, fmt.Errorf("failed to ensure server indicator led: %w", err)
 	}
+
+	if err := r.verifyBootOverride(ctx, log, server); err != nil {
+		return false, fmt.Errorf("failed to verify boot override: %w", err)
+	}
+
+	if err := r.verifyServerBoot(ctx, log, server); err != nil {
+		return false, fmt.Errorf("failed to verify server boot: %w", err)
+	}
 	log.V(1).Info("Reconciled reserved state")
 	return true, nil
 }
 
-func (r *ServerReconciler) ensureServerBootConfigRef(ctx context.Context, server *metalv1alpha1.Server, config *metalv1alpha1.ServerBootConfiguration) error {
-	serverBase := server.DeepCopy()
-	server.Spec.BootConfigurationRef = &v1.ObjectReference{
-		Namespace:  config.Namespace,
-		Name:       config.Name,
-		UID:        config.UID,
-		APIVersion: "metal.ironcore.dev/v1alpha1",
-		Kind:       "ServerBootConfiguration",
-	}
-	return r.Patch(ctx, server, client.MergeFrom(serverBase))
+func isServerBootInProgress(server *metalv1alpha1.Server) bool {
+	condition := meta.FindStatusCondition(server.Status.Conditions, ServerConditionBootFailed)
+	return condition != nil && condition.Reason == serverBootReasonInProgress
 }
 
-func (r *ServerReconciler) updateServerStatus(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) error {
-	if server.Spec.BMCRef == nil && server.Spec.BMC == nil {
-		log.V(1).Info("Server has no BMC connection configured")
+// startBootVerification records a new boot attempt of the Server. The condition is recreated so that its
+// LastTransitionTime marks the start of the attempt.
+func (r *ServerReconciler) startBootVerification(ctx context.Context, server *metalv1alpha1.Server, attempt int32) error {
+	if r.Settings().BootVerificationTimeout == 0 {
 		return nil
 	}
-	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.BMCOptions)
-	if err != nil {
-		return fmt.Errorf("failed to create BMC client: %w", err)
+	serverBase := server.DeepCopy()
+	server.Status.BootAttempts = attempt
+	meta.RemoveStatusCondition(&server.Status.Conditions, ServerConditionBootFailed)
+	meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:               ServerConditionBootFailed,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: server.Generation,
+		Reason:             serverBootReasonInProgress,
+		Message:            fmt.Sprintf("Waiting for the server to boot (attempt %d of %d)", attempt, r.maxBootRetries(server)+1),
+	})
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return fmt.Errorf("failed to patch server status: %w", err)
 	}
-	defer bmcClient.Logout()
+	return nil
+}
 
-	systemInfo, err := bmcClient.GetSystemInfo(ctx, server.Spec.SystemUUID)
-	if err != nil {
-		return fmt.Errorf("failed to get system info for Server: %w", err)
+// verifyServerBoot checks whether a PXE booted Server became reachable within the BootVerificationTimeout.
+// Unreachable Servers are PXE booted again until MaxBootRetries is exhausted, after which the BootFailed
+// condition is set.
+func (r *ServerReconciler) verifyServerBoot(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) error {
+	timeout := r.Settings().BootVerificationTimeout
+	if timeout == 0 || !isServerBootInProgress(server) {
+		return nil
+	}
+	if server.Status.PowerState != metalv1alpha1.ServerOnPowerState {
+		return nil
 	}
 
 	serverBase := server.DeepCopy()
-	server.Status.PowerState = metalv1alpha1.ServerPowerState(systemInfo.PowerState)
-	server.Status.SerialNumber = systemInfo.SerialNumber
-	server.Status.SKU = systemInfo.SKU
-	server.Status.Manufacturer = systemInfo.Manufacturer
-	server.Status.Model = systemInfo.Model
-	server.Status.IndicatorLED = metalv1alpha1.IndicatorLED(systemInfo.IndicatorLED)
-	server.Status.TotalSystemMemory = &systemInfo.TotalSystemMemory
+	if r.isServerReachable(server) {
+		log.V(1).Info("Server is reachable, boot succeeded", "Attempts", server.Status.BootAttempts)
+		meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:               ServerConditionBootFailed,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: server.Generation,
+			Reason:             serverBootReasonSucceeded,
+			Message:            fmt.Sprintf("Server became reachable after %d boot attempt(s)", server.Status.BootAttempts),
+		})
+		if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+			return fmt.Errorf("failed to patch server status: %w", err)
+		}
+		return nil
+	}
 
-	currentBiosVersion, err := bmcClient.GetBiosVersion(ctx, server.Spec.SystemUUID)
-	if err != nil {
-		return fmt.Errorf("failed to load bios version: %w", err)
+	condition := meta.FindStatusCondition(server.Status.Conditions, ServerConditionBootFailed)
+	if time.Since(condition.LastTransitionTime.Time) < timeout {
+		log.V(1).Info("Server is not reachable yet", "Attempts", server.Status.BootAttempts)
+		return nil
 	}
 
-	for _, bios := range server.Spec.BIOS {
-		if bios.Version == currentBiosVersion {
-			// with go 1.23: switch to maps.Keys(bios.Settings)
-			keys := make([]string, 0, len(bios.Settings))
-			for k := range bios.Settings {
-				keys = append(keys, k)
-			}
-			attributes, err := bmcClient.GetBiosAttributeValues(ctx, server.Spec.SystemUUID, keys)
-			if err != nil {
-				return fmt.Errorf("failed load bios settings: %w", err)
-			}
-			server.Status.BIOS.Version = currentBiosVersion
-			server.Status.BIOS.Settings = attributes
+	if int(server.Status.BootAttempts) <= r.maxBootRetries(server) {
+		log.V(1).Info("Server did not become reachable in time, retrying PXE boot", "Attempts", server.Status.BootAttempts)
+		if err := r.pxeRebootServer(ctx, server, "boot verification"); err != nil {
+			return err
+		}
+		if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+			return fmt.Errorf("failed to patch server power conditions: %w", err)
 		}
+		return r.startBootVerification(ctx, server, server.Status.BootAttempts+1)
 	}
 
+	log.V(1).Info("Server did not become reachable after all boot retries", "Attempts", server.Status.BootAttempts)
+	meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:               ServerConditionBootFailed,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: server.Generation,
+		Reason:             serverBootReasonFailed,
+		Message:            fmt.Sprintf("Server did not become reachable after %d boot attempt(s)", server.Status.BootAttempts),
+	})
 	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
-		return fmt.Errorf("failed to patch Server status: %w", err)
+		return fmt.Errorf("failed to patch server status: %w", err)
 	}
 
+	if r.TaintOnBootFailure {
+		serverBase := server.DeepCopy()
+		metav1.SetMetaDataLabel(&server.ObjectMeta, ServerBootFailedLabel, "true")
+		if err := r.Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+			return fmt.Errorf("failed to taint server: %w", err)
+		}
+		log.V(1).Info("Tainted Server after failed boot")
+	}
 	return nil
 }
 
-func (r *ServerReconciler) applyBootConfigurationAndIgnitionForDiscovery(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) error {
-	bootConfig := &metalv1alpha1.ServerBootConfiguration{}
-	bootConfig.Name = server.Name
-	bootConfig.Namespace = r.ManagerNamespace
-	opResult, err := controllerutil.CreateOrPatch(ctx, r.Client, bootConfig, func() error {
-		if bootConfig.Annotations == nil {
-			bootConfig.Annotations = make(map[string]string)
-		}
-		bootConfig.Annotations[InternalAnnotationTypeKeyName] = InternalAnnotationTypeValue
-		bootConfig.Spec.ServerRef = v1.LocalObjectReference{Name: server.Name}
-		bootConfig.Spec.IgnitionSecretRef = &v1.LocalObjectReference{Name: server.Name}
-		bootConfig.Spec.Image = r.ProbeOSImage
+// verifyBootOverride checks whether the BMC honored the PXE boot override the operator recorded in the status of a
+// powered on Server. A PXE override which is still pending BootOverrideCheckDelay after the boot has been ignored by
+// the firmware, which booted the Server from disk instead. The PXE boot is retried until MaxBootRetries is exhausted,
+// after which the BootOverrideIgnored condition is set. Overrides of other targets have not been set by the operator
+// and are left alone.
+func (r *ServerReconciler) verifyBootOverride(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) error {
+	delay := r.Settings().BootOverrideCheckDelay
+	if delay == 0 || server.Status.PowerState != metalv1alpha1.ServerOnPowerState {
 		return nil
-	})
+	}
+	if server.Status.PXEBootOverrideTime == nil {
+		return nil
+	}
+	bootTime := serverBootTime(server)
+	if bootTime.IsZero() || bootTime.Before(server.Status.PXEBootOverrideTime.Time) || time.Since(bootTime) < delay {
+		return nil
+	}
+
+	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.bmcOptions(server))
 	if err != nil {
-		return fmt.Errorf("failed to create or patch ServerBootConfiguration: %w", err)
+		return fmt.Errorf("failed to create BMC client: %w", err)
+	}
+	defer bmcClient.Logout()
+	override, err := bmcClient.GetBootSourceOverride(ctx, server.Spec.SystemUUID)
+	if err != nil {
+		return fmt.Errorf("failed to get boot source override: %w", err)
 	}
-	log.V(1).Info("Created or patched", "ServerBootConfiguration", bootConfig.Name, "Operation", opResult)
 
-	if err := r.ensureServerBootConfigRef(ctx, server, bootConfig); err != nil {
-		return err
+	serverBase := server.DeepCopy()
+	// the override is verified once, a retry records the override it sets again
+	server.Status.PXEBootOverrideTime = nil
+	if override.Pending() && override.Target != redfish.PxeBootSourceOverrideTarget {
+		log.V(1).Info("Pending boot override has not been set by the operator, skipping its verification",
+			"Target", override.Target)
+		if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+			return fmt.Errorf("failed to patch server status: %w", err)
+		}
+		return nil
 	}
-	return r.applyDefaultIgnitionForServer(ctx, log, server, bootConfig, r.RegistryURL)
+	// the condition is recreated so that its LastTransitionTime marks the boot it has been verified for
+	meta.RemoveStatusCondition(&server.Status.Conditions, ServerConditionBootOverrideIgnored)
+	switch {
+	case !override.Pending():
+		meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:               ServerConditionBootOverrideIgnored,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: server.Generation,
+			Reason:             serverBootOverrideReasonHonored,
+			Message:            "The BMC consumed the boot override",
+		})
+	case int(server.Status.BootOverrideRetries) < r.maxBootRetries(server):
+		log.V(1).Info("BMC did not honor the boot override, retrying PXE boot",
+			"Target", override.Target, "Retries", server.Status.BootOverrideRetries)
+		server.Status.BootOverrideRetries++
+		meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:               ServerConditionBootOverrideIgnored,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: server.Generation,
+			Reason:             serverBootOverrideReasonRetrying,
+			Message: fmt.Sprintf("Boot override %s was still pending after the boot, retrying (retry %d of %d)",
+				override.Target, server.Status.BootOverrideRetries, r.maxBootRetries(server)),
+		})
+		if err := r.pxeRebootServer(ctx, server, "boot override verification"); err != nil {
+			return err
+		}
+	default:
+		log.V(1).Info("BMC did not honor the boot override after all retries", "Target", override.Target,
+			"Retries", server.Status.BootOverrideRetries)
+		meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:               ServerConditionBootOverrideIgnored,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: server.Generation,
+			Reason:             serverBootOverrideReasonIgnored,
+			Message: fmt.Sprintf("Boot override %s was still pending after %d boot(s), the server does not boot from the network",
+				override.Target, server.Status.BootOverrideRetries+1),
+		})
+	}
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return fmt.Errorf("failed to patch server status: %w", err)
+	}
+	return nil
 }
 
-func (r *ServerReconciler) applyDefaultIgnitionForServer(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server, bootConfig *metalv1alpha1.ServerBootConfiguration, registryURL string) error {
-	sshPrivateKey, sshPublicKey, password, err := generateSSHKeyPairAndPassword()
-	if err != nil {
-		return fmt.Errorf("failed to generate SSH keypair: %w", err)
+// serverBootTime returns the time the Server was last powered on or reset, or the zero time if it is unknown.
+func serverBootTime(server *metalv1alpha1.Server) time.Time {
+	var bootTime time.Time
+	if condition := meta.FindStatusCondition(server.Status.Conditions, ServerConditionPoweredOn); condition != nil &&
+		condition.Status == metav1.ConditionTrue {
+		bootTime = condition.LastTransitionTime.Time
+	}
+	if condition := meta.FindStatusCondition(server.Status.Conditions, ServerConditionPowerCycleRequested); condition != nil &&
+		condition.LastTransitionTime.After(bootTime) {
+		bootTime = condition.LastTransitionTime.Time
 	}
+	return bootTime
+}
 
-	sshSecret := &v1.Secret{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: "v1",
-			Kind:       "Secret",
-		},
+// ensureArchitectureLabel labels the Server with its CPU architecture once it is known.
+func (r *ServerReconciler) ensureArchitectureLabel(ctx context.Context, server *metalv1alpha1.Server) (bool, error) {
+	architecture := string(server.Status.Architecture)
+	if architecture == "" || server.Labels[ServerArchitectureLabel] == architecture {
+		return false, nil
+	}
+	serverBase := server.DeepCopy()
+	metav1.SetMetaDataLabel(&server.ObjectMeta, ServerArchitectureLabel, architecture)
+	if err := r.Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return false, fmt.Errorf("failed to patch architecture label: %w", err)
+	}
+	return true, nil
+}
+
+// ensureVendorLabels labels the Server with its canonical manufacturer and model once they are known.
+func (r *ServerReconciler) ensureVendorLabels(ctx context.Context, server *metalv1alpha1.Server) (bool, error) {
+	labels := map[string]string{
+		ServerManufacturerLabel: labelValue(server.Status.CanonicalManufacturer),
+		ServerModelLabel:        labelValue(server.Status.CanonicalModel),
+	}
+	serverBase := server.DeepCopy()
+	modified := false
+	for key, value := range labels {
+		if value == "" || server.Labels[key] == value {
+			continue
+		}
+		metav1.SetMetaDataLabel(&server.ObjectMeta, key, value)
+		modified = true
+	}
+	if !modified {
+		return false, nil
+	}
+	if err := r.Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return false, fmt.Errorf("failed to patch vendor labels: %w", err)
+	}
+	return true, nil
+}
+
+// labelValue turns the value into a valid label value by replacing invalid characters with dashes, e.g.
+// "ProLiant-DL380-Gen10" for "ProLiant DL380 Gen10".
+func labelValue(value string) string {
+	value = strings.Map(func(r rune) rune {
+		if 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '-'
+	}, value)
+	if len(value) > validation.LabelValueMaxLength {
+		value = value[:validation.LabelValueMaxLength]
+	}
+	return strings.Trim(value, "-_.")
+}
+
+func (r *ServerReconciler) isServerReachable(server *metalv1alpha1.Server) bool {
+	port := strconv.Itoa(r.BootVerificationPort)
+	for _, nic := range server.Status.NetworkInterfaces {
+		if !nic.IP.IsValid() {
+			continue
+		}
+		conn, err := net.DialTimeout("tcp", net.JoinHostPort(nic.IP.String(), port), serverBootDialTimeout)
+		if err != nil {
+			continue
+		}
+		_ = conn.Close()
+		return true
+	}
+	return false
+}
+
+func (r *ServerReconciler) pxeRebootServer(ctx context.Context, server *metalv1alpha1.Server, initiator string) error {
+	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.bmcOptions(server))
+	if err != nil {
+		return fmt.Errorf("failed to get BMC client: %w", err)
+	}
+	defer bmcClient.Logout()
+
+	// Servers booting from a SAN keep their iSCSI boot configuration and are only restarted.
+	config, err := r.getServerBootConfiguration(ctx, server)
+	if err != nil {
+		return fmt.Errorf("failed to get server boot configuration: %w", err)
+	}
+	if !isISCSIBootConfiguration(config) {
+		if interfaces := networkBootInterfaces(server); len(interfaces) > 0 {
+			if err := bmcClient.SetPXEBootOnceFromInterfaces(ctx, server.Spec.SystemUUID, "", interfaces); err != nil {
+				return fmt.Errorf("failed to set PXE boot once from network boot interfaces for server: %w", err)
+			}
+		} else if err := bmcClient.SetPXEBootOnce(ctx, server.Spec.SystemUUID); err != nil {
+			return fmt.Errorf("failed to set PXE boot once for server: %w", err)
+		}
+		recordPXEBootOverride(server)
+	}
+	resetType, err := selectResetType(server, restartResetTypes...)
+	if err != nil {
+		return err
+	}
+	if err := bmcClient.Reset(ctx, server.Spec.SystemUUID, resetType); err != nil {
+		return fmt.Errorf("failed to reset server: %w", err)
+	}
+	setPowerCycleRequested(server, resetType, initiator)
+	return nil
+}
+
+// restartResetTypes are the reset types which restart a Server regardless of its operating system, best first.
+var restartResetTypes = []redfish.ResetType{
+	redfish.ForceRestartResetType,
+	redfish.PowerCycleResetType,
+	redfish.GracefulRestartResetType,
+}
+
+// selectResetType returns the first of the reset types the system of the Server supports. All reset types are
+// considered supported if the BMC does not report them.
+func selectResetType(server *metalv1alpha1.Server, resetTypes ...redfish.ResetType) (redfish.ResetType, error) {
+	if len(server.Status.SupportedResetTypes) == 0 {
+		return resetTypes[0], nil
+	}
+	for _, resetType := range resetTypes {
+		if slices.Contains(server.Status.SupportedResetTypes, string(resetType)) {
+			return resetType, nil
+		}
+	}
+	return "", fmt.Errorf("system supports none of the reset types %v, only %v", resetTypes, server.Status.SupportedResetTypes)
+}
+
+// resetBootVerification drops the boot verification state of a Server which is no longer reserved.
+func (r *ServerReconciler) resetBootVerification(ctx context.Context, server *metalv1alpha1.Server) error {
+	if server.Status.BootAttempts == 0 && server.Status.BootOverrideRetries == 0 && server.Status.PXEBootOverrideTime == nil &&
+		meta.FindStatusCondition(server.Status.Conditions, ServerConditionBootFailed) == nil &&
+		meta.FindStatusCondition(server.Status.Conditions, ServerConditionBootOverrideIgnored) == nil {
+		return nil
+	}
+	serverBase := server.DeepCopy()
+	server.Status.BootAttempts = 0
+	server.Status.BootOverrideRetries = 0
+	server.Status.PXEBootOverrideTime = nil
+	meta.RemoveStatusCondition(&server.Status.Conditions, ServerConditionBootFailed)
+	meta.RemoveStatusCondition(&server.Status.Conditions, ServerConditionBootOverrideIgnored)
+	meta.RemoveStatusCondition(&server.Status.Conditions, ServerConditionRediscovering)
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return fmt.Errorf("failed to patch server status: %w", err)
+	}
+	return nil
+}
+
+func (r *ServerReconciler) ensureServerBootConfigRef(ctx context.Context, server *metalv1alpha1.Server, config *metalv1alpha1.ServerBootConfiguration) error {
+	serverBase := server.DeepCopy()
+	server.Spec.BootConfigurationRef = &v1.ObjectReference{
+		Namespace:  config.Namespace,
+		Name:       config.Name,
+		UID:        config.UID,
+		APIVersion: "metal.ironcore.dev/v1alpha1",
+		Kind:       "ServerBootConfiguration",
+	}
+	return r.Patch(ctx, server, client.MergeFrom(serverBase))
+}
+
+func (r *ServerReconciler) updateServerStatus(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) error {
+	if server.Spec.BMCRef == nil && server.Spec.BMC == nil {
+		log.V(1).Info("Server has no BMC connection configured")
+		return nil
+	}
+	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.bmcOptions(server))
+	if err != nil {
+		return fmt.Errorf("failed to create BMC client: %w", err)
+	}
+	defer bmcClient.Logout()
+
+	systemInfo, err := bmcClient.GetSystemInfo(ctx, server.Spec.SystemUUID)
+	if err != nil {
+		return fmt.Errorf("failed to get system info for Server: %w", err)
+	}
+
+	serverBase := server.DeepCopy()
+	server.Status.PowerState = metalv1alpha1.ServerPowerState(systemInfo.PowerState)
+	server.Status.SerialNumber = systemInfo.SerialNumber
+	server.Status.SKU = systemInfo.SKU
+	server.Status.Manufacturer = systemInfo.Manufacturer
+	server.Status.Model = systemInfo.Model
+	server.Status.CanonicalManufacturer = bmc.NormalizeManufacturer(systemInfo.Manufacturer)
+	server.Status.CanonicalModel = bmc.NormalizeModel(systemInfo.Manufacturer, systemInfo.Model)
+	server.Status.IndicatorLED = metalv1alpha1.IndicatorLED(systemInfo.IndicatorLED)
+	server.Status.TotalSystemMemory = &systemInfo.TotalSystemMemory
+	server.Status.SupportedResetTypes = nil
+	for _, resetType := range systemInfo.SupportedResetTypes {
+		server.Status.SupportedResetTypes = append(server.Status.SupportedResetTypes, string(resetType))
+	}
+	syncPowerConditions(server)
+	migrateRebootNeededCondition(server)
+
+	if discoveringServer(server) {
+		updateServerInventory(ctx, log, bmcClient, server)
+	}
+	summarizeServerStatus(server)
+
+	frus, err := bmcClient.GetChassisFRUs(ctx, server.Spec.SystemUUID)
+	if err != nil {
+		log.V(1).Info("Failed to get power supplies and fans of Server", "Error", err.Error())
+	} else {
+		setChassisFRUStatus(server, frus)
+		setPowerRedundancyCondition(server)
+	}
+
+	// many BMCs expose no network adapters, so the DPUs are kept as they are if they cannot be listed
+	if dpus, err := bmcClient.GetDPUs(ctx, server.Spec.SystemUUID); err != nil {
+		log.V(1).Info("Failed to get DPUs of Server", "Error", err.Error())
+	} else {
+		server.Status.DPUs = nil
+		for _, dpu := range dpus {
+			server.Status.DPUs = append(server.Status.DPUs, dpuStatus(dpu))
+		}
+	}
+
+	bootMode, pendingBootMode, err := bmcClient.GetBootMode(ctx, server.Spec.SystemUUID)
+	if err != nil {
+		log.V(1).Info("Failed to get boot mode of Server", "Error", err.Error())
+	} else {
+		server.Status.BootMode = metalv1alpha1.BootMode(bootMode)
+		server.Status.PendingBootMode = metalv1alpha1.BootMode(pendingBootMode)
+	}
+
+	currentBiosVersion, err := bmcClient.GetBiosVersion(ctx, server.Spec.SystemUUID)
+	if err != nil {
+		return fmt.Errorf("failed to load bios version: %w", err)
+	}
+
+	for _, bios := range server.Spec.BIOS {
+		if bios.Version == currentBiosVersion {
+			// with go 1.23: switch to maps.Keys(bios.Settings)
+			keys := make([]string, 0, len(bios.Settings)+len(bios.SettingsFrom))
+			for k := range bios.Settings {
+				keys = append(keys, k)
+			}
+			for _, source := range bios.SettingsFrom {
+				keys = append(keys, source.Name)
+			}
+			attributes, err := bmcClient.GetBiosAttributeValues(ctx, server.Spec.SystemUUID, keys)
+			if err != nil {
+				return fmt.Errorf("failed load bios settings: %w", err)
+			}
+			for _, source := range bios.SettingsFrom {
+				if _, ok := attributes[source.Name]; ok && source.SecretKeyRef != nil {
+					attributes[source.Name] = biosSettingMaskedValue
+				}
+			}
+			server.Status.BIOS.Version = currentBiosVersion
+			server.Status.BIOS.Settings = r.Redactor.Map(attributes)
+		}
+	}
+
+	pendingChanges, err := r.pendingBIOSChanges(ctx, bmcClient, server)
+	if err != nil {
+		return err
+	}
+	server.Status.PendingChanges = pendingChanges
+
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return fmt.Errorf("failed to patch Server status: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ServerReconciler) applyBootConfigurationAndIgnitionForDiscovery(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) error {
+	image, err := r.discoveryImageForServer(ctx, server)
+	if err != nil {
+		return err
+	}
+	bootConfig := &metalv1alpha1.ServerBootConfiguration{}
+	bootConfig.Name = server.Name
+	bootConfig.Namespace = r.ManagerNamespace
+	opResult, err := controllerutil.CreateOrPatch(ctx, r.Client, bootConfig, func() error {
+		if bootConfig.Annotations == nil {
+			bootConfig.Annotations = make(map[string]string)
+		}
+		bootConfig.Annotations[InternalAnnotationTypeKeyName] = InternalAnnotationTypeValue
+		bootConfig.Spec.ServerRef = v1.LocalObjectReference{Name: server.Name}
+		bootConfig.Spec.IgnitionSecretRef = &v1.LocalObjectReference{Name: server.Name}
+		bootConfig.Spec.Image = image
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create or patch ServerBootConfiguration: %w", err)
+	}
+	log.V(1).Info("Created or patched", "ServerBootConfiguration", bootConfig.Name, "Operation", opResult, "Image", image)
+
+	if err := r.ensureServerBootConfigRef(ctx, server, bootConfig); err != nil {
+		return err
+	}
+	return r.applyDefaultIgnitionForServer(ctx, log, server, bootConfig, r.RegistryURL)
+}
+
+func (r *ServerReconciler) applyDefaultIgnitionForServer(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server, bootConfig *metalv1alpha1.ServerBootConfiguration, registryURL string) error {
+	probeFlags := fmt.Sprintf("--registry-url=%s --server-uuid=%s", registryURL, server.Spec.SystemUUID)
+	if len(r.RegistryTokenKey) > 0 {
+		probeFlags += fmt.Sprintf(" --registry-token=%s", registryserver.SystemToken(r.RegistryTokenKey, server.Spec.SystemUUID))
+	}
+	if r.ProbeBMCAccount != "" {
+		probeFlags += fmt.Sprintf(" --bmc-account=%s", r.ProbeBMCAccount)
+	}
+	return r.applyIgnitionForBootConfig(ctx, log, bootConfig, r.ProbeImage, probeFlags)
+}
+
+// applyIgnitionForBootConfig applies the SSH keypair and the ignition Secret of the boot configuration, which runs
+// the container image with the flags.
+func (r *ServerReconciler) applyIgnitionForBootConfig(ctx context.Context, log logr.Logger, bootConfig *metalv1alpha1.ServerBootConfiguration, image, flags string) error {
+	sshPrivateKey, sshPublicKey, password, err := generateSSHKeyPairAndPassword()
+	if err != nil {
+		return fmt.Errorf("failed to generate SSH keypair: %w", err)
+	}
+
+	sshSecret := &v1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: r.ManagerNamespace,
 			Name:      fmt.Sprintf("%s-ssh", bootConfig.Name),
@@ -527,8 +1364,7 @@ func (r *ServerReconciler) applyDefaultIgnitionForServer(ctx context.Context, lo
 	}
 	log.V(1).Info("Applied SSH keypair secret", "SSHKeyPair", client.ObjectKeyFromObject(sshSecret))
 
-	probeFlags := fmt.Sprintf("--registry-url=%s --server-uuid=%s", registryURL, server.Spec.SystemUUID)
-	ignitionData, err := r.generateDefaultIgnitionDataForServer(probeFlags, sshPublicKey, password)
+	ignitionData, err := r.generateDefaultIgnitionDataForServer(image, flags, sshPublicKey, password)
 	if err != nil {
 		return fmt.Errorf("failed to generate default ignitionSecret data: %w", err)
 	}
@@ -586,14 +1422,14 @@ func generateSSHKeyPairAndPassword() ([]byte, []byte, []byte, error) {
 	return privateKeyPem, publicKeyAuthorized, password, nil
 }
 
-func (r *ServerReconciler) generateDefaultIgnitionDataForServer(flags string, sshPublicKey []byte, password []byte) ([]byte, error) {
+func (r *ServerReconciler) generateDefaultIgnitionDataForServer(image, flags string, sshPublicKey []byte, password []byte) ([]byte, error) {
 	passwordHash, err := bcrypt.GenerateFromPassword(password, bcrypt.DefaultCost)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate password hash: %w", err)
 	}
 
 	ignitionData, err := ignition.GenerateDefaultIgnitionData(ignition.Config{
-		Image:        r.ProbeImage,
+		Image:        image,
 		Flags:        flags,
 		SSHPublicKey: string(sshPublicKey),
 		PasswordHash: string(passwordHash),
@@ -647,17 +1483,74 @@ func (r *ServerReconciler) setAndPatchServerPowerState(ctx context.Context, log
 }
 
 func (r *ServerReconciler) serverBootConfigurationIsReady(ctx context.Context, server *metalv1alpha1.Server) (bool, error) {
+	config, err := r.getServerBootConfiguration(ctx, server)
+	if err != nil || config == nil {
+		return false, err
+	}
+	return config.Status.State == metalv1alpha1.ServerBootConfigurationStateReady, nil
+}
+
+// getServerBootConfiguration returns the boot configuration of the Server, or nil if it has none.
+func (r *ServerReconciler) getServerBootConfiguration(ctx context.Context, server *metalv1alpha1.Server) (*metalv1alpha1.ServerBootConfiguration, error) {
 	if server.Spec.BootConfigurationRef == nil {
-		return false, nil
+		return nil, nil
 	}
 	config := &metalv1alpha1.ServerBootConfiguration{}
 	if err := r.Get(ctx, client.ObjectKey{Namespace: server.Spec.BootConfigurationRef.Namespace, Name: server.Spec.BootConfigurationRef.Name}, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func isISCSIBootConfiguration(config *metalv1alpha1.ServerBootConfiguration) bool {
+	return config != nil && config.Spec.SANBoot != nil && config.Spec.SANBoot.ISCSI != nil
+}
+
+// applySANBootConfiguration configures the BMC of the Server to boot from the iSCSI target of its boot
+// configuration. It reports whether the Server boots from a SAN instead of PXE.
+func (r *ServerReconciler) applySANBootConfiguration(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) (bool, error) {
+	config, err := r.getServerBootConfiguration(ctx, server)
+	if err != nil || !isISCSIBootConfiguration(config) {
 		return false, err
 	}
-	return config.Status.State == metalv1alpha1.ServerBootConfigurationStateReady, nil
+	iscsi := config.Spec.SANBoot.ISCSI
+	params := bmc.ISCSIBootParameters{
+		NetworkDeviceFunctionID: iscsi.NetworkDeviceFunction,
+		InitiatorName:           iscsi.InitiatorName,
+		TargetName:              iscsi.TargetName,
+		TargetAddress:           iscsi.TargetAddress,
+		TargetPort:              int(iscsi.TargetPort),
+		LUN:                     int(iscsi.LUN),
+	}
+	if iscsi.CHAPSecretRef != nil {
+		secret := &v1.Secret{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: config.Namespace, Name: iscsi.CHAPSecretRef.Name}, secret); err != nil {
+			return false, fmt.Errorf("failed to get CHAP secret: %w", err)
+		}
+		params.CHAPUsername = string(secret.Data["username"])
+		params.CHAPSecret = string(secret.Data["password"])
+	}
+
+	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.bmcOptions(server))
+	if err != nil {
+		return false, fmt.Errorf("failed to get BMC client: %w", err)
+	}
+	defer bmcClient.Logout()
+
+	if err := bmcClient.SetISCSIBoot(ctx, server.Spec.SystemUUID, params); err != nil {
+		return false, fmt.Errorf("failed to set iSCSI boot for server: %w", err)
+	}
+	log.V(1).Info("Configured iSCSI boot", "Target", iscsi.TargetName, "TargetAddress", iscsi.TargetAddress)
+	return true, nil
 }
 
 func (r *ServerReconciler) pxeBootServer(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) error {
+	return r.pxeBootServerWithMode(ctx, log, server, "")
+}
+
+// pxeBootServerWithMode sets a one time PXE boot for the Server. An empty mode boots in the boot mode of the Server,
+// or in the default UEFI boot mode if it is unknown.
+func (r *ServerReconciler) pxeBootServerWithMode(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server, mode redfish.BootSourceOverrideMode) error {
 	if server == nil || server.Spec.BootConfigurationRef == nil {
 		log.V(1).Info("Server not ready for netboot")
 		return nil
@@ -667,7 +1560,7 @@ func (r *ServerReconciler) pxeBootServer(ctx context.Context, log logr.Logger, s
 		return fmt.Errorf("can only PXE boot server with valid BMC ref or inline BMC configuration")
 	}
 
-	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.BMCOptions)
+	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.bmcOptions(server))
 	defer func() {
 		if bmcClient != nil {
 			bmcClient.Logout()
@@ -677,8 +1570,189 @@ func (r *ServerReconciler) pxeBootServer(ctx context.Context, log logr.Logger, s
 	if err != nil {
 		return fmt.Errorf("failed to get BMC client: %w", err)
 	}
-	if err := bmcClient.SetPXEBootOnce(ctx, server.Spec.SystemUUID); err != nil {
-		return fmt.Errorf("failed to set PXE boot one for server: %w", err)
+	interfaces := networkBootInterfaces(server)
+	if len(interfaces) == 0 && mode == "" {
+		mode = bmc.BootSourceOverrideMode(bmc.BootMode(serverBootMode(server)))
+	}
+	switch {
+	case len(interfaces) > 0:
+		if err := bmcClient.SetPXEBootOnceFromInterfaces(ctx, server.Spec.SystemUUID, mode, interfaces); err != nil {
+			return fmt.Errorf("failed to set PXE boot once from network boot interfaces for server: %w", err)
+		}
+	case mode != "":
+		if err := bmcClient.SetPXEBootOnceWithMode(ctx, server.Spec.SystemUUID, mode); err != nil {
+			return fmt.Errorf("failed to set PXE boot once with mode %s for server: %w", mode, err)
+		}
+	default:
+		if err := bmcClient.SetPXEBootOnce(ctx, server.Spec.SystemUUID); err != nil {
+			return fmt.Errorf("failed to set PXE boot one for server: %w", err)
+		}
+	}
+
+	serverBase := server.DeepCopy()
+	recordPXEBootOverride(server)
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return fmt.Errorf("failed to record PXE boot override: %w", err)
+	}
+	return nil
+}
+
+// recordPXEBootOverride records that the operator set a PXE boot override on the BMC of the Server, which is
+// verified after the next boot, see verifyBootOverride.
+func recordPXEBootOverride(server *metalv1alpha1.Server) {
+	now := metav1.Now()
+	server.Status.PXEBootOverrideTime = &now
+}
+
+// networkBootInterfaces returns the network boot interfaces of the Server. Interfaces selected by name are resolved
+// to their MAC address if the Server reports a network interface of that name.
+func networkBootInterfaces(server *metalv1alpha1.Server) []bmc.NetworkBootInterface {
+	interfaces := make([]bmc.NetworkBootInterface, 0, len(server.Spec.NetworkBootInterfaces))
+	for _, nic := range server.Spec.NetworkBootInterfaces {
+		bootInterface := bmc.NetworkBootInterface{MACAddress: nic.MACAddress, Name: nic.Name}
+		if bootInterface.MACAddress == "" {
+			for _, ni := range server.Status.NetworkInterfaces {
+				if ni.Name == nic.Name {
+					bootInterface.MACAddress = ni.MACAddress
+					break
+				}
+			}
+		}
+		interfaces = append(interfaces, bootInterface)
+	}
+	return interfaces
+}
+
+// handleDiscoveryTimeout records a timed out discovery boot. The Server is sent back to the initial state
+// until MaxDiscoveryAttempts is reached, after which each further timeout performs the next DiscoveryEscalation
+// action. Once all actions have been performed, the Server is moved to the error state with diagnostics
+// attached to the DiscoveryFailed condition.
+func (r *ServerReconciler) handleDiscoveryTimeout(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) (bool, error) {
+	serverBase := server.DeepCopy()
+	server.Status.DiscoveryAttempts++
+	attempts := int(server.Status.DiscoveryAttempts)
+	maxAttempts := r.maxDiscoveryAttempts(server)
+
+	if maxAttempts == 0 || attempts < maxAttempts {
+		log.V(1).Info("Retrying discovery", "Attempts", attempts)
+		server.Status.State = metalv1alpha1.ServerStateInitial
+		if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+			return false, fmt.Errorf("failed to patch server status: %w", err)
+		}
+		return true, nil
+	}
+
+	if step := attempts - maxAttempts; step < len(r.DiscoveryEscalation) {
+		action := r.DiscoveryEscalation[step]
+		log.V(1).Info("Escalating discovery", "Attempts", attempts, "Action", action)
+		if err := r.performDiscoveryEscalation(ctx, server, action); err != nil {
+			return false, fmt.Errorf("failed to perform discovery escalation %s: %w", action, err)
+		}
+		meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:               ServerConditionDiscoveryFailed,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: server.Generation,
+			Reason:             serverDiscoveryReasonEscalated,
+			Message:            fmt.Sprintf("Performed %s after %d timed out discovery attempt(s)", action, attempts),
+		})
+		server.Status.State = metalv1alpha1.ServerStateInitial
+		if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+			return false, fmt.Errorf("failed to patch server status: %w", err)
+		}
+		return true, nil
+	}
+
+	log.V(1).Info("Discovery failed, giving up", "Attempts", attempts)
+	meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:               ServerConditionDiscoveryFailed,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: server.Generation,
+		Reason:             serverDiscoveryReasonExhausted,
+		Message:            r.discoveryDiagnostics(ctx, server, attempts),
+	})
+	markServerError(server, serverDiscoveryReasonExhausted, serverErrorOperationDiscovery,
+		fmt.Sprintf("Discovery timed out %d time(s)", attempts))
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return false, fmt.Errorf("failed to patch server status: %w", err)
+	}
+	return true, nil
+}
+
+func (r *ServerReconciler) performDiscoveryEscalation(ctx context.Context, server *metalv1alpha1.Server, action DiscoveryEscalationAction) error {
+	switch action {
+	case DiscoveryEscalationResetBMC:
+		if server.Spec.BMCRef != nil {
+			return requestBMCReset(ctx, r.Client, server.Spec.BMCRef.Name)
+		}
+		bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.bmcOptions(server))
+		if err != nil {
+			return fmt.Errorf("failed to get BMC client: %w", err)
+		}
+		defer bmcClient.Logout()
+		return bmcClient.ResetManager(ctx, redfish.GracefulRestartResetType)
+	case DiscoveryEscalationSwitchBootMode:
+		// the boot mode is derived from the discovery attempts in handleInitialState
+		return nil
+	default:
+		return fmt.Errorf("unknown discovery escalation action %q", action)
+	}
+}
+
+func (r *ServerReconciler) isDiscoveryBootModeSwitched(server *metalv1alpha1.Server) bool {
+	maxAttempts := r.maxDiscoveryAttempts(server)
+	// aarch64 servers boot via UEFI only and have no legacy boot mode to switch to.
+	if maxAttempts == 0 || server.Status.Architecture == metalv1alpha1.ArchitectureAArch64 {
+		return false
+	}
+	for step, action := range r.DiscoveryEscalation {
+		if action == DiscoveryEscalationSwitchBootMode {
+			return int(server.Status.DiscoveryAttempts) >= maxAttempts+step
+		}
+	}
+	return false
+}
+
+// discoveryDiagnostics summarizes the last known power state and the latest event log entries of the Server.
+func (r *ServerReconciler) discoveryDiagnostics(ctx context.Context, server *metalv1alpha1.Server, attempts int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Discovery timed out %d time(s). Last power state: %s.", attempts, server.Status.PowerState)
+
+	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.bmcOptions(server))
+	if err != nil {
+		fmt.Fprintf(&b, " Event log unavailable: %v.", err)
+		return b.String()
+	}
+	defer bmcClient.Logout()
+
+	entries, err := bmcClient.GetEventLogEntries(ctx, server.Spec.SystemUUID, serverDiscoveryEventLogEntries)
+	if err != nil {
+		fmt.Fprintf(&b, " Event log unavailable: %v.", err)
+		return b.String()
+	}
+	if len(entries) == 0 {
+		b.WriteString(" Event log is empty.")
+		return b.String()
+	}
+	b.WriteString(" Last event log entries:")
+	for _, entry := range entries {
+		fmt.Fprintf(&b, " [%s %s] %s;", entry.Created, entry.Severity, entry.Message)
+	}
+	return b.String()
+}
+
+// completeDiscovery records the time of the completed discovery and resets the discovery attempts of the Server.
+func (r *ServerReconciler) completeDiscovery(ctx context.Context, server *metalv1alpha1.Server) error {
+	serverBase := server.DeepCopy()
+	now := metav1.Now()
+	server.Status.LastDiscoveryTime = &now
+	server.Status.DiscoveryAttempts = 0
+	server.Status.BootOverrideRetries = 0
+	server.Status.PXEBootOverrideTime = nil
+	meta.RemoveStatusCondition(&server.Status.Conditions, ServerConditionDiscoveryFailed)
+	meta.RemoveStatusCondition(&server.Status.Conditions, ServerConditionBootOverrideIgnored)
+	meta.RemoveStatusCondition(&server.Status.Conditions, ServerConditionRediscovering)
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return fmt.Errorf("failed to patch server status: %w", err)
 	}
 	return nil
 }
@@ -694,13 +1768,48 @@ func (r *ServerReconciler) extractServerDetailsFromRegistry(ctx context.Context,
 		return false, fmt.Errorf("failed to find server information in registry")
 	}
 
-	if err != nil {
-		return false, fmt.Errorf("failed to fetch server details: %w", err)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch server details: %w", err)
+	}
+
+	serverDetails := &registry.Server{}
+	if err := json.NewDecoder(resp.Body).Decode(serverDetails); err != nil {
+		return false, fmt.Errorf("failed to decode server details: %w", err)
+	}
+
+	if err := r.applyServerDetails(ctx, server, serverDetails); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// replayDiscoveryFromRegistry re-applies the last successful discovery of the Server kept by the registry.
+func (r *ServerReconciler) replayDiscoveryFromRegistry(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) error {
+	resp, err := http.Get(fmt.Sprintf("%s/history/%s", r.RegistryURL, server.Spec.SystemUUID))
+	if err != nil {
+		return fmt.Errorf("failed to fetch discovery history: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("no discovery history found for server in registry")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch discovery history: unexpected status code %d", resp.StatusCode)
+	}
+
+	record := &registry.DiscoveryRecord{}
+	if err := json.NewDecoder(resp.Body).Decode(record); err != nil {
+		return fmt.Errorf("failed to decode discovery history: %w", err)
 	}
+	log.V(1).Info("Replaying discovery from registry", "Timestamp", record.Timestamp)
+	return r.applyServerDetails(ctx, server, &record.Data)
+}
 
-	serverDetails := &registry.Server{}
-	if err := json.NewDecoder(resp.Body).Decode(serverDetails); err != nil {
-		return false, fmt.Errorf("failed to decode server details: %w", err)
+func (r *ServerReconciler) applyServerDetails(ctx context.Context, server *metalv1alpha1.Server, serverDetails *registry.Server) error {
+	if err := r.applyProbeExtensions(ctx, server, serverDetails.Extensions); err != nil {
+		return err
 	}
 
 	serverBase := server.DeepCopy()
@@ -714,26 +1823,141 @@ func (r *ServerReconciler) extractServerDetailsFromRegistry(ctx context.Context,
 		})
 	}
 	server.Status.NetworkInterfaces = nics
+	switch architecture := metalv1alpha1.Architecture(serverDetails.Architecture); architecture {
+	case metalv1alpha1.ArchitectureX8664, metalv1alpha1.ArchitectureAArch64:
+		server.Status.Architecture = architecture
+	}
+	if serverDetails.SMBIOS != nil {
+		server.Status.InBandSystemUUID = strings.ToLower(serverDetails.SMBIOS.System.UUID)
+		setSystemUUIDMismatchCondition(server)
+	}
 
 	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
-		return false, fmt.Errorf("failed to patch server status: %w", err)
+		return fmt.Errorf("failed to patch server status: %w", err)
 	}
+	return nil
+}
 
-	return true, nil
+// applyProbeExtensions surfaces the output of the probe agent collectors as annotations of the Server. Invalid and
+// oversized outputs are skipped and annotations of collectors which are gone are removed.
+func (r *ServerReconciler) applyProbeExtensions(ctx context.Context, server *metalv1alpha1.Server, extensions map[string]json.RawMessage) error {
+	annotations := map[string]string{}
+	for key, value := range server.GetAnnotations() {
+		if !strings.HasPrefix(key, metalv1alpha1.ProbeExtensionAnnotationPrefix) {
+			annotations[key] = value
+		}
+	}
+	maps.Copy(annotations, probeExtensionAnnotations(ctrl.LoggerFrom(ctx), extensions))
+	if maps.Equal(annotations, server.GetAnnotations()) {
+		return nil
+	}
+
+	serverBase := server.DeepCopy()
+	server.SetAnnotations(annotations)
+	if err := r.Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return fmt.Errorf("failed to patch server probe extensions: %w", err)
+	}
+	return nil
+}
+
+// probeExtensionAnnotations returns the annotations of the probe agent collector outputs. The outputs are reported by
+// the unauthenticated registry, so collectors whose name is no DNS label or whose output is no valid JSON are skipped,
+// as are outputs exceeding maxProbeExtensionSize or, in the order of their names, maxProbeExtensionsSize in total.
+func probeExtensionAnnotations(log logr.Logger, extensions map[string]json.RawMessage) map[string]string {
+	annotations := map[string]string{}
+	size := 0
+	for _, name := range slices.Sorted(maps.Keys(extensions)) {
+		data := extensions[name]
+		key := metalv1alpha1.ProbeExtensionAnnotationPrefix + name
+		switch {
+		case len(validation.IsDNS1123Label(name)) > 0 || len(validation.IsQualifiedName(key)) > 0:
+			log.Info("Skipped probe extension with invalid name", "Name", name)
+		case !json.Valid(data):
+			log.Info("Skipped probe extension with invalid JSON", "Name", name)
+		case len(data) > maxProbeExtensionSize:
+			log.Info("Skipped oversized probe extension", "Name", name, "Size", len(data))
+		case size+len(key)+len(data) > maxProbeExtensionsSize:
+			log.Info("Skipped probe extension exceeding the total size of the probe extensions", "Name", name, "Size", len(data))
+		default:
+			size += len(key) + len(data)
+			annotations[key] = string(data)
+		}
+	}
+	return annotations
 }
 
+// patchServerState transitions the Server into the given state. While pre-transition hooks for the state are
+// present, the transition is blocked and reported through the TransitionBlocked condition; modified is true
+// in that case as well, so that callers stop until the hooks are removed.
 func (r *ServerReconciler) patchServerState(ctx context.Context, server *metalv1alpha1.Server, state metalv1alpha1.ServerState) (bool, error) {
 	if server.Status.State == state {
 		return false, nil
 	}
 	serverBase := server.DeepCopy()
+	if hooks := preTransitionHooks(server, state); len(hooks) > 0 {
+		ctrl.LoggerFrom(ctx).V(1).Info("Transition blocked by hooks", "State", state, "Hooks", hooks)
+		if meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:    ServerConditionTransitionBlocked,
+			Status:  metav1.ConditionTrue,
+			Reason:  serverTransitionReasonHooksPending,
+			Message: fmt.Sprintf("Transition to %s is blocked by hooks: %s", state, strings.Join(hooks, ", ")),
+		}) {
+			if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+				return false, fmt.Errorf("failed to patch server transition condition: %w", err)
+			}
+		}
+		return true, nil
+	}
 	server.Status.State = state
+	meta.RemoveStatusCondition(&server.Status.Conditions, ServerConditionTransitionBlocked)
 	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
 		return false, fmt.Errorf("failed to patch server state: %w", err)
 	}
 	return true, nil
 }
 
+// patchPausedCondition reflects the pause of the reconciliation of the Server in its Paused condition. The
+// condition is removed once the pause has ended.
+func (r *ServerReconciler) patchPausedCondition(ctx context.Context, server *metalv1alpha1.Server, paused bool) error {
+	serverBase := server.DeepCopy()
+	var changed bool
+	if paused {
+		condition := metav1.Condition{
+			Type:    ServerConditionPaused,
+			Status:  metav1.ConditionTrue,
+			Reason:  serverPausedReasonIgnoreOperation,
+			Message: "Reconciliation is paused by the ignore operation",
+		}
+		if until, ok := pausedUntil(server); ok && server.GetAnnotations()[metalv1alpha1.OperationAnnotation] != metalv1alpha1.OperationAnnotationIgnore {
+			condition.Reason = serverPausedReasonPausedUntil
+			condition.Message = fmt.Sprintf("Reconciliation is paused until %s", until.Format(time.RFC3339))
+		}
+		changed = meta.SetStatusCondition(&server.Status.Conditions, condition)
+	} else {
+		changed = meta.RemoveStatusCondition(&server.Status.Conditions, ServerConditionPaused)
+	}
+	if !changed {
+		return nil
+	}
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return fmt.Errorf("failed to patch Server paused condition: %w", err)
+	}
+	return nil
+}
+
+// preTransitionHooks returns the sorted owners of the hooks blocking the transition of the Server into the state.
+func preTransitionHooks(server *metalv1alpha1.Server, state metalv1alpha1.ServerState) []string {
+	prefix := metalv1alpha1.PreTransitionHookAnnotationPrefix(state)
+	var hooks []string
+	for key := range server.GetAnnotations() {
+		if owner, ok := strings.CutPrefix(key, prefix); ok {
+			hooks = append(hooks, owner)
+		}
+	}
+	slices.Sort(hooks)
+	return hooks
+}
+
 func (r *ServerReconciler) ensureServerPowerState(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) error {
 	if server.Spec.Power == "" {
 		// no desired power state set
@@ -758,7 +1982,7 @@ func (r *ServerReconciler) ensureServerPowerState(ctx context.Context, log logr.
 		return nil
 	}
 
-	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.BMCOptions)
+	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.bmcOptions(server))
 	defer func() {
 		if bmcClient != nil {
 			bmcClient.Logout()
@@ -802,9 +2026,101 @@ func (r *ServerReconciler) ensureServerPowerState(ctx context.Context, log logr.
 	}
 	log.V(1).Info("Ensured server power state", "PowerState", server.Spec.Power)
 
+	serverBase := server.DeepCopy()
+	if powerOp == powerOpOn {
+		setPowerConditions(server, true, serverPowerReasonOnCompleted, fmt.Sprintf("Powered on by %s", powerInitiator(server)))
+	} else {
+		setPowerConditions(server, false, serverPowerReasonOffCompleted, fmt.Sprintf("Powered off by %s", powerInitiator(server)))
+	}
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return fmt.Errorf("failed to patch server power conditions: %w", err)
+	}
 	return nil
 }
 
+// powerInitiator describes the resource on whose behalf the power state of the Server is changed.
+func powerInitiator(server *metalv1alpha1.Server) string {
+	if ref := server.Spec.ServerClaimRef; ref != nil {
+		return fmt.Sprintf("ServerClaim %s/%s", ref.Namespace, ref.Name)
+	}
+	return fmt.Sprintf("Server %s in state %s", server.Name, server.Status.State)
+}
+
+// setPowerConditions sets the PoweredOn and PoweredOff conditions of the Server. The LastTransitionTime of the
+// conditions records when the power state changed.
+func setPowerConditions(server *metalv1alpha1.Server, poweredOn bool, reason, message string) {
+	on, off := metav1.ConditionFalse, metav1.ConditionTrue
+	if poweredOn {
+		on, off = metav1.ConditionTrue, metav1.ConditionFalse
+	}
+	meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:               ServerConditionPoweredOn,
+		Status:             on,
+		ObservedGeneration: server.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+	meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:               ServerConditionPoweredOff,
+		Status:             off,
+		ObservedGeneration: server.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// migrateRebootNeededCondition replaces the condition recorded under the legacy type by the RebootNeeded condition.
+// The legacy condition was only set once applied BIOS settings required a reboot.
+func migrateRebootNeededCondition(server *metalv1alpha1.Server) {
+	if meta.FindStatusCondition(server.Status.Conditions, legacyServerConditionRebootNeeded) == nil {
+		return
+	}
+	meta.RemoveStatusCondition(&server.Status.Conditions, legacyServerConditionRebootNeeded)
+	setRebootNeededCondition(server)
+}
+
+func setRebootNeededCondition(server *metalv1alpha1.Server) {
+	meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:    ServerConditionRebootNeeded,
+		Status:  metav1.ConditionTrue,
+		Reason:  "BIOSSettingsApplied",
+		Message: "The applied BIOS settings take effect after a reboot",
+	})
+}
+
+// syncPowerConditions updates the power conditions of the Server if the observed power state differs from
+// them, e.g. because the Server has been powered on or off outside of the operator.
+func syncPowerConditions(server *metalv1alpha1.Server) {
+	var poweredOn bool
+	switch server.Status.PowerState {
+	case metalv1alpha1.ServerOnPowerState:
+		poweredOn = true
+	case metalv1alpha1.ServerOffPowerState:
+		poweredOn = false
+	default:
+		return
+	}
+	if meta.IsStatusConditionPresentAndEqual(server.Status.Conditions, ServerConditionPoweredOn, metav1.ConditionTrue) == poweredOn &&
+		meta.FindStatusCondition(server.Status.Conditions, ServerConditionPoweredOff) != nil {
+		return
+	}
+	setPowerConditions(server, poweredOn, serverPowerReasonObserved,
+		fmt.Sprintf("Observed power state %s on the BMC", server.Status.PowerState))
+}
+
+// setPowerCycleRequested records a reset of the Server. The condition is recreated so that its
+// LastTransitionTime marks the latest request.
+func setPowerCycleRequested(server *metalv1alpha1.Server, resetType redfish.ResetType, initiator string) {
+	meta.RemoveStatusCondition(&server.Status.Conditions, ServerConditionPowerCycleRequested)
+	meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:               ServerConditionPowerCycleRequested,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: server.Generation,
+		Reason:             serverPowerReasonReset,
+		Message:            fmt.Sprintf("Reset %s requested by %s", resetType, initiator),
+	})
+}
+
 func (r *ServerReconciler) ensureIndicatorLED(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) error {
 	// TODO: implement
 	return nil
@@ -838,8 +2154,12 @@ func (r *ServerReconciler) ensureInitialBootConfigurationIsDeleted(ctx context.C
 	return nil
 }
 
-func (r *ServerReconciler) invalidateRegistryEntryForServer(log logr.Logger, server *metalv1alpha1.Server) error {
+func (r *ServerReconciler) invalidateRegistryEntryForServer(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) error {
 	url := fmt.Sprintf("%s/delete/%s", r.RegistryURL, server.Spec.SystemUUID)
+	if planned := bmc.PlannedWritesFrom(ctx); planned != nil {
+		planned.Record("delete " + url)
+		return nil
+	}
 
 	c := &http.Client{}
 
@@ -863,12 +2183,44 @@ func (r *ServerReconciler) invalidateRegistryEntryForServer(log logr.Logger, ser
 	return nil
 }
 
+// discardRegistryEntryForServer removes the registered discovery of the Server from the registry without keeping it
+// as its last discovery. With purge, the history and the SMBIOS table of the Server are removed as well. The request
+// is authenticated with the token of the manager.
+func (r *ServerReconciler) discardRegistryEntryForServer(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server, purge bool) error {
+	url := fmt.Sprintf("%s/systems/%s", r.RegistryURL, server.Spec.SystemUUID)
+	if purge {
+		url += "?purge=true"
+	}
+	if planned := bmc.PlannedWritesFrom(ctx); planned != nil {
+		planned.Record("delete " + url)
+		return nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+registryserver.ManagerToken(r.RegistryTokenKey))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func(Body io.ReadCloser) {
+		if err := Body.Close(); err != nil {
+			log.Error(err, "Failed to close response body")
+		}
+	}(resp.Body)
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
 func (r *ServerReconciler) applyBootOrder(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) error {
 	if server.Spec.BMCRef == nil && server.Spec.BMC == nil {
 		log.V(1).Info("Server has no BMC connection configured")
 		return nil
 	}
-	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.BMCOptions)
+	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.bmcOptions(server))
 	if err != nil {
 		return fmt.Errorf("failed to create BMC client: %w", err)
 	}
@@ -902,12 +2254,18 @@ func (r *ServerReconciler) applyBiosSettings(ctx context.Context, log logr.Logge
 		log.V(1).Info("Server has no BMC connection configured")
 		return nil
 	}
-	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.BMCOptions)
+	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.bmcOptions(server))
 	if err != nil {
 		return fmt.Errorf("failed to create BMC client: %w", err)
 	}
 	defer bmcClient.Logout()
 
+	biosPassword, err := r.reconcileBIOSPassword(ctx, log, bmcClient, server)
+	if err != nil {
+		return err
+	}
+	serverBase = server.DeepCopy()
+
 	version, err := bmcClient.GetBiosVersion(ctx, server.Spec.SystemUUID)
 	if err != nil {
 		return fmt.Errorf("failed to create BMC client: %w", err)
@@ -918,25 +2276,100 @@ func (r *ServerReconciler) applyBiosSettings(ctx context.Context, log logr.Logge
 	for _, bios := range server.Spec.BIOS {
 		if bios.Version == version {
 			versionMatch = true
+			var maskedKeys []string
 			for key, value := range bios.Settings {
-				if res, ok := server.Status.BIOS.Settings[key]; !ok {
-					if !ok || res != value {
-						diff[key] = value
+				res, ok := server.Status.BIOS.Settings[key]
+				if ok && res == biosSettingMaskedValue && r.Redactor.Sensitive(key) {
+					maskedKeys = append(maskedKeys, key)
+					continue
+				}
+				if !ok || res != value {
+					diff[key] = value
+				}
+			}
+			// sensitive settings are masked in the status, so they are compared with the values of the BMC
+			if len(maskedKeys) > 0 {
+				current, err := bmcClient.GetBiosAttributeValues(ctx, server.Spec.SystemUUID, maskedKeys)
+				if err != nil {
+					return fmt.Errorf("failed to get sensitive BIOS settings: %w", r.Redactor.Error(err))
+				}
+				for _, key := range maskedKeys {
+					if current[key] != bios.Settings[key] {
+						diff[key] = bios.Settings[key]
 					}
 				}
 			}
-			reset, err := bmcClient.SetBiosAttributes(ctx, server.Spec.SystemUUID, diff)
+			sources, err := r.resolveBIOSSettingSources(ctx, bios.SettingsFrom)
 			if err != nil {
 				return err
 			}
-			if reset {
-				if changed := meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
-					Type: "Reboot needed",
-				}); changed {
-					if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
-						return fmt.Errorf("failed to patch Server status: %w", err)
+			secretVersions := map[string]string{}
+			for name, source := range sources {
+				if source.secretVersion == "" {
+					if res, ok := server.Status.BIOS.Settings[name]; !ok || res != source.value {
+						diff[name] = source.value
 					}
+					continue
+				}
+				// Secret values are masked in the status, so they are applied whenever the Secret changed.
+				key := biosSecretVersionKey(version, name)
+				if server.Status.BIOSSecretVersions[key] != source.secretVersion {
+					diff[name] = source.value
+					secretVersions[key] = source.secretVersion
+				}
+			}
+			if len(diff) == 0 {
+				break
+			}
+			// The settings pending on the BMC are derived from the BMC rather than from the status, as the manager
+			// may have restarted after applying them, but before recording them.
+			pending, err := bmcClient.GetBiosPendingAttributeValues(ctx, server.Spec.SystemUUID)
+			if err != nil {
+				return fmt.Errorf("failed to get pending BIOS settings: %w", err)
+			}
+			toApply := map[string]string{}
+			for name, value := range diff {
+				if pendingValue, ok := pending[name]; !ok || pendingValue != value {
+					toApply[name] = value
+				}
+			}
+			reset := len(toApply) < len(diff)
+			if len(toApply) > 0 {
+				attributes := toApply
+				// locked-down systems only accept settings changes together with the BIOS password
+				if spec := server.Spec.BIOSPassword; spec != nil && spec.SettingsAttribute != "" && biosPassword != "" {
+					attributes = maps.Clone(toApply)
+					attributes[spec.SettingsAttribute] = biosPassword
+				}
+				resetRequired, applied, err := r.setBiosAttributes(ctx, bmcClient, server, bios.ApplyTime, attributes)
+				if err != nil {
+					return r.Redactor.Error(err)
 				}
+				if !applied {
+					log.V(1).Info("BIOS settings are deferred to the maintenance window", "Version", version)
+					break
+				}
+				reset = reset || resetRequired
+				log.V(1).Info("Applied BIOS settings", "Version", version, "Settings", slices.Sorted(maps.Keys(toApply)),
+					"ApplyTime", bios.ApplyTime)
+			} else {
+				log.V(1).Info("BIOS settings are pending until the next reboot", "Version", version)
+			}
+			if !biosSettingsChangeRecorded(server, version, diff, sources, r.Redactor) {
+				recordBIOSSettingsChange(server, version, diff, sources, r.Redactor)
+			}
+			if len(secretVersions) > 0 && server.Status.BIOSSecretVersions == nil {
+				server.Status.BIOSSecretVersions = map[string]string{}
+			}
+			maps.Copy(server.Status.BIOSSecretVersions, secretVersions)
+			if reset {
+				setRebootNeededCondition(server)
+			}
+			if equality.Semantic.DeepEqual(serverBase.Status, server.Status) {
+				break
+			}
+			if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+				return fmt.Errorf("failed to patch Server status: %w", err)
 			}
 			break
 		}
@@ -948,24 +2381,313 @@ func (r *ServerReconciler) applyBiosSettings(ctx context.Context, log logr.Logge
 	return nil
 }
 
-func (r *ServerReconciler) handleAnnotionOperations(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) (bool, error) {
+// setBiosAttributes sets the BIOS attributes at the apply time. The maintenance window of the server is passed to
+// the BMC for the maintenance window apply times, so that the hardware enforces it. BMCs which do not offer the
+// apply time get the attributes at their default apply time, but only within the maintenance window if the apply
+// time is bound to it. It reports whether the attributes have been set.
+func (r *ServerReconciler) setBiosAttributes(ctx context.Context, bmcClient bmc.BMC, server *metalv1alpha1.Server, applyTime metalv1alpha1.SettingsApplyTime, attributes map[string]string) (reset, applied bool, err error) {
+	if applyTime == "" {
+		reset, err = bmcClient.SetBiosAttributes(ctx, server.Spec.SystemUUID, attributes)
+		return reset, err == nil, err
+	}
+	window := server.Spec.MaintenanceWindow
+	if applyTime.IsMaintenanceWindow() && window == nil {
+		return false, false, fmt.Errorf("apply time %s requires a maintenance window", applyTime)
+	}
+	settingsApplyTime := bmc.SettingsApplyTime{ApplyTime: string(applyTime)}
+	if applyTime.IsMaintenanceWindow() {
+		settingsApplyTime.MaintenanceWindowStart = window.Start.Time
+		settingsApplyTime.MaintenanceWindowDuration = window.Duration.Duration
+	}
+	reset, err = bmcClient.SetBiosAttributesApplyAt(ctx, server.Spec.SystemUUID, attributes, settingsApplyTime)
+	if !errors.Is(err, bmc.ErrApplyTimeNotSupported) {
+		return reset, err == nil, err
+	}
+	if applyTime.IsMaintenanceWindow() && !window.Contains(time.Now()) {
+		return false, false, nil
+	}
+	reset, err = bmcClient.SetBiosAttributes(ctx, server.Spec.SystemUUID, attributes)
+	return reset, err == nil, err
+}
+
+// biosSettingSource is the resolved value of a BIOS setting read from a Secret or a ConfigMap.
+type biosSettingSource struct {
+	value string
+	// secretVersion is the resource version of the Secret holding the value. It is empty for ConfigMaps.
+	secretVersion string
+}
+
+func (s biosSettingSource) masked() bool {
+	return s.secretVersion != ""
+}
+
+// checkManagerNamespace ensures that a Secret or ConfigMap referenced by a Server is in the namespace of the
+// manager. Servers are cluster-scoped, so references into other namespaces would let anyone editing a Server read
+// the objects of any namespace with the permissions of the manager.
+func (r *ServerReconciler) checkManagerNamespace(kind string, ref *metalv1alpha1.ObjectKeySelector) error {
+	if ref.Namespace != r.ManagerNamespace {
+		return fmt.Errorf("%s %s/%s is not in the manager namespace %s", kind, ref.Namespace, ref.Name, r.ManagerNamespace)
+	}
+	return nil
+}
+
+// resolveBIOSSettingSources reads the values of the given BIOS setting sources, keyed by setting name. Only
+// Secrets and ConfigMaps in the manager namespace are read.
+func (r *ServerReconciler) resolveBIOSSettingSources(ctx context.Context, sources []metalv1alpha1.BIOSSettingSource) (map[string]biosSettingSource, error) {
+	resolved := make(map[string]biosSettingSource, len(sources))
+	for _, source := range sources {
+		switch {
+		case source.SecretKeyRef != nil:
+			ref := source.SecretKeyRef
+			if err := r.checkManagerNamespace("Secret", ref); err != nil {
+				return nil, fmt.Errorf("invalid source of BIOS setting %s: %w", source.Name, err)
+			}
+			secret := &v1.Secret{}
+			if err := r.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+				return nil, fmt.Errorf("failed to get Secret for BIOS setting %s: %w", source.Name, err)
+			}
+			value, ok := secret.Data[ref.Key]
+			if !ok {
+				return nil, fmt.Errorf("secret %s/%s has no key %s for BIOS setting %s", ref.Namespace, ref.Name, ref.Key, source.Name)
+			}
+			resolved[source.Name] = biosSettingSource{value: string(value), secretVersion: secret.ResourceVersion}
+		case source.ConfigMapKeyRef != nil:
+			ref := source.ConfigMapKeyRef
+			if err := r.checkManagerNamespace("ConfigMap", ref); err != nil {
+				return nil, fmt.Errorf("invalid source of BIOS setting %s: %w", source.Name, err)
+			}
+			configMap := &v1.ConfigMap{}
+			if err := r.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, configMap); err != nil {
+				return nil, fmt.Errorf("failed to get ConfigMap for BIOS setting %s: %w", source.Name, err)
+			}
+			value, ok := configMap.Data[ref.Key]
+			if !ok {
+				return nil, fmt.Errorf("configmap %s/%s has no key %s for BIOS setting %s", ref.Namespace, ref.Name, ref.Key, source.Name)
+			}
+			resolved[source.Name] = biosSettingSource{value: value}
+		}
+	}
+	return resolved, nil
+}
+
+// pendingBIOSChanges returns the BIOS settings pending on the BMC which change the value of the setting on the next
+// reboot of the Server, sorted by name. The values of sensitive settings, of settings read from Secrets and of the
+// BIOS password are masked.
+func (r *ServerReconciler) pendingBIOSChanges(ctx context.Context, bmcClient bmc.BMC, server *metalv1alpha1.Server) ([]metalv1alpha1.PendingChange, error) {
+	pending, err := bmcClient.GetBiosPendingAttributeValues(ctx, server.Spec.SystemUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending BIOS settings: %w", err)
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+	current, err := bmcClient.GetBiosAttributeValues(ctx, server.Spec.SystemUUID, slices.Collect(maps.Keys(pending)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get BIOS settings: %w", r.Redactor.Error(err))
+	}
+
+	masked := map[string]bool{}
+	for _, bios := range server.Spec.BIOS {
+		for _, source := range bios.SettingsFrom {
+			if source.SecretKeyRef != nil {
+				masked[source.Name] = true
+			}
+		}
+	}
+	if password := server.Spec.BIOSPassword; password != nil && password.SettingsAttribute != "" {
+		masked[password.SettingsAttribute] = true
+	}
+
+	var changes []metalv1alpha1.PendingChange
+	for _, name := range slices.Sorted(maps.Keys(pending)) {
+		currentValue, pendingValue := current[name], pending[name]
+		if currentValue == pendingValue {
+			continue
+		}
+		if masked[name] || r.Redactor.Sensitive(name) {
+			currentValue, pendingValue = biosSettingMaskedValue, biosSettingMaskedValue
+		}
+		changes = append(changes, metalv1alpha1.PendingChange{
+			Name:         name,
+			CurrentValue: currentValue,
+			PendingValue: pendingValue,
+		})
+	}
+	return changes, nil
+}
+
+func biosSecretVersionKey(version, name string) string {
+	return version + "/" + name
+}
+
+// biosSettingsChangeRecorded reports whether the latest entry of the BIOS settings history already records the
+// given settings, e.g. because they are pending until the next reboot.
+func biosSettingsChangeRecorded(server *metalv1alpha1.Server, version string, applied map[string]string, sources map[string]biosSettingSource, redactor *redact.Redactor) bool {
+	history := server.Status.BIOSSettingsHistory
+	if len(history) == 0 || history[len(history)-1].Version != version {
+		return false
+	}
+	recorded := map[string]string{}
+	for _, setting := range history[len(history)-1].Settings {
+		recorded[setting.Name] = setting.NewValue
+	}
+	for name, value := range applied {
+		if sources[name].masked() || redactor.Sensitive(name) {
+			value = biosSettingMaskedValue
+		}
+		if newValue, ok := recorded[name]; !ok || newValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// recordBIOSSettingsChange appends the applied BIOS settings to the history of the Server, keeping at most
+// biosSettingsHistoryLimit entries. Values read from Secrets and values of sensitive settings are masked.
+func recordBIOSSettingsChange(server *metalv1alpha1.Server, version string, applied map[string]string, sources map[string]biosSettingSource, redactor *redact.Redactor) {
+	change := metalv1alpha1.BIOSSettingsChange{
+		Time:     metav1.Now(),
+		Version:  version,
+		Settings: make([]metalv1alpha1.BIOSSettingChange, 0, len(applied)),
+	}
+	for name, value := range applied {
+		if sources[name].masked() || redactor.Sensitive(name) {
+			value = biosSettingMaskedValue
+		}
+		change.Settings = append(change.Settings, metalv1alpha1.BIOSSettingChange{
+			Name:     name,
+			OldValue: redactor.Value(name, server.Status.BIOS.Settings[name]),
+			NewValue: value,
+		})
+	}
+	sort.Slice(change.Settings, func(i, j int) bool {
+		return change.Settings[i].Name < change.Settings[j].Name
+	})
+	history := append(server.Status.BIOSSettingsHistory, change)
+	if len(history) > biosSettingsHistoryLimit {
+		history = history[len(history)-biosSettingsHistoryLimit:]
+	}
+	server.Status.BIOSSettingsHistory = history
+}
+
+func (r *ServerReconciler) handleAnnotionOperations(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) (bool, time.Duration, error) {
 	annotations := server.GetAnnotations()
 	operation, ok := annotations[metalv1alpha1.OperationAnnotation]
-	if !ok {
-		return false, nil
+	if !ok || operation == metalv1alpha1.OperationAnnotationIgnore {
+		return false, 0, nil
 	}
-	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.BMCOptions)
-	if err != nil {
-		return false, fmt.Errorf("failed to create BMC client: %w", err)
+	if _, err := trackOperation(ctx, r.Client, server, metalv1alpha1.OperationTargetKindServer); err != nil {
+		return false, 0, err
 	}
-	defer bmcClient.Logout()
+	// a crash dump which is being collected is completed regardless of the time window of the operation
+	if operation == metalv1alpha1.OperationAnnotationCrashDump && crashDumpInProgress(server) {
+		return r.progressCrashDump(ctx, log, server)
+	}
+	if operation == string(redfish.GracefulRestartResetType) && gracefulRestartInProgress(server) {
+		return r.progressGracefulRestart(ctx, log, server)
+	}
+
+	now := time.Now()
+	if value, ok := annotations[metalv1alpha1.OperationNotAfterAnnotation]; ok {
+		notAfter, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return false, 0, fmt.Errorf("failed to parse annotation %s: %w", metalv1alpha1.OperationNotAfterAnnotation, err)
+		}
+		if now.After(notAfter) {
+			log.V(1).Info("Discarding expired operation", "Operation", operation, "NotAfter", notAfter)
+			if err := recordOperationResult(ctx, r.Client, server, metalv1alpha1.OperationStateExpired,
+				fmt.Sprintf("Operation could not be performed before %s", value)); err != nil {
+				return false, 0, err
+			}
+			modified, err := r.removeOperationAnnotations(ctx, server)
+			return modified, 0, err
+		}
+	}
+	if value, ok := annotations[metalv1alpha1.OperationNotBeforeAnnotation]; ok {
+		notBefore, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return false, 0, fmt.Errorf("failed to parse annotation %s: %w", metalv1alpha1.OperationNotBeforeAnnotation, err)
+		}
+		if now.Before(notBefore) {
+			log.V(1).Info("Deferring operation", "Operation", operation, "NotBefore", notBefore)
+			return false, notBefore.Sub(now), nil
+		}
+	}
+
 	log.V(1).Info("Handling operation", "Operation", operation)
-	if err := bmcClient.Reset(ctx, server.Spec.SystemUUID, redfish.ResetType(operation)); err != nil {
-		return false, fmt.Errorf("failed to reset server: %w", err)
+	if operation == metalv1alpha1.OperationAnnotationCrashDump {
+		return r.startCrashDump(ctx, log, server)
+	}
+	if operation == string(redfish.GracefulRestartResetType) {
+		return r.startGracefulRestart(ctx, log, server)
+	}
+	if err := r.performOperation(ctx, log, server, operation); err != nil {
+		if recordErr := recordOperationResult(ctx, r.Client, server, metalv1alpha1.OperationStateInProgress,
+			fmt.Sprintf("Attempt failed: %v", err)); recordErr != nil {
+			log.Error(recordErr, "Failed to record failed attempt of operation", "Operation", operation)
+		}
+		return false, 0, err
 	}
 	log.V(1).Info("Operation completed", "Operation", operation)
+	if err := recordOperationResult(ctx, r.Client, server, metalv1alpha1.OperationStateSucceeded, ""); err != nil {
+		return false, 0, err
+	}
+	modified, err := r.removeOperationAnnotations(ctx, server)
+	return modified, 0, err
+}
+
+// performOperation performs the operation requested through the operation annotation of the Server.
+func (r *ServerReconciler) performOperation(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server, operation string) error {
+	switch operation {
+	case metalv1alpha1.OperationAnnotationRediscover, metalv1alpha1.OperationAnnotationClearError:
+		if err := r.leaveErrorState(ctx, log, server, operation); err != nil {
+			return fmt.Errorf("failed to leave error state: %w", err)
+		}
+	case metalv1alpha1.OperationAnnotationReplayDiscovery:
+		if err := r.replayDiscoveryFromRegistry(ctx, log, server); err != nil {
+			return fmt.Errorf("failed to replay discovery: %w", err)
+		}
+	case metalv1alpha1.OperationAnnotationRekeySystemUUID:
+		if err := r.rekeySystemUUID(ctx, log, server); err != nil {
+			return fmt.Errorf("failed to rekey system UUID: %w", err)
+		}
+	case metalv1alpha1.OperationAnnotationPXERestart:
+		statusBase := server.DeepCopy()
+		if err := r.pxeRebootServer(ctx, server, fmt.Sprintf("annotation %s", metalv1alpha1.OperationAnnotation)); err != nil {
+			return err
+		}
+		if err := r.Status().Patch(ctx, server, client.MergeFrom(statusBase)); err != nil {
+			return fmt.Errorf("failed to patch server power conditions: %w", err)
+		}
+	default:
+		bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.bmcOptions(server))
+		if err != nil {
+			return fmt.Errorf("failed to create BMC client: %w", err)
+		}
+		defer bmcClient.Logout()
+		resetType := redfish.ResetType(operation)
+		if _, err := selectResetType(server, resetType); err != nil {
+			return err
+		}
+		if err := bmcClient.Reset(ctx, server.Spec.SystemUUID, resetType); err != nil {
+			return fmt.Errorf("failed to reset server: %w", err)
+		}
+		statusBase := server.DeepCopy()
+		setPowerCycleRequested(server, resetType, fmt.Sprintf("annotation %s", metalv1alpha1.OperationAnnotation))
+		if err := r.Status().Patch(ctx, server, client.MergeFrom(statusBase)); err != nil {
+			return fmt.Errorf("failed to patch server power conditions: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *ServerReconciler) removeOperationAnnotations(ctx context.Context, server *metalv1alpha1.Server) (bool, error) {
 	serverBase := server.DeepCopy()
+	annotations := server.GetAnnotations()
 	delete(annotations, metalv1alpha1.OperationAnnotation)
+	delete(annotations, metalv1alpha1.OperationNotBeforeAnnotation)
+	delete(annotations, metalv1alpha1.OperationNotAfterAnnotation)
+	delete(annotations, metalv1alpha1.OperationNameAnnotation)
 	server.SetAnnotations(annotations)
 	if err := r.Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
 		return false, fmt.Errorf("failed to patch server annotations: %w", err)
@@ -973,6 +2695,52 @@ func (r *ServerReconciler) handleAnnotionOperations(ctx context.Context, log log
 	return true, nil
 }
 
+// discoveryTimeout returns the discovery timeout of the Server, overriding the DiscoveryTimeout with its
+// DiscoveryPolicy.
+func (r *ServerReconciler) discoveryTimeout(server *metalv1alpha1.Server) time.Duration {
+	if policy := server.Spec.DiscoveryPolicy; policy != nil && policy.Timeout != nil {
+		return policy.Timeout.Duration
+	}
+	return r.Settings().DiscoveryTimeout
+}
+
+// maxDiscoveryAttempts returns the maximum discovery attempts of the Server, overriding the MaxDiscoveryAttempts
+// with its DiscoveryPolicy.
+func (r *ServerReconciler) maxDiscoveryAttempts(server *metalv1alpha1.Server) int {
+	if policy := server.Spec.DiscoveryPolicy; policy != nil && policy.MaxAttempts != nil {
+		return int(*policy.MaxAttempts)
+	}
+	return r.Settings().MaxDiscoveryAttempts
+}
+
+// rediscoveryInterval returns the rediscovery interval of the Server, overriding the RediscoveryInterval with its
+// DiscoveryPolicy.
+func (r *ServerReconciler) rediscoveryInterval(server *metalv1alpha1.Server) time.Duration {
+	if policy := server.Spec.DiscoveryPolicy; policy != nil && policy.RediscoveryInterval != nil {
+		return policy.RediscoveryInterval.Duration
+	}
+	return r.Settings().RediscoveryInterval
+}
+
+// maxBootRetries returns the maximum PXE boot retries of the Server, overriding the MaxBootRetries with its
+// DiscoveryPolicy.
+func (r *ServerReconciler) maxBootRetries(server *metalv1alpha1.Server) int {
+	if policy := server.Spec.DiscoveryPolicy; policy != nil && policy.MaxBootRetries != nil {
+		return int(*policy.MaxBootRetries)
+	}
+	return r.Settings().MaxBootRetries
+}
+
+// bmcOptions returns the BMCOptions for the Server, overriding the power polling timeout with the power-on
+// timeout of its DiscoveryPolicy.
+func (r *ServerReconciler) bmcOptions(server *metalv1alpha1.Server) bmc.BMCOptions {
+	options := r.BMCOptions
+	if policy := server.Spec.DiscoveryPolicy; policy != nil && policy.PowerOnTimeout != nil {
+		options.PowerPollingTimeout = policy.PowerOnTimeout.Duration
+	}
+	return options
+}
+
 func (r *ServerReconciler) checkLastStatusUpdateAfter(duration time.Duration, server *metalv1alpha1.Server) bool {
 	length := len(server.ManagedFields) - 1
 	if server.ManagedFields[length].Operation == "Update" {
@@ -987,6 +2755,8 @@ func (r *ServerReconciler) checkLastStatusUpdateAfter(duration time.Duration, se
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Client = newDryRunClient(r.Client)
+
 	// Create a channel to send periodic events
 	ch := make(chan event.TypedGenericEvent[*metalv1alpha1.Server])
 
@@ -1014,10 +2784,30 @@ func (r *ServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&metalv1alpha1.Server{}).
+		WithOptions(controller.Options{
+			NewQueue: func(name string, rateLimiter workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+				return newPriorityQueue(name, rateLimiter)
+			},
+		}).
+		// Servers which are bound to or released from a ServerClaim are reconciled ahead of periodic resyncs.
+		Watches(&metalv1alpha1.Server{}, enqueueExpedited(func(oldObj, newObj client.Object) bool {
+			if oldObj == nil {
+				return false
+			}
+			oldServer, newServer := oldObj.(*metalv1alpha1.Server), newObj.(*metalv1alpha1.Server)
+			return (oldServer.Spec.ServerClaimRef == nil) != (newServer.Spec.ServerClaimRef == nil)
+		})).
 		Watches(
 			&metalv1alpha1.ServerBootConfiguration{},
 			r.enqueueServerByServerBootConfiguration(),
 		).
+		// Changed values of BIOS setting sources are applied right away instead of with the next resync.
+		Watches(&v1.Secret{}, r.enqueueServersByBIOSSettingSource(func(source metalv1alpha1.BIOSSettingSource) *metalv1alpha1.ObjectKeySelector {
+			return source.SecretKeyRef
+		})).
+		Watches(&v1.ConfigMap{}, r.enqueueServersByBIOSSettingSource(func(source metalv1alpha1.BIOSSettingSource) *metalv1alpha1.ObjectKeySelector {
+			return source.ConfigMapKeyRef
+		})).
 		WatchesRawSource(source.Channel(ch, &handler.TypedEnqueueRequestForObject[*metalv1alpha1.Server]{})).
 		Complete(r)
 }
@@ -1032,3 +2822,91 @@ func (r *ServerReconciler) enqueueServerByServerBootConfiguration() handler.Even
 		}
 	})
 }
+
+// enqueueServersByBIOSSettingSource enqueues the Servers whose BIOS settings are sourced from the object. Only
+// objects in the manager namespace are read as BIOS setting sources, so objects in other namespaces are ignored.
+func (r *ServerReconciler) enqueueServersByBIOSSettingSource(ref func(metalv1alpha1.BIOSSettingSource) *metalv1alpha1.ObjectKeySelector) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []ctrl.Request {
+		if obj.GetNamespace() != r.ManagerNamespace {
+			return nil
+		}
+		serverList := &metalv1alpha1.ServerList{}
+		if err := r.List(ctx, serverList); err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "Failed to list Servers")
+			return nil
+		}
+		var requests []ctrl.Request
+		for _, server := range serverList.Items {
+			if slices.ContainsFunc(server.Spec.BIOS, func(settings metalv1alpha1.BIOSSettings) bool {
+				return slices.ContainsFunc(settings.SettingsFrom, func(source metalv1alpha1.BIOSSettingSource) bool {
+					selector := ref(source)
+					return selector != nil && selector.Namespace == obj.GetNamespace() && selector.Name == obj.GetName()
+				})
+			}) {
+				requests = append(requests, ctrl.Request{NamespacedName: types.NamespacedName{Name: server.Name}})
+			}
+		}
+		return requests
+	})
+}
+
+// discoveringServer reports whether the Server has not completed its discovery yet, so that the inventory which
+// rarely changes is read from its BMC.
+func discoveringServer(server *metalv1alpha1.Server) bool {
+	switch server.Status.State {
+	case "", metalv1alpha1.ServerStateInitial, metalv1alpha1.ServerStateDiscovery:
+		return true
+	default:
+		return false
+	}
+}
+
+// updateServerInventory sets the processor summary and the rack in the status of the Server. They are only read
+// while the Server is discovered, as they cost extra Redfish requests which are not worth every status update.
+func updateServerInventory(ctx context.Context, log logr.Logger, bmcClient bmc.BMC, server *metalv1alpha1.Server) {
+	processors, err := bmcClient.GetProcessors(ctx, server.Spec.SystemUUID)
+	if err != nil {
+		log.V(1).Info("Failed to get processors of Server", "Error", err.Error())
+	} else {
+		summarizeProcessors(server, processors)
+	}
+	// The architecture reported by the discovery agent takes precedence, the processors of the BMC only
+	// provide it before the first discovery.
+	if server.Status.Architecture == "" {
+		server.Status.Architecture = metalv1alpha1.Architecture(bmc.ArchitectureFromProcessors(processors))
+	}
+
+	rack, err := bmcClient.GetSystemRack(ctx, server.Spec.SystemUUID)
+	if err != nil {
+		log.V(1).Info("Failed to get rack of Server", "Error", err.Error())
+	} else {
+		server.Status.Rack = rack
+	}
+}
+
+// summarizeProcessors sets the CPU cores and the GPU count in the status of the Server from its processors.
+func summarizeProcessors(server *metalv1alpha1.Server, processors []bmc.Processor) {
+	server.Status.CPUCores = 0
+	server.Status.GPUCount = 0
+	for _, processor := range processors {
+		switch redfish.ProcessorType(processor.ProcessorType) {
+		case redfish.CPUProcessorType, "":
+			server.Status.CPUCores += processor.TotalCores
+		case redfish.GPUProcessorType:
+			server.Status.GPUCount++
+		}
+	}
+}
+
+// summarizeServerStatus sets the summary fields of the status of the Server which are derived from its nested
+// status fields, so that they can be used in field selectors and printer columns.
+func summarizeServerStatus(server *metalv1alpha1.Server) {
+	server.Status.MemoryGiB = 0
+	if memory := server.Status.TotalSystemMemory; memory != nil {
+		server.Status.MemoryGiB = int32(memory.Value() >> 30)
+	}
+	server.Status.DiskCount = 0
+	for _, storage := range server.Status.Storages {
+		server.Status.DiskCount += int32(len(storage.Drives))
+	}
+}
@@ -4,8 +4,15 @@
 package controller
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/netip"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
@@ -13,10 +20,12 @@ import (
 	"gopkg.in/yaml.v3"
 
 	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
 	"github.com/ironcore-dev/metal-operator/internal/ignition"
 	"github.com/ironcore-dev/metal-operator/internal/probe"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/stmcginnis/gofish/redfish"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -84,6 +93,9 @@ var _ = Describe("Server Controller", func() {
 			HaveField("Spec.ServerClaimRef", BeNil()),
 			HaveField("Status.Manufacturer", "Contoso"),
 			HaveField("Status.Model", "3500"),
+			HaveField("Status.CanonicalManufacturer", "Contoso"),
+			HaveField("Status.CanonicalModel", "3500"),
+			HaveField("Labels", HaveKeyWithValue(ServerManufacturerLabel, "Contoso")),
 			HaveField("Status.SKU", "8675309"),
 			HaveField("Status.SerialNumber", "437XR1138R2"),
 			HaveField("Status.IndicatorLED", metalv1alpha1.OffIndicatorLED),
@@ -225,6 +237,18 @@ var _ = Describe("Server Controller", func() {
 			HaveField("Status.NetworkInterfaces", Not(BeEmpty())),
 		))
 
+		By("Ensuring that the power conditions reflect the power state")
+		Eventually(Object(server)).Should(HaveField("Status.Conditions", ContainElements(
+			SatisfyAll(
+				HaveField("Type", ServerConditionPoweredOff),
+				HaveField("Status", metav1.ConditionTrue),
+			),
+			SatisfyAll(
+				HaveField("Type", ServerConditionPoweredOn),
+				HaveField("Status", metav1.ConditionFalse),
+			),
+		)))
+
 		By("Ensuring that the boot configuration has been removed")
 		Consistently(Get(bootConfig)).Should(Satisfy(apierrors.IsNotFound))
 
@@ -418,6 +442,7 @@ var _ = Describe("Server Controller", func() {
 						Vendor:   "Contoso",
 						Model:    "3000GT8",
 						State:    metalv1alpha1.StorageStateEnabled,
+						Health:   metalv1alpha1.StorageHealthOK,
 					},
 					{
 						Name:     "SATA Bay 2",
@@ -425,6 +450,7 @@ var _ = Describe("Server Controller", func() {
 						Vendor:   "Contoso",
 						Model:    "3000GT7",
 						State:    metalv1alpha1.StorageStateEnabled,
+						Health:   metalv1alpha1.StorageHealthOK,
 					},
 					{
 						Name:     "SATA Bay 3",
@@ -450,3 +476,725 @@ var _ = Describe("Server Controller", func() {
 		Expect(response.StatusCode).To(Equal(http.StatusNotFound))
 	})
 })
+
+var _ = Describe("Server Discovery Policy", func() {
+	It("should override the manager defaults with the discovery policy", func() {
+		reconciler := &ServerReconciler{
+			DiscoveryTimeout:     30 * time.Minute,
+			MaxDiscoveryAttempts: 3,
+			MaxBootRetries:       3,
+		}
+		reconciler.BMCOptions.PowerPollingTimeout = 2 * time.Minute
+		server := &metalv1alpha1.Server{}
+
+		By("Ensuring that a server without a policy uses the manager defaults")
+		Expect(reconciler.discoveryTimeout(server)).To(Equal(30 * time.Minute))
+		Expect(reconciler.maxDiscoveryAttempts(server)).To(Equal(3))
+		Expect(reconciler.maxBootRetries(server)).To(Equal(3))
+		Expect(reconciler.bmcOptions(server).PowerPollingTimeout).To(Equal(2 * time.Minute))
+
+		By("Ensuring that the policy of the server overrides the manager defaults")
+		server.Spec.DiscoveryPolicy = &metalv1alpha1.ServerDiscoveryPolicy{
+			Timeout:        &metav1.Duration{Duration: 45 * time.Minute},
+			MaxAttempts:    ptr.To[int32](0),
+			MaxBootRetries: ptr.To[int32](1),
+			PowerOnTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+		}
+		Expect(reconciler.discoveryTimeout(server)).To(Equal(45 * time.Minute))
+		Expect(reconciler.maxDiscoveryAttempts(server)).To(Equal(0))
+		Expect(reconciler.maxBootRetries(server)).To(Equal(1))
+		Expect(reconciler.bmcOptions(server).PowerPollingTimeout).To(Equal(10 * time.Minute))
+		Expect(reconciler.BMCOptions.PowerPollingTimeout).To(Equal(2 * time.Minute))
+	})
+
+	It("should only rediscover servers whose rediscovery interval has passed", func(ctx SpecContext) {
+		reconciler := &ServerReconciler{RediscoveryInterval: 24 * time.Hour}
+		server := &metalv1alpha1.Server{}
+		server.Status.LastDiscoveryTime = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+
+		By("Ensuring that a recently discovered server is not rediscovered")
+		Expect(reconciler.ensureRediscovery(ctx, GinkgoLogr, server)).To(BeFalse())
+
+		By("Ensuring that a server excluded by its policy is not rediscovered")
+		server.Status.LastDiscoveryTime = &metav1.Time{Time: time.Now().Add(-48 * time.Hour)}
+		server.Spec.DiscoveryPolicy = &metalv1alpha1.ServerDiscoveryPolicy{RediscoveryInterval: &metav1.Duration{}}
+		Expect(reconciler.rediscoveryInterval(server)).To(BeZero())
+		Expect(reconciler.ensureRediscovery(ctx, GinkgoLogr, server)).To(BeFalse())
+	})
+})
+
+var _ = Describe("Server Rediscovery", func() {
+	_ = SetupTest()
+
+	It("Should seed the last discovery time and limit the concurrent rediscoveries", func(ctx SpecContext) {
+		first := createPausedServer(ctx, "10.30.0.13", "38947555-7742-3448-3784-823347823846")
+		second := createPausedServer(ctx, "10.30.0.14", "38947555-7742-3448-3784-823347823847")
+		for _, server := range []*metalv1alpha1.Server{first, second} {
+			Eventually(UpdateStatus(server, func() {
+				server.Status.State = metalv1alpha1.ServerStateAvailable
+			})).Should(Succeed())
+		}
+		reconciler := &ServerReconciler{
+			Client:                     k8sClient,
+			RediscoveryInterval:        24 * time.Hour,
+			MaxConcurrentRediscoveries: 1,
+		}
+
+		By("Seeding the last discovery time of Servers discovered before it has been recorded")
+		Expect(reconciler.ensureRediscovery(ctx, GinkgoLogr, first)).To(BeFalse())
+		Eventually(Object(first)).Should(HaveField("Status.LastDiscoveryTime.Time", SatisfyAll(
+			BeTemporally(">", time.Now().Add(-24*time.Hour)),
+			BeTemporally("<=", time.Now()),
+		)))
+		Expect(first.Status.State).To(Equal(metalv1alpha1.ServerStateAvailable))
+
+		By("Rediscovering the first Server once its interval has passed")
+		for _, server := range []*metalv1alpha1.Server{first, second} {
+			Eventually(UpdateStatus(server, func() {
+				server.Status.LastDiscoveryTime = &metav1.Time{Time: time.Now().Add(-48 * time.Hour)}
+			})).Should(Succeed())
+		}
+		Expect(reconciler.ensureRediscovery(ctx, GinkgoLogr, first)).To(BeTrue())
+		Expect(first.Status.State).To(Equal(metalv1alpha1.ServerStateInitial))
+
+		By("Deferring the second Server before the cache reflects the first rediscovery")
+		Expect(reconciler.ensureRediscovery(ctx, GinkgoLogr, second)).To(BeFalse())
+		Eventually(Object(first)).Should(HaveField("Status.Conditions", ContainElement(SatisfyAll(
+			HaveField("Type", ServerConditionRediscovering),
+			HaveField("Status", metav1.ConditionTrue),
+		))))
+		Expect(reconciler.ensureRediscovery(ctx, GinkgoLogr, second)).To(BeFalse())
+		Expect(Object(second)()).To(HaveField("Status.State", metalv1alpha1.ServerStateAvailable))
+
+		By("Rediscovering the second Server once the first discovery completed")
+		Expect(reconciler.completeDiscovery(ctx, first)).To(Succeed())
+		Eventually(Object(first)).Should(HaveField("Status.Conditions",
+			Not(ContainElement(HaveField("Type", ServerConditionRediscovering)))))
+		Eventually(func() (bool, error) {
+			return reconciler.ensureRediscovery(ctx, GinkgoLogr, second)
+		}).Should(BeTrue())
+		Expect(second.Status.State).To(Equal(metalv1alpha1.ServerStateInitial))
+	})
+})
+
+var _ = Describe("Server Inventory", func() {
+	_ = SetupTest()
+
+	It("Should only read the processors and the rack while the Server is discovered", func(ctx SpecContext) {
+		simulator := registerSimulator("10.30.0.16:8000", "38947555-7742-3448-3784-823347823850")
+		simulator.Update(func(state *bmc.SimulatorState) {
+			state.Systems[0].Rack = "R12"
+			state.Systems[0].Info.Processors = []bmc.Processor{
+				{ID: "CPU1", ProcessorType: string(redfish.CPUProcessorType), TotalCores: 32},
+				{ID: "CPU2", ProcessorType: string(redfish.CPUProcessorType), TotalCores: 32},
+				{ID: "GPU1", ProcessorType: string(redfish.GPUProcessorType)},
+			}
+		})
+		var inventoryReads atomic.Int32
+		simulator.SetIntercept(func(ctx context.Context, operation string) error {
+			if operation == "GetProcessors" || operation == "GetSystemRack" {
+				inventoryReads.Add(1)
+			}
+			return nil
+		})
+		server := createPausedServer(ctx, "10.30.0.16", "38947555-7742-3448-3784-823347823850")
+		reconciler := &ServerReconciler{
+			Client:     k8sClient,
+			Insecure:   true,
+			BMCOptions: bmc.BMCOptions{BasicAuth: true},
+		}
+
+		By("Reading the inventory of a Server in discovery")
+		Eventually(UpdateStatus(server, func() {
+			server.Status.State = metalv1alpha1.ServerStateDiscovery
+		})).Should(Succeed())
+		Expect(reconciler.updateServerStatus(ctx, GinkgoLogr, server)).To(Succeed())
+		Eventually(Object(server)).Should(SatisfyAll(
+			HaveField("Status.CPUCores", BeEquivalentTo(64)),
+			HaveField("Status.GPUCount", BeEquivalentTo(1)),
+			HaveField("Status.Rack", "R12"),
+		))
+		Expect(inventoryReads.Load()).To(BeEquivalentTo(2))
+
+		By("Keeping the inventory of an Available Server without reading it")
+		Eventually(UpdateStatus(server, func() {
+			server.Status.State = metalv1alpha1.ServerStateAvailable
+		})).Should(Succeed())
+		Expect(reconciler.updateServerStatus(ctx, GinkgoLogr, server)).To(Succeed())
+		Expect(inventoryReads.Load()).To(BeEquivalentTo(2))
+		Expect(Object(server)()).To(SatisfyAll(
+			HaveField("Status.CPUCores", BeEquivalentTo(64)),
+			HaveField("Status.Rack", "R12"),
+		))
+	})
+
+	It("Should update the status of a Server whose BMC cannot list its DPUs", func(ctx SpecContext) {
+		simulator := registerSimulator("10.30.0.23:8000", "38947555-7742-3448-3784-823347823858")
+		simulator.Update(func(state *bmc.SimulatorState) {
+			state.Systems[0].Info.PowerState = redfish.OffPowerState
+		})
+		simulator.SetFailure("GetDPUs", errors.New("resource not found"))
+		server := createPausedServer(ctx, "10.30.0.23", "38947555-7742-3448-3784-823347823858")
+		reconciler := &ServerReconciler{
+			Client:     k8sClient,
+			Insecure:   true,
+			BMCOptions: bmc.BMCOptions{BasicAuth: true},
+		}
+		Eventually(UpdateStatus(server, func() {
+			server.Status.PowerState = metalv1alpha1.ServerOnPowerState
+			server.Status.DPUs = []metalv1alpha1.DPUStatus{{Name: "DPU1"}}
+		})).Should(Succeed())
+
+		Expect(reconciler.updateServerStatus(ctx, GinkgoLogr, server)).To(Succeed())
+		Eventually(Object(server)).Should(SatisfyAll(
+			HaveField("Status.PowerState", metalv1alpha1.ServerOffPowerState),
+			HaveField("Status.DPUs", HaveExactElements(HaveField("Name", "DPU1"))),
+		))
+	})
+})
+
+// createPausedServer creates a Server behind the simulated BMC at the address whose reconciliation by the manager is
+// paused, so that the tests drive the reconciler themselves.
+func createPausedServer(ctx SpecContext, address, systemUUID string) *metalv1alpha1.Server {
+	bmcSecret := &metalv1alpha1.BMCSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "test-",
+		},
+		Data: map[string][]byte{
+			metalv1alpha1.BMCSecretUsernameKeyName: []byte("foo"),
+			metalv1alpha1.BMCSecretPasswordKeyName: []byte("bar"),
+		},
+	}
+	Expect(k8sClient.Create(ctx, bmcSecret)).To(Succeed())
+	DeferCleanup(k8sClient.Delete, bmcSecret)
+
+	server := &metalv1alpha1.Server{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "test-",
+			Annotations: map[string]string{
+				metalv1alpha1.PausedUntilAnnotation: time.Now().Add(time.Hour).Format(time.RFC3339),
+			},
+		},
+		Spec: metalv1alpha1.ServerSpec{
+			SystemUUID: systemUUID,
+			BMC: &metalv1alpha1.BMCAccess{
+				Protocol: metalv1alpha1.Protocol{
+					Name: metalv1alpha1.ProtocolRedfishFake,
+					Port: 8000,
+				},
+				Address:      address,
+				BMCSecretRef: v1.LocalObjectReference{Name: bmcSecret.Name},
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, server)).To(Succeed())
+	DeferCleanup(k8sClient.Delete, server)
+	// the manager records the pause once, which must not race with the status patches of the tests
+	Eventually(Object(server)).Should(HaveField("Status.Conditions", ContainElement(HaveField("Type", ServerConditionPaused))))
+	return server
+}
+
+// registerSimulator registers a simulated BMC at the address whose system has the UUID and is powered on.
+func registerSimulator(address, systemUUID string) *bmc.Simulator {
+	simulator := bmc.NewSimulator()
+	simulator.Update(func(state *bmc.SimulatorState) {
+		state.Systems[0].Info.SystemUUID = systemUUID
+		state.Systems[0].Info.PowerState = redfish.OnPowerState
+	})
+	bmc.Simulators.Register(address, simulator)
+	return simulator
+}
+
+var _ = Describe("Server Boot Verification", func() {
+	_ = SetupTest()
+
+	var (
+		simulator *bmc.Simulator
+		server    *metalv1alpha1.Server
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		simulator = registerSimulator("10.30.0.3:8000", "38947555-7742-3448-3784-823347823837")
+		server = createPausedServer(ctx, "10.30.0.3", "38947555-7742-3448-3784-823347823837")
+	})
+
+	// bootServer records a powered on Server with a network interface on the loopback address.
+	bootServer := func(ctx SpecContext, reconciler *ServerReconciler) {
+		Eventually(UpdateStatus(server, func() {
+			server.Status.PowerState = metalv1alpha1.ServerOnPowerState
+			server.Status.NetworkInterfaces = []metalv1alpha1.NetworkInterface{{
+				Name:       "eth0",
+				IP:         metalv1alpha1.IP{Addr: netip.MustParseAddr("127.0.0.1")},
+				MACAddress: "12:44:6A:3B:04:11",
+			}}
+		})).Should(Succeed())
+		Expect(reconciler.startBootVerification(ctx, server, 1)).To(Succeed())
+	}
+
+	It("should verify the boot of a reachable server", func(ctx SpecContext) {
+		By("Listening on the boot verification port")
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(listener.Close)
+
+		reconciler := &ServerReconciler{
+			Client:                  k8sClient,
+			Insecure:                true,
+			BMCOptions:              bmc.BMCOptions{BasicAuth: true},
+			BootVerificationTimeout: time.Minute,
+			BootVerificationPort:    listener.Addr().(*net.TCPAddr).Port,
+		}
+		bootServer(ctx, reconciler)
+
+		By("Ensuring that the boot is verified")
+		Expect(reconciler.verifyServerBoot(ctx, GinkgoLogr, server)).To(Succeed())
+		Expect(Object(server)()).To(SatisfyAll(
+			HaveField("Status.BootAttempts", BeEquivalentTo(1)),
+			HaveField("Status.Conditions", ContainElement(SatisfyAll(
+				HaveField("Type", ServerConditionBootFailed),
+				HaveField("Status", metav1.ConditionFalse),
+				HaveField("Reason", serverBootReasonSucceeded),
+			))),
+		))
+	})
+
+	It("should retry the PXE boot of an unreachable server and taint it after all retries", func(ctx SpecContext) {
+		By("Reserving a port nothing listens on")
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		port := listener.Addr().(*net.TCPAddr).Port
+		Expect(listener.Close()).To(Succeed())
+
+		reconciler := &ServerReconciler{
+			Client:                  k8sClient,
+			Insecure:                true,
+			BMCOptions:              bmc.BMCOptions{BasicAuth: true},
+			BootVerificationTimeout: time.Millisecond,
+			BootVerificationPort:    port,
+			MaxBootRetries:          1,
+			TaintOnBootFailure:      true,
+		}
+		bootServer(ctx, reconciler)
+		var operations []string
+		simulator.SetIntercept(func(_ context.Context, operation string) error {
+			operations = append(operations, operation)
+			return nil
+		})
+
+		By("Ensuring that the server is PXE booted again after the timeout")
+		Expect(reconciler.verifyServerBoot(ctx, GinkgoLogr, server)).To(Succeed())
+		Expect(Object(server)()).To(SatisfyAll(
+			HaveField("Status.BootAttempts", BeEquivalentTo(2)),
+			HaveField("Status.Conditions", ContainElement(HaveField("Type", ServerConditionPowerCycleRequested))),
+		))
+		Expect(operations).To(ContainElements("SetPXEBootOnce", "Reset"))
+
+		By("Ensuring that the boot fails and the server is tainted after all retries")
+		Expect(reconciler.verifyServerBoot(ctx, GinkgoLogr, server)).To(Succeed())
+		Expect(Object(server)()).To(SatisfyAll(
+			HaveField("Labels", HaveKeyWithValue(ServerBootFailedLabel, "true")),
+			HaveField("Status.Conditions", ContainElement(SatisfyAll(
+				HaveField("Type", ServerConditionBootFailed),
+				HaveField("Status", metav1.ConditionTrue),
+				HaveField("Reason", serverBootReasonFailed),
+			))),
+		))
+	})
+})
+
+var _ = Describe("Server Boot Override Verification", func() {
+	_ = SetupTest()
+
+	var (
+		simulator  *bmc.Simulator
+		server     *metalv1alpha1.Server
+		reconciler *ServerReconciler
+		operations []string
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		simulator = registerSimulator("10.30.0.18:8000", "38947555-7742-3448-3784-823347823852")
+		server = createPausedServer(ctx, "10.30.0.18", "38947555-7742-3448-3784-823347823852")
+		reconciler = &ServerReconciler{
+			Client:                 k8sClient,
+			Insecure:               true,
+			BMCOptions:             bmc.BMCOptions{BasicAuth: true},
+			BootOverrideCheckDelay: time.Millisecond,
+			MaxBootRetries:         1,
+		}
+		operations = nil
+		simulator.SetIntercept(func(_ context.Context, operation string) error {
+			operations = append(operations, operation)
+			return nil
+		})
+
+		By("Recording a Server powered on a minute ago")
+		Eventually(UpdateStatus(server, func() {
+			server.Status.PowerState = metalv1alpha1.ServerOnPowerState
+			server.Status.Conditions = append(server.Status.Conditions, metav1.Condition{
+				Type:               ServerConditionPoweredOn,
+				Status:             metav1.ConditionTrue,
+				Reason:             serverPowerReasonOnCompleted,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+			})
+		})).Should(Succeed())
+	})
+
+	recordOverride := func() {
+		Eventually(UpdateStatus(server, func() {
+			server.Status.PXEBootOverrideTime = ptr.To(metav1.NewTime(time.Now().Add(-2 * time.Minute)))
+		})).Should(Succeed())
+	}
+
+	It("should retry the PXE boot if the BMC ignored the override set by the operator", func(ctx SpecContext) {
+		simulator.Update(func(state *bmc.SimulatorState) {
+			state.Systems[0].BootOverride = string(redfish.PxeBootSourceOverrideTarget)
+			state.Systems[0].IgnoresBootOverride = true
+		})
+		recordOverride()
+
+		Expect(reconciler.verifyBootOverride(ctx, GinkgoLogr, server)).To(Succeed())
+		Expect(operations).To(ContainElements("SetPXEBootOnce", "Reset"))
+		Expect(Object(server)()).To(SatisfyAll(
+			HaveField("Status.BootOverrideRetries", BeEquivalentTo(1)),
+			HaveField("Status.PXEBootOverrideTime", Not(BeNil())),
+			HaveField("Status.Conditions", ContainElement(SatisfyAll(
+				HaveField("Type", ServerConditionBootOverrideIgnored),
+				HaveField("Reason", serverBootOverrideReasonRetrying),
+			))),
+		))
+	})
+
+	It("should not restart the server for overrides the operator did not set", func(ctx SpecContext) {
+		simulator.Update(func(state *bmc.SimulatorState) {
+			state.Systems[0].BootOverride = string(redfish.PxeBootSourceOverrideTarget)
+		})
+
+		By("Ensuring that a PXE override is not verified without having been recorded")
+		Expect(reconciler.verifyBootOverride(ctx, GinkgoLogr, server)).To(Succeed())
+		Expect(operations).To(BeEmpty())
+
+		By("Ensuring that a pending override of another target is left alone")
+		simulator.Update(func(state *bmc.SimulatorState) {
+			state.Systems[0].BootOverride = string(redfish.HddBootSourceOverrideTarget)
+		})
+		recordOverride()
+		Expect(reconciler.verifyBootOverride(ctx, GinkgoLogr, server)).To(Succeed())
+		Expect(operations).NotTo(ContainElement("Reset"))
+		Expect(Object(server)()).To(SatisfyAll(
+			HaveField("Status.BootOverrideRetries", BeZero()),
+			HaveField("Status.PXEBootOverrideTime", BeNil()),
+			HaveField("Status.Conditions", Not(ContainElement(HaveField("Type", ServerConditionBootOverrideIgnored)))),
+		))
+	})
+})
+
+var _ = Describe("Server Discovery Escalation", func() {
+	_ = SetupTest()
+
+	It("should escalate repeatedly timed out discoveries before giving up", func(ctx SpecContext) {
+		simulator := registerSimulator("10.30.0.4:8000", "38947555-7742-3448-3784-823347823838")
+		server := createPausedServer(ctx, "10.30.0.4", "38947555-7742-3448-3784-823347823838")
+		reconciler := &ServerReconciler{
+			Client:               k8sClient,
+			Insecure:             true,
+			BMCOptions:           bmc.BMCOptions{BasicAuth: true},
+			MaxDiscoveryAttempts: 2,
+			DiscoveryEscalation:  []DiscoveryEscalationAction{DiscoveryEscalationResetBMC, DiscoveryEscalationSwitchBootMode},
+		}
+		Eventually(UpdateStatus(server, func() {
+			server.Status.State = metalv1alpha1.ServerStateDiscovery
+		})).Should(Succeed())
+
+		By("Ensuring that the discovery is retried until the maximum attempts are reached")
+		Expect(reconciler.handleDiscoveryTimeout(ctx, GinkgoLogr, server)).To(BeTrue())
+		Expect(server.Status.State).To(Equal(metalv1alpha1.ServerStateInitial))
+		Expect(simulator.State().ManagerResets).To(BeZero())
+
+		By("Ensuring that the BMC is reset on the first escalation")
+		Expect(reconciler.handleDiscoveryTimeout(ctx, GinkgoLogr, server)).To(BeTrue())
+		Expect(simulator.State().ManagerResets).To(Equal(1))
+		Expect(reconciler.isDiscoveryBootModeSwitched(server)).To(BeFalse())
+		Expect(server.Status.Conditions).To(ContainElement(SatisfyAll(
+			HaveField("Type", ServerConditionDiscoveryFailed),
+			HaveField("Status", metav1.ConditionFalse),
+			HaveField("Reason", serverDiscoveryReasonEscalated),
+		)))
+
+		By("Ensuring that the boot mode is switched on the second escalation")
+		Expect(reconciler.handleDiscoveryTimeout(ctx, GinkgoLogr, server)).To(BeTrue())
+		Expect(reconciler.isDiscoveryBootModeSwitched(server)).To(BeTrue())
+		Expect(server.Status.State).To(Equal(metalv1alpha1.ServerStateInitial))
+
+		By("Ensuring that the server is moved into the error state once all escalations are exhausted")
+		Expect(reconciler.handleDiscoveryTimeout(ctx, GinkgoLogr, server)).To(BeTrue())
+		Expect(Object(server)()).To(SatisfyAll(
+			HaveField("Status.State", metalv1alpha1.ServerStateError),
+			HaveField("Status.DiscoveryAttempts", BeEquivalentTo(4)),
+			HaveField("Status.Conditions", ContainElement(SatisfyAll(
+				HaveField("Type", ServerConditionDiscoveryFailed),
+				HaveField("Status", metav1.ConditionTrue),
+				HaveField("Message", ContainSubstring("Discovery timed out 4 time(s)")),
+			))),
+		))
+	})
+})
+
+var _ = Describe("Server Operations", func() {
+	_ = SetupTest()
+
+	var (
+		simulator  *bmc.Simulator
+		server     *metalv1alpha1.Server
+		reconciler *ServerReconciler
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		simulator = registerSimulator("10.30.0.5:8000", "38947555-7742-3448-3784-823347823839")
+		server = createPausedServer(ctx, "10.30.0.5", "38947555-7742-3448-3784-823347823839")
+		reconciler = &ServerReconciler{
+			Client:   k8sClient,
+			Insecure: true,
+			BMCOptions: bmc.BMCOptions{
+				BasicAuth:            true,
+				PowerPollingInterval: 50 * time.Millisecond,
+				PowerPollingTimeout:  time.Minute,
+			},
+		}
+	})
+
+	// requestOperation annotates the Server with the operation and the given scheduling annotations.
+	requestOperation := func(operation string, annotations map[string]string) {
+		Eventually(Update(server, func() {
+			server.Annotations[metalv1alpha1.OperationAnnotation] = operation
+			for key, value := range annotations {
+				server.Annotations[key] = value
+			}
+		})).Should(Succeed())
+	}
+
+	It("should defer an operation until its NotBefore time", func(ctx SpecContext) {
+		notBefore := time.Now().Add(time.Hour)
+		requestOperation(string(redfish.ForceRestartResetType), map[string]string{
+			metalv1alpha1.OperationNotBeforeAnnotation: notBefore.Format(time.RFC3339),
+		})
+
+		modified, delay, err := reconciler.handleAnnotionOperations(ctx, GinkgoLogr, server)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(modified).To(BeFalse())
+		Expect(delay).To(BeNumerically("~", time.Hour, time.Minute))
+		Expect(server.Annotations).To(HaveKeyWithValue(metalv1alpha1.OperationAnnotation, string(redfish.ForceRestartResetType)))
+		Expect(simulator.State().Systems[0].Info.PowerState).To(Equal(redfish.OnPowerState))
+	})
+
+	It("should discard an operation after its NotAfter time", func(ctx SpecContext) {
+		requestOperation(string(redfish.ForceOffResetType), map[string]string{
+			metalv1alpha1.OperationNotAfterAnnotation: time.Now().Add(-time.Minute).Format(time.RFC3339),
+		})
+
+		Expect(reconciler.handleAnnotionOperations(ctx, GinkgoLogr, server)).To(BeTrue())
+		Expect(Object(server)()).To(HaveField("Annotations", SatisfyAll(
+			Not(HaveKey(metalv1alpha1.OperationAnnotation)),
+			Not(HaveKey(metalv1alpha1.OperationNotAfterAnnotation)),
+		)))
+		Expect(simulator.State().Systems[0].Info.PowerState).To(Equal(redfish.OnPowerState))
+	})
+
+	It("should restart a server gracefully across reconciliations", func(ctx SpecContext) {
+		requestOperation(string(redfish.GracefulRestartResetType), nil)
+
+		By("Ensuring that the operating system is asked to shut down without waiting for it")
+		modified, delay, err := reconciler.handleAnnotionOperations(ctx, GinkgoLogr, server)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(modified).To(BeFalse())
+		Expect(delay).To(Equal(50 * time.Millisecond))
+		Expect(server.Status.Conditions).To(ContainElement(SatisfyAll(
+			HaveField("Type", ServerConditionGracefulRestart),
+			HaveField("Status", metav1.ConditionTrue),
+			HaveField("Reason", gracefulRestartReasonShuttingDown),
+		)))
+		Expect(simulator.State().Systems[0].Info.PowerState).To(Equal(redfish.OffPowerState))
+
+		By("Ensuring that the server is powered on once it is off")
+		_, _, err = reconciler.handleAnnotionOperations(ctx, GinkgoLogr, server)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(server.Status.Conditions).To(ContainElement(HaveField("Reason", gracefulRestartReasonPoweringOn)))
+		Expect(simulator.State().Systems[0].Info.PowerState).To(Equal(redfish.OnPowerState))
+
+		By("Ensuring that the restart completes once the server is on")
+		Expect(reconciler.handleAnnotionOperations(ctx, GinkgoLogr, server)).To(BeTrue())
+		Expect(Object(server)()).To(SatisfyAll(
+			HaveField("Annotations", Not(HaveKey(metalv1alpha1.OperationAnnotation))),
+			HaveField("Status.Conditions", ContainElements(
+				SatisfyAll(
+					HaveField("Type", ServerConditionGracefulRestart),
+					HaveField("Status", metav1.ConditionFalse),
+					HaveField("Reason", gracefulRestartReasonCompleted),
+				),
+				HaveField("Type", ServerConditionPowerCycleRequested),
+			)),
+		))
+	})
+
+	It("should fail a graceful restart if the server does not shut down", func(ctx SpecContext) {
+		reconciler.BMCOptions.PowerPollingTimeout = time.Millisecond
+		requestOperation(string(redfish.GracefulRestartResetType), nil)
+		_, _, err := reconciler.handleAnnotionOperations(ctx, GinkgoLogr, server)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Simulating an operating system which ignores the shutdown")
+		simulator.Update(func(state *bmc.SimulatorState) {
+			state.Systems[0].Info.PowerState = redfish.OnPowerState
+		})
+
+		By("Ensuring that the restart fails after the power polling timeout")
+		Eventually(func(g Gomega) {
+			_, _, err := reconciler.handleAnnotionOperations(ctx, GinkgoLogr, server)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(Object(server)()).To(SatisfyAll(
+				HaveField("Annotations", Not(HaveKey(metalv1alpha1.OperationAnnotation))),
+				HaveField("Status.Conditions", ContainElement(SatisfyAll(
+					HaveField("Type", ServerConditionGracefulRestart),
+					HaveField("Status", metav1.ConditionFalse),
+					HaveField("Reason", gracefulRestartReasonFailed),
+				))),
+			))
+		}).Should(Succeed())
+	})
+})
+
+var _ = Describe("Server BIOS Settings", func() {
+	ns := SetupTest()
+
+	var (
+		simulator  *bmc.Simulator
+		server     *metalv1alpha1.Server
+		reconciler *ServerReconciler
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		simulator = registerSimulator("10.30.0.7:8000", "38947555-7742-3448-3784-823347823840")
+		server = createPausedServer(ctx, "10.30.0.7", "38947555-7742-3448-3784-823347823840")
+		reconciler = &ServerReconciler{
+			Client:           k8sClient,
+			Insecure:         true,
+			ManagerNamespace: ns.Name,
+			BMCOptions:       bmc.BMCOptions{BasicAuth: true},
+		}
+	})
+
+	It("Should only read BIOS settings from Secrets in the manager namespace", func(ctx SpecContext) {
+		otherNamespace := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: "test-"}}
+		Expect(k8sClient.Create(ctx, otherNamespace)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, otherNamespace)
+		secret := &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: otherNamespace.Name, Name: "bios"},
+			Data:       map[string][]byte{"password": []byte("secret")},
+		}
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+
+		Eventually(Update(server, func() {
+			server.Spec.BIOS = []metalv1alpha1.BIOSSettings{{
+				Version: simulator.State().Systems[0].BiosVersion,
+				SettingsFrom: []metalv1alpha1.BIOSSettingSource{{
+					Name: "AdminPassword",
+					SecretKeyRef: &metalv1alpha1.ObjectKeySelector{
+						Namespace: otherNamespace.Name,
+						Name:      secret.Name,
+						Key:       "password",
+					},
+				}},
+			}}
+		})).Should(Succeed())
+
+		Expect(reconciler.applyBiosSettings(ctx, GinkgoLogr, server)).To(MatchError(ContainSubstring("not in the manager namespace")))
+		Expect(simulator.State().Systems[0].PendingBiosAttributes).To(BeEmpty())
+	})
+
+	It("Should record the applied BIOS settings once while they are pending", func(ctx SpecContext) {
+		Eventually(Update(server, func() {
+			server.Spec.BIOS = []metalv1alpha1.BIOSSettings{{
+				Version:  simulator.State().Systems[0].BiosVersion,
+				Settings: map[string]string{"SriovGlobalEnable": "Enabled"},
+			}}
+		})).Should(Succeed())
+
+		By("Applying the BIOS settings")
+		Expect(reconciler.applyBiosSettings(ctx, GinkgoLogr, server)).To(Succeed())
+		Expect(simulator.State().Systems[0].PendingBiosAttributes).To(HaveKeyWithValue("SriovGlobalEnable", "Enabled"))
+		Expect(Object(server)()).To(SatisfyAll(
+			HaveField("Status.BIOSSettingsHistory", ConsistOf(HaveField("Settings", ConsistOf(SatisfyAll(
+				HaveField("Name", "SriovGlobalEnable"),
+				HaveField("NewValue", "Enabled"),
+			))))),
+			HaveField("Status.Conditions", ContainElement(SatisfyAll(
+				HaveField("Type", ServerConditionRebootNeeded),
+				HaveField("Status", metav1.ConditionTrue),
+			))),
+		))
+
+		By("Ensuring that the pending settings are not recorded again")
+		Expect(reconciler.applyBiosSettings(ctx, GinkgoLogr, server)).To(Succeed())
+		Expect(reconciler.applyBiosSettings(ctx, GinkgoLogr, server)).To(Succeed())
+		Expect(Object(server)()).To(HaveField("Status.BIOSSettingsHistory", HaveLen(1)))
+	})
+
+	It("Should record BIOS settings pending on the BMC without applying them again", func(ctx SpecContext) {
+		By("Simulating settings applied before a restart of the manager, but never recorded")
+		simulator.Update(func(state *bmc.SimulatorState) {
+			state.Systems[0].PendingBiosAttributes = map[string]string{"SriovGlobalEnable": "Enabled"}
+		})
+		simulator.SetFailure("SetBiosAttributes", errors.New("settings must not be applied again"))
+		Eventually(Update(server, func() {
+			server.Spec.BIOS = []metalv1alpha1.BIOSSettings{{
+				Version:  simulator.State().Systems[0].BiosVersion,
+				Settings: map[string]string{"SriovGlobalEnable": "Enabled"},
+			}}
+		})).Should(Succeed())
+
+		Expect(reconciler.applyBiosSettings(ctx, GinkgoLogr, server)).To(Succeed())
+		Expect(Object(server)()).To(SatisfyAll(
+			HaveField("Status.BIOSSettingsHistory", ConsistOf(HaveField("Settings", ConsistOf(SatisfyAll(
+				HaveField("Name", "SriovGlobalEnable"),
+				HaveField("NewValue", "Enabled"),
+			))))),
+			HaveField("Status.Conditions", ContainElement(SatisfyAll(
+				HaveField("Type", ServerConditionRebootNeeded),
+				HaveField("Status", metav1.ConditionTrue),
+			))),
+		))
+	})
+
+	It("Should migrate the legacy RebootNeeded condition", func() {
+		server := &metalv1alpha1.Server{}
+		server.Status.Conditions = []metav1.Condition{{Type: legacyServerConditionRebootNeeded}}
+		migrateRebootNeededCondition(server)
+		Expect(server.Status.Conditions).To(ConsistOf(SatisfyAll(
+			HaveField("Type", ServerConditionRebootNeeded),
+			HaveField("Status", metav1.ConditionTrue),
+		)))
+	})
+})
+
+var _ = Describe("Probe Extensions", func() {
+	It("Should skip probe extensions with invalid names or outputs", func() {
+		annotations := probeExtensionAnnotations(GinkgoLogr, map[string]json.RawMessage{
+			"fpga":         json.RawMessage(`{"slots":2}`),
+			"Invalid_Name": json.RawMessage(`{}`),
+			"broken":       json.RawMessage(`{"slots":`),
+			"oversized":    json.RawMessage(`"` + strings.Repeat("a", maxProbeExtensionSize) + `"`),
+		})
+		Expect(annotations).To(Equal(map[string]string{
+			metalv1alpha1.ProbeExtensionAnnotationPrefix + "fpga": `{"slots":2}`,
+		}))
+	})
+
+	It("Should cap the total size of the probe extensions", func() {
+		output := json.RawMessage(`"` + strings.Repeat("a", 30*1024) + `"`)
+		annotations := probeExtensionAnnotations(GinkgoLogr, map[string]json.RawMessage{
+			"a": output, "b": output, "c": output, "d": output, "e": output,
+		})
+		Expect(annotations).To(HaveLen(4))
+		Expect(annotations).NotTo(HaveKey(metalv1alpha1.ProbeExtensionAnnotationPrefix + "e"))
+	})
+})
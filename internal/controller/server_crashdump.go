@@ -0,0 +1,310 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stmcginnis/gofish/redfish"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+)
+
+const (
+	// defaultCrashDumpTimeout is the time a crash dump may take if no CrashDumpTimeout is configured.
+	defaultCrashDumpTimeout = time.Hour
+	// crashDumpSettleTime gives the crashed operating system time to write its dump to disk before the server is
+	// restarted into the capture environment.
+	crashDumpSettleTime = 2 * time.Minute
+	// crashDumpPollInterval is the interval in which the progress of a crash dump is checked.
+	crashDumpPollInterval = 30 * time.Second
+)
+
+// crashDumpInProgress reports whether a crash dump of the Server is being collected.
+func crashDumpInProgress(server *metalv1alpha1.Server) bool {
+	return server.Status.CrashDump != nil && server.Status.CrashDump.State == metalv1alpha1.CrashDumpStateCapturing
+}
+
+// crashDumpBootConfigName is the name of the boot configuration of the capture environment of the Server.
+func crashDumpBootConfigName(server *metalv1alpha1.Server) string {
+	return server.Name + "-crash-dump"
+}
+
+// crashDumpURL returns the location of the crash dump in the object storage.
+func (r *ServerReconciler) crashDumpURL(server *metalv1alpha1.Server, start time.Time) (string, error) {
+	u, err := url.Parse(r.CrashDumpUploadURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse crash dump upload URL: %w", err)
+	}
+	return u.JoinPath(server.Name, fmt.Sprintf("%s.dump", start.UTC().Format("20060102T150405Z"))).String(), nil
+}
+
+// startCrashDump sends an NMI to the Server and starts the collection of its crash dump. The BMC is asked to capture
+// the dump if it supports it. Otherwise, the Server is booted into the capture environment once the crashed
+// operating system had time to write its dump.
+func (r *ServerReconciler) startCrashDump(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) (bool, time.Duration, error) {
+	if r.CrashDumpUploadURL == "" {
+		return r.finishOperation(ctx, server, metalv1alpha1.OperationStateFailed, "Crash dumps are not configured")
+	}
+	if _, err := selectResetType(server, redfish.NmiResetType); err != nil {
+		return r.finishOperation(ctx, server, metalv1alpha1.OperationStateFailed, err.Error())
+	}
+
+	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.bmcOptions(server))
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to create BMC client: %w", err)
+	}
+	defer bmcClient.Logout()
+
+	now := metav1.Now()
+	if err := bmcClient.Reset(ctx, server.Spec.SystemUUID, redfish.NmiResetType); err != nil {
+		return false, 0, fmt.Errorf("failed to send NMI: %w", err)
+	}
+	log.V(1).Info("Sent NMI to Server for crash dump")
+
+	crashDump := &metalv1alpha1.ServerCrashDump{
+		State:     metalv1alpha1.CrashDumpStateCapturing,
+		Source:    metalv1alpha1.CrashDumpSourceBMC,
+		StartTime: now,
+		Message:   "Waiting for the BMC to capture the crash dump",
+	}
+	if crashDump.URL, err = r.crashDumpURL(server, now.Time); err != nil {
+		return false, 0, err
+	}
+	if err := bmcClient.CollectCrashDump(ctx, server.Spec.SystemUUID); err != nil {
+		if !errors.Is(err, bmc.ErrCrashDumpNotSupported) {
+			return false, 0, err
+		}
+		if r.CrashDumpCaptureImage == "" {
+			return r.finishOperation(ctx, server, metalv1alpha1.OperationStateFailed,
+				"NMI sent, but the BMC does not capture crash dumps and no capture environment is configured")
+		}
+		crashDump.Source = metalv1alpha1.CrashDumpSourceCaptureEnvironment
+		crashDump.Message = "Waiting for the operating system to write the crash dump"
+	}
+
+	serverBase := server.DeepCopy()
+	server.Status.CrashDump = crashDump
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return false, 0, fmt.Errorf("failed to patch crash dump status: %w", err)
+	}
+	if err := recordOperationResult(ctx, r.Client, server, metalv1alpha1.OperationStateInProgress, crashDump.Message); err != nil {
+		return false, 0, err
+	}
+	return false, crashDumpPollInterval, nil
+}
+
+// progressCrashDump advances the collection of the crash dump of the Server until the dump is in the object storage
+// or the CrashDumpTimeout has passed.
+func (r *ServerReconciler) progressCrashDump(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) (bool, time.Duration, error) {
+	crashDump := server.Status.CrashDump
+	timeout := r.CrashDumpTimeout
+	if timeout == 0 {
+		timeout = defaultCrashDumpTimeout
+	}
+	if time.Since(crashDump.StartTime.Time) > timeout {
+		return r.completeCrashDump(ctx, log, server, metalv1alpha1.CrashDumpStateFailed,
+			fmt.Sprintf("No crash dump has been collected within %s", timeout))
+	}
+
+	switch crashDump.Source {
+	case metalv1alpha1.CrashDumpSourceBMC:
+		uploaded, err := r.uploadBMCCrashDump(ctx, server)
+		if err != nil {
+			return false, 0, err
+		}
+		if !uploaded {
+			return false, crashDumpPollInterval, nil
+		}
+	case metalv1alpha1.CrashDumpSourceCaptureEnvironment:
+		if crashDump.PreviousBootConfigurationRef == nil {
+			if remaining := crashDumpSettleTime - time.Since(crashDump.StartTime.Time); remaining > 0 {
+				return false, remaining, nil
+			}
+			if err := r.bootCaptureEnvironment(ctx, log, server); err != nil {
+				return false, 0, fmt.Errorf("failed to boot capture environment: %w", err)
+			}
+			return false, crashDumpPollInterval, nil
+		}
+		uploaded, err := crashDumpExists(ctx, crashDump.URL)
+		if err != nil {
+			return false, 0, err
+		}
+		if !uploaded {
+			return false, crashDumpPollInterval, nil
+		}
+	}
+	return r.completeCrashDump(ctx, log, server, metalv1alpha1.CrashDumpStateCompleted, "Crash dump uploaded to "+crashDump.URL)
+}
+
+// uploadBMCCrashDump uploads the crash dump captured by the BMC to the object storage. It reports false if the BMC
+// did not capture the dump yet.
+func (r *ServerReconciler) uploadBMCCrashDump(ctx context.Context, server *metalv1alpha1.Server) (bool, error) {
+	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.bmcOptions(server))
+	if err != nil {
+		return false, fmt.Errorf("failed to create BMC client: %w", err)
+	}
+	defer bmcClient.Logout()
+
+	dump, err := bmcClient.GetCrashDump(ctx, server.Spec.SystemUUID, server.Status.CrashDump.StartTime.Time)
+	if err != nil || dump == nil {
+		return false, err
+	}
+	content, err := bmcClient.DownloadCrashDump(ctx, *dump)
+	if err != nil {
+		return false, err
+	}
+	defer content.Close() // nolint: errcheck
+
+	if planned := bmc.PlannedWritesFrom(ctx); planned != nil {
+		planned.Record("upload crash dump to " + server.Status.CrashDump.URL)
+		return true, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, server.Status.CrashDump.URL, content)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to upload crash dump: %w", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return false, fmt.Errorf("failed to upload crash dump: %s", resp.Status)
+	}
+	return true, nil
+}
+
+// crashDumpExists reports whether the capture environment uploaded the crash dump to the object storage.
+func crashDumpExists(ctx context.Context, dumpURL string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, dumpURL, nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to check crash dump: %w", err)
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode == http.StatusOK, nil
+}
+
+// bootCaptureEnvironment replaces the boot configuration of the Server with the capture environment, which runs the
+// CrashDumpCaptureImage, and restarts the Server into it.
+func (r *ServerReconciler) bootCaptureEnvironment(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) error {
+	image, err := r.discoveryImageForServer(ctx, server)
+	if err != nil {
+		return err
+	}
+	bootConfig := &metalv1alpha1.ServerBootConfiguration{}
+	bootConfig.Name = crashDumpBootConfigName(server)
+	bootConfig.Namespace = r.ManagerNamespace
+	if _, err := controllerutil.CreateOrPatch(ctx, r.Client, bootConfig, func() error {
+		metav1.SetMetaDataAnnotation(&bootConfig.ObjectMeta, InternalAnnotationTypeKeyName, InternalAnnotationTypeValue)
+		bootConfig.Spec.ServerRef = v1.LocalObjectReference{Name: server.Name}
+		bootConfig.Spec.IgnitionSecretRef = &v1.LocalObjectReference{Name: bootConfig.Name}
+		bootConfig.Spec.Image = image
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to create or patch ServerBootConfiguration: %w", err)
+	}
+	flags := fmt.Sprintf("--upload-url=%s --server-uuid=%s", server.Status.CrashDump.URL, server.Spec.SystemUUID)
+	if err := r.applyIgnitionForBootConfig(ctx, log, bootConfig, r.CrashDumpCaptureImage, flags); err != nil {
+		return err
+	}
+
+	statusBase := server.DeepCopy()
+	previous := &v1.ObjectReference{}
+	if server.Spec.BootConfigurationRef != nil {
+		previous = server.Spec.BootConfigurationRef.DeepCopy()
+	}
+	server.Status.CrashDump.PreviousBootConfigurationRef = previous
+	server.Status.CrashDump.Message = "Waiting for the capture environment to upload the crash dump"
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(statusBase)); err != nil {
+		return fmt.Errorf("failed to patch crash dump status: %w", err)
+	}
+	if err := r.ensureServerBootConfigRef(ctx, server, bootConfig); err != nil {
+		return err
+	}
+
+	statusBase = server.DeepCopy()
+	if err := r.pxeRebootServer(ctx, server, "crash dump"); err != nil {
+		return err
+	}
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(statusBase)); err != nil {
+		return fmt.Errorf("failed to patch server power conditions: %w", err)
+	}
+	log.V(1).Info("Booted Server into crash dump capture environment")
+	return nil
+}
+
+// completeCrashDump records the result of the crash dump, restores the boot configuration the Server had before the
+// capture environment and restarts the Server into it.
+func (r *ServerReconciler) completeCrashDump(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server, state metalv1alpha1.CrashDumpState, message string) (bool, time.Duration, error) {
+	if previous := server.Status.CrashDump.PreviousBootConfigurationRef; previous != nil {
+		serverBase := server.DeepCopy()
+		server.Spec.BootConfigurationRef = nil
+		if previous.Name != "" {
+			server.Spec.BootConfigurationRef = previous.DeepCopy()
+		}
+		if err := r.Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+			return false, 0, fmt.Errorf("failed to restore boot configuration: %w", err)
+		}
+		bootConfig := &metalv1alpha1.ServerBootConfiguration{}
+		bootConfig.Name = crashDumpBootConfigName(server)
+		bootConfig.Namespace = r.ManagerNamespace
+		if err := r.Delete(ctx, bootConfig); err != nil && !apierrors.IsNotFound(err) {
+			return false, 0, fmt.Errorf("failed to delete capture environment boot configuration: %w", err)
+		}
+		if server.Spec.BootConfigurationRef != nil {
+			statusBase := server.DeepCopy()
+			if err := r.pxeRebootServer(ctx, server, "crash dump"); err != nil {
+				return false, 0, err
+			}
+			if err := r.Status().Patch(ctx, server, client.MergeFrom(statusBase)); err != nil {
+				return false, 0, fmt.Errorf("failed to patch server power conditions: %w", err)
+			}
+		}
+	}
+
+	serverBase := server.DeepCopy()
+	now := metav1.Now()
+	server.Status.CrashDump.State = state
+	server.Status.CrashDump.CompletionTime = &now
+	server.Status.CrashDump.Message = message
+	server.Status.CrashDump.PreviousBootConfigurationRef = nil
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return false, 0, fmt.Errorf("failed to patch crash dump status: %w", err)
+	}
+	log.V(1).Info("Completed crash dump", "State", state, "Message", message)
+
+	operationState := metalv1alpha1.OperationStateSucceeded
+	if state == metalv1alpha1.CrashDumpStateFailed {
+		operationState = metalv1alpha1.OperationStateFailed
+	}
+	return r.finishOperation(ctx, server, operationState, message)
+}
+
+// finishOperation records the final result of the operation of the Server and removes its operation annotations.
+func (r *ServerReconciler) finishOperation(ctx context.Context, server *metalv1alpha1.Server, state metalv1alpha1.OperationState, message string) (bool, time.Duration, error) {
+	if err := recordOperationResult(ctx, r.Client, server, state, message); err != nil {
+		return false, 0, err
+	}
+	modified, err := r.removeOperationAnnotations(ctx, server)
+	return modified, 0, err
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/go-logr/logr"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+	"github.com/ironcore-dev/metal-operator/internal/features"
+)
+
+// dpuStatus converts a DPU reported by the BMC into the status of a DPU of the Server.
+func dpuStatus(dpu bmc.DPU) metalv1alpha1.DPUStatus {
+	return metalv1alpha1.DPUStatus{
+		Name:            path.Base(dpu.URI),
+		SystemURI:       dpu.URI,
+		Manufacturer:    dpu.Manufacturer,
+		Model:           dpu.Model,
+		SerialNumber:    dpu.SerialNumber,
+		FirmwareVersion: dpu.FirmwareVersion,
+		ManagementIP:    dpu.ManagementIP,
+		Mode:            dpuModeFromBMC(dpu.Mode),
+		PendingMode:     dpuModeFromBMC(dpu.PendingMode),
+	}
+}
+
+func dpuModeFromBMC(mode string) metalv1alpha1.DPUMode {
+	switch mode {
+	case bmc.DPUModeDPU:
+		return metalv1alpha1.DPUModeDPU
+	case bmc.DPUModeNIC:
+		return metalv1alpha1.DPUModeNIC
+	default:
+		return ""
+	}
+}
+
+func dpuModeToBMC(mode metalv1alpha1.DPUMode) string {
+	if mode == metalv1alpha1.DPUModeNIC {
+		return bmc.DPUModeNIC
+	}
+	return bmc.DPUModeDPU
+}
+
+// applyDPUModes switches the DPUs of the Server into the modes of its spec. As a mode switch disrupts the network
+// of the Server, it is only applied while the Server is not claimed and no firmware update is in progress.
+func (r *ServerReconciler) applyDPUModes(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) error {
+	if !r.FeatureGate.Enabled(features.DPUModeSwitching) {
+		return nil
+	}
+	var pending []metalv1alpha1.DPUStatus
+	for _, spec := range server.Spec.DPUs {
+		for _, status := range server.Status.DPUs {
+			if status.Name != spec.Name || status.Mode == spec.Mode || status.PendingMode == spec.Mode {
+				continue
+			}
+			status.PendingMode = spec.Mode
+			pending = append(pending, status)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	if server.Spec.ServerClaimRef != nil {
+		log.V(1).Info("Server is in use by a claim, deferring DPU mode switch")
+		return nil
+	}
+	busy, err := hasFirmwareUpdateInProgress(ctx, r.Client, server.Name, server)
+	if err != nil {
+		return err
+	}
+	if busy {
+		log.V(1).Info("Firmware update is in progress, deferring DPU mode switch")
+		return nil
+	}
+
+	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.bmcOptions(server))
+	if err != nil {
+		return fmt.Errorf("failed to create BMC client: %w", err)
+	}
+	defer bmcClient.Logout()
+	for _, dpu := range pending {
+		if err := bmcClient.SetDPUMode(ctx, dpu.SystemURI, dpuModeToBMC(dpu.PendingMode)); err != nil {
+			return fmt.Errorf("failed to switch mode of DPU %s: %w", dpu.Name, err)
+		}
+		log.V(1).Info("Switched DPU mode, which takes effect on the next power cycle", "DPU", dpu.Name, "Mode", dpu.PendingMode)
+	}
+	return nil
+}
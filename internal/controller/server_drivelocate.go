@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+)
+
+// defaultDriveLocateTimeout is the time the location indicator of a drive is turned on if no DriveLocateTimeout is
+// configured.
+const defaultDriveLocateTimeout = 30 * time.Minute
+
+// reconcileDriveLocate turns the location indicator of the drive requested with the locate-drive annotation on, and
+// turns it off again once the annotation is removed or changed, or once the locate timed out. A timed out locate
+// removes the annotation. The returned duration is the time until the locate times out.
+func (r *ServerReconciler) reconcileDriveLocate(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) (time.Duration, error) {
+	driveName, requested := server.Annotations[metalv1alpha1.LocateDriveAnnotation]
+	located := server.Status.LocatedDrive
+	if !requested && located == nil {
+		return 0, nil
+	}
+	if requested && located != nil && located.Name == driveName {
+		if remaining := time.Until(located.ExpirationTime.Time); remaining > 0 {
+			return remaining, nil
+		}
+	}
+
+	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.bmcOptions(server))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create BMC client: %w", err)
+	}
+	defer bmcClient.Logout()
+
+	serverBase := server.DeepCopy()
+	if located != nil {
+		// the drive may have been pulled in the meantime, which turns its indicator off anyway
+		if err := bmcClient.SetDriveLocationIndicator(ctx, server.Spec.SystemUUID, located.Name, false); err != nil {
+			log.V(1).Info("Failed to turn off location indicator of drive", "Drive", located.Name, "Error", err.Error())
+		} else {
+			log.V(1).Info("Turned off location indicator of drive", "Drive", located.Name)
+		}
+		server.Status.LocatedDrive = nil
+
+		if requested && located.Name == driveName {
+			if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+				return 0, fmt.Errorf("failed to patch located drive: %w", err)
+			}
+			log.V(1).Info("Drive locate timed out", "Drive", driveName)
+			annotationBase := server.DeepCopy()
+			delete(server.Annotations, metalv1alpha1.LocateDriveAnnotation)
+			if err := r.Patch(ctx, server, client.MergeFrom(annotationBase)); err != nil {
+				return 0, fmt.Errorf("failed to remove locate-drive annotation: %w", err)
+			}
+			return 0, nil
+		}
+	}
+
+	var remaining time.Duration
+	if requested {
+		if err := bmcClient.SetDriveLocationIndicator(ctx, server.Spec.SystemUUID, driveName, true); err != nil {
+			return 0, fmt.Errorf("failed to turn on location indicator of drive %s: %w", driveName, err)
+		}
+		remaining = r.DriveLocateTimeout
+		if remaining <= 0 {
+			remaining = defaultDriveLocateTimeout
+		}
+		server.Status.LocatedDrive = &metalv1alpha1.LocatedDrive{
+			Name:           driveName,
+			ExpirationTime: metav1.NewTime(time.Now().Add(remaining)),
+		}
+		log.V(1).Info("Turned on location indicator of drive", "Drive", driveName, "Timeout", remaining)
+	}
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return 0, fmt.Errorf("failed to patch located drive: %w", err)
+	}
+	return remaining, nil
+}
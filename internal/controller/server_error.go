@@ -0,0 +1,287 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ServerConditionBMCReachable reports whether the BMC of the Server answered the latest status update.
+	ServerConditionBMCReachable = "BMCReachable"
+	// ServerConditionFirmwareUpdateFailed is True once a firmware update of a Reserved Server failed. The Server stays
+	// Reserved, so that its workload keeps running, and enters the Error state once it is released.
+	ServerConditionFirmwareUpdateFailed = "FirmwareUpdateFailed"
+
+	serverBMCReasonRequestsFailed    = "RequestsFailed"
+	serverBMCReasonRequestsSucceeded = "RequestsSucceeded"
+
+	// ServerErrorReasonBMCUnreachable is the error reason of Servers whose BMC failed for longer than the
+	// BMCFailureTimeout.
+	ServerErrorReasonBMCUnreachable = "BMCUnreachable"
+	// ServerErrorReasonFirmwareUpdateFailed is the error reason of Servers whose firmware update failed.
+	ServerErrorReasonFirmwareUpdateFailed = "FirmwareUpdateFailed"
+
+	serverErrorOperationDiscovery      = "Discovery"
+	serverErrorOperationStatusUpdate   = "StatusUpdate"
+	serverErrorOperationFirmwareUpdate = "FirmwareUpdate"
+
+	// serverErrorEventLogEntries is the number of event log entries collected for a Server in the Error state.
+	serverErrorEventLogEntries = 5
+)
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+
+// markServerError moves the Server into the Error state with the given diagnostics. Servers already in the Error
+// state keep the diagnostics of their first error.
+func markServerError(server *metalv1alpha1.Server, reason, operation, message string) {
+	if server.Status.State == metalv1alpha1.ServerStateError {
+		return
+	}
+	server.Status.ErrorDiagnostics = &metalv1alpha1.ServerErrorDiagnostics{
+		Reason:        reason,
+		Operation:     operation,
+		Message:       message,
+		PreviousState: server.Status.State,
+		Time:          metav1.Now(),
+	}
+	server.Status.State = metalv1alpha1.ServerStateError
+}
+
+// patchServerError moves the Server into the Error state with the given diagnostics.
+func patchServerError(ctx context.Context, c client.Client, server *metalv1alpha1.Server, reason, operation, message string) error {
+	if server.Status.State == metalv1alpha1.ServerStateError {
+		return nil
+	}
+	serverBase := server.DeepCopy()
+	markServerError(server, reason, operation, message)
+	if err := c.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return fmt.Errorf("failed to patch server status: %w", err)
+	}
+	return nil
+}
+
+// recordBMCStatus tracks whether the BMC of the Server answered the latest status update in the BMCReachable
+// condition. Servers whose BMC failed for longer than the BMCFailureTimeout are moved into the Error state.
+func (r *ServerReconciler) recordBMCStatus(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server, bmcErr error) error {
+	cond := meta.FindStatusCondition(server.Status.Conditions, ServerConditionBMCReachable)
+	failureTimeout := r.Settings().BMCFailureTimeout
+	serverBase := server.DeepCopy()
+	switch {
+	case bmcErr == nil:
+		if cond == nil || cond.Status == metav1.ConditionTrue {
+			return nil
+		}
+		meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:               ServerConditionBMCReachable,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: server.Generation,
+			Reason:             serverBMCReasonRequestsSucceeded,
+		})
+	case cond == nil || cond.Status == metav1.ConditionTrue:
+		meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:               ServerConditionBMCReachable,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: server.Generation,
+			Reason:             serverBMCReasonRequestsFailed,
+			Message:            bmcErr.Error(),
+		})
+	case failureTimeout > 0 && time.Since(cond.LastTransitionTime.Time) > failureTimeout &&
+		server.Status.State != metalv1alpha1.ServerStateError:
+		log.V(1).Info("BMC failed for too long, moving Server to error state", "Since", cond.LastTransitionTime)
+		markServerError(server, ServerErrorReasonBMCUnreachable, serverErrorOperationStatusUpdate,
+			fmt.Sprintf("BMC requests have been failing since %s: %v", cond.LastTransitionTime.Format(time.RFC3339), bmcErr))
+	default:
+		return nil
+	}
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return fmt.Errorf("failed to patch server status: %w", err)
+	}
+	return nil
+}
+
+// handleErrorState collects the event log of a Server which entered the Error state and stores the diagnostic
+// bundle in a ConfigMap. The Server stays in the Error state until an admin moves it out with the Rediscover or
+// ClearError operation.
+func (r *ServerReconciler) handleErrorState(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) (bool, error) {
+	diagnostics := server.Status.ErrorDiagnostics
+	if diagnostics == nil || diagnostics.BundleRef != nil {
+		return false, nil
+	}
+
+	bundle := map[string]string{}
+	eventLog, err := r.getEventLogEntries(ctx, server, serverErrorEventLogEntries)
+	if err != nil {
+		bundle["event-log-error"] = err.Error()
+	}
+	for key, value := range map[string]any{
+		"diagnostics.yaml": diagnostics,
+		"conditions.yaml":  server.Status.Conditions,
+		"event-log.yaml":   eventLog,
+	} {
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return false, fmt.Errorf("failed to marshal %s: %w", key, err)
+		}
+		bundle[key] = string(data)
+	}
+
+	configMap := &v1.ConfigMap{}
+	configMap.Namespace = r.ManagerNamespace
+	configMap.Name = serverDiagnosticsConfigMapName(server)
+	if _, err := controllerutil.CreateOrPatch(ctx, r.Client, configMap, func() error {
+		configMap.Data = bundle
+		return controllerutil.SetControllerReference(server, configMap, r.Scheme)
+	}); err != nil {
+		return false, fmt.Errorf("failed to apply diagnostic bundle: %w", err)
+	}
+	log.V(1).Info("Stored diagnostic bundle", "ConfigMap", client.ObjectKeyFromObject(configMap))
+
+	serverBase := server.DeepCopy()
+	server.Status.ErrorDiagnostics.EventLog = eventLog
+	server.Status.ErrorDiagnostics.BundleRef = &v1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Namespace:  configMap.Namespace,
+		Name:       configMap.Name,
+	}
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return false, fmt.Errorf("failed to patch server status: %w", err)
+	}
+	return false, nil
+}
+
+func (r *ServerReconciler) getEventLogEntries(ctx context.Context, server *metalv1alpha1.Server, limit int) ([]metalv1alpha1.ServerEventLogEntry, error) {
+	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.bmcOptions(server))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get BMC client: %w", err)
+	}
+	defer bmcClient.Logout()
+	entries, err := bmcClient.GetEventLogEntries(ctx, server.Spec.SystemUUID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event log entries: %w", err)
+	}
+	eventLog := make([]metalv1alpha1.ServerEventLogEntry, 0, len(entries))
+	for _, entry := range entries {
+		eventLog = append(eventLog, metalv1alpha1.ServerEventLogEntry{
+			Created:  entry.Created,
+			Severity: entry.Severity,
+			Message:  entry.Message,
+		})
+	}
+	return eventLog, nil
+}
+
+// leaveErrorState moves a Server out of the Error state on the Rediscover or ClearError operation and removes its
+// diagnostic bundle. ClearError also clears the failed firmware update of a Reserved Server.
+func (r *ServerReconciler) leaveErrorState(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server, operation string) error {
+	if server.Status.State != metalv1alpha1.ServerStateError {
+		if operation == metalv1alpha1.OperationAnnotationClearError &&
+			meta.FindStatusCondition(server.Status.Conditions, ServerConditionFirmwareUpdateFailed) != nil {
+			serverBase := server.DeepCopy()
+			meta.RemoveStatusCondition(&server.Status.Conditions, ServerConditionFirmwareUpdateFailed)
+			if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+				return fmt.Errorf("failed to patch server status: %w", err)
+			}
+			log.V(1).Info("Cleared failed firmware update of the Server")
+			return nil
+		}
+		log.V(1).Info("Ignoring operation for Server which is not in the error state", "Operation", operation)
+		return nil
+	}
+
+	if diagnostics := server.Status.ErrorDiagnostics; diagnostics != nil && diagnostics.BundleRef != nil {
+		configMap := &v1.ConfigMap{}
+		configMap.Namespace = diagnostics.BundleRef.Namespace
+		configMap.Name = diagnostics.BundleRef.Name
+		if err := r.Delete(ctx, configMap); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete diagnostic bundle: %w", err)
+		}
+	}
+
+	serverBase := server.DeepCopy()
+	state := metalv1alpha1.ServerStateInitial
+	if operation == metalv1alpha1.OperationAnnotationClearError && server.Status.ErrorDiagnostics != nil {
+		switch server.Status.ErrorDiagnostics.PreviousState {
+		case metalv1alpha1.ServerStateAvailable, metalv1alpha1.ServerStateReserved:
+			state = metalv1alpha1.ServerStateAvailable
+			if server.Spec.ServerClaimRef != nil {
+				state = metalv1alpha1.ServerStateReserved
+			}
+		}
+	}
+	if state == metalv1alpha1.ServerStateInitial {
+		server.Status.DiscoveryAttempts = 0
+		meta.RemoveStatusCondition(&server.Status.Conditions, ServerConditionDiscoveryFailed)
+	}
+	server.Status.State = state
+	server.Status.ErrorDiagnostics = nil
+	meta.RemoveStatusCondition(&server.Status.Conditions, ServerConditionBMCReachable)
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return fmt.Errorf("failed to patch server status: %w", err)
+	}
+	log.V(1).Info("Moved Server out of the error state", "Operation", operation, "State", state)
+	return nil
+}
+
+func serverDiagnosticsConfigMapName(server *metalv1alpha1.Server) string {
+	return fmt.Sprintf("server-%s-diagnostics", server.Name)
+}
+
+// markServerFirmwareUpdateFailed moves the Server of a failed firmware update into the Error state. Reserved Servers
+// only get the FirmwareUpdateFailed condition and enter the Error state once they are released.
+func markServerFirmwareUpdateFailed(ctx context.Context, c client.Client, serverName, kind, name string) error {
+	server := &metalv1alpha1.Server{}
+	if err := c.Get(ctx, client.ObjectKey{Name: serverName}, server); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	message := fmt.Sprintf("%s %s failed", kind, name)
+	if server.Status.State != metalv1alpha1.ServerStateReserved {
+		return patchServerError(ctx, c, server, ServerErrorReasonFirmwareUpdateFailed, serverErrorOperationFirmwareUpdate, message)
+	}
+	serverBase := server.DeepCopy()
+	if !meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:               ServerConditionFirmwareUpdateFailed,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: server.Generation,
+		Reason:             ServerErrorReasonFirmwareUpdateFailed,
+		Message:            message,
+	}) {
+		return nil
+	}
+	if err := c.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return fmt.Errorf("failed to patch server status: %w", err)
+	}
+	return nil
+}
+
+// handleReleasedServerError moves a released Server whose firmware update failed while it was Reserved into the
+// Error state. It returns whether the Server has been modified.
+func (r *ServerReconciler) handleReleasedServerError(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) (bool, error) {
+	cond := meta.FindStatusCondition(server.Status.Conditions, ServerConditionFirmwareUpdateFailed)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		return false, nil
+	}
+	serverBase := server.DeepCopy()
+	meta.RemoveStatusCondition(&server.Status.Conditions, ServerConditionFirmwareUpdateFailed)
+	markServerError(server, ServerErrorReasonFirmwareUpdateFailed, serverErrorOperationFirmwareUpdate, cond.Message)
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return false, fmt.Errorf("failed to patch server status: %w", err)
+	}
+	log.V(1).Info("Moved released Server with a failed firmware update into the error state")
+	return true, nil
+}
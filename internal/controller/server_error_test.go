@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+)
+
+var _ = Describe("Server Error State", func() {
+	It("should record the diagnostics of the first error", func() {
+		server := &metalv1alpha1.Server{
+			Status: metalv1alpha1.ServerStatus{
+				State: metalv1alpha1.ServerStateAvailable,
+			},
+		}
+
+		By("Marking the server as failed")
+		markServerError(server, ServerErrorReasonFirmwareUpdateFailed, serverErrorOperationFirmwareUpdate, "failed")
+		Expect(server.Status.State).To(Equal(metalv1alpha1.ServerStateError))
+		Expect(server.Status.ErrorDiagnostics).To(SatisfyAll(
+			HaveField("Reason", ServerErrorReasonFirmwareUpdateFailed),
+			HaveField("Operation", serverErrorOperationFirmwareUpdate),
+			HaveField("Message", "failed"),
+			HaveField("PreviousState", metalv1alpha1.ServerStateAvailable),
+		))
+
+		By("Ensuring that a further error keeps the first diagnostics")
+		markServerError(server, ServerErrorReasonBMCUnreachable, serverErrorOperationStatusUpdate, "unreachable")
+		Expect(server.Status.ErrorDiagnostics).To(SatisfyAll(
+			HaveField("Reason", ServerErrorReasonFirmwareUpdateFailed),
+			HaveField("PreviousState", metalv1alpha1.ServerStateAvailable),
+		))
+	})
+})
+
+var _ = Describe("Server Firmware Update Failure", func() {
+	ns := SetupTest()
+
+	It("Should keep a Reserved Server Reserved and report the failure on its claim", func(ctx SpecContext) {
+		registerSimulator("10.30.0.12:8000", "38947555-7742-3448-3784-823347823845")
+		server := createPausedServer(ctx, "10.30.0.12", "38947555-7742-3448-3784-823347823845")
+		Eventually(UpdateStatus(server, func() {
+			server.Status.State = metalv1alpha1.ServerStateReserved
+			server.Status.PowerState = metalv1alpha1.ServerOffPowerState
+		})).Should(Succeed())
+
+		By("Binding a claim to the Server")
+		claim := &metalv1alpha1.ServerClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name, GenerateName: "test-"},
+			Spec: metalv1alpha1.ServerClaimSpec{
+				Power:     metalv1alpha1.PowerOff,
+				ServerRef: &v1.LocalObjectReference{Name: server.Name},
+				Image:     "foo:bar",
+			},
+		}
+		Expect(k8sClient.Create(ctx, claim)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, claim)
+		Eventually(Object(server)).Should(HaveField("Spec.ServerClaimRef.UID", claim.UID))
+		Eventually(Object(claim)).Should(HaveField("Status.Conditions", ContainElement(SatisfyAll(
+			HaveField("Type", ServerClaimConditionServerHealthy),
+			HaveField("Status", metav1.ConditionTrue),
+		))))
+
+		By("Failing a firmware update of the Server")
+		Expect(markServerFirmwareUpdateFailed(ctx, k8sClient, server.Name, "ComponentFirmware", "bios")).To(Succeed())
+		Eventually(Object(server)).Should(SatisfyAll(
+			HaveField("Status.State", metalv1alpha1.ServerStateReserved),
+			HaveField("Status.Conditions", ContainElement(SatisfyAll(
+				HaveField("Type", ServerConditionFirmwareUpdateFailed),
+				HaveField("Status", metav1.ConditionTrue),
+			))),
+		))
+		Eventually(Object(claim)).Should(HaveField("Status.Conditions", ContainElement(SatisfyAll(
+			HaveField("Type", ServerClaimConditionServerHealthy),
+			HaveField("Status", metav1.ConditionFalse),
+			HaveField("Reason", ServerErrorReasonFirmwareUpdateFailed),
+			HaveField("Message", "ComponentFirmware bios failed"),
+		))))
+
+		By("Moving the Server into the Error state once it is released")
+		reconciler := &ServerReconciler{Client: k8sClient}
+		Expect(reconciler.handleReleasedServerError(ctx, GinkgoLogr, server)).To(BeTrue())
+		Expect(Object(server)()).To(SatisfyAll(
+			HaveField("Status.State", metalv1alpha1.ServerStateError),
+			HaveField("Status.ErrorDiagnostics.Reason", ServerErrorReasonFirmwareUpdateFailed),
+			HaveField("Status.ErrorDiagnostics.Message", "ComponentFirmware bios failed"),
+			HaveField("Status.ErrorDiagnostics.PreviousState", metalv1alpha1.ServerStateReserved),
+			HaveField("Status.Conditions", Not(ContainElement(HaveField("Type", ServerConditionFirmwareUpdateFailed)))),
+		))
+	})
+})
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stmcginnis/gofish/common"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+)
+
+const (
+	// ServerConditionPowerRedundancyLost reports whether the power supplies of the Server have lost their
+	// redundancy, so that the failure of another power supply powers the Server off.
+	ServerConditionPowerRedundancyLost = "PowerRedundancyLost"
+
+	serverPowerRedundancyReasonLost      = "RedundancyLost"
+	serverPowerRedundancyReasonRedundant = "Redundant"
+)
+
+// setChassisFRUStatus sets the power supplies, fans and redundancy groups of the status of the Server from the FRUs
+// reported by the BMC.
+func setChassisFRUStatus(server *metalv1alpha1.Server, frus bmc.ChassisFRUs) {
+	server.Status.PowerSupplies = nil
+	for _, psu := range frus.PowerSupplies {
+		server.Status.PowerSupplies = append(server.Status.PowerSupplies, metalv1alpha1.PowerSupply{
+			Name:            psu.Name,
+			Manufacturer:    psu.Manufacturer,
+			Model:           psu.Model,
+			SerialNumber:    psu.SerialNumber,
+			PartNumber:      psu.PartNumber,
+			FirmwareVersion: psu.FirmwareVersion,
+			CapacityWatts:   int32(psu.PowerCapacityWatts),
+			State:           string(psu.State),
+			Health:          string(psu.Health),
+		})
+	}
+	server.Status.Fans = nil
+	for _, fan := range frus.Fans {
+		server.Status.Fans = append(server.Status.Fans, metalv1alpha1.Fan{
+			Name:         fan.Name,
+			Manufacturer: fan.Manufacturer,
+			Model:        fan.Model,
+			SerialNumber: fan.SerialNumber,
+			PartNumber:   fan.PartNumber,
+			State:        string(fan.State),
+			Health:       string(fan.Health),
+		})
+	}
+	server.Status.Redundancy = nil
+	for _, group := range frus.PowerRedundancy {
+		server.Status.Redundancy = append(server.Status.Redundancy, redundancyGroupStatus(group, metalv1alpha1.RedundancyGroupTypePowerSupply))
+	}
+	for _, group := range frus.FanRedundancy {
+		server.Status.Redundancy = append(server.Status.Redundancy, redundancyGroupStatus(group, metalv1alpha1.RedundancyGroupTypeFan))
+	}
+}
+
+func redundancyGroupStatus(group bmc.Redundancy, groupType metalv1alpha1.RedundancyGroupType) metalv1alpha1.RedundancyGroup {
+	return metalv1alpha1.RedundancyGroup{
+		Name:            group.Name,
+		Type:            groupType,
+		Mode:            string(group.Mode),
+		MinNumNeeded:    int32(group.MinNumNeeded),
+		MaxNumSupported: int32(group.MaxNumSupported),
+		State:           string(group.State),
+		Health:          string(group.Health),
+	}
+}
+
+// setPowerRedundancyCondition sets the PowerRedundancyLost condition of the Server from the power supplies in its
+// status and reports whether the condition has changed. Redundancy is lost if a redundancy group of the power
+// supplies is not healthy. BMCs which do not report redundancy groups lose redundancy once one of several power
+// supplies is not healthy. The condition is only maintained for servers which can be redundant at all.
+func setPowerRedundancyCondition(server *metalv1alpha1.Server) bool {
+	if len(server.Status.PowerSupplies) == 0 {
+		return false
+	}
+	var lost []string
+	hasGroups := false
+	for _, group := range server.Status.Redundancy {
+		if group.Type != metalv1alpha1.RedundancyGroupTypePowerSupply {
+			continue
+		}
+		hasGroups = true
+		if fruUnhealthy(group.Health) {
+			lost = append(lost, group.Name)
+		}
+	}
+	if !hasGroups {
+		if len(server.Status.PowerSupplies) < 2 {
+			return false
+		}
+		for _, psu := range server.Status.PowerSupplies {
+			if psu.State == string(common.AbsentState) || fruUnhealthy(psu.Health) {
+				lost = append(lost, psu.Name)
+			}
+		}
+	}
+
+	if len(lost) == 0 {
+		return meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:               ServerConditionPowerRedundancyLost,
+			Status:             metav1.ConditionFalse,
+			Reason:             serverPowerRedundancyReasonRedundant,
+			Message:            "The power supplies are redundant.",
+			ObservedGeneration: server.Generation,
+		})
+	}
+	return meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:               ServerConditionPowerRedundancyLost,
+		Status:             metav1.ConditionTrue,
+		Reason:             serverPowerRedundancyReasonLost,
+		Message:            fmt.Sprintf("Power redundancy is lost: %s.", strings.Join(lost, ", ")),
+		ObservedGeneration: server.Generation,
+	})
+}
+
+func fruUnhealthy(health string) bool {
+	return health == string(common.WarningHealth) || health == string(common.CriticalHealth)
+}
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stmcginnis/gofish/redfish"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+)
+
+const (
+	// ServerConditionGracefulRestart is true while a graceful restart requested through the operation annotation
+	// is in progress. Its reason is the step the restart is in.
+	ServerConditionGracefulRestart = "GracefulRestart"
+
+	gracefulRestartReasonShuttingDown    = "ShuttingDown"
+	gracefulRestartReasonForcingPowerOff = "ForcingPowerOff"
+	gracefulRestartReasonPoweringOn      = "PoweringOn"
+	gracefulRestartReasonCompleted       = "Completed"
+	gracefulRestartReasonFailed          = "Failed"
+)
+
+// gracefulRestartInProgress reports whether a graceful restart of the Server is in progress.
+func gracefulRestartInProgress(server *metalv1alpha1.Server) bool {
+	return meta.IsStatusConditionTrue(server.Status.Conditions, ServerConditionGracefulRestart)
+}
+
+// startGracefulRestart requests the graceful shutdown of the operating system of the Server. The restart is
+// advanced by progressGracefulRestart in later reconciliations, so that the reconciliation does not block while the
+// operating system shuts down.
+func (r *ServerReconciler) startGracefulRestart(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) (bool, time.Duration, error) {
+	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.bmcOptions(server))
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to create BMC client: %w", err)
+	}
+	defer bmcClient.Logout()
+
+	if err := bmcClient.PowerOff(ctx, server.Spec.SystemUUID); err != nil {
+		return false, 0, fmt.Errorf("failed to power off server: %w", err)
+	}
+	log.V(1).Info("Requested graceful shutdown of Server for restart")
+	message := "Waiting for the operating system to shut down"
+	if err := r.setGracefulRestartStep(ctx, server, metav1.ConditionTrue, gracefulRestartReasonShuttingDown, message); err != nil {
+		return false, 0, err
+	}
+	if err := recordOperationResult(ctx, r.Client, server, metalv1alpha1.OperationStateInProgress, message); err != nil {
+		return false, 0, err
+	}
+	return false, powerPollingInterval(r.bmcOptions(server)), nil
+}
+
+// progressGracefulRestart powers the Server on once it is off, and completes the restart once it is on again. If
+// the operating system does not shut down within the power polling timeout, the Server is forcefully powered off
+// when EnforcePowerOff is set, and the restart fails otherwise.
+func (r *ServerReconciler) progressGracefulRestart(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) (bool, time.Duration, error) {
+	step := meta.FindStatusCondition(server.Status.Conditions, ServerConditionGracefulRestart)
+	options := r.bmcOptions(server)
+	timeout := options.PowerPollingTimeout
+	if timeout == 0 {
+		timeout = bmc.DefaultPowerPollingTimeout
+	}
+	timedOut := time.Since(step.LastTransitionTime.Time) > timeout
+	pollInterval := powerPollingInterval(options)
+
+	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, options)
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to create BMC client: %w", err)
+	}
+	defer bmcClient.Logout()
+
+	systemInfo, err := bmcClient.GetSystemInfo(ctx, server.Spec.SystemUUID)
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to get system info: %w", err)
+	}
+
+	switch step.Reason {
+	case gracefulRestartReasonShuttingDown, gracefulRestartReasonForcingPowerOff:
+		if systemInfo.PowerState != redfish.OffPowerState {
+			if !timedOut {
+				return false, pollInterval, nil
+			}
+			if step.Reason == gracefulRestartReasonForcingPowerOff || !r.EnforcePowerOff {
+				return r.failGracefulRestart(ctx, server, fmt.Sprintf("Server did not power off within %s", timeout))
+			}
+			log.V(1).Info("Server did not shut down gracefully, forcing power off")
+			if err := bmcClient.ForcePowerOff(ctx, server.Spec.SystemUUID); err != nil {
+				return false, 0, fmt.Errorf("failed to power off server: %w", err)
+			}
+			return false, pollInterval, r.setGracefulRestartStep(ctx, server, metav1.ConditionTrue,
+				gracefulRestartReasonForcingPowerOff, "Waiting for the forced power off")
+		}
+		if err := bmcClient.PowerOn(ctx, server.Spec.SystemUUID); err != nil {
+			return false, 0, fmt.Errorf("failed to power on server: %w", err)
+		}
+		log.V(1).Info("Powered on Server for restart")
+		return false, pollInterval, r.setGracefulRestartStep(ctx, server, metav1.ConditionTrue,
+			gracefulRestartReasonPoweringOn, "Waiting for the server to power on")
+	default:
+		if systemInfo.PowerState != redfish.OnPowerState {
+			if !timedOut {
+				return false, pollInterval, nil
+			}
+			return r.failGracefulRestart(ctx, server, fmt.Sprintf("Server did not power on within %s", timeout))
+		}
+	}
+
+	serverBase := server.DeepCopy()
+	setGracefulRestartCondition(server, metav1.ConditionFalse, gracefulRestartReasonCompleted, "Server has been restarted")
+	setPowerCycleRequested(server, redfish.GracefulRestartResetType, fmt.Sprintf("annotation %s", metalv1alpha1.OperationAnnotation))
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return false, 0, fmt.Errorf("failed to patch server power conditions: %w", err)
+	}
+	log.V(1).Info("Completed graceful restart of Server")
+	return r.finishOperation(ctx, server, metalv1alpha1.OperationStateSucceeded, "")
+}
+
+// powerPollingInterval returns the interval in which the power state of a Server is checked with the options.
+func powerPollingInterval(options bmc.BMCOptions) time.Duration {
+	if options.PowerPollingInterval > 0 {
+		return options.PowerPollingInterval
+	}
+	return bmc.DefaultPowerPollingInterval
+}
+
+func (r *ServerReconciler) failGracefulRestart(ctx context.Context, server *metalv1alpha1.Server, message string) (bool, time.Duration, error) {
+	if err := r.setGracefulRestartStep(ctx, server, metav1.ConditionFalse, gracefulRestartReasonFailed, message); err != nil {
+		return false, 0, err
+	}
+	return r.finishOperation(ctx, server, metalv1alpha1.OperationStateFailed, message)
+}
+
+func (r *ServerReconciler) setGracefulRestartStep(ctx context.Context, server *metalv1alpha1.Server, status metav1.ConditionStatus, reason, message string) error {
+	serverBase := server.DeepCopy()
+	setGracefulRestartCondition(server, status, reason, message)
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return fmt.Errorf("failed to patch graceful restart condition: %w", err)
+	}
+	return nil
+}
+
+// setGracefulRestartCondition sets the GracefulRestart condition of the Server. The condition is recreated on every
+// step, so that its LastTransitionTime marks the start of the step.
+func setGracefulRestartCondition(server *metalv1alpha1.Server, status metav1.ConditionStatus, reason, message string) {
+	meta.RemoveStatusCondition(&server.Status.Conditions, ServerConditionGracefulRestart)
+	meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:               ServerConditionGracefulRestart,
+		Status:             status,
+		ObservedGeneration: server.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
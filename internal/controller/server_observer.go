@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+)
+
+const (
+	// ServerConditionDrifted reports whether the state observed on the BMC of a Server differs from its spec. It
+	// is only maintained in observer mode, in which the manager does not converge the Server to its spec.
+	ServerConditionDrifted = "Drifted"
+
+	serverDriftReasonInSync  = "InSync"
+	serverDriftReasonDrifted = "Drifted"
+)
+
+// observe updates the status of the Server from its BMC and reports its drift from the spec in observer mode,
+// without changing the state of the BMC or of the Server.
+func (r *ServerReconciler) observe(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) (ctrl.Result, error) {
+	if warmUpDelay, err := r.pollServerStatus(ctx, log, server); err != nil || warmUpDelay > 0 {
+		return ctrl.Result{RequeueAfter: warmUpDelay}, err
+	}
+
+	serverBase := server.DeepCopy()
+	if r.setDriftCondition(server) {
+		if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to patch Server conditions: %w", err)
+		}
+	}
+	log.V(1).Info("Observed Server")
+	return ctrl.Result{RequeueAfter: resyncAfter(server, r.ResyncInterval)}, nil
+}
+
+// setDriftCondition reports the drift of the Server from its spec in the Drifted condition. It reports whether the
+// condition changed.
+func (r *ServerReconciler) setDriftCondition(server *metalv1alpha1.Server) bool {
+	condition := metav1.Condition{
+		Type:               ServerConditionDrifted,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: server.Generation,
+		Reason:             serverDriftReasonInSync,
+		Message:            "The observed state matches the spec",
+	}
+	if drift := serverDrift(server); len(drift) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = serverDriftReasonDrifted
+		condition.Message = strings.Join(drift, "; ")
+	}
+	return meta.SetStatusCondition(&server.Status.Conditions, condition)
+}
+
+// serverDrift returns the differences between the spec of the Server and the state observed on its BMC. BIOS
+// settings read from Secrets are masked in the status and therefore not compared.
+func serverDrift(server *metalv1alpha1.Server) []string {
+	var drift []string
+	switch {
+	case server.Spec.Power == metalv1alpha1.PowerOn && server.Status.PowerState == metalv1alpha1.ServerOffPowerState,
+		server.Spec.Power == metalv1alpha1.PowerOff && server.Status.PowerState == metalv1alpha1.ServerOnPowerState:
+		drift = append(drift, fmt.Sprintf("power state is %s instead of %s", server.Status.PowerState, server.Spec.Power))
+	}
+	for _, bios := range server.Spec.BIOS {
+		if bios.Version != server.Status.BIOS.Version {
+			continue
+		}
+		var settings []string
+		for key, value := range bios.Settings {
+			if server.Status.BIOS.Settings[key] != value {
+				settings = append(settings, key)
+			}
+		}
+		if len(settings) > 0 {
+			slices.Sort(settings)
+			drift = append(drift, fmt.Sprintf("BIOS settings %s differ", strings.Join(settings, ", ")))
+		}
+	}
+	return drift
+}
@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import "time"
+
+// ServerSettings are the settings of the ServerReconciler which may be changed while the manager is running.
+type ServerSettings struct {
+	DiscoveryTimeout           time.Duration
+	MaxDiscoveryAttempts       int
+	RediscoveryInterval        time.Duration
+	MaxConcurrentRediscoveries int
+	BootVerificationTimeout    time.Duration
+	MaxBootRetries             int
+	BootOverrideCheckDelay     time.Duration
+	BMCFailureTimeout          time.Duration
+}
+
+// UpdateSettings replaces the settings the ServerReconciler was created with. Reconciliations in progress keep
+// the settings they have started with.
+func (r *ServerReconciler) UpdateSettings(settings ServerSettings) {
+	r.updatedSettings.Store(&settings)
+}
+
+// Settings returns the current settings of the ServerReconciler.
+func (r *ServerReconciler) Settings() ServerSettings {
+	if settings := r.updatedSettings.Load(); settings != nil {
+		return *settings
+	}
+	return ServerSettings{
+		DiscoveryTimeout:           r.DiscoveryTimeout,
+		MaxDiscoveryAttempts:       r.MaxDiscoveryAttempts,
+		RediscoveryInterval:        r.RediscoveryInterval,
+		MaxConcurrentRediscoveries: r.MaxConcurrentRediscoveries,
+		BootVerificationTimeout:    r.BootVerificationTimeout,
+		MaxBootRetries:             r.MaxBootRetries,
+		BootOverrideCheckDelay:     r.BootOverrideCheckDelay,
+		BMCFailureTimeout:          r.BMCFailureTimeout,
+	}
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+)
+
+const (
+	// ServerConditionDegraded reports whether a drive of the Server has failed. It is cleared once the drive has
+	// been replaced, e.g. by a DriveReplacement.
+	ServerConditionDegraded = "Degraded"
+
+	serverDegradedReasonDriveFailed   = "DriveFailed"
+	serverDegradedReasonDrivesHealthy = "DrivesHealthy"
+)
+
+// storageStatus converts the storages reported by the BMC into the storages of the status of a Server.
+func storageStatus(storages []bmc.Storage) []metalv1alpha1.Storage {
+	var result []metalv1alpha1.Storage
+	for _, storage := range storages {
+		metalStorage := metalv1alpha1.Storage{
+			Name:  storage.Name,
+			State: metalv1alpha1.StorageState(storage.State),
+		}
+		for _, controller := range storage.Controllers {
+			metalStorage.Controllers = append(metalStorage.Controllers, metalv1alpha1.StorageController{
+				Name:            controller.Name,
+				Manufacturer:    controller.Manufacturer,
+				Model:           controller.Model,
+				FirmwareVersion: controller.FirmwareVersion,
+				SerialNumber:    controller.SerialNumber,
+			})
+		}
+		for _, drive := range storage.Drives {
+			metalDrive := metalv1alpha1.StorageDrive{
+				Name:            drive.Name,
+				Model:           drive.Model,
+				Vendor:          drive.Vendor,
+				SerialNumber:    drive.SerialNumber,
+				FirmwareVersion: drive.FirmwareVersion,
+				Capacity:        resource.NewQuantity(drive.SizeBytes, resource.BinarySI),
+				Type:            string(drive.Type),
+				State:           metalv1alpha1.StorageState(drive.State),
+				Health:          metalv1alpha1.StorageHealth(drive.Health),
+				MediaType:       drive.MediaType,
+				Bay:             drive.Bay,
+			}
+			if drive.Enclosure != nil {
+				metalDrive.Enclosure = &metalv1alpha1.StorageEnclosure{
+					Name:         drive.Enclosure.Name,
+					Model:        drive.Enclosure.Model,
+					SerialNumber: drive.Enclosure.SerialNumber,
+				}
+			}
+			metalStorage.Drives = append(metalStorage.Drives, metalDrive)
+		}
+		metalStorage.Volumes = make([]metalv1alpha1.StorageVolume, 0, len(storage.Volumes))
+		for _, volume := range storage.Volumes {
+			metalStorage.Volumes = append(metalStorage.Volumes, metalv1alpha1.StorageVolume{
+				Name:        volume.Name,
+				Capacity:    resource.NewQuantity(volume.SizeBytes, resource.BinarySI),
+				State:       metalv1alpha1.StorageState(volume.State),
+				Health:      metalv1alpha1.StorageHealth(volume.Health),
+				RAIDType:    string(volume.RAIDType),
+				VolumeUsage: volume.VolumeUsage,
+			})
+		}
+		result = append(result, metalStorage)
+	}
+	return result
+}
+
+// setDegradedCondition sets the Degraded condition of the Server from the health of the drives in its status and
+// reports whether the condition has changed. The condition is only maintained once a drive has failed.
+func setDegradedCondition(server *metalv1alpha1.Server) bool {
+	var failed []string
+	for _, storage := range server.Status.Storages {
+		for _, drive := range storage.Drives {
+			if drive.State != metalv1alpha1.StorageStateAbsent &&
+				(drive.Health == metalv1alpha1.StorageHealthWarning || drive.Health == metalv1alpha1.StorageHealthCritical) {
+				failed = append(failed, drive.Name)
+			}
+		}
+	}
+	if len(failed) == 0 {
+		if meta.FindStatusCondition(server.Status.Conditions, ServerConditionDegraded) == nil {
+			return false
+		}
+		return meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:               ServerConditionDegraded,
+			Status:             metav1.ConditionFalse,
+			Reason:             serverDegradedReasonDrivesHealthy,
+			Message:            "All drives are healthy.",
+			ObservedGeneration: server.Generation,
+		})
+	}
+	return meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:               ServerConditionDegraded,
+		Status:             metav1.ConditionTrue,
+		Reason:             serverDegradedReasonDriveFailed,
+		Message:            fmt.Sprintf("Drives %s have failed.", strings.Join(failed, ", ")),
+		ObservedGeneration: server.Generation,
+	})
+}
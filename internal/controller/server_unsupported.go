@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+)
+
+const (
+	// ServerConditionOperationUnsupported reports whether the BMC of the Server rejected an operation as not
+	// supported by its Redfish implementation. The reason is the rejected operation and the message carries a hint
+	// of the vendor on how to achieve it instead.
+	ServerConditionOperationUnsupported = "OperationUnsupported"
+
+	serverOperationReasonSupported = "OperationsSupported"
+)
+
+// recordOperationSupport tracks in the OperationUnsupported condition whether the BMC of the Server rejected the
+// operation as unsupported. The condition is cleared once the operation which set it succeeds.
+func (r *ServerReconciler) recordOperationSupport(ctx context.Context, server *metalv1alpha1.Server, operation string, opErr error) error {
+	serverBase := server.DeepCopy()
+	var unsupportedErr *bmc.UnsupportedOperationError
+	switch {
+	case errors.As(opErr, &unsupportedErr):
+		if !meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:               ServerConditionOperationUnsupported,
+			Status:             metav1.ConditionTrue,
+			Reason:             unsupportedErr.Operation,
+			Message:            unsupportedErr.Error(),
+			ObservedGeneration: server.Generation,
+		}) {
+			return nil
+		}
+	case opErr == nil && meta.IsStatusConditionTrue(server.Status.Conditions, ServerConditionOperationUnsupported):
+		cond := meta.FindStatusCondition(server.Status.Conditions, ServerConditionOperationUnsupported)
+		if cond.Reason != operation {
+			return nil
+		}
+		meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:               ServerConditionOperationUnsupported,
+			Status:             metav1.ConditionFalse,
+			Reason:             serverOperationReasonSupported,
+			ObservedGeneration: server.Generation,
+		})
+	default:
+		return nil
+	}
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return fmt.Errorf("failed to patch server status: %w", err)
+	}
+	return nil
+}
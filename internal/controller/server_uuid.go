@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+)
+
+const (
+	// ServerConditionSystemUUIDMismatch reports whether the system UUID read in-band by the discovery differs from
+	// the systemUUID of the Server reported by the BMC, e.g. after a replacement of the board. Servers with a
+	// mismatch are re-keyed with the RekeySystemUUID operation.
+	ServerConditionSystemUUIDMismatch = "SystemUUIDMismatch"
+
+	serverUUIDReasonMismatch = "UUIDMismatch"
+	serverUUIDReasonMatch    = "UUIDsMatch"
+	serverUUIDReasonRekeyed  = "Rekeyed"
+)
+
+// setSystemUUIDMismatchCondition sets the SystemUUIDMismatch condition of the Server from its in-band system UUID
+// and reports whether the condition has changed. UUIDs which only differ in the byte order of their first three
+// fields are the same UUID encoded differently by the BMC and the SMBIOS tables.
+func setSystemUUIDMismatchCondition(server *metalv1alpha1.Server) bool {
+	inBand := server.Status.InBandSystemUUID
+	if inBand == "" {
+		return false
+	}
+	systemUUID := strings.ToLower(server.Spec.SystemUUID)
+	switch {
+	case inBand == systemUUID:
+		return meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:               ServerConditionSystemUUIDMismatch,
+			Status:             metav1.ConditionFalse,
+			Reason:             serverUUIDReasonMatch,
+			Message:            "The in-band system UUID matches the system UUID reported by the BMC.",
+			ObservedGeneration: server.Generation,
+		})
+	case inBand == swapUUIDByteOrder(systemUUID):
+		return meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:               ServerConditionSystemUUIDMismatch,
+			Status:             metav1.ConditionFalse,
+			Reason:             serverUUIDReasonMatch,
+			Message:            "The in-band system UUID matches the system UUID reported by the BMC in swapped byte order.",
+			ObservedGeneration: server.Generation,
+		})
+	default:
+		return meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:   ServerConditionSystemUUIDMismatch,
+			Status: metav1.ConditionTrue,
+			Reason: serverUUIDReasonMismatch,
+			Message: fmt.Sprintf("The in-band system UUID %s differs from the system UUID %s reported by the BMC.",
+				inBand, systemUUID),
+			ObservedGeneration: server.Generation,
+		})
+	}
+}
+
+// swapUUIDByteOrder returns the UUID with the byte order of its first three fields swapped, or an empty string if
+// it is no UUID.
+func swapUUIDByteOrder(uuid string) string {
+	fields := strings.Split(uuid, "-")
+	if len(fields) != 5 || len(fields[0]) != 8 || len(fields[1]) != 4 || len(fields[2]) != 4 {
+		return ""
+	}
+	for i := range 3 {
+		var swapped strings.Builder
+		for j := len(fields[i]); j > 0; j -= 2 {
+			swapped.WriteString(fields[i][j-2 : j])
+		}
+		fields[i] = swapped.String()
+	}
+	return strings.Join(fields, "-")
+}
+
+// rekeySystemUUID sets the systemUUID of the Server to its in-band system UUID. To not re-key a Server to the
+// system of another one, the BMC has to report a system with the in-band UUID and no other Server may use it.
+func (r *ServerReconciler) rekeySystemUUID(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server) error {
+	if !meta.IsStatusConditionTrue(server.Status.Conditions, ServerConditionSystemUUIDMismatch) {
+		return fmt.Errorf("the system UUID of the server does not differ from its in-band system UUID")
+	}
+	inBand := server.Status.InBandSystemUUID
+
+	servers := &metalv1alpha1.ServerList{}
+	if err := r.List(ctx, servers); err != nil {
+		return fmt.Errorf("failed to list servers: %w", err)
+	}
+	for _, other := range servers.Items {
+		if other.Name != server.Name && strings.EqualFold(other.Spec.SystemUUID, inBand) {
+			return fmt.Errorf("the in-band system UUID %s is used by server %s", inBand, other.Name)
+		}
+	}
+
+	bmcClient, err := bmcutils.GetBMCClientForServer(ctx, r.Client, server, r.Insecure, r.bmcOptions(server))
+	if err != nil {
+		return fmt.Errorf("failed to create BMC client: %w", err)
+	}
+	defer bmcClient.Logout()
+	systems, err := bmcClient.GetSystems(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get systems: %w", err)
+	}
+	systemURI := ""
+	for _, system := range systems {
+		if strings.EqualFold(system.UUID, inBand) {
+			systemURI = system.URI
+			break
+		}
+	}
+	if systemURI == "" {
+		return fmt.Errorf("the BMC does not report a system with the in-band system UUID %s", inBand)
+	}
+
+	oldUUID := server.Spec.SystemUUID
+	serverBase := server.DeepCopy()
+	if strings.EqualFold(server.Spec.UUID, oldUUID) {
+		server.Spec.UUID = inBand
+	}
+	server.Spec.SystemUUID = inBand
+	if server.Spec.SystemURI != "" {
+		server.Spec.SystemURI = systemURI
+	}
+	if err := r.Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return fmt.Errorf("failed to patch server: %w", err)
+	}
+
+	statusBase := server.DeepCopy()
+	meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:               ServerConditionSystemUUIDMismatch,
+		Status:             metav1.ConditionFalse,
+		Reason:             serverUUIDReasonRekeyed,
+		Message:            fmt.Sprintf("The system UUID has been changed from %s to the in-band system UUID.", oldUUID),
+		ObservedGeneration: server.Generation,
+	})
+	if err := r.Status().Patch(ctx, server, client.MergeFrom(statusBase)); err != nil {
+		return fmt.Errorf("failed to patch server status: %w", err)
+	}
+	log.V(1).Info("Rekeyed system UUID of server", "OldSystemUUID", oldUUID, "SystemUUID", inBand)
+	return nil
+}
@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("System UUID mismatch", func() {
+	It("should flag a Server whose in-band system UUID differs from the one of the BMC", func() {
+		server := &metalv1alpha1.Server{
+			Spec:   metalv1alpha1.ServerSpec{SystemUUID: "38947555-7742-3448-3784-823347823834"},
+			Status: metalv1alpha1.ServerStatus{InBandSystemUUID: "5c2b0e5e-7a1d-4f3e-9c1b-2a3d4e5f6a7b"},
+		}
+		Expect(setSystemUUIDMismatchCondition(server)).To(BeTrue())
+		Expect(meta.FindStatusCondition(server.Status.Conditions, ServerConditionSystemUUIDMismatch)).To(SatisfyAll(
+			HaveField("Status", metav1.ConditionTrue),
+			HaveField("Reason", serverUUIDReasonMismatch),
+		))
+	})
+
+	It("should not flag a Server whose BMC reports the in-band system UUID in swapped byte order", func() {
+		server := &metalv1alpha1.Server{
+			Spec:   metalv1alpha1.ServerSpec{SystemUUID: "38947555-7742-3448-3784-823347823834"},
+			Status: metalv1alpha1.ServerStatus{InBandSystemUUID: "55759438-4277-4834-3784-823347823834"},
+		}
+		Expect(setSystemUUIDMismatchCondition(server)).To(BeTrue())
+		Expect(meta.IsStatusConditionFalse(server.Status.Conditions, ServerConditionSystemUUIDMismatch)).To(BeTrue())
+	})
+})
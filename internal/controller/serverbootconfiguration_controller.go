@@ -5,12 +5,16 @@ package controller
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/go-logr/logr"
 	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 // ServerBootConfigurationReconciler reconciles a ServerBootConfiguration object
@@ -58,6 +62,21 @@ func (r *ServerBootConfigurationReconciler) reconcile(ctx context.Context, log l
 	}
 	log.V(1).Info("Patched state")
 
+	if err := r.patchArchitecture(ctx, config); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Configurations booting from a SAN without a network boot image do not need to be served by a boot
+	// operator and are ready right away.
+	if isISCSIBootConfiguration(config) && config.Spec.Image == "" {
+		if modified, err := r.patchState(ctx, config, metalv1alpha1.ServerBootConfigurationStateReady); err != nil || modified {
+			return ctrl.Result{}, err
+		}
+		if err := r.patchObservedIgnitionHash(ctx, config); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	log.V(1).Info("Reconciled ServerBootConfiguration")
 	return ctrl.Result{}, nil
 }
@@ -74,9 +93,63 @@ func (r *ServerBootConfigurationReconciler) patchState(ctx context.Context, conf
 	return true, nil
 }
 
+// patchObservedIgnitionHash records the ignition hash of a configuration which does not need a boot operator as
+// observed.
+func (r *ServerBootConfigurationReconciler) patchObservedIgnitionHash(ctx context.Context, config *metalv1alpha1.ServerBootConfiguration) error {
+	hash := config.Annotations[metalv1alpha1.IgnitionHashAnnotation]
+	if config.Status.ObservedIgnitionHash == hash {
+		return nil
+	}
+	configBase := config.DeepCopy()
+	config.Status.ObservedIgnitionHash = hash
+	if err := r.Status().Patch(ctx, config, client.MergeFrom(configBase)); err != nil {
+		return fmt.Errorf("failed to patch observed ignition hash: %w", err)
+	}
+	return nil
+}
+
+// patchArchitecture records the architecture of the Server in the status of the configuration, so that the boot
+// operator selects the matching artifacts from multi-architecture images.
+func (r *ServerBootConfigurationReconciler) patchArchitecture(ctx context.Context, config *metalv1alpha1.ServerBootConfiguration) error {
+	server := &metalv1alpha1.Server{}
+	if err := r.Get(ctx, client.ObjectKey{Name: config.Spec.ServerRef.Name}, server); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if config.Status.Architecture == server.Status.Architecture {
+		return nil
+	}
+	configBase := config.DeepCopy()
+	config.Status.Architecture = server.Status.Architecture
+	if err := r.Status().Patch(ctx, config, client.MergeFrom(configBase)); err != nil {
+		return fmt.Errorf("failed to patch architecture: %w", err)
+	}
+	return nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ServerBootConfigurationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&metalv1alpha1.ServerBootConfiguration{}).
+		Watches(&metalv1alpha1.Server{}, r.enqueueServerBootConfigurationsByServer()).
 		Complete(r)
 }
+
+func (r *ServerBootConfigurationReconciler) enqueueServerBootConfigurationsByServer() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
+		server := object.(*metalv1alpha1.Server)
+		configList := &metalv1alpha1.ServerBootConfigurationList{}
+		if err := r.List(ctx, configList); err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "failed to list ServerBootConfigurations")
+			return nil
+		}
+		var req []reconcile.Request
+		for _, config := range configList.Items {
+			if config.Spec.ServerRef.Name == server.Name && config.Status.Architecture != server.Status.Architecture {
+				req = append(req, reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: config.Namespace, Name: config.Name},
+				})
+			}
+		}
+		return req
+	})
+}
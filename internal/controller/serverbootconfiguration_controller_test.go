@@ -51,4 +51,54 @@ var _ = Describe("ServerBootConfiguration Controller", func() {
 			HaveField("Status.State", metalv1alpha1.ServerBootConfigurationStatePending),
 		))
 	})
+
+	It("should record the architecture of the server", func(ctx SpecContext) {
+		By("By creating a server boot configuration")
+		config := &metalv1alpha1.ServerBootConfiguration{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns.Name,
+				Name:      server.Name,
+			},
+			Spec: metalv1alpha1.ServerBootConfigurationSpec{
+				ServerRef: v1.LocalObjectReference{Name: server.Name},
+				Image:     "foo:latest",
+			},
+		}
+		Expect(k8sClient.Create(ctx, config)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, config)
+
+		By("Patching the architecture of the server")
+		Eventually(UpdateStatus(server, func() {
+			server.Status.Architecture = metalv1alpha1.ArchitectureAArch64
+		})).Should(Succeed())
+
+		Eventually(Object(config)).Should(HaveField("Status.Architecture", metalv1alpha1.ArchitectureAArch64))
+	})
+
+	It("should mark an iSCSI boot configuration without image as ready", func(ctx SpecContext) {
+		By("By creating a server boot configuration booting from iSCSI")
+		config := &metalv1alpha1.ServerBootConfiguration{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns.Name,
+				Name:      server.Name,
+			},
+			Spec: metalv1alpha1.ServerBootConfigurationSpec{
+				ServerRef: v1.LocalObjectReference{Name: server.Name},
+				SANBoot: &metalv1alpha1.SANBootConfiguration{
+					ISCSI: &metalv1alpha1.ISCSIBootConfiguration{
+						InitiatorName: "iqn.2024-01.dev.ironcore:initiator",
+						TargetName:    "iqn.2024-01.dev.ironcore:target",
+						TargetAddress: "10.0.0.1",
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, config)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, config)
+
+		Eventually(Object(config)).Should(SatisfyAll(
+			HaveField("Spec.SANBoot.ISCSI.TargetPort", BeNumerically("==", 3260)),
+			HaveField("Status.State", metalv1alpha1.ServerBootConfigurationStateReady),
+		))
+	})
 })
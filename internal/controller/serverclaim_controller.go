@@ -5,7 +5,13 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"time"
 
 	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -14,11 +20,16 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/ironcore-dev/controller-utils/clientutils"
 	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/internal/oci"
+	"github.com/ironcore-dev/metal-operator/internal/placement"
+	"github.com/stmcginnis/gofish/redfish"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -27,12 +38,59 @@ import (
 
 const (
 	ServerClaimFinalizer = "metal.ironcore.dev/serverclaim"
+
+	// ServerClaimConditionImageAllowed reports whether the image of the claim is allowed by the ImagePolicies.
+	ServerClaimConditionImageAllowed = "ImageAllowed"
+	// ServerClaimConditionImageVerified is set once the image of the claim has been resolved in its registry.
+	ServerClaimConditionImageVerified = "ImageVerified"
+	// ServerClaimConditionServerSelected is set once a Server has been selected for the claim.
+	ServerClaimConditionServerSelected = "ServerSelected"
+	// ServerClaimConditionBootConfigurationReady reflects the state of the ServerBootConfiguration of the claim.
+	ServerClaimConditionBootConfigurationReady = "BootConfigurationReady"
+	// ServerClaimConditionIgnitionRendered is set once the ignition referenced by the claim is available.
+	ServerClaimConditionIgnitionRendered = "IgnitionRendered"
+	// ServerClaimConditionServerPoweredOn reflects whether the claimed Server is powered on.
+	ServerClaimConditionServerPoweredOn = "ServerPoweredOn"
+	// ServerClaimConditionBootVerified reflects the boot verification of the claimed Server.
+	ServerClaimConditionBootVerified = "BootVerified"
+	// ServerClaimConditionIgnitionUpToDate reports whether the claimed Server has booted the current ignition.
+	ServerClaimConditionIgnitionUpToDate = "IgnitionUpToDate"
+	// ServerClaimConditionMaintenancePending reports maintenance requested for the claimed Server.
+	ServerClaimConditionMaintenancePending = "MaintenancePending"
+	// ServerClaimConditionServerHealthy reports whether the claimed Server is in the Error state or one of its
+	// firmware updates failed.
+	ServerClaimConditionServerHealthy = "ServerHealthy"
 )
 
 // ServerClaimReconciler reconciles a ServerClaim object
 type ServerClaimReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// ImageResolver verifies the image of a claim before it is bound. If nil, the image is not verified.
+	ImageResolver oci.Resolver
+	// PlacementAdmitter may veto or re-rank the Servers selected for a claim before it is bound. If nil, the first
+	// selected Server is bound.
+	PlacementAdmitter placement.Admitter
+	// MaintenanceWebhooks allows claims to register a webhook with the MaintenanceWebhookAnnotation, which is
+	// notified of maintenance requested for the claimed Server.
+	MaintenanceWebhooks bool
+}
+
+// placementRetryInterval is the interval in which the placement of a claim whose candidates have all been vetoed
+// is retried.
+const placementRetryInterval = time.Minute
+
+// imageVerificationRetryInterval is the interval in which the verification of an image which could not be resolved
+// in its registry is retried.
+const imageVerificationRetryInterval = 30 * time.Second
+
+// placementRejectedError is returned if the PlacementAdmitter vetoed all candidates of a claim.
+type placementRejectedError struct {
+	reason string
+}
+
+func (e *placementRejectedError) Error() string {
+	return fmt.Sprintf("placement rejected: %s", e.reason)
 }
 
 // +kubebuilder:rbac:groups=metal.ironcore.dev,resources=serverclaims,verbs=get;list;watch;create;update;patch;delete
@@ -41,6 +99,10 @@ type ServerClaimReconciler struct {
 // +kubebuilder:rbac:groups=metal.ironcore.dev,resources=servers,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=metal.ironcore.dev,resources=servers/finalizers,verbs=update
 // +kubebuilder:rbac:groups=metal.ironcore.dev,resources=serverbootconfigurations,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=metal.ironcore.dev,resources=componentfirmwares;drivefirmwares,verbs=get;list;watch
+// +kubebuilder:rbac:groups=metal.ironcore.dev,resources=servergrants,verbs=get;list;watch
+// +kubebuilder:rbac:groups=metal.ironcore.dev,resources=imagepolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -123,14 +185,15 @@ func (r *ServerClaimReconciler) cleanupAndShutdownServer(ctx context.Context, lo
 // - Handle reconciliation ignore and late state initialization
 // - Check if a ServerRef has been set
 // - Ensure finalizer is set on claim
+// - Verify the image of the claim before binding
 // - Ensure server spec matches claim & set claim ref on server
 // - Patch the claim status to bound
 // - Apply Boot configuration
 func (r *ServerClaimReconciler) reconcile(ctx context.Context, log logr.Logger, claim *metalv1alpha1.ServerClaim) (ctrl.Result, error) {
 	log.V(1).Info("Reconciling server claim")
-	if shouldIgnoreReconciliation(claim) {
+	if paused, remaining := shouldIgnoreReconciliation(claim); paused {
 		log.V(1).Info("Skipped Server reconciliation")
-		return ctrl.Result{}, nil
+		return ctrl.Result{RequeueAfter: remaining}, nil
 	}
 
 	// do late state initialization
@@ -145,12 +208,49 @@ func (r *ServerClaimReconciler) reconcile(ctx context.Context, log logr.Logger,
 	}
 	log.V(1).Info("Ensured finalizer has been added")
 
+	if err := r.updateServerHealthCondition(ctx, claim); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if allowed, err := r.checkImagePolicies(ctx, log, claim); err != nil || !allowed {
+		return ctrl.Result{}, err
+	}
+
+	if granted, err := r.checkServerGrant(ctx, log, claim); err != nil || !granted {
+		return ctrl.Result{}, err
+	}
+
+	verified, err := r.verifyImage(ctx, log, claim)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !verified {
+		return ctrl.Result{RequeueAfter: imageVerificationRetryInterval}, nil
+	}
+
 	server, modified, err := r.claimServer(ctx, log, claim)
+	var rejected *placementRejectedError
+	if errors.As(err, &rejected) {
+		log.V(1).Info("Placement of claim rejected", "Reason", rejected.reason)
+		claimBase := claim.DeepCopy()
+		setServerClaimCondition(claim, ServerClaimConditionServerSelected, metav1.ConditionFalse,
+			"PlacementRejected", rejected.reason)
+		if err := r.Status().Patch(ctx, claim, client.MergeFrom(claimBase)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to patch server claim status: %w", err)
+		}
+		return ctrl.Result{RequeueAfter: placementRetryInterval}, nil
+	}
 	if err != nil || modified {
 		return ctrl.Result{Requeue: true}, err
 	}
 	if server == nil {
 		log.V(1).Info("No server found for claim")
+		claimBase := claim.DeepCopy()
+		setServerClaimCondition(claim, ServerClaimConditionServerSelected, metav1.ConditionFalse,
+			"NoServerAvailable", "No server matching the claim is available")
+		if err := r.Status().Patch(ctx, claim, client.MergeFrom(claimBase)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to patch server claim status: %w", err)
+		}
 		return ctrl.Result{}, nil
 	}
 
@@ -159,7 +259,11 @@ func (r *ServerClaimReconciler) reconcile(ctx context.Context, log logr.Logger,
 	}
 	log.V(1).Info("Patched ServerRef in Claim")
 
-	if err := r.applyBootConfiguration(ctx, log, server, claim); err != nil {
+	ignitionHash, err := r.ignitionHash(ctx, claim)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.applyBootConfiguration(ctx, log, server, claim, ignitionHash); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to apply boot configuration: %w", err)
 	}
 	log.V(1).Info("Applied BootConfiguration for ServerClaim")
@@ -174,10 +278,264 @@ func (r *ServerClaimReconciler) reconcile(ctx context.Context, log logr.Logger,
 	}
 	log.V(1).Info("Ensured PowerState for Server", "Server", server.Name)
 
+	if err := r.reconcileIgnitionUpdate(ctx, log, claim, server, ignitionHash); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile ignition update: %w", err)
+	}
+
+	if err := r.updateBindingConditions(ctx, claim, server); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update binding conditions: %w", err)
+	}
+	log.V(1).Info("Updated binding conditions")
+
 	log.V(1).Info("Reconciled server claim")
 	return ctrl.Result{}, nil
 }
 
+// checkImagePolicies re-checks the image of the claim against the ImagePolicies, as the policies may have changed
+// since the claim has been admitted. A claim with a forbidden image is neither bound nor is its boot configuration
+// updated, until the image or the policies change.
+func (r *ServerClaimReconciler) checkImagePolicies(ctx context.Context, log logr.Logger, claim *metalv1alpha1.ServerClaim) (bool, error) {
+	policyList := &metalv1alpha1.ImagePolicyList{}
+	if err := r.List(ctx, policyList); err != nil {
+		return false, fmt.Errorf("failed to list image policies: %w", err)
+	}
+	allowed := metalv1alpha1.ImageAllowed(policyList.Items, claim.Spec.Image)
+	if cond := meta.FindStatusCondition(claim.Status.Conditions, ServerClaimConditionImageAllowed); cond == nil && allowed {
+		return true, nil
+	}
+
+	claimBase := claim.DeepCopy()
+	if allowed {
+		setServerClaimCondition(claim, ServerClaimConditionImageAllowed, metav1.ConditionTrue,
+			"ImageAllowed", "The image is allowed by the image policies")
+	} else {
+		log.V(1).Info("Image is not allowed by the image policies", "Image", claim.Spec.Image)
+		setServerClaimCondition(claim, ServerClaimConditionImageAllowed, metav1.ConditionFalse,
+			"ImageNotAllowed", fmt.Sprintf("Image %s is not allowed by any image policy", claim.Spec.Image))
+	}
+	if err := r.Status().Patch(ctx, claim, client.MergeFrom(claimBase)); err != nil {
+		return false, fmt.Errorf("failed to patch server claim status: %w", err)
+	}
+	return allowed, nil
+}
+
+// checkServerGrant releases the Server bound to the claim once the claim may no longer keep it, e.g. because the
+// ServerGrant delegating it to the namespace of the claim expired or the image of the claim is not allowed by the
+// grant any longer. The claim is not bound again until a grant allows it, see admitPlacement.
+func (r *ServerClaimReconciler) checkServerGrant(ctx context.Context, log logr.Logger, claim *metalv1alpha1.ServerClaim) (bool, error) {
+	if claim.Spec.ServerRef == nil {
+		return true, nil
+	}
+	server := &metalv1alpha1.Server{}
+	if err := r.Get(ctx, client.ObjectKey{Name: claim.Spec.ServerRef.Name}, server); err != nil {
+		return apierrors.IsNotFound(err), client.IgnoreNotFound(err)
+	}
+	if claimRef := server.Spec.ServerClaimRef; claimRef == nil || claimRef.UID != claim.UID {
+		return true, nil
+	}
+	granted, err := serverGranted(ctx, r.Client, claim.Namespace, claim.Spec.Image, server)
+	if err != nil || granted {
+		return granted, err
+	}
+
+	log.V(1).Info("Server is no longer granted to the namespace of the claim, releasing it", "Server", server.Name)
+	if err := r.cleanupAndShutdownServer(ctx, log, claim); err != nil {
+		return false, fmt.Errorf("failed to release server: %w", err)
+	}
+	claimBase := claim.DeepCopy()
+	claim.Status.Phase = metalv1alpha1.PhaseUnbound
+	setServerClaimCondition(claim, ServerClaimConditionServerSelected, metav1.ConditionFalse, "ServerGrantRevoked",
+		fmt.Sprintf("Server %s is no longer granted to the namespace for image %s", server.Name, claim.Spec.Image))
+	if err := r.Status().Patch(ctx, claim, client.MergeFrom(claimBase)); err != nil {
+		return false, fmt.Errorf("failed to patch server claim status: %w", err)
+	}
+	return false, nil
+}
+
+// verifyImage resolves the image of an unbound claim in its registry and records the resolved digest in
+// the claim status. Claims which are already bound are not verified again.
+func (r *ServerClaimReconciler) verifyImage(ctx context.Context, log logr.Logger, claim *metalv1alpha1.ServerClaim) (bool, error) {
+	if r.ImageResolver == nil || claim.Status.Phase == metalv1alpha1.PhaseBound {
+		return true, nil
+	}
+	if cond := meta.FindStatusCondition(claim.Status.Conditions, ServerClaimConditionImageVerified); cond != nil &&
+		cond.Status == metav1.ConditionTrue && cond.ObservedGeneration == claim.Generation {
+		return true, nil
+	}
+
+	claimBase := claim.DeepCopy()
+	digest, err := r.ImageResolver.Resolve(ctx, claim.Spec.Image)
+	if err != nil {
+		log.V(1).Info("Failed to verify image", "Image", claim.Spec.Image, "Error", err.Error())
+		claim.Status.ImageDigest = ""
+		meta.SetStatusCondition(&claim.Status.Conditions, metav1.Condition{
+			Type:               ServerClaimConditionImageVerified,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: claim.Generation,
+			Reason:             "ImageResolutionFailed",
+			Message:            err.Error(),
+		})
+		if err := r.Status().Patch(ctx, claim, client.MergeFrom(claimBase)); err != nil {
+			return false, fmt.Errorf("failed to patch server claim status: %w", err)
+		}
+		return false, nil
+	}
+
+	claim.Status.ImageDigest = digest
+	meta.SetStatusCondition(&claim.Status.Conditions, metav1.Condition{
+		Type:               ServerClaimConditionImageVerified,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: claim.Generation,
+		Reason:             "ImageResolved",
+		Message:            fmt.Sprintf("Image %s resolved to %s", claim.Spec.Image, digest),
+	})
+	if err := r.Status().Patch(ctx, claim, client.MergeFrom(claimBase)); err != nil {
+		return false, fmt.Errorf("failed to patch server claim status: %w", err)
+	}
+	log.V(1).Info("Verified image", "Image", claim.Spec.Image, "Digest", digest)
+	return true, nil
+}
+
+func setServerClaimCondition(claim *metalv1alpha1.ServerClaim, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&claim.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: claim.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// updateServerHealthCondition reports in the ServerHealthy condition whether the Server bound to the claim is in the
+// Error state or one of its firmware updates failed, so that the owner of the claim notices failures of its Server.
+func (r *ServerClaimReconciler) updateServerHealthCondition(ctx context.Context, claim *metalv1alpha1.ServerClaim) error {
+	if claim.Spec.ServerRef == nil {
+		return nil
+	}
+	server := &metalv1alpha1.Server{}
+	if err := r.Get(ctx, client.ObjectKey{Name: claim.Spec.ServerRef.Name}, server); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if ref := server.Spec.ServerClaimRef; ref == nil || ref.UID != claim.UID {
+		return nil
+	}
+
+	condition := metav1.Condition{
+		Type:               ServerClaimConditionServerHealthy,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: claim.Generation,
+		Reason:             "ServerHealthy",
+		Message:            "Server is healthy",
+	}
+	firmwareFailed := meta.FindStatusCondition(server.Status.Conditions, ServerConditionFirmwareUpdateFailed)
+	switch {
+	case server.Status.State == metalv1alpha1.ServerStateError:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ServerError"
+		condition.Message = "Server is in the error state"
+		if diagnostics := server.Status.ErrorDiagnostics; diagnostics != nil {
+			condition.Message = fmt.Sprintf("Server is in the error state (%s): %s", diagnostics.Reason, diagnostics.Message)
+		}
+	case firmwareFailed != nil && firmwareFailed.Status == metav1.ConditionTrue:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ServerErrorReasonFirmwareUpdateFailed
+		condition.Message = firmwareFailed.Message
+	}
+	claimBase := claim.DeepCopy()
+	if !meta.SetStatusCondition(&claim.Status.Conditions, condition) {
+		return nil
+	}
+	if err := r.Status().Patch(ctx, claim, client.MergeFrom(claimBase)); err != nil {
+		return fmt.Errorf("failed to patch server claim status: %w", err)
+	}
+	return nil
+}
+
+// updateBindingConditions reports the progress of the binding pipeline of the claim, so that a stuck claim shows
+// which step it is blocked on.
+func (r *ServerClaimReconciler) updateBindingConditions(ctx context.Context, claim *metalv1alpha1.ServerClaim, server *metalv1alpha1.Server) error {
+	claimBase := claim.DeepCopy()
+
+	setServerClaimCondition(claim, ServerClaimConditionServerSelected, metav1.ConditionTrue,
+		"ServerSelected", fmt.Sprintf("Server %s has been selected", server.Name))
+
+	config := &metalv1alpha1.ServerBootConfiguration{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: claim.Namespace, Name: claim.Name}, config); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get ServerBootConfiguration: %w", err)
+		}
+		setServerClaimCondition(claim, ServerClaimConditionBootConfigurationReady, metav1.ConditionFalse,
+			"BootConfigurationNotFound", "ServerBootConfiguration has not been created yet")
+	} else {
+		switch config.Status.State {
+		case metalv1alpha1.ServerBootConfigurationStateReady:
+			setServerClaimCondition(claim, ServerClaimConditionBootConfigurationReady, metav1.ConditionTrue,
+				"BootConfigurationReady", "ServerBootConfiguration is ready")
+		case metalv1alpha1.ServerBootConfigurationStateError:
+			setServerClaimCondition(claim, ServerClaimConditionBootConfigurationReady, metav1.ConditionFalse,
+				"BootConfigurationError", "ServerBootConfiguration is in error state")
+		default:
+			setServerClaimCondition(claim, ServerClaimConditionBootConfigurationReady, metav1.ConditionFalse,
+				"BootConfigurationPending", "Waiting for the ServerBootConfiguration to become ready")
+		}
+	}
+
+	if ref := claim.Spec.IgnitionSecretRef; ref == nil {
+		setServerClaimCondition(claim, ServerClaimConditionIgnitionRendered, metav1.ConditionTrue,
+			"NoIgnition", "Claim does not reference an ignition")
+	} else {
+		secret := &v1.Secret{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: claim.Namespace, Name: ref.Name}, secret); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to get ignition secret: %w", err)
+			}
+			setServerClaimCondition(claim, ServerClaimConditionIgnitionRendered, metav1.ConditionFalse,
+				"IgnitionSecretNotFound", fmt.Sprintf("Ignition secret %s not found", ref.Name))
+		} else if len(secret.Data[DefaultIgnitionSecretKeyName]) == 0 {
+			setServerClaimCondition(claim, ServerClaimConditionIgnitionRendered, metav1.ConditionFalse,
+				"IgnitionEmpty", fmt.Sprintf("Ignition secret %s has no %s key", ref.Name, DefaultIgnitionSecretKeyName))
+		} else {
+			setServerClaimCondition(claim, ServerClaimConditionIgnitionRendered, metav1.ConditionTrue,
+				"IgnitionAvailable", fmt.Sprintf("Ignition secret %s is available", ref.Name))
+		}
+	}
+
+	switch server.Status.PowerState {
+	case metalv1alpha1.ServerOnPowerState:
+		setServerClaimCondition(claim, ServerClaimConditionServerPoweredOn, metav1.ConditionTrue,
+			"ServerPoweredOn", "Server is powered on")
+	case metalv1alpha1.ServerPoweringOnPowerState:
+		setServerClaimCondition(claim, ServerClaimConditionServerPoweredOn, metav1.ConditionFalse,
+			"ServerPoweringOn", "Server is powering on")
+	default:
+		setServerClaimCondition(claim, ServerClaimConditionServerPoweredOn, metav1.ConditionFalse,
+			"ServerPoweredOff", fmt.Sprintf("Server power state is %s", server.Status.PowerState))
+	}
+
+	if err := r.updateMaintenanceCondition(ctx, claim, server); err != nil {
+		return err
+	}
+
+	if boot := meta.FindStatusCondition(server.Status.Conditions, ServerConditionBootFailed); boot == nil {
+		setServerClaimCondition(claim, ServerClaimConditionBootVerified, metav1.ConditionUnknown,
+			"BootNotVerified", "Boot of the server is not verified")
+	} else if boot.Status == metav1.ConditionTrue {
+		setServerClaimCondition(claim, ServerClaimConditionBootVerified, metav1.ConditionFalse,
+			"BootFailed", boot.Message)
+	} else if boot.Reason == serverBootReasonSucceeded {
+		setServerClaimCondition(claim, ServerClaimConditionBootVerified, metav1.ConditionTrue,
+			"BootSucceeded", boot.Message)
+	} else {
+		setServerClaimCondition(claim, ServerClaimConditionBootVerified, metav1.ConditionFalse,
+			"BootInProgress", boot.Message)
+	}
+
+	if err := r.Status().Patch(ctx, claim, client.MergeFrom(claimBase)); err != nil {
+		return fmt.Errorf("failed to patch server claim status: %w", err)
+	}
+	return nil
+}
+
 func (r *ServerClaimReconciler) ensureObjectRefForServer(ctx context.Context, log logr.Logger, claim *metalv1alpha1.ServerClaim, server *metalv1alpha1.Server) (bool, error) {
 	if server.Spec.ServerClaimRef != nil {
 		log.V(1).Info("Server is already claimed", "Server", server.Name, "Claim", server.Spec.ServerClaimRef.Name)
@@ -245,7 +603,122 @@ func (r *ServerClaimReconciler) patchServerRef(ctx context.Context, claim *metal
 	return false, fmt.Errorf("failed to patch server ref for claim: server reference is immutable")
 }
 
-func (r *ServerClaimReconciler) applyBootConfiguration(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server, claim *metalv1alpha1.ServerClaim) error {
+// ignitionHash returns the hash of the data of the ignition secret of the claim. It is empty if the claim does not
+// reference an existing ignition secret.
+func (r *ServerClaimReconciler) ignitionHash(ctx context.Context, claim *metalv1alpha1.ServerClaim) (string, error) {
+	if claim.Spec.IgnitionSecretRef == nil {
+		return "", nil
+	}
+	secret := &v1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: claim.Namespace, Name: claim.Spec.IgnitionSecretRef.Name}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get ignition secret: %w", err)
+	}
+	hash := sha256.New()
+	for _, key := range slices.Sorted(maps.Keys(secret.Data)) {
+		hash.Write([]byte(key))
+		hash.Write([]byte{0})
+		hash.Write(secret.Data[key])
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// reconcileIgnitionUpdate tracks changes of the ignition of a bound claim. A change is reported as drift in the
+// IgnitionUpToDate condition until the Server has been restarted, which is requested according to the
+// IgnitionUpdatePolicy of the claim once the re-rendered boot configuration is ready.
+func (r *ServerClaimReconciler) reconcileIgnitionUpdate(ctx context.Context, log logr.Logger, claim *metalv1alpha1.ServerClaim, server *metalv1alpha1.Server, hash string) error {
+	claimBase := claim.DeepCopy()
+	condition := meta.FindStatusCondition(claim.Status.Conditions, ServerClaimConditionIgnitionUpToDate)
+	switch {
+	case condition == nil:
+		claim.Status.IgnitionHash = hash
+		setServerClaimCondition(claim, ServerClaimConditionIgnitionUpToDate, metav1.ConditionTrue,
+			"IgnitionApplied", "Server boots the current ignition")
+	case claim.Status.IgnitionHash != hash:
+		log.V(1).Info("Ignition of the claim changed", "Hash", hash, "Policy", claim.Spec.IgnitionUpdatePolicy)
+		claim.Status.IgnitionHash = hash
+		message := "Ignition changed, the Server boots it on its next restart"
+		if policy := claim.Spec.IgnitionUpdatePolicy; policy != "" && policy != metalv1alpha1.IgnitionUpdatePolicyNone {
+			message = fmt.Sprintf("Ignition changed, the Server is restarted (%s) once the boot configuration is re-rendered", policy)
+		}
+		// the condition is recreated, so that its transition time marks the change
+		meta.RemoveStatusCondition(&claim.Status.Conditions, ServerClaimConditionIgnitionUpToDate)
+		setServerClaimCondition(claim, ServerClaimConditionIgnitionUpToDate, metav1.ConditionFalse,
+			"IgnitionChanged", message)
+	case condition.Status == metav1.ConditionTrue:
+		return nil
+	case server.Status.PowerState == metalv1alpha1.ServerOffPowerState:
+		setServerClaimCondition(claim, ServerClaimConditionIgnitionUpToDate, metav1.ConditionTrue,
+			"IgnitionApplied", "Server boots the changed ignition on its next power on")
+	case serverRestartedSince(server, condition.LastTransitionTime):
+		setServerClaimCondition(claim, ServerClaimConditionIgnitionUpToDate, metav1.ConditionTrue,
+			"IgnitionApplied", "Server has been restarted with the changed ignition")
+	case condition.Reason == "IgnitionChanged":
+		requested, err := r.requestIgnitionRestart(ctx, log, claim, server)
+		if err != nil || !requested {
+			return err
+		}
+		setServerClaimCondition(claim, ServerClaimConditionIgnitionUpToDate, metav1.ConditionFalse,
+			"RestartRequested", fmt.Sprintf("Restart of the Server requested by the %s policy", claim.Spec.IgnitionUpdatePolicy))
+	default:
+		return nil
+	}
+	if err := r.Status().Patch(ctx, claim, client.MergeFrom(claimBase)); err != nil {
+		return fmt.Errorf("failed to patch server claim status: %w", err)
+	}
+	return nil
+}
+
+// requestIgnitionRestart requests the restart of the Server by the IgnitionUpdatePolicy of the claim through the
+// operation annotation of the Server. The restart is only requested once the boot configuration is ready, the boot
+// operator rendered it with the current ignition and no other operation is pending on the Server.
+func (r *ServerClaimReconciler) requestIgnitionRestart(ctx context.Context, log logr.Logger, claim *metalv1alpha1.ServerClaim, server *metalv1alpha1.Server) (bool, error) {
+	var operation string
+	switch claim.Spec.IgnitionUpdatePolicy {
+	case metalv1alpha1.IgnitionUpdatePolicyReboot:
+		operation = string(redfish.GracefulRestartResetType)
+	case metalv1alpha1.IgnitionUpdatePolicyReprovision:
+		operation = metalv1alpha1.OperationAnnotationPXERestart
+	default:
+		return false, nil
+	}
+	if _, ok := server.GetAnnotations()[metalv1alpha1.OperationAnnotation]; ok {
+		log.V(1).Info("Waiting for the pending operation of the Server before restarting it")
+		return false, nil
+	}
+	config := &metalv1alpha1.ServerBootConfiguration{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: claim.Namespace, Name: claim.Name}, config); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	if config.Status.State != metalv1alpha1.ServerBootConfigurationStateReady {
+		log.V(1).Info("Waiting for the boot configuration to become ready before restarting the Server")
+		return false, nil
+	}
+	if config.Status.ObservedIgnitionHash != claim.Status.IgnitionHash {
+		log.V(1).Info("Waiting for the boot configuration to be rendered with the changed ignition before restarting the Server",
+			"Hash", claim.Status.IgnitionHash, "ObservedHash", config.Status.ObservedIgnitionHash)
+		return false, nil
+	}
+
+	serverBase := server.DeepCopy()
+	metav1.SetMetaDataAnnotation(&server.ObjectMeta, metalv1alpha1.OperationAnnotation, operation)
+	if err := r.Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return false, fmt.Errorf("failed to request restart of server: %w", err)
+	}
+	log.V(1).Info("Requested restart of the Server for the changed ignition", "Server", server.Name, "Operation", operation)
+	return true, nil
+}
+
+// serverRestartedSince returns whether a reset of the Server has been requested since the given time.
+func serverRestartedSince(server *metalv1alpha1.Server, since metav1.Time) bool {
+	reset := meta.FindStatusCondition(server.Status.Conditions, ServerConditionPowerCycleRequested)
+	return reset != nil && !reset.LastTransitionTime.Before(&since)
+}
+
+func (r *ServerClaimReconciler) applyBootConfiguration(ctx context.Context, log logr.Logger, server *metalv1alpha1.Server, claim *metalv1alpha1.ServerClaim, ignitionHash string) error {
 	config := &metalv1alpha1.ServerBootConfiguration{}
 	config.Name = claim.Name
 	config.Namespace = claim.Namespace
@@ -254,6 +727,12 @@ func (r *ServerClaimReconciler) applyBootConfiguration(ctx context.Context, log
 		config.Spec.ServerRef = *claim.Spec.ServerRef
 		config.Spec.Image = claim.Spec.Image
 		config.Spec.IgnitionSecretRef = claim.Spec.IgnitionSecretRef
+		config.Spec.SANBoot = claim.Spec.SANBoot
+		if ignitionHash != "" {
+			metav1.SetMetaDataAnnotation(&config.ObjectMeta, metalv1alpha1.IgnitionHashAnnotation, ignitionHash)
+		} else {
+			delete(config.Annotations, metalv1alpha1.IgnitionHashAnnotation)
+		}
 		return ctrl.SetControllerReference(claim, config, r.Scheme)
 	})
 	if err != nil {
@@ -340,8 +819,12 @@ func (r *ServerClaimReconciler) claimServerByReference(ctx context.Context, log
 		log.V(1).Info("Server is not powered off", "Server", server.Name, "PowerState", server.Status.PowerState)
 		return nil, nil
 	}
+	if serverHeld(server) && server.Spec.ServerClaimRef == nil {
+		log.V(1).Info("Server is held by a ServerReservation", "Server", server.Name)
+		return nil, nil
+	}
 	if claim.Spec.ServerSelector == nil {
-		return server, nil
+		return r.admitPlacement(ctx, log, claim, []metalv1alpha1.Server{*server})
 	}
 	selector, err := metav1.LabelSelectorAsSelector(claim.Spec.ServerSelector)
 	if err != nil {
@@ -351,7 +834,7 @@ func (r *ServerClaimReconciler) claimServerByReference(ctx context.Context, log
 		log.V(1).Info("Specified server does not match label selector", "Server", server.Name, "Claim", claim.Name)
 		return nil, nil
 	}
-	return server, nil
+	return r.admitPlacement(ctx, log, claim, []metalv1alpha1.Server{*server})
 }
 
 func (r *ServerClaimReconciler) claimServerBySelector(ctx context.Context, log logr.Logger, claim *metalv1alpha1.ServerClaim) (*metalv1alpha1.Server, error) {
@@ -364,6 +847,7 @@ func (r *ServerClaimReconciler) claimServerBySelector(ctx context.Context, log l
 	if err := r.List(ctx, serverList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
 		return nil, err
 	}
+	var candidates []metalv1alpha1.Server
 	for _, server := range serverList.Items {
 		if claimRef := server.Spec.ServerClaimRef; claimRef != nil && claimRef.UID != claim.UID {
 			log.V(1).Info("Server claim ref UID does not match claim", "Server", server.Name, "ClaimUID", claimRef.UID)
@@ -377,10 +861,17 @@ func (r *ServerClaimReconciler) claimServerBySelector(ctx context.Context, log l
 			log.V(1).Info("Server is not powered off", "Server", server.Name, "PowerState", server.Status.PowerState)
 			continue
 		}
-		return &server, nil
-
+		if _, ok := server.Labels[ServerBootFailedLabel]; ok && server.Spec.ServerClaimRef == nil {
+			log.V(1).Info("Server is tainted after a failed boot", "Server", server.Name)
+			continue
+		}
+		if serverHeld(&server) && server.Spec.ServerClaimRef == nil {
+			log.V(1).Info("Server is held by a ServerReservation", "Server", server.Name)
+			continue
+		}
+		candidates = append(candidates, server)
 	}
-	return nil, nil
+	return r.admitPlacement(ctx, log, claim, candidates)
 }
 
 func checkForPrevUsedServer(log logr.Logger, servers []metalv1alpha1.Server, claim *metalv1alpha1.ServerClaim) *metalv1alpha1.Server {
@@ -406,6 +897,7 @@ func (r *ServerClaimReconciler) claimFirstBestServer(ctx context.Context, log lo
 	}
 
 	log.V(1).Info("Trying to claim first best server")
+	var candidates []metalv1alpha1.Server
 	for _, server := range serverList.Items {
 		if server.Spec.ServerClaimRef != nil {
 			continue
@@ -413,10 +905,94 @@ func (r *ServerClaimReconciler) claimFirstBestServer(ctx context.Context, log lo
 		if server.Status.State != metalv1alpha1.ServerStateAvailable {
 			continue
 		}
-		return &server, nil
+		if _, ok := server.Labels[ServerBootFailedLabel]; ok {
+			continue
+		}
+		if serverHeld(&server) {
+			continue
+		}
+		candidates = append(candidates, server)
+	}
+	return r.admitPlacement(ctx, log, claim, candidates)
+}
+
+// admitPlacement returns the Server of the candidates the claim is bound to. A Server already bound to the claim is
+// returned as is. Otherwise, the candidates not granted to the namespace of the claim are dropped, the
+// PlacementAdmitter decides on the remaining ones, and a placementRejectedError is returned if it vetoes all of them.
+func (r *ServerClaimReconciler) admitPlacement(ctx context.Context, log logr.Logger, claim *metalv1alpha1.ServerClaim, candidates []metalv1alpha1.Server) (*metalv1alpha1.Server, error) {
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	for _, candidate := range candidates {
+		if claimRef := candidate.Spec.ServerClaimRef; claimRef != nil && claimRef.UID == claim.UID {
+			return &candidate, nil
+		}
+	}
+	candidates, err := grantedServers(ctx, r.Client, claim.Namespace, claim.Spec.Image, candidates)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		log.V(1).Info("No candidate server is granted to the namespace of the claim")
+		return nil, nil
+	}
+	admitted, reason, err := admitCandidates(ctx, log, r.PlacementAdmitter, claim, candidates)
+	if err != nil {
+		return nil, err
+	}
+	if len(admitted) == 0 {
+		return nil, &placementRejectedError{reason: reason}
+	}
+	return &admitted[0], nil
+}
+
+// admitCandidates returns the candidates the admitter admits for the claim in its order of preference. If it admits
+// none of them, the reason of the decision is returned. Without an admitter, all candidates are admitted in their
+// order.
+func admitCandidates(ctx context.Context, log logr.Logger, admitter placement.Admitter, claim *metalv1alpha1.ServerClaim, candidates []metalv1alpha1.Server) ([]metalv1alpha1.Server, string, error) {
+	if admitter == nil {
+		return candidates, "", nil
+	}
+
+	decision, err := admitter.Admit(ctx, claim, candidates)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to admit placement: %w", err)
+	}
+	log.V(1).Info("Admitted placement", "ServerClaim", claim.Name, "Servers", decision.Servers, "Reason", decision.Reason)
+	var admitted []metalv1alpha1.Server
+	for _, name := range decision.Servers {
+		for _, candidate := range candidates {
+			if candidate.Name == name {
+				admitted = append(admitted, candidate)
+			}
+		}
 	}
+	if len(admitted) == 0 && decision.Reason == "" {
+		return nil, "All candidate servers have been vetoed", nil
+	}
+	return admitted, decision.Reason, nil
+}
 
-	return nil, nil
+// placeClaims picks a distinct server of the candidates for each of the claims, in order, which the admitter admits
+// for the claim. The claims may not exist yet, so that servers can be admitted before they are handed over. If any
+// claim is left without a server, nothing is placed and the returned message explains why.
+func placeClaims(ctx context.Context, log logr.Logger, admitter placement.Admitter, claims []*metalv1alpha1.ServerClaim, candidates []metalv1alpha1.Server) ([]metalv1alpha1.Server, string, error) {
+	placed := make([]metalv1alpha1.Server, 0, len(claims))
+	remaining := slices.Clone(candidates)
+	for _, claim := range claims {
+		admitted, reason, err := admitCandidates(ctx, log, admitter, claim, remaining)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(admitted) == 0 {
+			return nil, fmt.Sprintf("The placement of ServerClaim %s has been rejected: %s", claim.Name, reason), nil
+		}
+		placed = append(placed, admitted[0])
+		remaining = slices.DeleteFunc(remaining, func(server metalv1alpha1.Server) bool {
+			return server.Name == admitted[0].Name
+		})
+	}
+	return placed, "", nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -424,13 +1000,86 @@ func (r *ServerClaimReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: 1,
+			NewQueue: func(name string, rateLimiter workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+				return newPriorityQueue(name, rateLimiter)
+			},
 		}).
 		For(&metalv1alpha1.ServerClaim{}).
+		// New ServerClaims are reconciled ahead of the events caused by Server status updates.
+		Watches(&metalv1alpha1.ServerClaim{}, enqueueExpedited(func(oldObj, _ client.Object) bool {
+			return oldObj == nil
+		})).
 		Owns(&metalv1alpha1.ServerBootConfiguration{}).
 		Watches(&metalv1alpha1.Server{}, r.enqueueServerClaimByRefs()).
+		Watches(&v1.Secret{}, r.enqueueServerClaimsByIgnitionSecret()).
+		Watches(&metalv1alpha1.ComponentFirmware{}, r.enqueueServerClaimByFirmwareServer()).
+		Watches(&metalv1alpha1.DriveFirmware{}, r.enqueueServerClaimByFirmwareServer()).
+		Watches(&metalv1alpha1.ImagePolicy{}, r.enqueueServerClaimsByImagePolicy()).
+		Watches(&metalv1alpha1.ServerGrant{}, r.enqueueServerClaimsByServerGrant()).
 		Complete(r)
 }
 
+// enqueueServerClaimsByImagePolicy enqueues all ServerClaims, as any of them may be affected by a changed policy.
+func (r *ServerClaimReconciler) enqueueServerClaimsByImagePolicy() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, _ client.Object) []reconcile.Request {
+		log := ctrl.LoggerFrom(ctx)
+		claimList := &metalv1alpha1.ServerClaimList{}
+		if err := r.List(ctx, claimList); err != nil {
+			log.Error(err, "failed to list server claims")
+			return nil
+		}
+		var req []reconcile.Request
+		for _, claim := range claimList.Items {
+			req = append(req, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: claim.Namespace, Name: claim.Name},
+			})
+		}
+		return req
+	})
+}
+
+// enqueueServerClaimsByServerGrant enqueues the ServerClaims of the grantee namespace of a changed ServerGrant, which
+// may have to release the servers of an expired grant.
+func (r *ServerClaimReconciler) enqueueServerClaimsByServerGrant() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
+		log := ctrl.LoggerFrom(ctx)
+		grant := object.(*metalv1alpha1.ServerGrant)
+		claimList := &metalv1alpha1.ServerClaimList{}
+		if err := r.List(ctx, claimList, client.InNamespace(grant.Spec.GranteeNamespace)); err != nil {
+			log.Error(err, "failed to list server claims")
+			return nil
+		}
+		var req []reconcile.Request
+		for _, claim := range claimList.Items {
+			req = append(req, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: claim.Namespace, Name: claim.Name},
+			})
+		}
+		return req
+	})
+}
+
+func (r *ServerClaimReconciler) enqueueServerClaimsByIgnitionSecret() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
+		log := ctrl.LoggerFrom(ctx)
+
+		claimList := &metalv1alpha1.ServerClaimList{}
+		if err := r.List(ctx, claimList, client.InNamespace(object.GetNamespace())); err != nil {
+			log.Error(err, "failed to list server claims")
+			return nil
+		}
+		var req []reconcile.Request
+		for _, claim := range claimList.Items {
+			if claim.Spec.IgnitionSecretRef != nil && claim.Spec.IgnitionSecretRef.Name == object.GetName() {
+				req = append(req, reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: claim.Namespace, Name: claim.Name},
+				})
+			}
+		}
+		return req
+	})
+}
+
 func (r *ServerClaimReconciler) enqueueServerClaimByRefs() handler.EventHandler {
 	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
 		log := ctrl.LoggerFrom(ctx)
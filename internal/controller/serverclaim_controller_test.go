@@ -6,6 +6,7 @@ package controller
 import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/stmcginnis/gofish/redfish"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -103,10 +104,26 @@ var _ = Describe("ServerClaim Controller", func() {
 		Eventually(Object(claim)).Should(SatisfyAll(
 			HaveField("Finalizers", ContainElement(ServerClaimFinalizer)),
 			HaveField("Status.Phase", metalv1alpha1.PhaseBound),
+			HaveField("Status.ImageDigest", Not(BeEmpty())),
 			HaveField("Spec.ServerRef", Not(BeNil())),
 			HaveField("Spec.ServerRef.Name", server.Name),
 		))
 
+		By("Ensuring that the ServerClaim reports the binding pipeline")
+		Eventually(Object(claim)).Should(HaveField("Status.Conditions", ContainElements(
+			SatisfyAll(
+				HaveField("Type", ServerClaimConditionServerSelected),
+				HaveField("Status", metav1.ConditionTrue),
+			),
+			HaveField("Type", ServerClaimConditionBootConfigurationReady),
+			HaveField("Type", ServerClaimConditionIgnitionRendered),
+			HaveField("Type", ServerClaimConditionServerPoweredOn),
+			SatisfyAll(
+				HaveField("Type", ServerClaimConditionBootVerified),
+				HaveField("Status", metav1.ConditionUnknown),
+			),
+		)))
+
 		By("Ensuring that the ServerBootConfiguration has been created")
 		config := &metalv1alpha1.ServerBootConfiguration{
 			ObjectMeta: metav1.ObjectMeta{
@@ -427,6 +444,199 @@ var _ = Describe("ServerClaim Controller", func() {
 		))
 	})
 
+	It("should not claim a server for a claim with a missing image", func(ctx SpecContext) {
+		By("Patching the Server to available state")
+		Eventually(UpdateStatus(server, func() {
+			server.Status.State = metalv1alpha1.ServerStateAvailable
+			server.Status.PowerState = metalv1alpha1.ServerOffPowerState
+		})).Should(Succeed())
+
+		By("Creating a ServerClaim with a missing image")
+		claim := &metalv1alpha1.ServerClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:    ns.Name,
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.ServerClaimSpec{
+				Power:     metalv1alpha1.PowerOn,
+				ServerRef: &v1.LocalObjectReference{Name: server.Name},
+				Image:     missingImageRepository + ":latest",
+			},
+		}
+		Expect(k8sClient.Create(ctx, claim)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, claim)
+
+		By("Ensuring that the ServerClaim reports the failed image verification")
+		Eventually(Object(claim)).Should(SatisfyAll(
+			HaveField("Status.Phase", metalv1alpha1.PhaseUnbound),
+			HaveField("Status.ImageDigest", BeEmpty()),
+			HaveField("Status.Conditions", ContainElement(SatisfyAll(
+				HaveField("Type", ServerClaimConditionImageVerified),
+				HaveField("Status", metav1.ConditionFalse),
+				HaveField("Reason", "ImageResolutionFailed"),
+			))),
+		))
+
+		By("Ensuring that the Server has no claim ref")
+		Consistently(Object(server)).Should(HaveField("Spec.ServerClaimRef", BeNil()))
+	})
+
+	It("should not claim a server whose placement is vetoed", func(ctx SpecContext) {
+		By("Patching the Server to available state")
+		Eventually(UpdateStatus(server, func() {
+			server.Status.State = metalv1alpha1.ServerStateAvailable
+			server.Status.PowerState = metalv1alpha1.ServerOffPowerState
+		})).Should(Succeed())
+
+		By("Creating a ServerClaim whose placement is vetoed")
+		claim := &metalv1alpha1.ServerClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:    ns.Name,
+				GenerateName: "test-",
+				Labels:       map[string]string{vetoedPlacementLabel: ""},
+			},
+			Spec: metalv1alpha1.ServerClaimSpec{
+				Power:     metalv1alpha1.PowerOn,
+				ServerRef: &v1.LocalObjectReference{Name: server.Name},
+				Image:     "foo:bar",
+			},
+		}
+		Expect(k8sClient.Create(ctx, claim)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, claim)
+
+		By("Ensuring that the ServerClaim reports the rejected placement")
+		Eventually(Object(claim)).Should(SatisfyAll(
+			HaveField("Status.Phase", metalv1alpha1.PhaseUnbound),
+			HaveField("Status.Conditions", ContainElement(SatisfyAll(
+				HaveField("Type", ServerClaimConditionServerSelected),
+				HaveField("Status", metav1.ConditionFalse),
+				HaveField("Reason", "PlacementRejected"),
+				HaveField("Message", "Vetoed by test"),
+			))),
+		))
+
+		By("Ensuring that the Server has no claim ref")
+		Consistently(Object(server)).Should(HaveField("Spec.ServerClaimRef", BeNil()))
+	})
+
+	It("should block the reservation of a server while a pre-transition hook is present", func(ctx SpecContext) {
+		By("Adding a pre-transition hook to the Server")
+		hook := metalv1alpha1.PreTransitionHookAnnotationPrefix(metalv1alpha1.ServerStateReserved) + "ipam"
+		Eventually(Update(server, func() {
+			metav1.SetMetaDataAnnotation(&server.ObjectMeta, hook, "")
+		})).Should(Succeed())
+
+		By("Patching the Server to available state")
+		Eventually(UpdateStatus(server, func() {
+			server.Status.State = metalv1alpha1.ServerStateAvailable
+		})).Should(Succeed())
+
+		By("Creating a ServerClaim")
+		claim := &metalv1alpha1.ServerClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:    ns.Name,
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.ServerClaimSpec{
+				Power:     metalv1alpha1.PowerOff,
+				ServerRef: &v1.LocalObjectReference{Name: server.Name},
+				Image:     "foo:bar",
+			},
+		}
+		Expect(k8sClient.Create(ctx, claim)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, claim)
+
+		By("Ensuring that the transition of the Server is blocked")
+		Eventually(Object(server)).Should(SatisfyAll(
+			HaveField("Spec.ServerClaimRef.Name", claim.Name),
+			HaveField("Status.State", metalv1alpha1.ServerStateAvailable),
+			HaveField("Status.Conditions", ContainElement(SatisfyAll(
+				HaveField("Type", ServerConditionTransitionBlocked),
+				HaveField("Status", metav1.ConditionTrue),
+			))),
+		))
+
+		By("Removing the pre-transition hook")
+		Eventually(Update(server, func() {
+			delete(server.Annotations, hook)
+		})).Should(Succeed())
+
+		By("Ensuring that the Server is reserved")
+		Eventually(Object(server)).Should(SatisfyAll(
+			HaveField("Status.State", metalv1alpha1.ServerStateReserved),
+			HaveField("Status.Conditions", Not(ContainElement(HaveField("Type", ServerConditionTransitionBlocked)))),
+		))
+	})
+
+	It("should re-render the boot configuration when the ignition changes", func(ctx SpecContext) {
+		By("Creating an ignition secret")
+		ignitionSecret := &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:    ns.Name,
+				GenerateName: "test-",
+			},
+			Data: map[string][]byte{
+				DefaultIgnitionSecretKeyName: []byte(`{"ignition":{"version":"3.4.0"}}`),
+			},
+		}
+		Expect(k8sClient.Create(ctx, ignitionSecret)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ignitionSecret)
+
+		By("Patching the Server to available state")
+		Eventually(UpdateStatus(server, func() {
+			server.Status.State = metalv1alpha1.ServerStateAvailable
+		})).Should(Succeed())
+
+		By("Creating a ServerClaim with the Reboot policy")
+		claim := &metalv1alpha1.ServerClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:    ns.Name,
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.ServerClaimSpec{
+				Power:                metalv1alpha1.PowerOff,
+				ServerRef:            &v1.LocalObjectReference{Name: server.Name},
+				Image:                "foo:bar",
+				IgnitionSecretRef:    &v1.LocalObjectReference{Name: ignitionSecret.Name},
+				IgnitionUpdatePolicy: metalv1alpha1.IgnitionUpdatePolicyReboot,
+			},
+		}
+		Expect(k8sClient.Create(ctx, claim)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, claim)
+
+		By("Ensuring that the boot configuration is rendered with the hash of the ignition")
+		Eventually(Object(claim)).Should(SatisfyAll(
+			HaveField("Status.IgnitionHash", Not(BeEmpty())),
+			HaveField("Status.Conditions", ContainElement(SatisfyAll(
+				HaveField("Type", ServerClaimConditionIgnitionUpToDate),
+				HaveField("Status", metav1.ConditionTrue),
+			))),
+		))
+		initialHash := claim.Status.IgnitionHash
+		config := &metalv1alpha1.ServerBootConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name, Name: claim.Name},
+		}
+		Eventually(Object(config)).Should(
+			HaveField("ObjectMeta.Annotations", HaveKeyWithValue(metalv1alpha1.IgnitionHashAnnotation, initialHash)))
+
+		By("Changing the ignition")
+		Eventually(Update(ignitionSecret, func() {
+			ignitionSecret.Data[DefaultIgnitionSecretKeyName] = []byte(`{"ignition":{"version":"3.4.0"},"passwd":{}}`)
+		})).Should(Succeed())
+
+		By("Ensuring that the boot configuration is re-rendered")
+		Eventually(Object(claim)).Should(HaveField("Status.IgnitionHash", Not(Equal(initialHash))))
+		Eventually(Object(config)).Should(HaveField("ObjectMeta.Annotations",
+			HaveKeyWithValue(metalv1alpha1.IgnitionHashAnnotation, claim.Status.IgnitionHash)))
+
+		By("Ensuring that the powered off Server boots the changed ignition")
+		Eventually(Object(claim)).Should(HaveField("Status.Conditions", ContainElement(SatisfyAll(
+			HaveField("Type", ServerClaimConditionIgnitionUpToDate),
+			HaveField("Status", metav1.ConditionTrue),
+			HaveField("Message", "Server boots the changed ignition on its next power on"),
+		))))
+	})
+
 	It("should allow deletion of ServerClaim without a Server", func(ctx SpecContext) {
 		By("Creating a ServerClaim")
 		claim := &metalv1alpha1.ServerClaim{
@@ -548,3 +758,57 @@ var _ = Describe("ServerClaim Validation", func() {
 			HaveField("Spec.ServerSelector.MatchLabels", Equal(map[string]string{"foo": "bar"}))))
 	})
 })
+
+var _ = Describe("ServerClaim Ignition Restart", func() {
+	ns := SetupTest()
+
+	It("Should only restart the Server once the boot configuration is rendered with the changed ignition", func(ctx SpecContext) {
+		registerSimulator("10.30.0.11:8000", "38947555-7742-3448-3784-823347823844")
+		server := createPausedServer(ctx, "10.30.0.11", "38947555-7742-3448-3784-823347823844")
+		claim := &metalv1alpha1.ServerClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name, Name: "ignition-restart"},
+			Spec: metalv1alpha1.ServerClaimSpec{
+				ServerRef:            &v1.LocalObjectReference{Name: server.Name},
+				IgnitionUpdatePolicy: metalv1alpha1.IgnitionUpdatePolicyReboot,
+			},
+			Status: metalv1alpha1.ServerClaimStatus{IgnitionHash: "changed"},
+		}
+
+		By("Creating a boot configuration which is still ready from the previous rendering")
+		config := &metalv1alpha1.ServerBootConfiguration{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   ns.Name,
+				Name:        claim.Name,
+				Annotations: map[string]string{metalv1alpha1.IgnitionHashAnnotation: "changed"},
+			},
+			Spec: metalv1alpha1.ServerBootConfigurationSpec{
+				ServerRef: v1.LocalObjectReference{Name: server.Name},
+			},
+		}
+		Expect(k8sClient.Create(ctx, config)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, config)
+		Eventually(Object(config)).Should(HaveField("Status.State", metalv1alpha1.ServerBootConfigurationStatePending))
+		Eventually(UpdateStatus(config, func() {
+			config.Status.State = metalv1alpha1.ServerBootConfigurationStateReady
+			config.Status.ObservedIgnitionHash = "previous"
+		})).Should(Succeed())
+
+		reconciler := &ServerClaimReconciler{Client: k8sClient}
+		requested, err := reconciler.requestIgnitionRestart(ctx, GinkgoLogr, claim, server)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requested).To(BeFalse())
+		Expect(Object(server)()).To(HaveField("ObjectMeta.Annotations", Not(HaveKey(metalv1alpha1.OperationAnnotation))))
+
+		By("Restarting the Server once the boot operator rendered the changed ignition")
+		Eventually(UpdateStatus(config, func() {
+			config.Status.ObservedIgnitionHash = "changed"
+		})).Should(Succeed())
+		Eventually(func(g Gomega) {
+			requested, err := reconciler.requestIgnitionRestart(ctx, GinkgoLogr, claim, server)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(requested).To(BeTrue())
+		}).Should(Succeed())
+		Expect(Object(server)()).To(HaveField("ObjectMeta.Annotations",
+			HaveKeyWithValue(metalv1alpha1.OperationAnnotation, string(redfish.GracefulRestartResetType))))
+	})
+})
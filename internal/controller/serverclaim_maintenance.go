@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// MaintenanceNotice describes a maintenance requested for a claimed Server. It is sent to the maintenance webhook
+// of the claim.
+type MaintenanceNotice struct {
+	// Namespace and Name identify the ServerClaim.
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Server is the name of the claimed Server.
+	Server string `json:"server"`
+	// Operations are the requested operations, e.g. GracefulRestart or ComponentFirmware/bios-update.
+	Operations []string `json:"operations"`
+	// NotBefore and NotAfter are the window of an operation scheduled with the operation annotations.
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+	NotAfter  *metav1.Time `json:"notAfter,omitempty"`
+	// Message summarizes the requested maintenance.
+	Message string `json:"message"`
+}
+
+var maintenanceWebhookClient = &http.Client{Timeout: 10 * time.Second}
+
+// maintenanceNotice returns the maintenance requested for the claimed Server, or nil if there is none. Requested
+// maintenance are disruptive operations annotated on the Server and firmware updates waiting for the Server to
+// be released.
+func (r *ServerClaimReconciler) maintenanceNotice(ctx context.Context, claim *metalv1alpha1.ServerClaim, server *metalv1alpha1.Server) (*MaintenanceNotice, error) {
+	notice := &MaintenanceNotice{Namespace: claim.Namespace, Name: claim.Name, Server: server.Name}
+
+	switch operation := server.Annotations[metalv1alpha1.OperationAnnotation]; operation {
+	case "", metalv1alpha1.OperationAnnotationIgnore, metalv1alpha1.OperationAnnotationReplayDiscovery,
+		metalv1alpha1.OperationAnnotationRediscover, metalv1alpha1.OperationAnnotationClearError,
+		metalv1alpha1.OperationAnnotationRekeySystemUUID:
+	default:
+		notice.Operations = append(notice.Operations, operation)
+		for annotation, field := range map[string]**metav1.Time{
+			metalv1alpha1.OperationNotBeforeAnnotation: &notice.NotBefore,
+			metalv1alpha1.OperationNotAfterAnnotation:  &notice.NotAfter,
+		} {
+			if value, ok := server.Annotations[annotation]; ok {
+				if t, err := time.Parse(time.RFC3339, value); err == nil {
+					*field = &metav1.Time{Time: t}
+				}
+			}
+		}
+	}
+
+	componentFirmwares := &metalv1alpha1.ComponentFirmwareList{}
+	if err := r.List(ctx, componentFirmwares); err != nil {
+		return nil, fmt.Errorf("failed to list ComponentFirmwares: %w", err)
+	}
+	for _, item := range componentFirmwares.Items {
+		if item.Spec.ServerRef.Name == server.Name &&
+			(item.Status.State == "" || item.Status.State == metalv1alpha1.ComponentFirmwareStatePending) {
+			notice.Operations = append(notice.Operations, "ComponentFirmware/"+item.Name)
+		}
+	}
+	driveFirmwares := &metalv1alpha1.DriveFirmwareList{}
+	if err := r.List(ctx, driveFirmwares); err != nil {
+		return nil, fmt.Errorf("failed to list DriveFirmwares: %w", err)
+	}
+	for _, item := range driveFirmwares.Items {
+		if item.Spec.ServerRef.Name == server.Name &&
+			(item.Status.State == "" || item.Status.State == metalv1alpha1.DriveFirmwareStatePending) {
+			notice.Operations = append(notice.Operations, "DriveFirmware/"+item.Name)
+		}
+	}
+
+	if len(notice.Operations) == 0 {
+		return nil, nil
+	}
+	notice.Message = fmt.Sprintf("Maintenance requested for server %s: %s", server.Name, strings.Join(notice.Operations, ", "))
+	if notice.NotBefore != nil {
+		notice.Message += fmt.Sprintf(", not before %s", notice.NotBefore.UTC().Format(time.RFC3339))
+	}
+	if notice.NotAfter != nil {
+		notice.Message += fmt.Sprintf(", not after %s", notice.NotAfter.UTC().Format(time.RFC3339))
+	}
+	return notice, nil
+}
+
+// updateMaintenanceCondition reports the maintenance requested for the claimed Server in the MaintenancePending
+// condition of the claim. New notices are sent to the maintenance webhook of the claim, if the manager allows them.
+func (r *ServerClaimReconciler) updateMaintenanceCondition(ctx context.Context, claim *metalv1alpha1.ServerClaim, server *metalv1alpha1.Server) error {
+	notice, err := r.maintenanceNotice(ctx, claim, server)
+	if err != nil {
+		return err
+	}
+	if notice == nil {
+		setServerClaimCondition(claim, ServerClaimConditionMaintenancePending, metav1.ConditionFalse,
+			"NoMaintenance", "No maintenance is requested for the server")
+		return nil
+	}
+
+	current := meta.FindStatusCondition(claim.Status.Conditions, ServerClaimConditionMaintenancePending)
+	if current == nil || current.Status != metav1.ConditionTrue || current.Message != notice.Message {
+		if url := claim.Annotations[metalv1alpha1.MaintenanceWebhookAnnotation]; url != "" && r.MaintenanceWebhooks {
+			if err := sendMaintenanceNotice(ctx, url, notice); err != nil {
+				return err
+			}
+		}
+	}
+	setServerClaimCondition(claim, ServerClaimConditionMaintenancePending, metav1.ConditionTrue,
+		"MaintenanceRequested", notice.Message)
+	return nil
+}
+
+func sendMaintenanceNotice(ctx context.Context, url string, notice *MaintenanceNotice) error {
+	body, err := json.Marshal(notice)
+	if err != nil {
+		return fmt.Errorf("failed to marshal maintenance notice: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create maintenance notice request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := maintenanceWebhookClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send maintenance notice: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("maintenance webhook responded with status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// enqueueServerClaimByFirmwareServer enqueues the claim of the Server a firmware update is requested for.
+func (r *ServerClaimReconciler) enqueueServerClaimByFirmwareServer() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
+		var serverName string
+		switch firmware := object.(type) {
+		case *metalv1alpha1.ComponentFirmware:
+			serverName = firmware.Spec.ServerRef.Name
+		case *metalv1alpha1.DriveFirmware:
+			serverName = firmware.Spec.ServerRef.Name
+		}
+		server := &metalv1alpha1.Server{}
+		if err := r.Get(ctx, client.ObjectKey{Name: serverName}, server); err != nil || server.Spec.ServerClaimRef == nil {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{
+			Namespace: server.Spec.ServerClaimRef.Namespace,
+			Name:      server.Spec.ServerClaimRef.Name,
+		}}}
+	})
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("ServerClaim Maintenance Notices", func() {
+	It("should notify the claim of maintenance requested for its server", func(ctx SpecContext) {
+		var notices []MaintenanceNotice
+		webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			notice := MaintenanceNotice{}
+			Expect(json.NewDecoder(r.Body).Decode(&notice)).To(Succeed())
+			notices = append(notices, notice)
+		}))
+		DeferCleanup(webhook.Close)
+
+		server := &metalv1alpha1.Server{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "server",
+				Annotations: map[string]string{
+					metalv1alpha1.OperationAnnotation:          "GracefulRestart",
+					metalv1alpha1.OperationNotBeforeAnnotation: "2025-01-01T02:00:00Z",
+				},
+			},
+		}
+		claim := &metalv1alpha1.ServerClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "claim",
+				Annotations: map[string]string{metalv1alpha1.MaintenanceWebhookAnnotation: webhook.URL},
+			},
+		}
+		server.Spec.ServerClaimRef = &v1.ObjectReference{Namespace: claim.Namespace, Name: claim.Name}
+		firmware := &metalv1alpha1.ComponentFirmware{
+			ObjectMeta: metav1.ObjectMeta{Name: "bios-update"},
+			Spec:       metalv1alpha1.ComponentFirmwareSpec{ServerRef: v1.LocalObjectReference{Name: server.Name}},
+		}
+		reconciler := &ServerClaimReconciler{
+			Client:              fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(server, claim, firmware).Build(),
+			MaintenanceWebhooks: true,
+		}
+
+		By("Ensuring that the requested maintenance is reported and sent to the webhook")
+		Expect(reconciler.updateMaintenanceCondition(ctx, claim, server)).To(Succeed())
+		condition := meta.FindStatusCondition(claim.Status.Conditions, ServerClaimConditionMaintenancePending)
+		Expect(condition).To(HaveField("Status", metav1.ConditionTrue))
+		Expect(notices).To(ConsistOf(SatisfyAll(
+			HaveField("Server", "server"),
+			HaveField("Operations", ConsistOf("GracefulRestart", "ComponentFirmware/bios-update")),
+			HaveField("NotBefore", Not(BeNil())),
+		)))
+
+		By("Ensuring that an unchanged notice is not sent again")
+		Expect(reconciler.updateMaintenanceCondition(ctx, claim, server)).To(Succeed())
+		Expect(notices).To(HaveLen(1))
+
+		By("Ensuring that the condition is cleared once the maintenance has been performed")
+		delete(server.Annotations, metalv1alpha1.OperationAnnotation)
+		Expect(reconciler.Delete(ctx, firmware)).To(Succeed())
+		Expect(reconciler.updateMaintenanceCondition(ctx, claim, server)).To(Succeed())
+		Expect(meta.FindStatusCondition(claim.Status.Conditions, ServerClaimConditionMaintenancePending)).To(
+			HaveField("Status", metav1.ConditionFalse))
+	})
+})
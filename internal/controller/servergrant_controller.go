@@ -0,0 +1,256 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ServerGrantReconciler reconciles a ServerGrant object
+type ServerGrantReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=metal.ironcore.dev,resources=servergrants,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=metal.ironcore.dev,resources=servergrants/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=metal.ironcore.dev,resources=servers,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *ServerGrantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	grant := &metalv1alpha1.ServerGrant{}
+	if err := r.Get(ctx, req.NamespacedName, grant); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !grant.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	poolServers, err := listPoolServers(ctx, r.Client, grant.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	grantBase := grant.DeepCopy()
+	grant.Status.State = metalv1alpha1.ServerGrantStateActive
+	grant.Status.ExpirationTime = nil
+	if expiration, ok := serverGrantExpiration(grant); ok {
+		grant.Status.ExpirationTime = &metav1.Time{Time: expiration}
+		if !time.Now().Before(expiration) {
+			grant.Status.State = metalv1alpha1.ServerGrantStateExpired
+		}
+	}
+	grant.Status.ClaimedServers = nil
+	for _, server := range serverGrantClaimedServers(grant, poolServers) {
+		grant.Status.ClaimedServers = append(grant.Status.ClaimedServers, v1.LocalObjectReference{Name: server.Name})
+	}
+	if err := r.Status().Patch(ctx, grant, client.MergeFrom(grantBase)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to patch ServerGrant status: %w", err)
+	}
+	log.V(1).Info("Reconciled ServerGrant", "State", grant.Status.State, "ClaimedServers", len(grant.Status.ClaimedServers))
+
+	if grant.Status.State == metalv1alpha1.ServerGrantStateActive && grant.Status.ExpirationTime != nil {
+		return ctrl.Result{RequeueAfter: time.Until(grant.Status.ExpirationTime.Time)}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// listPoolServers returns the servers of the pool of the namespace, sorted by name.
+func listPoolServers(ctx context.Context, c client.Reader, namespace string) ([]metalv1alpha1.Server, error) {
+	serverList := &metalv1alpha1.ServerList{}
+	if err := c.List(ctx, serverList, client.MatchingLabels{metalv1alpha1.OwnerNamespaceLabel: namespace}); err != nil {
+		return nil, fmt.Errorf("failed to list servers of the pool of namespace %s: %w", namespace, err)
+	}
+	servers := serverList.Items
+	slices.SortFunc(servers, func(a, b metalv1alpha1.Server) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return servers, nil
+}
+
+// serverGrantExpiration returns the time the grant expires at, if it has a duration.
+func serverGrantExpiration(grant *metalv1alpha1.ServerGrant) (time.Time, bool) {
+	if grant.Spec.Duration == nil {
+		return time.Time{}, false
+	}
+	return grant.CreationTimestamp.Add(grant.Spec.Duration.Duration), true
+}
+
+// serverGrantSelects reports whether the server is granted by the grant. The server has to be part of the pool of
+// the namespace of the grant, listed in its servers if any, and matched by its selector if any.
+func serverGrantSelects(grant *metalv1alpha1.ServerGrant, server *metalv1alpha1.Server) bool {
+	if server.Labels[metalv1alpha1.OwnerNamespaceLabel] != grant.Namespace {
+		return false
+	}
+	if len(grant.Spec.Servers) > 0 && !slices.ContainsFunc(grant.Spec.Servers, func(ref v1.LocalObjectReference) bool {
+		return ref.Name == server.Name
+	}) {
+		return false
+	}
+	if grant.Spec.ServerSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(grant.Spec.ServerSelector)
+		if err != nil || !selector.Matches(labels.Set(server.Labels)) {
+			return false
+		}
+	}
+	return true
+}
+
+// serverGrantAllowsImage reports whether the image may be booted on the servers granted by the grant. The allowed
+// images of the grant are matched like those of an ImagePolicy.
+func serverGrantAllowsImage(grant *metalv1alpha1.ServerGrant, image string) bool {
+	if len(grant.Spec.AllowedImages) == 0 {
+		return true
+	}
+	return metalv1alpha1.ImageAllowed([]metalv1alpha1.ImagePolicy{{
+		Spec: metalv1alpha1.ImagePolicySpec{AllowedImages: grant.Spec.AllowedImages},
+	}}, image)
+}
+
+// serverGrantClaimedServers returns the servers of the pool granted by the grant which are claimed by the grantee
+// namespace.
+func serverGrantClaimedServers(grant *metalv1alpha1.ServerGrant, poolServers []metalv1alpha1.Server) []metalv1alpha1.Server {
+	var claimed []metalv1alpha1.Server
+	for _, server := range poolServers {
+		if ref := server.Spec.ServerClaimRef; ref != nil && ref.Namespace == grant.Spec.GranteeNamespace && serverGrantSelects(grant, &server) {
+			claimed = append(claimed, server)
+		}
+	}
+	return claimed
+}
+
+// grantedServers returns the servers which may be claimed by a claim in the namespace booting the image. Servers
+// without a pool and servers of the pool of the namespace itself are always returned. Servers of the pool of another
+// namespace are only returned if an active ServerGrant of that namespace delegates them to the namespace, allows the
+// image and has not reached its maximum number of servers yet. The order of the servers is kept.
+func grantedServers(ctx context.Context, c client.Reader, namespace, image string, servers []metalv1alpha1.Server) ([]metalv1alpha1.Server, error) {
+	var granted []metalv1alpha1.Server
+	grantsByOwner := map[string][]metalv1alpha1.ServerGrant{}
+	remaining := map[types.UID]int{}
+	for _, server := range servers {
+		owner, ok := server.Labels[metalv1alpha1.OwnerNamespaceLabel]
+		if !ok || owner == namespace {
+			granted = append(granted, server)
+			continue
+		}
+
+		grants, ok := grantsByOwner[owner]
+		if !ok {
+			var err error
+			if grants, err = activeServerGrants(ctx, c, owner, namespace); err != nil {
+				return nil, err
+			}
+			grantsByOwner[owner] = grants
+			if len(grants) > 0 {
+				poolServers, err := listPoolServers(ctx, c, owner)
+				if err != nil {
+					return nil, err
+				}
+				for _, grant := range grants {
+					if grant.Spec.MaxServers == nil {
+						remaining[grant.UID] = len(poolServers)
+						continue
+					}
+					remaining[grant.UID] = int(*grant.Spec.MaxServers) - len(serverGrantClaimedServers(&grant, poolServers))
+				}
+			}
+		}
+
+		for _, grant := range grants {
+			if remaining[grant.UID] > 0 && serverGrantAllowsImage(&grant, image) && serverGrantSelects(&grant, &server) {
+				remaining[grant.UID]--
+				granted = append(granted, server)
+				break
+			}
+		}
+	}
+	return granted, nil
+}
+
+// serverGranted reports whether a claim in the namespace booting the image may keep the server it is bound to.
+// Servers without a pool and servers of the pool of the namespace itself are always kept. Servers of the pool of
+// another namespace are only kept as long as an active ServerGrant of that namespace delegates them to the namespace
+// and allows the image. The maximum number of servers of the grants is not checked, as the server already counts
+// against it.
+func serverGranted(ctx context.Context, c client.Reader, namespace, image string, server *metalv1alpha1.Server) (bool, error) {
+	owner, ok := server.Labels[metalv1alpha1.OwnerNamespaceLabel]
+	if !ok || owner == namespace {
+		return true, nil
+	}
+	grants, err := activeServerGrants(ctx, c, owner, namespace)
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(grants, func(grant metalv1alpha1.ServerGrant) bool {
+		return serverGrantAllowsImage(&grant, image) && serverGrantSelects(&grant, server)
+	}), nil
+}
+
+// activeServerGrants returns the ServerGrants of the owner namespace delegating servers to the grantee namespace
+// which have not expired.
+func activeServerGrants(ctx context.Context, c client.Reader, owner, grantee string) ([]metalv1alpha1.ServerGrant, error) {
+	grantList := &metalv1alpha1.ServerGrantList{}
+	if err := c.List(ctx, grantList, client.InNamespace(owner)); err != nil {
+		return nil, fmt.Errorf("failed to list server grants of namespace %s: %w", owner, err)
+	}
+	var grants []metalv1alpha1.ServerGrant
+	for _, grant := range grantList.Items {
+		if grant.Spec.GranteeNamespace != grantee || !grant.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if expiration, ok := serverGrantExpiration(&grant); ok && !time.Now().Before(expiration) {
+			continue
+		}
+		grants = append(grants, grant)
+	}
+	return grants, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ServerGrantReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&metalv1alpha1.ServerGrant{}).
+		Watches(&metalv1alpha1.Server{}, r.enqueueServerGrantsByOwner()).
+		Complete(r)
+}
+
+// enqueueServerGrantsByOwner enqueues the ServerGrants of the namespace owning a changed Server.
+func (r *ServerGrantReconciler) enqueueServerGrantsByOwner() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
+		log := ctrl.LoggerFrom(ctx)
+		owner, ok := object.GetLabels()[metalv1alpha1.OwnerNamespaceLabel]
+		if !ok {
+			return nil
+		}
+
+		grantList := &metalv1alpha1.ServerGrantList{}
+		if err := r.List(ctx, grantList, client.InNamespace(owner)); err != nil {
+			log.Error(err, "failed to list server grants")
+			return nil
+		}
+		var req []reconcile.Request
+		for _, grant := range grantList.Items {
+			req = append(req, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: grant.Namespace, Name: grant.Name},
+			})
+		}
+		return req
+	})
+}
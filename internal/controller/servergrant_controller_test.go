@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+)
+
+var _ = Describe("ServerGrant Controller", func() {
+	ns := SetupTest()
+
+	It("should report the expiration of a grant", func(ctx SpecContext) {
+		By("Creating a ServerGrant valid for an hour")
+		grant := &metalv1alpha1.ServerGrant{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:    ns.Name,
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.ServerGrantSpec{
+				GranteeNamespace: "grantee",
+				Duration:         &metav1.Duration{Duration: time.Hour},
+			},
+		}
+		Expect(k8sClient.Create(ctx, grant)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, grant)
+
+		By("Ensuring that the ServerGrant is active")
+		Eventually(Object(grant)).Should(SatisfyAll(
+			HaveField("Status.State", metalv1alpha1.ServerGrantStateActive),
+			HaveField("Status.ExpirationTime", Not(BeNil())),
+			HaveField("Status.ClaimedServers", BeEmpty()),
+		))
+
+		By("Creating a ServerGrant valid for a second")
+		expiring := &metalv1alpha1.ServerGrant{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:    ns.Name,
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.ServerGrantSpec{
+				GranteeNamespace: "grantee",
+				Duration:         &metav1.Duration{Duration: time.Second},
+			},
+		}
+		Expect(k8sClient.Create(ctx, expiring)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, expiring)
+
+		By("Ensuring that the ServerGrant expires")
+		Eventually(Object(expiring)).Should(HaveField("Status.State", metalv1alpha1.ServerGrantStateExpired))
+	})
+})
+
+var _ = Describe("ServerGrant Release", func() {
+	ns := SetupTest()
+
+	var (
+		owner  *v1.Namespace
+		grant  *metalv1alpha1.ServerGrant
+		claim  *metalv1alpha1.ServerClaim
+		server *metalv1alpha1.Server
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		owner = &v1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: "test-"}}
+		Expect(k8sClient.Create(ctx, owner)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, owner)
+
+		grant = &metalv1alpha1.ServerGrant{
+			ObjectMeta: metav1.ObjectMeta{Namespace: owner.Name, GenerateName: "test-"},
+			Spec: metalv1alpha1.ServerGrantSpec{
+				GranteeNamespace: ns.Name,
+				AllowedImages:    []string{"registry.example.com/os"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, grant)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, grant)
+
+		By("Binding a Server of the pool of the owner namespace to a paused claim of the grantee namespace")
+		paused := map[string]string{
+			metalv1alpha1.PausedUntilAnnotation: time.Now().Add(time.Hour).Format(time.RFC3339),
+		}
+		claim = &metalv1alpha1.ServerClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name, GenerateName: "test-", Annotations: paused},
+			Spec: metalv1alpha1.ServerClaimSpec{
+				Power: metalv1alpha1.PowerOff,
+				Image: "registry.example.com/os:1.0",
+			},
+		}
+		Expect(k8sClient.Create(ctx, claim)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, claim)
+		server = &metalv1alpha1.Server{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Labels:       map[string]string{metalv1alpha1.OwnerNamespaceLabel: owner.Name},
+				Annotations:  paused,
+			},
+			Spec: metalv1alpha1.ServerSpec{
+				SystemUUID: "38947555-7742-3448-3784-823347823853",
+				ServerClaimRef: &v1.ObjectReference{
+					Namespace: claim.Namespace,
+					Name:      claim.Name,
+					UID:       claim.UID,
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, server)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, server)
+		Eventually(Update(claim, func() {
+			claim.Spec.ServerRef = &v1.LocalObjectReference{Name: server.Name}
+		})).Should(Succeed())
+	})
+
+	It("should release the server once the grant does not allow the image of the claim", func(ctx SpecContext) {
+		reconciler := &ServerClaimReconciler{Client: k8sClient}
+		Expect(reconciler.checkServerGrant(ctx, GinkgoLogr, claim)).To(BeTrue())
+
+		By("Changing the image of the claim to another repository sharing the prefix")
+		Eventually(Update(claim, func() {
+			claim.Spec.Image = "registry.example.com/osx:1.0"
+		})).Should(Succeed())
+		Expect(reconciler.checkServerGrant(ctx, GinkgoLogr, claim)).To(BeFalse())
+		Eventually(Object(server)).Should(HaveField("Spec.ServerClaimRef", BeNil()))
+		Eventually(Object(claim)).Should(HaveField("Status.Conditions", ContainElement(SatisfyAll(
+			HaveField("Type", ServerClaimConditionServerSelected),
+			HaveField("Status", metav1.ConditionFalse),
+			HaveField("Reason", "ServerGrantRevoked"),
+		))))
+	})
+
+	It("should release the server once the grant expired", func(ctx SpecContext) {
+		reconciler := &ServerClaimReconciler{Client: k8sClient}
+		Eventually(Update(grant, func() {
+			grant.Spec.Duration = &metav1.Duration{Duration: time.Second}
+		})).Should(Succeed())
+
+		Eventually(func() (bool, error) {
+			return reconciler.checkServerGrant(ctx, GinkgoLogr, claim)
+		}).Should(BeFalse())
+		Eventually(Object(server)).Should(HaveField("Spec.ServerClaimRef", BeNil()))
+	})
+})
@@ -0,0 +1,354 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/ironcore-dev/controller-utils/clientutils"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/internal/placement"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	ServerReservationFinalizer = "metal.ironcore.dev/serverreservation"
+
+	// ServerHeldByLabel holds the UID of the ServerReservation holding a Server. Held Servers are not claimed by
+	// other claims.
+	ServerHeldByLabel = "metal.ironcore.dev/held-by"
+	// ServerReservationLabel holds the name of the ServerReservation a ServerClaim has been created for.
+	ServerReservationLabel = "metal.ironcore.dev/server-reservation"
+
+	// ServerReservationConditionServersHeld reports whether all servers of a ServerReservation are held.
+	ServerReservationConditionServersHeld = "ServersHeld"
+)
+
+// serverHeld reports whether the Server is held by a ServerReservation.
+func serverHeld(server *metalv1alpha1.Server) bool {
+	_, ok := server.Labels[ServerHeldByLabel]
+	return ok
+}
+
+// ServerReservationReconciler reconciles a ServerReservation object
+type ServerReservationReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// PlacementAdmitter may veto or re-rank the Servers selected for the ServerClaims of a reservation before they
+	// are held and handed over. If nil, the servers are held and handed over in the order of their names.
+	PlacementAdmitter placement.Admitter
+}
+
+// +kubebuilder:rbac:groups=metal.ironcore.dev,resources=serverreservations,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=metal.ironcore.dev,resources=serverreservations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=metal.ironcore.dev,resources=serverreservations/finalizers,verbs=update
+// +kubebuilder:rbac:groups=metal.ironcore.dev,resources=serverclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=metal.ironcore.dev,resources=servergrants,verbs=get;list;watch
+// +kubebuilder:rbac:groups=metal.ironcore.dev,resources=servers,verbs=get;list;watch;update;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *ServerReservationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	reservation := &metalv1alpha1.ServerReservation{}
+	if err := r.Get(ctx, req.NamespacedName, reservation); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	return r.reconcileExists(ctx, log, reservation)
+}
+
+func (r *ServerReservationReconciler) reconcileExists(ctx context.Context, log logr.Logger, reservation *metalv1alpha1.ServerReservation) (ctrl.Result, error) {
+	if !reservation.DeletionTimestamp.IsZero() {
+		return r.delete(ctx, log, reservation)
+	}
+	return r.reconcile(ctx, log, reservation)
+}
+
+func (r *ServerReservationReconciler) delete(ctx context.Context, log logr.Logger, reservation *metalv1alpha1.ServerReservation) (ctrl.Result, error) {
+	log.V(1).Info("Deleting ServerReservation")
+	if !controllerutil.ContainsFinalizer(reservation, ServerReservationFinalizer) {
+		return ctrl.Result{}, nil
+	}
+	held, err := r.heldServers(ctx, reservation)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.releaseServers(ctx, held); err != nil {
+		return ctrl.Result{}, err
+	}
+	log.V(1).Info("Released held servers", "Count", len(held))
+
+	if modified, err := clientutils.PatchEnsureNoFinalizer(ctx, r.Client, reservation, ServerReservationFinalizer); !apierrors.IsNotFound(err) || modified {
+		return ctrl.Result{}, err
+	}
+	log.V(1).Info("Deleted ServerReservation")
+	return ctrl.Result{}, nil
+}
+
+func (r *ServerReservationReconciler) reconcile(ctx context.Context, log logr.Logger, reservation *metalv1alpha1.ServerReservation) (ctrl.Result, error) {
+	if reservation.Status.State == metalv1alpha1.ServerReservationStateClaimed {
+		return ctrl.Result{}, nil
+	}
+	if modified, err := clientutils.PatchEnsureFinalizer(ctx, r.Client, reservation, ServerReservationFinalizer); err != nil || modified {
+		return ctrl.Result{}, err
+	}
+
+	held, err := r.heldServers(ctx, reservation)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	heldMessage := ""
+	if missing := int(reservation.Spec.Replicas) - len(held); missing > 0 {
+		if heldMessage, err = r.holdServers(ctx, log, reservation, missing); err != nil {
+			return ctrl.Result{}, err
+		}
+		if held, err = r.heldServers(ctx, reservation); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if heldMessage != "" {
+		return ctrl.Result{RequeueAfter: placementRetryInterval}, r.patchStatus(ctx, reservation, metalv1alpha1.ServerReservationStatePending, held, heldMessage)
+	}
+
+	if untilStart := time.Until(reservation.Spec.StartTime.Time); untilStart > 0 {
+		log.V(1).Info("Holding servers until the start of the reservation", "StartTime", reservation.Spec.StartTime)
+		return ctrl.Result{RequeueAfter: untilStart}, r.patchStatus(ctx, reservation, metalv1alpha1.ServerReservationStateHeld, held, "")
+	}
+
+	claimMessage, err := r.claimServers(ctx, log, reservation, held)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if claimMessage != "" {
+		return ctrl.Result{RequeueAfter: placementRetryInterval}, r.patchStatus(ctx, reservation, metalv1alpha1.ServerReservationStateHeld, held, claimMessage)
+	}
+	return ctrl.Result{}, r.patchStatus(ctx, reservation, metalv1alpha1.ServerReservationStateClaimed, held, "")
+}
+
+// heldServers returns the servers held by the reservation, sorted by name.
+func (r *ServerReservationReconciler) heldServers(ctx context.Context, reservation *metalv1alpha1.ServerReservation) ([]metalv1alpha1.Server, error) {
+	serverList := &metalv1alpha1.ServerList{}
+	if err := r.List(ctx, serverList, client.MatchingLabels{ServerHeldByLabel: string(reservation.UID)}); err != nil {
+		return nil, fmt.Errorf("failed to list held servers: %w", err)
+	}
+	held := serverList.Items
+	slices.SortFunc(held, func(a, b metalv1alpha1.Server) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return held, nil
+}
+
+// holdServers labels the given number of claimable servers as held by the reservation, all or none. Only servers the
+// PlacementAdmitter admits for the ServerClaims to be created for them are held. The servers are labeled with
+// optimistic locking, and the labels are removed again if any of them fails. If too few servers are available or
+// admitted, nothing is held and the returned message explains why.
+func (r *ServerReservationReconciler) holdServers(ctx context.Context, log logr.Logger, reservation *metalv1alpha1.ServerReservation, count int) (string, error) {
+	candidates, err := claimableServers(ctx, r.Client, reservation.Spec.ServerSelector)
+	if err != nil {
+		return "", err
+	}
+	if candidates, err = grantedServers(ctx, r.Client, reservation.Namespace, reservation.Spec.Template.Image, candidates); err != nil {
+		return "", err
+	}
+	if len(candidates) < count {
+		return fmt.Sprintf("%d of the %d missing servers are available", len(candidates), count), nil
+	}
+	claims := make([]*metalv1alpha1.ServerClaim, 0, count)
+	for i := int(reservation.Spec.Replicas) - count; i < int(reservation.Spec.Replicas); i++ {
+		claim, err := r.newServerClaim(reservation, i)
+		if err != nil {
+			return "", err
+		}
+		claims = append(claims, claim)
+	}
+	placed, message, err := placeClaims(ctx, log, r.PlacementAdmitter, claims, candidates)
+	if err != nil || message != "" {
+		return message, err
+	}
+
+	var held []metalv1alpha1.Server
+	for _, server := range placed {
+		serverBase := server.DeepCopy()
+		metav1.SetMetaDataLabel(&server.ObjectMeta, ServerHeldByLabel, string(reservation.UID))
+		if err := r.Patch(ctx, &server, client.MergeFromWithOptions(serverBase, client.MergeFromWithOptimisticLock{})); err != nil {
+			log.V(1).Info("Rolling back server holds", "Reason", err.Error())
+			if releaseErr := r.releaseServers(ctx, held); releaseErr != nil {
+				return "", fmt.Errorf("failed to hold server %s: %w, release failed: %w", server.Name, err, releaseErr)
+			}
+			if apierrors.IsConflict(err) {
+				return "A server has been claimed concurrently", nil
+			}
+			return "", fmt.Errorf("failed to hold server %s: %w", server.Name, err)
+		}
+		held = append(held, server)
+	}
+	log.V(1).Info("Held servers", "Count", len(held))
+	return "", nil
+}
+
+// releaseServers removes the hold of the reservation from the servers.
+func (r *ServerReservationReconciler) releaseServers(ctx context.Context, servers []metalv1alpha1.Server) error {
+	for _, server := range servers {
+		serverBase := server.DeepCopy()
+		delete(server.Labels, ServerHeldByLabel)
+		if err := r.Patch(ctx, &server, client.MergeFrom(serverBase)); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to release server %s: %w", server.Name, err)
+		}
+	}
+	return nil
+}
+
+// newServerClaim returns the ServerClaim with the given index to be created from the template of the reservation. It
+// is controlled by the reservation, so that it is garbage collected with it.
+func (r *ServerReservationReconciler) newServerClaim(reservation *metalv1alpha1.ServerReservation, index int) (*metalv1alpha1.ServerClaim, error) {
+	claim := &metalv1alpha1.ServerClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: reservation.Namespace,
+			Name:      fmt.Sprintf("%s-%d", reservation.Name, index),
+			Labels:    map[string]string{ServerReservationLabel: reservation.Name},
+		},
+		Spec: *reservation.Spec.Template.DeepCopy(),
+	}
+	if err := controllerutil.SetControllerReference(reservation, claim, r.Scheme); err != nil {
+		return nil, fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	return claim, nil
+}
+
+// claimServers creates a ServerClaim from the template for each held server and hands the server over to it, which
+// replaces the hold of the reservation. The held servers are admitted for the claims by the PlacementAdmitter again,
+// as the claims are bound to the servers handed over to them without being admitted. If it rejects the placement of
+// any claim, nothing is handed over and the returned message explains why.
+func (r *ServerReservationReconciler) claimServers(ctx context.Context, log logr.Logger, reservation *metalv1alpha1.ServerReservation, held []metalv1alpha1.Server) (string, error) {
+	claims := make([]*metalv1alpha1.ServerClaim, 0, len(held))
+	for i := range held {
+		claim, err := r.newServerClaim(reservation, i)
+		if err != nil {
+			return "", err
+		}
+		claims = append(claims, claim)
+	}
+	placed, message, err := placeClaims(ctx, log, r.PlacementAdmitter, claims, held)
+	if err != nil || message != "" {
+		return message, err
+	}
+
+	for i, claim := range claims {
+		server := placed[i]
+		claim.Spec.ServerRef = &v1.LocalObjectReference{Name: server.Name}
+		if err := r.Create(ctx, claim); err != nil {
+			if !apierrors.IsAlreadyExists(err) {
+				return "", fmt.Errorf("failed to create ServerClaim %s: %w", claim.Name, err)
+			}
+			// the claim has been created by an earlier attempt whose handover failed
+			if err := r.Get(ctx, client.ObjectKeyFromObject(claim), claim); err != nil {
+				return "", fmt.Errorf("failed to get ServerClaim %s: %w", claim.Name, err)
+			}
+		}
+
+		serverBase := server.DeepCopy()
+		delete(server.Labels, ServerHeldByLabel)
+		server.Spec.ServerClaimRef = &v1.ObjectReference{
+			APIVersion: "metal.ironcore.dev/v1alpha1",
+			Kind:       "ServerClaim",
+			Namespace:  claim.Namespace,
+			Name:       claim.Name,
+			UID:        claim.UID,
+		}
+		if err := r.Patch(ctx, &server, client.MergeFromWithOptions(serverBase, client.MergeFromWithOptimisticLock{})); err != nil {
+			return "", fmt.Errorf("failed to hand over server %s to ServerClaim %s: %w", server.Name, claim.Name, err)
+		}
+		log.V(1).Info("Claimed held server", "Server", server.Name, "ServerClaim", claim.Name)
+	}
+	return "", nil
+}
+
+func (r *ServerReservationReconciler) patchStatus(ctx context.Context, reservation *metalv1alpha1.ServerReservation, state metalv1alpha1.ServerReservationState, held []metalv1alpha1.Server, heldMessage string) error {
+	reservationBase := reservation.DeepCopy()
+	reservation.Status.State = state
+	reservation.Status.Servers = nil
+	for _, server := range held {
+		reservation.Status.Servers = append(reservation.Status.Servers, v1.LocalObjectReference{Name: server.Name})
+	}
+	if state == metalv1alpha1.ServerReservationStateClaimed {
+		reservation.Status.ClaimRefs = nil
+		for i := range held {
+			reservation.Status.ClaimRefs = append(reservation.Status.ClaimRefs, v1.LocalObjectReference{Name: fmt.Sprintf("%s-%d", reservation.Name, i)})
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:    ServerReservationConditionServersHeld,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Held",
+		Message: fmt.Sprintf("All %d servers are held", reservation.Spec.Replicas),
+	}
+	switch {
+	case heldMessage != "" && state == metalv1alpha1.ServerReservationStateHeld:
+		// the servers are held, but could not be handed over
+		condition.Reason = "PlacementRejected"
+		condition.Message = heldMessage
+	case heldMessage != "":
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "InsufficientServers"
+		condition.Message = heldMessage
+	case state == metalv1alpha1.ServerReservationStateClaimed:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Claimed"
+		condition.Message = "The servers have been handed over to their ServerClaims"
+	}
+	meta.SetStatusCondition(&reservation.Status.Conditions, condition)
+	if err := r.Status().Patch(ctx, reservation, client.MergeFrom(reservationBase)); err != nil {
+		return fmt.Errorf("failed to patch ServerReservation status: %w", err)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ServerReservationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&metalv1alpha1.ServerReservation{}).
+		Watches(&metalv1alpha1.Server{}, r.enqueuePendingServerReservations()).
+		Complete(r)
+}
+
+// enqueuePendingServerReservations enqueues the ServerReservations which do not hold all their servers yet, as a
+// changed Server may complete them.
+func (r *ServerReservationReconciler) enqueuePendingServerReservations() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, _ client.Object) []reconcile.Request {
+		log := ctrl.LoggerFrom(ctx)
+
+		reservationList := &metalv1alpha1.ServerReservationList{}
+		if err := r.List(ctx, reservationList); err != nil {
+			log.Error(err, "failed to list server reservations")
+			return nil
+		}
+		var req []reconcile.Request
+		for _, reservation := range reservationList.Items {
+			if reservation.Status.State != metalv1alpha1.ServerReservationStateHeld &&
+				reservation.Status.State != metalv1alpha1.ServerReservationStateClaimed {
+				req = append(req, reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: reservation.Namespace, Name: reservation.Name},
+				})
+			}
+		}
+		return req
+	})
+}
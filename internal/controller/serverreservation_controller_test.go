@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+)
+
+var _ = Describe("ServerReservation Controller", func() {
+	ns := SetupTest()
+
+	It("should not hold any server if fewer servers than replicas are available", func(ctx SpecContext) {
+		By("Creating a BMCSecret")
+		bmcSecret := &metalv1alpha1.BMCSecret{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Data: map[string][]byte{
+				metalv1alpha1.BMCSecretUsernameKeyName: []byte("foo"),
+				metalv1alpha1.BMCSecretPasswordKeyName: []byte("bar"),
+			},
+		}
+		Expect(k8sClient.Create(ctx, bmcSecret)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, bmcSecret)
+
+		By("Creating a Server")
+		server := &metalv1alpha1.Server{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Labels:       map[string]string{"reservation": ns.Name},
+			},
+			Spec: metalv1alpha1.ServerSpec{
+				UUID:       "38947555-7742-3448-3784-823347823834",
+				SystemUUID: "38947555-7742-3448-3784-823347823834",
+				BMC: &metalv1alpha1.BMCAccess{
+					Protocol: metalv1alpha1.Protocol{
+						Name: metalv1alpha1.ProtocolRedfishLocal,
+						Port: 8000,
+					},
+					Address: "127.0.0.1",
+					BMCSecretRef: v1.LocalObjectReference{
+						Name: bmcSecret.Name,
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, server)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, server)
+
+		By("Creating a ServerReservation for two servers")
+		reservation := &metalv1alpha1.ServerReservation{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:    ns.Name,
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.ServerReservationSpec{
+				Replicas:       2,
+				ServerSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"reservation": ns.Name}},
+				StartTime:      metav1.NewTime(time.Now().Add(time.Hour)),
+				Template: metalv1alpha1.ServerClaimSpec{
+					Power: metalv1alpha1.PowerOff,
+					Image: "foo:bar",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, reservation)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, reservation)
+
+		By("Ensuring that the ServerReservation reports the missing servers")
+		Eventually(Object(reservation)).Should(SatisfyAll(
+			HaveField("Status.State", metalv1alpha1.ServerReservationStatePending),
+			HaveField("Status.Servers", BeEmpty()),
+			HaveField("Status.Conditions", ContainElement(SatisfyAll(
+				HaveField("Type", ServerReservationConditionServersHeld),
+				HaveField("Status", metav1.ConditionFalse),
+				HaveField("Reason", "InsufficientServers"),
+			))),
+		))
+
+		By("Ensuring that the server is neither held nor claimed")
+		Consistently(Object(server)).Should(SatisfyAll(
+			HaveField("ObjectMeta.Labels", Not(HaveKey(ServerHeldByLabel))),
+			HaveField("Spec.ServerClaimRef", BeNil()),
+		))
+		claims := &metalv1alpha1.ServerClaimList{}
+		Consistently(ObjectList(claims, client.InNamespace(ns.Name), client.MatchingLabels{ServerReservationLabel: reservation.Name})).
+			Should(HaveField("Items", BeEmpty()))
+	})
+
+	It("should only hold and hand over servers whose placement has been admitted", func(ctx SpecContext) {
+		By("Creating two available Servers, one of them vetoed by the placement admitter")
+		var servers []*metalv1alpha1.Server
+		for i, systemUUID := range []string{"38947555-7742-3448-3784-823347823856", "38947555-7742-3448-3784-823347823857"} {
+			server := createPausedServer(ctx, fmt.Sprintf("10.30.0.%d", 21+i), systemUUID)
+			Eventually(Update(server, func() {
+				metav1.SetMetaDataLabel(&server.ObjectMeta, "reservation", ns.Name)
+			})).Should(Succeed())
+			Eventually(UpdateStatus(server, func() {
+				server.Status.State = metalv1alpha1.ServerStateAvailable
+				server.Status.PowerState = metalv1alpha1.ServerOffPowerState
+			})).Should(Succeed())
+			servers = append(servers, server)
+		}
+		veto := func(server *metalv1alpha1.Server, vetoed bool) {
+			Eventually(Update(server, func() {
+				if vetoed {
+					metav1.SetMetaDataLabel(&server.ObjectMeta, vetoedPlacementLabel, "")
+				} else {
+					delete(server.Labels, vetoedPlacementLabel)
+				}
+			})).Should(Succeed())
+		}
+		veto(servers[1], true)
+
+		By("Creating a ServerReservation for two servers")
+		reservation := &metalv1alpha1.ServerReservation{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:    ns.Name,
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.ServerReservationSpec{
+				Replicas:       2,
+				ServerSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"reservation": ns.Name}},
+				StartTime:      metav1.NewTime(time.Now().Add(time.Hour)),
+				Template: metalv1alpha1.ServerClaimSpec{
+					Power: metalv1alpha1.PowerOff,
+					Image: "foo:bar",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, reservation)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, reservation)
+
+		By("Ensuring that no server is held while the placement is rejected")
+		Eventually(Object(reservation)).Should(SatisfyAll(
+			HaveField("Status.State", metalv1alpha1.ServerReservationStatePending),
+			HaveField("Status.Conditions", ContainElement(HaveField("Message", ContainSubstring("Vetoed by test")))),
+		))
+		for _, server := range servers {
+			Consistently(Object(server)).Should(HaveField("ObjectMeta.Labels", Not(HaveKey(ServerHeldByLabel))))
+		}
+
+		By("Ensuring that the servers are held once the placement is admitted")
+		veto(servers[1], false)
+		Eventually(Object(reservation)).Should(HaveField("Status.State", metalv1alpha1.ServerReservationStateHeld))
+
+		By("Ensuring that the servers are not handed over while the placement is rejected")
+		veto(servers[1], true)
+		Eventually(Update(reservation, func() {
+			reservation.Spec.StartTime = metav1.Now()
+		})).Should(Succeed())
+		Eventually(Object(reservation)).Should(SatisfyAll(
+			HaveField("Status.State", metalv1alpha1.ServerReservationStateHeld),
+			HaveField("Status.Conditions", ContainElement(SatisfyAll(
+				HaveField("Type", ServerReservationConditionServersHeld),
+				HaveField("Reason", "PlacementRejected"),
+				HaveField("Message", ContainSubstring("Vetoed by test")),
+			))),
+		))
+		claims := &metalv1alpha1.ServerClaimList{}
+		Consistently(ObjectList(claims, client.InNamespace(ns.Name), client.MatchingLabels{ServerReservationLabel: reservation.Name})).
+			Should(HaveField("Items", BeEmpty()))
+
+		By("Ensuring that the servers are handed over to claims owned by the reservation once admitted")
+		veto(servers[1], false)
+		Eventually(Update(reservation, func() {
+			metav1.SetMetaDataAnnotation(&reservation.ObjectMeta, "test", "retry")
+		})).Should(Succeed())
+		Eventually(Object(reservation)).Should(HaveField("Status.State", metalv1alpha1.ServerReservationStateClaimed))
+		Eventually(ObjectList(claims, client.InNamespace(ns.Name), client.MatchingLabels{ServerReservationLabel: reservation.Name})).
+			Should(HaveField("Items", HaveEach(HaveField("OwnerReferences", ContainElement(SatisfyAll(
+				HaveField("UID", reservation.UID),
+				HaveField("Controller", HaveValue(BeTrue())),
+			))))))
+		for _, server := range servers {
+			Eventually(Object(server)).Should(HaveField("Spec.ServerClaimRef", Not(BeNil())))
+		}
+	})
+})
@@ -0,0 +1,330 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/internal/placement"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// ServerSetClaimLabel holds the name of the ServerSetClaim a ServerClaim is a member of.
+	ServerSetClaimLabel = "metal.ironcore.dev/server-set-claim"
+
+	// ServerSetClaimConditionServersReserved reports whether servers have been reserved for all members of a
+	// ServerSetClaim.
+	ServerSetClaimConditionServersReserved = "ServersReserved"
+)
+
+// ServerSetClaimReconciler reconciles a ServerSetClaim object
+type ServerSetClaimReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// PlacementAdmitter may veto or re-rank the Servers selected for the members of a set before they are reserved.
+	// If nil, the servers are reserved in the order of their names.
+	PlacementAdmitter placement.Admitter
+}
+
+// +kubebuilder:rbac:groups=metal.ironcore.dev,resources=serversetclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=metal.ironcore.dev,resources=serversetclaims/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=metal.ironcore.dev,resources=serversetclaims/finalizers,verbs=update
+// +kubebuilder:rbac:groups=metal.ironcore.dev,resources=serverclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=metal.ironcore.dev,resources=servergrants,verbs=get;list;watch
+// +kubebuilder:rbac:groups=metal.ironcore.dev,resources=servers,verbs=get;list;watch;update;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *ServerSetClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	set := &metalv1alpha1.ServerSetClaim{}
+	if err := r.Get(ctx, req.NamespacedName, set); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !set.DeletionTimestamp.IsZero() {
+		// the member ServerClaims are owned by the set and release their servers once they are garbage collected
+		return ctrl.Result{}, nil
+	}
+
+	return r.reconcile(ctx, log, set)
+}
+
+func (r *ServerSetClaimReconciler) reconcile(ctx context.Context, log logr.Logger, set *metalv1alpha1.ServerSetClaim) (ctrl.Result, error) {
+	members, err := r.listMembers(ctx, set)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	var missing []string
+	for i := range int(set.Spec.Replicas) {
+		if _, ok := members[serverSetClaimMemberName(set, i)]; !ok {
+			missing = append(missing, serverSetClaimMemberName(set, i))
+		}
+	}
+
+	reservedMessage := ""
+	if len(missing) > 0 {
+		reserved, message, err := r.reserveServers(ctx, log, set, missing)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !reserved {
+			reservedMessage = message
+		} else if members, err = r.listMembers(ctx, set); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := r.patchStatus(ctx, set, members, reservedMessage); err != nil {
+		return ctrl.Result{}, err
+	}
+	log.V(1).Info("Reconciled ServerSetClaim", "Phase", set.Status.Phase, "BoundReplicas", set.Status.BoundReplicas)
+	if reservedMessage != "" {
+		return ctrl.Result{RequeueAfter: placementRetryInterval}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// serverSetClaimMemberName returns the name of the ServerClaim of the member with the given index.
+func serverSetClaimMemberName(set *metalv1alpha1.ServerSetClaim, index int) string {
+	return fmt.Sprintf("%s-%d", set.Name, index)
+}
+
+// listMembers returns the member ServerClaims of the set by name.
+func (r *ServerSetClaimReconciler) listMembers(ctx context.Context, set *metalv1alpha1.ServerSetClaim) (map[string]metalv1alpha1.ServerClaim, error) {
+	claimList := &metalv1alpha1.ServerClaimList{}
+	if err := r.List(ctx, claimList, client.InNamespace(set.Namespace), client.MatchingLabels{ServerSetClaimLabel: set.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list member ServerClaims: %w", err)
+	}
+	members := make(map[string]metalv1alpha1.ServerClaim, len(claimList.Items))
+	for _, claim := range claimList.Items {
+		if metav1.IsControlledBy(&claim, set) {
+			members[claim.Name] = claim
+		}
+	}
+	return members, nil
+}
+
+// reserveServers creates the missing member ServerClaims, each referencing a server of its own, all or none. The
+// server of every member has to be admitted for it by the PlacementAdmitter, as the member is bound to the server
+// reserved for it without being admitted again. The servers are reserved with optimistic locking, and the
+// reservations are rolled back if any of them fails, so that a server claimed concurrently never leaves the set
+// partially allocated. If too few servers are available or admitted, nothing is reserved and the returned message
+// explains why.
+func (r *ServerSetClaimReconciler) reserveServers(ctx context.Context, log logr.Logger, set *metalv1alpha1.ServerSetClaim, missing []string) (bool, string, error) {
+	candidates, err := claimableServers(ctx, r.Client, set.Spec.ServerSelector)
+	if err != nil {
+		return false, "", err
+	}
+	if candidates, err = grantedServers(ctx, r.Client, set.Namespace, set.Spec.Template.Image, candidates); err != nil {
+		return false, "", err
+	}
+	if len(candidates) < len(missing) {
+		return false, fmt.Sprintf("%d of the %d missing servers are available", len(candidates), len(missing)), nil
+	}
+
+	members := make([]*metalv1alpha1.ServerClaim, 0, len(missing))
+	for _, name := range missing {
+		claim := &metalv1alpha1.ServerClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: set.Namespace,
+				Name:      name,
+				Labels:    map[string]string{ServerSetClaimLabel: set.Name},
+			},
+			Spec: *set.Spec.Template.DeepCopy(),
+		}
+		claim.Spec.ServerSelector = set.Spec.ServerSelector.DeepCopy()
+		if err := controllerutil.SetControllerReference(set, claim, r.Scheme); err != nil {
+			return false, "", fmt.Errorf("failed to set controller reference: %w", err)
+		}
+		members = append(members, claim)
+	}
+	placed, message, err := placeClaims(ctx, log, r.PlacementAdmitter, members, candidates)
+	if err != nil || message != "" {
+		return false, message, err
+	}
+
+	var (
+		claims   []*metalv1alpha1.ServerClaim
+		reserved []*metalv1alpha1.Server
+	)
+	reserve := func() error {
+		for i, claim := range members {
+			server := &placed[i]
+			claim.Spec.ServerRef = &v1.LocalObjectReference{Name: server.Name}
+			if err := r.Create(ctx, claim); err != nil {
+				return fmt.Errorf("failed to create member ServerClaim %s: %w", claim.Name, err)
+			}
+			claims = append(claims, claim)
+
+			serverBase := server.DeepCopy()
+			server.Spec.ServerClaimRef = &v1.ObjectReference{
+				APIVersion: "metal.ironcore.dev/v1alpha1",
+				Kind:       "ServerClaim",
+				Namespace:  claim.Namespace,
+				Name:       claim.Name,
+				UID:        claim.UID,
+			}
+			if err := r.Patch(ctx, server, client.MergeFromWithOptions(serverBase, client.MergeFromWithOptimisticLock{})); err != nil {
+				return fmt.Errorf("failed to reserve server %s: %w", server.Name, err)
+			}
+			reserved = append(reserved, server)
+		}
+		return nil
+	}
+	if err := reserve(); err != nil {
+		log.V(1).Info("Rolling back server reservations", "Reason", err.Error())
+		if rollbackErr := r.rollbackReservations(ctx, claims, reserved); rollbackErr != nil {
+			return false, "", fmt.Errorf("%w, rollback failed: %w", err, rollbackErr)
+		}
+		if apierrors.IsConflict(err) {
+			// the server has been claimed concurrently, so the reservation is retried with fresh candidates
+			return false, "A server has been claimed concurrently", nil
+		}
+		return false, "", err
+	}
+	log.V(1).Info("Reserved servers", "Count", len(reserved))
+	return true, "", nil
+}
+
+// rollbackReservations releases the reserved servers and deletes the created member ServerClaims.
+func (r *ServerSetClaimReconciler) rollbackReservations(ctx context.Context, claims []*metalv1alpha1.ServerClaim, servers []*metalv1alpha1.Server) error {
+	for _, server := range servers {
+		serverBase := server.DeepCopy()
+		server.Spec.ServerClaimRef = nil
+		if err := r.Patch(ctx, server, client.MergeFromWithOptions(serverBase, client.MergeFromWithOptimisticLock{})); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to release server %s: %w", server.Name, err)
+		}
+	}
+	for _, claim := range claims {
+		if err := r.Delete(ctx, claim); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete member ServerClaim %s: %w", claim.Name, err)
+		}
+	}
+	return nil
+}
+
+// claimableServers returns the servers matching the selector which may be claimed, sorted by name. Servers which are
+// claimed, not Available, powered on, tainted after a failed boot or held by a ServerReservation are omitted. A nil
+// selector matches all servers.
+func claimableServers(ctx context.Context, c client.Reader, labelSelector *metav1.LabelSelector) ([]metalv1alpha1.Server, error) {
+	selector := labels.Everything()
+	if labelSelector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(labelSelector); err != nil {
+			return nil, err
+		}
+	}
+	serverList := &metalv1alpha1.ServerList{}
+	if err := c.List(ctx, serverList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	var candidates []metalv1alpha1.Server
+	for _, server := range serverList.Items {
+		if server.Spec.ServerClaimRef != nil || !server.DeletionTimestamp.IsZero() || serverHeld(&server) {
+			continue
+		}
+		if server.Status.State != metalv1alpha1.ServerStateAvailable || server.Status.PowerState != metalv1alpha1.ServerOffPowerState {
+			continue
+		}
+		if _, ok := server.Labels[ServerBootFailedLabel]; ok {
+			continue
+		}
+		candidates = append(candidates, server)
+	}
+	slices.SortFunc(candidates, func(a, b metalv1alpha1.Server) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return candidates, nil
+}
+
+// patchStatus summarizes the members in the status of the set. The set is Bound once all members are bound.
+func (r *ServerSetClaimReconciler) patchStatus(ctx context.Context, set *metalv1alpha1.ServerSetClaim, members map[string]metalv1alpha1.ServerClaim, reservedMessage string) error {
+	setBase := set.DeepCopy()
+	set.Status.Members = nil
+	set.Status.BoundReplicas = 0
+	for i := range int(set.Spec.Replicas) {
+		claim, ok := members[serverSetClaimMemberName(set, i)]
+		if !ok {
+			continue
+		}
+		member := metalv1alpha1.ServerSetClaimMember{ClaimName: claim.Name, Phase: claim.Status.Phase}
+		if claim.Spec.ServerRef != nil {
+			member.ServerRef = claim.Spec.ServerRef.DeepCopy()
+		}
+		if claim.Status.Phase == metalv1alpha1.PhaseBound {
+			set.Status.BoundReplicas++
+		}
+		set.Status.Members = append(set.Status.Members, member)
+	}
+	set.Status.Phase = metalv1alpha1.PhaseUnbound
+	if set.Status.BoundReplicas == set.Spec.Replicas {
+		set.Status.Phase = metalv1alpha1.PhaseBound
+	}
+
+	condition := metav1.Condition{
+		Type:    ServerSetClaimConditionServersReserved,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Reserved",
+		Message: fmt.Sprintf("Servers have been reserved for all %d members", set.Spec.Replicas),
+	}
+	if reservedMessage != "" {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "InsufficientServers"
+		condition.Message = reservedMessage
+	}
+	meta.SetStatusCondition(&set.Status.Conditions, condition)
+	if err := r.Status().Patch(ctx, set, client.MergeFrom(setBase)); err != nil {
+		return fmt.Errorf("failed to patch ServerSetClaim status: %w", err)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ServerSetClaimReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&metalv1alpha1.ServerSetClaim{}).
+		Owns(&metalv1alpha1.ServerClaim{}).
+		Watches(&metalv1alpha1.Server{}, r.enqueueUnboundServerSetClaims()).
+		Complete(r)
+}
+
+// enqueueUnboundServerSetClaims enqueues the ServerSetClaims which are not bound yet, as a changed Server may
+// complete their reservation.
+func (r *ServerSetClaimReconciler) enqueueUnboundServerSetClaims() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, _ client.Object) []reconcile.Request {
+		log := ctrl.LoggerFrom(ctx)
+
+		setList := &metalv1alpha1.ServerSetClaimList{}
+		if err := r.List(ctx, setList); err != nil {
+			log.Error(err, "failed to list server set claims")
+			return nil
+		}
+		var req []reconcile.Request
+		for _, set := range setList.Items {
+			if set.Status.Phase != metalv1alpha1.PhaseBound {
+				req = append(req, reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: set.Namespace, Name: set.Name},
+				})
+			}
+		}
+		return req
+	})
+}
@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+)
+
+var _ = Describe("ServerSetClaim Controller", func() {
+	ns := SetupTest()
+
+	It("should not claim any server if fewer servers than replicas are available", func(ctx SpecContext) {
+		By("Creating a BMCSecret")
+		bmcSecret := &metalv1alpha1.BMCSecret{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Data: map[string][]byte{
+				metalv1alpha1.BMCSecretUsernameKeyName: []byte("foo"),
+				metalv1alpha1.BMCSecretPasswordKeyName: []byte("bar"),
+			},
+		}
+		Expect(k8sClient.Create(ctx, bmcSecret)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, bmcSecret)
+
+		By("Creating a Server")
+		server := &metalv1alpha1.Server{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Labels:       map[string]string{"set": ns.Name},
+			},
+			Spec: metalv1alpha1.ServerSpec{
+				UUID:       "38947555-7742-3448-3784-823347823834",
+				SystemUUID: "38947555-7742-3448-3784-823347823834",
+				BMC: &metalv1alpha1.BMCAccess{
+					Protocol: metalv1alpha1.Protocol{
+						Name: metalv1alpha1.ProtocolRedfishLocal,
+						Port: 8000,
+					},
+					Address: "127.0.0.1",
+					BMCSecretRef: v1.LocalObjectReference{
+						Name: bmcSecret.Name,
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, server)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, server)
+
+		By("Creating a ServerSetClaim for two servers")
+		set := &metalv1alpha1.ServerSetClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:    ns.Name,
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.ServerSetClaimSpec{
+				Replicas:       2,
+				ServerSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"set": ns.Name}},
+				Template: metalv1alpha1.ServerClaimSpec{
+					Power: metalv1alpha1.PowerOff,
+					Image: "foo:bar",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, set)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, set)
+
+		By("Ensuring that the ServerSetClaim reports the missing servers")
+		Eventually(Object(set)).Should(SatisfyAll(
+			HaveField("Status.Phase", metalv1alpha1.PhaseUnbound),
+			HaveField("Status.Conditions", ContainElement(SatisfyAll(
+				HaveField("Type", ServerSetClaimConditionServersReserved),
+				HaveField("Status", metav1.ConditionFalse),
+				HaveField("Reason", "InsufficientServers"),
+			))),
+		))
+
+		By("Ensuring that neither a member ServerClaim nor a server reservation exists")
+		claims := &metalv1alpha1.ServerClaimList{}
+		Consistently(ObjectList(claims, client.InNamespace(ns.Name), client.MatchingLabels{ServerSetClaimLabel: set.Name})).
+			Should(HaveField("Items", BeEmpty()))
+		Consistently(Object(server)).Should(HaveField("Spec.ServerClaimRef", BeNil()))
+	})
+
+	It("should only reserve servers whose placement has been admitted", func(ctx SpecContext) {
+		By("Creating two available Servers, one of them vetoed by the placement admitter")
+		var servers []*metalv1alpha1.Server
+		for i, systemUUID := range []string{"38947555-7742-3448-3784-823347823854", "38947555-7742-3448-3784-823347823855"} {
+			server := createPausedServer(ctx, fmt.Sprintf("10.30.0.%d", 19+i), systemUUID)
+			Eventually(Update(server, func() {
+				metav1.SetMetaDataLabel(&server.ObjectMeta, "set", ns.Name)
+			})).Should(Succeed())
+			Eventually(UpdateStatus(server, func() {
+				server.Status.State = metalv1alpha1.ServerStateAvailable
+				server.Status.PowerState = metalv1alpha1.ServerOffPowerState
+			})).Should(Succeed())
+			servers = append(servers, server)
+		}
+		Eventually(Update(servers[1], func() {
+			metav1.SetMetaDataLabel(&servers[1].ObjectMeta, vetoedPlacementLabel, "")
+		})).Should(Succeed())
+
+		By("Creating a ServerSetClaim for two servers")
+		set := &metalv1alpha1.ServerSetClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:    ns.Name,
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.ServerSetClaimSpec{
+				Replicas:       2,
+				ServerSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"set": ns.Name}},
+				Template: metalv1alpha1.ServerClaimSpec{
+					Power: metalv1alpha1.PowerOff,
+					Image: "foo:bar",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, set)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, set)
+
+		By("Ensuring that no server is reserved while the placement is rejected")
+		Eventually(Object(set)).Should(HaveField("Status.Conditions", ContainElement(SatisfyAll(
+			HaveField("Type", ServerSetClaimConditionServersReserved),
+			HaveField("Status", metav1.ConditionFalse),
+			HaveField("Message", ContainSubstring("Vetoed by test")),
+		))))
+		for _, server := range servers {
+			Consistently(Object(server)).Should(HaveField("Spec.ServerClaimRef", BeNil()))
+		}
+
+		By("Ensuring that the servers are reserved once the placement is admitted")
+		Eventually(Update(servers[1], func() {
+			delete(servers[1].Labels, vetoedPlacementLabel)
+		})).Should(Succeed())
+		for _, server := range servers {
+			Eventually(Object(server)).Should(HaveField("Spec.ServerClaimRef", Not(BeNil())))
+		}
+	})
+})
@@ -8,12 +8,15 @@ import (
 	"fmt"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
 	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
 	"github.com/ironcore-dev/metal-operator/bmc"
 	"github.com/ironcore-dev/metal-operator/internal/api/macdb"
+	"github.com/ironcore-dev/metal-operator/internal/oci"
+	"github.com/ironcore-dev/metal-operator/internal/placement"
 	"github.com/ironcore-dev/metal-operator/internal/registry"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -37,6 +40,9 @@ const (
 	pollingInterval      = 50 * time.Millisecond
 	eventuallyTimeout    = 3 * time.Second
 	consistentlyDuration = 1 * time.Second
+
+	missingImageRepository = "missing"
+	vetoedPlacementLabel   = "test.metal.ironcore.dev/vetoed-placement"
 )
 
 var (
@@ -44,6 +50,8 @@ var (
 	k8sClient   client.Client
 	testEnv     *envtest.Environment
 	registryURL = "http://localhost:30000"
+	// registryTokenKey is the key of the registry tokens, see registry.SystemToken.
+	registryTokenKey = []byte("controller-test")
 )
 
 func TestControllers(t *testing.T) {
@@ -99,13 +107,42 @@ var _ = BeforeSuite(func() {
 	var mgrCtx context.Context
 	mgrCtx, cancel := context.WithCancel(context.Background())
 	DeferCleanup(cancel)
-	registryServer := registry.NewServer(":30000")
+	registryServer := registry.NewServer(":30000", registryTokenKey)
 	go func() {
 		defer GinkgoRecover()
 		Expect(registryServer.Start(mgrCtx)).To(Succeed(), "failed to start registry server")
 	}()
 })
 
+// testImageResolver resolves every image except the ones of the missing repository.
+type testImageResolver struct{}
+
+func (testImageResolver) Resolve(_ context.Context, image string) (string, error) {
+	if strings.HasPrefix(image, missingImageRepository) {
+		return "", fmt.Errorf("%w: %s", oci.ErrImageNotFound, image)
+	}
+	return "sha256:6c3c624b58dbbcd3c0dd82b4c53f04194d1247c6eebdaab7c610cf7d66709b3b", nil
+}
+
+// testPlacementAdmitter vetoes the placement of claims and on servers labeled with the vetoedPlacementLabel.
+type testPlacementAdmitter struct{}
+
+func (testPlacementAdmitter) Admit(_ context.Context, claim *metalv1alpha1.ServerClaim, candidates []metalv1alpha1.Server) (placement.Decision, error) {
+	if _, ok := claim.Labels[vetoedPlacementLabel]; ok {
+		return placement.Decision{Reason: "Vetoed by test"}, nil
+	}
+	decision := placement.Decision{}
+	for _, candidate := range candidates {
+		if _, ok := candidate.Labels[vetoedPlacementLabel]; !ok {
+			decision.Servers = append(decision.Servers, candidate.Name)
+		}
+	}
+	if len(decision.Servers) == 0 {
+		decision.Reason = "Vetoed by test"
+	}
+	return decision, nil
+}
+
 func SetupTest() *corev1.Namespace {
 	ns := &corev1.Namespace{}
 
@@ -179,6 +216,7 @@ func SetupTest() *corev1.Namespace {
 			ProbeImage:             "foo:latest",
 			ProbeOSImage:           "fooOS:latest",
 			RegistryURL:            registryURL,
+			RegistryTokenKey:       registryTokenKey,
 			RegistryResyncInterval: 50 * time.Millisecond,
 			ResyncInterval:         50 * time.Millisecond,
 			EnforceFirstBoot:       true,
@@ -191,6 +229,25 @@ func SetupTest() *corev1.Namespace {
 		}).SetupWithManager(k8sManager)).To(Succeed())
 
 		Expect((&ServerClaimReconciler{
+			Client:            k8sManager.GetClient(),
+			Scheme:            k8sManager.GetScheme(),
+			ImageResolver:     testImageResolver{},
+			PlacementAdmitter: testPlacementAdmitter{},
+		}).SetupWithManager(k8sManager)).To(Succeed())
+
+		Expect((&ServerSetClaimReconciler{
+			Client:            k8sManager.GetClient(),
+			Scheme:            k8sManager.GetScheme(),
+			PlacementAdmitter: testPlacementAdmitter{},
+		}).SetupWithManager(k8sManager)).To(Succeed())
+
+		Expect((&ServerReservationReconciler{
+			Client:            k8sManager.GetClient(),
+			Scheme:            k8sManager.GetScheme(),
+			PlacementAdmitter: testPlacementAdmitter{},
+		}).SetupWithManager(k8sManager)).To(Succeed())
+
+		Expect((&ServerGrantReconciler{
 			Client: k8sManager.GetClient(),
 			Scheme: k8sManager.GetScheme(),
 		}).SetupWithManager(k8sManager)).To(Succeed())
@@ -200,6 +257,72 @@ func SetupTest() *corev1.Namespace {
 			Scheme: k8sManager.GetScheme(),
 		}).SetupWithManager(k8sManager)).To(Succeed())
 
+		Expect((&DriveFirmwareReconciler{
+			Client:   k8sManager.GetClient(),
+			Scheme:   k8sManager.GetScheme(),
+			Insecure: true,
+			BMCOptions: bmc.BMCOptions{
+				BasicAuth: true,
+			},
+			ResyncInterval: 50 * time.Millisecond,
+		}).SetupWithManager(k8sManager)).To(Succeed())
+
+		Expect((&DriveReplacementReconciler{
+			Client:   k8sManager.GetClient(),
+			Scheme:   k8sManager.GetScheme(),
+			Insecure: true,
+			BMCOptions: bmc.BMCOptions{
+				BasicAuth: true,
+			},
+			ResyncInterval: 50 * time.Millisecond,
+		}).SetupWithManager(k8sManager)).To(Succeed())
+
+		Expect((&BoardReplacementReconciler{
+			Client:   k8sManager.GetClient(),
+			Scheme:   k8sManager.GetScheme(),
+			Insecure: true,
+			BMCOptions: bmc.BMCOptions{
+				BasicAuth: true,
+			},
+			ResyncInterval: 50 * time.Millisecond,
+		}).SetupWithManager(k8sManager)).To(Succeed())
+
+		Expect((&FirmwareCampaignReconciler{
+			Client: k8sManager.GetClient(),
+			Scheme: k8sManager.GetScheme(),
+		}).SetupWithManager(k8sManager)).To(Succeed())
+
+		Expect((&ComponentFirmwareReconciler{
+			Client:   k8sManager.GetClient(),
+			Scheme:   k8sManager.GetScheme(),
+			Insecure: true,
+			BMCOptions: bmc.BMCOptions{
+				BasicAuth: true,
+			},
+			ResyncInterval: 50 * time.Millisecond,
+		}).SetupWithManager(k8sManager)).To(Succeed())
+
+		Expect((&ComposedServerReconciler{
+			Client:   k8sManager.GetClient(),
+			Scheme:   k8sManager.GetScheme(),
+			Insecure: true,
+			BMCOptions: bmc.BMCOptions{
+				BasicAuth: true,
+			},
+			ResyncInterval: 50 * time.Millisecond,
+		}).SetupWithManager(k8sManager)).To(Succeed())
+
+		Expect((&FleetReportReconciler{
+			Client:         k8sManager.GetClient(),
+			Scheme:         k8sManager.GetScheme(),
+			ResyncInterval: 50 * time.Millisecond,
+		}).SetupWithManager(k8sManager)).To(Succeed())
+
+		Expect((&OperationReconciler{
+			Client: k8sManager.GetClient(),
+			Scheme: k8sManager.GetScheme(),
+		}).SetupWithManager(k8sManager)).To(Succeed())
+
 		go func() {
 			defer GinkgoRecover()
 			Expect(k8sManager.Start(mgrCtx)).To(Succeed(), "failed to start manager")
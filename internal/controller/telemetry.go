@@ -0,0 +1,215 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+	"github.com/prometheus/client_golang/prometheus"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultTelemetryConcurrency is the default number of BMCs whose metric reports are read at the same time.
+	DefaultTelemetryConcurrency = 10
+	// DefaultTelemetryMaxSeriesPerBMC is the default maximum number of metric values exported per BMC.
+	DefaultTelemetryMaxSeriesPerBMC = 200
+)
+
+var bmcMetricValueDesc = prometheus.NewDesc(
+	"metal_operator_bmc_metric_value",
+	"Value of a metric report of the Redfish TelemetryService of a BMC.",
+	[]string{"bmc", "server", "report", "metric_id", "metric_property"}, nil,
+)
+
+// telemetrySample is a metric value of a BMC together with the Server it belongs to.
+type telemetrySample struct {
+	server         string
+	report         string
+	metricID       string
+	metricProperty string
+	value          float64
+}
+
+// TelemetryCollector periodically reads the metric reports of the TelemetryService of all BMCs and exports their
+// values as metrics. Scrapes are served from the last collected reports, so that they never reach the BMCs.
+type TelemetryCollector struct {
+	Client     client.Client
+	Insecure   bool
+	BMCOptions bmc.BMCOptions
+	// Interval is the interval in which the metric reports are read from the BMCs.
+	Interval time.Duration
+	// Concurrency is the number of BMCs whose metric reports are read at the same time. A zero value selects
+	// DefaultTelemetryConcurrency.
+	Concurrency int
+	// MaxSeriesPerBMC is the maximum number of metric values exported per BMC, further values are dropped. A zero
+	// value selects DefaultTelemetryMaxSeriesPerBMC.
+	MaxSeriesPerBMC int
+
+	mu      sync.RWMutex
+	samples map[string][]telemetrySample
+}
+
+// Start implements manager.Runnable.
+func (c *TelemetryCollector) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("telemetry-collector")
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := c.collect(ctx); err != nil {
+				log.Error(err, "Failed to collect BMC metric reports")
+			}
+		}
+	}
+}
+
+func (c *TelemetryCollector) collect(ctx context.Context) error {
+	log := ctrl.Log.WithName("telemetry-collector")
+	bmcList := &metalv1alpha1.BMCList{}
+	if err := c.Client.List(ctx, bmcList); err != nil {
+		return fmt.Errorf("failed to list BMCs: %w", err)
+	}
+	serverList := &metalv1alpha1.ServerList{}
+	if err := c.Client.List(ctx, serverList); err != nil {
+		return fmt.Errorf("failed to list Servers: %w", err)
+	}
+	serversByBMC := map[string][]metalv1alpha1.Server{}
+	for _, server := range serverList.Items {
+		if server.Spec.BMCRef != nil {
+			serversByBMC[server.Spec.BMCRef.Name] = append(serversByBMC[server.Spec.BMCRef.Name], server)
+		}
+	}
+
+	concurrency := c.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultTelemetryConcurrency
+	}
+	bmcs := make(chan *metalv1alpha1.BMC)
+	samples := make(map[string][]telemetrySample, len(bmcList.Items))
+	var (
+		wg        sync.WaitGroup
+		samplesMu sync.Mutex
+	)
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for bmcObj := range bmcs {
+				reports, err := c.getMetricReports(ctx, bmcObj)
+				if err != nil {
+					log.V(1).Info("Failed to get metric reports", "BMC", bmcObj.Name, "Error", err.Error())
+					continue
+				}
+				bmcSamples := c.samplesFromReports(log, bmcObj.Name, serversByBMC[bmcObj.Name], reports)
+				samplesMu.Lock()
+				samples[bmcObj.Name] = bmcSamples
+				samplesMu.Unlock()
+			}
+		}()
+	}
+	for i := range bmcList.Items {
+		bmcObj := &bmcList.Items[i]
+		if ignored, _ := shouldIgnoreReconciliation(bmcObj); ignored {
+			continue
+		}
+		select {
+		case bmcs <- bmcObj:
+		case <-ctx.Done():
+		}
+	}
+	close(bmcs)
+	wg.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.samples = samples
+	return nil
+}
+
+// samplesFromReports returns the samples of the metric reports of a BMC. Reports may contain the same property
+// several times, e.g. for aggregated values, of which only the first value is kept. At most MaxSeriesPerBMC
+// samples are returned, which bounds the number of series exported for the BMC.
+func (c *TelemetryCollector) samplesFromReports(log logr.Logger, bmcName string, servers []metalv1alpha1.Server, reports []bmc.MetricReport) []telemetrySample {
+	maxSeries := c.MaxSeriesPerBMC
+	if maxSeries <= 0 {
+		maxSeries = DefaultTelemetryMaxSeriesPerBMC
+	}
+	var samples []telemetrySample
+	seen := map[telemetrySample]struct{}{}
+	for _, report := range reports {
+		for _, value := range report.Values {
+			sample := telemetrySample{
+				server:         serverForMetricProperty(servers, value.MetricProperty),
+				report:         report.ID,
+				metricID:       value.MetricID,
+				metricProperty: value.MetricProperty,
+			}
+			if _, ok := seen[sample]; ok {
+				continue
+			}
+			seen[sample] = struct{}{}
+			if len(samples) == maxSeries {
+				log.V(1).Info("Dropping metric values exceeding the maximum number of series", "BMC", bmcName,
+					"MaxSeries", maxSeries)
+				return samples
+			}
+			sample.value = value.Value
+			samples = append(samples, sample)
+		}
+	}
+	return samples
+}
+
+func (c *TelemetryCollector) getMetricReports(ctx context.Context, bmcObj *metalv1alpha1.BMC) ([]bmc.MetricReport, error) {
+	bmcClient, err := bmcutils.GetBMCClientFromBMC(ctx, c.Client, bmcObj, c.Insecure, c.BMCOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create BMC client: %w", err)
+	}
+	defer bmcClient.Logout()
+	return bmcClient.GetMetricReports(ctx)
+}
+
+// serverForMetricProperty returns the name of the Server whose system the metric property refers to. Metric
+// properties of resources other than systems, e.g. chassis, are attributed to the only Server of the BMC.
+func serverForMetricProperty(servers []metalv1alpha1.Server, metricProperty string) string {
+	for _, server := range servers {
+		if server.Spec.SystemURI != "" && strings.HasPrefix(metricProperty, strings.TrimSuffix(server.Spec.SystemURI, "/")+"/") {
+			return server.Name
+		}
+	}
+	if len(servers) == 1 {
+		return servers[0].Name
+	}
+	return ""
+}
+
+// Describe implements prometheus.Collector.
+func (c *TelemetryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- bmcMetricValueDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *TelemetryCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for bmcName, samples := range c.samples {
+		for _, sample := range samples {
+			ch <- prometheus.MustNewConstMetric(bmcMetricValueDesc, prometheus.GaugeValue, sample.value,
+				bmcName, sample.server, sample.report, sample.metricID, sample.metricProperty)
+		}
+	}
+}
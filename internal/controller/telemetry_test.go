@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"fmt"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("TelemetryCollector", func() {
+	servers := []metalv1alpha1.Server{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node1"}, Spec: metalv1alpha1.ServerSpec{SystemURI: "/redfish/v1/Systems/1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node2"}, Spec: metalv1alpha1.ServerSpec{SystemURI: "/redfish/v1/Systems/2"}},
+	}
+
+	It("Should attribute the metric values to the Servers and drop duplicates", func() {
+		collector := &TelemetryCollector{}
+		samples := collector.samplesFromReports(GinkgoLogr, "rack", servers, []bmc.MetricReport{{
+			ID: "Power",
+			Values: []bmc.MetricValue{
+				{MetricID: "PowerConsumedWatts", MetricProperty: "/redfish/v1/Systems/1/Power#/PowerConsumedWatts", Value: 231.5},
+				{MetricID: "PowerConsumedWatts", MetricProperty: "/redfish/v1/Systems/1/Power#/PowerConsumedWatts", Value: 240},
+				{MetricID: "PowerConsumedWatts", MetricProperty: "/redfish/v1/Systems/2/Power#/PowerConsumedWatts", Value: 198},
+				{MetricID: "PowerConsumedWatts", MetricProperty: "/redfish/v1/Chassis/rack/Power#/PowerConsumedWatts", Value: 1200},
+			},
+		}})
+		Expect(samples).To(ConsistOf(
+			telemetrySample{server: "node1", report: "Power", metricID: "PowerConsumedWatts",
+				metricProperty: "/redfish/v1/Systems/1/Power#/PowerConsumedWatts", value: 231.5},
+			telemetrySample{server: "node2", report: "Power", metricID: "PowerConsumedWatts",
+				metricProperty: "/redfish/v1/Systems/2/Power#/PowerConsumedWatts", value: 198},
+			telemetrySample{server: "", report: "Power", metricID: "PowerConsumedWatts",
+				metricProperty: "/redfish/v1/Chassis/rack/Power#/PowerConsumedWatts", value: 1200},
+		))
+	})
+
+	It("Should export at most the maximum number of series per BMC", func() {
+		values := make([]bmc.MetricValue, 0, 10)
+		for i := range 10 {
+			values = append(values, bmc.MetricValue{
+				MetricID:       "ReadingCelsius",
+				MetricProperty: fmt.Sprintf("/redfish/v1/Chassis/1/Sensors/Temp%d#/Reading", i),
+				Value:          float64(40 + i),
+			})
+		}
+		collector := &TelemetryCollector{MaxSeriesPerBMC: 4}
+		Expect(collector.samplesFromReports(GinkgoLogr, "bmc", nil, []bmc.MetricReport{{ID: "Thermal", Values: values}})).
+			To(HaveLen(4))
+	})
+})
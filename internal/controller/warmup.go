@@ -0,0 +1,239 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// WarmUpStateConfigMapName is the name of the ConfigMap holding the warm-up state of the leader.
+	WarmUpStateConfigMapName = "metal-operator-warmup-state"
+	// WarmUpStateKey is the ConfigMap data key holding the warm-up state as JSON.
+	WarmUpStateKey = "state.json"
+)
+
+// WarmUpEntry is the persisted warm-up state of a single BMC.
+type WarmUpEntry struct {
+	// Reachable is true if the BMC was reachable when the state was persisted.
+	Reachable bool `json:"reachable"`
+	// InventoryHash is a hash over the spec generation and the inventory of the BMC.
+	InventoryHash string `json:"inventoryHash"`
+}
+
+// WarmUp spreads the first BMC polls of a newly elected leader over the warm-up period instead of polling
+// every BMC at once. Operations on the BMCs and their servers are not deferred. The leader periodically persists
+// the reachability and inventory hash of all BMCs into a ConfigMap, which its successor loads on startup:
+//   - BMCs which are unknown or whose inventory hash changed since are polled immediately.
+//   - BMCs which were reachable are reconnected at a jittered point in the first half of the period.
+//   - BMCs which were unreachable are reconnected at a jittered point in the second half of the period.
+type WarmUp struct {
+	Client client.Client
+	// APIReader is used to load the persisted state before the caches are synced.
+	APIReader client.Reader
+	Namespace string
+	// Period is the duration over which the first connections are spread. A zero value disables the warm-up.
+	Period time.Duration
+	// Interval is the interval in which the warm-up state is persisted.
+	Interval time.Duration
+
+	mu      sync.Mutex
+	started time.Time
+	loaded  bool
+	state   map[string]WarmUpEntry
+}
+
+// Start implements manager.Runnable. It is only started on the elected leader.
+func (w *WarmUp) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("warm-up")
+	w.mu.Lock()
+	if w.started.IsZero() {
+		w.started = time.Now()
+	}
+	w.mu.Unlock()
+
+	state, err := w.load(ctx)
+	if err != nil {
+		log.Error(err, "Failed to load warm-up state, BMCs are reconciled without warm-up")
+	}
+	w.mu.Lock()
+	w.state = state
+	w.loaded = true
+	w.mu.Unlock()
+	log.V(1).Info("Loaded warm-up state", "BMCs", len(state))
+
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := w.persist(ctx); err != nil {
+				log.Error(err, "Failed to persist warm-up state")
+			}
+		}
+	}
+}
+
+// Delay returns the time the first connection to the given BMC should be deferred by. Once the warm-up
+// period is over, Delay always returns zero.
+func (w *WarmUp) Delay(bmcObj *metalv1alpha1.BMC) time.Duration {
+	if w == nil || w.Period <= 0 {
+		return 0
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.started.IsZero() {
+		w.started = time.Now()
+	}
+	elapsed := time.Since(w.started)
+	if elapsed >= w.Period {
+		return 0
+	}
+
+	reachable := true
+	if w.loaded {
+		entry, ok := w.state[bmcObj.Name]
+		if !ok || entry.InventoryHash != bmcInventoryHash(bmcObj) {
+			return 0
+		}
+		reachable = entry.Reachable
+	}
+	return max(0, w.slot(bmcObj.Name, reachable)-elapsed)
+}
+
+// DelayInline returns the time the first connection to the inline BMC of the given Server should be deferred
+// by. Inline BMCs are not part of the persisted state and are treated as reachable.
+func (w *WarmUp) DelayInline(server *metalv1alpha1.Server) time.Duration {
+	if w == nil || w.Period <= 0 {
+		return 0
+	}
+	w.mu.Lock()
+	if w.started.IsZero() {
+		w.started = time.Now()
+	}
+	elapsed := time.Since(w.started)
+	w.mu.Unlock()
+	if elapsed >= w.Period {
+		return 0
+	}
+	return max(0, w.slot(server.Name, true)-elapsed)
+}
+
+// slot returns the jittered point in the warm-up period at which the given key is reconnected.
+func (w *WarmUp) slot(key string, reachable bool) time.Duration {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	half := w.Period / 2
+	if half <= 0 {
+		return 0
+	}
+	offset := time.Duration(h.Sum64() % uint64(half))
+	if !reachable {
+		offset += half
+	}
+	return offset
+}
+
+func (w *WarmUp) load(ctx context.Context) (map[string]WarmUpEntry, error) {
+	reader := w.APIReader
+	if reader == nil {
+		reader = w.Client
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: w.Namespace, Name: WarmUpStateConfigMapName}, configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return map[string]WarmUpEntry{}, nil
+		}
+		return nil, fmt.Errorf("failed to get warm-up state ConfigMap: %w", err)
+	}
+	state := map[string]WarmUpEntry{}
+	if data, ok := configMap.Data[WarmUpStateKey]; ok {
+		if err := json.Unmarshal([]byte(data), &state); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal warm-up state: %w", err)
+		}
+	}
+	return state, nil
+}
+
+func (w *WarmUp) persist(ctx context.Context) error {
+	bmcList := &metalv1alpha1.BMCList{}
+	if err := w.Client.List(ctx, bmcList); err != nil {
+		return fmt.Errorf("failed to list BMCs: %w", err)
+	}
+	state := make(map[string]WarmUpEntry, len(bmcList.Items))
+	for i := range bmcList.Items {
+		bmcObj := &bmcList.Items[i]
+		state[bmcObj.Name] = WarmUpEntry{
+			Reachable:     bmcObj.Status.State == metalv1alpha1.BMCStateEnabled,
+			InventoryHash: bmcInventoryHash(bmcObj),
+		}
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal warm-up state: %w", err)
+	}
+
+	configMap := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: w.Namespace, Name: WarmUpStateConfigMapName}
+	if err := w.Client.Get(ctx, key, configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get warm-up state ConfigMap: %w", err)
+		}
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Data:       map[string]string{WarmUpStateKey: string(data)},
+		}
+		if err := w.Client.Create(ctx, configMap); err != nil {
+			return fmt.Errorf("failed to create warm-up state ConfigMap: %w", err)
+		}
+		return nil
+	}
+
+	configMapBase := configMap.DeepCopy()
+	configMap.Data = map[string]string{WarmUpStateKey: string(data)}
+	if err := w.Client.Patch(ctx, configMap, client.MergeFrom(configMapBase)); err != nil {
+		return fmt.Errorf("failed to patch warm-up state ConfigMap: %w", err)
+	}
+	return nil
+}
+
+// bmcInventoryHash hashes the spec generation and the inventory of a BMC, so that BMCs which changed during
+// a leader handoff are not deferred.
+func bmcInventoryHash(bmcObj *metalv1alpha1.BMC) string {
+	data, _ := json.Marshal(struct {
+		Generation      int64
+		MACAddress      string
+		IP              string
+		Manufacturer    string
+		Model           string
+		SerialNumber    string
+		FirmwareVersion string
+	}{
+		Generation:      bmcObj.Generation,
+		MACAddress:      bmcObj.Status.MACAddress,
+		IP:              bmcObj.Status.IP.String(),
+		Manufacturer:    bmcObj.Status.Manufacturer,
+		Model:           bmcObj.Status.Model,
+		SerialNumber:    bmcObj.Status.SerialNumber,
+		FirmwareVersion: bmcObj.Status.FirmwareVersion,
+	})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
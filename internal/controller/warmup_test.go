@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"time"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+)
+
+var _ = Describe("WarmUp", func() {
+	newBMC := func(name string) *metalv1alpha1.BMC {
+		return &metalv1alpha1.BMC{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     metalv1alpha1.BMCStatus{SerialNumber: "2M220100SL"},
+		}
+	}
+
+	It("Should not defer BMCs which are unknown or changed since the state was persisted", func() {
+		known := newBMC("known")
+		warmUp := &WarmUp{
+			Period:  time.Hour,
+			started: time.Now(),
+			loaded:  true,
+			state: map[string]WarmUpEntry{
+				"known": {Reachable: true, InventoryHash: bmcInventoryHash(known)},
+			},
+		}
+		Expect(warmUp.Delay(newBMC("unknown"))).To(BeZero())
+
+		changed := newBMC("known")
+		changed.Status.FirmwareVersion = "1.45.455b66-rev5"
+		Expect(warmUp.Delay(changed)).To(BeZero())
+	})
+
+	It("Should defer reachable BMCs into the first and unreachable BMCs into the second half of the period", func() {
+		reachable := newBMC("reachable")
+		unreachable := newBMC("unreachable")
+		warmUp := &WarmUp{
+			Period:  time.Hour,
+			started: time.Now(),
+			loaded:  true,
+			state: map[string]WarmUpEntry{
+				"reachable":   {Reachable: true, InventoryHash: bmcInventoryHash(reachable)},
+				"unreachable": {Reachable: false, InventoryHash: bmcInventoryHash(unreachable)},
+			},
+		}
+		Expect(warmUp.Delay(reachable)).To(BeNumerically("<", 30*time.Minute))
+		Expect(warmUp.Delay(unreachable)).To(SatisfyAll(
+			BeNumerically(">", 29*time.Minute),
+			BeNumerically("<", time.Hour),
+		))
+	})
+
+	It("Should not defer BMCs once the period is over", func() {
+		unreachable := newBMC("unreachable")
+		warmUp := &WarmUp{
+			Period:  time.Hour,
+			started: time.Now().Add(-2 * time.Hour),
+			loaded:  true,
+			state: map[string]WarmUpEntry{
+				"unreachable": {Reachable: false, InventoryHash: bmcInventoryHash(unreachable)},
+			},
+		}
+		Expect(warmUp.Delay(unreachable)).To(BeZero())
+
+		var disabled *WarmUp
+		Expect(disabled.Delay(unreachable)).To(BeZero())
+	})
+})
+
+var _ = Describe("Server Warm-Up", func() {
+	_ = SetupTest()
+
+	var server *metalv1alpha1.Server
+
+	BeforeEach(func(ctx SpecContext) {
+		registerSimulator("10.30.0.9:8000", "38947555-7742-3448-3784-823347823842")
+		server = createPausedServer(ctx, "10.30.0.9", "38947555-7742-3448-3784-823347823842")
+	})
+
+	It("Should only defer the status polling of a Server during the warm-up", func(ctx SpecContext) {
+		reconciler := &ServerReconciler{
+			Client:     k8sClient,
+			Insecure:   true,
+			BMCOptions: bmc.BMCOptions{BasicAuth: true},
+			WarmUp:     &WarmUp{Period: time.Hour, started: time.Now()},
+		}
+
+		By("Deferring the polling while the leader warms up")
+		Eventually(func(g Gomega) {
+			delay, err := reconciler.pollServerStatus(ctx, GinkgoLogr, server)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(delay).To(BeNumerically(">", 0))
+		}).Should(Succeed())
+		Expect(Object(server)()).To(HaveField("Status.PowerState", BeEmpty()))
+
+		By("Polling the BMC once the warm-up is over")
+		reconciler.WarmUp.started = time.Now().Add(-2 * time.Hour)
+		Eventually(func(g Gomega) {
+			delay, err := reconciler.pollServerStatus(ctx, GinkgoLogr, server)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(delay).To(BeZero())
+		}).Should(Succeed())
+		Expect(Object(server)()).To(HaveField("Status.PowerState", metalv1alpha1.ServerOnPowerState))
+	})
+})
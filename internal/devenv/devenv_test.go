@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package devenv
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/api/registry"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("DevEnv", func() {
+	var k8sClient client.Client
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(metalv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(v1.AddToScheme(scheme)).To(Succeed())
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).
+			WithStatusSubresource(&metalv1alpha1.ServerBootConfiguration{}).Build()
+	})
+
+	It("Should create a fleet of simulated BMCs with distinct addresses", func(ctx SpecContext) {
+		fleet := Fleet{Prefix: "mock-bmc", Size: 3}
+		Expect(fleet.Apply(ctx, k8sClient)).To(Succeed())
+		// applying the fleet again must keep the addresses
+		Expect(fleet.Apply(ctx, k8sClient)).To(Succeed())
+
+		bmcs := &metalv1alpha1.BMCList{}
+		Expect(k8sClient.List(ctx, bmcs)).To(Succeed())
+		Expect(bmcs.Items).To(HaveLen(3))
+		addresses := map[string]bool{}
+		for _, bmcObj := range bmcs.Items {
+			Expect(bmcObj.Spec.Protocol.Name).To(BeEquivalentTo(metalv1alpha1.ProtocolRedfishFake))
+			Expect(bmcObj.Spec.BMCSecretRef.Name).To(Equal(bmcObj.Name))
+			addresses[bmcObj.Spec.Endpoint.IP.String()] = true
+		}
+		Expect(addresses).To(HaveLen(3))
+
+		Expect(fleet.Delete(ctx, k8sClient)).To(Succeed())
+		Expect(k8sClient.List(ctx, bmcs)).To(Succeed())
+		Expect(bmcs.Items).To(BeEmpty())
+	})
+
+	It("Should seed BMCs of a vendor with fixtures of their simulated BMCs", func(ctx SpecContext) {
+		seed := Seed{Prefix: "seed", Count: 5, Vendor: "dell", RandomSeed: 42}
+		Expect(seed.Apply(ctx, k8sClient)).To(Succeed())
+
+		fixtures, err := seed.Fixtures()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fixtures).To(HaveLen(5))
+		// the fixtures are reproducible
+		Expect(seed.Fixtures()).To(Equal(fixtures))
+
+		configMap := &v1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: bmcutils.DefaultKubeNamespace,
+			Name: bmcutils.SimulatorFixturesConfigMapName}, configMap)).To(Succeed())
+		Expect(configMap.Data).To(HaveLen(5))
+
+		bmcs := &metalv1alpha1.BMCList{}
+		Expect(k8sClient.List(ctx, bmcs)).To(Succeed())
+		Expect(bmcs.Items).To(HaveLen(5))
+		for _, bmcObj := range bmcs.Items {
+			endpoint := &metalv1alpha1.Endpoint{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: bmcObj.Spec.EndpointRef.Name}, endpoint)).To(Succeed())
+			data, ok := configMap.Data[bmcutils.SimulatorFixtureKey(endpoint.Spec.IP.String()+":8000")]
+			Expect(ok).To(BeTrue())
+			var fixture bmc.SimulatorFixture
+			Expect(json.Unmarshal([]byte(data), &fixture)).To(Succeed())
+			Expect(fixture.Manufacturer).To(Equal("Dell Inc."))
+			Expect(fixture.BMCModel).To(Equal("iDRAC9"))
+			Expect(fixture.BMCMACAddress).To(Equal(endpoint.Spec.MACAddress))
+		}
+
+		Expect(seed.Delete(ctx, k8sClient)).To(Succeed())
+		Expect(k8sClient.List(ctx, bmcs)).To(Succeed())
+		Expect(bmcs.Items).To(BeEmpty())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(configMap), configMap)).To(Succeed())
+		Expect(configMap.Data).To(BeEmpty())
+	})
+
+	It("Should reject unknown vendors", func() {
+		_, err := (Seed{Prefix: "seed", Count: 1, Vendor: "unknown"}).Fixtures()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Should mark boot configurations as ready and register servers in discovery", func(ctx SpecContext) {
+		var registrations []registry.RegistrationPayload
+		registryServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Path).To(Equal("/register"))
+			var payload registry.RegistrationPayload
+			Expect(json.NewDecoder(r.Body).Decode(&payload)).To(Succeed())
+			registrations = append(registrations, payload)
+			w.WriteHeader(http.StatusCreated)
+		}))
+		DeferCleanup(registryServer.Close)
+
+		bootConfig := &metalv1alpha1.ServerBootConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "discovering"},
+		}
+		Expect(k8sClient.Create(ctx, bootConfig)).To(Succeed())
+		for _, server := range []*metalv1alpha1.Server{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "discovering"},
+				Spec:       metalv1alpha1.ServerSpec{SystemUUID: "discovering-uuid"},
+				Status: metalv1alpha1.ServerStatus{
+					State:      metalv1alpha1.ServerStateDiscovery,
+					PowerState: metalv1alpha1.ServerOnPowerState,
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "available"},
+				Spec:       metalv1alpha1.ServerSpec{SystemUUID: "available-uuid"},
+				Status: metalv1alpha1.ServerStatus{
+					State:      metalv1alpha1.ServerStateAvailable,
+					PowerState: metalv1alpha1.ServerOffPowerState,
+				},
+			},
+		} {
+			Expect(k8sClient.Create(ctx, server)).To(Succeed())
+		}
+
+		responder := &DiscoveryResponder{Client: k8sClient, RegistryURL: registryServer.URL}
+		Expect(responder.Respond(ctx)).To(Succeed())
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(bootConfig), bootConfig)).To(Succeed())
+		Expect(bootConfig.Status.State).To(Equal(metalv1alpha1.ServerBootConfigurationStateReady))
+		Expect(registrations).To(ConsistOf(SatisfyAll(
+			HaveField("SystemUUID", "discovering-uuid"),
+			HaveField("Data.NetworkInterfaces", HaveLen(1)),
+		)))
+	})
+})
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package devenv
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/internal/api/registry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DiscoveryResponder stands in for the boot operator and the probe agents of a fleet of simulated BMCs. It marks
+// ServerBootConfigurations as ready with their current ignition and registers powered on Servers in discovery with
+// the registry of the operator.
+type DiscoveryResponder struct {
+	Client client.Client
+	// RegistryURL is the URL under which the registry of the operator is reachable.
+	RegistryURL string
+	// Interval is the interval in which the responder looks for boot configurations and servers.
+	Interval time.Duration
+}
+
+// Start runs the responder until the context is done.
+func (d *DiscoveryResponder) Start(ctx context.Context) error {
+	ticker := time.NewTicker(d.Interval)
+	defer ticker.Stop()
+	for {
+		if err := d.Respond(ctx); err != nil && ctx.Err() == nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Respond handles the current boot configurations and servers once.
+func (d *DiscoveryResponder) Respond(ctx context.Context) error {
+	bootConfigs := &metalv1alpha1.ServerBootConfigurationList{}
+	if err := d.Client.List(ctx, bootConfigs); err != nil {
+		return fmt.Errorf("failed to list ServerBootConfigurations: %w", err)
+	}
+	var errs []error
+	for i := range bootConfigs.Items {
+		bootConfig := &bootConfigs.Items[i]
+		hash := bootConfig.Annotations[metalv1alpha1.IgnitionHashAnnotation]
+		if bootConfig.Status.State == metalv1alpha1.ServerBootConfigurationStateReady && bootConfig.Status.ObservedIgnitionHash == hash {
+			continue
+		}
+		bootConfigBase := bootConfig.DeepCopy()
+		bootConfig.Status.State = metalv1alpha1.ServerBootConfigurationStateReady
+		bootConfig.Status.ObservedIgnitionHash = hash
+		if err := d.Client.Status().Patch(ctx, bootConfig, client.MergeFrom(bootConfigBase)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to patch ServerBootConfiguration %s/%s: %w", bootConfig.Namespace, bootConfig.Name, err))
+		}
+	}
+
+	servers := &metalv1alpha1.ServerList{}
+	if err := d.Client.List(ctx, servers); err != nil {
+		return fmt.Errorf("failed to list Servers: %w", err)
+	}
+	for _, server := range servers.Items {
+		if server.Status.State != metalv1alpha1.ServerStateDiscovery || server.Status.PowerState != metalv1alpha1.ServerOnPowerState {
+			continue
+		}
+		if err := d.register(ctx, server.Spec.SystemUUID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to register Server %s: %w", server.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// register posts the data a probe agent would collect on the system to the registry. The network interface is
+// derived from the system UUID.
+func (d *DiscoveryResponder) register(ctx context.Context, systemUUID string) error {
+	sum := sha256.Sum256([]byte(systemUUID))
+	data, err := json.Marshal(registry.RegistrationPayload{
+		SystemUUID: systemUUID,
+		Data: registry.Server{
+			NetworkInterfaces: []registry.NetworkInterface{{
+				Name:       "eth0",
+				IPAddress:  fmt.Sprintf("10.201.%d.%d", sum[0], sum[1]),
+				MACAddress: fmt.Sprintf("02:%02x:%02x:%02x:%02x:%02x", sum[2], sum[3], sum[4], sum[5], sum[6]),
+			}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal registration: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.RegistryURL+"/register", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create registration request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() // nolint: errcheck
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("registry responded with %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package devenv sets up development and e2e environments of the metal-operator: a kind cluster running the
+// operator, a fleet of simulated BMCs and a responder standing in for the boot operator and the probe agent.
+package devenv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"slices"
+	"strings"
+)
+
+const (
+	// DefaultClusterName is the name of the kind cluster of the environment.
+	DefaultClusterName = "metal"
+	// DefaultKustomizeDir is the kustomization deploying the operator for a fleet of simulated BMCs.
+	DefaultKustomizeDir = "config/e2e"
+	// Namespace is the namespace the operator is deployed to.
+	Namespace = "metal-operator-system"
+	// ManagerDeployment is the name of the deployment of the operator.
+	ManagerDeployment = "metal-operator-controller-manager"
+	// RegistryPort is the port of the registry of the operator in the environment.
+	RegistryPort = 30000
+
+	certManagerURL = "https://github.com/jetstack/cert-manager/releases/download/v1.5.3/cert-manager.yaml"
+	// managerImagePlaceholder is the image of the manager in the kustomization, which is replaced by the image of
+	// the environment.
+	managerImagePlaceholder = "controller:latest"
+)
+
+// Environment is a kind cluster running the metal-operator.
+type Environment struct {
+	// ClusterName is the name of the kind cluster.
+	ClusterName string
+	// Image is the image of the operator. It is loaded into the kind cluster, so it only has to exist locally.
+	Image string
+	// KustomizeDir is the kustomization the operator is deployed with.
+	KustomizeDir string
+	// Out receives the output of the commands run for the environment. If nil, it is discarded.
+	Out io.Writer
+}
+
+// Up creates the kind cluster unless it exists and deploys cert-manager and the operator to it.
+func (e *Environment) Up(ctx context.Context) error {
+	clusters, err := e.output(ctx, nil, "kind", "get", "clusters")
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(strings.Fields(clusters), e.ClusterName) {
+		if err := e.run(ctx, nil, "kind", "create", "cluster", "--name", e.ClusterName); err != nil {
+			return err
+		}
+	}
+	if err := e.run(ctx, nil, "kubectl", "config", "use-context", "kind-"+e.ClusterName); err != nil {
+		return err
+	}
+	if err := e.run(ctx, nil, "kind", "load", "docker-image", e.Image, "--name", e.ClusterName); err != nil {
+		return err
+	}
+
+	if err := e.run(ctx, nil, "kubectl", "apply", "-f", certManagerURL); err != nil {
+		return err
+	}
+	if err := e.run(ctx, nil, "kubectl", "wait", "deployment.apps/cert-manager-webhook", "--for", "condition=Available",
+		"--namespace", "cert-manager", "--timeout", "5m"); err != nil {
+		return err
+	}
+
+	manifests, err := e.output(ctx, nil, "kubectl", "kustomize", e.KustomizeDir)
+	if err != nil {
+		return err
+	}
+	manifests = strings.ReplaceAll(manifests, "image: "+managerImagePlaceholder, "image: "+e.Image)
+	if err := e.run(ctx, strings.NewReader(manifests), "kubectl", "apply", "--server-side", "-f", "-"); err != nil {
+		return err
+	}
+	return e.run(ctx, nil, "kubectl", "wait", "deployment/"+ManagerDeployment, "--for", "condition=Available",
+		"--namespace", Namespace, "--timeout", "5m")
+}
+
+// Down deletes the kind cluster.
+func (e *Environment) Down(ctx context.Context) error {
+	return e.run(ctx, nil, "kind", "delete", "cluster", "--name", e.ClusterName)
+}
+
+// PortForward forwards the local port to the port of the manager until the context is done. It returns once the
+// forwarding has been set up.
+func (e *Environment) PortForward(ctx context.Context, localPort, port int) error {
+	cmd := exec.CommandContext(ctx, "kubectl", "port-forward", "--namespace", Namespace,
+		"deployment/"+ManagerDeployment, fmt.Sprintf("%d:%d", localPort, port))
+	cmd.Stderr = e.out()
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to get output of port forwarding: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start port forwarding: %w", err)
+	}
+	go func() {
+		_ = cmd.Wait()
+	}()
+
+	// kubectl reports the forwarding once it is ready to accept connections
+	line := make([]byte, 256)
+	n, err := stdout.Read(line)
+	if err != nil {
+		return fmt.Errorf("failed to forward port %d: %w", port, err)
+	}
+	_, _ = e.out().Write(line[:n])
+	go func() {
+		_, _ = io.Copy(e.out(), stdout)
+	}()
+	return nil
+}
+
+func (e *Environment) run(ctx context.Context, stdin io.Reader, name string, args ...string) error {
+	_, err := e.output(ctx, stdin, name, args...)
+	return err
+}
+
+// output runs the command and returns its standard output. The standard error is written to Out.
+func (e *Environment) output(ctx context.Context, stdin io.Reader, name string, args ...string) (string, error) {
+	_, _ = fmt.Fprintf(e.out(), "running: %s %s\n", name, strings.Join(args, " "))
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = stdin
+	cmd.Stderr = e.out()
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s %s failed: %w", name, strings.Join(args, " "), err)
+	}
+	return stdout.String(), nil
+}
+
+func (e *Environment) out() io.Writer {
+	if e.Out == nil {
+		return io.Discard
+	}
+	return e.Out
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package devenv
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// fleetNetwork is the network the addresses of the simulated BMCs are taken from. The addresses are never
+// connected to, as the simulated BMCs run within the manager.
+var fleetNetwork = netip.MustParseAddr("10.200.0.1")
+
+// Fleet is a set of BMCs using the RedfishFake protocol, each managing a single simulated system.
+type Fleet struct {
+	// Prefix is the name prefix of the BMC and BMCSecret objects of the fleet.
+	Prefix string
+	// Size is the number of BMCs of the fleet.
+	Size int
+}
+
+// BMCName returns the name of the i-th BMC of the fleet.
+func (f Fleet) BMCName(i int) string {
+	return fmt.Sprintf("%s-%d", f.Prefix, i)
+}
+
+// Apply creates or updates the BMC and BMCSecret objects of the fleet.
+func (f Fleet) Apply(ctx context.Context, c client.Client) error {
+	address := fleetNetwork
+	for i := range f.Size {
+		bmcSecret := &metalv1alpha1.BMCSecret{}
+		bmcSecret.Name = f.BMCName(i)
+		if _, err := controllerutil.CreateOrPatch(ctx, c, bmcSecret, func() error {
+			bmcSecret.Data = map[string][]byte{
+				metalv1alpha1.BMCSecretUsernameKeyName: []byte("admin"),
+				metalv1alpha1.BMCSecretPasswordKeyName: []byte("admin"),
+			}
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to apply BMCSecret %s: %w", bmcSecret.Name, err)
+		}
+
+		bmcObj := &metalv1alpha1.BMC{}
+		bmcObj.Name = f.BMCName(i)
+		if _, err := controllerutil.CreateOrPatch(ctx, c, bmcObj, func() error {
+			if bmcObj.Spec.Endpoint == nil {
+				bmcObj.Spec.Endpoint = &metalv1alpha1.InlineEndpoint{IP: metalv1alpha1.MustParseIP(address.String())}
+			}
+			bmcObj.Spec.Protocol = metalv1alpha1.Protocol{Name: metalv1alpha1.ProtocolRedfishFake, Port: 8000}
+			bmcObj.Spec.BMCSecretRef = v1.LocalObjectReference{Name: bmcSecret.Name}
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to apply BMC %s: %w", bmcObj.Name, err)
+		}
+		address = address.Next()
+	}
+	return nil
+}
+
+// Delete deletes the BMC and BMCSecret objects of the fleet.
+func (f Fleet) Delete(ctx context.Context, c client.Client) error {
+	for i := range f.Size {
+		bmcObj := &metalv1alpha1.BMC{}
+		bmcObj.Name = f.BMCName(i)
+		if err := client.IgnoreNotFound(c.Delete(ctx, bmcObj)); err != nil {
+			return fmt.Errorf("failed to delete BMC %s: %w", bmcObj.Name, err)
+		}
+		bmcSecret := &metalv1alpha1.BMCSecret{}
+		bmcSecret.Name = f.BMCName(i)
+		if err := client.IgnoreNotFound(c.Delete(ctx, bmcSecret)); err != nil {
+			return fmt.Errorf("failed to delete BMCSecret %s: %w", bmcSecret.Name, err)
+		}
+	}
+	return nil
+}
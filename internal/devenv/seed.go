@@ -0,0 +1,283 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package devenv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"math/rand/v2"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// seedPort is the port of the seeded BMCs.
+const seedPort = 8000
+
+// vendorProfile describes the hardware of a vendor the fixtures of seeded BMCs are generated from.
+type vendorProfile struct {
+	manufacturer        string
+	models              []string
+	bmcModel            string
+	bmcFirmwareVersions []string
+	biosVersions        []string
+	// ouis are the prefixes of the MAC addresses of the vendor.
+	ouis            []string
+	processorModels []string
+	// serialPrefix and serialLength describe the serial numbers of the vendor.
+	serialPrefix string
+	serialLength int
+}
+
+var vendorProfiles = map[string]vendorProfile{
+	"dell": {
+		manufacturer:        "Dell Inc.",
+		models:              []string{"PowerEdge R650", "PowerEdge R750", "PowerEdge R6525"},
+		bmcModel:            "iDRAC9",
+		bmcFirmwareVersions: []string{"6.10.30.00", "6.10.80.00", "7.00.00.00"},
+		biosVersions:        []string{"1.10.2", "1.11.2", "1.13.2"},
+		ouis:                []string{"B0:7B:25", "D0:8E:79", "F4:02:70"},
+		processorModels:     []string{"Intel(R) Xeon(R) Gold 6338 CPU @ 2.00GHz", "AMD EPYC 7543 32-Core Processor"},
+		serialLength:        7,
+	},
+	"hpe": {
+		manufacturer:        "HPE",
+		models:              []string{"ProLiant DL360 Gen10 Plus", "ProLiant DL380 Gen10 Plus"},
+		bmcModel:            "iLO 5",
+		bmcFirmwareVersions: []string{"2.72", "2.78", "3.00"},
+		biosVersions:        []string{"U46 v1.64", "U46 v1.72", "U46 v1.80"},
+		ouis:                []string{"94:40:C9", "B4:7A:F1", "88:E9:A4"},
+		processorModels:     []string{"Intel(R) Xeon(R) Gold 6330 CPU @ 2.00GHz", "Intel(R) Xeon(R) Gold 5318Y CPU @ 2.10GHz"},
+		serialPrefix:        "CZ",
+		serialLength:        10,
+	},
+	"lenovo": {
+		manufacturer:        "Lenovo",
+		models:              []string{"ThinkSystem SR630 V2", "ThinkSystem SR650 V2"},
+		bmcModel:            "XClarity Controller",
+		bmcFirmwareVersions: []string{"4.10", "4.30", "5.10"},
+		biosVersions:        []string{"AFE120G-2.10", "AFE122F-3.10", "AFE124E-3.20"},
+		ouis:                []string{"08:94:EF", "7C:D3:0A", "38:68:DD"},
+		processorModels:     []string{"Intel(R) Xeon(R) Gold 6326 CPU @ 2.90GHz", "Intel(R) Xeon(R) Silver 4314 CPU @ 2.40GHz"},
+		serialPrefix:        "J30",
+		serialLength:        8,
+	},
+	"supermicro": {
+		manufacturer:        "Supermicro",
+		models:              []string{"SYS-120U-TNR", "SYS-220U-TNR", "AS -1114S-WN10RT"},
+		bmcModel:            "BMC",
+		bmcFirmwareVersions: []string{"01.01.06", "01.02.04", "01.04.03"},
+		biosVersions:        []string{"1.4", "1.5", "1.6"},
+		ouis:                []string{"3C:EC:EF", "AC:1F:6B", "7C:C2:55"},
+		processorModels:     []string{"Intel(R) Xeon(R) Gold 6342 CPU @ 2.80GHz", "AMD EPYC 7313P 16-Core Processor"},
+		serialPrefix:        "S",
+		serialLength:        15,
+	},
+}
+
+// Vendors returns the vendors fixtures can be seeded for.
+func Vendors() []string {
+	return slices.Sorted(maps.Keys(vendorProfiles))
+}
+
+// Seed is a set of BMCs of a vendor using the RedfishFake protocol, with Endpoints, BMCSecrets and fixtures of
+// their simulated BMCs resembling the hardware of the vendor. The Servers are created by the operator from the
+// simulated BMCs, so that they match the fixtures.
+type Seed struct {
+	// Prefix is the name prefix of the Endpoint, BMC and BMCSecret objects of the seed.
+	Prefix string
+	// Count is the number of BMCs of the seed.
+	Count int
+	// Vendor is the vendor of the BMCs and their systems.
+	Vendor string
+	// RandomSeed makes the generated fixtures reproducible.
+	RandomSeed uint64
+}
+
+// Name returns the name of the objects of the i-th BMC of the seed.
+func (s Seed) Name(i int) string {
+	return fmt.Sprintf("%s-%d", s.Prefix, i)
+}
+
+// firstAddress returns the address of the first BMC of the seed. Like the addresses of a fleet, the addresses are
+// never connected to. Each prefix gets its own network within 10.128.0.0/10, so that seeds of different prefixes
+// do not share simulated BMCs.
+func (s Seed) firstAddress() netip.Addr {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(s.Prefix))
+	return netip.AddrFrom4([4]byte{10, byte(128 + h.Sum32()%64), 0, 1})
+}
+
+// Fixtures returns the fixtures of the simulated BMCs of the seed by their address.
+func (s Seed) Fixtures() (map[string]bmc.SimulatorFixture, error) {
+	profile, ok := vendorProfiles[s.Vendor]
+	if !ok {
+		return nil, fmt.Errorf("unknown vendor %q, expected one of %s", s.Vendor, strings.Join(Vendors(), ", "))
+	}
+	r := rand.New(rand.NewPCG(s.RandomSeed, uint64(s.Count)))
+	fixtures := make(map[string]bmc.SimulatorFixture, s.Count)
+	address := s.firstAddress()
+	for range s.Count {
+		processorModel := pick(r, profile.processorModels)
+		cores := 16
+		if strings.Contains(processorModel, "32-Core") || strings.Contains(processorModel, "6338") {
+			cores = 32
+		}
+		fixtures[net.JoinHostPort(address.String(), fmt.Sprintf("%d", seedPort))] = bmc.SimulatorFixture{
+			Manufacturer:       profile.manufacturer,
+			Model:              pick(r, profile.models),
+			SystemUUID:         randomUUID(r),
+			SerialNumber:       profile.serialPrefix + randomString(r, profile.serialLength-len(profile.serialPrefix)),
+			MACAddress:         randomMACAddress(r, pick(r, profile.ouis)),
+			BIOSVersion:        pick(r, profile.biosVersions),
+			ProcessorModel:     processorModel,
+			Processors:         2,
+			CoresPerProcessor:  cores,
+			MemoryGiB:          pick(r, []int64{256, 512, 1024}),
+			BMCManufacturer:    profile.manufacturer,
+			BMCModel:           profile.bmcModel,
+			BMCFirmwareVersion: pick(r, profile.bmcFirmwareVersions),
+			BMCMACAddress:      randomMACAddress(r, pick(r, profile.ouis)),
+		}
+		address = address.Next()
+	}
+	return fixtures, nil
+}
+
+// Apply creates or updates the Endpoint, BMC and BMCSecret objects of the seed and the fixtures of their simulated
+// BMCs. The fixtures have to be applied before the operator first connects to the simulated BMCs.
+func (s Seed) Apply(ctx context.Context, c client.Client) error {
+	fixtures, err := s.Fixtures()
+	if err != nil {
+		return err
+	}
+
+	configMap := &v1.ConfigMap{}
+	configMap.Namespace = bmcutils.DefaultKubeNamespace
+	configMap.Name = bmcutils.SimulatorFixturesConfigMapName
+	if _, err := controllerutil.CreateOrPatch(ctx, c, configMap, func() error {
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		for address, fixture := range fixtures {
+			data, err := json.Marshal(fixture)
+			if err != nil {
+				return fmt.Errorf("failed to marshal simulator fixture of %s: %w", address, err)
+			}
+			configMap.Data[bmcutils.SimulatorFixtureKey(address)] = string(data)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to apply simulator fixtures: %w", err)
+	}
+
+	address := s.firstAddress()
+	for i := range s.Count {
+		fixture := fixtures[net.JoinHostPort(address.String(), fmt.Sprintf("%d", seedPort))]
+
+		endpoint := &metalv1alpha1.Endpoint{}
+		endpoint.Name = s.Name(i)
+		if _, err := controllerutil.CreateOrPatch(ctx, c, endpoint, func() error {
+			endpoint.Spec.MACAddress = fixture.BMCMACAddress
+			endpoint.Spec.IP = metalv1alpha1.MustParseIP(address.String())
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to apply Endpoint %s: %w", endpoint.Name, err)
+		}
+
+		bmcSecret := &metalv1alpha1.BMCSecret{}
+		bmcSecret.Name = s.Name(i)
+		if _, err := controllerutil.CreateOrPatch(ctx, c, bmcSecret, func() error {
+			bmcSecret.Data = map[string][]byte{
+				metalv1alpha1.BMCSecretUsernameKeyName: []byte("admin"),
+				metalv1alpha1.BMCSecretPasswordKeyName: []byte("admin"),
+			}
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to apply BMCSecret %s: %w", bmcSecret.Name, err)
+		}
+
+		bmcObj := &metalv1alpha1.BMC{}
+		bmcObj.Name = s.Name(i)
+		if _, err := controllerutil.CreateOrPatch(ctx, c, bmcObj, func() error {
+			bmcObj.Spec.EndpointRef = &v1.LocalObjectReference{Name: endpoint.Name}
+			bmcObj.Spec.Protocol = metalv1alpha1.Protocol{Name: metalv1alpha1.ProtocolRedfishFake, Port: seedPort}
+			bmcObj.Spec.BMCSecretRef = v1.LocalObjectReference{Name: bmcSecret.Name}
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to apply BMC %s: %w", bmcObj.Name, err)
+		}
+		address = address.Next()
+	}
+	return nil
+}
+
+// Delete deletes the Endpoint, BMC and BMCSecret objects of the seed and removes the fixtures of their simulated
+// BMCs.
+func (s Seed) Delete(ctx context.Context, c client.Client) error {
+	for i := range s.Count {
+		for _, obj := range []client.Object{&metalv1alpha1.BMC{}, &metalv1alpha1.BMCSecret{}, &metalv1alpha1.Endpoint{}} {
+			obj.SetName(s.Name(i))
+			if err := client.IgnoreNotFound(c.Delete(ctx, obj)); err != nil {
+				return fmt.Errorf("failed to delete %T %s: %w", obj, obj.GetName(), err)
+			}
+		}
+	}
+
+	fixtures, err := s.Fixtures()
+	if err != nil {
+		return err
+	}
+	configMap := &v1.ConfigMap{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: bmcutils.DefaultKubeNamespace, Name: bmcutils.SimulatorFixturesConfigMapName}, configMap); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	base := configMap.DeepCopy()
+	for address := range fixtures {
+		delete(configMap.Data, bmcutils.SimulatorFixtureKey(address))
+	}
+	if err := c.Patch(ctx, configMap, client.MergeFrom(base)); err != nil {
+		return fmt.Errorf("failed to delete simulator fixtures: %w", err)
+	}
+	return nil
+}
+
+func pick[T any](r *rand.Rand, values []T) T {
+	return values[r.IntN(len(values))]
+}
+
+func randomString(r *rand.Rand, length int) string {
+	const alphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ0123456789"
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = alphabet[r.IntN(len(alphabet))]
+	}
+	return string(b)
+}
+
+func randomMACAddress(r *rand.Rand, oui string) string {
+	return fmt.Sprintf("%s:%02X:%02X:%02X", oui, r.IntN(256), r.IntN(256), r.IntN(256))
+}
+
+func randomUUID(r *rand.Rand) string {
+	b := make([]byte, 16)
+	for i := range b {
+		b[i] = byte(r.IntN(256))
+	}
+	// version 4, variant 10
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dhcp
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// LeaseEventType is the type of DHCP lease event.
+type LeaseEventType string
+
+const (
+	// LeaseEventCommit is sent when a lease has been assigned or renewed.
+	LeaseEventCommit LeaseEventType = "commit"
+	// LeaseEventRelease is sent when a lease has been released or has expired.
+	LeaseEventRelease LeaseEventType = "release"
+)
+
+// LeaseEvent is a DHCP lease event of the out-of-band network, as sent by the hook scripts of the DHCP server.
+type LeaseEvent struct {
+	Type       LeaseEventType `json:"type"`
+	MACAddress string         `json:"macAddress"`
+	IP         string         `json:"ip"`
+}
+
+// Server receives DHCP lease events and creates or updates the Endpoints of the leased MAC addresses. It
+// replaces scripts which create Endpoints out of the cluster.
+type Server struct {
+	Client client.Client
+	// Addr is the address the server listens on.
+	Addr string
+	// Token is the bearer token the hook scripts have to send. The server refuses to start without a token.
+	Token string
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Every replica accepts lease events, as the
+// Endpoint updates are idempotent.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable.
+func (s *Server) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("dhcp-lease-server")
+	if s.Token == "" {
+		return errors.New("DHCP lease server requires a bearer token")
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/leases", s.leasesHandler)
+	server := &http.Server{
+		Addr:        s.Addr,
+		Handler:     mux,
+		BaseContext: func(net.Listener) context.Context { return logr.NewContext(ctx, log) },
+	}
+
+	log.Info("Starting DHCP lease server", "Address", s.Addr)
+	errChan := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			errChan <- fmt.Errorf("HTTP DHCP lease server ListenAndServe: %w", err)
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		if err := server.Shutdown(context.Background()); err != nil {
+			return fmt.Errorf("HTTP DHCP lease server Shutdown: %w", err)
+		}
+		return nil
+	case err := <-errChan:
+		return err
+	}
+}
+
+// leasesHandler handles the /leases endpoint.
+func (s *Server) leasesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST method is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || s.Token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.Token)) != 1 {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var event LeaseEvent
+	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := s.handleLeaseEvent(r.Context(), event); err != nil {
+		var invalid *invalidEventError
+		if errors.As(err, &invalid) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		logr.FromContextOrDiscard(r.Context()).Error(err, "Failed to handle lease event", "MACAddress", event.MACAddress)
+		http.Error(w, "Failed to handle lease event", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type invalidEventError struct {
+	msg string
+}
+
+func (e *invalidEventError) Error() string {
+	return e.msg
+}
+
+// handleLeaseEvent creates or updates the Endpoint of a committed lease. Released leases keep their Endpoint, as
+// the BMC behind it stays known to the cluster.
+func (s *Server) handleLeaseEvent(ctx context.Context, event LeaseEvent) error {
+	mac, err := net.ParseMAC(event.MACAddress)
+	if err != nil {
+		return &invalidEventError{msg: fmt.Sprintf("invalid MAC address %q", event.MACAddress)}
+	}
+	switch event.Type {
+	case LeaseEventCommit:
+	case LeaseEventRelease:
+		return nil
+	default:
+		return &invalidEventError{msg: fmt.Sprintf("unknown lease event type %q", event.Type)}
+	}
+	ip, err := metalv1alpha1.ParseIP(event.IP)
+	if err != nil {
+		return &invalidEventError{msg: fmt.Sprintf("invalid IP address %q", event.IP)}
+	}
+
+	log := logr.FromContextOrDiscard(ctx)
+	endpoints := &metalv1alpha1.EndpointList{}
+	if err := s.Client.List(ctx, endpoints); err != nil {
+		return fmt.Errorf("failed to list Endpoints: %w", err)
+	}
+	for i := range endpoints.Items {
+		endpoint := &endpoints.Items[i]
+		if existing, err := net.ParseMAC(endpoint.Spec.MACAddress); err != nil || existing.String() != mac.String() {
+			continue
+		}
+		if endpoint.Spec.IP == ip {
+			return nil
+		}
+		endpointBase := endpoint.DeepCopy()
+		endpoint.Spec.IP = ip
+		if err := s.Client.Patch(ctx, endpoint, client.MergeFrom(endpointBase)); err != nil {
+			return fmt.Errorf("failed to patch Endpoint: %w", err)
+		}
+		log.Info("Updated Endpoint", "Endpoint", endpoint.Name, "IP", ip)
+		return nil
+	}
+
+	endpoint := &metalv1alpha1.Endpoint{}
+	endpoint.Name = EndpointNameForMAC(mac)
+	endpoint.Spec.MACAddress = mac.String()
+	endpoint.Spec.IP = ip
+	if err := s.Client.Create(ctx, endpoint); err != nil {
+		return fmt.Errorf("failed to create Endpoint: %w", err)
+	}
+	log.Info("Created Endpoint", "Endpoint", endpoint.Name, "IP", ip)
+	return nil
+}
+
+// EndpointNameForMAC returns the name of the Endpoint created for a MAC address.
+func EndpointNameForMAC(mac net.HardwareAddr) string {
+	return "endpoint-" + strings.ReplaceAll(mac.String(), ":", "")
+}
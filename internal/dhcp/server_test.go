@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dhcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("DHCP Lease Server", func() {
+	var (
+		k8sClient client.Client
+		server    *Server
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(metalv1alpha1.AddToScheme(scheme)).To(Succeed())
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(&metalv1alpha1.Endpoint{
+			ObjectMeta: metav1.ObjectMeta{Name: "existing"},
+			Spec: metalv1alpha1.EndpointSpec{
+				MACAddress: "00:1A:2B:3C:4D:5E",
+				IP:         metalv1alpha1.MustParseIP("192.168.100.10"),
+			},
+		}).Build()
+		server = &Server{Client: k8sClient, Token: "secret"}
+	})
+
+	post := func(token string, event LeaseEvent) int {
+		body, err := json.Marshal(event)
+		Expect(err).NotTo(HaveOccurred())
+		req := httptest.NewRequest(http.MethodPost, "/leases", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		server.leasesHandler(rec, req)
+		return rec.Code
+	}
+
+	It("Should create an Endpoint for a new lease", func(ctx SpecContext) {
+		Expect(post("secret", LeaseEvent{Type: LeaseEventCommit, MACAddress: "00-1a-2b-3c-4d-5f", IP: "192.168.100.11"})).
+			To(Equal(http.StatusNoContent))
+
+		endpoint := &metalv1alpha1.Endpoint{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: "endpoint-001a2b3c4d5f"}, endpoint)).To(Succeed())
+		Expect(endpoint.Spec.MACAddress).To(Equal("00:1a:2b:3c:4d:5f"))
+		Expect(endpoint.Spec.IP).To(Equal(metalv1alpha1.MustParseIP("192.168.100.11")))
+	})
+
+	It("Should update the IP of an existing Endpoint", func(ctx SpecContext) {
+		Expect(post("secret", LeaseEvent{Type: LeaseEventCommit, MACAddress: "00:1a:2b:3c:4d:5e", IP: "192.168.100.20"})).
+			To(Equal(http.StatusNoContent))
+
+		endpoint := &metalv1alpha1.Endpoint{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: "existing"}, endpoint)).To(Succeed())
+		Expect(endpoint.Spec.IP).To(Equal(metalv1alpha1.MustParseIP("192.168.100.20")))
+
+		endpoints := &metalv1alpha1.EndpointList{}
+		Expect(k8sClient.List(ctx, endpoints)).To(Succeed())
+		Expect(endpoints.Items).To(HaveLen(1))
+	})
+
+	It("Should keep the Endpoint of a released lease", func(ctx SpecContext) {
+		Expect(post("secret", LeaseEvent{Type: LeaseEventRelease, MACAddress: "00:1a:2b:3c:4d:5e", IP: "192.168.100.10"})).
+			To(Equal(http.StatusNoContent))
+
+		endpoints := &metalv1alpha1.EndpointList{}
+		Expect(k8sClient.List(ctx, endpoints)).To(Succeed())
+		Expect(endpoints.Items).To(HaveLen(1))
+	})
+
+	It("Should reject invalid and unauthorized events", func() {
+		Expect(post("wrong", LeaseEvent{Type: LeaseEventCommit, MACAddress: "00:1a:2b:3c:4d:5f", IP: "192.168.100.11"})).
+			To(Equal(http.StatusUnauthorized))
+		Expect(post("secret", LeaseEvent{Type: LeaseEventCommit, MACAddress: "foo", IP: "192.168.100.11"})).
+			To(Equal(http.StatusBadRequest))
+		Expect(post("secret", LeaseEvent{Type: LeaseEventCommit, MACAddress: "00:1a:2b:3c:4d:5f", IP: "bar"})).
+			To(Equal(http.StatusBadRequest))
+		Expect(post("secret", LeaseEvent{Type: "foo", MACAddress: "00:1a:2b:3c:4d:5f", IP: "192.168.100.11"})).
+			To(Equal(http.StatusBadRequest))
+	})
+
+	It("Should require a token", func(ctx SpecContext) {
+		server.Token = ""
+		Expect(server.Start(ctx)).To(MatchError(ContainSubstring("requires a bearer token")))
+		Expect(post("", LeaseEvent{Type: LeaseEventCommit, MACAddress: "00:1a:2b:3c:4d:5f", IP: "192.168.100.11"})).
+			To(Equal(http.StatusUnauthorized))
+	})
+})
@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package diagnostics serves runtime diagnostics of the manager: pprof profiles, the work queues and in-flight
+// reconciles of the controllers and the BMC clients.
+package diagnostics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+	"github.com/ironcore-dev/metal-operator/internal/kubeauth"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// PprofPath is the path of the pprof index.
+	PprofPath = "/debug/pprof/"
+	// ControllersPath is the path of the statistics of the controllers.
+	ControllersPath = "/debug/controllers"
+	// BMCClientsPath is the path of the statistics of the BMC clients.
+	BMCClientsPath = "/debug/bmc-clients"
+	// RuntimePath is the path of the statistics of the Go runtime.
+	RuntimePath = "/debug/runtime"
+)
+
+// ControllerStats are the statistics of a controller.
+type ControllerStats struct {
+	// QueueDepth is the number of objects waiting in the work queue.
+	QueueDepth int64 `json:"queueDepth"`
+	// ActiveWorkers is the number of reconciles in flight.
+	ActiveWorkers int64 `json:"activeWorkers"`
+	// MaxConcurrentReconciles is the maximum number of reconciles in flight.
+	MaxConcurrentReconciles int64 `json:"maxConcurrentReconciles"`
+	// LongestRunningSeconds is the duration of the longest reconcile in flight.
+	LongestRunningSeconds float64 `json:"longestRunningSeconds"`
+	// Reconciles is the number of reconciles since the start of the process by result.
+	Reconciles map[string]int64 `json:"reconciles,omitempty"`
+}
+
+// RuntimeStats are the statistics of the Go runtime.
+type RuntimeStats struct {
+	Goroutines     int    `json:"goroutines"`
+	GOMAXPROCS     int    `json:"gomaxprocs"`
+	HeapAllocBytes uint64 `json:"heapAllocBytes"`
+	HeapObjects    uint64 `json:"heapObjects"`
+	NumGC          uint32 `json:"numGC"`
+}
+
+// Server serves the diagnostics. Requests have to carry the bearer token of a Kubernetes user, who is allowed to
+// get the requested non-resource URL, e.g. /debug/*.
+type Server struct {
+	// Client authenticates and authorizes the requests.
+	Client client.Client
+	// Addr is the address the diagnostics are served on.
+	Addr string
+	// CertFile and KeyFile are the serving certificate. If empty, the diagnostics are served via plain HTTP.
+	CertFile string
+	KeyFile  string
+	// Gatherer provides the metrics of the controllers. It defaults to the controller-runtime metrics registry.
+	Gatherer prometheus.Gatherer
+
+	authorizer kubeauth.Authorizer
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Every replica serves its own diagnostics.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable.
+func (s *Server) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("diagnostics")
+	server := &http.Server{
+		Addr:        s.Addr,
+		Handler:     s,
+		BaseContext: func(net.Listener) context.Context { return logr.NewContext(ctx, log) },
+	}
+
+	log.Info("Starting diagnostics server", "Address", s.Addr)
+	if err := kubeauth.ListenAndServe(ctx, server, s.CertFile, s.KeyFile); err != nil {
+		return fmt.Errorf("HTTP diagnostics server %w", err)
+	}
+	return nil
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	log := logr.FromContextOrDiscard(r.Context())
+	user, allowed, err := s.authorizer.Authorize(r.Context(), s.Client, token, nil, &authorizationv1.NonResourceAttributes{
+		Path: r.URL.Path,
+		Verb: strings.ToLower(r.Method),
+	})
+	if err != nil {
+		log.Error(err, "Failed to authorize diagnostics request")
+		http.Error(w, "Failed to authorize request", http.StatusInternalServerError)
+		return
+	}
+	if user == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if !allowed {
+		log.Info("Denied diagnostics request", "User", user, "Method", r.Method, "Path", r.URL.Path)
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	s.Handler().ServeHTTP(w, r)
+}
+
+// Handler returns the handler of the diagnostics without authentication and authorization.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(PprofPath, pprof.Index)
+	mux.HandleFunc(PprofPath+"cmdline", pprof.Cmdline)
+	mux.HandleFunc(PprofPath+"profile", pprof.Profile)
+	mux.HandleFunc(PprofPath+"symbol", pprof.Symbol)
+	mux.HandleFunc(PprofPath+"trace", pprof.Trace)
+	mux.HandleFunc(ControllersPath, func(w http.ResponseWriter, r *http.Request) {
+		stats, err := s.controllerStats()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, r, stats)
+	})
+	mux.HandleFunc(BMCClientsPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, r, bmcutils.GetBMCClientStats())
+	})
+	mux.HandleFunc(RuntimePath, func(w http.ResponseWriter, r *http.Request) {
+		var memStats runtime.MemStats
+		runtime.ReadMemStats(&memStats)
+		writeJSON(w, r, RuntimeStats{
+			Goroutines:     runtime.NumGoroutine(),
+			GOMAXPROCS:     runtime.GOMAXPROCS(0),
+			HeapAllocBytes: memStats.HeapAlloc,
+			HeapObjects:    memStats.HeapObjects,
+			NumGC:          memStats.NumGC,
+		})
+	})
+	return mux
+}
+
+// controllerStats returns the statistics of the controllers by name from the controller-runtime metrics.
+func (s *Server) controllerStats() (map[string]*ControllerStats, error) {
+	gatherer := s.Gatherer
+	if gatherer == nil {
+		gatherer = metrics.Registry
+	}
+	families, err := gatherer.Gather()
+	if err != nil {
+		return nil, fmt.Errorf("failed to gather metrics: %w", err)
+	}
+
+	stats := map[string]*ControllerStats{}
+	forController := func(m *dto.Metric, label string) *ControllerStats {
+		name := labelValue(m, label)
+		if _, ok := stats[name]; !ok {
+			stats[name] = &ControllerStats{}
+		}
+		return stats[name]
+	}
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			switch family.GetName() {
+			case "workqueue_depth":
+				forController(m, "name").QueueDepth = int64(m.GetGauge().GetValue())
+			case "workqueue_longest_running_processor_seconds":
+				forController(m, "name").LongestRunningSeconds = m.GetGauge().GetValue()
+			case "controller_runtime_active_workers":
+				forController(m, "controller").ActiveWorkers = int64(m.GetGauge().GetValue())
+			case "controller_runtime_max_concurrent_reconciles":
+				forController(m, "controller").MaxConcurrentReconciles = int64(m.GetGauge().GetValue())
+			case "controller_runtime_reconcile_total":
+				controllerStats := forController(m, "controller")
+				if controllerStats.Reconciles == nil {
+					controllerStats.Reconciles = map[string]int64{}
+				}
+				controllerStats.Reconciles[labelValue(m, "result")] = int64(m.GetCounter().GetValue())
+			}
+		}
+	}
+	return stats, nil
+}
+
+func labelValue(m *dto.Metric, name string) string {
+	for _, label := range m.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
+
+func writeJSON(w http.ResponseWriter, r *http.Request, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logr.FromContextOrDiscard(r.Context()).Error(err, "Failed to write diagnostics", "Path", r.URL.Path)
+	}
+}
@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package diagnostics
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/bmcutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		registry *prometheus.Registry
+		server   *httptest.Server
+	)
+
+	BeforeEach(func() {
+		registry = prometheus.NewRegistry()
+		server = httptest.NewServer((&Server{Gatherer: registry}).Handler())
+		DeferCleanup(server.Close)
+	})
+
+	get := func(path string, v any) {
+		resp, err := http.Get(server.URL + path)
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		if v != nil {
+			Expect(json.NewDecoder(resp.Body).Decode(v)).To(Succeed())
+		}
+	}
+
+	It("Should report the work queues and in-flight reconciles of the controllers", func() {
+		depth := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "workqueue_depth"}, []string{"name"})
+		activeWorkers := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "controller_runtime_active_workers"}, []string{"controller"})
+		reconciles := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "controller_runtime_reconcile_total"}, []string{"controller", "result"})
+		registry.MustRegister(depth, activeWorkers, reconciles)
+		depth.WithLabelValues("server").Set(42)
+		activeWorkers.WithLabelValues("server").Set(3)
+		reconciles.WithLabelValues("server", "success").Add(7)
+		reconciles.WithLabelValues("bmc", "error").Inc()
+
+		stats := map[string]ControllerStats{}
+		get(ControllersPath, &stats)
+		Expect(stats).To(HaveKeyWithValue("server", ControllerStats{
+			QueueDepth:    42,
+			ActiveWorkers: 3,
+			Reconciles:    map[string]int64{"success": 7},
+		}))
+		Expect(stats).To(HaveKeyWithValue("bmc", ControllerStats{Reconciles: map[string]int64{"error": 1}}))
+	})
+
+	It("Should report the open BMC clients", func(ctx SpecContext) {
+		scheme := runtime.NewScheme()
+		Expect(metalv1alpha1.AddToScheme(scheme)).To(Succeed())
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		bmcSecret := &metalv1alpha1.BMCSecret{Data: map[string][]byte{
+			metalv1alpha1.BMCSecretUsernameKeyName: []byte("admin"),
+			metalv1alpha1.BMCSecretPasswordKeyName: []byte("admin"),
+		}}
+		bmc.Simulators.Register("10.10.0.1:8000", bmc.NewSimulator())
+
+		bmcClient, err := bmcutils.CreateBMCClient(ctx, k8sClient, true,
+			metalv1alpha1.Protocol{Name: metalv1alpha1.ProtocolRedfishFake, Port: 8000}, "10.10.0.1", bmcSecret, bmc.BMCOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		stats := map[string]bmcutils.BMCClientStats{}
+		get(BMCClientsPath, &stats)
+		Expect(stats).To(HaveKeyWithValue(metalv1alpha1.ProtocolRedfishFake, bmcutils.BMCClientStats{Open: 1, Created: 1}))
+
+		bmcClient.Logout()
+		bmcClient.Logout()
+		get(BMCClientsPath, &stats)
+		Expect(stats).To(HaveKeyWithValue(metalv1alpha1.ProtocolRedfishFake, bmcutils.BMCClientStats{Open: 0, Created: 1}))
+	})
+
+	It("Should serve the runtime statistics and pprof profiles", func() {
+		var stats RuntimeStats
+		get(RuntimePath, &stats)
+		Expect(stats.Goroutines).To(BeNumerically(">", 0))
+		get(PprofPath, nil)
+		get(PprofPath+"goroutine?debug=1", nil)
+	})
+
+	It("Should only serve authorized users", func() {
+		k8sClient := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				switch review := obj.(type) {
+				case *authenticationv1.TokenReview:
+					if review.Spec.Token == "operator-token" || review.Spec.Token == "viewer-token" {
+						review.Status.Authenticated = true
+						review.Status.User.Username = review.Spec.Token[:len(review.Spec.Token)-len("-token")]
+					}
+					return nil
+				case *authorizationv1.SubjectAccessReview:
+					review.Status.Allowed = review.Spec.User == "operator" &&
+						review.Spec.NonResourceAttributes.Path == RuntimePath &&
+						review.Spec.NonResourceAttributes.Verb == "get"
+					return nil
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).Build()
+		authorizedServer := httptest.NewServer(&Server{Client: k8sClient, Gatherer: registry})
+		DeferCleanup(authorizedServer.Close)
+
+		statusFor := func(token string) int {
+			req, err := http.NewRequest(http.MethodGet, authorizedServer.URL+RuntimePath, nil)
+			Expect(err).NotTo(HaveOccurred())
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Body.Close()).To(Succeed())
+			return resp.StatusCode
+		}
+		Expect(statusFor("")).To(Equal(http.StatusUnauthorized))
+		Expect(statusFor("invalid-token")).To(Equal(http.StatusUnauthorized))
+		Expect(statusFor("viewer-token")).To(Equal(http.StatusForbidden))
+		Expect(statusFor("operator-token")).To(Equal(http.StatusOK))
+	})
+})
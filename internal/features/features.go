@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package features gates experimental subsystems of the manager, so that they ship disabled by default and are
+// enabled per environment with --feature-gates or the featureGates of the OperatorConfiguration.
+package features
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Feature is the name of a feature gate.
+type Feature string
+
+// Stage is the maturity of a feature.
+type Stage string
+
+const (
+	// Alpha features are disabled by default and may change or be removed without notice.
+	Alpha Stage = "Alpha"
+	// Beta features are enabled by default and may still change.
+	Beta Stage = "Beta"
+	// GA features are always enabled. Their gate is kept for a while so that configurations do not break.
+	GA Stage = "GA"
+)
+
+// Spec describes a feature.
+type Spec struct {
+	// Default is whether the feature is enabled if its gate is not set.
+	Default bool
+	// Stage is the maturity of the feature.
+	Stage Stage
+}
+
+const (
+	// DPUModeSwitching switches the DPUs of a Server into the modes of its spec.
+	DPUModeSwitching Feature = "DPUModeSwitching"
+)
+
+// knownFeatures holds the spec of every feature which may be gated.
+var knownFeatures = map[Feature]Spec{
+	DPUModeSwitching: {Default: false, Stage: Alpha},
+}
+
+// IsKnown returns whether the feature may be gated.
+func IsKnown(feature Feature) bool {
+	_, ok := knownFeatures[feature]
+	return ok
+}
+
+// Gate holds the enabled features. It implements flag.Value, which takes a comma separated list of
+// <Feature>=<bool> pairs. A nil Gate enables the features which are enabled by default.
+type Gate struct {
+	mu      sync.RWMutex
+	enabled map[Feature]bool
+}
+
+// NewGate returns a Gate with all features set to their defaults.
+func NewGate() *Gate {
+	return &Gate{enabled: map[Feature]bool{}}
+}
+
+// Enabled returns whether the feature is enabled.
+func (g *Gate) Enabled(feature Feature) bool {
+	spec := knownFeatures[feature]
+	if g == nil || spec.Stage == GA {
+		return spec.Default || spec.Stage == GA
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if enabled, ok := g.enabled[feature]; ok {
+		return enabled
+	}
+	return spec.Default
+}
+
+// SetFromMap enables or disables the given features, keeping the other features as they are.
+func (g *Gate) SetFromMap(features map[string]bool) error {
+	for name := range features {
+		feature := Feature(name)
+		if !IsKnown(feature) {
+			return fmt.Errorf("unknown feature gate %q", name)
+		}
+		if spec := knownFeatures[feature]; spec.Stage == GA && !features[name] {
+			return fmt.Errorf("feature gate %q is GA and cannot be disabled", name)
+		}
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.enabled == nil {
+		g.enabled = map[Feature]bool{}
+	}
+	for name, enabled := range features {
+		g.enabled[Feature(name)] = enabled
+	}
+	return nil
+}
+
+// Set implements flag.Value.
+func (g *Gate) Set(value string) error {
+	features := map[string]bool{}
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, rawEnabled, ok := strings.Cut(pair, "=")
+		if !ok {
+			return fmt.Errorf("missing bool value for feature gate %q", name)
+		}
+		enabled, err := strconv.ParseBool(strings.TrimSpace(rawEnabled))
+		if err != nil {
+			return fmt.Errorf("invalid value %q of feature gate %q: %w", rawEnabled, name, err)
+		}
+		features[strings.TrimSpace(name)] = enabled
+	}
+	return g.SetFromMap(features)
+}
+
+// String implements flag.Value.
+func (g *Gate) String() string {
+	if g == nil {
+		return ""
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	pairs := make([]string, 0, len(g.enabled))
+	for feature, enabled := range g.enabled {
+		pairs = append(pairs, fmt.Sprintf("%s=%t", feature, enabled))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// Usage returns the description of the known features for the help of the flag.
+func Usage() string {
+	features := make([]string, 0, len(knownFeatures))
+	for feature, spec := range knownFeatures {
+		features = append(features, fmt.Sprintf("%s=true|false (%s - default=%t)", feature, spec.Stage, spec.Default))
+	}
+	sort.Strings(features)
+	return "A set of key=value pairs that describe feature gates for experimental features. Options are:\n" +
+		strings.Join(features, "\n")
+}
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package features
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Gate", func() {
+	BeforeEach(func() {
+		knownFeatures["TestAlpha"] = Spec{Default: false, Stage: Alpha}
+		knownFeatures["TestBeta"] = Spec{Default: true, Stage: Beta}
+		knownFeatures["TestGA"] = Spec{Default: true, Stage: GA}
+		DeferCleanup(func() {
+			delete(knownFeatures, "TestAlpha")
+			delete(knownFeatures, "TestBeta")
+			delete(knownFeatures, "TestGA")
+		})
+	})
+
+	It("should enable the features which are enabled by default", func() {
+		for _, gate := range []*Gate{nil, NewGate()} {
+			Expect(gate.Enabled("TestAlpha")).To(BeFalse())
+			Expect(gate.Enabled("TestBeta")).To(BeTrue())
+			Expect(gate.Enabled("TestGA")).To(BeTrue())
+		}
+	})
+
+	It("should merge the features set with the flag", func() {
+		gate := NewGate()
+		Expect(gate.Set("TestAlpha=true")).To(Succeed())
+		Expect(gate.Set(" TestBeta = false ,")).To(Succeed())
+		Expect(gate.Enabled("TestAlpha")).To(BeTrue())
+		Expect(gate.Enabled("TestBeta")).To(BeFalse())
+		Expect(gate.String()).To(Equal("TestAlpha=true,TestBeta=false"))
+	})
+
+	It("should reject invalid feature gates", func() {
+		gate := NewGate()
+		Expect(gate.Set("Unknown=true")).To(MatchError(ContainSubstring("unknown feature gate")))
+		Expect(gate.Set("TestAlpha")).To(MatchError(ContainSubstring("missing bool value")))
+		Expect(gate.Set("TestAlpha=maybe")).To(MatchError(ContainSubstring("invalid value")))
+		Expect(gate.Set("TestGA=false")).To(MatchError(ContainSubstring("cannot be disabled")))
+		Expect(gate.String()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package firmware
+
+import (
+	"errors"
+	"strings"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+)
+
+// ComponentTypeForInventory derives the component type of a firmware inventory entry from the
+// resources it is related to. If the entry has no related items, its name is used as a fallback.
+// An empty type is returned if the component type could not be determined.
+func ComponentTypeForInventory(inventory bmc.FirmwareInventory) metalv1alpha1.ComponentType {
+	name := strings.ToLower(inventory.Name)
+	// DPUs relate to network adapters as well, so they are told apart by their name first.
+	if strings.Contains(name, "dpu") || strings.Contains(name, "bluefield") {
+		return metalv1alpha1.ComponentTypeDPU
+	}
+	for _, item := range inventory.RelatedItems {
+		switch {
+		case strings.HasSuffix(item, "/Bios"):
+			return metalv1alpha1.ComponentTypeBIOS
+		case strings.Contains(item, "/Managers/"):
+			return metalv1alpha1.ComponentTypeBMC
+		case strings.Contains(item, "/NetworkAdapters/"):
+			return metalv1alpha1.ComponentTypeNIC
+		case strings.Contains(item, "/Drives/"):
+			return metalv1alpha1.ComponentTypeDrive
+		}
+	}
+
+	switch {
+	case strings.Contains(name, "bios"):
+		return metalv1alpha1.ComponentTypeBIOS
+	case strings.Contains(name, "bmc"), strings.Contains(name, "remote access controller"), strings.Contains(name, "ilo"):
+		return metalv1alpha1.ComponentTypeBMC
+	case strings.Contains(name, "network"), strings.Contains(name, "nic"), strings.Contains(name, "ethernet"):
+		return metalv1alpha1.ComponentTypeNIC
+	case strings.Contains(name, "disk"), strings.Contains(name, "drive"), strings.Contains(name, "ssd"):
+		return metalv1alpha1.ComponentTypeDrive
+	}
+	return ""
+}
+
+// SelectComponents returns the firmware inventory entries matching the given selector. Entries whose version cannot
+// be compared with the version constraint of the selector are skipped and returned separately.
+func SelectComponents(inventory []bmc.FirmwareInventory, selector metalv1alpha1.ComponentSelector) (result, skipped []bmc.FirmwareInventory, err error) {
+	for _, item := range inventory {
+		if !item.Updateable || ComponentTypeForInventory(item) != selector.Type {
+			continue
+		}
+		if selector.Model != "" &&
+			!strings.Contains(strings.ToLower(item.Name), strings.ToLower(selector.Model)) &&
+			!strings.Contains(strings.ToLower(item.SoftwareID), strings.ToLower(selector.Model)) {
+			continue
+		}
+		match, err := MatchesConstraint(item.Version, selector.VersionConstraint)
+		if errors.Is(err, ErrUnparsableVersion) {
+			skipped = append(skipped, item)
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if match {
+			result = append(result, item)
+		}
+	}
+	return result, skipped, nil
+}
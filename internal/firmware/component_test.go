@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package firmware_test
+
+import (
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/firmware"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SelectComponents", func() {
+	inventory := []bmc.FirmwareInventory{
+		{
+			Entity:       bmc.Entity{ID: "BIOS", Name: "System BIOS"},
+			Version:      "2.1.0",
+			Updateable:   true,
+			RelatedItems: []string{"/redfish/v1/Systems/1/Bios"},
+		},
+		{
+			Entity:       bmc.Entity{ID: "BMC", Name: "Integrated Remote Access Controller"},
+			Version:      "7.00.00",
+			Updateable:   true,
+			RelatedItems: []string{"/redfish/v1/Managers/1"},
+		},
+		{
+			Entity:     bmc.Entity{ID: "NIC.1", Name: "Broadcom NetXtreme Gigabit Ethernet"},
+			SoftwareID: "BCM5720",
+			Version:    "21.80.9",
+			Updateable: true,
+		},
+		{
+			Entity:       bmc.Entity{ID: "Disk.0", Name: "Disk 0"},
+			SoftwareID:   "MZ7LH480HAHQ",
+			Version:      "1.0.0",
+			Updateable:   false,
+			RelatedItems: []string{"/redfish/v1/Systems/1/Storage/1/Drives/0"},
+		},
+		{
+			Entity:       bmc.Entity{ID: "DPU.1", Name: "BlueField-3 DPU Firmware"},
+			Version:      "32.41.1000",
+			Updateable:   true,
+			RelatedItems: []string{"/redfish/v1/Chassis/1/NetworkAdapters/DPU.1"},
+		},
+		{
+			Entity:     bmc.Entity{ID: "NIC.2", Name: "Mellanox ConnectX-6 Ethernet Adapter"},
+			SoftwareID: "MT28908",
+			Version:    "U46",
+			Updateable: true,
+		},
+	}
+
+	It("should select the components by type", func() {
+		components, _, err := firmware.SelectComponents(inventory, metalv1alpha1.ComponentSelector{
+			Type: metalv1alpha1.ComponentTypeBMC,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(components).To(ConsistOf(HaveField("ID", "BMC")))
+	})
+
+	It("should select DPUs apart from network adapters", func() {
+		components, _, err := firmware.SelectComponents(inventory, metalv1alpha1.ComponentSelector{
+			Type: metalv1alpha1.ComponentTypeDPU,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(components).To(ConsistOf(HaveField("ID", "DPU.1")))
+	})
+
+	It("should select the components by model and version constraint", func() {
+		components, _, err := firmware.SelectComponents(inventory, metalv1alpha1.ComponentSelector{
+			Type:              metalv1alpha1.ComponentTypeNIC,
+			Model:             "bcm5720",
+			VersionConstraint: "< 22.0.0",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(components).To(ConsistOf(HaveField("ID", "NIC.1")))
+
+		components, _, err = firmware.SelectComponents(inventory, metalv1alpha1.ComponentSelector{
+			Type:              metalv1alpha1.ComponentTypeBIOS,
+			VersionConstraint: ">= 2.2.0",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(components).To(BeEmpty())
+	})
+
+	It("should skip components whose version cannot be compared", func() {
+		components, skipped, err := firmware.SelectComponents(inventory, metalv1alpha1.ComponentSelector{
+			Type:              metalv1alpha1.ComponentTypeNIC,
+			VersionConstraint: "< 22.0.0",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(components).To(ConsistOf(HaveField("ID", "NIC.1")))
+		Expect(skipped).To(ConsistOf(HaveField("ID", "NIC.2")))
+	})
+
+	It("should fail for an unparsable version constraint", func() {
+		_, _, err := firmware.SelectComponents(inventory, metalv1alpha1.ComponentSelector{
+			Type:              metalv1alpha1.ComponentTypeNIC,
+			VersionConstraint: "< latest",
+		})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should not select components which are not updateable", func() {
+		components, _, err := firmware.SelectComponents(inventory, metalv1alpha1.ComponentSelector{
+			Type: metalv1alpha1.ComponentTypeDrive,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(components).To(BeEmpty())
+	})
+})
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package firmware
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+var operators = []string{">=", "<=", "!=", ">", "<", "="}
+
+// ErrUnparsableVersion is returned if a version has to be compared but does not follow a numeric scheme.
+var ErrUnparsableVersion = errors.New("unparsable version")
+
+// MatchesConstraint reports whether the given version satisfies the constraint. A constraint consists of
+// comma separated clauses like ">= 1.2.0, < 2.0.0" which all have to be satisfied. A clause without an
+// operator is treated as an exact match. An empty constraint matches every version.
+func MatchesConstraint(v, constraint string) (bool, error) {
+	for _, clause := range strings.Split(constraint, ",") {
+		clause = strings.TrimSpace(clause)
+		if clause == "" {
+			continue
+		}
+		op := "="
+		for _, o := range operators {
+			if strings.HasPrefix(clause, o) {
+				op = o
+				clause = strings.TrimSpace(strings.TrimPrefix(clause, o))
+				break
+			}
+		}
+		match, err := matchesClause(v, op, clause)
+		if err != nil {
+			return false, err
+		}
+		if !match {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func matchesClause(v, op, expected string) (bool, error) {
+	if op == "=" || op == "!=" {
+		equal := v == expected
+		if !equal {
+			// versions like 1.2 and 1.2.0 are considered equal
+			if actual, err := version.ParseGeneric(v); err == nil {
+				if cmp, err := actual.Compare(expected); err == nil {
+					equal = cmp == 0
+				}
+			}
+		}
+		return equal == (op == "="), nil
+	}
+
+	actual, err := version.ParseGeneric(v)
+	if err != nil {
+		return false, fmt.Errorf("%w %q: %w", ErrUnparsableVersion, v, err)
+	}
+	cmp, err := actual.Compare(expected)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse version %q: %w", expected, err)
+	}
+	switch op {
+	case ">=":
+		return cmp >= 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	default:
+		return cmp < 0, nil
+	}
+}
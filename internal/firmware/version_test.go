@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package firmware_test
+
+import (
+	"github.com/ironcore-dev/metal-operator/internal/firmware"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MatchesConstraint", func() {
+	DescribeTable("should evaluate the version constraint",
+		func(version, constraint string, expected bool) {
+			match, err := firmware.MatchesConstraint(version, constraint)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(match).To(Equal(expected))
+		},
+		Entry("empty constraint", "1.0.0", "", true),
+		Entry("exact match", "1.0.0", "1.0.0", true),
+		Entry("exact match of non semantic version", "U46", "U46", true),
+		Entry("exact mismatch", "1.0.0", "= 1.0.1", false),
+		Entry("equal generic versions", "1.2", "=1.2.0", true),
+		Entry("not equal", "1.0.0", "!= 1.0.0", false),
+		Entry("lower bound", "1.2.0", ">= 1.2.0", true),
+		Entry("upper bound", "2.0.0", "< 2.0.0", false),
+		Entry("range", "1.5.3", ">= 1.2.0, < 2.0.0", true),
+		Entry("outside of range", "1.1.9", ">1.2.0, <2.0.0", false),
+	)
+
+	It("should fail for unparsable versions in comparisons", func() {
+		_, err := firmware.MatchesConstraint("U46", ">= 1.0.0")
+		Expect(err).To(MatchError(firmware.ErrUnparsableVersion))
+	})
+})
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package kubeauth provides what the HTTP servers of the manager share which authenticate their users with
+// Kubernetes tokens: the authorization of the tokens and the serving loop.
+package kubeauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// authorizationTTL is the time the result of an authentication and authorization is cached.
+const authorizationTTL = time.Minute
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// Authorizer authenticates tokens with TokenReviews and authorizes their users with SubjectAccessReviews. Results
+// are cached for authorizationTTL. The zero value is ready to use.
+type Authorizer struct {
+	mu             sync.Mutex
+	authorizations map[string]authorization
+}
+
+type authorization struct {
+	user    string
+	allowed bool
+	expires time.Time
+}
+
+// Authorize authenticates the token and checks whether its user is allowed the resource or non-resource
+// attributes. It returns an empty user if the token is invalid.
+func (a *Authorizer) Authorize(ctx context.Context, c client.Client, token string, resource *authorizationv1.ResourceAttributes, nonResource *authorizationv1.NonResourceAttributes) (string, bool, error) {
+	key := fmt.Sprintf("%x/%v/%v", sha256.Sum256([]byte(token)), resource, nonResource)
+	a.mu.Lock()
+	cached, ok := a.authorizations[key]
+	a.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.user, cached.allowed, nil
+	}
+
+	tokenReview := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+	if err := c.Create(ctx, tokenReview); err != nil {
+		return "", false, fmt.Errorf("failed to create TokenReview: %w", err)
+	}
+	if !tokenReview.Status.Authenticated {
+		return "", false, nil
+	}
+	userInfo := tokenReview.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(userInfo.Extra))
+	for key, value := range userInfo.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	review := &authorizationv1.SubjectAccessReview{Spec: authorizationv1.SubjectAccessReviewSpec{
+		User:                  userInfo.Username,
+		UID:                   userInfo.UID,
+		Groups:                userInfo.Groups,
+		Extra:                 extra,
+		ResourceAttributes:    resource,
+		NonResourceAttributes: nonResource,
+	}}
+	if err := c.Create(ctx, review); err != nil {
+		return "", false, fmt.Errorf("failed to create SubjectAccessReview: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.authorizations == nil {
+		a.authorizations = map[string]authorization{}
+	}
+	now := time.Now()
+	for key, cached := range a.authorizations {
+		if now.After(cached.expires) {
+			delete(a.authorizations, key)
+		}
+	}
+	a.authorizations[key] = authorization{
+		user:    userInfo.Username,
+		allowed: review.Status.Allowed,
+		expires: now.Add(authorizationTTL),
+	}
+	return userInfo.Username, review.Status.Allowed, nil
+}
+
+// ListenAndServe serves the server until the context is done. It serves HTTPS if certFile is set, and plain HTTP
+// otherwise.
+func ListenAndServe(ctx context.Context, server *http.Server, certFile, keyFile string) error {
+	errChan := make(chan error, 1)
+	go func() {
+		var err error
+		if certFile != "" {
+			err = server.ListenAndServeTLS(certFile, keyFile)
+		} else {
+			err = server.ListenAndServe()
+		}
+		if !errors.Is(err, http.ErrServerClosed) {
+			errChan <- fmt.Errorf("ListenAndServe: %w", err)
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		if err := server.Shutdown(context.Background()); err != nil {
+			return fmt.Errorf("shutdown: %w", err)
+		}
+		return nil
+	case err := <-errChan:
+		return err
+	}
+}
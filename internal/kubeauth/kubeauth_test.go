@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeauth
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("Authorizer", func() {
+	var (
+		k8sClient client.Client
+		reviews   int
+	)
+
+	BeforeEach(func() {
+		reviews = 0
+		k8sClient = fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				switch review := obj.(type) {
+				case *authenticationv1.TokenReview:
+					if review.Spec.Token == "operator-token" {
+						review.Status.Authenticated = true
+						review.Status.User.Username = "operator"
+					}
+					return nil
+				case *authorizationv1.SubjectAccessReview:
+					reviews++
+					review.Status.Allowed = review.Spec.ResourceAttributes != nil &&
+						review.Spec.ResourceAttributes.Name == "allowed"
+					return nil
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).Build()
+	})
+
+	It("Should authorize the users of valid tokens and cache the results per attributes", func(ctx SpecContext) {
+		authorizer := &Authorizer{}
+		authorize := func(token, name string) (string, bool) {
+			user, allowed, err := authorizer.Authorize(ctx, k8sClient, token, &authorizationv1.ResourceAttributes{
+				Resource: "bmcs",
+				Verb:     "get",
+				Name:     name,
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			return user, allowed
+		}
+
+		user, _ := authorize("invalid-token", "allowed")
+		Expect(user).To(BeEmpty())
+
+		user, allowed := authorize("operator-token", "allowed")
+		Expect(user).To(Equal("operator"))
+		Expect(allowed).To(BeTrue())
+		_, allowed = authorize("operator-token", "denied")
+		Expect(allowed).To(BeFalse())
+		Expect(reviews).To(Equal(2))
+
+		By("Answering repeated requests from the cache")
+		_, allowed = authorize("operator-token", "allowed")
+		Expect(allowed).To(BeTrue())
+		Expect(reviews).To(Equal(2))
+	})
+})
@@ -0,0 +1,337 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"sort"
+	"time"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/internal/controller"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// AlertFirmwareUpdateFailed fires for ComponentFirmwares and DriveFirmwares in the Failed state.
+	AlertFirmwareUpdateFailed = "FirmwareUpdateFailed"
+	// AlertBMCUnreachable fires for BMCs which have been unreachable for longer than the configured duration.
+	AlertBMCUnreachable = "BMCUnreachable"
+	// AlertServerError fires for Servers in the Error state.
+	AlertServerError = "ServerError"
+
+	// StateConfigMapName is the name of the ConfigMap persisting the alerts the sinks have been notified of.
+	StateConfigMapName = "metal-operator-notification-state"
+	// StateKey is the ConfigMap data key holding the notified alerts of the sinks as JSON.
+	StateKey = "state.json"
+)
+
+// Triggers configures the events alerts are sent for.
+type Triggers struct {
+	// FirmwareUpdateFailed enables alerts for failed firmware updates.
+	FirmwareUpdateFailed bool `json:"firmwareUpdateFailed,omitempty"`
+	// BMCUnreachableAfter enables alerts for BMCs which have been unreachable for longer than the duration.
+	BMCUnreachableAfter *metav1.Duration `json:"bmcUnreachableAfter,omitempty"`
+	// ServerError enables alerts for Servers in the Error state.
+	ServerError bool `json:"serverError,omitempty"`
+}
+
+// Config is the configuration of the notifications.
+type Config struct {
+	// Sinks are the sinks every alert is sent to.
+	Sinks []Sink `json:"sinks"`
+	// Triggers are the events alerts are sent for.
+	Triggers Triggers `json:"triggers"`
+}
+
+// LoadConfig reads the notification configuration from a YAML file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notification config: %w", err)
+	}
+	config := &Config{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal notification config: %w", err)
+	}
+	for _, sink := range config.Sinks {
+		switch sink.Format {
+		case FormatWebhook, FormatSlack, FormatAlertmanager, "":
+		default:
+			return nil, fmt.Errorf("unknown format %q of notification sink %s", sink.Format, sink.Name)
+		}
+		if sink.URL == "" {
+			return nil, fmt.Errorf("notification sink %s has no URL", sink.Name)
+		}
+	}
+	return config, nil
+}
+
+// Notifier periodically evaluates the triggers against the cluster state and sends an alert to every sink when
+// a trigger starts or stops firing. Alertmanager sinks receive all firing alerts on every evaluation, as
+// Alertmanager resolves alerts which are not sent again.
+//
+// The alerts a sink has been notified of are only updated once a send to the sink succeeded, so that failed sends
+// are retried on the next evaluation. If a Namespace is set, they are persisted to a ConfigMap, so that alerts do
+// not fire again after a restart or leader failover.
+type Notifier struct {
+	Client client.Client
+	Config *Config
+	// Interval is the interval in which the triggers are evaluated.
+	Interval time.Duration
+	// Namespace is the namespace of the ConfigMap persisting the notified alerts. If empty, they are only kept in
+	// memory.
+	Namespace string
+
+	loaded bool
+	// notified holds the firing alerts each sink has been notified of by sink name and alert key.
+	notified map[string]map[string]Alert
+}
+
+// Start implements manager.Runnable.
+func (n *Notifier) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("notifier")
+	ticker := time.NewTicker(n.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := n.notify(ctx, time.Now()); err != nil {
+				log.Error(err, "Failed to send notifications")
+			}
+		}
+	}
+}
+
+func (n *Notifier) notify(ctx context.Context, now time.Time) error {
+	if !n.loaded {
+		notified, err := n.load(ctx)
+		if err != nil {
+			return err
+		}
+		n.notified = notified
+		n.loaded = true
+	}
+	firing, err := n.evaluate(ctx, now)
+	if err != nil {
+		return err
+	}
+	for key, alert := range firing {
+		for _, notified := range n.notified {
+			if previous, ok := notified[key]; ok {
+				alert.StartsAt = previous.StartsAt
+				firing[key] = alert
+				break
+			}
+		}
+	}
+
+	var (
+		errs    error
+		updated bool
+	)
+	for i := range n.Config.Sinks {
+		sink := &n.Config.Sinks[i]
+		notified := n.notified[sink.Name]
+		var changed []Alert
+		for key, alert := range firing {
+			if _, ok := notified[key]; !ok {
+				changed = append(changed, alert)
+			}
+		}
+		for key, alert := range notified {
+			if _, ok := firing[key]; !ok {
+				alert.EndsAt = now
+				changed = append(changed, alert)
+			}
+		}
+		alerts := sortAlerts(changed)
+		if sink.Format == FormatAlertmanager {
+			alerts = append(sortedAlerts(firing), filterResolved(changed)...)
+		}
+		if err := sink.Send(ctx, alerts); err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		if len(changed) > 0 {
+			n.notified[sink.Name] = maps.Clone(firing)
+			updated = true
+		}
+	}
+	if updated {
+		if err := n.persist(ctx); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+	return errs
+}
+
+// load returns the notified alerts persisted to the state ConfigMap.
+func (n *Notifier) load(ctx context.Context) (map[string]map[string]Alert, error) {
+	notified := map[string]map[string]Alert{}
+	if n.Namespace == "" {
+		return notified, nil
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := n.Client.Get(ctx, client.ObjectKey{Namespace: n.Namespace, Name: StateConfigMapName}, configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return notified, nil
+		}
+		return nil, fmt.Errorf("failed to get notification state ConfigMap: %w", err)
+	}
+	if data, ok := configMap.Data[StateKey]; ok {
+		if err := json.Unmarshal([]byte(data), &notified); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal notification state: %w", err)
+		}
+	}
+	return notified, nil
+}
+
+// persist writes the notified alerts to the state ConfigMap.
+func (n *Notifier) persist(ctx context.Context) error {
+	if n.Namespace == "" {
+		return nil
+	}
+	data, err := json.Marshal(n.notified)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification state: %w", err)
+	}
+
+	configMap := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: n.Namespace, Name: StateConfigMapName}
+	if err := n.Client.Get(ctx, key, configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get notification state ConfigMap: %w", err)
+		}
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Data:       map[string]string{StateKey: string(data)},
+		}
+		if err := n.Client.Create(ctx, configMap); err != nil {
+			return fmt.Errorf("failed to create notification state ConfigMap: %w", err)
+		}
+		return nil
+	}
+
+	configMapBase := configMap.DeepCopy()
+	configMap.Data = map[string]string{StateKey: string(data)}
+	if err := n.Client.Patch(ctx, configMap, client.MergeFrom(configMapBase)); err != nil {
+		return fmt.Errorf("failed to patch notification state ConfigMap: %w", err)
+	}
+	return nil
+}
+
+// evaluate returns the firing alerts by a key identifying the trigger and the affected object.
+func (n *Notifier) evaluate(ctx context.Context, now time.Time) (map[string]Alert, error) {
+	firing := map[string]Alert{}
+	add := func(name, kind, objName, summary string, labels map[string]string) {
+		alertLabels := map[string]string{"kind": kind, "name": objName}
+		for key, value := range labels {
+			alertLabels[key] = value
+		}
+		firing[name+"/"+kind+"/"+objName] = Alert{Name: name, Labels: alertLabels, Summary: summary, StartsAt: now}
+	}
+	triggers := n.Config.Triggers
+
+	if triggers.FirmwareUpdateFailed {
+		componentFirmwares := &metalv1alpha1.ComponentFirmwareList{}
+		if err := n.Client.List(ctx, componentFirmwares); err != nil {
+			return nil, fmt.Errorf("failed to list ComponentFirmwares: %w", err)
+		}
+		for _, firmware := range componentFirmwares.Items {
+			if firmware.Status.State == metalv1alpha1.ComponentFirmwareStateFailed {
+				add(AlertFirmwareUpdateFailed, "ComponentFirmware", firmware.Name,
+					fmt.Sprintf("Firmware update %s of Server %s failed", firmware.Name, firmware.Spec.ServerRef.Name),
+					map[string]string{"server": firmware.Spec.ServerRef.Name})
+			}
+		}
+		driveFirmwares := &metalv1alpha1.DriveFirmwareList{}
+		if err := n.Client.List(ctx, driveFirmwares); err != nil {
+			return nil, fmt.Errorf("failed to list DriveFirmwares: %w", err)
+		}
+		for _, firmware := range driveFirmwares.Items {
+			if firmware.Status.State == metalv1alpha1.DriveFirmwareStateFailed {
+				add(AlertFirmwareUpdateFailed, "DriveFirmware", firmware.Name,
+					fmt.Sprintf("Drive firmware update %s of Server %s failed", firmware.Name, firmware.Spec.ServerRef.Name),
+					map[string]string{"server": firmware.Spec.ServerRef.Name})
+			}
+		}
+	}
+
+	if triggers.BMCUnreachableAfter != nil {
+		bmcs := &metalv1alpha1.BMCList{}
+		if err := n.Client.List(ctx, bmcs); err != nil {
+			return nil, fmt.Errorf("failed to list BMCs: %w", err)
+		}
+		for _, bmcObj := range bmcs.Items {
+			condition := meta.FindStatusCondition(bmcObj.Status.Conditions, controller.BMCConditionReachable)
+			if condition == nil || condition.Status != metav1.ConditionFalse {
+				continue
+			}
+			if unreachable := now.Sub(condition.LastTransitionTime.Time); unreachable > triggers.BMCUnreachableAfter.Duration {
+				add(AlertBMCUnreachable, "BMC", bmcObj.Name,
+					fmt.Sprintf("BMC %s has been unreachable for %s: %s", bmcObj.Name, unreachable.Round(time.Second), condition.Message),
+					nil)
+			}
+		}
+	}
+
+	if triggers.ServerError {
+		servers := &metalv1alpha1.ServerList{}
+		if err := n.Client.List(ctx, servers); err != nil {
+			return nil, fmt.Errorf("failed to list Servers: %w", err)
+		}
+		for _, server := range servers.Items {
+			if server.Status.State == metalv1alpha1.ServerStateError {
+				add(AlertServerError, "Server", server.Name, fmt.Sprintf("Server %s entered the Error state", server.Name), nil)
+			}
+		}
+	}
+	return firing, nil
+}
+
+func filterResolved(alerts []Alert) []Alert {
+	var result []Alert
+	for _, alert := range alerts {
+		if alert.Resolved() {
+			result = append(result, alert)
+		}
+	}
+	return result
+}
+
+func sortedAlerts(alerts map[string]Alert) []Alert {
+	result := make([]Alert, 0, len(alerts))
+	for _, alert := range alerts {
+		result = append(result, alert)
+	}
+	return sortAlerts(result)
+}
+
+// sortAlerts orders the alerts by name and affected object, so that notifications are stable.
+func sortAlerts(alerts []Alert) []Alert {
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].Name != alerts[j].Name {
+			return alerts[i].Name < alerts[j].Name
+		}
+		if alerts[i].Labels["kind"] != alerts[j].Labels["kind"] {
+			return alerts[i].Labels["kind"] < alerts[j].Labels["kind"]
+		}
+		return alerts[i].Labels["name"] < alerts[j].Labels["name"]
+	})
+	return alerts
+}
@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package notification
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/internal/controller"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Notifier", func() {
+	var (
+		k8sClient client.Client
+		notifier  *Notifier
+		now       time.Time
+
+		mu       sync.Mutex
+		payloads map[Format][]json.RawMessage
+	)
+
+	BeforeEach(func() {
+		now = time.Now()
+		scheme := runtime.NewScheme()
+		Expect(metalv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&metalv1alpha1.Server{
+				ObjectMeta: metav1.ObjectMeta{Name: "broken"},
+				Status:     metalv1alpha1.ServerStatus{State: metalv1alpha1.ServerStateError},
+			},
+			&metalv1alpha1.Server{
+				ObjectMeta: metav1.ObjectMeta{Name: "healthy"},
+				Status:     metalv1alpha1.ServerStatus{State: metalv1alpha1.ServerStateAvailable},
+			},
+			&metalv1alpha1.BMC{
+				ObjectMeta: metav1.ObjectMeta{Name: "gone"},
+				Status: metalv1alpha1.BMCStatus{Conditions: []metav1.Condition{{
+					Type:               controller.BMCConditionReachable,
+					Status:             metav1.ConditionFalse,
+					Reason:             "ConnectionFailed",
+					Message:            "connection refused",
+					LastTransitionTime: metav1.NewTime(now.Add(-time.Hour)),
+				}}},
+			},
+			&metalv1alpha1.BMC{
+				ObjectMeta: metav1.ObjectMeta{Name: "flaky"},
+				Status: metalv1alpha1.BMCStatus{Conditions: []metav1.Condition{{
+					Type:               controller.BMCConditionReachable,
+					Status:             metav1.ConditionFalse,
+					Reason:             "ConnectionFailed",
+					LastTransitionTime: metav1.NewTime(now.Add(-time.Minute)),
+				}}},
+			},
+		).Build()
+
+		payloads = map[Format][]json.RawMessage{}
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var payload json.RawMessage
+			Expect(json.NewDecoder(r.Body).Decode(&payload)).To(Succeed())
+			mu.Lock()
+			defer mu.Unlock()
+			format := Format(r.URL.Path[1:])
+			payloads[format] = append(payloads[format], payload)
+		}))
+		DeferCleanup(receiver.Close)
+
+		notifier = &Notifier{
+			Client: k8sClient,
+			Config: &Config{
+				Sinks: []Sink{
+					{Name: "webhook", URL: receiver.URL + "/Webhook", Format: FormatWebhook},
+					{Name: "alertmanager", URL: receiver.URL + "/Alertmanager", Format: FormatAlertmanager},
+				},
+				Triggers: Triggers{
+					ServerError:         true,
+					BMCUnreachableAfter: &metav1.Duration{Duration: 10 * time.Minute},
+				},
+			},
+		}
+	})
+
+	It("Should notify about firing and resolved alerts", func(ctx SpecContext) {
+		By("Sending the firing alerts")
+		Expect(notifier.notify(ctx, now)).To(Succeed())
+		Expect(payloads[FormatWebhook]).To(HaveLen(1))
+		var webhook struct {
+			Status string
+			Alerts []struct {
+				Status string
+				Labels map[string]string
+			}
+		}
+		Expect(json.Unmarshal(payloads[FormatWebhook][0], &webhook)).To(Succeed())
+		Expect(webhook.Status).To(Equal("firing"))
+		Expect(webhook.Alerts).To(ConsistOf(
+			HaveField("Labels", Equal(map[string]string{"alertname": AlertBMCUnreachable, "kind": "BMC", "name": "gone"})),
+			HaveField("Labels", Equal(map[string]string{"alertname": AlertServerError, "kind": "Server", "name": "broken"})),
+		))
+
+		By("Not sending unchanged alerts to webhooks again")
+		Expect(notifier.notify(ctx, now.Add(time.Minute))).To(Succeed())
+		Expect(payloads[FormatWebhook]).To(HaveLen(1))
+		Expect(payloads[FormatAlertmanager]).To(HaveLen(2))
+
+		By("Sending resolved alerts")
+		server := &metalv1alpha1.Server{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: "broken"}, server)).To(Succeed())
+		server.Status.State = metalv1alpha1.ServerStateAvailable
+		Expect(k8sClient.Update(ctx, server)).To(Succeed())
+
+		Expect(notifier.notify(ctx, now.Add(2*time.Minute))).To(Succeed())
+		Expect(payloads[FormatWebhook]).To(HaveLen(2))
+		Expect(json.Unmarshal(payloads[FormatWebhook][1], &webhook)).To(Succeed())
+		Expect(webhook.Status).To(Equal("resolved"))
+		Expect(webhook.Alerts).To(ConsistOf(SatisfyAll(
+			HaveField("Status", "resolved"),
+			HaveField("Labels", HaveKeyWithValue("name", "broken")),
+		)))
+
+		var alertmanager []struct {
+			Labels map[string]string
+			EndsAt *time.Time
+		}
+		Expect(json.Unmarshal(payloads[FormatAlertmanager][2], &alertmanager)).To(Succeed())
+		Expect(alertmanager).To(ConsistOf(
+			SatisfyAll(HaveField("Labels", HaveKeyWithValue("name", "gone")), HaveField("EndsAt", BeNil())),
+			SatisfyAll(HaveField("Labels", HaveKeyWithValue("name", "broken")), HaveField("EndsAt", Not(BeNil()))),
+		))
+	})
+
+	It("Should retry failed sends on the next evaluation", func(ctx SpecContext) {
+		var (
+			failing  = true
+			received [][]Alert
+		)
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			if failing {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			var payload struct {
+				Alerts []Alert
+			}
+			Expect(json.NewDecoder(r.Body).Decode(&payload)).To(Succeed())
+			received = append(received, payload.Alerts)
+		}))
+		DeferCleanup(receiver.Close)
+		notifier.Config.Sinks = []Sink{{Name: "webhook", URL: receiver.URL, Format: FormatWebhook}}
+
+		By("Failing to send the firing alerts")
+		Expect(notifier.notify(ctx, now)).NotTo(Succeed())
+		Expect(received).To(BeEmpty())
+
+		By("Sending the firing alerts once the sink recovered")
+		mu.Lock()
+		failing = false
+		mu.Unlock()
+		Expect(notifier.notify(ctx, now.Add(time.Minute))).To(Succeed())
+		Expect(received).To(HaveLen(1))
+
+		By("Not sending the alerts again")
+		Expect(notifier.notify(ctx, now.Add(2*time.Minute))).To(Succeed())
+		Expect(received).To(HaveLen(1))
+	})
+
+	It("Should not send the notified alerts again after a restart", func(ctx SpecContext) {
+		notifier.Namespace = "default"
+		notifier.Config.Sinks = notifier.Config.Sinks[:1]
+		Expect(notifier.notify(ctx, now)).To(Succeed())
+		Expect(payloads[FormatWebhook]).To(HaveLen(1))
+		configMap := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: StateConfigMapName}, configMap)).To(Succeed())
+		Expect(configMap.Data).To(HaveKey(StateKey))
+
+		restarted := &Notifier{Client: k8sClient, Config: notifier.Config, Namespace: "default"}
+		Expect(restarted.notify(ctx, now.Add(time.Minute))).To(Succeed())
+		Expect(payloads[FormatWebhook]).To(HaveLen(1))
+	})
+})
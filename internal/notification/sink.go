@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Format is the payload format of a notification sink.
+type Format string
+
+const (
+	// FormatWebhook posts the alerts in the payload format of the Alertmanager webhook receiver, so that receivers
+	// written for Alertmanager can be reused.
+	FormatWebhook Format = "Webhook"
+	// FormatSlack posts the alerts as message to a Slack incoming webhook.
+	FormatSlack Format = "Slack"
+	// FormatAlertmanager posts the alerts to the v2 alerts API of an Alertmanager, e.g.
+	// "http://alertmanager:9093/api/v2/alerts".
+	FormatAlertmanager Format = "Alertmanager"
+)
+
+// Alert is a notification about a hardware event.
+type Alert struct {
+	// Name is the name of the trigger which raised the alert, e.g. FirmwareUpdateFailed.
+	Name string
+	// Labels identify the affected object, e.g. its kind and name.
+	Labels map[string]string
+	// Summary is a human-readable description of the alert.
+	Summary string
+	// StartsAt is the time the alert started firing.
+	StartsAt time.Time
+	// EndsAt is the time the alert was resolved. It is zero while the alert is firing.
+	EndsAt time.Time
+}
+
+// Resolved returns whether the alert has been resolved.
+func (a Alert) Resolved() bool {
+	return !a.EndsAt.IsZero()
+}
+
+func (a Alert) status() string {
+	if a.Resolved() {
+		return "resolved"
+	}
+	return "firing"
+}
+
+// labels returns the labels of the alert including the alertname label.
+func (a Alert) labels() map[string]string {
+	labels := make(map[string]string, len(a.Labels)+1)
+	for key, value := range a.Labels {
+		labels[key] = value
+	}
+	labels["alertname"] = a.Name
+	return labels
+}
+
+// Sink delivers alerts to an external system.
+type Sink struct {
+	// Name is the name of the sink.
+	Name string `json:"name"`
+	// URL is the URL the alerts are posted to.
+	URL string `json:"url"`
+	// Format is the payload format of the sink. Defaults to Webhook.
+	Format Format `json:"format,omitempty"`
+
+	client *http.Client
+}
+
+// Send posts the alerts to the sink.
+func (s *Sink) Send(ctx context.Context, alerts []Alert) error {
+	if len(alerts) == 0 {
+		return nil
+	}
+	var payload any
+	switch s.Format {
+	case FormatWebhook, "":
+		payload = webhookPayload(alerts)
+	case FormatSlack:
+		payload = slackPayload(alerts)
+	case FormatAlertmanager:
+		payload = alertmanagerPayload(alerts)
+	default:
+		return fmt.Errorf("unknown notification format %q", s.Format)
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	httpClient := s.client
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send notification to sink %s: %w", s.Name, err)
+	}
+	defer resp.Body.Close() // nolint: errcheck
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to send notification to sink %s: %s", s.Name, resp.Status)
+	}
+	return nil
+}
+
+type alertmanagerAlert struct {
+	Status      string            `json:"status,omitempty"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	StartsAt    time.Time         `json:"startsAt"`
+	EndsAt      *time.Time        `json:"endsAt,omitempty"`
+}
+
+func toAlertmanagerAlert(alert Alert, withStatus bool) alertmanagerAlert {
+	result := alertmanagerAlert{
+		Labels:      alert.labels(),
+		Annotations: map[string]string{"summary": alert.Summary},
+		StartsAt:    alert.StartsAt,
+	}
+	if withStatus {
+		result.Status = alert.status()
+	}
+	if alert.Resolved() {
+		endsAt := alert.EndsAt
+		result.EndsAt = &endsAt
+	}
+	return result
+}
+
+func alertmanagerPayload(alerts []Alert) []alertmanagerAlert {
+	result := make([]alertmanagerAlert, 0, len(alerts))
+	for _, alert := range alerts {
+		result = append(result, toAlertmanagerAlert(alert, false))
+	}
+	return result
+}
+
+func webhookPayload(alerts []Alert) any {
+	status := "resolved"
+	converted := make([]alertmanagerAlert, 0, len(alerts))
+	for _, alert := range alerts {
+		if !alert.Resolved() {
+			status = "firing"
+		}
+		converted = append(converted, toAlertmanagerAlert(alert, true))
+	}
+	return struct {
+		Version  string              `json:"version"`
+		Receiver string              `json:"receiver"`
+		Status   string              `json:"status"`
+		Alerts   []alertmanagerAlert `json:"alerts"`
+	}{Version: "4", Receiver: "metal-operator", Status: status, Alerts: converted}
+}
+
+func slackPayload(alerts []Alert) any {
+	lines := make([]string, 0, len(alerts))
+	for _, alert := range alerts {
+		lines = append(lines, fmt.Sprintf("[%s] %s: %s", strings.ToUpper(alert.status()), alert.Name, alert.Summary))
+	}
+	return struct {
+		Text string `json:"text"`
+	}{Text: strings.Join(lines, "\n")}
+}
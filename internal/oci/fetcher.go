@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// TitleAnnotation is the annotation of a layer holding its file name, as set by ORAS.
+const TitleAnnotation = "org.opencontainers.image.title"
+
+// ErrLayerNotFound is returned if an image has no layer with the requested title.
+var ErrLayerNotFound = errors.New("layer not found")
+
+// Descriptor describes a layer of an image.
+type Descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Platform describes the platform of a manifest in an image index.
+type Platform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+}
+
+type platformDescriptor struct {
+	Descriptor
+	Platform *Platform `json:"platform,omitempty"`
+}
+
+// imageManifest is either an image manifest with layers or an image index with manifests.
+type imageManifest struct {
+	Manifests []platformDescriptor `json:"manifests,omitempty"`
+	Layers    []Descriptor         `json:"layers,omitempty"`
+}
+
+// Fetcher fetches the layers of artifact images, e.g. kernels and initrds pushed with ORAS.
+type Fetcher interface {
+	// Layer returns the descriptor of the layer of the image whose title annotation is the given name. Images
+	// with an image index are resolved to the manifest of the given architecture in the naming of OCI, e.g.
+	// amd64. An empty architecture selects the first manifest of the index.
+	Layer(ctx context.Context, image, architecture, name string) (Descriptor, error)
+	// Blob returns the content of the blob with the given digest of the repository of the image.
+	Blob(ctx context.Context, image, digest string) (io.ReadCloser, error)
+}
+
+// NewFetcher returns a Fetcher querying the OCI distribution API of the image registries with the given
+// client. Registries requiring a bearer token are accessed anonymously.
+func NewFetcher(client *http.Client) Fetcher {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &registryResolver{client: client}
+}
+
+func (r *registryResolver) Layer(ctx context.Context, image, architecture, name string) (Descriptor, error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return Descriptor{}, err
+	}
+	reference := ref.Tag
+	if ref.Digest != "" {
+		reference = ref.Digest
+	}
+	manifest, err := r.manifest(ctx, ref, image, reference)
+	if err != nil {
+		return Descriptor{}, err
+	}
+	if len(manifest.Manifests) > 0 {
+		index := manifest.Manifests
+		selected := -1
+		for i, m := range index {
+			if architecture == "" || (m.Platform != nil && m.Platform.Architecture == architecture) {
+				selected = i
+				break
+			}
+		}
+		if selected < 0 {
+			return Descriptor{}, fmt.Errorf("%w: no manifest for architecture %s in image %s", ErrImageNotFound, architecture, image)
+		}
+		if manifest, err = r.manifest(ctx, ref, image, index[selected].Digest); err != nil {
+			return Descriptor{}, err
+		}
+	}
+	for _, layer := range manifest.Layers {
+		if layer.Annotations[TitleAnnotation] == name {
+			return layer, nil
+		}
+	}
+	return Descriptor{}, fmt.Errorf("%w: %s in image %s", ErrLayerNotFound, name, image)
+}
+
+func (r *registryResolver) manifest(ctx context.Context, ref Reference, image, reference string) (imageManifest, error) {
+	resp, err := r.get(ctx, ref, "manifests/"+reference, strings.Join(manifestMediaTypes, ", "))
+	if err != nil {
+		return imageManifest{}, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return imageManifest{}, fmt.Errorf("%w: %s", ErrImageNotFound, image)
+	default:
+		return imageManifest{}, fmt.Errorf("unexpected status code %d for manifest of image %s", resp.StatusCode, image)
+	}
+	manifest := imageManifest{}
+	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
+		return imageManifest{}, fmt.Errorf("failed to decode manifest of image %s: %w", image, err)
+	}
+	return manifest, nil
+}
+
+func (r *registryResolver) Blob(ctx context.Context, image, digest string) (io.ReadCloser, error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.get(ctx, ref, "blobs/"+digest, "")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status code %d for blob %s of image %s", resp.StatusCode, digest, image)
+	}
+	return resp.Body, nil
+}
+
+// get requests the given path below the repository of the reference, authenticating with an anonymous bearer
+// token if the registry requires one. The caller has to close the body of the response.
+func (r *registryResolver) get(ctx context.Context, ref Reference, path, accept string) (*http.Response, error) {
+	host := ref.Registry
+	if host == defaultRegistry {
+		host = defaultRegistryHost
+	}
+	url := fmt.Sprintf("https://%s/v2/%s/%s", host, ref.Repository, path)
+
+	do := func(token string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := r.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to request %s: %w", strings.SplitN(path, "/", 2)[0], err)
+		}
+		return resp, nil
+	}
+
+	resp, err := do("")
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	_ = resp.Body.Close()
+	token, err := r.fetchToken(ctx, resp.Header.Get("WWW-Authenticate"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate against registry %s: %w", ref.Registry, err)
+	}
+	return do(token)
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package oci_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/ironcore-dev/metal-operator/internal/oci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Fetcher", func() {
+	var (
+		registry *httptest.Server
+		fetcher  oci.Fetcher
+		image    string
+	)
+
+	BeforeEach(func() {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = fmt.Fprint(w, `{"token":"secret"}`)
+		})
+		mux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.Header().Set("WWW-Authenticate",
+					fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:foo:pull"`, registry.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			switch r.URL.Path {
+			case "/v2/foo/manifests/multi-arch":
+				_, _ = fmt.Fprint(w, `{"manifests":[{"digest":"sha256:amd64","platform":{"architecture":"amd64","os":"linux"}},`+
+					`{"digest":"sha256:arm64","platform":{"architecture":"arm64","os":"linux"}}]}`)
+			case "/v2/foo/manifests/sha256:arm64":
+				_, _ = fmt.Fprintf(w, `{"layers":[{"digest":"sha256:kernel-arm64","annotations":{%q:"vmlinuz"}}]}`,
+					oci.TitleAnnotation)
+			case "/v2/foo/manifests/latest":
+				_, _ = fmt.Fprintf(w, `{"layers":[{"digest":%q,"size":6,"annotations":{%q:"vmlinuz"}}]}`,
+					testDigest, oci.TitleAnnotation)
+			case "/v2/foo/blobs/" + testDigest:
+				_, _ = fmt.Fprint(w, "kernel")
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		})
+		registry = httptest.NewTLSServer(mux)
+		DeferCleanup(registry.Close)
+		fetcher = oci.NewFetcher(registry.Client())
+		image = strings.TrimPrefix(registry.URL, "https://") + "/foo:latest"
+	})
+
+	It("should fetch the layer with the requested title", func(ctx SpecContext) {
+		layer, err := fetcher.Layer(ctx, image, "", "vmlinuz")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(layer.Digest).To(Equal(testDigest))
+
+		blob, err := fetcher.Blob(ctx, image, layer.Digest)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(blob.Close)
+		Expect(io.ReadAll(blob)).To(BeEquivalentTo("kernel"))
+	})
+
+	It("should select the manifest of the architecture from an image index", func(ctx SpecContext) {
+		layer, err := fetcher.Layer(ctx, strings.Replace(image, ":latest", ":multi-arch", 1), "arm64", "vmlinuz")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(layer.Digest).To(Equal("sha256:kernel-arm64"))
+
+		_, err = fetcher.Layer(ctx, strings.Replace(image, ":latest", ":multi-arch", 1), "s390x", "vmlinuz")
+		Expect(err).To(MatchError(oci.ErrImageNotFound))
+	})
+
+	It("should fail for a missing layer", func(ctx SpecContext) {
+		_, err := fetcher.Layer(ctx, image, "", "initrd")
+		Expect(err).To(MatchError(oci.ErrLayerNotFound))
+	})
+})
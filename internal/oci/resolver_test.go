@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package oci_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/ironcore-dev/metal-operator/internal/oci"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const testDigest = "sha256:6c3c624b58dbbcd3c0dd82b4c53f04194d1247c6eebdaab7c610cf7d66709b3b"
+
+var _ = Describe("ParseReference", func() {
+	DescribeTable("should parse the image reference",
+		func(image string, expected oci.Reference) {
+			Expect(oci.ParseReference(image)).To(Equal(expected))
+		},
+		Entry("official image", "ubuntu",
+			oci.Reference{Registry: "docker.io", Repository: "library/ubuntu", Tag: "latest"}),
+		Entry("tagged image", "ghcr.io/ironcore-dev/os-images/gardenlinux:1443.3",
+			oci.Reference{Registry: "ghcr.io", Repository: "ironcore-dev/os-images/gardenlinux", Tag: "1443.3"}),
+		Entry("registry with port", "localhost:5000/foo:bar",
+			oci.Reference{Registry: "localhost:5000", Repository: "foo", Tag: "bar"}),
+		Entry("digest", "ghcr.io/foo/bar@"+testDigest,
+			oci.Reference{Registry: "ghcr.io", Repository: "foo/bar", Digest: testDigest}),
+	)
+})
+
+var _ = Describe("Resolver", func() {
+	var (
+		registry *httptest.Server
+		resolver oci.Resolver
+	)
+
+	BeforeEach(func() {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("scope") != "repository:foo:pull,push" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = fmt.Fprint(w, `{"token":"secret"}`)
+		})
+		mux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.Header().Set("WWW-Authenticate",
+					fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:foo:pull,push"`, registry.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if r.URL.Path != "/v2/foo/manifests/latest" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Docker-Content-Digest", testDigest)
+		})
+		registry = httptest.NewTLSServer(mux)
+		DeferCleanup(registry.Close)
+		resolver = oci.NewResolver(registry.Client())
+	})
+
+	It("should resolve the digest of an existing image", func(ctx SpecContext) {
+		host := strings.TrimPrefix(registry.URL, "https://")
+		Expect(resolver.Resolve(ctx, host+"/foo:latest")).To(Equal(testDigest))
+	})
+
+	It("should fail for a missing image", func(ctx SpecContext) {
+		host := strings.TrimPrefix(registry.URL, "https://")
+		_, err := resolver.Resolve(ctx, host+"/foo:missing")
+		Expect(err).To(MatchError(oci.ErrImageNotFound))
+	})
+})
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package placement lets external systems veto or re-rank the Servers selected for a ServerClaim before the claim
+// is bound, e.g. for capacity planning or security zones.
+package placement
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+)
+
+// Admitter admits the placement of ServerClaims on Servers.
+type Admitter interface {
+	// Admit decides which of the candidate Servers the claim may be bound to.
+	Admit(ctx context.Context, claim *metalv1alpha1.ServerClaim, candidates []metalv1alpha1.Server) (Decision, error)
+}
+
+// Review is the request sent to a placement webhook.
+type Review struct {
+	// Claim is the ServerClaim to be bound.
+	Claim *metalv1alpha1.ServerClaim `json:"claim"`
+	// Candidates are the Servers the claim can be bound to, in the order of the operator.
+	Candidates []metalv1alpha1.Server `json:"candidates"`
+}
+
+// Decision is the response of a placement webhook.
+type Decision struct {
+	// Servers are the names of the candidates the claim may be bound to, in order of preference. Candidates which
+	// are not listed are vetoed.
+	Servers []string `json:"servers"`
+	// Reason explains the decision, e.g. why all candidates have been vetoed.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Webhook admits placements by posting a Review to an HTTP endpoint, which responds with a Decision.
+type Webhook struct {
+	// URL is the URL of the webhook.
+	URL string
+	// Client sends the requests. It defaults to http.DefaultClient.
+	Client *http.Client
+	// IgnoreFailures admits all candidates in their original order if the webhook cannot be reached or fails.
+	IgnoreFailures bool
+}
+
+// Admit implements Admitter.
+func (w *Webhook) Admit(ctx context.Context, claim *metalv1alpha1.ServerClaim, candidates []metalv1alpha1.Server) (Decision, error) {
+	decision, err := w.review(ctx, claim, candidates)
+	if err != nil {
+		if !w.IgnoreFailures {
+			return Decision{}, err
+		}
+		decision = Decision{Reason: fmt.Sprintf("Ignored failed placement webhook: %v", err)}
+		for _, candidate := range candidates {
+			decision.Servers = append(decision.Servers, candidate.Name)
+		}
+		return decision, nil
+	}
+
+	// Only candidates may be admitted.
+	names := make(map[string]bool, len(candidates))
+	for _, candidate := range candidates {
+		names[candidate.Name] = true
+	}
+	admitted := make([]string, 0, len(decision.Servers))
+	for _, name := range decision.Servers {
+		if names[name] {
+			admitted = append(admitted, name)
+			delete(names, name)
+		}
+	}
+	decision.Servers = admitted
+	return decision, nil
+}
+
+func (w *Webhook) review(ctx context.Context, claim *metalv1alpha1.ServerClaim, candidates []metalv1alpha1.Server) (Decision, error) {
+	review := Review{Claim: claim.DeepCopy(), Candidates: make([]metalv1alpha1.Server, 0, len(candidates))}
+	review.Claim.ManagedFields = nil
+	for _, candidate := range candidates {
+		server := candidate.DeepCopy()
+		server.ManagedFields = nil
+		review.Candidates = append(review.Candidates, *server)
+	}
+	body, err := json.Marshal(review)
+	if err != nil {
+		return Decision{}, fmt.Errorf("failed to marshal placement review: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return Decision{}, fmt.Errorf("failed to create placement webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := w.Client
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return Decision{}, fmt.Errorf("failed to call placement webhook: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return Decision{}, fmt.Errorf("placement webhook returned %s: %s", resp.Status, bytes.TrimSpace(message))
+	}
+
+	decision := Decision{}
+	if err := json.NewDecoder(resp.Body).Decode(&decision); err != nil {
+		return Decision{}, fmt.Errorf("failed to decode placement decision: %w", err)
+	}
+	return decision, nil
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package placement_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/internal/placement"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Webhook", func() {
+	var (
+		claim      *metalv1alpha1.ServerClaim
+		candidates []metalv1alpha1.Server
+	)
+
+	BeforeEach(func() {
+		claim = &metalv1alpha1.ServerClaim{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "claim"}}
+		candidates = []metalv1alpha1.Server{
+			{ObjectMeta: metav1.ObjectMeta{Name: "server-a"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "server-b"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "server-c"}},
+		}
+	})
+
+	It("should re-rank and veto the candidates", func(ctx SpecContext) {
+		var review placement.Review
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(json.NewDecoder(r.Body).Decode(&review)).To(Succeed())
+			_ = json.NewEncoder(w).Encode(placement.Decision{
+				Servers: []string{"server-c", "unknown", "server-a", "server-c"},
+				Reason:  "server-b is in a full rack",
+			})
+		}))
+		DeferCleanup(server.Close)
+
+		webhook := &placement.Webhook{URL: server.URL}
+		Expect(webhook.Admit(ctx, claim, candidates)).To(Equal(placement.Decision{
+			Servers: []string{"server-c", "server-a"},
+			Reason:  "server-b is in a full rack",
+		}))
+		Expect(review.Claim.Name).To(Equal("claim"))
+		Expect(review.Candidates).To(HaveLen(3))
+	})
+
+	It("should fail or admit all candidates if the webhook fails", func(ctx SpecContext) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "capacity service unavailable", http.StatusServiceUnavailable)
+		}))
+		DeferCleanup(server.Close)
+
+		webhook := &placement.Webhook{URL: server.URL}
+		_, err := webhook.Admit(ctx, claim, candidates)
+		Expect(err).To(MatchError(ContainSubstring("capacity service unavailable")))
+
+		webhook.IgnoreFailures = true
+		Expect(webhook.Admit(ctx, claim, candidates)).To(HaveField("Servers", []string{"server-a", "server-b", "server-c"}))
+	})
+})
@@ -10,6 +10,8 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"runtime"
 	"time"
 
 	"github.com/ironcore-dev/metal-operator/internal/api/registry"
@@ -19,8 +21,21 @@ import (
 type Agent struct {
 	SystemUUID  string
 	RegistryURL string
-	Duration    time.Duration
-	Server      *registry.Server // Pointer to Server for late initialization.
+	// RegistryToken authenticates the agent at the registry, see registry.SystemToken. It is required for posting
+	// bootstrapped BMC credentials and uploading the SMBIOS table.
+	RegistryToken string
+	Duration      time.Duration
+	Server        *registry.Server // Pointer to Server for late initialization.
+	// Collectors collect site-specific inventory which is attached to the registry payload.
+	Collectors []Collector
+	// CollectorTimeout is the time each collector may take. Defaults to DefaultCollectorTimeout.
+	CollectorTimeout time.Duration
+	// BMCAccount is the BMC account whose password is set through the Redfish host interface, if the BMC
+	// supports credential bootstrapping. An empty value disables the bootstrapping.
+	BMCAccount string
+
+	// smbiosTable is the raw SMBIOS table of the server, which is uploaded to the registry.
+	smbiosTable []byte
 }
 
 // NewAgent creates a new Agent with the specified system UUID and registry URL.
@@ -39,7 +54,22 @@ func (a *Agent) Init() error {
 		return err
 	}
 
-	a.Server = &registry.Server{NetworkInterfaces: interfaces}
+	timeout := a.CollectorTimeout
+	if timeout == 0 {
+		timeout = DefaultCollectorTimeout
+	}
+	a.Server = &registry.Server{
+		NetworkInterfaces: interfaces,
+		Architecture:      architecture(runtime.GOARCH),
+		Extensions:        runCollectors(context.Background(), a.Collectors, timeout),
+	}
+	// servers without SMBIOS tables, e.g. some virtual machines, are registered without them
+	if table, err := os.ReadFile(smbiosTable); err != nil {
+		log.Printf("Failed to read SMBIOS table: %v", err)
+	} else {
+		a.smbiosTable = table
+		a.Server.SMBIOS = parseSMBIOS(table)
+	}
 	return nil
 }
 
@@ -56,6 +86,16 @@ func (a *Agent) Start(ctx context.Context) error {
 		}
 	}
 
+	if a.BMCAccount != "" && a.RegistryToken == "" {
+		// the credentials could not be posted to the registry, so the BMC password must not be changed
+		log.Println("Skipping the BMC credential bootstrapping without a registry token")
+	} else if a.BMCAccount != "" {
+		// a failed bootstrapping must not prevent the registration of the server
+		if err := a.bootstrapBMCCredentials(ctx); err != nil {
+			log.Printf("Error bootstrapping BMC credentials: %v", err)
+		}
+	}
+
 	// Run the registration immediately before starting the ticker loop.
 	log.Println("Registering server ...")
 	if err := a.registerServer(ctx); err != nil {
@@ -64,6 +104,14 @@ func (a *Agent) Start(ctx context.Context) error {
 	}
 	log.Printf("Server with UUID: %s registered.", a.SystemUUID)
 
+	if len(a.smbiosTable) > 0 && a.RegistryToken == "" {
+		log.Println("Skipping the upload of the SMBIOS table without a registry token")
+	} else if len(a.smbiosTable) > 0 {
+		if err := a.uploadSMBIOSTable(ctx, a.smbiosTable); err != nil {
+			log.Printf("Error uploading SMBIOS table: %v", err)
+		}
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -122,3 +170,15 @@ func (a *Agent) registerServer(ctx context.Context) error {
 		},
 	)
 }
+
+// architecture returns the CPU architecture of a Go architecture in the naming of the kernel, e.g. x86_64 for amd64.
+func architecture(goarch string) string {
+	switch goarch {
+	case "amd64":
+		return "x86_64"
+	case "arm64":
+		return "aarch64"
+	default:
+		return goarch
+	}
+}
@@ -29,5 +29,8 @@ var _ = Describe("ProbeAgent", func() {
 		server := &registry.Server{}
 		Expect(json.NewDecoder(resp.Body).Decode(server)).NotTo(HaveOccurred())
 		Expect(server.NetworkInterfaces).NotTo(BeEmpty())
+
+		By("ensuring that the output of the collectors is attached")
+		Expect(server.Extensions).To(HaveKeyWithValue("fpga", json.RawMessage(`{"model":"x1"}`)))
 	})
 })
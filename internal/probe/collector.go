@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package probe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCollectorTimeout is the time a collector may take to collect its inventory.
+const DefaultCollectorTimeout = time.Minute
+
+// collectorNamePattern restricts collector names, as they are used in the keys of Server annotations.
+var collectorNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+// Collector collects site-specific inventory of a server, e.g. custom FPGAs. Its output is attached to the
+// registry payload under the name of the collector and shows up as annotation of the Server.
+type Collector interface {
+	// Name returns the name of the collector. It has to be a DNS label.
+	Name() string
+	// Collect returns the collected inventory as JSON.
+	Collect(ctx context.Context) (json.RawMessage, error)
+}
+
+var (
+	collectorsMu sync.Mutex
+	collectors   []Collector
+)
+
+// RegisterCollector registers a collector which is compiled into the probe agent. Custom agent images register
+// their collectors from an init function of a package imported by their main package.
+func RegisterCollector(collector Collector) {
+	collectorsMu.Lock()
+	defer collectorsMu.Unlock()
+	collectors = append(collectors, collector)
+}
+
+// RegisteredCollectors returns the collectors registered with RegisterCollector.
+func RegisteredCollectors() []Collector {
+	collectorsMu.Lock()
+	defer collectorsMu.Unlock()
+	return append([]Collector(nil), collectors...)
+}
+
+// ExecCollector runs an executable which writes its inventory as JSON to stdout.
+type ExecCollector struct {
+	// Path is the path of the executable. The name of the collector is the file name without its extension.
+	Path string
+}
+
+func (c ExecCollector) Name() string {
+	name := filepath.Base(c.Path)
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+func (c ExecCollector) Collect(ctx context.Context) (json.RawMessage, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.Path)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to run collector %s: %w: %s", c.Path, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// ExecCollectorsFromDir returns an ExecCollector for every executable file in the directory.
+func ExecCollectorsFromDir(dir string) ([]Collector, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read collector directory: %w", err)
+	}
+	var result []Collector
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat collector %s: %w", entry.Name(), err)
+		}
+		if info.Mode().Perm()&0o111 == 0 {
+			continue
+		}
+		result = append(result, ExecCollector{Path: filepath.Join(dir, entry.Name())})
+	}
+	return result, nil
+}
+
+// runCollectors runs the collectors and returns their valid JSON output by collector name. Failing collectors
+// are logged and skipped, so that they never prevent the registration of the server.
+func runCollectors(ctx context.Context, collectors []Collector, timeout time.Duration) map[string]json.RawMessage {
+	if len(collectors) == 0 {
+		return nil
+	}
+	extensions := map[string]json.RawMessage{}
+	for _, collector := range collectors {
+		name := collector.Name()
+		if !collectorNamePattern.MatchString(name) {
+			log.Printf("Skipping collector with invalid name %q", name)
+			continue
+		}
+		if _, ok := extensions[name]; ok {
+			log.Printf("Skipping duplicate collector %q", name)
+			continue
+		}
+
+		collectCtx, cancel := context.WithTimeout(ctx, timeout)
+		data, err := collector.Collect(collectCtx)
+		cancel()
+		if err != nil {
+			log.Printf("Collector %q failed: %v", name, err)
+			continue
+		}
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, data); err != nil {
+			log.Printf("Collector %q returned invalid JSON: %v", name, err)
+			continue
+		}
+		extensions[name] = compacted.Bytes()
+	}
+	return extensions
+}
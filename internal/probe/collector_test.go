@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package probe_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/ironcore-dev/metal-operator/internal/probe"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ExecCollector", func() {
+	It("should collect the output of the executables in a directory", func(ctx SpecContext) {
+		dir := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "gpu.sh"), []byte("#!/bin/sh\necho '{\"count\": 2}'\n"), 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "README"), []byte("not a collector"), 0o644)).To(Succeed())
+
+		collectors, err := probe.ExecCollectorsFromDir(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(collectors).To(HaveLen(1))
+		Expect(collectors[0].Name()).To(Equal("gpu"))
+
+		data, err := collectors[0].Collect(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{"count": 2}`))
+	})
+})
@@ -0,0 +1,207 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package probe
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/api/registry"
+)
+
+const (
+	// hostInterfaceEntries matches the SMBIOS type 42 (Management Controller Host Interface) entries.
+	hostInterfaceEntries = "/sys/firmware/dmi/entries/42-*/raw"
+	// ipmiDevice is the device of the in-band IPMI system interface (KCS).
+	ipmiDevice = "/dev/ipmi0"
+
+	smbiosProtocolRedfishOverIP = 0x04
+	smbiosAddressFormatIPv4     = 0x01
+	smbiosAddressFormatIPv6     = 0x02
+
+	// ipmiNetFnGroupExtension and ipmiCmdGetBootstrapCredentials form the "Get Manager Bootstrap Account
+	// Credentials" command of the Redfish Host Interface Specification (DSP0270).
+	ipmiNetFnGroupExtension        = 0x2c
+	ipmiCmdGetBootstrapCredentials = 0x02
+	ipmiGroupExtensionRedfish      = 0x52
+	// ipmiKeepCredentialBootstrapping keeps the credential bootstrapping enabled after the request, so that the
+	// credentials can be bootstrapped again after a reinstallation.
+	ipmiKeepCredentialBootstrapping = 0xa5
+)
+
+// HostInterface is the Redfish service of the BMC reachable from the host, as described by SMBIOS type 42.
+type HostInterface struct {
+	IP   net.IP
+	Port int
+}
+
+// Endpoint returns the URL of the Redfish service.
+func (h HostInterface) Endpoint() string {
+	return "https://" + net.JoinHostPort(h.IP.String(), strconv.Itoa(h.Port))
+}
+
+// readHostInterface returns the Redfish host interface of the first SMBIOS type 42 entry describing one.
+func readHostInterface() (HostInterface, error) {
+	entries, err := filepath.Glob(hostInterfaceEntries)
+	if err != nil {
+		return HostInterface{}, err
+	}
+	for _, entry := range entries {
+		data, err := os.ReadFile(entry)
+		if err != nil {
+			return HostInterface{}, fmt.Errorf("failed to read SMBIOS entry %s: %w", entry, err)
+		}
+		if hostInterface, ok := parseHostInterface(data); ok {
+			return hostInterface, nil
+		}
+	}
+	return HostInterface{}, errors.New("no Redfish host interface found in SMBIOS")
+}
+
+// parseHostInterface parses the Redfish over IP protocol record of a SMBIOS type 42 structure.
+func parseHostInterface(data []byte) (HostInterface, bool) {
+	// header (4 bytes), interface type, length of the interface specific data
+	if len(data) < 6 || data[0] != 42 {
+		return HostInterface{}, false
+	}
+	offset := 6 + int(data[5])
+	if len(data) <= offset {
+		return HostInterface{}, false
+	}
+	records := int(data[offset])
+	offset++
+	for range records {
+		if len(data) < offset+2 {
+			return HostInterface{}, false
+		}
+		protocol, length := data[offset], int(data[offset+1])
+		record := data[offset+2:]
+		offset += 2 + length
+		if protocol != smbiosProtocolRedfishOverIP || len(record) < 86 || length < 86 {
+			continue
+		}
+		// service UUID (16), host IP assignment type, host address format, host IP (16), host mask (16),
+		// service IP discovery type, service address format, service IP (16), service mask (16), port (2)
+		var ip net.IP
+		switch record[51] {
+		case smbiosAddressFormatIPv4:
+			ip = net.IP(bytes.Clone(record[52:56]))
+		case smbiosAddressFormatIPv6:
+			ip = net.IP(bytes.Clone(record[52:68]))
+		default:
+			continue
+		}
+		if ip.IsUnspecified() {
+			continue
+		}
+		port := int(binary.LittleEndian.Uint16(record[84:86]))
+		if port == 0 {
+			port = 443
+		}
+		return HostInterface{IP: ip, Port: port}, true
+	}
+	return HostInterface{}, false
+}
+
+// parseBootstrapCredentials parses the response of the Get Manager Bootstrap Account Credentials command.
+func parseBootstrapCredentials(response []byte) (string, string, error) {
+	if len(response) < 1 || response[0] != 0 {
+		if len(response) > 0 {
+			return "", "", fmt.Errorf("bootstrap credentials request failed with completion code %#x", response[0])
+		}
+		return "", "", errors.New("empty bootstrap credentials response")
+	}
+	// completion code, group extension, user name (16), password (16)
+	if len(response) < 34 || response[1] != ipmiGroupExtensionRedfish {
+		return "", "", errors.New("invalid bootstrap credentials response")
+	}
+	username := string(bytes.TrimRight(response[2:18], "\x00"))
+	password := string(bytes.TrimRight(response[18:34], "\x00"))
+	return username, password, nil
+}
+
+// bootstrapBMCCredentials sets a new password for the BMCAccount through the Redfish host interface and hands the
+// credentials to the registry, from where the metal-operator updates the BMCSecret of the BMC. The BMC is accessed
+// with the bootstrap credentials, which the host obtains from the BMC through IPMI.
+func (a *Agent) bootstrapBMCCredentials(ctx context.Context) error {
+	hostInterface, err := readHostInterface()
+	if err != nil {
+		return err
+	}
+	response, err := ipmiRequest(ipmiDevice, ipmiNetFnGroupExtension, ipmiCmdGetBootstrapCredentials,
+		[]byte{ipmiGroupExtensionRedfish, ipmiKeepCredentialBootstrapping})
+	if err != nil {
+		return fmt.Errorf("failed to request bootstrap credentials: %w", err)
+	}
+	username, password, err := parseBootstrapCredentials(response)
+	if err != nil {
+		return err
+	}
+
+	bmcClient, err := bmc.NewRedfishBMCClient(ctx, bmc.BMCOptions{
+		Endpoint:  hostInterface.Endpoint(),
+		Username:  username,
+		Password:  password,
+		BasicAuth: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Redfish host interface: %w", err)
+	}
+	defer bmcClient.Logout()
+	manager, err := bmcClient.GetManager()
+	if err != nil {
+		return fmt.Errorf("failed to get manager: %w", err)
+	}
+	if manager == nil || manager.MACAddress == "" {
+		return errors.New("failed to determine the MAC address of the BMC")
+	}
+	newPassword, err := bmc.PasswordPolicyForManufacturer(manager.Manufacturer).GeneratePassword()
+	if err != nil {
+		return err
+	}
+	if err := bmcClient.SetAccountPassword(ctx, a.BMCAccount, newPassword); err != nil {
+		return err
+	}
+	log.Printf("Set the password of BMC account %s through the Redfish host interface.", a.BMCAccount)
+
+	return a.postBMCCredentials(ctx, registry.BMCCredentialsPayload{
+		SystemUUID: a.SystemUUID,
+		MACAddress: manager.MACAddress,
+		Username:   a.BMCAccount,
+		Password:   newPassword,
+	})
+}
+
+func (a *Agent) postBMCCredentials(ctx context.Context, payload registry.BMCCredentialsPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal BMC credentials: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.RegistryURL+"/bmc-credentials", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create BMC credentials request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+a.RegistryToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post BMC credentials: %w", err)
+	}
+	defer resp.Body.Close() // nolint: errcheck
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to post BMC credentials: %s", resp.Status)
+	}
+	return nil
+}
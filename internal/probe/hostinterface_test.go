@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package probe
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Redfish Host Interface", func() {
+	It("should parse the Redfish over IP protocol record of SMBIOS type 42", func() {
+		record := make([]byte, 91)
+		record[51] = smbiosAddressFormatIPv4
+		copy(record[52:], []byte{169, 254, 0, 17})
+		record[84], record[85] = 0xbb, 0x01 // 443
+		// header, network host interface type, 2 bytes of interface specific data, one protocol record
+		data := append([]byte{42, 0, 0, 0, 0x40, 2, 0xaa, 0xbb, 1, smbiosProtocolRedfishOverIP, byte(len(record))}, record...)
+
+		hostInterface, ok := parseHostInterface(data)
+		Expect(ok).To(BeTrue())
+		Expect(hostInterface.IP.Equal(net.IPv4(169, 254, 0, 17))).To(BeTrue())
+		Expect(hostInterface.Endpoint()).To(Equal("https://169.254.0.17:443"))
+	})
+
+	It("should skip SMBIOS type 42 entries without Redfish over IP protocol record", func() {
+		_, ok := parseHostInterface([]byte{42, 0, 0, 0, 0x40, 0, 1, 0x02, 0})
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should parse the bootstrap credentials", func() {
+		response := make([]byte, 34)
+		response[1] = ipmiGroupExtensionRedfish
+		copy(response[2:], "bootstrap")
+		copy(response[18:], "0123456789abcdef")
+
+		username, password, err := parseBootstrapCredentials(response)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(username).To(Equal("bootstrap"))
+		Expect(password).To(Equal("0123456789abcdef"))
+	})
+
+	It("should fail if the BMC rejects the bootstrap credentials request", func() {
+		_, _, err := parseBootstrapCredentials([]byte{0x80, ipmiGroupExtensionRedfish})
+		Expect(err).To(MatchError(ContainSubstring("completion code 0x80")))
+	})
+})
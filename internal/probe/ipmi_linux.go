@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package probe
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// The structures and ioctls of the Linux IPMI driver, see include/uapi/linux/ipmi.h.
+const (
+	ipmiSystemInterfaceAddrType = 0x0c
+	ipmiBMCChannel              = 0x0f
+	ipmiResponseTimeout         = 5 * time.Second
+)
+
+type ipmiSystemInterfaceAddr struct {
+	addrType int32
+	channel  int16
+	lun      uint8
+}
+
+type ipmiMsg struct {
+	netfn   uint8
+	cmd     uint8
+	dataLen uint16
+	data    *byte
+}
+
+type ipmiReq struct {
+	addr    *ipmiSystemInterfaceAddr
+	addrLen uint32
+	msgid   int64
+	msg     ipmiMsg
+}
+
+type ipmiRecv struct {
+	recvType int32
+	addr     *ipmiSystemInterfaceAddr
+	addrLen  uint32
+	msgid    int64
+	msg      ipmiMsg
+}
+
+var (
+	ipmictlSendCommand     = ioctlNumber(2, 13, unsafe.Sizeof(ipmiReq{}))
+	ipmictlReceiveMsgTrunc = ioctlNumber(3, 11, unsafe.Sizeof(ipmiRecv{}))
+)
+
+// ioctlNumber returns the number of an ioctl of the IPMI driver ('i') with the direction (1 write, 2 read).
+func ioctlNumber(dir, nr, size uintptr) uintptr {
+	return dir<<30 | size<<16 | 'i'<<8 | nr
+}
+
+// ipmiRequest sends an IPMI command to the BMC through the system interface and returns the response data,
+// starting with the completion code.
+func ipmiRequest(device string, netfn, cmd uint8, data []byte) ([]byte, error) {
+	file, err := os.OpenFile(device, os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open IPMI device: %w", err)
+	}
+	defer file.Close() // nolint: errcheck
+
+	addr := &ipmiSystemInterfaceAddr{addrType: ipmiSystemInterfaceAddrType, channel: ipmiBMCChannel}
+	request := &ipmiReq{
+		addr:    addr,
+		addrLen: uint32(unsafe.Sizeof(*addr)),
+		msgid:   1,
+		msg:     ipmiMsg{netfn: netfn, cmd: cmd, dataLen: uint16(len(data))},
+	}
+	if len(data) > 0 {
+		request.msg.data = &data[0]
+	}
+	if err := ioctl(file.Fd(), ipmictlSendCommand, unsafe.Pointer(request)); err != nil {
+		return nil, fmt.Errorf("failed to send IPMI command: %w", err)
+	}
+	runtime.KeepAlive(request)
+	runtime.KeepAlive(data)
+
+	buffer := make([]byte, 1024)
+	recvAddr := &ipmiSystemInterfaceAddr{}
+	response := &ipmiRecv{
+		addr:    recvAddr,
+		addrLen: uint32(unsafe.Sizeof(*recvAddr)),
+		msg:     ipmiMsg{dataLen: uint16(len(buffer)), data: &buffer[0]},
+	}
+	deadline := time.Now().Add(ipmiResponseTimeout)
+	for {
+		err := ioctl(file.Fd(), ipmictlReceiveMsgTrunc, unsafe.Pointer(response))
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, syscall.EAGAIN) || time.Now().After(deadline) {
+			return nil, fmt.Errorf("failed to receive IPMI response: %w", err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	runtime.KeepAlive(response)
+	return buffer[:response.msg.dataLen], nil
+}
+
+func ioctl(fd, request uintptr, arg unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, request, uintptr(arg)); errno != 0 {
+		return errno
+	}
+	return nil
+}
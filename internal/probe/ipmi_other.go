@@ -0,0 +1,13 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux
+
+package probe
+
+import "errors"
+
+// ipmiRequest is only supported through the IPMI driver of Linux.
+func ipmiRequest(string, uint8, uint8, []byte) ([]byte, error) {
+	return nil, errors.New("IPMI requests are only supported on Linux")
+}
@@ -5,6 +5,7 @@ package probe_test
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"testing"
 	"time"
@@ -26,6 +27,15 @@ var (
 	systemUUID   = "1234-5678"
 )
 
+// fpgaCollector is a collector compiled into the probe agent.
+type fpgaCollector struct{}
+
+func (fpgaCollector) Name() string { return "fpga" }
+
+func (fpgaCollector) Collect(context.Context) (json.RawMessage, error) {
+	return json.RawMessage(`{ "model": "x1" }`), nil
+}
+
 func TestRegistry(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Registry Suite")
@@ -38,7 +48,7 @@ var _ = BeforeSuite(func() {
 	DeferCleanup(cancel)
 
 	// Initialize the registry
-	registryServer = registry.NewServer(registryAddr)
+	registryServer = registry.NewServer(registryAddr, []byte("probe-test"))
 	go func() {
 		defer GinkgoRecover()
 		Expect(registryServer.Start(ctx)).To(Succeed(), "failed to start registry agent")
@@ -51,6 +61,7 @@ var _ = BeforeSuite(func() {
 
 	// Initialize your probe server
 	probeAgent = probe.NewAgent(systemUUID, registryURL, 100*time.Millisecond)
+	probeAgent.Collectors = []probe.Collector{fpgaCollector{}}
 	go func() {
 		defer GinkgoRecover()
 		Expect(probeAgent.Start(ctx)).To(Succeed(), "failed to start probe agent")
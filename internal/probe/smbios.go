@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package probe
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ironcore-dev/metal-operator/internal/api/registry"
+)
+
+const (
+	// smbiosTable is the raw SMBIOS structure table exported by the kernel.
+	smbiosTable = "/sys/firmware/dmi/tables/DMI"
+
+	smbiosTypeSystem      = 1
+	smbiosTypeBaseboard   = 2
+	smbiosTypeChassis     = 3
+	smbiosTypeSystemSlots = 9
+	smbiosTypeEndOfTable  = 127
+
+	smbiosSlotUsageInUse = 0x04
+)
+
+// smbiosStructure is a structure of the SMBIOS table with its formatted area and its strings.
+type smbiosStructure struct {
+	formatted []byte
+	strings   []string
+}
+
+// byte returns the byte at the offset of the formatted area, or 0 if the structure is too short.
+func (s smbiosStructure) byte(offset int) byte {
+	if offset >= len(s.formatted) {
+		return 0
+	}
+	return s.formatted[offset]
+}
+
+// string returns the string referenced by the byte at the offset of the formatted area.
+func (s smbiosStructure) string(offset int) string {
+	index := int(s.byte(offset))
+	if index == 0 || index > len(s.strings) {
+		return ""
+	}
+	return strings.TrimSpace(s.strings[index-1])
+}
+
+// parseSMBIOSStructures splits the SMBIOS table into its structures.
+func parseSMBIOSStructures(table []byte) []smbiosStructure {
+	var structures []smbiosStructure
+	for len(table) >= 4 {
+		length := int(table[1])
+		if length < 4 || length > len(table) {
+			break
+		}
+		structure := smbiosStructure{formatted: table[:length]}
+		// the string set is terminated by two null bytes
+		end := bytes.Index(table[length:], []byte{0, 0})
+		if end < 0 {
+			break
+		}
+		if end > 0 {
+			structure.strings = strings.Split(string(table[length:length+end]), "\x00")
+		}
+		structures = append(structures, structure)
+		if table[0] == smbiosTypeEndOfTable {
+			break
+		}
+		table = table[length+end+2:]
+	}
+	return structures
+}
+
+// parseSMBIOS parses the identifying subset of the SMBIOS table.
+func parseSMBIOS(table []byte) *registry.SMBIOS {
+	result := &registry.SMBIOS{}
+	var baseboard, chassis bool
+	for _, s := range parseSMBIOSStructures(table) {
+		switch s.byte(0) {
+		case smbiosTypeSystem:
+			result.System = registry.SMBIOSSystem{
+				Manufacturer: s.string(4),
+				ProductName:  s.string(5),
+				SerialNumber: s.string(7),
+			}
+			if len(s.formatted) >= 24 {
+				result.System.UUID = smbiosUUID(s.formatted[8:24])
+			}
+		case smbiosTypeBaseboard:
+			if baseboard {
+				continue
+			}
+			baseboard = true
+			result.Baseboard = registry.SMBIOSBaseboard{
+				Manufacturer: s.string(4),
+				Product:      s.string(5),
+				SerialNumber: s.string(7),
+			}
+		case smbiosTypeChassis:
+			if chassis {
+				continue
+			}
+			chassis = true
+			result.Chassis = registry.SMBIOSChassis{
+				Manufacturer: s.string(4),
+				SerialNumber: s.string(7),
+				AssetTag:     s.string(8),
+			}
+		case smbiosTypeSystemSlots:
+			result.Slots = append(result.Slots, registry.SMBIOSSlot{
+				Designation: s.string(4),
+				InUse:       s.byte(7) == smbiosSlotUsageInUse,
+			})
+		}
+	}
+	return result
+}
+
+// smbiosUUID formats the UUID of the System Information structure. Since SMBIOS 2.6, its first three fields are
+// encoded in little endian. UUIDs of all zeros or all ones are not set.
+func smbiosUUID(data []byte) string {
+	if bytes.Count(data, []byte{0}) == len(data) || bytes.Count(data, []byte{0xff}) == len(data) {
+		return ""
+	}
+	return fmt.Sprintf("%08x-%04x-%04x-%x-%x", binary.LittleEndian.Uint32(data[0:4]),
+		binary.LittleEndian.Uint16(data[4:6]), binary.LittleEndian.Uint16(data[6:8]), data[8:10], data[10:16])
+}
+
+// uploadSMBIOSTable uploads the gzip compressed raw SMBIOS table to the registry, where it is kept for debugging,
+// e.g. of system UUIDs differing between the host and the BMC.
+func (a *Agent) uploadSMBIOSTable(ctx context.Context, table []byte) error {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(table); err != nil {
+		return fmt.Errorf("failed to compress SMBIOS table: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to compress SMBIOS table: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, fmt.Sprintf("%s/smbios/%s", a.RegistryURL, a.SystemUUID), &compressed)
+	if err != nil {
+		return fmt.Errorf("failed to create SMBIOS table request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/gzip")
+	req.Header.Set("Authorization", "Bearer "+a.RegistryToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload SMBIOS table: %w", err)
+	}
+	defer resp.Body.Close() // nolint: errcheck
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to upload SMBIOS table: %s", resp.Status)
+	}
+	return nil
+}
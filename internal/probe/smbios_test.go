@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package probe
+
+import (
+	"github.com/ironcore-dev/metal-operator/internal/api/registry"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SMBIOS", func() {
+	It("should parse the system, baseboard, chassis and slots of the SMBIOS table", func() {
+		var table []byte
+		// system information with the UUID 38947555-7742-3448-3784-823347823834
+		system := []byte{smbiosTypeSystem, 27, 0, 1, 1, 2, 0, 3}
+		system = append(system, 0x55, 0x75, 0x94, 0x38, 0x42, 0x77, 0x48, 0x34,
+			0x37, 0x84, 0x82, 0x33, 0x47, 0x82, 0x38, 0x34)
+		system = append(system, 0, 0, 0)
+		table = append(table, system...)
+		table = append(table, "Contoso\x00Server 1\x00SYS123\x00\x00"...)
+		table = append(table, smbiosTypeBaseboard, 8, 0, 2, 1, 2, 0, 3)
+		table = append(table, "Contoso\x00Board\x00MB456\x00\x00"...)
+		table = append(table, smbiosTypeChassis, 9, 0, 3, 1, 0, 0, 2, 3)
+		table = append(table, "Contoso\x00CH789\x00ASSET-1\x00\x00"...)
+		table = append(table, smbiosTypeSystemSlots, 8, 0, 4, 1, 0xa5, 0x0d, smbiosSlotUsageInUse)
+		table = append(table, "PCIe Slot 1\x00\x00"...)
+		table = append(table, smbiosTypeSystemSlots, 8, 0, 5, 1, 0xa5, 0x0d, 0x03)
+		table = append(table, "PCIe Slot 2\x00\x00"...)
+		table = append(table, smbiosTypeEndOfTable, 4, 0, 6, 0, 0)
+
+		Expect(parseSMBIOS(table)).To(Equal(&registry.SMBIOS{
+			System: registry.SMBIOSSystem{
+				Manufacturer: "Contoso",
+				ProductName:  "Server 1",
+				SerialNumber: "SYS123",
+				UUID:         "38947555-7742-3448-3784-823347823834",
+			},
+			Baseboard: registry.SMBIOSBaseboard{Manufacturer: "Contoso", Product: "Board", SerialNumber: "MB456"},
+			Chassis:   registry.SMBIOSChassis{Manufacturer: "Contoso", SerialNumber: "CH789", AssetTag: "ASSET-1"},
+			Slots: []registry.SMBIOSSlot{
+				{Designation: "PCIe Slot 1", InUse: true},
+				{Designation: "PCIe Slot 2", InUse: false},
+			},
+		}))
+	})
+})
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package redact
+
+import (
+	"github.com/go-logr/logr"
+)
+
+// Logger returns a logger which redacts the messages, errors and key-value pairs before passing them to the given
+// logger. The values of sensitive keys and the sensitive entries of string maps are masked.
+func (r *Redactor) Logger(logger logr.Logger) logr.Logger {
+	sink := logger.GetSink()
+	if sink == nil {
+		return logger
+	}
+	return logger.WithSink(&logSink{sink: sink, redactor: r})
+}
+
+type logSink struct {
+	sink     logr.LogSink
+	redactor *Redactor
+}
+
+func (s *logSink) Init(info logr.RuntimeInfo) {
+	// the redacting sink adds a frame to the call stack
+	info.CallDepth++
+	s.sink.Init(info)
+}
+
+func (s *logSink) Enabled(level int) bool {
+	return s.sink.Enabled(level)
+}
+
+func (s *logSink) Info(level int, msg string, keysAndValues ...any) {
+	s.sink.Info(level, s.redactor.Message(msg), s.keysAndValues(keysAndValues)...)
+}
+
+func (s *logSink) Error(err error, msg string, keysAndValues ...any) {
+	s.sink.Error(s.redactor.Error(err), s.redactor.Message(msg), s.keysAndValues(keysAndValues)...)
+}
+
+func (s *logSink) WithValues(keysAndValues ...any) logr.LogSink {
+	return &logSink{sink: s.sink.WithValues(s.keysAndValues(keysAndValues)...), redactor: s.redactor}
+}
+
+func (s *logSink) WithName(name string) logr.LogSink {
+	return &logSink{sink: s.sink.WithName(name), redactor: s.redactor}
+}
+
+// keysAndValues returns a copy of the key-value pairs with the sensitive values masked.
+func (s *logSink) keysAndValues(keysAndValues []any) []any {
+	redacted := make([]any, len(keysAndValues))
+	copy(redacted, keysAndValues)
+	for i := 1; i < len(redacted); i += 2 {
+		if key, ok := redacted[i-1].(string); ok && s.redactor.Sensitive(key) {
+			redacted[i] = Mask
+			continue
+		}
+		switch value := redacted[i].(type) {
+		case map[string]string:
+			redacted[i] = s.redactor.Map(value)
+		case error:
+			redacted[i] = s.redactor.Error(value)
+		case string:
+			redacted[i] = s.redactor.Message(value)
+		}
+	}
+	return redacted
+}
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package redact masks the values of sensitive keys, e.g. BIOS passwords, before they are written to the status of
+// resources, to conditions or to logs.
+package redact
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Mask replaces redacted values.
+const Mask = "<redacted>"
+
+// DefaultKeyPatterns are the patterns of the keys whose values are redacted if none are configured.
+var DefaultKeyPatterns = []string{`(?i)passw(or)?d`, `(?i)secret`, `(?i)token`, `(?i)credential`, `(?i)private.?key`}
+
+// Default redacts the values of the keys matching the DefaultKeyPatterns.
+var Default = MustNew(DefaultKeyPatterns)
+
+// messageValuePattern matches the assignments of values to keys in messages, e.g. "AdminPassword=foo",
+// "AdminPassword: foo" or "attribute AdminPassword value foo".
+var messageValuePattern = regexp.MustCompile(`([\w.-]+)("?\s*[=:]\s*|\s+value\s+)("(?:[^"\\]|\\.)*"|[^\s,;]+)`)
+
+// Redactor masks the values of the keys matching any of its patterns. A nil Redactor behaves like Default.
+type Redactor struct {
+	patterns []*regexp.Regexp
+}
+
+// New returns a Redactor for the given regular expressions of sensitive keys.
+func New(patterns []string) (*Redactor, error) {
+	r := &Redactor{}
+	for _, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile redaction pattern %q: %w", pattern, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// MustNew is like New, but panics if a pattern does not compile.
+func MustNew(patterns []string) *Redactor {
+	r, err := New(patterns)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Sensitive reports whether the values of the key are redacted.
+func (r *Redactor) Sensitive(key string) bool {
+	if r == nil {
+		r = Default
+	}
+	for _, pattern := range r.patterns {
+		if pattern.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Value returns the value, or Mask if the key is sensitive.
+func (r *Redactor) Value(key, value string) string {
+	if r.Sensitive(key) {
+		return Mask
+	}
+	return value
+}
+
+// Map returns a copy of the map with the values of sensitive keys masked.
+func (r *Redactor) Map(values map[string]string) map[string]string {
+	if values == nil {
+		return nil
+	}
+	redacted := make(map[string]string, len(values))
+	for key, value := range values {
+		redacted[key] = r.Value(key, value)
+	}
+	return redacted
+}
+
+// Message masks the values assigned to sensitive keys in the message.
+func (r *Redactor) Message(message string) string {
+	return messageValuePattern.ReplaceAllStringFunc(message, func(match string) string {
+		groups := messageValuePattern.FindStringSubmatch(match)
+		if !r.Sensitive(strings.Trim(groups[1], `"`)) {
+			return match
+		}
+		return groups[1] + groups[2] + Mask
+	})
+}
+
+// Error returns an error with the message of err redacted, which still wraps err. It returns nil for a nil err.
+func (r *Redactor) Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &redactedError{err: err, message: r.Message(err.Error())}
+}
+
+type redactedError struct {
+	err     error
+	message string
+}
+
+func (e *redactedError) Error() string {
+	return e.message
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
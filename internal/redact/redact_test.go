@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package redact_test
+
+import (
+	"errors"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/ironcore-dev/metal-operator/internal/redact"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Redactor", func() {
+	It("should mask the values of sensitive keys", func() {
+		Expect(redact.Default.Map(map[string]string{
+			"AdminPassword":  "secret",
+			"HyperThreading": "Enabled",
+		})).To(Equal(map[string]string{
+			"AdminPassword":  redact.Mask,
+			"HyperThreading": "Enabled",
+		}))
+
+		redactor, err := redact.New([]string{`^SetupPwd$`})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(redactor.Value("SetupPwd", "secret")).To(Equal(redact.Mask))
+		Expect(redactor.Value("AdminPassword", "secret")).To(Equal("secret"))
+
+		_, err = redact.New([]string{"("})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should mask the values of sensitive keys in messages and errors", func() {
+		Expect(redact.Default.Message("attribute AdminPassword value hunter2 is not allowed")).
+			To(Equal("attribute AdminPassword value <redacted> is not allowed"))
+		Expect(redact.Default.Message(`AdminPassword=hunter2, BootMode=Uefi, "SetupToken": "abc"`)).
+			To(Equal(`AdminPassword=<redacted>, BootMode=Uefi, "SetupToken": <redacted>`))
+
+		cause := errors.New("failed")
+		err := redact.Default.Error(errors.Join(cause, errors.New("attribute AdminPassword value hunter2 is not allowed")))
+		Expect(err.Error()).NotTo(ContainSubstring("hunter2"))
+		Expect(err).To(MatchError(cause))
+		Expect(redact.Default.Error(nil)).To(Succeed())
+	})
+
+	It("should redact log messages and key-value pairs", func() {
+		var lines []string
+		logger := redact.Default.Logger(funcr.New(func(prefix, args string) {
+			lines = append(lines, args)
+		}, funcr.Options{}))
+
+		logger.WithValues("Token", "abc").Info("Applied BIOS settings",
+			"Settings", map[string]string{"AdminPassword": "hunter2", "BootMode": "Uefi"})
+		logger.Error(errors.New("attribute AdminPassword value hunter2 is not allowed"), "Failed to apply")
+		Expect(lines).To(HaveLen(2))
+		for _, line := range lines {
+			Expect(line).NotTo(ContainSubstring("hunter2"))
+			Expect(line).NotTo(ContainSubstring("abc"))
+		}
+		Expect(lines[0]).To(ContainSubstring("Uefi"))
+	})
+})
@@ -19,6 +19,7 @@ var (
 	server         *registry.Server
 	testServerURL  = "http://localhost:30002"
 	testServerAddr = ":30002"
+	testTokenKey   = []byte("registry-test")
 )
 
 func TestRegistry(t *testing.T) {
@@ -32,7 +33,7 @@ var _ = BeforeSuite(func() {
 	ctx, cancel := context.WithCancel(context.Background())
 	DeferCleanup(cancel)
 
-	server = registry.NewServer(testServerAddr)
+	server = registry.NewServer(testServerAddr, testTokenKey)
 	go func() {
 		defer GinkgoRecover()
 		Expect(server.Start(ctx)).To(Succeed(), "failed to start registry server")
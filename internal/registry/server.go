@@ -4,14 +4,19 @@
 package registry
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/ironcore-dev/metal-operator/internal/api/registry"
 )
@@ -26,15 +31,47 @@ type Server struct {
 	addr         string
 	mux          *http.ServeMux
 	systemsStore *sync.Map
+	// historyStore holds the last consumed discovery of every system by system UUID, see maxHistoryRecords.
+	historyStore *boundedStore
+	// credentialsStore holds the BMC credentials bootstrapped by probe agents by normalized MAC address.
+	credentialsStore *sync.Map
+	// tokenKey is the key the tokens authenticating the probe agents and the manager are derived from, see
+	// SystemToken and ManagerToken.
+	tokenKey []byte
+	// smbiosStore holds the gzip compressed raw SMBIOS tables uploaded by probe agents by system UUID. They are
+	// kept after the system entry has been consumed, for debugging, see maxSMBIOSStoreSize.
+	smbiosStore *boundedStore
+	// timestampStore holds the time of the last change of every system by system UUID, after which systems
+	// without a Server are swept.
+	timestampStore *sync.Map
+
+	watchersMu sync.Mutex
+	// watchers are the channels of the clients watching the systems.
+	watchers map[chan systemChange]struct{}
 }
 
-// NewServer initializes and returns a new Server instance.
-func NewServer(addr string) *Server {
+// maxSMBIOSTableSize is the maximum size of a compressed SMBIOS table accepted by the registry.
+const maxSMBIOSTableSize = 1 << 20
+
+// maxBMCCredentialsSize is the maximum size of the BMC credentials posted to the registry.
+const maxBMCCredentialsSize = 64 << 10
+
+// NewServer initializes and returns a new Server instance. The tokens authenticating the requests for bootstrapped
+// BMC credentials are derived from the tokenKey.
+func NewServer(addr string, tokenKey []byte) *Server {
 	mux := http.NewServeMux()
 	server := &Server{
-		addr:         addr,
-		mux:          mux,
-		systemsStore: &sync.Map{},
+		addr:             addr,
+		mux:              mux,
+		systemsStore:     &sync.Map{},
+		historyStore:     newBoundedStore("discovery record", maxHistoryRecords, func(any) int { return 1 }),
+		credentialsStore: &sync.Map{},
+		tokenKey:         tokenKey,
+		smbiosStore: newBoundedStore("SMBIOS table", maxSMBIOSStoreSize, func(value any) int {
+			return len(value.([]byte))
+		}),
+		timestampStore: &sync.Map{},
+		watchers:       map[chan systemChange]struct{}{},
 	}
 	server.routes()
 	return server
@@ -44,7 +81,12 @@ func NewServer(addr string) *Server {
 func (s *Server) routes() {
 	s.mux.HandleFunc("/register", s.registerHandler)
 	s.mux.HandleFunc("/delete/", s.deleteHandler)
+	s.mux.HandleFunc("/systems", s.listSystemsHandler)
 	s.mux.HandleFunc("/systems/", s.systemsHandler)
+	s.mux.HandleFunc("/history/", s.historyHandler)
+	s.mux.HandleFunc("/bmc-credentials", s.postCredentialsHandler)
+	s.mux.HandleFunc("/bmc-credentials/", s.credentialsHandler)
+	s.mux.HandleFunc("/smbios/", s.smbiosHandler)
 }
 
 // registerHandler handles the /register endpoint.
@@ -61,20 +103,30 @@ func (s *Server) registerHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Store the registration information.
+	previous, existed := s.systemEntryFor(reg.SystemUUID)
 	s.systemsStore.Store(reg.SystemUUID, reg.Data)
+	s.timestampStore.Store(reg.SystemUUID, time.Now())
 	log.Printf("Registered system UUID: %s\n", reg.SystemUUID)
+	s.publish(reg.SystemUUID, previous, existed)
 	w.WriteHeader(http.StatusCreated)
 }
 
 // systemsHandler handles the /systems/{uuid} endpoint.
 func (s *Server) systemsHandler(w http.ResponseWriter, r *http.Request) {
+	uuid := r.URL.Path[len("/systems/"):]
+	if uuid == "" {
+		s.listSystemsHandler(w, r)
+		return
+	}
+	if r.Method == http.MethodDelete {
+		s.discardHandler(w, r, uuid)
+		return
+	}
 	if r.Method != http.MethodGet {
-		http.Error(w, "Only GET method is allowed", http.StatusMethodNotAllowed)
+		http.Error(w, "Only GET and DELETE methods are allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	uuid := r.URL.Path[len("/systems/"):]
-
 	if value, ok := s.systemsStore.Load(uuid); ok {
 		server, ok := value.(registry.Server)
 		if !ok {
@@ -95,6 +147,144 @@ func (s *Server) systemsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// historyHandler handles the /history/{uuid} endpoint returning the last successful discovery of a system.
+func (s *Server) historyHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET method is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	uuid := r.URL.Path[len("/history/"):]
+
+	value, ok := s.historyStore.Load(uuid)
+	if !ok {
+		log.Printf("No discovery history for system UUID: %s\n", uuid)
+		http.NotFound(w, r)
+		return
+	}
+	record, ok := value.(registry.DiscoveryRecord)
+	if !ok {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		log.Println("Error asserting type of discovery record")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(record); err != nil {
+		log.Printf("Failed to encode result: %v\n", err)
+		http.Error(w, "Failed to encode result", http.StatusInternalServerError)
+	}
+}
+
+// postCredentialsHandler handles the /bmc-credentials endpoint, to which probe agents post the BMC credentials
+// they have set through the Redfish host interface. Requests are authenticated with the token of the system.
+func (s *Server) postCredentialsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST method is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var credentials registry.BMCCredentialsPayload
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBMCCredentialsSize)).Decode(&credentials); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if credentials.SystemUUID == "" || !hasToken(r, SystemToken(s.tokenKey, credentials.SystemUUID)) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	mac, err := net.ParseMAC(credentials.MACAddress)
+	if err != nil || credentials.Username == "" || credentials.Password == "" {
+		http.Error(w, "Invalid BMC credentials", http.StatusBadRequest)
+		return
+	}
+
+	s.credentialsStore.Store(mac.String(), credentials)
+	log.Printf("Stored bootstrapped credentials of BMC %s of system UUID: %s\n", mac, credentials.SystemUUID)
+	w.WriteHeader(http.StatusCreated)
+}
+
+// credentialsHandler handles the /bmc-credentials/{mac} endpoint, from where the manager gets the bootstrapped
+// credentials of a BMC. The manager deletes them once it has stored them in the BMCSecret, so that they do not
+// stay in the registry, but are not lost if storing them fails. Requests are authenticated with the token of the
+// manager.
+func (s *Server) credentialsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodDelete {
+		http.Error(w, "Only GET and DELETE methods are allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !hasToken(r, ManagerToken(s.tokenKey)) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	mac, err := net.ParseMAC(r.URL.Path[len("/bmc-credentials/"):])
+	if err != nil {
+		http.Error(w, "Invalid MAC address", http.StatusBadRequest)
+		return
+	}
+	if r.Method == http.MethodDelete {
+		s.credentialsStore.Delete(mac.String())
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	value, ok := s.credentialsStore.Load(mac.String())
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		log.Printf("Failed to encode result: %v\n", err)
+		http.Error(w, "Failed to encode result", http.StatusInternalServerError)
+	}
+}
+
+// smbiosHandler handles the /smbios/{uuid} endpoint, to which probe agents upload the gzip compressed raw SMBIOS
+// table of their system and from where it can be retrieved. Uploads are authenticated with the token of the system.
+func (s *Server) smbiosHandler(w http.ResponseWriter, r *http.Request) {
+	uuid := r.URL.Path[len("/smbios/"):]
+	if uuid == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		if !hasToken(r, SystemToken(s.tokenKey, uuid)) {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSMBIOSTableSize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		if _, err := gzip.NewReader(bytes.NewReader(data)); err != nil {
+			http.Error(w, "SMBIOS table is not gzip compressed", http.StatusBadRequest)
+			return
+		}
+		s.smbiosStore.Store(uuid, data)
+		s.timestampStore.Store(uuid, time.Now())
+		log.Printf("Stored SMBIOS table of system UUID: %s\n", uuid)
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodGet:
+		value, ok := s.smbiosStore.Load(uuid)
+		if !ok {
+			log.Printf("No SMBIOS table for system UUID: %s\n", uuid)
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/gzip")
+		if _, err := w.Write(value.([]byte)); err != nil {
+			log.Printf("Failed to write SMBIOS table: %v\n", err)
+		}
+	default:
+		http.Error(w, "Only GET and PUT methods are allowed", http.StatusMethodNotAllowed)
+	}
+}
+
 // deleteHandler handles the DELETE requests to remove a system by UUID.
 func (s *Server) deleteHandler(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Received method: %s", r.Method)   // This will log the method of the request
@@ -108,18 +298,66 @@ func (s *Server) deleteHandler(w http.ResponseWriter, r *http.Request) {
 	uuid := r.URL.Path[len("/delete/"):] // Assuming the URL is like /delete/{uuid}
 
 	// Attempt to delete the entry from the store
-	if _, ok := s.systemsStore.Load(uuid); !ok {
+	value, ok := s.systemsStore.Load(uuid)
+	if !ok {
 		http.NotFound(w, r)
 		return
 	}
 
+	previous, existed := s.systemEntryFor(uuid)
 	s.systemsStore.Delete(uuid) // Perform the deletion
 
+	// Entries are deleted once they have been consumed, so keep the payload as the last successful discovery.
+	var evicted map[string]any
+	if server, ok := value.(registry.Server); ok {
+		evicted = s.historyStore.Store(uuid, registry.DiscoveryRecord{
+			SystemUUID: uuid,
+			Timestamp:  time.Now(),
+			Data:       server,
+		})
+		s.timestampStore.Store(uuid, time.Now())
+	}
+	s.publish(uuid, previous, existed)
+	for evictedUUID, record := range evicted {
+		s.publishEvictedRecord(evictedUUID, record)
+	}
+
 	// Respond with success message
 	w.WriteHeader(http.StatusOK)
 	log.Printf("System with UUID %s deleted successfully", uuid)
 }
 
+// discardHandler handles the DELETE requests to the /systems/{uuid} endpoint. Other than deleteHandler, it discards
+// the registered system without keeping it as the last discovery, e.g. as it is stale. With the purge query
+// parameter, the history and the SMBIOS table of the system are removed as well. Requests are authenticated with the
+// token of the manager.
+func (s *Server) discardHandler(w http.ResponseWriter, r *http.Request, uuid string) {
+	if !hasToken(r, ManagerToken(s.tokenKey)) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if r.URL.Query().Get("purge") == "true" {
+		s.purge(uuid)
+		log.Printf("Purged system UUID: %s\n", uuid)
+	} else {
+		previous, existed := s.systemEntryFor(uuid)
+		s.systemsStore.Delete(uuid)
+		s.publish(uuid, previous, existed)
+		log.Printf("Discarded system UUID: %s\n", uuid)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// purge removes the system and everything kept about it from the registry.
+func (s *Server) purge(uuid string) {
+	previous, existed := s.systemEntryFor(uuid)
+	s.systemsStore.Delete(uuid)
+	s.historyStore.Delete(uuid)
+	s.smbiosStore.Delete(uuid)
+	s.timestampStore.Delete(uuid)
+	s.publish(uuid, previous, existed)
+}
+
 // Start starts the server on the specified address and adds logging for key events.
 func (s *Server) Start(ctx context.Context) error {
 	log.Printf("Starting registry server on port %s\n", s.addr)
@@ -4,12 +4,16 @@
 package registry_test
 
 import (
+	"bufio"
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/ironcore-dev/metal-operator/internal/api/registry"
+	registryserver "github.com/ironcore-dev/metal-operator/internal/registry"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -67,5 +71,262 @@ var _ = Describe("RegistryServer", func() {
 		response, err = http.Get(fmt.Sprintf("%s/systems/%s", testServerURL, systemRegistrationPayload.SystemUUID))
 		Expect(err).NotTo(HaveOccurred())
 		Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+
+		By("Ensuring that the last discovery is kept in the history")
+		response, err = http.Get(fmt.Sprintf("%s/history/%s", testServerURL, systemRegistrationPayload.SystemUUID))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		record := &registry.DiscoveryRecord{}
+		Expect(json.NewDecoder(response.Body).Decode(record)).NotTo(HaveOccurred())
+		Expect(record.SystemUUID).To(Equal(systemRegistrationPayload.SystemUUID))
+		Expect(record.Timestamp).NotTo(BeZero())
+		Expect(record.Data).To(Equal(systemRegistrationPayload.Data))
+	})
+
+	It("should not return a history for an unknown system", func() {
+		response, err := http.Get(fmt.Sprintf("%s/history/%s", testServerURL, "unknown-uuid"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+	})
+	It("should hand out bootstrapped BMC credentials to the manager until they are deleted", func() {
+		credentialsRequest := func(method, url, token string, body []byte) *http.Response {
+			request, err := http.NewRequest(method, url, bytes.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+			if token != "" {
+				request.Header.Set("Authorization", "Bearer "+token)
+			}
+			response, err := http.DefaultClient.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(response.Body.Close)
+			return response
+		}
+		payload, err := json.Marshal(registry.BMCCredentialsPayload{
+			SystemUUID: "credentials-uuid",
+			MACAddress: "00:1A:2B:3C:4D:5E",
+			Username:   "metal",
+			Password:   "secret",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		credentialsURL := fmt.Sprintf("%s/bmc-credentials", testServerURL)
+		managerToken := registryserver.ManagerToken(testTokenKey)
+
+		By("rejecting credentials without the token of the system")
+		Expect(credentialsRequest(http.MethodPost, credentialsURL, "", payload).StatusCode).
+			To(Equal(http.StatusUnauthorized))
+		Expect(credentialsRequest(http.MethodPost, credentialsURL, registryserver.SystemToken(testTokenKey, "other-uuid"), payload).StatusCode).
+			To(Equal(http.StatusUnauthorized))
+
+		By("posting the credentials with the token of the system")
+		Expect(credentialsRequest(http.MethodPost, credentialsURL, registryserver.SystemToken(testTokenKey, "credentials-uuid"), payload).StatusCode).
+			To(Equal(http.StatusCreated))
+
+		By("rejecting requests for the credentials without the token of the manager")
+		Expect(credentialsRequest(http.MethodGet, credentialsURL+"/00-1a-2b-3c-4d-5e", "", nil).StatusCode).
+			To(Equal(http.StatusUnauthorized))
+		Expect(credentialsRequest(http.MethodGet, credentialsURL+"/00-1a-2b-3c-4d-5e", registryserver.SystemToken(testTokenKey, "credentials-uuid"), nil).StatusCode).
+			To(Equal(http.StatusUnauthorized))
+
+		By("getting the credentials by the MAC address of the BMC until they are deleted")
+		for range 2 {
+			resp := credentialsRequest(http.MethodGet, credentialsURL+"/00-1a-2b-3c-4d-5e", managerToken, nil)
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			credentials := registry.BMCCredentialsPayload{}
+			Expect(json.NewDecoder(resp.Body).Decode(&credentials)).To(Succeed())
+			Expect(credentials.SystemUUID).To(Equal("credentials-uuid"))
+			Expect(credentials.Username).To(Equal("metal"))
+			Expect(credentials.Password).To(Equal("secret"))
+		}
+		Expect(credentialsRequest(http.MethodDelete, credentialsURL+"/00:1a:2b:3c:4d:5e", managerToken, nil).StatusCode).
+			To(Equal(http.StatusNoContent))
+
+		By("ensuring that the credentials are gone")
+		Expect(credentialsRequest(http.MethodGet, credentialsURL+"/00:1a:2b:3c:4d:5e", managerToken, nil).StatusCode).
+			To(Equal(http.StatusNotFound))
+	})
+
+	It("should keep the uploaded SMBIOS table of a system", func() {
+		By("uploading a compressed SMBIOS table to the /smbios/{uuid} endpoint")
+		var table bytes.Buffer
+		writer := gzip.NewWriter(&table)
+		_, err := writer.Write([]byte{127, 4, 0, 0, 0, 0})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(writer.Close()).To(Succeed())
+		upload := func(uuid, token string, body []byte) *http.Response {
+			request, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/smbios/%s", testServerURL, uuid), bytes.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+			if token != "" {
+				request.Header.Set("Authorization", "Bearer "+token)
+			}
+			response, err := http.DefaultClient.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(response.Body.Close)
+			return response
+		}
+		systemToken := registryserver.SystemToken(testTokenKey, "smbios-uuid")
+		Expect(upload("smbios-uuid", systemToken, table.Bytes()).StatusCode).To(Equal(http.StatusCreated))
+
+		By("retrieving the SMBIOS table")
+		response, err := http.Get(fmt.Sprintf("%s/smbios/%s", testServerURL, "smbios-uuid"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(io.ReadAll(response.Body)).To(Equal(table.Bytes()))
+
+		By("rejecting uncompressed SMBIOS tables")
+		Expect(upload("smbios-uuid", systemToken, []byte{127, 4, 0, 0, 0, 0}).StatusCode).To(Equal(http.StatusBadRequest))
+
+		By("rejecting uploads without the token of the system")
+		Expect(upload("other-uuid", "", table.Bytes()).StatusCode).To(Equal(http.StatusUnauthorized))
+		Expect(upload("other-uuid", systemToken, table.Bytes()).StatusCode).To(Equal(http.StatusUnauthorized))
+		response, err = http.Get(fmt.Sprintf("%s/smbios/%s", testServerURL, "other-uuid"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+	})
+
+	register := func(uuid, manufacturer string) {
+		payload, err := json.Marshal(registry.RegistrationPayload{
+			SystemUUID: uuid,
+			Data: registry.Server{
+				SMBIOS: &registry.SMBIOS{System: registry.SMBIOSSystem{Manufacturer: manufacturer}},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		response, err := http.Post(fmt.Sprintf("%s/register", testServerURL), "application/json", bytes.NewBuffer(payload))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusCreated))
+	}
+
+	listSystems := func(query string) *registry.SystemList {
+		response, err := http.Get(fmt.Sprintf("%s/systems?%s", testServerURL, query))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		list := &registry.SystemList{}
+		Expect(json.NewDecoder(response.Body).Decode(list)).To(Succeed())
+		return list
+	}
+
+	It("should list the systems page by page", func() {
+		By("registering systems of different manufacturers")
+		register("list-uuid-1", "Dell Inc.")
+		register("list-uuid-2", "DELL")
+		register("list-uuid-3", "Dell Inc.")
+		register("list-uuid-4", "HPE")
+
+		By("listing the first page of the Dell systems")
+		list := listSystems("manufacturer=Dell&limit=2")
+		Expect(list.Items).To(HaveLen(2))
+		Expect(list.Items[0].SystemUUID).To(Equal("list-uuid-1"))
+		Expect(list.Items[0].State).To(Equal(registry.SystemStateRegistered))
+		Expect(list.Items[0].Manufacturer).To(Equal("Dell"))
+		Expect(list.Items[1].SystemUUID).To(Equal("list-uuid-2"))
+		Expect(list.Continue).NotTo(BeEmpty())
+
+		By("listing the next page of the Dell systems")
+		list = listSystems("manufacturer=Dell&limit=2&continue=" + list.Continue)
+		Expect(list.Items).To(HaveLen(1))
+		Expect(list.Items[0].SystemUUID).To(Equal("list-uuid-3"))
+		Expect(list.Continue).To(BeEmpty())
+
+		By("consuming a system")
+		request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/delete/%s", testServerURL, "list-uuid-4"), nil)
+		Expect(err).NotTo(HaveOccurred())
+		response, err := http.DefaultClient.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+		By("listing the consumed systems")
+		list = listSystems("manufacturer=Hewlett%20Packard%20Enterprise&state=Consumed")
+		Expect(list.Items).To(HaveLen(1))
+		Expect(list.Items[0].SystemUUID).To(Equal("list-uuid-4"))
+
+		By("rejecting unknown states")
+		response, err = http.Get(fmt.Sprintf("%s/systems?state=Unknown", testServerURL))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should stream the changes of the systems", func() {
+		register("watch-uuid-1", "Lenovo")
+
+		By("watching the registered Lenovo systems")
+		response, err := http.Get(fmt.Sprintf("%s/systems?watch=true&manufacturer=Lenovo&state=Registered", testServerURL))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		DeferCleanup(response.Body.Close)
+		decoder := json.NewDecoder(bufio.NewReader(response.Body))
+		nextEvent := func() registry.SystemEvent {
+			event := registry.SystemEvent{}
+			Expect(decoder.Decode(&event)).To(Succeed())
+			return event
+		}
+
+		By("receiving the existing systems")
+		event := nextEvent()
+		Expect(event.Type).To(Equal(registry.SystemEventAdded))
+		Expect(event.System.SystemUUID).To(Equal("watch-uuid-1"))
+
+		By("receiving newly registered systems")
+		register("watch-uuid-2", "Lenovo Global Technology")
+		event = nextEvent()
+		Expect(event.Type).To(Equal(registry.SystemEventAdded))
+		Expect(event.System.SystemUUID).To(Equal("watch-uuid-2"))
+
+		By("receiving consumed systems as deleted")
+		request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/delete/%s", testServerURL, "watch-uuid-1"), nil)
+		Expect(err).NotTo(HaveOccurred())
+		response, err = http.DefaultClient.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		event = nextEvent()
+		Expect(event.Type).To(Equal(registry.SystemEventDeleted))
+		Expect(event.System.SystemUUID).To(Equal("watch-uuid-1"))
+		Expect(event.System.State).To(Equal(registry.SystemStateRegistered))
+	})
+
+	It("should discard a registered system without keeping it as history", func() {
+		register("discard-uuid", "Dell Inc.")
+
+		By("discarding the system")
+		request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/systems/%s", testServerURL, "discard-uuid"), nil)
+		Expect(err).NotTo(HaveOccurred())
+		request.Header.Set("Authorization", "Bearer "+registryserver.ManagerToken(testTokenKey))
+		response, err := http.DefaultClient.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+		By("ensuring that neither the system nor a history is kept")
+		response, err = http.Get(fmt.Sprintf("%s/systems/%s", testServerURL, "discard-uuid"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+		response, err = http.Get(fmt.Sprintf("%s/history/%s", testServerURL, "discard-uuid"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+	})
+
+	It("should only purge a system on behalf of the manager", func() {
+		register("purge-uuid", "Dell Inc.")
+		purge := func(token string) int {
+			request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/systems/%s?purge=true", testServerURL, "purge-uuid"), nil)
+			Expect(err).NotTo(HaveOccurred())
+			if token != "" {
+				request.Header.Set("Authorization", "Bearer "+token)
+			}
+			response, err := http.DefaultClient.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(response.Body.Close)
+			return response.StatusCode
+		}
+
+		By("rejecting purges without the token of the manager")
+		Expect(purge("")).To(Equal(http.StatusUnauthorized))
+		Expect(purge(registryserver.SystemToken(testTokenKey, "purge-uuid"))).To(Equal(http.StatusUnauthorized))
+		response, err := http.Get(fmt.Sprintf("%s/systems/%s", testServerURL, "purge-uuid"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+		By("purging the system with the token of the manager")
+		Expect(purge(registryserver.ManagerToken(testTokenKey))).To(Equal(http.StatusNoContent))
+		response, err = http.Get(fmt.Sprintf("%s/systems/%s", testServerURL, "purge-uuid"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusNotFound))
 	})
 })
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"log"
+	"sync"
+)
+
+const (
+	// maxHistoryRecords is the maximum number of discovery records kept by the registry.
+	maxHistoryRecords = 10000
+	// maxSMBIOSStoreSize is the maximum total size of the compressed SMBIOS tables kept by the registry.
+	maxSMBIOSStoreSize = 256 << 20
+)
+
+// boundedStore is a store of values by system UUID whose total cost is limited. Storing a value evicts the values
+// stored first until it fits, so that clients cannot exhaust the memory of the registry.
+type boundedStore struct {
+	// name describes the values in the logs.
+	name string
+	// limit is the maximum total cost of the values.
+	limit int
+	// cost returns the cost of a value.
+	cost func(value any) int
+
+	mu      sync.Mutex
+	entries map[string]boundedEntry
+	total   int
+	// sequence orders the entries by the time they were stored.
+	sequence uint64
+}
+
+type boundedEntry struct {
+	value    any
+	cost     int
+	sequence uint64
+}
+
+func newBoundedStore(name string, limit int, cost func(value any) int) *boundedStore {
+	return &boundedStore{name: name, limit: limit, cost: cost, entries: map[string]boundedEntry{}}
+}
+
+// Load returns the value stored for the key.
+func (s *boundedStore) Load(key string) (any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	return entry.value, ok
+}
+
+// Store stores the value for the key, evicting the values stored first if the limit would be exceeded. It returns
+// the evicted values by key.
+func (s *boundedStore) Store(key string, value any) map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.delete(key)
+	entry := boundedEntry{value: value, cost: s.cost(value), sequence: s.sequence}
+	s.sequence++
+	evicted := map[string]any{}
+	for s.total+entry.cost > s.limit && len(s.entries) > 0 {
+		var oldest string
+		first := true
+		for k, e := range s.entries {
+			if first || e.sequence < s.entries[oldest].sequence {
+				oldest, first = k, false
+			}
+		}
+		evicted[oldest] = s.entries[oldest].value
+		s.delete(oldest)
+		log.Printf("Evicted %s of system UUID: %s\n", s.name, oldest)
+	}
+	s.entries[key] = entry
+	s.total += entry.cost
+	return evicted
+}
+
+// Delete removes the value stored for the key.
+func (s *boundedStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.delete(key)
+}
+
+func (s *boundedStore) delete(key string) {
+	if entry, ok := s.entries[key]; ok {
+		s.total -= entry.cost
+		delete(s.entries, key)
+	}
+}
+
+// Range calls f for the keys and values of a snapshot of the store until it returns false.
+func (s *boundedStore) Range(f func(key, value any) bool) {
+	s.mu.Lock()
+	entries := make(map[string]any, len(s.entries))
+	for key, entry := range s.entries {
+		entries[key] = entry.value
+	}
+	s.mu.Unlock()
+	for key, value := range entries {
+		if !f(key, value) {
+			return
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("boundedStore", func() {
+	It("should evict the values stored first once the limit is exceeded", func() {
+		store := newBoundedStore("table", 6, func(value any) int { return len(value.([]byte)) })
+		load := func(key string) any {
+			value, _ := store.Load(key)
+			return value
+		}
+
+		Expect(store.Store("a", []byte("aa"))).To(BeEmpty())
+		Expect(store.Store("b", []byte("bb"))).To(BeEmpty())
+		Expect(store.Store("c", []byte("cc"))).To(BeEmpty())
+
+		By("replacing a value without evicting others")
+		Expect(store.Store("a", []byte("a"))).To(BeEmpty())
+
+		By("evicting the oldest values until the new one fits")
+		Expect(store.Store("d", []byte("ddd"))).To(Equal(map[string]any{"b": []byte("bb")}))
+		Expect(load("b")).To(BeNil())
+		Expect(load("a")).To(Equal([]byte("a")))
+		Expect(load("c")).To(Equal([]byte("cc")))
+		Expect(store.Store("e", []byte("ee"))).To(Equal(map[string]any{"c": []byte("cc")}))
+
+		By("releasing the cost of deleted values")
+		store.Delete("d")
+		store.Delete("e")
+		Expect(store.Store("f", []byte("fffff"))).To(BeEmpty())
+	})
+})
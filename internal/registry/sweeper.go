@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+)
+
+// Sweeper purges the systems of the registry which have no matching Server and have not changed within the TTL,
+// e.g. systems of Servers deleted while the manager was down, or of probe agents which registered under a wrong
+// system UUID.
+type Sweeper struct {
+	// Registry is the registry to sweep.
+	Registry *Server
+	// Client lists the Servers.
+	Client client.Reader
+	// TTL is the time after which systems without a matching Server are purged.
+	TTL time.Duration
+	// Interval is the interval in which the registry is swept.
+	Interval time.Duration
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Every replica sweeps its own registry.
+func (s *Sweeper) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable.
+func (s *Sweeper) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("registry-sweeper")
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			purged, err := s.Sweep(ctx)
+			if err != nil {
+				log.Error(err, "Failed to sweep registry")
+				continue
+			}
+			if len(purged) > 0 {
+				log.Info("Purged systems without Server from registry", "SystemUUIDs", purged)
+			}
+		}
+	}
+}
+
+// Sweep purges the systems without a matching Server which have not changed within the TTL and returns their
+// system UUIDs.
+func (s *Sweeper) Sweep(ctx context.Context) ([]string, error) {
+	servers := &metalv1alpha1.ServerList{}
+	if err := s.Client.List(ctx, servers); err != nil {
+		return nil, fmt.Errorf("failed to list Servers: %w", err)
+	}
+	known := make(map[string]struct{}, len(servers.Items))
+	for _, server := range servers.Items {
+		known[server.Spec.SystemUUID] = struct{}{}
+	}
+	return s.Registry.sweep(known, time.Now().Add(-s.TTL)), nil
+}
+
+// sweep purges the systems which are not known and have not changed since the cutoff.
+func (s *Server) sweep(known map[string]struct{}, cutoff time.Time) []string {
+	var purged []string
+	s.timestampStore.Range(func(key, value any) bool {
+		uuid, _ := key.(string)
+		timestamp, _ := value.(time.Time)
+		if _, ok := known[uuid]; ok || timestamp.After(cutoff) {
+			return true
+		}
+		s.purge(uuid)
+		purged = append(purged, uuid)
+		return true
+	})
+	return purged
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package registry_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/internal/api/registry"
+	registryserver "github.com/ironcore-dev/metal-operator/internal/registry"
+)
+
+var _ = Describe("Sweeper", func() {
+	It("should purge systems without a Server after the TTL", func(ctx SpecContext) {
+		By("registering a system with and a system without a Server")
+		for _, uuid := range []string{"sweep-uuid-known", "sweep-uuid-unknown"} {
+			payload, err := json.Marshal(registry.RegistrationPayload{SystemUUID: uuid})
+			Expect(err).NotTo(HaveOccurred())
+			response, err := http.Post(fmt.Sprintf("%s/register", testServerURL), "application/json", bytes.NewBuffer(payload))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.StatusCode).To(Equal(http.StatusCreated))
+		}
+
+		scheme := runtime.NewScheme()
+		Expect(metalv1alpha1.AddToScheme(scheme)).To(Succeed())
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&metalv1alpha1.Server{
+			ObjectMeta: metav1.ObjectMeta{Name: "known"},
+			Spec:       metalv1alpha1.ServerSpec{SystemUUID: "sweep-uuid-known"},
+		}).Build()
+
+		By("keeping systems which changed within the TTL")
+		sweeper := &registryserver.Sweeper{Registry: server, Client: k8sClient, TTL: time.Hour}
+		Expect(sweeper.Sweep(ctx)).NotTo(ContainElement("sweep-uuid-unknown"))
+
+		By("purging the system without a Server after the TTL")
+		sweeper.TTL = 0
+		purged, err := sweeper.Sweep(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(purged).To(ContainElement("sweep-uuid-unknown"))
+		Expect(purged).NotTo(ContainElement("sweep-uuid-known"))
+
+		response, err := http.Get(fmt.Sprintf("%s/systems/%s", testServerURL, "sweep-uuid-unknown"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+		response, err = http.Get(fmt.Sprintf("%s/systems/%s", testServerURL, "sweep-uuid-known"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+	})
+})
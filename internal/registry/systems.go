@@ -0,0 +1,288 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ironcore-dev/metal-operator/bmc"
+	"github.com/ironcore-dev/metal-operator/internal/api/registry"
+)
+
+const (
+	// defaultSystemsLimit is the page size of system listings without a limit.
+	defaultSystemsLimit = 500
+	// maxSystemsLimit is the maximum page size of system listings.
+	maxSystemsLimit = 1000
+	// systemWatchBuffer is the number of changes buffered per watcher. Watchers falling behind are disconnected
+	// and have to list the systems again.
+	systemWatchBuffer = 64
+)
+
+// systemChange is a change of a system sent to watchers. Previous is the entry before the change, so that
+// watchers can tell when a system stops matching their filter.
+type systemChange struct {
+	event    registry.SystemEvent
+	previous *registry.SystemEntry
+}
+
+// systemsFilter selects the systems of a listing or watch by their state and canonical manufacturer.
+type systemsFilter struct {
+	state        registry.SystemState
+	manufacturer string
+}
+
+func parseSystemsFilter(query url.Values) (systemsFilter, error) {
+	filter := systemsFilter{state: registry.SystemState(query.Get("state"))}
+	switch filter.state {
+	case "", registry.SystemStateRegistered, registry.SystemStateConsumed:
+	default:
+		return systemsFilter{}, fmt.Errorf("unknown state %q", filter.state)
+	}
+	if manufacturer := query.Get("manufacturer"); manufacturer != "" {
+		filter.manufacturer = bmc.NormalizeManufacturer(manufacturer)
+	}
+	return filter, nil
+}
+
+func (f systemsFilter) matches(entry registry.SystemEntry) bool {
+	return (f.state == "" || f.state == entry.State) &&
+		(f.manufacturer == "" || strings.EqualFold(f.manufacturer, entry.Manufacturer))
+}
+
+// systemEntryFor returns the listing entry of the system, which is consumed if it is only kept in the history.
+func (s *Server) systemEntryFor(uuid string) (registry.SystemEntry, bool) {
+	entry := registry.SystemEntry{SystemUUID: uuid}
+	if value, ok := s.systemsStore.Load(uuid); ok {
+		if server, ok := value.(registry.Server); ok {
+			entry.State, entry.Data = registry.SystemStateRegistered, server
+		}
+	}
+	if value, ok := s.historyStore.Load(uuid); ok && entry.State == "" {
+		if record, ok := value.(registry.DiscoveryRecord); ok {
+			entry.State, entry.Data = registry.SystemStateConsumed, record.Data
+		}
+	}
+	if entry.State == "" {
+		return registry.SystemEntry{}, false
+	}
+	if smbios := entry.Data.SMBIOS; smbios != nil && smbios.System.Manufacturer != "" {
+		entry.Manufacturer = bmc.NormalizeManufacturer(smbios.System.Manufacturer)
+	}
+	if value, ok := s.timestampStore.Load(uuid); ok {
+		entry.Timestamp, _ = value.(time.Time)
+	}
+	return entry, true
+}
+
+// systemEntries returns the listing entries of all systems sorted by their UUID.
+func (s *Server) systemEntries() []registry.SystemEntry {
+	var uuids []string
+	collect := func(key, _ any) bool {
+		if uuid, ok := key.(string); ok {
+			uuids = append(uuids, uuid)
+		}
+		return true
+	}
+	s.systemsStore.Range(collect)
+	s.historyStore.Range(collect)
+	slices.Sort(uuids)
+
+	entries := make([]registry.SystemEntry, 0, len(uuids))
+	for _, uuid := range slices.Compact(uuids) {
+		if entry, ok := s.systemEntryFor(uuid); ok {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// listSystemsHandler handles the /systems endpoint listing the systems page by page, or streaming their changes
+// if the watch query parameter is set.
+func (s *Server) listSystemsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET method is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	filter, err := parseSystemsFilter(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if query.Get("watch") == "true" {
+		s.watchSystems(w, r, filter)
+		return
+	}
+
+	limit := defaultSystemsLimit
+	if value := query.Get("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil || limit <= 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = min(limit, maxSystemsLimit)
+	}
+	var after string
+	if token := query.Get("continue"); token != "" {
+		data, err := base64.RawURLEncoding.DecodeString(token)
+		if err != nil {
+			http.Error(w, "Invalid continue token", http.StatusBadRequest)
+			return
+		}
+		after = string(data)
+	}
+
+	list := registry.SystemList{Items: []registry.SystemEntry{}}
+	for _, entry := range s.systemEntries() {
+		if entry.SystemUUID <= after || !filter.matches(entry) {
+			continue
+		}
+		if len(list.Items) == limit {
+			list.Continue = base64.RawURLEncoding.EncodeToString([]byte(list.Items[limit-1].SystemUUID))
+			break
+		}
+		list.Items = append(list.Items, entry)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(list); err != nil {
+		log.Printf("Failed to encode result: %v\n", err)
+		http.Error(w, "Failed to encode result", http.StatusInternalServerError)
+	}
+}
+
+// watchSystems streams the systems matching the filter as ADDED events, followed by their changes, as newline
+// delimited JSON until the client disconnects.
+func (s *Server) watchSystems(w http.ResponseWriter, r *http.Request, filter systemsFilter) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	// subscribe before listing, so that no change is missed
+	changes := s.subscribe()
+	defer s.unsubscribe(changes)
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	for _, entry := range s.systemEntries() {
+		if !filter.matches(entry) {
+			continue
+		}
+		if err := encoder.Encode(registry.SystemEvent{Type: registry.SystemEventAdded, System: entry}); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case change, ok := <-changes:
+			if !ok {
+				log.Println("Disconnected systems watcher falling behind")
+				return
+			}
+			event, ok := filterSystemChange(change, filter)
+			if !ok {
+				continue
+			}
+			if err := encoder.Encode(event); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// filterSystemChange returns the event a watcher with the filter receives for the change. Systems starting to
+// match the filter are reported as added, systems no longer matching it as deleted.
+func filterSystemChange(change systemChange, filter systemsFilter) (registry.SystemEvent, bool) {
+	matches := filter.matches(change.event.System)
+	matched := change.previous != nil && filter.matches(*change.previous)
+	switch {
+	case matches && !matched && change.event.Type == registry.SystemEventModified:
+		return registry.SystemEvent{Type: registry.SystemEventAdded, System: change.event.System}, true
+	case matches:
+		return change.event, true
+	case matched:
+		return registry.SystemEvent{Type: registry.SystemEventDeleted, System: *change.previous}, true
+	default:
+		return registry.SystemEvent{}, false
+	}
+}
+
+func (s *Server) subscribe() chan systemChange {
+	changes := make(chan systemChange, systemWatchBuffer)
+	s.watchersMu.Lock()
+	defer s.watchersMu.Unlock()
+	s.watchers[changes] = struct{}{}
+	return changes
+}
+
+func (s *Server) unsubscribe(changes chan systemChange) {
+	s.watchersMu.Lock()
+	defer s.watchersMu.Unlock()
+	if _, ok := s.watchers[changes]; ok {
+		delete(s.watchers, changes)
+		close(changes)
+	}
+}
+
+// publish notifies the watchers of the change of the system from the previous entry, if it existed.
+func (s *Server) publish(uuid string, previous registry.SystemEntry, existed bool) {
+	entry, exists := s.systemEntryFor(uuid)
+	switch {
+	case exists && existed:
+		s.notify(registry.SystemEventModified, entry, &previous)
+	case exists:
+		s.notify(registry.SystemEventAdded, entry, nil)
+	case existed:
+		s.notify(registry.SystemEventDeleted, previous, nil)
+	}
+}
+
+// publishEvictedRecord reports a system whose discovery record has been evicted from the history as deleted, unless
+// it has been registered again.
+func (s *Server) publishEvictedRecord(uuid string, value any) {
+	record, ok := value.(registry.DiscoveryRecord)
+	if _, registered := s.systemsStore.Load(uuid); !ok || registered {
+		return
+	}
+	entry := registry.SystemEntry{SystemUUID: uuid, State: registry.SystemStateConsumed, Data: record.Data,
+		Timestamp: record.Timestamp}
+	if smbios := record.Data.SMBIOS; smbios != nil && smbios.System.Manufacturer != "" {
+		entry.Manufacturer = bmc.NormalizeManufacturer(smbios.System.Manufacturer)
+	}
+	s.notify(registry.SystemEventDeleted, entry, nil)
+}
+
+// notify sends the change of a system to all watchers. Watchers whose buffer is full are disconnected.
+func (s *Server) notify(eventType registry.SystemEventType, entry registry.SystemEntry, previous *registry.SystemEntry) {
+	change := systemChange{event: registry.SystemEvent{Type: eventType, System: entry}, previous: previous}
+	s.watchersMu.Lock()
+	defer s.watchersMu.Unlock()
+	for changes := range s.watchers {
+		select {
+		case changes <- change:
+		default:
+			delete(s.watchers, changes)
+			close(changes)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// tokenKeySize is the size of the token keys generated by GenerateTokenKey.
+const tokenKeySize = 32
+
+// GenerateTokenKey returns a random key to derive the tokens of the registry from.
+func GenerateTokenKey() ([]byte, error) {
+	key := make([]byte, tokenKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate registry token key: %w", err)
+	}
+	return key, nil
+}
+
+// SystemToken returns the token with which the probe agent of the system authenticates its requests to the
+// registry. It is passed to the agent through the ignition of the discovery boot. Tokens are derived from the key
+// of the registry, so that the registry does not need to keep them.
+func SystemToken(key []byte, systemUUID string) string {
+	return deriveToken(key, "system/"+systemUUID)
+}
+
+// ManagerToken returns the token with which the manager authenticates its requests for the BMC credentials
+// bootstrapped by probe agents.
+func ManagerToken(key []byte) string {
+	return deriveToken(key, "manager")
+}
+
+func deriveToken(key []byte, subject string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(subject))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// hasToken reports whether the request carries the token as bearer token.
+func hasToken(r *http.Request, token string) bool {
+	bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && hmac.Equal([]byte(bearer), []byte(token))
+}
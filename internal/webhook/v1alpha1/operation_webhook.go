@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+)
+
+// SetupOperationWebhookWithManager registers the webhook for Operation in the manager.
+func SetupOperationWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&metalv1alpha1.Operation{}).
+		WithDefaulter(&OperationCustomDefaulter{}).
+		WithValidator(&OperationCustomValidator{}).
+		Complete()
+}
+
+// NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
+// Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
+// +kubebuilder:webhook:path=/mutate-metal-ironcore-dev-v1alpha1-operation,mutating=true,failurePolicy=fail,sideEffects=None,groups=metal.ironcore.dev,resources=operations,verbs=create,versions=v1alpha1,name=moperation-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// OperationCustomDefaulter struct is responsible for recording the user who creates an Operation.
+type OperationCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &OperationCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type Operation. The
+// requester of an Operation is always taken from the admission request, so that it cannot be forged.
+func (d *OperationCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	operation, ok := obj.(*metalv1alpha1.Operation)
+	if !ok {
+		return fmt.Errorf("expected an Operation object but got %T", obj)
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get admission request: %w", err)
+	}
+	operation.Spec.RequestedBy = req.UserInfo.Username
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-metal-ironcore-dev-v1alpha1-operation,mutating=false,failurePolicy=fail,sideEffects=None,groups=metal.ironcore.dev,resources=operations,verbs=update,versions=v1alpha1,name=voperation-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// OperationCustomValidator struct is responsible for keeping the spec of an Operation, including its requester,
+// unchanged once it has been created.
+type OperationCustomValidator struct{}
+
+var _ webhook.CustomValidator = &OperationCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Operation.
+func (v *OperationCustomValidator) ValidateCreate(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Operation. It
+// rejects every change of the spec, so that the requester recorded on creation cannot be rewritten.
+func (v *OperationCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldOperation, ok := oldObj.(*metalv1alpha1.Operation)
+	if !ok {
+		return nil, fmt.Errorf("expected an Operation object for the oldObj but got %T", oldObj)
+	}
+	operation, ok := newObj.(*metalv1alpha1.Operation)
+	if !ok {
+		return nil, fmt.Errorf("expected an Operation object for the newObj but got %T", newObj)
+	}
+	if apiequality.Semantic.DeepEqual(oldOperation.Spec, operation.Spec) {
+		return nil, nil
+	}
+	return nil, apierrors.NewInvalid(
+		schema.GroupKind{Group: "metal.ironcore.dev", Kind: "Operation"},
+		operation.GetName(), field.ErrorList{field.Forbidden(field.NewPath("spec"), "spec is immutable")})
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Operation.
+func (v *OperationCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+)
+
+var _ = Describe("Operation Webhook", func() {
+	It("Should record the requesting user", func(ctx SpecContext) {
+		operation := &metalv1alpha1.Operation{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: metalv1alpha1.OperationSpec{
+				TargetRef:   metalv1alpha1.OperationTargetRef{Kind: metalv1alpha1.OperationTargetKindServer, Name: "foo"},
+				Type:        metalv1alpha1.OperationAnnotationPXERestart,
+				RequestedBy: "someone-else",
+			},
+		}
+		reqCtx := admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			UserInfo: authenticationv1.UserInfo{Username: "alice"},
+		}})
+		Expect((&OperationCustomDefaulter{}).Default(reqCtx, operation)).To(Succeed())
+		Expect(operation.Spec.RequestedBy).To(Equal("alice"))
+	})
+
+	It("Should reject changes of the spec", func(ctx SpecContext) {
+		operation := &metalv1alpha1.Operation{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: metalv1alpha1.OperationSpec{
+				TargetRef:   metalv1alpha1.OperationTargetRef{Kind: metalv1alpha1.OperationTargetKindServer, Name: "foo"},
+				Type:        metalv1alpha1.OperationAnnotationPXERestart,
+				RequestedBy: "alice",
+			},
+		}
+		validator := &OperationCustomValidator{}
+
+		updated := operation.DeepCopy()
+		updated.Status.State = metalv1alpha1.OperationStateSucceeded
+		Expect(validator.ValidateUpdate(ctx, operation, updated)).Error().NotTo(HaveOccurred())
+
+		updated.Spec.RequestedBy = "mallory"
+		Expect(validator.ValidateUpdate(ctx, operation, updated)).Error().To(HaveOccurred())
+
+		updated.Spec = metalv1alpha1.OperationSpec{}
+		Expect(validator.ValidateUpdate(ctx, operation, updated)).Error().To(HaveOccurred())
+	})
+})
@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+)
+
+// log is for logging in this package.
+var serverlog = logf.Log.WithName("server-resource")
+
+// garbageCollectorUser is the user of the garbage collector of the kube-controller-manager, which deletes the
+// Servers of deleted BMCs.
+const garbageCollectorUser = "system:serviceaccount:kube-system:generic-garbage-collector"
+
+// SetupServerWebhookWithManager registers the webhook for Server in the manager. The trusted users may delete
+// claimed Servers without the force annotation, e.g. the manager itself.
+func SetupServerWebhookWithManager(mgr ctrl.Manager, trustedUsers ...string) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&metalv1alpha1.Server{}).
+		WithValidator(&ServerCustomValidator{Client: mgr.GetClient(), TrustedUsers: trustedUsers}).
+		Complete()
+}
+
+// NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
+// Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
+// +kubebuilder:webhook:path=/validate-metal-ironcore-dev-v1alpha1-server,mutating=false,failurePolicy=fail,sideEffects=None,groups=metal.ironcore.dev,resources=servers,verbs=create;update;delete,versions=v1alpha1,name=vserver-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ServerCustomValidator struct is responsible for validating the Server resource
+// when it is created, updated or deleted.
+type ServerCustomValidator struct {
+	Client client.Client
+	// TrustedUsers may delete claimed Servers and Servers with a firmware update in progress without the force
+	// annotation, in addition to the garbage collector.
+	TrustedUsers []string
+}
+
+var _ webhook.CustomValidator = &ServerCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Server.
+func (v *ServerCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	server, ok := obj.(*metalv1alpha1.Server)
+	if !ok {
+		return nil, fmt.Errorf("expected a Server object but got %T", obj)
+	}
+	return nil, validatePausedUntil(server)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Server.
+// The paused-until annotation is only validated if it changes, so that existing Servers remain updatable.
+func (v *ServerCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldServer, ok := oldObj.(*metalv1alpha1.Server)
+	if !ok {
+		return nil, fmt.Errorf("expected a Server object but got %T", oldObj)
+	}
+	server, ok := newObj.(*metalv1alpha1.Server)
+	if !ok {
+		return nil, fmt.Errorf("expected a Server object but got %T", newObj)
+	}
+	if oldServer.GetAnnotations()[metalv1alpha1.PausedUntilAnnotation] == server.GetAnnotations()[metalv1alpha1.PausedUntilAnnotation] {
+		return nil, nil
+	}
+	return nil, validatePausedUntil(server)
+}
+
+// validatePausedUntil ensures that the paused-until annotation of the Server is an RFC 3339 timestamp.
+func validatePausedUntil(server *metalv1alpha1.Server) error {
+	val, ok := server.GetAnnotations()[metalv1alpha1.PausedUntilAnnotation]
+	if !ok {
+		return nil
+	}
+	if _, err := time.Parse(time.RFC3339, val); err != nil {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: "metal.ironcore.dev", Kind: "Server"}, server.Name,
+			field.ErrorList{field.Invalid(
+				field.NewPath("metadata", "annotations").Key(metalv1alpha1.PausedUntilAnnotation), val,
+				"must be an RFC 3339 timestamp, e.g. 2024-01-02T15:04:05Z")})
+	}
+	return nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Server.
+// Claimed Servers and Servers with a firmware update in progress can only be deleted with the force annotation,
+// as deleting them orphans the hardware records of running workloads. The garbage collector and the trusted users
+// are exempt, so that owner references cascade and the manager can clean up after itself.
+func (v *ServerCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	server, ok := obj.(*metalv1alpha1.Server)
+	if !ok {
+		return nil, fmt.Errorf("expected a Server object but got %T", obj)
+	}
+	serverlog.Info("Validation for Server upon deletion", "name", server.GetName())
+
+	if server.GetAnnotations()[metalv1alpha1.ForceDeleteAnnotation] == "true" {
+		return admission.Warnings{fmt.Sprintf("Server %s is deleted forcefully", server.Name)}, nil
+	}
+	if req, err := admission.RequestFromContext(ctx); err == nil && v.isTrustedUser(req.UserInfo.Username) {
+		return nil, nil
+	}
+
+	if ref := server.Spec.ServerClaimRef; ref != nil {
+		return nil, apierrors.NewForbidden(
+			schema.GroupResource{Group: "metal.ironcore.dev", Resource: "servers"}, server.Name,
+			fmt.Errorf("server is claimed by ServerClaim %s/%s, set the annotation %s=true to delete it anyway",
+				ref.Namespace, ref.Name, metalv1alpha1.ForceDeleteAnnotation))
+	}
+
+	inMaintenance, err := v.hasFirmwareUpdateInProgress(ctx, server.Name)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	if inMaintenance {
+		return nil, apierrors.NewForbidden(
+			schema.GroupResource{Group: "metal.ironcore.dev", Resource: "servers"}, server.Name,
+			fmt.Errorf("server has a firmware update in progress, set the annotation %s=true to delete it anyway",
+				metalv1alpha1.ForceDeleteAnnotation))
+	}
+
+	return nil, nil
+}
+
+func (v *ServerCustomValidator) isTrustedUser(username string) bool {
+	return username == garbageCollectorUser || slices.Contains(v.TrustedUsers, username)
+}
+
+func (v *ServerCustomValidator) hasFirmwareUpdateInProgress(ctx context.Context, serverName string) (bool, error) {
+	driveFirmwares := &metalv1alpha1.DriveFirmwareList{}
+	if err := v.Client.List(ctx, driveFirmwares); err != nil {
+		return false, fmt.Errorf("failed to list DriveFirmwares: %w", err)
+	}
+	for _, firmware := range driveFirmwares.Items {
+		if firmware.Spec.ServerRef.Name == serverName && firmware.Status.State == metalv1alpha1.DriveFirmwareStateInProgress {
+			return true, nil
+		}
+	}
+
+	componentFirmwares := &metalv1alpha1.ComponentFirmwareList{}
+	if err := v.Client.List(ctx, componentFirmwares); err != nil {
+		return false, fmt.Errorf("failed to list ComponentFirmwares: %w", err)
+	}
+	for _, firmware := range componentFirmwares.Items {
+		if firmware.Spec.ServerRef.Name == serverName && firmware.Status.State == metalv1alpha1.ComponentFirmwareStateInProgress {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+)
+
+var _ = Describe("Server Webhook", func() {
+	var validator ServerCustomValidator
+
+	BeforeEach(func() {
+		validator = ServerCustomValidator{
+			Client: k8sClient,
+		}
+	})
+
+	Context("When deleting a Server under Validating Webhook", func() {
+		It("Should deny deletion if the Server is claimed", func(ctx SpecContext) {
+			server := &metalv1alpha1.Server{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-claimed",
+				},
+				Spec: metalv1alpha1.ServerSpec{
+					ServerClaimRef: &v1.ObjectReference{
+						Namespace: "foo",
+						Name:      "bar",
+					},
+				},
+			}
+			Expect(validator.ValidateDelete(ctx, server)).Error().To(HaveOccurred())
+		})
+
+		It("Should allow deletion of a claimed Server with the force annotation", func(ctx SpecContext) {
+			server := &metalv1alpha1.Server{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-forced",
+					Annotations: map[string]string{
+						metalv1alpha1.ForceDeleteAnnotation: "true",
+					},
+				},
+				Spec: metalv1alpha1.ServerSpec{
+					ServerClaimRef: &v1.ObjectReference{
+						Namespace: "foo",
+						Name:      "bar",
+					},
+				},
+			}
+			Expect(validator.ValidateDelete(ctx, server)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny deletion if a firmware update is in progress", func(ctx SpecContext) {
+			By("Creating a DriveFirmware in progress")
+			firmware := &metalv1alpha1.DriveFirmware{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "test-",
+				},
+				Spec: metalv1alpha1.DriveFirmwareSpec{
+					ServerRef: v1.LocalObjectReference{Name: "test-maintenance"},
+					Model:     "foo",
+					Version:   "1.0.0",
+					Image: metalv1alpha1.FirmwareImage{
+						URI: "http://example.com/drive-firmware.bin",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, firmware)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, firmware)
+
+			firmwareBase := firmware.DeepCopy()
+			firmware.Status.State = metalv1alpha1.DriveFirmwareStateInProgress
+			Expect(k8sClient.Status().Patch(ctx, firmware, client.MergeFrom(firmwareBase))).To(Succeed())
+
+			server := &metalv1alpha1.Server{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-maintenance",
+				},
+			}
+			Expect(validator.ValidateDelete(ctx, server)).Error().To(HaveOccurred())
+		})
+
+		It("Should allow deletion of an unclaimed Server", func(ctx SpecContext) {
+			server := &metalv1alpha1.Server{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-unclaimed",
+				},
+			}
+			Expect(validator.ValidateDelete(ctx, server)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should allow the garbage collector and trusted users to delete a claimed Server", func(ctx SpecContext) {
+			server := &metalv1alpha1.Server{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-claimed-trusted",
+				},
+				Spec: metalv1alpha1.ServerSpec{
+					ServerClaimRef: &v1.ObjectReference{
+						Namespace: "foo",
+						Name:      "bar",
+					},
+				},
+			}
+			validator.TrustedUsers = []string{"system:serviceaccount:metal:manager"}
+			requestBy := func(username string) context.Context {
+				return admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+					UserInfo: authenticationv1.UserInfo{Username: username},
+				}})
+			}
+
+			Expect(validator.ValidateDelete(requestBy(garbageCollectorUser), server)).Error().NotTo(HaveOccurred())
+			Expect(validator.ValidateDelete(requestBy("system:serviceaccount:metal:manager"), server)).Error().NotTo(HaveOccurred())
+			Expect(validator.ValidateDelete(requestBy("jane"), server)).Error().To(HaveOccurred())
+		})
+	})
+
+	Context("When creating or updating a Server under Validating Webhook", func() {
+		It("Should deny an invalid paused-until timestamp", func(ctx SpecContext) {
+			server := &metalv1alpha1.Server{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-paused",
+					Annotations: map[string]string{
+						metalv1alpha1.PausedUntilAnnotation: "tomorrow",
+					},
+				},
+			}
+			Expect(validator.ValidateCreate(ctx, server)).Error().To(HaveOccurred())
+			Expect(validator.ValidateUpdate(ctx, &metalv1alpha1.Server{}, server)).Error().To(HaveOccurred())
+		})
+
+		It("Should allow a valid paused-until timestamp", func(ctx SpecContext) {
+			server := &metalv1alpha1.Server{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-paused",
+					Annotations: map[string]string{
+						metalv1alpha1.PausedUntilAnnotation: "2030-01-02T15:04:05Z",
+					},
+				},
+			}
+			Expect(validator.ValidateCreate(ctx, server)).Error().NotTo(HaveOccurred())
+			Expect(validator.ValidateUpdate(ctx, &metalv1alpha1.Server{}, server)).Error().NotTo(HaveOccurred())
+		})
+	})
+})
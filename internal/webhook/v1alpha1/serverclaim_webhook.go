@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+)
+
+// SetupServerClaimWebhookWithManager registers the webhook for ServerClaim in the manager.
+func SetupServerClaimWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&metalv1alpha1.ServerClaim{}).
+		WithValidator(&ServerClaimCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
+// Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
+// +kubebuilder:webhook:path=/validate-metal-ironcore-dev-v1alpha1-serverclaim,mutating=false,failurePolicy=fail,sideEffects=None,groups=metal.ironcore.dev,resources=serverclaims,verbs=create;update,versions=v1alpha1,name=vserverclaim-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ServerClaimCustomValidator struct is responsible for validating the image of a ServerClaim against the
+// ImagePolicies when it is created or updated.
+type ServerClaimCustomValidator struct {
+	Client client.Client
+}
+
+var _ webhook.CustomValidator = &ServerClaimCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type ServerClaim.
+func (v *ServerClaimCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	claim, ok := obj.(*metalv1alpha1.ServerClaim)
+	if !ok {
+		return nil, fmt.Errorf("expected a ServerClaim object but got %T", obj)
+	}
+	return nil, v.validateImage(ctx, claim)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type ServerClaim. The
+// image is only validated if it changed, so that claims created before a policy can still be updated.
+func (v *ServerClaimCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldClaim, ok := oldObj.(*metalv1alpha1.ServerClaim)
+	if !ok {
+		return nil, fmt.Errorf("expected a ServerClaim object for the oldObj but got %T", oldObj)
+	}
+	claim, ok := newObj.(*metalv1alpha1.ServerClaim)
+	if !ok {
+		return nil, fmt.Errorf("expected a ServerClaim object for the newObj but got %T", newObj)
+	}
+	if claim.Spec.Image == oldClaim.Spec.Image {
+		return nil, nil
+	}
+	return nil, v.validateImage(ctx, claim)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type ServerClaim.
+func (v *ServerClaimCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *ServerClaimCustomValidator) validateImage(ctx context.Context, claim *metalv1alpha1.ServerClaim) error {
+	path := field.NewPath("spec").Child("image")
+	policyList := &metalv1alpha1.ImagePolicyList{}
+	if err := v.Client.List(ctx, policyList); err != nil {
+		return apierrors.NewInternalError(fmt.Errorf("failed to list ImagePolicies: %w", err))
+	}
+	if metalv1alpha1.ImageAllowed(policyList.Items, claim.Spec.Image) {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: "metal.ironcore.dev", Kind: "ServerClaim"},
+		claim.GetName(), field.ErrorList{field.Forbidden(path, fmt.Sprintf("image %s is not allowed by any ImagePolicy", claim.Spec.Image))})
+}
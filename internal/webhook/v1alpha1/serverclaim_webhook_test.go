@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+)
+
+var _ = Describe("ServerClaim Webhook", func() {
+	var validator ServerClaimCustomValidator
+
+	BeforeEach(func() {
+		validator = ServerClaimCustomValidator{
+			Client: k8sClient,
+		}
+	})
+
+	It("Should allow any image if there is no ImagePolicy", func(ctx SpecContext) {
+		claim := &metalv1alpha1.ServerClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec:       metalv1alpha1.ServerClaimSpec{Image: "registry.example.com/os/foo:bar"},
+		}
+		Expect(validator.ValidateCreate(ctx, claim)).Error().NotTo(HaveOccurred())
+	})
+
+	It("Should only allow the images of the ImagePolicies", func(ctx SpecContext) {
+		By("Creating an ImagePolicy")
+		policy := &metalv1alpha1.ImagePolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.ImagePolicySpec{
+				AllowedImages: []string{"registry.example.com/os"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, policy)
+
+		By("Allowing an image of the repository")
+		claim := &metalv1alpha1.ServerClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec:       metalv1alpha1.ServerClaimSpec{Image: "registry.example.com/os/foo:bar"},
+		}
+		Expect(validator.ValidateCreate(ctx, claim)).Error().NotTo(HaveOccurred())
+
+		By("Denying an image of another repository")
+		forbidden := claim.DeepCopy()
+		forbidden.Spec.Image = "registry.example.com/osx/foo:bar"
+		Expect(validator.ValidateCreate(ctx, forbidden)).Error().To(HaveOccurred())
+		Expect(validator.ValidateUpdate(ctx, claim, forbidden)).Error().To(HaveOccurred())
+
+		By("Allowing updates which keep a forbidden image")
+		updated := forbidden.DeepCopy()
+		updated.Spec.Power = metalv1alpha1.PowerOn
+		Expect(validator.ValidateUpdate(ctx, forbidden, updated)).Error().NotTo(HaveOccurred())
+	})
+
+	It("Should not allow the images of another port of an allowed registry", func(ctx SpecContext) {
+		By("Creating an ImagePolicy for a registry")
+		policy := &metalv1alpha1.ImagePolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+			},
+			Spec: metalv1alpha1.ImagePolicySpec{
+				AllowedImages: []string{"registry.example.com", "mirror.example.com:5000"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, policy)
+
+		for image, allowed := range map[string]bool{
+			"registry.example.com/os/foo:bar":      true,
+			"registry.example.com:5000/os/foo:bar": false,
+			"registry.example.com.evil/os:bar":     false,
+			"mirror.example.com:5000/os/foo:bar":   true,
+			"mirror.example.com:50000/os/foo:bar":  false,
+		} {
+			claim := &metalv1alpha1.ServerClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec:       metalv1alpha1.ServerClaimSpec{Image: image},
+			}
+			if allowed {
+				Expect(validator.ValidateCreate(ctx, claim)).Error().NotTo(HaveOccurred(), image)
+			} else {
+				Expect(validator.ValidateCreate(ctx, claim)).Error().To(HaveOccurred(), image)
+			}
+		}
+	})
+})
@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"github.com/ironcore-dev/metal-operator/internal/devenv"
+	"github.com/ironcore-dev/metal-operator/test/utils"
+)
+
+// The lifecycle specs run against a kind cluster with a fleet of simulated BMCs. They are configured through the
+// environment:
+//   - E2E_IMG is the locally built image of the operator.
+//   - E2E_SYSTEMS is the number of simulated systems.
+//   - KIND_CLUSTER is the name of the kind cluster, which is created if it does not exist.
+var _ = Describe("lifecycle", Label("lifecycle"), Ordered, func() {
+	var (
+		k8sClient client.Client
+		fleet     devenv.Fleet
+		servers   []string
+	)
+
+	BeforeAll(func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+
+		systems, err := strconv.Atoi(getenv("E2E_SYSTEMS", "3"))
+		Expect(err).NotTo(HaveOccurred())
+		fleet = devenv.Fleet{Prefix: "mock-bmc", Size: systems}
+		Expect(fleet.Size).To(BeNumerically(">=", 2), "the lifecycle specs need at least two systems")
+
+		projectDir, err := utils.GetProjectDir()
+		Expect(err).NotTo(HaveOccurred())
+		env := &devenv.Environment{
+			ClusterName:  getenv("KIND_CLUSTER", devenv.DefaultClusterName),
+			Image:        getenv("E2E_IMG", "example.com/metal-operator:v0.0.1"),
+			KustomizeDir: projectDir + "/" + devenv.DefaultKustomizeDir,
+			Out:          GinkgoWriter,
+		}
+		By("deploying the operator to the kind cluster")
+		Expect(env.Up(ctx)).To(Succeed())
+
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(metalv1alpha1.AddToScheme(scheme)).To(Succeed())
+		clientConfig, err := config.GetConfigWithContext("kind-" + env.ClusterName)
+		Expect(err).NotTo(HaveOccurred())
+		k8sClient, err = client.New(clientConfig, client.Options{Scheme: scheme})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("creating the fleet of simulated BMCs")
+		Expect(fleet.Apply(ctx, k8sClient)).To(Succeed())
+		DeferCleanup(func(ctx SpecContext) {
+			Expect(fleet.Delete(ctx, k8sClient)).To(Succeed())
+		})
+
+		By("standing in for the boot operator and the probe agents")
+		Expect(env.PortForward(ctx, devenv.RegistryPort, devenv.RegistryPort)).To(Succeed())
+		responder := &devenv.DiscoveryResponder{
+			Client:      k8sClient,
+			RegistryURL: fmt.Sprintf("http://127.0.0.1:%d", devenv.RegistryPort),
+			Interval:    2 * time.Second,
+		}
+		go func() {
+			defer GinkgoRecover()
+			Expect(responder.Start(ctx)).To(Succeed())
+		}()
+	})
+
+	It("should discover the servers of all BMCs", func(ctx SpecContext) {
+		Eventually(func(g Gomega) {
+			serverList := &metalv1alpha1.ServerList{}
+			g.Expect(k8sClient.List(ctx, serverList)).To(Succeed())
+			servers = nil
+			for _, server := range serverList.Items {
+				if server.Spec.BMCRef == nil {
+					continue
+				}
+				g.Expect(server.Status.State).To(Equal(metalv1alpha1.ServerStateAvailable), "server %s", server.Name)
+				servers = append(servers, server.Name)
+			}
+			g.Expect(servers).To(HaveLen(fleet.Size))
+		}).WithContext(ctx).WithTimeout(10 * time.Minute).WithPolling(5 * time.Second).Should(Succeed())
+	})
+
+	It("should bind a claim to a server and release it again", func(ctx SpecContext) {
+		claim := &metalv1alpha1.ServerClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: v1.NamespaceDefault, Name: "e2e-claim"},
+			Spec: metalv1alpha1.ServerClaimSpec{
+				Power:     metalv1alpha1.PowerOn,
+				ServerRef: &v1.LocalObjectReference{Name: servers[0]},
+				Image:     "ghcr.io/ironcore-dev/os-images/gardenlinux:1443.10",
+			},
+		}
+		Expect(k8sClient.Create(ctx, claim)).To(Succeed())
+
+		server := &metalv1alpha1.Server{ObjectMeta: metav1.ObjectMeta{Name: servers[0]}}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(claim), claim)).To(Succeed())
+			g.Expect(claim.Status.Phase).To(Equal(metalv1alpha1.PhaseBound))
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(server), server)).To(Succeed())
+			g.Expect(server.Status.State).To(Equal(metalv1alpha1.ServerStateReserved))
+			g.Expect(server.Status.PowerState).To(Equal(metalv1alpha1.ServerOnPowerState))
+		}).WithContext(ctx).WithTimeout(5 * time.Minute).WithPolling(5 * time.Second).Should(Succeed())
+
+		Expect(k8sClient.Delete(ctx, claim)).To(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(server), server)).To(Succeed())
+			g.Expect(server.Status.State).To(Equal(metalv1alpha1.ServerStateAvailable))
+		}).WithContext(ctx).WithTimeout(10 * time.Minute).WithPolling(5 * time.Second).Should(Succeed())
+	})
+
+	It("should update the BIOS firmware of a server", func(ctx SpecContext) {
+		componentFirmware := &metalv1alpha1.ComponentFirmware{
+			ObjectMeta: metav1.ObjectMeta{Name: "e2e-bios"},
+			Spec: metalv1alpha1.ComponentFirmwareSpec{
+				ServerRef: v1.LocalObjectReference{Name: servers[1]},
+				Component: metalv1alpha1.ComponentSelector{Type: metalv1alpha1.ComponentTypeBIOS},
+				Version:   "2.0.0",
+				// the simulated BMCs install the version given by the image URI
+				Image: metalv1alpha1.FirmwareImage{URI: "http://firmware.example.com/bios.bin?version=2.0.0"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, componentFirmware)).To(Succeed())
+		DeferCleanup(func(ctx SpecContext) {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, componentFirmware))).To(Succeed())
+		})
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(componentFirmware), componentFirmware)).To(Succeed())
+			g.Expect(componentFirmware.Status.State).To(Equal(metalv1alpha1.ComponentFirmwareStateCompleted))
+		}).WithContext(ctx).WithTimeout(5 * time.Minute).WithPolling(5 * time.Second).Should(Succeed())
+	})
+})
+
+func getenv(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return fallback
+}